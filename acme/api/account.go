@@ -1,11 +1,14 @@
 package api
 
 import (
+	"crypto"
 	"encoding/base64"
+	"encoding/json"
 	"errors"
 	"fmt"
 
 	"github.com/go-acme/lego/v4/acme"
+	jose "gopkg.in/square/go-jose.v2"
 )
 
 type AccountService service
@@ -29,12 +32,23 @@ func (a *AccountService) New(req acme.Account) (acme.ExtendedAccount, error) {
 
 // NewEAB Creates a new account with an External Account Binding.
 func (a *AccountService) NewEAB(accMsg acme.Account, kid, hmacEncoded string) (acme.ExtendedAccount, error) {
+	return a.NewEABWithAlgorithm(accMsg, kid, hmacEncoded, "")
+}
+
+// NewEABWithAlgorithm Creates a new account with an External Account Binding signed with a MAC algorithm.
+// The supported algorithms are HS256 (default), HS384, and HS512.
+func (a *AccountService) NewEABWithAlgorithm(accMsg acme.Account, kid, hmacEncoded, algorithm string) (acme.ExtendedAccount, error) {
+	alg, err := eabAlgorithm(algorithm)
+	if err != nil {
+		return acme.ExtendedAccount{}, err
+	}
+
 	hmac, err := base64.RawURLEncoding.DecodeString(hmacEncoded)
 	if err != nil {
 		return acme.ExtendedAccount{}, fmt.Errorf("acme: could not decode hmac key: %w", err)
 	}
 
-	eabJWS, err := a.core.signEABContent(a.core.GetDirectory().NewAccountURL, kid, hmac)
+	eabJWS, err := a.core.signEABContent(a.core.GetDirectory().NewAccountURL, kid, hmac, alg)
 	if err != nil {
 		return acme.ExtendedAccount{}, fmt.Errorf("acme: error signing eab content: %w", err)
 	}
@@ -82,3 +96,40 @@ func (a *AccountService) Deactivate(accountURL string) error {
 	_, err := a.core.post(accountURL, req, nil)
 	return err
 }
+
+// ChangeKey Changes the key of the account (account key rollover).
+// The next requests are signed with the new key.
+// - https://tools.ietf.org/html/rfc8555#section-7.3.5
+func (a *AccountService) ChangeKey(newKey crypto.PrivateKey) (acme.Account, error) {
+	if newKey == nil {
+		return acme.Account{}, errors.New("account[keyChange]: empty key")
+	}
+
+	keyChangeURL := a.core.GetDirectory().KeyChangeURL
+
+	keyChangeJWS, err := a.core.signKeyChangeContent(keyChangeURL, newKey)
+	if err != nil {
+		return acme.Account{}, fmt.Errorf("acme: error signing key change content: %w", err)
+	}
+
+	var account acme.Account
+	_, err = a.core.post(keyChangeURL, json.RawMessage(keyChangeJWS), &account)
+	if err != nil {
+		return acme.Account{}, err
+	}
+
+	a.core.jws.SetPrivateKey(newKey)
+
+	return account, nil
+}
+
+func eabAlgorithm(algorithm string) (jose.SignatureAlgorithm, error) {
+	switch jose.SignatureAlgorithm(algorithm) {
+	case "", jose.HS256:
+		return jose.HS256, nil
+	case jose.HS384, jose.HS512:
+		return jose.SignatureAlgorithm(algorithm), nil
+	default:
+		return "", fmt.Errorf("acme: unsupported External Account Binding algorithm: %s", algorithm)
+	}
+}
@@ -16,6 +16,8 @@ import (
 	"github.com/go-acme/lego/v4/acme/api/internal/secure"
 	"github.com/go-acme/lego/v4/acme/api/internal/sender"
 	"github.com/go-acme/lego/v4/log"
+	"github.com/go-acme/lego/v4/metrics"
+	jose "gopkg.in/square/go-jose.v2"
 )
 
 // Core ACME/LE core API.
@@ -23,8 +25,13 @@ type Core struct {
 	doer         *sender.Doer
 	nonceManager *nonces.Manager
 	jws          *secure.JWS
-	directory    acme.Directory
+	directories  *DirectoryCache
+	caDirURL     string
 	HTTPClient   *http.Client
+	ctx          context.Context
+	retry        RetryOptions
+	pacer        *pacer
+	metrics      metrics.Recorder
 
 	common         service // Reuse a single struct instead of allocating one for each service on the heap.
 	Accounts       *AccountService
@@ -32,13 +39,26 @@ type Core struct {
 	Certificates   *CertificateService
 	Challenges     *ChallengeService
 	Orders         *OrderService
+	RenewalInfo    *RenewalInfoService
 }
 
 // New Creates a new Core.
+// The directory is fetched for this Core only, see NewWithDirectoryCache to share the directories between the Cores.
 func New(httpClient *http.Client, userAgent, caDirURL, kid string, privateKey crypto.PrivateKey) (*Core, error) {
+	return NewWithDirectoryCache(httpClient, userAgent, caDirURL, kid, privateKey, nil)
+}
+
+// NewWithDirectoryCache Creates a new Core, the directory is taken from the cache.
+// The cache can be shared by several Cores, a nil cache is a cache for this Core only, without refresh.
+func NewWithDirectoryCache(httpClient *http.Client, userAgent, caDirURL, kid string, privateKey crypto.PrivateKey,
+	directories *DirectoryCache) (*Core, error) {
+	if directories == nil {
+		directories = NewDirectoryCache(0)
+	}
+
 	doer := sender.NewDoer(httpClient, userAgent)
 
-	dir, err := getDirectory(doer, caDirURL)
+	dir, err := directories.get(doer, caDirURL)
 	if err != nil {
 		return nil, err
 	}
@@ -47,18 +67,133 @@ func New(httpClient *http.Client, userAgent, caDirURL, kid string, privateKey cr
 
 	jws := secure.NewJWS(privateKey, kid, nonceManager)
 
-	c := &Core{doer: doer, nonceManager: nonceManager, jws: jws, directory: dir, HTTPClient: httpClient}
-
-	c.common.core = c
-	c.Accounts = (*AccountService)(&c.common)
-	c.Authorizations = (*AuthorizationService)(&c.common)
-	c.Certificates = (*CertificateService)(&c.common)
-	c.Challenges = (*ChallengeService)(&c.common)
-	c.Orders = (*OrderService)(&c.common)
+	c := &Core{
+		doer:         doer,
+		nonceManager: nonceManager,
+		jws:          jws,
+		directories:  directories,
+		caDirURL:     caDirURL,
+		HTTPClient:   httpClient,
+		pacer:        newPacer(),
+		metrics:      metrics.NoopRecorder{},
+	}
+	c.initServices()
+	c.SetNoncePool(NoncePoolOptions{})
 
 	return c, nil
 }
 
+// WithContext returns a copy of the Core whose requests are bound to the context.
+// The copy shares the account key, the nonces, the directory, and the rate limits with the original Core.
+func (a *Core) WithContext(ctx context.Context) *Core {
+	c := &Core{
+		doer:         a.doer.WithContext(ctx),
+		nonceManager: a.nonceManager,
+		jws:          a.jws,
+		directories:  a.directories,
+		caDirURL:     a.caDirURL,
+		HTTPClient:   a.HTTPClient,
+		ctx:          ctx,
+		retry:        a.retry,
+		pacer:        a.pacer,
+		metrics:      a.metrics,
+	}
+	c.initServices()
+
+	return c
+}
+
+// WithRetryOptions returns a copy of the Core whose requests are retried with the options.
+// The copy shares the account key, the nonces, the directory, and the rate limits with the original Core.
+func (a *Core) WithRetryOptions(opts RetryOptions) *Core {
+	c := &Core{
+		doer:         a.doer,
+		nonceManager: a.nonceManager,
+		jws:          a.jws,
+		directories:  a.directories,
+		caDirURL:     a.caDirURL,
+		HTTPClient:   a.HTTPClient,
+		ctx:          a.ctx,
+		retry:        opts,
+		pacer:        a.pacer,
+		metrics:      a.metrics,
+	}
+	c.initServices()
+
+	return c
+}
+
+// WithMetricsRecorder returns a copy of the Core whose requests, and the lifecycle of the orders,
+// are observed with the recorder (the requests of the nonces, shared with the original Core, are not observed).
+// The copy shares the account key, the nonces, the directory, and the rate limits with the original Core.
+func (a *Core) WithMetricsRecorder(recorder metrics.Recorder) *Core {
+	if recorder == nil {
+		recorder = metrics.NoopRecorder{}
+	}
+
+	c := &Core{
+		doer:         a.doer.WithMetricsRecorder(recorder),
+		nonceManager: a.nonceManager,
+		jws:          a.jws,
+		directories:  a.directories,
+		caDirURL:     a.caDirURL,
+		HTTPClient:   a.HTTPClient,
+		ctx:          a.ctx,
+		retry:        a.retry,
+		pacer:        a.pacer,
+		metrics:      recorder,
+	}
+	c.initServices()
+
+	return c
+}
+
+// withPrivateKey returns a copy of the Core whose requests are signed with the private key,
+// the JWK is embedded in the requests instead of the account URL (ex: revocation with the certificate key).
+func (a *Core) withPrivateKey(privateKey crypto.PrivateKey) *Core {
+	c := &Core{
+		doer:         a.doer,
+		nonceManager: a.nonceManager,
+		jws:          secure.NewJWS(privateKey, "", a.nonceManager),
+		directories:  a.directories,
+		caDirURL:     a.caDirURL,
+		HTTPClient:   a.HTTPClient,
+		ctx:          a.ctx,
+		retry:        a.retry,
+		pacer:        a.pacer,
+		metrics:      a.metrics,
+	}
+	c.initServices()
+
+	return c
+}
+
+// Metrics returns the recorder of the metrics of the issuance lifecycle.
+// The metrics are discarded if no recorder is defined.
+func (a *Core) Metrics() metrics.Recorder {
+	return a.metrics
+}
+
+// Context returns the context of the Core.
+// It's context.Background() if the Core is not bound to a context.
+func (a *Core) Context() context.Context {
+	if a.ctx == nil {
+		return context.Background()
+	}
+
+	return a.ctx
+}
+
+func (a *Core) initServices() {
+	a.common.core = a
+	a.Accounts = (*AccountService)(&a.common)
+	a.Authorizations = (*AuthorizationService)(&a.common)
+	a.Certificates = (*CertificateService)(&a.common)
+	a.Challenges = (*ChallengeService)(&a.common)
+	a.Orders = (*OrderService)(&a.common)
+	a.RenewalInfo = (*RenewalInfoService)(&a.common)
+}
+
 // post performs an HTTP POST request and parses the response body as JSON,
 // into the provided respBody object.
 func (a *Core) post(uri string, reqBody, response interface{}) (*http.Response, error) {
@@ -76,28 +211,51 @@ func (a *Core) postAsGet(uri string, response interface{}) (*http.Response, erro
 	return a.retrievablePost(uri, []byte{}, response)
 }
 
+// retrievablePost performs a signed HTTP POST request, retried when the server is rate limiting the requests.
+// The request waits for the delay requested by the server (Retry-After) if it's not longer than the maximum rate limit wait,
+// the next requests to the same URL are also delayed.
 func (a *Core) retrievablePost(uri string, content []byte, response interface{}) (*http.Response, error) {
-	// during tests, allow to support ~90% of bad nonce with a minimum of attempts.
-	bo := backoff.NewExponentialBackOff()
-	bo.InitialInterval = 200 * time.Millisecond
-	bo.MaxInterval = 5 * time.Second
-	bo.MaxElapsedTime = 20 * time.Second
+	deadline := time.Now().Add(a.retry.maxRateLimitWait())
+
+	for {
+		err := a.pacer.wait(a.Context(), uri, time.Until(deadline))
+		if err != nil {
+			return nil, err
+		}
+
+		resp, err := a.postWithBackOff(uri, content, response)
+
+		var rateLimitErr *acme.RateLimitError
+		if !errors.As(err, &rateLimitErr) {
+			return resp, err
+		}
+
+		a.pacer.pause(uri, rateLimitErr.RetryAfter)
+
+		if rateLimitErr.RetryAfter <= 0 || time.Now().Add(rateLimitErr.RetryAfter).After(deadline) {
+			return nil, err
+		}
+
+		log.Infof("acme: rate limited, retry in %s: %v", rateLimitErr.RetryAfter, err)
+	}
+}
 
-	ctx, cancel := context.WithCancel(context.Background())
+// postWithBackOff performs a signed HTTP POST request, retried with an exponential backoff (badNonce and 5xx errors).
+func (a *Core) postWithBackOff(uri string, content []byte, response interface{}) (*http.Response, error) {
+	ctx, cancel := context.WithCancel(a.Context())
+	defer cancel()
 
 	var resp *http.Response
 	operation := func() error {
 		var err error
 		resp, err = a.signedPost(uri, content, response)
 		if err != nil {
-			switch err.(type) {
-			// Retry if the nonce was invalidated
-			case *acme.NonceError:
-				return err
-			default:
-				cancel()
+			if isRetryable(resp, err) {
 				return err
 			}
+
+			cancel()
+			return err
 		}
 
 		return nil
@@ -107,7 +265,7 @@ func (a *Core) retrievablePost(uri string, content []byte, response interface{})
 		log.Infof("retry due to: %v", err)
 	}
 
-	err := backoff.RetryNotify(operation, backoff.WithContext(bo, ctx), notify)
+	err := backoff.RetryNotify(operation, backoff.WithContext(a.retry.backOff(), ctx), notify)
 	if err != nil {
 		return nil, err
 	}
@@ -125,17 +283,23 @@ func (a *Core) signedPost(uri string, content []byte, response interface{}) (*ht
 
 	resp, err := a.doer.Post(uri, signedBody, "application/jose+json", response)
 
-	// nonceErr is ignored to keep the root error.
-	nonce, nonceErr := nonces.GetFromResponse(resp)
-	if nonceErr == nil {
+	var nonceErr *acme.NonceError
+	if errors.As(err, &nonceErr) {
+		// the pool may contain other stale nonces, only the nonce of the error response is kept.
+		a.nonceManager.Reset()
+	}
+
+	// the error is ignored to keep the root error.
+	nonce, errN := nonces.GetFromResponse(resp)
+	if errN == nil {
 		a.nonceManager.Push(nonce)
 	}
 
 	return resp, err
 }
 
-func (a *Core) signEABContent(newAccountURL, kid string, hmac []byte) ([]byte, error) {
-	eabJWS, err := a.jws.SignEABContent(newAccountURL, kid, hmac)
+func (a *Core) signEABContent(newAccountURL, kid string, hmac []byte, alg jose.SignatureAlgorithm) ([]byte, error) {
+	eabJWS, err := a.jws.SignEABContent(newAccountURL, kid, hmac, alg)
 	if err != nil {
 		return nil, err
 	}
@@ -143,13 +307,30 @@ func (a *Core) signEABContent(newAccountURL, kid string, hmac []byte) ([]byte, e
 	return []byte(eabJWS.FullSerialize()), nil
 }
 
+func (a *Core) signKeyChangeContent(keyChangeURL string, newKey crypto.PrivateKey) ([]byte, error) {
+	keyChangeJWS, err := a.jws.SignKeyChangeContent(keyChangeURL, newKey)
+	if err != nil {
+		return nil, err
+	}
+
+	return []byte(keyChangeJWS.FullSerialize()), nil
+}
+
 // GetKeyAuthorization Gets the key authorization.
 func (a *Core) GetKeyAuthorization(token string) (string, error) {
 	return a.jws.GetKeyAuthorization(token)
 }
 
+// GetDirectory returns the directory of the ACME server.
+// The directory is fetched again if the refresh interval of the DirectoryCache has passed,
+// the URL of the nonces follows the changes of the directory.
 func (a *Core) GetDirectory() acme.Directory {
-	return a.directory
+	// the directory is in the cache since the creation of the Core, the previous directory is kept if the refresh fails.
+	dir, _ := a.directories.get(a.doer, a.caDirURL)
+
+	a.nonceManager.SetNonceURL(dir.NewNonceURL)
+
+	return dir
 }
 
 func getDirectory(do *sender.Doer, caDirURL string) (acme.Directory, error) {
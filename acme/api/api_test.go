@@ -0,0 +1,112 @@
+package api
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/rsa"
+	"errors"
+	"fmt"
+	"net/http"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/go-acme/lego/v4/acme"
+	"github.com/go-acme/lego/v4/metrics"
+	"github.com/go-acme/lego/v4/platform/tester"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCore_WithContext(t *testing.T) {
+	mux, apiURL, tearDown := tester.SetupFakeAPI()
+	defer tearDown()
+
+	var calls int
+	mux.HandleFunc("/newOrder", func(w http.ResponseWriter, _ *http.Request) {
+		calls++
+		w.WriteHeader(http.StatusCreated)
+	})
+
+	// small value keeps test fast
+	privateKey, err := rsa.GenerateKey(rand.Reader, 512)
+	require.NoError(t, err, "Could not generate test key")
+
+	core, err := New(http.DefaultClient, "lego-test", apiURL+"/dir", "", privateKey)
+	require.NoError(t, err)
+
+	assert.Equal(t, context.Background(), core.Context())
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	ctxCore := core.WithContext(ctx)
+	assert.Equal(t, ctx, ctxCore.Context())
+	assert.Equal(t, core.GetDirectory(), ctxCore.GetDirectory())
+
+	_, err = ctxCore.Orders.New([]string{"example.com"})
+	require.Error(t, err)
+	assert.True(t, errors.Is(err, context.Canceled))
+
+	assert.Equal(t, 0, calls)
+}
+
+type metricsRecorderMock struct {
+	metrics.NoopRecorder
+
+	mu       sync.Mutex
+	orders   int
+	requests []string
+}
+
+func (m *metricsRecorderMock) OrderCreated() {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.orders++
+}
+
+func (m *metricsRecorderMock) CARequest(method string, statusCode int, _ time.Duration) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.requests = append(m.requests, fmt.Sprintf("%s %d", method, statusCode))
+}
+
+func TestCore_WithMetricsRecorder(t *testing.T) {
+	mux, apiURL, tearDown := tester.SetupFakeAPI()
+	defer tearDown()
+
+	mux.HandleFunc("/newOrder", func(w http.ResponseWriter, _ *http.Request) {
+		err := tester.WriteJSONResponse(w, acme.Order{Status: acme.StatusPending})
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+		}
+	})
+
+	// small value keeps test fast
+	privateKey, err := rsa.GenerateKey(rand.Reader, 512)
+	require.NoError(t, err, "Could not generate test key")
+
+	core, err := New(http.DefaultClient, "lego-test", apiURL+"/dir", "", privateKey)
+	require.NoError(t, err)
+
+	assert.Equal(t, metrics.NoopRecorder{}, core.Metrics())
+
+	recorder := &metricsRecorderMock{}
+
+	metricsCore := core.WithMetricsRecorder(recorder)
+	assert.Equal(t, recorder, metricsCore.Metrics())
+
+	_, err = metricsCore.WithContext(context.Background()).Orders.New([]string{"example.com"})
+	require.NoError(t, err)
+
+	assert.Equal(t, 1, recorder.orders)
+	assert.Equal(t, []string{"POST 200"}, recorder.requests)
+
+	// the original Core is not observed.
+	_, err = core.Orders.New([]string{"example.com"})
+	require.NoError(t, err)
+
+	assert.Equal(t, 1, recorder.orders)
+}
@@ -6,8 +6,36 @@ import (
 	"github.com/go-acme/lego/v4/acme"
 )
 
+// ErrNoPreAuthorization is returned when the ACME server does not support the pre-authorizations (newAuthz).
+var ErrNoPreAuthorization = errors.New("authorization[new]: the ACME server does not support pre-authorization")
+
 type AuthorizationService service
 
+// New Creates a new authorization for an identifier (pre-authorization).
+// - https://tools.ietf.org/html/rfc8555#section-7.4.1
+func (c *AuthorizationService) New(domain string) (acme.ExtendedAuthorization, error) {
+	if c.core.GetDirectory().NewAuthzURL == "" {
+		return acme.ExtendedAuthorization{}, ErrNoPreAuthorization
+	}
+
+	authzReq := struct {
+		Identifier acme.Identifier `json:"identifier"`
+	}{
+		Identifier: acme.Identifier{Type: "dns", Value: domain},
+	}
+
+	var authz acme.Authorization
+	resp, err := c.core.post(c.core.GetDirectory().NewAuthzURL, authzReq, &authz)
+	if err != nil {
+		return acme.ExtendedAuthorization{}, err
+	}
+
+	return acme.ExtendedAuthorization{
+		Authorization: authz,
+		Location:      resp.Header.Get("Location"),
+	}, nil
+}
+
 // Get Gets an authorization.
 func (c *AuthorizationService) Get(authzURL string) (acme.Authorization, error) {
 	if len(authzURL) == 0 {
@@ -0,0 +1,67 @@
+package api
+
+import (
+	"crypto/rand"
+	"crypto/rsa"
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"github.com/go-acme/lego/v4/acme"
+	"github.com/go-acme/lego/v4/platform/tester"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAuthorizationService_New(t *testing.T) {
+	mux, apiURL, tearDown := tester.SetupFakeAPI()
+	defer tearDown()
+
+	// small value keeps test fast
+	privateKey, errK := rsa.GenerateKey(rand.Reader, 512)
+	require.NoError(t, errK, "Could not generate test key")
+
+	mux.HandleFunc("/newAuthz", func(w http.ResponseWriter, r *http.Request) {
+		body, err := readSignedBody(r, privateKey)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+
+		authzReq := struct {
+			Identifier acme.Identifier `json:"identifier"`
+		}{}
+		err = json.Unmarshal(body, &authzReq)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+
+		w.Header().Set("Location", apiURL+"/authz/1")
+		w.WriteHeader(http.StatusCreated)
+
+		err = json.NewEncoder(w).Encode(acme.Authorization{
+			Status:     acme.StatusPending,
+			Identifier: authzReq.Identifier,
+		})
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+	})
+
+	core, err := New(http.DefaultClient, "lego-test", apiURL+"/dir", "", privateKey)
+	require.NoError(t, err)
+
+	authz, err := core.Authorizations.New("example.com")
+	require.NoError(t, err)
+
+	expected := acme.ExtendedAuthorization{
+		Authorization: acme.Authorization{
+			Status:     acme.StatusPending,
+			Identifier: acme.Identifier{Type: "dns", Value: "example.com"},
+		},
+		Location: apiURL + "/authz/1",
+	}
+	assert.Equal(t, expected, authz)
+}
@@ -1,6 +1,7 @@
 package api
 
 import (
+	"crypto"
 	"crypto/x509"
 	"encoding/pem"
 	"errors"
@@ -53,6 +54,19 @@ func (c *CertificateService) Revoke(req acme.RevokeCertMessage) error {
 	return err
 }
 
+// RevokeWithKey Revokes a certificate, the request is signed with the private key of the certificate instead of the account key.
+// - https://tools.ietf.org/html/rfc8555#section-7.6
+func (c *CertificateService) RevokeWithKey(req acme.RevokeCertMessage, privateKey crypto.PrivateKey) error {
+	if privateKey == nil {
+		return errors.New("certificate[revoke]: empty key")
+	}
+
+	core := c.core.withPrivateKey(privateKey)
+
+	_, err := core.post(core.GetDirectory().RevokeCertURL, req, nil)
+	return err
+}
+
 // get Returns the certificate and the "up" link.
 func (c *CertificateService) get(certURL string) ([]byte, string, error) {
 	if len(certURL) == 0 {
@@ -3,13 +3,17 @@ package api
 import (
 	"crypto/rand"
 	"crypto/rsa"
+	"encoding/json"
 	"encoding/pem"
+	"io/ioutil"
 	"net/http"
 	"testing"
 
+	"github.com/go-acme/lego/v4/acme"
 	"github.com/go-acme/lego/v4/platform/tester"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+	jose "gopkg.in/square/go-jose.v2"
 )
 
 const certResponseMock = `-----BEGIN CERTIFICATE-----
@@ -127,3 +131,62 @@ func TestCertificateService_Get_embeddedIssuer(t *testing.T) {
 	assert.Equal(t, certResponseMock, string(cert), "Certificate")
 	assert.Equal(t, issuerMock, string(issuer), "IssuerCertificate")
 }
+
+func TestCertificateService_RevokeWithKey(t *testing.T) {
+	mux, apiURL, tearDown := tester.SetupFakeAPI()
+	defer tearDown()
+
+	// small value keeps test fast
+	accountKey, err := rsa.GenerateKey(rand.Reader, 512)
+	require.NoError(t, err, "Could not generate test key")
+
+	certKey, err := rsa.GenerateKey(rand.Reader, 512)
+	require.NoError(t, err, "Could not generate test key")
+
+	mux.HandleFunc("/revokeCert", func(w http.ResponseWriter, r *http.Request) {
+		reqBody, err := ioutil.ReadAll(r.Body)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+
+		jws, err := jose.ParseSigned(string(reqBody))
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+
+		// the JWK of the certificate key is embedded instead of the account URL.
+		header := jws.Signatures[0].Protected
+		if header.KeyID != "" || header.JSONWebKey == nil {
+			http.Error(w, "the JWK must be embedded", http.StatusBadRequest)
+			return
+		}
+
+		body, err := jws.Verify(&jose.JSONWebKey{Key: certKey.Public(), Algorithm: "RSA"})
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+
+		var msg acme.RevokeCertMessage
+		err = json.Unmarshal(body, &msg)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+
+		if msg.Reason == nil || *msg.Reason != acme.CRLReasonKeyCompromise {
+			http.Error(w, "invalid reason", http.StatusBadRequest)
+			return
+		}
+	})
+
+	core, err := New(http.DefaultClient, "lego-test", apiURL+"/dir", apiURL+"/account/1", accountKey)
+	require.NoError(t, err)
+
+	reason := acme.CRLReasonKeyCompromise
+
+	err = core.Certificates.RevokeWithKey(acme.RevokeCertMessage{Certificate: "cert", Reason: &reason}, certKey)
+	require.NoError(t, err)
+}
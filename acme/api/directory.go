@@ -0,0 +1,82 @@
+package api
+
+import (
+	"sync"
+	"time"
+
+	"github.com/go-acme/lego/v4/acme"
+	"github.com/go-acme/lego/v4/acme/api/internal/sender"
+	"github.com/go-acme/lego/v4/log"
+)
+
+// DirectoryCache a cache of the directories of the ACME servers, by directory URL.
+// It can be shared by several Cores (clients): the directory of a CA is fetched once,
+// then fetched again, when accessed, once the refresh interval has passed.
+// It's safe for concurrent use.
+type DirectoryCache struct {
+	refreshInterval time.Duration
+
+	mu      sync.Mutex
+	entries map[string]*cachedDirectory
+}
+
+type cachedDirectory struct {
+	directory  acme.Directory
+	fetchedAt  time.Time
+	refreshing bool
+}
+
+// NewDirectoryCache creates a DirectoryCache.
+// 0 disables the refresh: the directories are fetched once.
+func NewDirectoryCache(refreshInterval time.Duration) *DirectoryCache {
+	return &DirectoryCache{
+		refreshInterval: refreshInterval,
+		entries:         make(map[string]*cachedDirectory),
+	}
+}
+
+// get returns the directory from the cache.
+// The directory is fetched if it's not in the cache.
+// A stale directory is fetched again, the previous directory is kept if the directory cannot be fetched.
+// The requests are sent without holding the lock of the cache.
+func (d *DirectoryCache) get(do *sender.Doer, caDirURL string) (acme.Directory, error) {
+	d.mu.Lock()
+
+	entry, ok := d.entries[caDirURL]
+	if ok && (d.refreshInterval <= 0 || entry.refreshing || time.Since(entry.fetchedAt) < d.refreshInterval) {
+		dir := entry.directory
+		d.mu.Unlock()
+
+		return dir, nil
+	}
+
+	if ok {
+		// only one refresh at a time, the other callers use the previous directory in the meantime.
+		entry.refreshing = true
+	}
+
+	d.mu.Unlock()
+
+	dir, err := getDirectory(do, caDirURL)
+
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	if ok {
+		entry.refreshing = false
+	}
+
+	if err != nil {
+		if !ok {
+			return acme.Directory{}, err
+		}
+
+		log.Warnf("acme: could not refresh the directory, the previous directory is used: %v", err)
+
+		return entry.directory, nil
+	}
+
+	d.entries[caDirURL] = &cachedDirectory{directory: dir, fetchedAt: time.Now()}
+
+	return dir, nil
+}
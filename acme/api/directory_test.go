@@ -0,0 +1,210 @@
+package api
+
+import (
+	"crypto/rand"
+	"crypto/rsa"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/go-acme/lego/v4/acme"
+	"github.com/go-acme/lego/v4/acme/api/internal/sender"
+	"github.com/go-acme/lego/v4/platform/tester"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func setupDirectoryServer(t *testing.T) (string, *int32, *int32) {
+	t.Helper()
+
+	var calls, lastNonce int32
+
+	mux := http.NewServeMux()
+	server := httptest.NewServer(mux)
+	t.Cleanup(server.Close)
+
+	mux.HandleFunc("/dir", func(w http.ResponseWriter, _ *http.Request) {
+		current := atomic.AddInt32(&calls, 1)
+
+		if current > 2 {
+			http.Error(w, "unavailable", http.StatusServiceUnavailable)
+			return
+		}
+
+		err := tester.WriteJSONResponse(w, acme.Directory{
+			NewNonceURL:   server.URL + "/nonce/" + strconv.Itoa(int(current)),
+			NewAccountURL: "https://example.com/account",
+			NewOrderURL:   "https://example.com/newOrder",
+			Meta:          acme.Meta{TermsOfService: "https://example.com/tos/" + strconv.Itoa(int(current))},
+		})
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+		}
+	})
+
+	mux.HandleFunc("/nonce/", func(w http.ResponseWriter, r *http.Request) {
+		n, err := strconv.Atoi(r.URL.Path[len("/nonce/"):])
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+
+		atomic.StoreInt32(&lastNonce, int32(n))
+
+		w.Header().Set("Replay-Nonce", "12345")
+		w.WriteHeader(http.StatusOK)
+	})
+
+	return server.URL + "/dir", &calls, &lastNonce
+}
+
+func TestDirectoryCache_get(t *testing.T) {
+	dirURL, calls, _ := setupDirectoryServer(t)
+
+	doer := sender.NewDoer(http.DefaultClient, "lego-test")
+
+	cache := NewDirectoryCache(time.Hour)
+
+	for i := 0; i < 3; i++ {
+		dir, err := cache.get(doer, dirURL)
+		require.NoError(t, err)
+
+		assert.Equal(t, "https://example.com/tos/1", dir.Meta.TermsOfService)
+	}
+
+	assert.Equal(t, int32(1), atomic.LoadInt32(calls))
+
+	// the stale directory is fetched again.
+	cache.entries[dirURL].fetchedAt = time.Now().Add(-2 * time.Hour)
+
+	dir, err := cache.get(doer, dirURL)
+	require.NoError(t, err)
+
+	assert.Equal(t, "https://example.com/tos/2", dir.Meta.TermsOfService)
+	assert.Equal(t, int32(2), atomic.LoadInt32(calls))
+
+	// the previous directory is kept when the directory cannot be fetched.
+	cache.entries[dirURL].fetchedAt = time.Now().Add(-2 * time.Hour)
+
+	dir, err = cache.get(doer, dirURL)
+	require.NoError(t, err)
+
+	assert.Equal(t, "https://example.com/tos/2", dir.Meta.TermsOfService)
+	assert.Equal(t, int32(3), atomic.LoadInt32(calls))
+}
+
+func TestDirectoryCache_get_disabled(t *testing.T) {
+	dirURL, calls, _ := setupDirectoryServer(t)
+
+	doer := sender.NewDoer(http.DefaultClient, "lego-test")
+
+	cache := NewDirectoryCache(0)
+
+	_, err := cache.get(doer, dirURL)
+	require.NoError(t, err)
+
+	cache.entries[dirURL].fetchedAt = time.Now().Add(-2 * time.Hour)
+
+	for i := 0; i < 3; i++ {
+		dir, err := cache.get(doer, dirURL)
+		require.NoError(t, err)
+
+		assert.Equal(t, "https://example.com/tos/1", dir.Meta.TermsOfService)
+	}
+
+	assert.Equal(t, int32(1), atomic.LoadInt32(calls))
+}
+
+func TestDirectoryCache_get_error(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		http.Error(w, "unavailable", http.StatusServiceUnavailable)
+	}))
+	t.Cleanup(server.Close)
+
+	cache := NewDirectoryCache(time.Hour)
+
+	_, err := cache.get(sender.NewDoer(http.DefaultClient, "lego-test"), server.URL)
+	require.Error(t, err)
+
+	assert.Empty(t, cache.entries)
+}
+
+func TestDirectoryCache_get_concurrent(t *testing.T) {
+	dirURL, _, _ := setupDirectoryServer(t)
+
+	doer := sender.NewDoer(http.DefaultClient, "lego-test")
+
+	cache := NewDirectoryCache(time.Nanosecond)
+
+	var wg sync.WaitGroup
+	for i := 0; i < 10; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+
+			dir, err := cache.get(doer, dirURL)
+			if err == nil {
+				assert.NotEmpty(t, dir.NewOrderURL)
+			}
+		}()
+	}
+
+	wg.Wait()
+}
+
+func TestNewWithDirectoryCache_shared(t *testing.T) {
+	dirURL, calls, _ := setupDirectoryServer(t)
+
+	// small value keeps test fast
+	privateKey, err := rsa.GenerateKey(rand.Reader, 512)
+	require.NoError(t, err, "Could not generate test key")
+
+	cache := NewDirectoryCache(time.Hour)
+
+	coreA, err := NewWithDirectoryCache(http.DefaultClient, "lego-test", dirURL, "", privateKey, cache)
+	require.NoError(t, err)
+
+	coreB, err := NewWithDirectoryCache(http.DefaultClient, "lego-test", dirURL, "", privateKey, cache)
+	require.NoError(t, err)
+
+	// the directory is fetched once for the Cores sharing the cache.
+	assert.Equal(t, "https://example.com/tos/1", coreA.GetDirectory().Meta.TermsOfService)
+	assert.Equal(t, "https://example.com/tos/1", coreB.GetDirectory().Meta.TermsOfService)
+
+	assert.Equal(t, int32(1), atomic.LoadInt32(calls))
+}
+
+func TestCore_GetDirectory_nonceURL(t *testing.T) {
+	dirURL, calls, lastNonce := setupDirectoryServer(t)
+
+	// small value keeps test fast
+	privateKey, err := rsa.GenerateKey(rand.Reader, 512)
+	require.NoError(t, err, "Could not generate test key")
+
+	cache := NewDirectoryCache(time.Hour)
+
+	core, err := NewWithDirectoryCache(http.DefaultClient, "lego-test", dirURL, "", privateKey, cache)
+	require.NoError(t, err)
+
+	_, err = core.nonceManager.Nonce()
+	require.NoError(t, err)
+
+	assert.Equal(t, int32(1), atomic.LoadInt32(lastNonce))
+
+	cache.mu.Lock()
+	cache.entries[dirURL].fetchedAt = time.Now().Add(-2 * time.Hour)
+	cache.mu.Unlock()
+
+	// the URL of the nonces follows the refreshed directory.
+	assert.Equal(t, "https://example.com/tos/2", core.GetDirectory().Meta.TermsOfService)
+	assert.Equal(t, int32(2), atomic.LoadInt32(calls))
+
+	_, err = core.nonceManager.Nonce()
+	require.NoError(t, err)
+
+	assert.Equal(t, int32(2), atomic.LoadInt32(lastNonce))
+}
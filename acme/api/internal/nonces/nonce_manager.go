@@ -5,15 +5,28 @@ import (
 	"fmt"
 	"net/http"
 	"sync"
+	"time"
 
 	"github.com/go-acme/lego/v4/acme/api/internal/sender"
 )
 
+type nonce struct {
+	value     string
+	fetchedAt time.Time
+}
+
 // Manager Manages nonces.
 type Manager struct {
 	do       *sender.Doer
 	nonceURL string
-	nonces   []string
+	nonces   []nonce
+
+	// poolSize the number of nonces pre-fetched, 0 disables the prefetch.
+	poolSize int
+	// maxAge the maximum age of the nonces, 0 means no expiration.
+	maxAge    time.Duration
+	refilling bool
+
 	sync.Mutex
 }
 
@@ -25,7 +38,26 @@ func NewManager(do *sender.Doer, nonceURL string) *Manager {
 	}
 }
 
+// SetNonceURL Sets the URL of the nonces (ex: the directory has been refreshed).
+func (n *Manager) SetNonceURL(nonceURL string) {
+	n.Lock()
+	defer n.Unlock()
+
+	n.nonceURL = nonceURL
+}
+
+// SetPool Sets the number of nonces pre-fetched (0 disables the prefetch),
+// and the maximum age of the nonces (0 means no expiration).
+func (n *Manager) SetPool(size int, maxAge time.Duration) {
+	n.Lock()
+	defer n.Unlock()
+
+	n.poolSize = size
+	n.maxAge = maxAge
+}
+
 // Pop Pops a nonce.
+// The expired nonces are discarded.
 func (n *Manager) Pop() (string, bool) {
 	n.Lock()
 	defer n.Unlock()
@@ -34,28 +66,92 @@ func (n *Manager) Pop() (string, bool) {
 		return "", false
 	}
 
-	nonce := n.nonces[len(n.nonces)-1]
+	last := n.nonces[len(n.nonces)-1]
+
+	if n.maxAge > 0 && time.Since(last.fetchedAt) > n.maxAge {
+		// the last nonce is the most recent: all the nonces are expired.
+		n.nonces = nil
+		return "", false
+	}
+
 	n.nonces = n.nonces[:len(n.nonces)-1]
-	return nonce, true
+	return last.value, true
 }
 
 // Push Pushes a nonce.
-func (n *Manager) Push(nonce string) {
+// The oldest nonces are discarded when the pool is full.
+func (n *Manager) Push(value string) {
+	n.Lock()
+	defer n.Unlock()
+
+	n.nonces = append(n.nonces, nonce{value: value, fetchedAt: time.Now()})
+
+	if n.poolSize > 0 && len(n.nonces) > n.poolSize {
+		n.nonces = n.nonces[len(n.nonces)-n.poolSize:]
+	}
+}
+
+// Reset Discards all the nonces (ex: a nonce has been rejected by the server).
+func (n *Manager) Reset() {
 	n.Lock()
 	defer n.Unlock()
-	n.nonces = append(n.nonces, nonce)
+
+	n.nonces = nil
 }
 
 // Nonce implement jose.NonceSource.
 func (n *Manager) Nonce() (string, error) {
+	defer n.prefetch()
+
 	if nonce, ok := n.Pop(); ok {
 		return nonce, nil
 	}
 	return n.getNonce()
 }
 
+// prefetch fills the pool in the background, if it's enabled.
+func (n *Manager) prefetch() {
+	n.Lock()
+
+	missing := n.poolSize - len(n.nonces)
+	if n.refilling || missing <= 0 {
+		n.Unlock()
+		return
+	}
+
+	n.refilling = true
+	n.Unlock()
+
+	go func() {
+		var wg sync.WaitGroup
+		for i := 0; i < missing; i++ {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+
+				nonce, err := n.getNonce()
+				if err != nil {
+					return
+				}
+
+				n.Push(nonce)
+			}()
+		}
+
+		wg.Wait()
+
+		n.Lock()
+		n.refilling = false
+		n.Unlock()
+	}()
+}
+
 func (n *Manager) getNonce() (string, error) {
-	resp, err := n.do.Head(n.nonceURL)
+	n.Lock()
+	nonceURL := n.nonceURL
+	n.Unlock()
+
+	resp, err := n.do.Head(nonceURL)
 	if err != nil {
 		return "", fmt.Errorf("failed to get nonce from HTTP HEAD: %w", err)
 	}
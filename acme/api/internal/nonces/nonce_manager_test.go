@@ -1,14 +1,18 @@
 package nonces
 
 import (
+	"fmt"
 	"net/http"
 	"net/http/httptest"
+	"sync/atomic"
 	"testing"
 	"time"
 
 	"github.com/go-acme/lego/v4/acme"
 	"github.com/go-acme/lego/v4/acme/api/internal/sender"
 	"github.com/go-acme/lego/v4/platform/tester"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 func TestNotHoldingLockWhileMakingHTTPRequests(t *testing.T) {
@@ -53,3 +57,82 @@ func TestNotHoldingLockWhileMakingHTTPRequests(t *testing.T) {
 		t.Fatal("JWS is probably holding a lock while making HTTP request")
 	}
 }
+
+func TestManager_Pop_expired(t *testing.T) {
+	manager := NewManager(nil, "")
+	manager.SetPool(0, time.Minute)
+
+	manager.Push("a")
+	manager.nonces[0].fetchedAt = time.Now().Add(-2 * time.Minute)
+
+	_, ok := manager.Pop()
+	assert.False(t, ok)
+	assert.Empty(t, manager.nonces)
+}
+
+func TestManager_Push_poolSize(t *testing.T) {
+	manager := NewManager(nil, "")
+	manager.SetPool(2, 0)
+
+	manager.Push("a")
+	manager.Push("b")
+	manager.Push("c")
+
+	nonce, ok := manager.Pop()
+	require.True(t, ok)
+	assert.Equal(t, "c", nonce)
+
+	nonce, ok = manager.Pop()
+	require.True(t, ok)
+	assert.Equal(t, "b", nonce)
+
+	_, ok = manager.Pop()
+	assert.False(t, ok)
+}
+
+func TestManager_Reset(t *testing.T) {
+	manager := NewManager(nil, "")
+
+	manager.Push("a")
+	manager.Push("b")
+
+	manager.Reset()
+
+	_, ok := manager.Pop()
+	assert.False(t, ok)
+}
+
+func TestManager_Nonce_prefetch(t *testing.T) {
+	var calls int32
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.Header().Add("Replay-Nonce", fmt.Sprintf("nonce-%d", atomic.AddInt32(&calls, 1)))
+	}))
+	defer ts.Close()
+
+	doer := sender.NewDoer(http.DefaultClient, "lego-test")
+	manager := NewManager(doer, ts.URL)
+	manager.SetPool(3, time.Minute)
+
+	_, err := manager.Nonce()
+	require.NoError(t, err)
+
+	// the pool is filled in the background.
+	assert.Eventually(t, func() bool {
+		manager.Lock()
+		defer manager.Unlock()
+
+		return len(manager.nonces) == 3 && !manager.refilling
+	}, time.Second, 10*time.Millisecond)
+
+	assert.Equal(t, int32(4), atomic.LoadInt32(&calls))
+
+	manager.Lock()
+	expected := manager.nonces[len(manager.nonces)-1].value
+	manager.Unlock()
+
+	// the nonce is taken from the pool.
+	nonce, err := manager.Nonce()
+	require.NoError(t, err)
+
+	assert.Equal(t, expected, nonce)
+}
@@ -6,7 +6,10 @@ import (
 	"crypto/elliptic"
 	"crypto/rsa"
 	"encoding/base64"
+	"encoding/json"
+	"errors"
 	"fmt"
+	"sync"
 
 	"github.com/go-acme/lego/v4/acme/api/internal/nonces"
 	jose "gopkg.in/square/go-jose.v2"
@@ -17,6 +20,9 @@ type JWS struct {
 	privKey crypto.PrivateKey
 	kid     string // Key identifier
 	nonces  *nonces.Manager
+
+	// mu guards the key and the key identifier: they can change during concurrent signatures (ex: account key rollover).
+	mu sync.RWMutex
 }
 
 // NewJWS Create a new JWS.
@@ -30,26 +36,35 @@ func NewJWS(privateKey crypto.PrivateKey, kid string, nonceManager *nonces.Manag
 
 // SetKid Sets a key identifier.
 func (j *JWS) SetKid(kid string) {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+
 	j.kid = kid
 }
 
+// SetPrivateKey Sets the private key (account key rollover).
+func (j *JWS) SetPrivateKey(privateKey crypto.PrivateKey) {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+
+	j.privKey = privateKey
+}
+
+// key returns the private key and the key identifier.
+func (j *JWS) key() (crypto.PrivateKey, string) {
+	j.mu.RLock()
+	defer j.mu.RUnlock()
+
+	return j.privKey, j.kid
+}
+
 // SignContent Signs a content with the JWS.
 func (j *JWS) SignContent(url string, content []byte) (*jose.JSONWebSignature, error) {
-	var alg jose.SignatureAlgorithm
-	switch k := j.privKey.(type) {
-	case *rsa.PrivateKey:
-		alg = jose.RS256
-	case *ecdsa.PrivateKey:
-		if k.Curve == elliptic.P256() {
-			alg = jose.ES256
-		} else if k.Curve == elliptic.P384() {
-			alg = jose.ES384
-		}
-	}
+	privKey, kid := j.key()
 
 	signKey := jose.SigningKey{
-		Algorithm: alg,
-		Key:       jose.JSONWebKey{Key: j.privKey, KeyID: j.kid},
+		Algorithm: signatureAlgorithm(privKey),
+		Key:       jose.JSONWebKey{Key: privKey, KeyID: kid},
 	}
 
 	options := jose.SignerOptions{
@@ -59,7 +74,7 @@ func (j *JWS) SignContent(url string, content []byte) (*jose.JSONWebSignature, e
 		},
 	}
 
-	if j.kid == "" {
+	if kid == "" {
 		options.EmbedJWK = true
 	}
 
@@ -76,15 +91,18 @@ func (j *JWS) SignContent(url string, content []byte) (*jose.JSONWebSignature, e
 }
 
 // SignEABContent Signs an external account binding content with the JWS.
-func (j *JWS) SignEABContent(url, kid string, hmac []byte) (*jose.JSONWebSignature, error) {
-	jwk := jose.JSONWebKey{Key: j.privKey}
+// The algorithm is a MAC algorithm (HS256, HS384, or HS512).
+func (j *JWS) SignEABContent(url, kid string, hmac []byte, alg jose.SignatureAlgorithm) (*jose.JSONWebSignature, error) {
+	privKey, _ := j.key()
+
+	jwk := jose.JSONWebKey{Key: privKey}
 	jwkJSON, err := jwk.Public().MarshalJSON()
 	if err != nil {
 		return nil, fmt.Errorf("acme: error encoding eab jwk key: %w", err)
 	}
 
 	signer, err := jose.NewSigner(
-		jose.SigningKey{Algorithm: jose.HS256, Key: hmac},
+		jose.SigningKey{Algorithm: alg, Key: hmac},
 		&jose.SignerOptions{
 			EmbedJWK: false,
 			ExtraHeaders: map[jose.HeaderKey]interface{}{
@@ -105,10 +123,56 @@ func (j *JWS) SignEABContent(url, kid string, hmac []byte) (*jose.JSONWebSignatu
 	return signed, nil
 }
 
+// SignKeyChangeContent Signs the inner JWS of an account key rollover with the new key.
+// - https://tools.ietf.org/html/rfc8555#section-7.3.5
+func (j *JWS) SignKeyChangeContent(url string, newKey crypto.PrivateKey) (*jose.JSONWebSignature, error) {
+	privKey, kid := j.key()
+
+	if kid == "" {
+		return nil, errors.New("acme: the account URL is required to change the account key")
+	}
+
+	oldKey := jose.JSONWebKey{Key: privKey}
+
+	content, err := json.Marshal(struct {
+		Account string          `json:"account"`
+		OldKey  jose.JSONWebKey `json:"oldKey"`
+	}{
+		Account: kid,
+		OldKey:  oldKey.Public(),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("acme: error encoding key change content: %w", err)
+	}
+
+	// the inner JWS has no nonce, and contains the new key.
+	signer, err := jose.NewSigner(
+		jose.SigningKey{Algorithm: signatureAlgorithm(newKey), Key: newKey},
+		&jose.SignerOptions{
+			EmbedJWK: true,
+			ExtraHeaders: map[jose.HeaderKey]interface{}{
+				"url": url,
+			},
+		},
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create key change jose signer: %w", err)
+	}
+
+	signed, err := signer.Sign(content)
+	if err != nil {
+		return nil, fmt.Errorf("failed to sign key change content: %w", err)
+	}
+
+	return signed, nil
+}
+
 // GetKeyAuthorization Gets the key authorization for a token.
 func (j *JWS) GetKeyAuthorization(token string) (string, error) {
+	privKey, _ := j.key()
+
 	var publicKey crypto.PublicKey
-	switch k := j.privKey.(type) {
+	switch k := privKey.(type) {
 	case *ecdsa.PrivateKey:
 		publicKey = k.Public()
 	case *rsa.PrivateKey:
@@ -128,3 +192,19 @@ func (j *JWS) GetKeyAuthorization(token string) (string, error) {
 
 	return token + "." + keyThumb, nil
 }
+
+func signatureAlgorithm(privateKey crypto.PrivateKey) jose.SignatureAlgorithm {
+	switch k := privateKey.(type) {
+	case *rsa.PrivateKey:
+		return jose.RS256
+	case *ecdsa.PrivateKey:
+		switch k.Curve {
+		case elliptic.P256():
+			return jose.ES256
+		case elliptic.P384():
+			return jose.ES384
+		}
+	}
+
+	return ""
+}
@@ -1,8 +1,11 @@
 package secure
 
 import (
+	"crypto/rand"
+	"crypto/rsa"
 	"net/http"
 	"net/http/httptest"
+	"sync"
 	"testing"
 	"time"
 
@@ -10,6 +13,8 @@ import (
 	"github.com/go-acme/lego/v4/acme/api/internal/nonces"
 	"github.com/go-acme/lego/v4/acme/api/internal/sender"
 	"github.com/go-acme/lego/v4/platform/tester"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 func TestNotHoldingLockWhileMakingHTTPRequests(t *testing.T) {
@@ -54,3 +59,32 @@ func TestNotHoldingLockWhileMakingHTTPRequests(t *testing.T) {
 		t.Fatal("JWS is probably holding a lock while making HTTP request")
 	}
 }
+
+func TestJWS_SetPrivateKey_concurrent(t *testing.T) {
+	oldKey, err := rsa.GenerateKey(rand.Reader, 512)
+	require.NoError(t, err)
+
+	newKey, err := rsa.GenerateKey(rand.Reader, 512)
+	require.NoError(t, err)
+
+	j := NewJWS(oldKey, "https://example.com/acme/acct/1", nil)
+
+	var wg sync.WaitGroup
+	for i := 0; i < 10; i++ {
+		wg.Add(1)
+
+		go func() {
+			defer wg.Done()
+
+			_, errK := j.GetKeyAuthorization("token")
+			assert.NoError(t, errK)
+		}()
+	}
+
+	j.SetPrivateKey(newKey)
+
+	wg.Wait()
+
+	privKey, _ := j.key()
+	assert.Equal(t, newKey, privKey)
+}
@@ -1,15 +1,19 @@
 package sender
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 	"io"
 	"io/ioutil"
 	"net/http"
 	"runtime"
+	"strconv"
 	"strings"
+	"time"
 
 	"github.com/go-acme/lego/v4/acme"
+	"github.com/go-acme/lego/v4/metrics"
 )
 
 type RequestOption func(*http.Request) error
@@ -24,6 +28,8 @@ func contentType(ct string) RequestOption {
 type Doer struct {
 	httpClient *http.Client
 	userAgent  string
+	ctx        context.Context
+	metrics    metrics.Recorder
 }
 
 // NewDoer Creates a new Doer.
@@ -31,6 +37,27 @@ func NewDoer(client *http.Client, userAgent string) *Doer {
 	return &Doer{
 		httpClient: client,
 		userAgent:  userAgent,
+		metrics:    metrics.NoopRecorder{},
+	}
+}
+
+// WithContext returns a copy of the Doer whose requests are bound to the context.
+func (d *Doer) WithContext(ctx context.Context) *Doer {
+	return &Doer{
+		httpClient: d.httpClient,
+		userAgent:  d.userAgent,
+		ctx:        ctx,
+		metrics:    d.metrics,
+	}
+}
+
+// WithMetricsRecorder returns a copy of the Doer recording the latency of the requests with the recorder.
+func (d *Doer) WithMetricsRecorder(recorder metrics.Recorder) *Doer {
+	return &Doer{
+		httpClient: d.httpClient,
+		userAgent:  d.userAgent,
+		ctx:        d.ctx,
+		metrics:    recorder,
 	}
 }
 
@@ -68,7 +95,12 @@ func (d *Doer) Post(url string, body io.Reader, bodyType string, response interf
 }
 
 func (d *Doer) newRequest(method, uri string, body io.Reader, opts ...RequestOption) (*http.Request, error) {
-	req, err := http.NewRequest(method, uri, body)
+	ctx := d.ctx
+	if ctx == nil {
+		ctx = context.Background()
+	}
+
+	req, err := http.NewRequestWithContext(ctx, method, uri, body)
 	if err != nil {
 		return nil, fmt.Errorf("failed to create request: %w", err)
 	}
@@ -86,11 +118,16 @@ func (d *Doer) newRequest(method, uri string, body io.Reader, opts ...RequestOpt
 }
 
 func (d *Doer) do(req *http.Request, response interface{}) (*http.Response, error) {
+	start := time.Now()
+
 	resp, err := d.httpClient.Do(req)
 	if err != nil {
+		d.metrics.CARequest(req.Method, 0, time.Since(start))
 		return nil, err
 	}
 
+	d.metrics.CARequest(req.Method, resp.StatusCode, time.Since(start))
+
 	if err = checkError(req, resp); err != nil {
 		return resp, err
 	}
@@ -139,7 +176,39 @@ func checkError(req *http.Request, resp *http.Response) error {
 			return &acme.NonceError{ProblemDetails: errorDetails}
 		}
 
+		if errorDetails.Type == acme.RateLimitedErr {
+			return &acme.RateLimitError{ProblemDetails: errorDetails, RetryAfter: ParseRetryAfter(resp.Header.Get("Retry-After"))}
+		}
+
 		return errorDetails
 	}
 	return nil
 }
+
+// ParseRetryAfter parses the value of the header Retry-After (a number of seconds or an HTTP date).
+// It returns zero if the value is missing or invalid.
+// - https://tools.ietf.org/html/rfc7231#section-7.1.3
+func ParseRetryAfter(value string) time.Duration {
+	if value == "" {
+		return 0
+	}
+
+	if seconds, err := strconv.Atoi(value); err == nil {
+		if seconds < 0 {
+			return 0
+		}
+
+		return time.Duration(seconds) * time.Second
+	}
+
+	date, err := http.ParseTime(value)
+	if err != nil {
+		return 0
+	}
+
+	if d := time.Until(date); d > 0 {
+		return d
+	}
+
+	return 0
+}
@@ -1,11 +1,15 @@
 package sender
 
 import (
+	"context"
+	"errors"
 	"net/http"
 	"net/http/httptest"
 	"strings"
 	"testing"
+	"time"
 
+	"github.com/go-acme/lego/v4/acme"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 )
@@ -65,3 +69,82 @@ func TestDo_CustomUserAgent(t *testing.T) {
 	}
 	assert.Len(t, strings.Split(ua, " "), 5)
 }
+
+func TestDo_WithContext(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(_ http.ResponseWriter, _ *http.Request) {}))
+	defer ts.Close()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	doer := NewDoer(http.DefaultClient, "").WithContext(ctx)
+
+	_, err := doer.Get(ts.URL, nil)
+	require.Error(t, err)
+	assert.True(t, errors.Is(err, context.Canceled))
+}
+
+func TestDo_rateLimited(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.Header().Set("Content-Type", "application/problem+json")
+		w.Header().Set("Retry-After", "120")
+		w.WriteHeader(http.StatusTooManyRequests)
+		_, _ = w.Write([]byte(`{"type":"urn:ietf:params:acme:error:rateLimited","detail":"too many new orders","status":429}`))
+	}))
+	defer ts.Close()
+
+	doer := NewDoer(http.DefaultClient, "")
+
+	_, err := doer.Post(ts.URL, strings.NewReader(""), "application/jose+json", nil)
+	require.Error(t, err)
+
+	var rateLimitErr *acme.RateLimitError
+	require.True(t, errors.As(err, &rateLimitErr))
+	assert.Equal(t, 2*time.Minute, rateLimitErr.RetryAfter)
+	assert.Equal(t, "too many new orders", rateLimitErr.Detail)
+}
+
+func TestParseRetryAfter(t *testing.T) {
+	testCases := []struct {
+		desc     string
+		value    string
+		expected time.Duration
+	}{
+		{
+			desc: "empty",
+		},
+		{
+			desc:     "seconds",
+			value:    "120",
+			expected: 2 * time.Minute,
+		},
+		{
+			desc:  "negative seconds",
+			value: "-1",
+		},
+		{
+			desc:  "date in the past",
+			value: "Wed, 21 Oct 2015 07:28:00 GMT",
+		},
+		{
+			desc:  "invalid",
+			value: "soon",
+		},
+	}
+
+	for _, test := range testCases {
+		test := test
+		t.Run(test.desc, func(t *testing.T) {
+			t.Parallel()
+
+			assert.Equal(t, test.expected, ParseRetryAfter(test.value))
+		})
+	}
+}
+
+func TestParseRetryAfter_date(t *testing.T) {
+	value := time.Now().Add(time.Hour).UTC().Format(http.TimeFormat)
+
+	d := ParseRetryAfter(value)
+	assert.True(t, d > 59*time.Minute && d <= time.Hour, d.String())
+}
@@ -0,0 +1,76 @@
+package api
+
+import (
+	"net/http"
+	"strings"
+)
+
+// Middleware wraps the http.RoundTripper of the HTTP client used to communicate with the ACME server.
+// It allows to inspect, modify, or capture the requests and the responses (audit, proxies, retries, etc.).
+type Middleware func(next http.RoundTripper) http.RoundTripper
+
+// RoundTripperFunc is an adapter to allow the use of ordinary functions as http.RoundTripper.
+type RoundTripperFunc func(req *http.Request) (*http.Response, error)
+
+// RoundTrip calls f(req).
+func (f RoundTripperFunc) RoundTrip(req *http.Request) (*http.Response, error) {
+	return f(req)
+}
+
+// WrapHTTPClient returns a copy of the HTTP client whose transport is wrapped by the middlewares.
+// The first middleware is the outermost: it sees the requests first, and the responses last.
+// The transport of the client is http.DefaultTransport if it's nil.
+func WrapHTTPClient(client *http.Client, middlewares ...Middleware) *http.Client {
+	if len(middlewares) == 0 {
+		return client
+	}
+
+	transport := client.Transport
+	if transport == nil {
+		transport = http.DefaultTransport
+	}
+
+	for i := len(middlewares) - 1; i >= 0; i-- {
+		transport = middlewares[i](transport)
+	}
+
+	wrapped := *client
+	wrapped.Transport = transport
+
+	return &wrapped
+}
+
+// SetHeaders returns a Middleware adding static headers to the requests (ex: identification headers for allow-listing).
+// The headers replace the existing values.
+func SetHeaders(headers http.Header) Middleware {
+	return func(next http.RoundTripper) http.RoundTripper {
+		return RoundTripperFunc(func(req *http.Request) (*http.Response, error) {
+			// the request must not be modified by a RoundTripper.
+			req = req.Clone(req.Context())
+
+			for key, values := range headers {
+				req.Header.Del(key)
+
+				for _, value := range values {
+					req.Header.Add(key, value)
+				}
+			}
+
+			return next.RoundTrip(req)
+		})
+	}
+}
+
+// AppendUserAgent returns a Middleware appending a suffix to the User-Agent of the requests.
+func AppendUserAgent(suffix string) Middleware {
+	return func(next http.RoundTripper) http.RoundTripper {
+		return RoundTripperFunc(func(req *http.Request) (*http.Response, error) {
+			// the request must not be modified by a RoundTripper.
+			req = req.Clone(req.Context())
+
+			req.Header.Set("User-Agent", strings.TrimSpace(req.Header.Get("User-Agent")+" "+suffix))
+
+			return next.RoundTrip(req)
+		})
+	}
+}
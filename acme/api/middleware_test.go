@@ -0,0 +1,111 @@
+package api
+
+import (
+	"crypto/rand"
+	"crypto/rsa"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/go-acme/lego/v4/platform/tester"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func recordMiddleware(name string, calls *[]string) Middleware {
+	return func(next http.RoundTripper) http.RoundTripper {
+		return RoundTripperFunc(func(req *http.Request) (*http.Response, error) {
+			*calls = append(*calls, name+" "+req.Method+" "+req.URL.Path)
+
+			resp, err := next.RoundTrip(req)
+
+			*calls = append(*calls, name+" done")
+
+			return resp, err
+		})
+	}
+}
+
+func TestWrapHTTPClient(t *testing.T) {
+	_, apiURL, tearDown := tester.SetupFakeAPI()
+	defer tearDown()
+
+	var calls []string
+
+	client := WrapHTTPClient(&http.Client{}, recordMiddleware("audit", &calls), recordMiddleware("capture", &calls))
+
+	// small value keeps test fast
+	privateKey, err := rsa.GenerateKey(rand.Reader, 512)
+	require.NoError(t, err, "Could not generate test key")
+
+	_, err = New(client, "lego-test", apiURL+"/dir", "", privateKey)
+	require.NoError(t, err)
+
+	expected := []string{
+		"audit GET /dir",
+		"capture GET /dir",
+		"capture done",
+		"audit done",
+	}
+	assert.Equal(t, expected, calls)
+}
+
+func TestWrapHTTPClient_noMiddleware(t *testing.T) {
+	client := &http.Client{}
+
+	assert.Same(t, client, WrapHTTPClient(client))
+}
+
+func TestWrapHTTPClient_copy(t *testing.T) {
+	client := &http.Client{}
+
+	wrapped := WrapHTTPClient(client, func(next http.RoundTripper) http.RoundTripper { return next })
+
+	assert.NotSame(t, client, wrapped)
+	assert.Nil(t, client.Transport)
+	assert.Equal(t, http.DefaultTransport, wrapped.Transport)
+}
+
+func TestSetHeaders(t *testing.T) {
+	var header http.Header
+
+	next := RoundTripperFunc(func(req *http.Request) (*http.Response, error) {
+		header = req.Header
+		return &http.Response{StatusCode: http.StatusOK}, nil
+	})
+
+	headers := http.Header{}
+	headers.Set("X-Client-Id", "lego-1")
+	headers.Add("X-Team", "a")
+	headers.Add("X-Team", "b")
+
+	req := httptest.NewRequest(http.MethodGet, "https://example.com/dir", nil)
+	req.Header.Set("X-Team", "c")
+
+	_, err := SetHeaders(headers)(next).RoundTrip(req)
+	require.NoError(t, err)
+
+	assert.Equal(t, "lego-1", header.Get("X-Client-Id"))
+	assert.Equal(t, []string{"a", "b"}, header.Values("X-Team"))
+
+	// the original request is not modified.
+	assert.Equal(t, []string{"c"}, req.Header.Values("X-Team"))
+}
+
+func TestAppendUserAgent(t *testing.T) {
+	var userAgent string
+
+	next := RoundTripperFunc(func(req *http.Request) (*http.Response, error) {
+		userAgent = req.Header.Get("User-Agent")
+		return &http.Response{StatusCode: http.StatusOK}, nil
+	})
+
+	req := httptest.NewRequest(http.MethodGet, "https://example.com/dir", nil)
+	req.Header.Set("User-Agent", "lego-test xenolf-acme/4.0.1")
+
+	_, err := AppendUserAgent("my-company/1.0")(next).RoundTrip(req)
+	require.NoError(t, err)
+
+	assert.Equal(t, "lego-test xenolf-acme/4.0.1 my-company/1.0", userAgent)
+	assert.Equal(t, "lego-test xenolf-acme/4.0.1", req.Header.Get("User-Agent"))
+}
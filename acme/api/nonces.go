@@ -0,0 +1,29 @@
+package api
+
+import "time"
+
+// DefaultNonceMaxAge the default maximum age of the nonces.
+const DefaultNonceMaxAge = 5 * time.Minute
+
+// NoncePoolOptions the options of the pool of nonces.
+// The pool avoids to serialize the parallel requests (ex: bursts of orders) on the requests of new nonces (HEAD newNonce).
+type NoncePoolOptions struct {
+	// Size the number of nonces pre-fetched in the background.
+	// 0 disables the prefetch: a nonce is requested when the pool is empty.
+	Size int
+
+	// MaxAge the maximum age of a nonce, the older nonces are discarded.
+	// DefaultNonceMaxAge if zero.
+	MaxAge time.Duration
+}
+
+// SetNoncePool sets the options of the pool of nonces.
+// The pool is shared with the copies of the Core.
+func (a *Core) SetNoncePool(opts NoncePoolOptions) {
+	maxAge := opts.MaxAge
+	if maxAge <= 0 {
+		maxAge = DefaultNonceMaxAge
+	}
+
+	a.nonceManager.SetPool(opts.Size, maxAge)
+}
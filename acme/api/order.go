@@ -7,10 +7,27 @@ import (
 	"github.com/go-acme/lego/v4/acme"
 )
 
+// OrderOptions the optional fields of a new order.
+type OrderOptions struct {
+	// Replaces the ARI certificate identifier of the certificate replaced by the new order.
+	Replaces string
+
+	// Profile the name of the certificate profile (see acme.Meta.Profiles).
+	Profile string
+
+	// AutoRenewal the auto-renewal object of a STAR order (RFC 8739).
+	AutoRenewal *acme.AutoRenewal
+}
+
 type OrderService service
 
 // New Creates a new order.
 func (o *OrderService) New(domains []string) (acme.ExtendedOrder, error) {
+	return o.NewWithOptions(domains, nil)
+}
+
+// NewWithOptions Creates a new order with the optional fields.
+func (o *OrderService) NewWithOptions(domains []string, opts *OrderOptions) (acme.ExtendedOrder, error) {
 	var identifiers []acme.Identifier
 	for _, domain := range domains {
 		identifiers = append(identifiers, acme.Identifier{Type: "dns", Value: domain})
@@ -18,12 +35,20 @@ func (o *OrderService) New(domains []string) (acme.ExtendedOrder, error) {
 
 	orderReq := acme.Order{Identifiers: identifiers}
 
+	if opts != nil {
+		orderReq.Replaces = opts.Replaces
+		orderReq.Profile = opts.Profile
+		orderReq.AutoRenewal = opts.AutoRenewal
+	}
+
 	var order acme.Order
 	resp, err := o.core.post(o.core.GetDirectory().NewOrderURL, orderReq, &order)
 	if err != nil {
 		return acme.ExtendedOrder{}, err
 	}
 
+	o.core.metrics.OrderCreated()
+
 	return acme.ExtendedOrder{
 		Order:               order,
 		Location:            resp.Header.Get("Location"),
@@ -70,3 +95,19 @@ func (o *OrderService) UpdateForCSR(orderURL string, csr []byte) (acme.ExtendedO
 		AlternateChainLinks: getLinks(resp.Header, "alternate"),
 	}, nil
 }
+
+// Cancel Cancels the auto-renewal of a STAR order.
+// - https://www.rfc-editor.org/rfc/rfc8739#section-2.3
+func (o *OrderService) Cancel(orderURL string) (acme.ExtendedOrder, error) {
+	if len(orderURL) == 0 {
+		return acme.ExtendedOrder{}, errors.New("order[cancel]: empty URL")
+	}
+
+	var order acme.Order
+	_, err := o.core.post(orderURL, acme.Order{Status: acme.StatusCanceled}, &order)
+	if err != nil {
+		return acme.ExtendedOrder{}, err
+	}
+
+	return acme.ExtendedOrder{Order: order, Location: orderURL}, nil
+}
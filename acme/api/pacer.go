@@ -0,0 +1,71 @@
+package api
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// pacer delays the requests to the URLs rate limited by the server (Retry-After).
+// It's shared by the copies of a Core.
+type pacer struct {
+	mu        sync.Mutex
+	notBefore map[string]time.Time
+}
+
+func newPacer() *pacer {
+	return &pacer{notBefore: map[string]time.Time{}}
+}
+
+// pause delays the next requests to the URL.
+func (p *pacer) pause(uri string, delay time.Duration) {
+	if delay <= 0 {
+		return
+	}
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	until := time.Now().Add(delay)
+	if until.After(p.notBefore[uri]) {
+		p.notBefore[uri] = until
+	}
+}
+
+// delay returns the remaining delay before the next request to the URL.
+func (p *pacer) delay(uri string) time.Duration {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	notBefore, ok := p.notBefore[uri]
+	if !ok {
+		return 0
+	}
+
+	d := time.Until(notBefore)
+	if d <= 0 {
+		delete(p.notBefore, uri)
+		return 0
+	}
+
+	return d
+}
+
+// wait waits until a request to the URL can be sent, if the remaining delay is not longer than maxWait.
+// Otherwise, the request is sent right away, and the server responds with the rate limit error.
+func (p *pacer) wait(ctx context.Context, uri string, maxWait time.Duration) error {
+	d := p.delay(uri)
+	if d <= 0 || d > maxWait {
+		return nil
+	}
+
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+
+	select {
+	case <-timer.C:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
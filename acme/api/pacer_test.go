@@ -0,0 +1,59 @@
+package api
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPacer_wait(t *testing.T) {
+	p := newPacer()
+
+	p.pause("https://example.com/newOrder", 50*time.Millisecond)
+
+	start := time.Now()
+	err := p.wait(context.Background(), "https://example.com/newOrder", time.Second)
+	require.NoError(t, err)
+
+	assert.True(t, time.Since(start) >= 40*time.Millisecond)
+
+	// the delay is over.
+	assert.Equal(t, time.Duration(0), p.delay("https://example.com/newOrder"))
+}
+
+func TestPacer_wait_otherURL(t *testing.T) {
+	p := newPacer()
+
+	p.pause("https://example.com/newOrder", time.Hour)
+
+	assert.Equal(t, time.Duration(0), p.delay("https://example.com/newAccount"))
+}
+
+func TestPacer_wait_tooLong(t *testing.T) {
+	p := newPacer()
+
+	p.pause("https://example.com/newOrder", time.Hour)
+
+	start := time.Now()
+	err := p.wait(context.Background(), "https://example.com/newOrder", time.Minute)
+	require.NoError(t, err)
+
+	assert.True(t, time.Since(start) < time.Second)
+}
+
+func TestPacer_wait_canceled(t *testing.T) {
+	p := newPacer()
+
+	p.pause("https://example.com/newOrder", time.Minute)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	err := p.wait(ctx, "https://example.com/newOrder", time.Hour)
+	require.Error(t, err)
+	assert.True(t, errors.Is(err, context.Canceled))
+}
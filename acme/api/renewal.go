@@ -0,0 +1,39 @@
+package api
+
+import (
+	"errors"
+	"strings"
+
+	"github.com/go-acme/lego/v4/acme"
+	"github.com/go-acme/lego/v4/acme/api/internal/sender"
+)
+
+// ErrNoARI is returned when the ACME server does not support the ACME Renewal Information extension.
+var ErrNoARI = errors.New("renewalInfo[get]: the ACME server does not support ARI")
+
+type RenewalInfoService service
+
+// Get Gets the renewal information of a certificate.
+// The certificate identifier is built with certificate.MakeARICertID.
+// - https://datatracker.ietf.org/doc/draft-ietf-acme-ari/
+func (r *RenewalInfoService) Get(certID string) (acme.ExtendedRenewalInfo, error) {
+	if r.core.GetDirectory().RenewalInfo == "" {
+		return acme.ExtendedRenewalInfo{}, ErrNoARI
+	}
+
+	if len(certID) == 0 {
+		return acme.ExtendedRenewalInfo{}, errors.New("renewalInfo[get]: empty certificate ID")
+	}
+
+	// the renewalInfo resources are not authenticated, they are fetched with a GET request.
+	var info acme.RenewalInfo
+	resp, err := r.core.doer.Get(strings.TrimSuffix(r.core.GetDirectory().RenewalInfo, "/")+"/"+certID, &info)
+	if err != nil {
+		return acme.ExtendedRenewalInfo{}, err
+	}
+
+	return acme.ExtendedRenewalInfo{
+		RenewalInfo: info,
+		RetryAfter:  sender.ParseRetryAfter(resp.Header.Get("Retry-After")),
+	}, nil
+}
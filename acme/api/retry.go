@@ -0,0 +1,91 @@
+package api
+
+import (
+	"errors"
+	"net/http"
+	"time"
+
+	"github.com/cenkalti/backoff/v4"
+	"github.com/go-acme/lego/v4/acme"
+)
+
+// Default values of the RetryOptions.
+// During tests, allow to support ~90% of bad nonce with a minimum of attempts.
+const (
+	DefaultRetryInitialInterval = 200 * time.Millisecond
+	DefaultRetryMaxInterval     = 5 * time.Second
+	DefaultRetryMaxElapsedTime  = 20 * time.Second
+
+	DefaultMaxRateLimitWait = time.Minute
+)
+
+// RetryOptions the options of the retries of the requests to the ACME server.
+// A request is retried, with an exponential backoff,
+// when the nonce is rejected by the server (badNonce) or when the server responds with a 5xx status.
+// The zero values are replaced by the default values.
+type RetryOptions struct {
+	// MaxAttempts the maximum number of attempts of a request, including the first one.
+	// 0 means that the number of attempts is only limited by MaxElapsedTime.
+	MaxAttempts int
+
+	// InitialInterval the delay before the first retry.
+	InitialInterval time.Duration
+
+	// MaxInterval the maximum delay between two attempts.
+	MaxInterval time.Duration
+
+	// MaxElapsedTime the maximum duration of the retries of a request.
+	MaxElapsedTime time.Duration
+
+	// MaxRateLimitWait the maximum duration to wait when the server is rate limiting the requests (Retry-After).
+	// If the server requests a longer delay, the acme.RateLimitError is returned.
+	// A negative value disables the waits.
+	MaxRateLimitWait time.Duration
+}
+
+func (o RetryOptions) backOff() backoff.BackOff {
+	bo := backoff.NewExponentialBackOff()
+	bo.InitialInterval = DefaultRetryInitialInterval
+	bo.MaxInterval = DefaultRetryMaxInterval
+	bo.MaxElapsedTime = DefaultRetryMaxElapsedTime
+
+	if o.InitialInterval > 0 {
+		bo.InitialInterval = o.InitialInterval
+	}
+
+	if o.MaxInterval > 0 {
+		bo.MaxInterval = o.MaxInterval
+	}
+
+	if o.MaxElapsedTime > 0 {
+		bo.MaxElapsedTime = o.MaxElapsedTime
+	}
+
+	if o.MaxAttempts > 0 {
+		return backoff.WithMaxRetries(bo, uint64(o.MaxAttempts-1))
+	}
+
+	return bo
+}
+
+func (o RetryOptions) maxRateLimitWait() time.Duration {
+	switch {
+	case o.MaxRateLimitWait < 0:
+		return 0
+	case o.MaxRateLimitWait == 0:
+		return DefaultMaxRateLimitWait
+	default:
+		return o.MaxRateLimitWait
+	}
+}
+
+// isRetryable returns true if the request can be retried:
+// the nonce was invalidated, or the server had a transient failure (5xx).
+func isRetryable(resp *http.Response, err error) bool {
+	var nonceErr *acme.NonceError
+	if errors.As(err, &nonceErr) {
+		return true
+	}
+
+	return resp != nil && resp.StatusCode >= http.StatusInternalServerError
+}
@@ -0,0 +1,150 @@
+package api
+
+import (
+	"crypto/rand"
+	"crypto/rsa"
+	"errors"
+	"fmt"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/go-acme/lego/v4/acme"
+	"github.com/go-acme/lego/v4/platform/tester"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// setupRetryTest creates a Core with a new order endpoint failing the first requests.
+func setupRetryTest(t *testing.T, failures, status int, problemType string, opts RetryOptions) (*Core, *int) {
+	t.Helper()
+
+	return setupRetryAfterTest(t, failures, status, problemType, "", opts)
+}
+
+// setupRetryAfterTest creates a Core with a new order endpoint failing the first requests, with a Retry-After header.
+func setupRetryAfterTest(t *testing.T, failures, status int, problemType, retryAfter string, opts RetryOptions) (*Core, *int) {
+	t.Helper()
+
+	mux, apiURL, tearDown := tester.SetupFakeAPI()
+	t.Cleanup(tearDown)
+
+	var calls int
+	mux.HandleFunc("/newOrder", func(w http.ResponseWriter, _ *http.Request) {
+		calls++
+
+		if calls <= failures {
+			w.Header().Set("Content-Type", "application/problem+json")
+			if retryAfter != "" {
+				w.Header().Set("Retry-After", retryAfter)
+			}
+			w.WriteHeader(status)
+			_, _ = fmt.Fprintf(w, `{"type":%q,"status":%d}`, problemType, status)
+			return
+		}
+
+		err := tester.WriteJSONResponse(w, acme.Order{Status: acme.StatusPending})
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+		}
+	})
+
+	// small value keeps test fast
+	privateKey, err := rsa.GenerateKey(rand.Reader, 512)
+	require.NoError(t, err, "Could not generate test key")
+
+	core, err := New(http.DefaultClient, "lego-test", apiURL+"/dir", "", privateKey)
+	require.NoError(t, err)
+
+	return core.WithRetryOptions(opts), &calls
+}
+
+func TestCore_retry(t *testing.T) {
+	testCases := []struct {
+		desc        string
+		status      int
+		problemType string
+	}{
+		{
+			desc:        "badNonce",
+			status:      http.StatusBadRequest,
+			problemType: acme.BadNonceErr,
+		},
+		{
+			desc:        "service unavailable",
+			status:      http.StatusServiceUnavailable,
+			problemType: "urn:ietf:params:acme:error:serverInternal",
+		},
+	}
+
+	for _, test := range testCases {
+		test := test
+		t.Run(test.desc, func(t *testing.T) {
+			core, calls := setupRetryTest(t, 2, test.status, test.problemType, RetryOptions{InitialInterval: time.Millisecond, MaxInterval: time.Millisecond})
+
+			order, err := core.Orders.New([]string{"example.com"})
+			require.NoError(t, err)
+
+			assert.Equal(t, acme.StatusPending, order.Status)
+			assert.Equal(t, 3, *calls)
+		})
+	}
+}
+
+func TestCore_retry_maxAttempts(t *testing.T) {
+	core, calls := setupRetryTest(t, 5, http.StatusServiceUnavailable, "urn:ietf:params:acme:error:serverInternal",
+		RetryOptions{MaxAttempts: 3, InitialInterval: time.Millisecond, MaxInterval: time.Millisecond})
+
+	_, err := core.Orders.New([]string{"example.com"})
+	require.Error(t, err)
+
+	assert.Equal(t, 3, *calls)
+}
+
+func TestCore_retry_notRetryable(t *testing.T) {
+	core, calls := setupRetryTest(t, 5, http.StatusForbidden, "urn:ietf:params:acme:error:unauthorized",
+		RetryOptions{InitialInterval: time.Millisecond, MaxInterval: time.Millisecond})
+
+	_, err := core.Orders.New([]string{"example.com"})
+	require.Error(t, err)
+
+	assert.Equal(t, 1, *calls)
+}
+
+func TestCore_rateLimited(t *testing.T) {
+	core, calls := setupRetryAfterTest(t, 1, http.StatusTooManyRequests, acme.RateLimitedErr, "1", RetryOptions{})
+
+	start := time.Now()
+
+	order, err := core.Orders.New([]string{"example.com"})
+	require.NoError(t, err)
+
+	assert.Equal(t, acme.StatusPending, order.Status)
+	assert.Equal(t, 2, *calls)
+	assert.True(t, time.Since(start) >= time.Second)
+}
+
+func TestCore_rateLimited_tooLong(t *testing.T) {
+	core, calls := setupRetryAfterTest(t, 1, http.StatusTooManyRequests, acme.RateLimitedErr, "3600", RetryOptions{})
+
+	_, err := core.Orders.New([]string{"example.com"})
+	require.Error(t, err)
+
+	var rateLimitErr *acme.RateLimitError
+	require.True(t, errors.As(err, &rateLimitErr))
+	assert.Equal(t, time.Hour, rateLimitErr.RetryAfter)
+
+	assert.Equal(t, 1, *calls)
+}
+
+func TestCore_rateLimited_disabled(t *testing.T) {
+	core, calls := setupRetryAfterTest(t, 1, http.StatusTooManyRequests, acme.RateLimitedErr, "1", RetryOptions{MaxRateLimitWait: -1})
+
+	_, err := core.Orders.New([]string{"example.com"})
+	require.Error(t, err)
+
+	var rateLimitErr *acme.RateLimitError
+	require.True(t, errors.As(err, &rateLimitErr))
+
+	assert.Equal(t, 1, *calls)
+}
@@ -17,6 +17,25 @@ const (
 	StatusDeactivated = "deactivated"
 	StatusExpired     = "expired"
 	StatusRevoked     = "revoked"
+
+	// StatusCanceled the status of a STAR order canceled by the client.
+	// - https://www.rfc-editor.org/rfc/rfc8739#section-2.1.2
+	StatusCanceled = "canceled"
+)
+
+// CRL reason codes (revocation reasons).
+// - https://tools.ietf.org/html/rfc5280#section-5.3.1
+const (
+	CRLReasonUnspecified          uint = 0
+	CRLReasonKeyCompromise        uint = 1
+	CRLReasonCACompromise         uint = 2
+	CRLReasonAffiliationChanged   uint = 3
+	CRLReasonSuperseded           uint = 4
+	CRLReasonCessationOfOperation uint = 5
+	CRLReasonCertificateHold      uint = 6
+	CRLReasonRemoveFromCRL        uint = 8
+	CRLReasonPrivilegeWithdrawn   uint = 9
+	CRLReasonAACompromise         uint = 10
 )
 
 // Directory the ACME directory object.
@@ -29,6 +48,11 @@ type Directory struct {
 	RevokeCertURL string `json:"revokeCert"`
 	KeyChangeURL  string `json:"keyChange"`
 	Meta          Meta   `json:"meta"`
+
+	// renewalInfo (optional, string):
+	// The base URL of the renewalInfo resources (ACME Renewal Information extension).
+	// - https://datatracker.ietf.org/doc/draft-ietf-acme-ari/
+	RenewalInfo string `json:"renewalInfo,omitempty"`
 }
 
 // Meta the ACME meta object (related to Directory).
@@ -55,6 +79,33 @@ type Meta struct {
 	// then the CA requires that all new- account requests include an "externalAccountBinding" field
 	// associating the new account with an external account.
 	ExternalAccountRequired bool `json:"externalAccountRequired"`
+
+	// profiles (optional, object):
+	// The certificate profiles advertised by the ACME server:
+	// the keys are the profile names, and the values are their human-readable descriptions.
+	// - https://datatracker.ietf.org/doc/draft-aaron-acme-profiles/
+	Profiles map[string]string `json:"profiles,omitempty"`
+
+	// auto-renewal (optional, object):
+	// The server supports the STAR certificates (Short-Term, Automatically Renewed).
+	// - https://www.rfc-editor.org/rfc/rfc8739#section-3.1.1
+	AutoRenewal *MetaAutoRenewal `json:"auto-renewal,omitempty"`
+}
+
+// MetaAutoRenewal the STAR capabilities of the ACME server (related to Meta).
+// - https://www.rfc-editor.org/rfc/rfc8739#section-3.1.1
+type MetaAutoRenewal struct {
+	// min-lifetime (required, integer):
+	// The minimum acceptable value for the lifetime of the certificates, in seconds.
+	MinLifetime int `json:"min-lifetime"`
+
+	// max-duration (required, integer):
+	// The maximum allowed delta between the end-date and the start-date of the orders, in seconds.
+	MaxDuration int `json:"max-duration"`
+
+	// allow-certificate-get (optional, boolean):
+	// The server allows to fetch the STAR certificates with unauthenticated GET requests.
+	AllowCertificateGet bool `json:"allow-certificate-get,omitempty"`
 }
 
 // ExtendedAccount a extended Account.
@@ -166,6 +217,59 @@ type Order struct {
 	// certificate (optional, string):
 	// A URL for the certificate that has been issued in response to this order
 	Certificate string `json:"certificate,omitempty"`
+
+	// replaces (optional, string):
+	// The certificate identifier (ARI) of the certificate replaced by this order.
+	// - https://datatracker.ietf.org/doc/draft-ietf-acme-ari/
+	Replaces string `json:"replaces,omitempty"`
+
+	// profile (optional, string):
+	// The name of the certificate profile (advertised in the directory metadata) requested by this order.
+	// - https://datatracker.ietf.org/doc/draft-aaron-acme-profiles/
+	Profile string `json:"profile,omitempty"`
+
+	// auto-renewal (optional, object):
+	// The STAR (Short-Term, Automatically Renewed) certificates requested by this order.
+	// The fields notBefore and notAfter must not be used with this field.
+	// - https://www.rfc-editor.org/rfc/rfc8739#section-3.1.1
+	AutoRenewal *AutoRenewal `json:"auto-renewal,omitempty"`
+
+	// star-certificate (optional, string):
+	// A URL for the STAR certificate, rotated by the server, that has been issued in response to this order.
+	// - https://www.rfc-editor.org/rfc/rfc8739#section-3.1.2
+	StarCertificate string `json:"star-certificate,omitempty"`
+}
+
+// AutoRenewal the auto-renewal object of a STAR order (related to Order).
+// - https://www.rfc-editor.org/rfc/rfc8739#section-3.1.1
+type AutoRenewal struct {
+	// start-date (optional, string):
+	// The earliest date of validity of the first certificate, in the date format defined in [RFC3339].
+	// It's set by the server.
+	StartDate string `json:"start-date,omitempty"`
+
+	// end-date (required, string):
+	// The latest date of validity of the last certificate, in the date format defined in [RFC3339].
+	EndDate string `json:"end-date"`
+
+	// lifetime (required, integer):
+	// The maximum validity period of each certificate, in seconds.
+	Lifetime int `json:"lifetime"`
+
+	// lifetime-adjust (optional, integer):
+	// The amount of "left pad" added to each certificate, in seconds.
+	LifetimeAdjust int `json:"lifetime-adjust,omitempty"`
+
+	// allow-certificate-get (optional, boolean):
+	// Allows to fetch the certificates with unauthenticated GET requests.
+	AllowCertificateGet bool `json:"allow-certificate-get,omitempty"`
+}
+
+// ExtendedAuthorization a extended Authorization.
+type ExtendedAuthorization struct {
+	Authorization
+	// The authorization URL, contains the value of the response header `Location`
+	Location string `json:"-"`
 }
 
 // Authorization the ACME authorization object.
@@ -287,3 +391,28 @@ type RevokeCertMessage struct {
 	// The problem document detail SHOULD indicate which reasonCodes are allowed.
 	Reason *uint `json:"reason,omitempty"`
 }
+
+// ExtendedRenewalInfo a extended RenewalInfo.
+type ExtendedRenewalInfo struct {
+	RenewalInfo
+	// The delay from the response header `Retry-After` (a number of seconds or an HTTP date), zero if missing or invalid.
+	RetryAfter time.Duration `json:"-"`
+}
+
+// RenewalInfo the renewalInfo object (ACME Renewal Information extension).
+// - https://datatracker.ietf.org/doc/draft-ietf-acme-ari/
+type RenewalInfo struct {
+	// suggestedWindow (required, object):
+	// The window within which the CA recommends renewing the certificate.
+	SuggestedWindow Window `json:"suggestedWindow"`
+
+	// explanationURL (optional, string):
+	// A URL pointing to a page which may explain why the suggested renewal window is what it is.
+	ExplanationURL string `json:"explanationURL,omitempty"`
+}
+
+// Window a time window.
+type Window struct {
+	Start time.Time `json:"start"`
+	End   time.Time `json:"end"`
+}
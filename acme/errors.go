@@ -2,12 +2,21 @@ package acme
 
 import (
 	"fmt"
+	"time"
 )
 
 // Errors types.
 const (
-	errNS       = "urn:ietf:params:acme:error:"
-	BadNonceErr = errNS + "badNonce"
+	errNS          = "urn:ietf:params:acme:error:"
+	BadNonceErr    = errNS + "badNonce"
+	RateLimitedErr = errNS + "rateLimited"
+
+	// AutoRenewalCanceledErr the STAR order has been canceled.
+	// - https://www.rfc-editor.org/rfc/rfc8739#section-2.3
+	AutoRenewalCanceledErr = errNS + "autoRenewalCanceled"
+	// AutoRenewalExpiredErr the STAR order has expired.
+	// - https://www.rfc-editor.org/rfc/rfc8739#section-2.3
+	AutoRenewalExpiredErr = errNS + "autoRenewalExpired"
 )
 
 // ProblemDetails the problem details object
@@ -56,3 +65,13 @@ func (p ProblemDetails) Error() string {
 type NonceError struct {
 	*ProblemDetails
 }
+
+// RateLimitError represents the error which is returned
+// if the request exceeded a rate limit of the server.
+type RateLimitError struct {
+	*ProblemDetails
+
+	// RetryAfter the delay before the request can be sent again, from the response header `Retry-After`.
+	// It's zero if the server did not provide the header.
+	RetryAfter time.Duration
+}
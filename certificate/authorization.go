@@ -1,6 +1,11 @@
 package certificate
 
 import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
 	"time"
 
 	"github.com/go-acme/lego/v4/acme"
@@ -24,6 +29,12 @@ func (c *Certifier) getAuthorizations(order acme.ExtendedOrder) ([]acme.Authoriz
 		time.Sleep(delay)
 
 		go func(authzURL string) {
+			if authz, ok := c.preAuthorizations.get(authzURL); ok {
+				log.Infof("[%s] acme: use the pre-authorization", authz.Identifier.Value)
+				resc <- authz
+				return
+			}
+
 			authz, err := c.core.Authorizations.Get(authzURL)
 			if err != nil {
 				errc <- domainError{Domain: authz.Identifier.Value, Error: err}
@@ -79,3 +90,115 @@ func (c *Certifier) deactivateAuthorizations(order acme.ExtendedOrder) {
 		}
 	}
 }
+
+// DeactivateAuthorization deactivates an authorization (ex: a pending authorization which will not be completed).
+// A deactivated authorization cannot be used anymore.
+// - https://tools.ietf.org/html/rfc8555#section-7.5.2
+func (c *Certifier) DeactivateAuthorization(authzURL string) error {
+	c.preAuthorizations.remove(authzURL)
+
+	log.Infof("Deactivating auth: %s", authzURL)
+
+	return c.core.Authorizations.Deactivate(authzURL)
+}
+
+// PreAuthorize creates and validates the authorizations of the domains ahead of the orders (pre-authorization).
+// The valid authorizations are cached: the challenges of the orders reusing them are skipped.
+//
+// It returns api.ErrNoPreAuthorization if the CA does not advertise the newAuthz endpoint.
+// The wildcard domains cannot be pre-authorized.
+func (c *Certifier) PreAuthorize(domains []string) error {
+	if len(domains) == 0 {
+		return errors.New("no domains to pre-authorize")
+	}
+
+	domains = sanitizeDomain(domains)
+
+	for _, domain := range domains {
+		if strings.HasPrefix(domain, "*.") {
+			return fmt.Errorf("[%s] acme: a wildcard domain cannot be pre-authorized", domain)
+		}
+	}
+
+	var authorizations []acme.Authorization
+	var authzURLs []string
+
+	for _, domain := range domains {
+		authz, err := c.core.Authorizations.New(domain)
+		if err != nil {
+			return fmt.Errorf("[%s] acme: %w", domain, err)
+		}
+
+		log.Infof("[%s] AuthURL: %s", domain, authz.Location)
+
+		authorizations = append(authorizations, authz.Authorization)
+		authzURLs = append(authzURLs, authz.Location)
+	}
+
+	err := c.solve(authorizations)
+	if err != nil {
+		return err
+	}
+
+	for _, authzURL := range authzURLs {
+		authz, err := c.core.Authorizations.Get(authzURL)
+		if err != nil {
+			return err
+		}
+
+		if authz.Status != acme.StatusValid {
+			return fmt.Errorf("[%s] acme: the authorization state %s", authz.Identifier.Value, authz.Status)
+		}
+
+		c.preAuthorizations.set(authzURL, authz)
+	}
+
+	return nil
+}
+
+// PreAuthorizeWithContext is like PreAuthorize, but the process is abandoned when the context is done.
+func (c *Certifier) PreAuthorizeWithContext(ctx context.Context, domains []string) error {
+	return c.withContext(ctx).PreAuthorize(domains)
+}
+
+// authorizationCache the valid authorizations obtained by pre-authorization, indexed by URL.
+type authorizationCache struct {
+	mu             sync.Mutex
+	authorizations map[string]acme.Authorization
+}
+
+func newAuthorizationCache() *authorizationCache {
+	return &authorizationCache{authorizations: make(map[string]acme.Authorization)}
+}
+
+func (a *authorizationCache) set(authzURL string, authz acme.Authorization) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	a.authorizations[authzURL] = authz
+}
+
+func (a *authorizationCache) remove(authzURL string) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	delete(a.authorizations, authzURL)
+}
+
+// get returns the authorization if it's still valid.
+func (a *authorizationCache) get(authzURL string) (acme.Authorization, bool) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	authz, ok := a.authorizations[authzURL]
+	if !ok {
+		return acme.Authorization{}, false
+	}
+
+	if !authz.Expires.IsZero() && !authz.Expires.After(time.Now()) {
+		delete(a.authorizations, authzURL)
+		return acme.Authorization{}, false
+	}
+
+	return authz, true
+}
@@ -0,0 +1,123 @@
+package certificate
+
+import (
+	"crypto/rand"
+	"crypto/rsa"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/go-acme/lego/v4/acme"
+	"github.com/go-acme/lego/v4/acme/api"
+	"github.com/go-acme/lego/v4/certcrypto"
+	"github.com/go-acme/lego/v4/platform/tester"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCertifier_PreAuthorize(t *testing.T) {
+	mux, apiURL, tearDown := tester.SetupFakeAPI()
+	defer tearDown()
+
+	mux.HandleFunc("/newAuthz", func(w http.ResponseWriter, _ *http.Request) {
+		w.Header().Set("Location", apiURL+"/authz/1")
+
+		err := tester.WriteJSONResponse(w, acme.Authorization{
+			Status:     acme.StatusPending,
+			Identifier: acme.Identifier{Type: "dns", Value: "acme.wtf"},
+		})
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+		}
+	})
+
+	var authzCalls int
+	mux.HandleFunc("/authz/1", func(w http.ResponseWriter, _ *http.Request) {
+		authzCalls++
+
+		err := tester.WriteJSONResponse(w, acme.Authorization{
+			Status:     acme.StatusValid,
+			Expires:    time.Now().Add(time.Hour),
+			Identifier: acme.Identifier{Type: "dns", Value: "acme.wtf"},
+		})
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+		}
+	})
+
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err, "Could not generate test key")
+
+	core, err := api.New(http.DefaultClient, "lego-test", apiURL+"/dir", "", key)
+	require.NoError(t, err)
+
+	certifier := NewCertifier(core, &resolverMock{}, CertifierOptions{KeyType: certcrypto.RSA2048})
+
+	err = certifier.PreAuthorize([]string{"acme.wtf"})
+	require.NoError(t, err)
+
+	assert.Equal(t, 1, authzCalls)
+
+	// the authorization of the order is the pre-authorization: it's not requested again.
+	authz, err := certifier.getAuthorizations(acme.ExtendedOrder{
+		Order: acme.Order{
+			Identifiers:    []acme.Identifier{{Type: "dns", Value: "acme.wtf"}},
+			Authorizations: []string{apiURL + "/authz/1"},
+		},
+	})
+	require.NoError(t, err)
+
+	require.Len(t, authz, 1)
+	assert.Equal(t, acme.StatusValid, authz[0].Status)
+	assert.Equal(t, 1, authzCalls)
+}
+
+func TestCertifier_PreAuthorize_wildcard(t *testing.T) {
+	certifier := NewCertifier(nil, &resolverMock{}, CertifierOptions{KeyType: certcrypto.RSA2048})
+
+	err := certifier.PreAuthorize([]string{"*.acme.wtf"})
+	require.EqualError(t, err, "[*.acme.wtf] acme: a wildcard domain cannot be pre-authorized")
+}
+
+func TestCertifier_DeactivateAuthorization(t *testing.T) {
+	mux, apiURL, tearDown := tester.SetupFakeAPI()
+	defer tearDown()
+
+	var deactivated bool
+	mux.HandleFunc("/authz/1", func(w http.ResponseWriter, _ *http.Request) {
+		deactivated = true
+
+		err := tester.WriteJSONResponse(w, acme.Authorization{Status: acme.StatusDeactivated})
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+		}
+	})
+
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err, "Could not generate test key")
+
+	core, err := api.New(http.DefaultClient, "lego-test", apiURL+"/dir", "", key)
+	require.NoError(t, err)
+
+	certifier := NewCertifier(core, &resolverMock{}, CertifierOptions{KeyType: certcrypto.RSA2048})
+	certifier.preAuthorizations.set(apiURL+"/authz/1", acme.Authorization{Status: acme.StatusValid})
+
+	err = certifier.DeactivateAuthorization(apiURL + "/authz/1")
+	require.NoError(t, err)
+
+	assert.True(t, deactivated)
+
+	// the pre-authorization is not reused.
+	_, ok := certifier.preAuthorizations.get(apiURL + "/authz/1")
+	assert.False(t, ok)
+}
+
+func Test_authorizationCache_expired(t *testing.T) {
+	cache := newAuthorizationCache()
+
+	cache.set("https://example.com/authz/1", acme.Authorization{Status: acme.StatusValid, Expires: time.Now().Add(-time.Minute)})
+
+	_, ok := cache.get("https://example.com/authz/1")
+	assert.False(t, ok)
+	assert.Empty(t, cache.authorizations)
+}
@@ -0,0 +1,68 @@
+package certificate
+
+import (
+	"context"
+	"sync"
+)
+
+// DefaultBatchWorkers the default number of certificates obtained concurrently by ObtainBatch.
+const DefaultBatchWorkers = 4
+
+// BatchResult the result of a request of ObtainBatch.
+type BatchResult struct {
+	Request  ObtainRequest
+	Resource *Resource
+	Error    error
+}
+
+// ObtainBatch obtains the certificates of the requests concurrently,
+// with a pool of workers (CertifierOptions.BatchWorkers).
+// The workers share the nonces and the rate limits of the Certifier.
+//
+// The results are in the same order as the requests.
+// The challenge providers must support the concurrent challenges (ex: a DNS-01 provider),
+// unlike the built-in HTTP-01 and TLS-ALPN-01 servers which listen on a single port.
+func (c *Certifier) ObtainBatch(requests []ObtainRequest) []BatchResult {
+	results := make([]BatchResult, len(requests))
+
+	workers := c.options.BatchWorkers
+	if workers <= 0 {
+		workers = DefaultBatchWorkers
+	}
+
+	if workers > len(requests) {
+		workers = len(requests)
+	}
+
+	jobs := make(chan int)
+
+	var wg sync.WaitGroup
+	for i := 0; i < workers; i++ {
+		wg.Add(1)
+
+		go func() {
+			defer wg.Done()
+
+			for index := range jobs {
+				res, err := c.Obtain(requests[index])
+
+				results[index] = BatchResult{Request: requests[index], Resource: res, Error: err}
+			}
+		}()
+	}
+
+	for index := range requests {
+		jobs <- index
+	}
+
+	close(jobs)
+
+	wg.Wait()
+
+	return results
+}
+
+// ObtainBatchWithContext is like ObtainBatch, but the process is abandoned when the context is done.
+func (c *Certifier) ObtainBatchWithContext(ctx context.Context, requests []ObtainRequest) []BatchResult {
+	return c.withContext(ctx).ObtainBatch(requests)
+}
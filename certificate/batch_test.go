@@ -0,0 +1,73 @@
+package certificate
+
+import (
+	"crypto/rand"
+	"crypto/rsa"
+	"net/http"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/go-acme/lego/v4/acme/api"
+	"github.com/go-acme/lego/v4/certcrypto"
+	"github.com/go-acme/lego/v4/platform/tester"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCertifier_ObtainBatch(t *testing.T) {
+	mux, apiURL, tearDown := tester.SetupFakeAPI()
+	defer tearDown()
+
+	var mu sync.Mutex
+	var inFlight, maxInFlight int
+
+	mux.HandleFunc("/newOrder", func(w http.ResponseWriter, _ *http.Request) {
+		mu.Lock()
+		inFlight++
+		if inFlight > maxInFlight {
+			maxInFlight = inFlight
+		}
+		mu.Unlock()
+
+		time.Sleep(50 * time.Millisecond)
+
+		mu.Lock()
+		inFlight--
+		mu.Unlock()
+
+		w.Header().Set("Content-Type", "application/problem+json")
+		w.WriteHeader(http.StatusForbidden)
+		_, _ = w.Write([]byte(`{"type":"urn:ietf:params:acme:error:rejectedIdentifier","status":403}`))
+	})
+
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err, "Could not generate test key")
+
+	core, err := api.New(http.DefaultClient, "lego-test", apiURL+"/dir", "", key)
+	require.NoError(t, err)
+
+	certifier := NewCertifier(core, &resolverMock{}, CertifierOptions{KeyType: certcrypto.RSA2048, BatchWorkers: 2})
+
+	requests := []ObtainRequest{
+		{Domains: []string{"a.wtf"}},
+		{},
+		{Domains: []string{"b.wtf"}},
+		{Domains: []string{"c.wtf"}},
+		{Domains: []string{"d.wtf"}},
+	}
+
+	results := certifier.ObtainBatch(requests)
+	require.Len(t, results, len(requests))
+
+	for i, result := range results {
+		assert.Equal(t, requests[i], result.Request)
+		assert.Nil(t, result.Resource)
+		require.Error(t, result.Error)
+	}
+
+	assert.EqualError(t, results[1].Error, "no domains to obtain a certificate for")
+	assert.Contains(t, results[0].Error.Error(), "rejectedIdentifier")
+
+	assert.Equal(t, 2, maxInFlight)
+}
@@ -2,6 +2,7 @@ package certificate
 
 import (
 	"bytes"
+	"context"
 	"crypto"
 	"crypto/x509"
 	"encoding/base64"
@@ -34,6 +35,7 @@ type Resource struct {
 	Domain            string `json:"domain"`
 	CertURL           string `json:"certUrl"`
 	CertStableURL     string `json:"certStableUrl"`
+	OrderURL          string `json:"orderUrl,omitempty"`
 	PrivateKey        []byte `json:"-"`
 	Certificate       []byte `json:"-"`
 	IssuerCertificate []byte `json:"-"`
@@ -51,11 +53,26 @@ type Resource struct {
 //
 // If bundle is true, the []byte contains both the issuer certificate and your issued certificate as a bundle.
 type ObtainRequest struct {
-	Domains        []string
-	Bundle         bool
-	PrivateKey     crypto.PrivateKey
-	MustStaple     bool
+	Domains    []string
+	Bundle     bool
+	PrivateKey crypto.PrivateKey
+	MustStaple bool
+
+	// PreferredChain if the CA offers multiple certificate chains ("alternate" links),
+	// the chain with an issuer matching this Common Name is preferred.
+	// If empty, the preferred chain of the CertifierOptions is used.
 	PreferredChain string
+
+	// ReplacesCertID the ARI certificate identifier (see MakeARICertID) of the certificate replaced by the new one.
+	ReplacesCertID string
+
+	// Profile the name of the certificate profile (ex: "classic", "shortlived") advertised by the CA.
+	// If empty, the default profile of the CA is used.
+	Profile string
+
+	// AutoRenewal requests STAR certificates (RFC 8739), rotated by the CA until the end date.
+	// See WatchStarCertificate.
+	AutoRenewal *AutoRenewal
 }
 
 // ObtainForCSRRequest The request to obtain a certificate matching the CSR passed into it.
@@ -65,15 +82,47 @@ type ObtainForCSRRequest struct {
 	CSR            *x509.CertificateRequest
 	Bundle         bool
 	PreferredChain string
+
+	// ReplacesCertID the ARI certificate identifier (see MakeARICertID) of the certificate replaced by the new one.
+	ReplacesCertID string
+
+	// Profile the name of the certificate profile (ex: "classic", "shortlived") advertised by the CA.
+	// If empty, the default profile of the CA is used.
+	Profile string
+
+	// AutoRenewal requests STAR certificates (RFC 8739), rotated by the CA until the end date.
+	// See WatchStarCertificate.
+	AutoRenewal *AutoRenewal
 }
 
 type resolver interface {
 	Solve(authorizations []acme.Authorization) error
 }
 
+// contextResolver is a resolver which can be abandoned when the context is done.
+type contextResolver interface {
+	SolveWithContext(ctx context.Context, authorizations []acme.Authorization) error
+}
+
 type CertifierOptions struct {
 	KeyType certcrypto.KeyType
 	Timeout time.Duration
+
+	// PreferredChain the default preferred chain (Common Name of an issuer) used when the request does not define one.
+	PreferredChain string
+
+	// OnOrderCreated is called when an order has been created by the CA.
+	OnOrderCreated func(order acme.ExtendedOrder)
+
+	// OnCertificateIssued is called when a certificate has been issued by the CA.
+	OnCertificateIssued func(res *Resource)
+
+	// BatchWorkers the number of certificates obtained concurrently by ObtainBatch (DefaultBatchWorkers if zero).
+	BatchWorkers int
+
+	// ARIReplaces if true, Renew notifies the CA of the certificate replaced by the new one,
+	// if the CA supports ARI (`replaces` field of the order).
+	ARIReplaces bool
 }
 
 // Certifier A service to obtain/renew/revoke certificates.
@@ -81,17 +130,70 @@ type Certifier struct {
 	core     *api.Core
 	resolver resolver
 	options  CertifierOptions
+
+	preAuthorizations *authorizationCache
 }
 
 // NewCertifier creates a Certifier.
 func NewCertifier(core *api.Core, resolver resolver, options CertifierOptions) *Certifier {
 	return &Certifier{
-		core:     core,
-		resolver: resolver,
-		options:  options,
+		core:              core,
+		resolver:          resolver,
+		options:           options,
+		preAuthorizations: newAuthorizationCache(),
 	}
 }
 
+// withContext returns a copy of the Certifier whose requests to the CA and challenges are bound to the context.
+func (c *Certifier) withContext(ctx context.Context) *Certifier {
+	return &Certifier{
+		core:              c.core.WithContext(ctx),
+		resolver:          c.resolver,
+		options:           c.options,
+		preAuthorizations: c.preAuthorizations,
+	}
+}
+
+// ObtainWithContext is like Obtain, but the process is abandoned when the context is done.
+func (c *Certifier) ObtainWithContext(ctx context.Context, request ObtainRequest) (*Resource, error) {
+	return c.withContext(ctx).Obtain(request)
+}
+
+// ObtainForCSRWithContext is like ObtainForCSR, but the process is abandoned when the context is done.
+func (c *Certifier) ObtainForCSRWithContext(ctx context.Context, request ObtainForCSRRequest) (*Resource, error) {
+	return c.withContext(ctx).ObtainForCSR(request)
+}
+
+// RevokeWithContext is like Revoke, but the request is abandoned when the context is done.
+func (c *Certifier) RevokeWithContext(ctx context.Context, cert []byte) error {
+	return c.withContext(ctx).Revoke(cert)
+}
+
+// RevokeWithReasonWithContext is like RevokeWithReason, but the request is abandoned when the context is done.
+func (c *Certifier) RevokeWithReasonWithContext(ctx context.Context, cert []byte, reason *uint) error {
+	return c.withContext(ctx).RevokeWithReason(cert, reason)
+}
+
+// RevokeWithCertificateKeyWithContext is like RevokeWithCertificateKey, but the request is abandoned when the context is done.
+func (c *Certifier) RevokeWithCertificateKeyWithContext(ctx context.Context, cert []byte, privateKey crypto.PrivateKey, reason *uint) error {
+	return c.withContext(ctx).RevokeWithCertificateKey(cert, privateKey, reason)
+}
+
+// RenewWithContext is like Renew, but the process is abandoned when the context is done.
+func (c *Certifier) RenewWithContext(ctx context.Context, certRes Resource, bundle, mustStaple bool, preferredChain string) (*Resource, error) {
+	return c.withContext(ctx).Renew(certRes, bundle, mustStaple, preferredChain)
+}
+
+// GetOCSPWithContext is like GetOCSP, but the requests are abandoned when the context is done.
+func (c *Certifier) GetOCSPWithContext(ctx context.Context, bundle []byte) ([]byte, *ocsp.Response, error) {
+	return c.withContext(ctx).GetOCSP(bundle)
+}
+
+// GetWithContext is like Get, but the request is abandoned when the context is done.
+func (c *Certifier) GetWithContext(ctx context.Context, url string, bundle bool) (*Resource, error) {
+	return c.withContext(ctx).Get(url, bundle)
+}
+
 // Obtain tries to obtain a single certificate using all domains passed into it.
 //
 // This function will never return a partial certificate.
@@ -109,11 +211,19 @@ func (c *Certifier) Obtain(request ObtainRequest) (*Resource, error) {
 		log.Infof("[%s] acme: Obtaining SAN certificate", strings.Join(domains, ", "))
 	}
 
-	order, err := c.core.Orders.New(domains)
+	order, err := c.core.Orders.NewWithOptions(domains, &api.OrderOptions{
+		Replaces:    request.ReplacesCertID,
+		Profile:     request.Profile,
+		AutoRenewal: request.AutoRenewal.toACME(),
+	})
 	if err != nil {
 		return nil, err
 	}
 
+	if c.options.OnOrderCreated != nil {
+		c.options.OnOrderCreated(order)
+	}
+
 	authz, err := c.getAuthorizations(order)
 	if err != nil {
 		// If any challenge fails, return. Do not generate partial SAN certificates.
@@ -121,7 +231,7 @@ func (c *Certifier) Obtain(request ObtainRequest) (*Resource, error) {
 		return nil, err
 	}
 
-	err = c.resolver.Solve(authz)
+	err = c.solve(authz)
 	if err != nil {
 		// If any challenge fails, return. Do not generate partial SAN certificates.
 		c.deactivateAuthorizations(order)
@@ -143,6 +253,9 @@ func (c *Certifier) Obtain(request ObtainRequest) (*Resource, error) {
 	if len(failures) > 0 {
 		return cert, failures
 	}
+
+	c.certificateIssued(cert)
+
 	return cert, nil
 }
 
@@ -170,11 +283,19 @@ func (c *Certifier) ObtainForCSR(request ObtainForCSRRequest) (*Resource, error)
 		log.Infof("[%s] acme: Obtaining SAN certificate given a CSR", strings.Join(domains, ", "))
 	}
 
-	order, err := c.core.Orders.New(domains)
+	order, err := c.core.Orders.NewWithOptions(domains, &api.OrderOptions{
+		Replaces:    request.ReplacesCertID,
+		Profile:     request.Profile,
+		AutoRenewal: request.AutoRenewal.toACME(),
+	})
 	if err != nil {
 		return nil, err
 	}
 
+	if c.options.OnOrderCreated != nil {
+		c.options.OnOrderCreated(order)
+	}
+
 	authz, err := c.getAuthorizations(order)
 	if err != nil {
 		// If any challenge fails, return. Do not generate partial SAN certificates.
@@ -182,7 +303,7 @@ func (c *Certifier) ObtainForCSR(request ObtainForCSRRequest) (*Resource, error)
 		return nil, err
 	}
 
-	err = c.resolver.Solve(authz)
+	err = c.solve(authz)
 	if err != nil {
 		// If any challenge fails, return. Do not generate partial SAN certificates.
 		c.deactivateAuthorizations(order)
@@ -209,9 +330,26 @@ func (c *Certifier) ObtainForCSR(request ObtainForCSRRequest) (*Resource, error)
 	if len(failures) > 0 {
 		return cert, failures
 	}
+
+	c.certificateIssued(cert)
 	return cert, nil
 }
 
+func (c *Certifier) certificateIssued(res *Resource) {
+	if c.options.OnCertificateIssued != nil && res != nil {
+		c.options.OnCertificateIssued(res)
+	}
+}
+
+// solve solves the challenges of the authorizations, with the context of the Certifier if the resolver supports it.
+func (c *Certifier) solve(authz []acme.Authorization) error {
+	if r, ok := c.resolver.(contextResolver); ok {
+		return r.SolveWithContext(c.core.Context(), authz)
+	}
+
+	return c.resolver.Solve(authz)
+}
+
 func (c *Certifier) getForOrder(domains []string, order acme.ExtendedOrder, bundle bool, privateKey crypto.PrivateKey, mustStaple bool, preferredChain string) (*Resource, error) {
 	if privateKey == nil {
 		var err error
@@ -259,6 +397,11 @@ func (c *Certifier) getForCSR(domains []string, order acme.ExtendedOrder, bundle
 		PrivateKey: privateKeyPem,
 	}
 
+	if order.AutoRenewal != nil {
+		// the order is needed to cancel the auto-renewal of the STAR certificates.
+		certRes.OrderURL = order.Location
+	}
+
 	if respOrder.Status == acme.StatusValid {
 		// if the certificate is available right away, short cut!
 		ok, errR := c.checkResponse(respOrder, certRes, bundle, preferredChain)
@@ -276,7 +419,7 @@ func (c *Certifier) getForCSR(domains []string, order acme.ExtendedOrder, bundle
 		timeout = 30 * time.Second
 	}
 
-	err = wait.For("certificate", timeout, timeout/60, func() (bool, error) {
+	err = wait.ForWithContext(c.core.Context(), "certificate", timeout, timeout/60, func() (bool, error) {
 		ord, errW := c.core.Orders.Get(order.Location)
 		if errW != nil {
 			return false, errW
@@ -307,7 +450,18 @@ func (c *Certifier) checkResponse(order acme.ExtendedOrder, certRes *Resource, b
 		return valid, err
 	}
 
-	links := append([]string{order.Certificate}, order.AlternateChainLinks...)
+	// the preferred chain of the request takes precedence over the one of the options.
+	if preferredChain == "" {
+		preferredChain = c.options.PreferredChain
+	}
+
+	certURL := order.Certificate
+	if certURL == "" {
+		// the certificate of a STAR order, rotated by the CA.
+		certURL = order.StarCertificate
+	}
+
+	links := append([]string{certURL}, order.AlternateChainLinks...)
 
 	for i, link := range links {
 		cert, issuer, err := c.core.Certificates.Get(link, bundle)
@@ -353,21 +507,39 @@ func (c *Certifier) checkResponse(order acme.ExtendedOrder, certRes *Resource, b
 
 // Revoke takes a PEM encoded certificate or bundle and tries to revoke it at the CA.
 func (c *Certifier) Revoke(cert []byte) error {
-	certificates, err := certcrypto.ParsePEMBundle(cert)
+	return c.RevokeWithReason(cert, nil)
+}
+
+// RevokeWithReason takes a PEM encoded certificate or bundle and tries to revoke it at the CA.
+// The reason is one of the CRL reason codes (acme.CRLReasonXXX), the server omits the reason when it's nil.
+func (c *Certifier) RevokeWithReason(cert []byte, reason *uint) error {
+	x509Cert, revokeMsg, err := newRevokeMessage(cert, reason)
 	if err != nil {
 		return err
 	}
 
-	x509Cert := certificates[0]
-	if x509Cert.IsCA {
-		return errors.New("certificate bundle starts with a CA certificate")
+	log.Infof("[%s] acme: Revoking certificate", x509Cert.Subject.CommonName)
+
+	return c.core.Certificates.Revoke(revokeMsg)
+}
+
+// RevokeWithCertificateKey is like RevokeWithReason,
+// but the request is signed with the private key of the certificate instead of the account key.
+// It allows to revoke a certificate when the account that has issued it is not available.
+func (c *Certifier) RevokeWithCertificateKey(cert []byte, privateKey crypto.PrivateKey, reason *uint) error {
+	x509Cert, revokeMsg, err := newRevokeMessage(cert, reason)
+	if err != nil {
+		return err
 	}
 
-	revokeMsg := acme.RevokeCertMessage{
-		Certificate: base64.RawURLEncoding.EncodeToString(x509Cert.Raw),
+	err = checkCertificateKey(x509Cert, privateKey)
+	if err != nil {
+		return err
 	}
 
-	return c.core.Certificates.Revoke(revokeMsg)
+	log.Infof("[%s] acme: Revoking certificate with the certificate key", x509Cert.Subject.CommonName)
+
+	return c.core.Certificates.RevokeWithKey(revokeMsg, privateKey)
 }
 
 // Renew takes a Resource and tries to renew the certificate.
@@ -398,6 +570,15 @@ func (c *Certifier) Renew(certRes Resource, bundle, mustStaple bool, preferredCh
 	timeLeft := x509Cert.NotAfter.Sub(time.Now().UTC())
 	log.Infof("[%s] acme: Trying renewal with %d hours remaining", certRes.Domain, int(timeLeft.Hours()))
 
+	// The CA is notified of the certificate replaced by the new one, if it supports ARI (opt-in).
+	var replacesCertID string
+	if c.options.ARIReplaces && c.core.GetDirectory().RenewalInfo != "" {
+		replacesCertID, err = MakeARICertID(x509Cert)
+		if err != nil {
+			log.Warnf("[%s] acme: the ARI certificate ID of the renewed certificate cannot be built: %v", certRes.Domain, err)
+		}
+	}
+
 	// We always need to request a new certificate to renew.
 	// Start by checking to see if the certificate was based off a CSR,
 	// and use that if it's defined.
@@ -411,6 +592,7 @@ func (c *Certifier) Renew(certRes Resource, bundle, mustStaple bool, preferredCh
 			CSR:            csr,
 			Bundle:         bundle,
 			PreferredChain: preferredChain,
+			ReplacesCertID: replacesCertID,
 		})
 	}
 
@@ -423,10 +605,12 @@ func (c *Certifier) Renew(certRes Resource, bundle, mustStaple bool, preferredCh
 	}
 
 	query := ObtainRequest{
-		Domains:    certcrypto.ExtractDomains(x509Cert),
-		Bundle:     bundle,
-		PrivateKey: privateKey,
-		MustStaple: mustStaple,
+		Domains:        certcrypto.ExtractDomains(x509Cert),
+		Bundle:         bundle,
+		PrivateKey:     privateKey,
+		MustStaple:     mustStaple,
+		PreferredChain: preferredChain,
+		ReplacesCertID: replacesCertID,
 	}
 	return c.Obtain(query)
 }
@@ -463,7 +647,12 @@ func (c *Certifier) GetOCSP(bundle []byte) ([]byte, *ocsp.Response, error) {
 			return nil, nil, errors.New("no issuing certificate URL")
 		}
 
-		resp, errC := c.core.HTTPClient.Get(issuedCert.IssuingCertificateURL[0])
+		req, errC := http.NewRequestWithContext(c.core.Context(), http.MethodGet, issuedCert.IssuingCertificateURL[0], nil)
+		if errC != nil {
+			return nil, nil, errC
+		}
+
+		resp, errC := c.core.HTTPClient.Do(req)
 		if errC != nil {
 			return nil, nil, errC
 		}
@@ -492,7 +681,14 @@ func (c *Certifier) GetOCSP(bundle []byte) ([]byte, *ocsp.Response, error) {
 		return nil, nil, err
 	}
 
-	resp, err := c.core.HTTPClient.Post(issuedCert.OCSPServer[0], "application/ocsp-request", bytes.NewReader(ocspReq))
+	req, err := http.NewRequestWithContext(c.core.Context(), http.MethodPost, issuedCert.OCSPServer[0], bytes.NewReader(ocspReq))
+	if err != nil {
+		return nil, nil, err
+	}
+
+	req.Header.Set("Content-Type", "application/ocsp-request")
+
+	resp, err := c.core.HTTPClient.Do(req)
 	if err != nil {
 		return nil, nil, err
 	}
@@ -582,3 +778,46 @@ func sanitizeDomain(domains []string) []string {
 	}
 	return sanitizedDomains
 }
+
+func newRevokeMessage(cert []byte, reason *uint) (*x509.Certificate, acme.RevokeCertMessage, error) {
+	certificates, err := certcrypto.ParsePEMBundle(cert)
+	if err != nil {
+		return nil, acme.RevokeCertMessage{}, err
+	}
+
+	x509Cert := certificates[0]
+	if x509Cert.IsCA {
+		return nil, acme.RevokeCertMessage{}, errors.New("certificate bundle starts with a CA certificate")
+	}
+
+	revokeMsg := acme.RevokeCertMessage{
+		Certificate: base64.RawURLEncoding.EncodeToString(x509Cert.Raw),
+		Reason:      reason,
+	}
+
+	return x509Cert, revokeMsg, nil
+}
+
+// checkCertificateKey checks that the private key is the key of the certificate.
+func checkCertificateKey(x509Cert *x509.Certificate, privateKey crypto.PrivateKey) error {
+	signer, ok := privateKey.(crypto.Signer)
+	if !ok {
+		return errors.New("unsupported certificate private key")
+	}
+
+	certPublicKey, err := x509.MarshalPKIXPublicKey(x509Cert.PublicKey)
+	if err != nil {
+		return err
+	}
+
+	publicKey, err := x509.MarshalPKIXPublicKey(signer.Public())
+	if err != nil {
+		return err
+	}
+
+	if !bytes.Equal(certPublicKey, publicKey) {
+		return errors.New("the private key does not match the certificate")
+	}
+
+	return nil
+}
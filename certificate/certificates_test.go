@@ -1,9 +1,15 @@
 package certificate
 
 import (
+	"context"
 	"crypto/rand"
 	"crypto/rsa"
+	"crypto/x509/pkix"
+	"encoding/asn1"
+	"encoding/json"
 	"encoding/pem"
+	"errors"
+	"io/ioutil"
 	"net/http"
 	"testing"
 
@@ -13,6 +19,7 @@ import (
 	"github.com/go-acme/lego/v4/platform/tester"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+	jose "gopkg.in/square/go-jose.v2"
 )
 
 const certResponseMock = `-----BEGIN CERTIFICATE-----
@@ -333,6 +340,55 @@ func Test_checkResponse_alternate(t *testing.T) {
 	assert.Equal(t, issuerMock2, string(certRes.IssuerCertificate), "IssuerCertificate")
 }
 
+func Test_checkResponse_alternate_preferredChainOption(t *testing.T) {
+	mux, apiURL, tearDown := tester.SetupFakeAPI()
+	defer tearDown()
+
+	mux.HandleFunc("/certificate", func(w http.ResponseWriter, _ *http.Request) {
+		_, err := w.Write([]byte(certResponseMock))
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+		}
+	})
+	mux.HandleFunc("/certificate2", func(w http.ResponseWriter, _ *http.Request) {
+		_, err := w.Write([]byte(certResponseMock2))
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+		}
+	})
+
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err, "Could not generate test key")
+
+	core, err := api.New(http.DefaultClient, "lego-test", apiURL+"/dir", "", key)
+	require.NoError(t, err)
+
+	certifier := NewCertifier(core, &resolverMock{}, CertifierOptions{KeyType: certcrypto.RSA2048, PreferredChain: "DST Root CA X3"})
+
+	order := acme.ExtendedOrder{
+		Order: acme.Order{
+			Status:      acme.StatusValid,
+			Certificate: apiURL + "/certificate",
+		},
+		AlternateChainLinks: []string{apiURL + "/certificate2"},
+	}
+	certRes := &Resource{}
+	bundle := false
+
+	valid, err := certifier.checkResponse(order, certRes, bundle, "")
+	require.NoError(t, err)
+
+	assert.True(t, valid)
+	assert.NotNil(t, certRes)
+	assert.Equal(t, "", certRes.Domain)
+	assert.Contains(t, certRes.CertStableURL, "/certificate2")
+	assert.Contains(t, certRes.CertURL, "/certificate2")
+	assert.Nil(t, certRes.CSR)
+	assert.Nil(t, certRes.PrivateKey)
+	assert.Equal(t, certResponseMock2, string(certRes.Certificate), "Certificate")
+	assert.Equal(t, issuerMock2, string(certRes.IssuerCertificate), "IssuerCertificate")
+}
+
 func Test_Get(t *testing.T) {
 	mux, apiURL, tearDown := tester.SetupFakeAPI()
 	defer tearDown()
@@ -365,6 +421,253 @@ func Test_Get(t *testing.T) {
 	assert.Equal(t, issuerMock, string(certRes.IssuerCertificate), "IssuerCertificate")
 }
 
+func Test_ObtainWithContext_canceled(t *testing.T) {
+	_, apiURL, tearDown := tester.SetupFakeAPI()
+	defer tearDown()
+
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err, "Could not generate test key")
+
+	core, err := api.New(http.DefaultClient, "lego-test", apiURL+"/dir", "", key)
+	require.NoError(t, err)
+
+	certifier := NewCertifier(core, &resolverMock{}, CertifierOptions{KeyType: certcrypto.RSA2048})
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	_, err = certifier.ObtainWithContext(ctx, ObtainRequest{Domains: []string{"acme.wtf"}})
+	require.Error(t, err)
+	assert.True(t, errors.Is(err, context.Canceled), err.Error())
+}
+
+func Test_Obtain_callbacks(t *testing.T) {
+	mux, apiURL, tearDown := tester.SetupFakeAPI()
+	defer tearDown()
+
+	mux.HandleFunc("/newOrder", func(w http.ResponseWriter, _ *http.Request) {
+		err := tester.WriteJSONResponse(w, acme.Order{
+			Status:         acme.StatusPending,
+			Identifiers:    []acme.Identifier{{Type: "dns", Value: "acme.wtf"}},
+			Authorizations: []string{apiURL + "/authz"},
+			Finalize:       apiURL + "/finalize",
+		})
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+		}
+	})
+	mux.HandleFunc("/authz", func(w http.ResponseWriter, _ *http.Request) {
+		err := tester.WriteJSONResponse(w, acme.Authorization{
+			Status:     acme.StatusValid,
+			Identifier: acme.Identifier{Type: "dns", Value: "acme.wtf"},
+		})
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+		}
+	})
+	mux.HandleFunc("/finalize", func(w http.ResponseWriter, _ *http.Request) {
+		err := tester.WriteJSONResponse(w, acme.Order{
+			Status: acme.StatusInvalid,
+			Error:  &acme.ProblemDetails{Detail: "OOPS"},
+		})
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+		}
+	})
+
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err, "Could not generate test key")
+
+	core, err := api.New(http.DefaultClient, "lego-test", apiURL+"/dir", "", key)
+	require.NoError(t, err)
+
+	var orders []acme.ExtendedOrder
+	var issued []*Resource
+
+	certifier := NewCertifier(core, &resolverMock{}, CertifierOptions{
+		KeyType:             certcrypto.RSA2048,
+		OnOrderCreated:      func(order acme.ExtendedOrder) { orders = append(orders, order) },
+		OnCertificateIssued: func(res *Resource) { issued = append(issued, res) },
+	})
+
+	_, err = certifier.Obtain(ObtainRequest{Domains: []string{"acme.wtf"}})
+	require.Error(t, err)
+
+	require.Len(t, orders, 1)
+	assert.Equal(t, apiURL+"/finalize", orders[0].Finalize)
+
+	// the finalization has failed.
+	assert.Empty(t, issued)
+}
+
+func Test_Renew_ariReplaces(t *testing.T) {
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err, "Could not generate test key")
+
+	// the ARI certificate ID requires the AuthorityKeyId.
+	aki, err := asn1.Marshal(struct {
+		ID []byte `asn1:"optional,tag:0"`
+	}{ID: []byte{0x69, 0x88, 0x5B, 0x6B}})
+	require.NoError(t, err)
+
+	certPEM, err := certcrypto.GeneratePemCert(key, "acme.wtf", []pkix.Extension{{Id: asn1.ObjectIdentifier{2, 5, 29, 35}, Value: aki}})
+	require.NoError(t, err)
+
+	cert, err := certcrypto.ParsePEMCertificate(certPEM)
+	require.NoError(t, err)
+
+	certID, err := MakeARICertID(cert)
+	require.NoError(t, err)
+
+	testCases := []struct {
+		desc     string
+		enabled  bool
+		expected string
+	}{
+		{
+			desc: "default",
+		},
+		{
+			desc:     "enabled",
+			enabled:  true,
+			expected: certID,
+		},
+	}
+
+	for _, test := range testCases {
+		t.Run(test.desc, func(t *testing.T) {
+			mux, apiURL, tearDown := tester.SetupFakeAPI()
+			defer tearDown()
+
+			var orderReq *acme.Order
+			mux.HandleFunc("/newOrder", func(w http.ResponseWriter, r *http.Request) {
+				body, errR := readSignedBody(r, key)
+				if errR != nil {
+					http.Error(w, errR.Error(), http.StatusBadRequest)
+					return
+				}
+
+				orderReq = &acme.Order{}
+				errR = json.Unmarshal(body, orderReq)
+				if errR != nil {
+					http.Error(w, errR.Error(), http.StatusBadRequest)
+					return
+				}
+
+				errR = tester.WriteJSONResponse(w, acme.Order{
+					Status:      acme.StatusInvalid,
+					Identifiers: orderReq.Identifiers,
+					Error:       &acme.ProblemDetails{Detail: "OOPS"},
+				})
+				if errR != nil {
+					http.Error(w, errR.Error(), http.StatusInternalServerError)
+				}
+			})
+
+			core, err := api.New(http.DefaultClient, "lego-test", apiURL+"/dir", "", key)
+			require.NoError(t, err)
+
+			certifier := NewCertifier(core, &resolverMock{}, CertifierOptions{KeyType: certcrypto.RSA2048, ARIReplaces: test.enabled})
+
+			// the directory advertises the renewal information.
+			require.NotEmpty(t, core.GetDirectory().RenewalInfo)
+
+			_, _ = certifier.Renew(Resource{Domain: "acme.wtf", Certificate: certPEM}, false, false, "")
+
+			require.NotNil(t, orderReq)
+			assert.Equal(t, test.expected, orderReq.Replaces)
+		})
+	}
+}
+
+func Test_RevokeWithReason(t *testing.T) {
+	mux, apiURL, tearDown := tester.SetupFakeAPI()
+	defer tearDown()
+
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err, "Could not generate test key")
+
+	var revokeMsg acme.RevokeCertMessage
+	mux.HandleFunc("/revokeCert", func(w http.ResponseWriter, r *http.Request) {
+		body, err := readSignedBody(r, key)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+
+		err = json.Unmarshal(body, &revokeMsg)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+		}
+	})
+
+	core, err := api.New(http.DefaultClient, "lego-test", apiURL+"/dir", "", key)
+	require.NoError(t, err)
+
+	certifier := NewCertifier(core, &resolverMock{}, CertifierOptions{KeyType: certcrypto.RSA2048})
+
+	reason := acme.CRLReasonSuperseded
+
+	err = certifier.RevokeWithReason([]byte(certResponseMock), &reason)
+	require.NoError(t, err)
+
+	require.NotNil(t, revokeMsg.Reason)
+	assert.Equal(t, acme.CRLReasonSuperseded, *revokeMsg.Reason)
+	assert.NotEmpty(t, revokeMsg.Certificate)
+}
+
+func Test_RevokeWithCertificateKey(t *testing.T) {
+	mux, apiURL, tearDown := tester.SetupFakeAPI()
+	defer tearDown()
+
+	accountKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err, "Could not generate test key")
+
+	certKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err, "Could not generate test key")
+
+	cert, err := certcrypto.GeneratePemCert(certKey, "acme.wtf", nil)
+	require.NoError(t, err)
+
+	var revoked bool
+	mux.HandleFunc("/revokeCert", func(w http.ResponseWriter, r *http.Request) {
+		_, err := readSignedBody(r, certKey)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+
+		revoked = true
+	})
+
+	core, err := api.New(http.DefaultClient, "lego-test", apiURL+"/dir", "", accountKey)
+	require.NoError(t, err)
+
+	certifier := NewCertifier(core, &resolverMock{}, CertifierOptions{KeyType: certcrypto.RSA2048})
+
+	err = certifier.RevokeWithCertificateKey(cert, accountKey, nil)
+	require.EqualError(t, err, "the private key does not match the certificate")
+	assert.False(t, revoked)
+
+	err = certifier.RevokeWithCertificateKey(cert, certKey, nil)
+	require.NoError(t, err)
+	assert.True(t, revoked)
+}
+
+func readSignedBody(r *http.Request, privateKey *rsa.PrivateKey) ([]byte, error) {
+	reqBody, err := ioutil.ReadAll(r.Body)
+	if err != nil {
+		return nil, err
+	}
+
+	jws, err := jose.ParseSigned(string(reqBody))
+	if err != nil {
+		return nil, err
+	}
+
+	return jws.Verify(&jose.JSONWebKey{Key: privateKey.Public(), Algorithm: "RSA"})
+}
+
 type resolverMock struct {
 	error error
 }
@@ -0,0 +1,110 @@
+package certificate
+
+import (
+	"crypto/x509"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"math/rand"
+	"time"
+
+	"github.com/go-acme/lego/v4/acme"
+)
+
+// RenewalInfoRequest contains the necessary renewal information.
+type RenewalInfoRequest struct {
+	Cert *x509.Certificate
+}
+
+// RenewalInfoResponse is a wrapper around acme.RenewalInfo that provides a method for determining when to renew a certificate.
+type RenewalInfoResponse struct {
+	acme.RenewalInfo
+
+	// RetryAfter is the delay before the client should fetch the renewal information again.
+	// It's zero if the server did not provide the header `Retry-After`.
+	RetryAfter time.Duration
+}
+
+// ShouldRenewAt determines the optimal renewal time based on the current time (UTC), renewal window suggested by ARI, and the client's willingness to sleep.
+// It returns a pointer to a time.Time value indicating when the renewal should be attempted or nil if deferred until the next normal wake time.
+// This method implements the RECOMMENDED algorithm described in draft-ietf-acme-ari.
+//
+// - (4.1-11. Getting Renewal Information) https://datatracker.ietf.org/doc/draft-ietf-acme-ari/
+func (r *RenewalInfoResponse) ShouldRenewAt(now time.Time, willingToSleep time.Duration) *time.Time {
+	// Explicitly convert all times to UTC.
+	now = now.UTC()
+	start := r.SuggestedWindow.Start.UTC()
+	end := r.SuggestedWindow.End.UTC()
+
+	// Select a uniform random time within the suggested window.
+	window := end.Sub(start)
+	randomDuration := time.Duration(0)
+	if window > 0 {
+		randomDuration = time.Duration(rand.Int63n(int64(window)))
+	}
+	rt := start.Add(randomDuration)
+
+	// If the selected time is in the past, attempt renewal immediately.
+	if rt.Before(now) {
+		return &now
+	}
+
+	// Otherwise, if the client can schedule itself to attempt renewal at exactly the selected time, do so.
+	willingToSleepUntil := now.Add(willingToSleep)
+	if willingToSleepUntil.After(rt) || willingToSleepUntil.Equal(rt) {
+		return &rt
+	}
+
+	// Otherwise, sleep until the next normal wake time, re-check ARI, and return to Step 1.
+	return nil
+}
+
+// GetRenewalInfo sends a request to the ACME server's renewalInfo endpoint to obtain a suggested renewal window.
+// The caller MUST provide the certificate they wish to renew.
+//
+// Note: this endpoint is part of a draft specification, not all ACME servers will implement it.
+// This method will return api.ErrNoARI if the server does not advertise a renewal info endpoint.
+//
+// https://datatracker.ietf.org/doc/draft-ietf-acme-ari
+func (c *Certifier) GetRenewalInfo(req RenewalInfoRequest) (*RenewalInfoResponse, error) {
+	certID, err := MakeARICertID(req.Cert)
+	if err != nil {
+		return nil, fmt.Errorf("error making certID: %w", err)
+	}
+
+	info, err := c.core.RenewalInfo.Get(certID)
+	if err != nil {
+		return nil, err
+	}
+
+	return &RenewalInfoResponse{RenewalInfo: info.RenewalInfo, RetryAfter: info.RetryAfter}, nil
+}
+
+// MakeARICertID constructs a certificate identifier as described in draft-ietf-acme-ari-03, section 4.1.
+// The identifier is the base64url encoding of the key identifier of the authority key identifier extension,
+// and the base64url encoding of the DER encoded serial number, separated by a dot.
+func MakeARICertID(leaf *x509.Certificate) (string, error) {
+	if leaf == nil {
+		return "", errors.New("leaf certificate is nil")
+	}
+
+	if len(leaf.AuthorityKeyId) == 0 {
+		return "", errors.New("missing AuthorityKeyId")
+	}
+
+	if leaf.SerialNumber == nil {
+		return "", errors.New("missing SerialNumber")
+	}
+
+	// the serial number is a positive integer:
+	// its DER encoding is prefixed by a 0x00 byte when the most significant bit is set.
+	serial := leaf.SerialNumber.Bytes()
+	if len(serial) == 0 || serial[0]&0x80 != 0 {
+		serial = append([]byte{0x00}, serial...)
+	}
+
+	return fmt.Sprintf("%s.%s",
+		base64.RawURLEncoding.EncodeToString(leaf.AuthorityKeyId),
+		base64.RawURLEncoding.EncodeToString(serial),
+	), nil
+}
@@ -0,0 +1,194 @@
+package certificate
+
+import (
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"math/big"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/go-acme/lego/v4/acme"
+	"github.com/go-acme/lego/v4/acme/api"
+	"github.com/go-acme/lego/v4/certcrypto"
+	"github.com/go-acme/lego/v4/platform/tester"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// ariLeaf the certificate of the example of draft-ietf-acme-ari-03, section 4.1.
+func ariLeaf() *x509.Certificate {
+	return &x509.Certificate{
+		AuthorityKeyId: []byte{0x69, 0x88, 0x5B, 0x6B, 0x87, 0x46, 0x40, 0x41, 0xE1, 0xB3, 0x7B, 0x84, 0x7B, 0xA0, 0xAE, 0x2C, 0xDE, 0x01, 0xC8, 0xD4},
+		SerialNumber:   big.NewInt(0x87654321),
+	}
+}
+
+func TestMakeARICertID(t *testing.T) {
+	certID, err := MakeARICertID(ariLeaf())
+	require.NoError(t, err)
+
+	assert.Equal(t, "aYhba4dGQEHhs3uEe6CuLN4ByNQ.AIdlQyE", certID)
+}
+
+func TestMakeARICertID_errors(t *testing.T) {
+	testCases := []struct {
+		desc     string
+		leaf     *x509.Certificate
+		expected string
+	}{
+		{
+			desc:     "nil certificate",
+			expected: "leaf certificate is nil",
+		},
+		{
+			desc:     "missing AuthorityKeyId",
+			leaf:     &x509.Certificate{SerialNumber: big.NewInt(1)},
+			expected: "missing AuthorityKeyId",
+		},
+		{
+			desc:     "missing SerialNumber",
+			leaf:     &x509.Certificate{AuthorityKeyId: []byte{0x01}},
+			expected: "missing SerialNumber",
+		},
+	}
+
+	for _, test := range testCases {
+		test := test
+		t.Run(test.desc, func(t *testing.T) {
+			t.Parallel()
+
+			_, err := MakeARICertID(test.leaf)
+			require.EqualError(t, err, test.expected)
+		})
+	}
+}
+
+func TestRenewalInfoResponse_ShouldRenewAt(t *testing.T) {
+	now := time.Date(2023, time.January, 1, 0, 0, 0, 0, time.UTC)
+
+	testCases := []struct {
+		desc           string
+		window         acme.Window
+		willingToSleep time.Duration
+		assert         func(t *testing.T, at *time.Time)
+	}{
+		{
+			desc:   "window in the past",
+			window: acme.Window{Start: now.Add(-48 * time.Hour), End: now.Add(-24 * time.Hour)},
+			assert: func(t *testing.T, at *time.Time) {
+				t.Helper()
+				require.NotNil(t, at)
+				assert.Equal(t, now, *at)
+			},
+		},
+		{
+			desc:           "window in the future, willing to sleep until the end",
+			window:         acme.Window{Start: now.Add(time.Hour), End: now.Add(2 * time.Hour)},
+			willingToSleep: 2 * time.Hour,
+			assert: func(t *testing.T, at *time.Time) {
+				t.Helper()
+				require.NotNil(t, at)
+				assert.False(t, at.Before(now.Add(time.Hour)))
+				assert.False(t, at.After(now.Add(2*time.Hour)))
+			},
+		},
+		{
+			desc:           "window in the future, not willing to sleep",
+			window:         acme.Window{Start: now.Add(24 * time.Hour), End: now.Add(48 * time.Hour)},
+			willingToSleep: time.Hour,
+			assert: func(t *testing.T, at *time.Time) {
+				t.Helper()
+				assert.Nil(t, at)
+			},
+		},
+	}
+
+	for _, test := range testCases {
+		test := test
+		t.Run(test.desc, func(t *testing.T) {
+			t.Parallel()
+
+			resp := RenewalInfoResponse{RenewalInfo: acme.RenewalInfo{SuggestedWindow: test.window}}
+
+			test.assert(t, resp.ShouldRenewAt(now, test.willingToSleep))
+		})
+	}
+}
+
+func TestCertifier_GetRenewalInfo(t *testing.T) {
+	mux, apiURL, tearDown := tester.SetupFakeAPI()
+	defer tearDown()
+
+	mux.HandleFunc("/renewalInfo/aYhba4dGQEHhs3uEe6CuLN4ByNQ.AIdlQyE", func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodGet {
+			http.Error(w, http.StatusText(http.StatusMethodNotAllowed), http.StatusMethodNotAllowed)
+			return
+		}
+
+		w.Header().Set("Retry-After", "21600")
+
+		err := tester.WriteJSONResponse(w, acme.RenewalInfo{
+			SuggestedWindow: acme.Window{
+				Start: time.Date(2023, time.January, 2, 0, 0, 0, 0, time.UTC),
+				End:   time.Date(2023, time.January, 3, 0, 0, 0, 0, time.UTC),
+			},
+			ExplanationURL: "https://example.com/docs/ari",
+		})
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+		}
+	})
+
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err, "Could not generate test key")
+
+	core, err := api.New(http.DefaultClient, "lego-test", apiURL+"/dir", "", key)
+	require.NoError(t, err)
+
+	certifier := NewCertifier(core, &resolverMock{}, CertifierOptions{KeyType: certcrypto.RSA2048})
+
+	resp, err := certifier.GetRenewalInfo(RenewalInfoRequest{Cert: ariLeaf()})
+	require.NoError(t, err)
+
+	expected := &RenewalInfoResponse{
+		RenewalInfo: acme.RenewalInfo{
+			SuggestedWindow: acme.Window{
+				Start: time.Date(2023, time.January, 2, 0, 0, 0, 0, time.UTC),
+				End:   time.Date(2023, time.January, 3, 0, 0, 0, 0, time.UTC),
+			},
+			ExplanationURL: "https://example.com/docs/ari",
+		},
+		RetryAfter: 6 * time.Hour,
+	}
+	assert.Equal(t, expected, resp)
+}
+
+func TestCertifier_GetRenewalInfo_retryAfterDate(t *testing.T) {
+	mux, apiURL, tearDown := tester.SetupFakeAPI()
+	defer tearDown()
+
+	mux.HandleFunc("/renewalInfo/aYhba4dGQEHhs3uEe6CuLN4ByNQ.AIdlQyE", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Retry-After", time.Now().Add(6*time.Hour).UTC().Format(http.TimeFormat))
+
+		err := tester.WriteJSONResponse(w, acme.RenewalInfo{})
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+		}
+	})
+
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err, "Could not generate test key")
+
+	core, err := api.New(http.DefaultClient, "lego-test", apiURL+"/dir", "", key)
+	require.NoError(t, err)
+
+	certifier := NewCertifier(core, &resolverMock{}, CertifierOptions{KeyType: certcrypto.RSA2048})
+
+	resp, err := certifier.GetRenewalInfo(RenewalInfoRequest{Cert: ariLeaf()})
+	require.NoError(t, err)
+
+	// the HTTP date has a precision of one second.
+	assert.InDelta(t, float64(6*time.Hour), float64(resp.RetryAfter), float64(2*time.Second))
+}
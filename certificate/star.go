@@ -0,0 +1,131 @@
+package certificate
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/go-acme/lego/v4/acme"
+	"github.com/go-acme/lego/v4/certcrypto"
+	"github.com/go-acme/lego/v4/log"
+)
+
+// starPollInterval the minimum interval between two requests to the URL of a STAR certificate.
+var starPollInterval = time.Minute
+
+// AutoRenewal the options of a STAR order (Short-Term, Automatically Renewed certificates).
+// The CA issues a new certificate before the expiration of the previous one, until the end date.
+//
+// https://www.rfc-editor.org/rfc/rfc8739
+type AutoRenewal struct {
+	// EndDate the latest date of validity of the last certificate.
+	EndDate time.Time
+
+	// Lifetime the maximum validity period of each certificate.
+	Lifetime time.Duration
+
+	// LifetimeAdjust the amount of "left pad" added to each certificate (optional).
+	LifetimeAdjust time.Duration
+
+	// AllowCertificateGet allows to fetch the certificates with unauthenticated GET requests (optional).
+	AllowCertificateGet bool
+}
+
+func (a *AutoRenewal) toACME() *acme.AutoRenewal {
+	if a == nil {
+		return nil
+	}
+
+	return &acme.AutoRenewal{
+		EndDate:             a.EndDate.UTC().Format(time.RFC3339),
+		Lifetime:            int(a.Lifetime.Seconds()),
+		LifetimeAdjust:      int(a.LifetimeAdjust.Seconds()),
+		AllowCertificateGet: a.AllowCertificateGet,
+	}
+}
+
+// CancelAutoRenewal cancels the auto-renewal of a STAR order.
+// The orderURL is the OrderURL of the Resource obtained with an AutoRenewal.
+func (c *Certifier) CancelAutoRenewal(orderURL string) error {
+	order, err := c.core.Orders.Cancel(orderURL)
+	if err != nil {
+		return err
+	}
+
+	if order.Status != acme.StatusCanceled {
+		log.Warnf("acme: the STAR order %s has the status %q after the cancellation", orderURL, order.Status)
+	}
+
+	return nil
+}
+
+// WatchStarCertificate polls the URL of the STAR certificate (the CertStableURL of a Resource obtained with an AutoRenewal),
+// and calls onRotated with each certificate rotated by the CA.
+//
+// The URL is requested again halfway through the validity period of the current certificate.
+// It returns nil when the auto-renewal is canceled or expired, or the error of the context when it's done.
+func (c *Certifier) WatchStarCertificate(ctx context.Context, certRes Resource, bundle bool, onRotated func(res *Resource)) error {
+	var serial string
+	if len(certRes.Certificate) > 0 {
+		x509Cert, err := certcrypto.ParsePEMCertificate(certRes.Certificate)
+		if err != nil {
+			return err
+		}
+
+		serial = x509Cert.SerialNumber.String()
+	}
+
+	for {
+		cert, issuer, err := c.withContext(ctx).core.Certificates.Get(certRes.CertStableURL, bundle)
+		if err != nil {
+			if isAutoRenewalEnded(err) {
+				log.Infof("[%s] acme: the auto-renewal of the STAR certificate has ended: %v", certRes.Domain, err)
+				return nil
+			}
+
+			return err
+		}
+
+		x509Cert, err := certcrypto.ParsePEMCertificate(cert)
+		if err != nil {
+			return err
+		}
+
+		if x509Cert.SerialNumber.String() != serial {
+			serial = x509Cert.SerialNumber.String()
+
+			log.Infof("[%s] acme: the STAR certificate has been rotated, valid until %s", certRes.Domain, x509Cert.NotAfter)
+
+			onRotated(&Resource{
+				Domain:            certRes.Domain,
+				CertURL:           certRes.CertStableURL,
+				CertStableURL:     certRes.CertStableURL,
+				OrderURL:          certRes.OrderURL,
+				PrivateKey:        certRes.PrivateKey,
+				Certificate:       cert,
+				IssuerCertificate: issuer,
+				CSR:               certRes.CSR,
+			})
+		}
+
+		next := time.Until(x509Cert.NotBefore.Add(x509Cert.NotAfter.Sub(x509Cert.NotBefore) / 2))
+		if next < starPollInterval {
+			next = starPollInterval
+		}
+
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-time.After(next):
+		}
+	}
+}
+
+func isAutoRenewalEnded(err error) bool {
+	var problem *acme.ProblemDetails
+	if !errors.As(err, &problem) {
+		return false
+	}
+
+	return problem.Type == acme.AutoRenewalCanceledErr || problem.Type == acme.AutoRenewalExpiredErr
+}
@@ -0,0 +1,121 @@
+package certificate
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/rsa"
+	"fmt"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/go-acme/lego/v4/acme"
+	"github.com/go-acme/lego/v4/acme/api"
+	"github.com/go-acme/lego/v4/certcrypto"
+	"github.com/go-acme/lego/v4/platform/tester"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAutoRenewal_toACME(t *testing.T) {
+	autoRenewal := &AutoRenewal{
+		EndDate:        time.Date(2026, time.November, 1, 12, 0, 0, 0, time.UTC),
+		Lifetime:       7 * 24 * time.Hour,
+		LifetimeAdjust: time.Hour,
+	}
+
+	expected := &acme.AutoRenewal{
+		EndDate:        "2026-11-01T12:00:00Z",
+		Lifetime:       604800,
+		LifetimeAdjust: 3600,
+	}
+	assert.Equal(t, expected, autoRenewal.toACME())
+
+	var nilAutoRenewal *AutoRenewal
+	assert.Nil(t, nilAutoRenewal.toACME())
+}
+
+func TestCertifier_WatchStarCertificate(t *testing.T) {
+	interval := starPollInterval
+	starPollInterval = time.Millisecond
+	defer func() { starPollInterval = interval }()
+
+	mux, apiURL, tearDown := tester.SetupFakeAPI()
+	defer tearDown()
+
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err, "Could not generate test key")
+
+	// the certificates are already expired: they are polled at the minimum interval.
+	first, err := certcrypto.GeneratePemCert(key, "example.com", nil)
+	require.NoError(t, err)
+	second, err := certcrypto.GeneratePemCert(key, "example.com", nil)
+	require.NoError(t, err)
+
+	var calls int
+	mux.HandleFunc("/star", func(w http.ResponseWriter, _ *http.Request) {
+		calls++
+
+		switch calls {
+		case 1:
+			_, _ = w.Write(first)
+		case 2, 3:
+			_, _ = w.Write(second)
+		default:
+			w.Header().Set("Content-Type", "application/problem+json")
+			w.WriteHeader(http.StatusForbidden)
+			_, _ = fmt.Fprintf(w, `{"type":%q,"status":403}`, acme.AutoRenewalExpiredErr)
+		}
+	})
+
+	core, err := api.New(http.DefaultClient, "lego-test", apiURL+"/dir", "", key)
+	require.NoError(t, err)
+
+	certifier := NewCertifier(core, &resolverMock{}, CertifierOptions{KeyType: certcrypto.RSA2048})
+
+	certRes := Resource{
+		Domain:        "example.com",
+		CertStableURL: apiURL + "/star",
+		OrderURL:      apiURL + "/order/1",
+		Certificate:   first,
+	}
+
+	var rotated []*Resource
+	err = certifier.WatchStarCertificate(context.Background(), certRes, false, func(res *Resource) {
+		rotated = append(rotated, res)
+	})
+	require.NoError(t, err)
+
+	assert.Equal(t, 4, calls)
+	require.Len(t, rotated, 1)
+	assert.Equal(t, second, rotated[0].Certificate)
+	assert.Equal(t, apiURL+"/star", rotated[0].CertURL)
+	assert.Equal(t, apiURL+"/order/1", rotated[0].OrderURL)
+}
+
+func TestCertifier_WatchStarCertificate_canceled(t *testing.T) {
+	mux, apiURL, tearDown := tester.SetupFakeAPI()
+	defer tearDown()
+
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err, "Could not generate test key")
+
+	cert, err := certcrypto.GeneratePemCert(key, "example.com", nil)
+	require.NoError(t, err)
+
+	mux.HandleFunc("/star", func(w http.ResponseWriter, _ *http.Request) {
+		_, _ = w.Write(cert)
+	})
+
+	core, err := api.New(http.DefaultClient, "lego-test", apiURL+"/dir", "", key)
+	require.NoError(t, err)
+
+	certifier := NewCertifier(core, &resolverMock{}, CertifierOptions{KeyType: certcrypto.RSA2048})
+
+	ctx, cancel := context.WithCancel(context.Background())
+
+	err = certifier.WatchStarCertificate(ctx, Resource{CertStableURL: apiURL + "/star"}, false, func(*Resource) {
+		cancel()
+	})
+	assert.Equal(t, context.Canceled, err)
+}
@@ -0,0 +1,51 @@
+package dns01
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/miekg/dns"
+)
+
+// ExpectTXTAnswerCount requires the TXT record to contain exactly count values
+// on all the authoritative nameservers before the end of the propagation check.
+// It is intended for the combined challenges (ex: example.com and *.example.com share the same TXT record):
+// the challenges are not validated while only some of the values have propagated.
+func ExpectTXTAnswerCount(count int) ChallengeOption {
+	return func(chlg *Challenge) error {
+		if count < 1 {
+			return fmt.Errorf("invalid TXT answer count: %d", count)
+		}
+
+		chlg.preCheck.expectedAnswers = count
+		return nil
+	}
+}
+
+// checkAnswerCount queries each of the given nameservers for the TXT record, and checks the number of values.
+func checkAnswerCount(fqdn string, count int, nameservers []string) (bool, error) {
+	for _, ns := range nameservers {
+		r, err := dnsQuery(fqdn, dns.TypeTXT, []string{withDefaultPort(ns)}, false)
+		if err != nil {
+			return false, queryError(ns, err)
+		}
+
+		if r.Rcode != dns.RcodeSuccess {
+			return false, rcodeError(ns, r.Rcode, fmt.Errorf("NS %s returned %s for %s", ns, dns.RcodeToString[r.Rcode], fqdn))
+		}
+
+		var records []string
+		for _, rr := range r.Answer {
+			if txt, ok := rr.(*dns.TXT); ok {
+				records = append(records, strings.Join(txt.Txt, ""))
+			}
+		}
+
+		if len(records) != count {
+			return false, recordsError(ns, records, fmt.Errorf("NS %s returned %d TXT records instead of %d [fqdn: %s]: %s",
+				ns, len(records), count, fqdn, strings.Join(records, " ,")))
+		}
+	}
+
+	return true, nil
+}
@@ -0,0 +1,159 @@
+package dns01
+
+import (
+	"crypto/rand"
+	"crypto/rsa"
+	"net/http"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/go-acme/lego/v4/acme"
+	"github.com/go-acme/lego/v4/acme/api"
+	"github.com/go-acme/lego/v4/challenge"
+	"github.com/go-acme/lego/v4/platform/tester"
+	"github.com/miekg/dns"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// growingAnswer returns a fakeResolver answering with the first value only for the first queries, then with all the values.
+func growingAnswer(lag int, values ...string) (fakeResolver, func() int) {
+	var mu sync.Mutex
+	var count int
+
+	resolver := func(req *dns.Msg) *dns.Msg {
+		mu.Lock()
+		count++
+		current := count
+		mu.Unlock()
+
+		if current <= lag {
+			return txtAnswer(values[0])(req)
+		}
+
+		return txtAnswer(values...)(req)
+	}
+
+	queries := func() int {
+		mu.Lock()
+		defer mu.Unlock()
+		return count
+	}
+
+	return resolver, queries
+}
+
+func TestExpectTXTAnswerCount_invalid(t *testing.T) {
+	chlg := &Challenge{}
+
+	err := ExpectTXTAnswerCount(0)(chlg)
+	require.EqualError(t, err, "invalid TXT answer count: 0")
+}
+
+func TestCheckAnswerCount(t *testing.T) {
+	one := startFakeDNSServer(t, "udp", txtAnswer("a"))
+	two := startFakeDNSServer(t, "udp", txtAnswer("a", "b"))
+
+	ok, err := checkAnswerCount("_acme-challenge.example.com.", 2, []string{two})
+	require.NoError(t, err)
+	assert.True(t, ok)
+
+	ok, err = checkAnswerCount("_acme-challenge.example.com.", 2, []string{two, one})
+	require.EqualError(t, err, "NS "+one+" returned 1 TXT records instead of 2 [fqdn: _acme-challenge.example.com.]: a")
+	assert.False(t, ok)
+
+	ok, err = checkAnswerCount("_acme-challenge.example.com.", 1, []string{two})
+	require.EqualError(t, err, "NS "+two+" returned 2 TXT records instead of 1 [fqdn: _acme-challenge.example.com.]: a ,b")
+	assert.False(t, ok)
+}
+
+func TestChallenge_Solve_expectTXTAnswerCount(t *testing.T) {
+	_, apiURL, tearDown := tester.SetupFakeAPI()
+	defer tearDown()
+
+	privateKey, err := rsa.GenerateKey(rand.Reader, 512)
+	require.NoError(t, err)
+
+	core, err := api.New(http.DefaultClient, "lego-test", apiURL+"/dir", "", privateKey)
+	require.NoError(t, err)
+
+	keyAuth, err := core.GetKeyAuthorization("token")
+	require.NoError(t, err)
+
+	_, value := GetRecord("example.com", keyAuth)
+
+	// the value of the wildcard challenge propagates later.
+	resolver, queries := growingAnswer(4, value, "wildcard")
+
+	provider := &providerNameserversMock{
+		providerTimeoutMock: providerTimeoutMock{timeout: 2 * time.Second, interval: 10 * time.Millisecond},
+		nameservers:         []string{startFakeDNSServer(t, "udp", resolver)},
+	}
+
+	var validated bool
+	validate := func(_ *api.Core, _ string, _ acme.Challenge) error {
+		validated = true
+		return nil
+	}
+
+	chlg := NewChallenge(core, validate, provider, ExpectTXTAnswerCount(2))
+
+	authz := acme.Authorization{
+		Identifier: acme.Identifier{
+			Value: "example.com",
+		},
+		Challenges: []acme.Challenge{
+			{Type: challenge.DNS01.String(), Token: "token"},
+		},
+	}
+
+	err = chlg.Solve(authz)
+	require.NoError(t, err)
+
+	assert.True(t, validated)
+
+	// each attempt queries the nameserver twice (the value, then the count): the third attempt returns the two values.
+	assert.Equal(t, 6, queries())
+}
+
+func TestChallenge_Solve_expectTXTAnswerCount_timeout(t *testing.T) {
+	_, apiURL, tearDown := tester.SetupFakeAPI()
+	defer tearDown()
+
+	privateKey, err := rsa.GenerateKey(rand.Reader, 512)
+	require.NoError(t, err)
+
+	core, err := api.New(http.DefaultClient, "lego-test", apiURL+"/dir", "", privateKey)
+	require.NoError(t, err)
+
+	keyAuth, err := core.GetKeyAuthorization("token")
+	require.NoError(t, err)
+
+	_, value := GetRecord("example.com", keyAuth)
+
+	provider := &providerNameserversMock{
+		providerTimeoutMock: providerTimeoutMock{timeout: 200 * time.Millisecond, interval: 10 * time.Millisecond},
+		nameservers:         []string{startFakeDNSServer(t, "udp", txtAnswer(value))},
+	}
+
+	validate := func(_ *api.Core, _ string, _ acme.Challenge) error {
+		t.Fatal("the challenge must not be validated")
+		return nil
+	}
+
+	chlg := NewChallenge(core, validate, provider, ExpectTXTAnswerCount(2))
+
+	authz := acme.Authorization{
+		Identifier: acme.Identifier{
+			Value: "example.com",
+		},
+		Challenges: []acme.Challenge{
+			{Type: challenge.DNS01.String(), Token: "token"},
+		},
+	}
+
+	err = chlg.Solve(authz)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "returned 1 TXT records instead of 2")
+}
@@ -0,0 +1,79 @@
+package dns01
+
+import (
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+)
+
+// ErrCircuitBreakerOpen is returned when the calls to the provider are short-circuited.
+var ErrCircuitBreakerOpen = errors.New("circuit breaker is open")
+
+// AddCircuitBreaker short-circuits the calls to the provider (Present and CleanUp)
+// after threshold consecutive failures.
+// The calls are allowed again after the cooldown:
+// a success closes the circuit, a failure opens it for another cooldown.
+func AddCircuitBreaker(threshold int, cooldown time.Duration) ChallengeOption {
+	return func(chlg *Challenge) error {
+		if threshold <= 0 {
+			return fmt.Errorf("invalid circuit breaker threshold: %d", threshold)
+		}
+
+		chlg.breaker = newCircuitBreaker(threshold, cooldown)
+		return nil
+	}
+}
+
+type circuitBreaker struct {
+	threshold int
+	cooldown  time.Duration
+
+	mu       sync.Mutex
+	failures int
+	openedAt time.Time
+
+	// used to control the time in tests.
+	now func() time.Time
+}
+
+func newCircuitBreaker(threshold int, cooldown time.Duration) *circuitBreaker {
+	return &circuitBreaker{
+		threshold: threshold,
+		cooldown:  cooldown,
+		now:       time.Now,
+	}
+}
+
+// call calls fn if the circuit is closed, or if the cooldown is over.
+func (b *circuitBreaker) call(fn func() error) error {
+	if b == nil {
+		return fn()
+	}
+
+	b.mu.Lock()
+	if b.failures >= b.threshold {
+		if remaining := b.cooldown - b.now().Sub(b.openedAt); remaining > 0 {
+			b.mu.Unlock()
+			return fmt.Errorf("%w: %d consecutive failures, retry in %s", ErrCircuitBreakerOpen, b.failures, remaining.Round(time.Second))
+		}
+	}
+	b.mu.Unlock()
+
+	err := fn()
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if err == nil {
+		b.failures = 0
+		return nil
+	}
+
+	b.failures++
+	if b.failures >= b.threshold {
+		b.openedAt = b.now()
+	}
+
+	return err
+}
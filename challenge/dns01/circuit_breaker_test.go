@@ -0,0 +1,113 @@
+package dns01
+
+import (
+	"crypto/rand"
+	"crypto/rsa"
+	"errors"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/go-acme/lego/v4/acme"
+	"github.com/go-acme/lego/v4/acme/api"
+	"github.com/go-acme/lego/v4/challenge"
+	"github.com/go-acme/lego/v4/platform/tester"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type providerCountMock struct {
+	present error
+	calls   int
+}
+
+func (p *providerCountMock) Present(domain, token, keyAuth string) error {
+	p.calls++
+	return p.present
+}
+
+func (p *providerCountMock) CleanUp(domain, token, keyAuth string) error { return nil }
+
+func Test_circuitBreaker(t *testing.T) {
+	now := time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	breaker := newCircuitBreaker(2, time.Minute)
+	breaker.now = func() time.Time { return now }
+
+	var calls int
+	failure := func() error {
+		calls++
+		return errors.New("OOPS")
+	}
+	success := func() error {
+		calls++
+		return nil
+	}
+
+	// closed: the errors are returned as is.
+	require.EqualError(t, breaker.call(failure), "OOPS")
+	require.EqualError(t, breaker.call(failure), "OOPS")
+	assert.Equal(t, 2, calls)
+
+	// open: the calls are short-circuited.
+	err := breaker.call(success)
+	require.True(t, errors.Is(err, ErrCircuitBreakerOpen))
+	assert.Equal(t, 2, calls)
+
+	// cooldown over, the next call fails: open again.
+	now = now.Add(time.Minute)
+	require.EqualError(t, breaker.call(failure), "OOPS")
+	assert.Equal(t, 3, calls)
+
+	err = breaker.call(success)
+	require.True(t, errors.Is(err, ErrCircuitBreakerOpen))
+	assert.Equal(t, 3, calls)
+
+	// cooldown over, the next call succeeds: closed.
+	now = now.Add(time.Minute)
+	require.NoError(t, breaker.call(success))
+	assert.Equal(t, 4, calls)
+
+	require.EqualError(t, breaker.call(failure), "OOPS")
+	require.NoError(t, breaker.call(success))
+	assert.Equal(t, 6, calls)
+}
+
+func Test_circuitBreaker_nil(t *testing.T) {
+	var breaker *circuitBreaker
+
+	require.EqualError(t, breaker.call(func() error { return errors.New("OOPS") }), "OOPS")
+	require.NoError(t, breaker.call(func() error { return nil }))
+}
+
+func TestChallenge_PreSolve_circuitBreaker(t *testing.T) {
+	_, apiURL, tearDown := tester.SetupFakeAPI()
+	defer tearDown()
+
+	privateKey, err := rsa.GenerateKey(rand.Reader, 512)
+	require.NoError(t, err)
+
+	core, err := api.New(http.DefaultClient, "lego-test", apiURL+"/dir", "", privateKey)
+	require.NoError(t, err)
+
+	provider := &providerCountMock{present: errors.New("OOPS")}
+
+	chlg := NewChallenge(core, nil, provider, AddCircuitBreaker(2, time.Hour))
+
+	authz := acme.Authorization{
+		Identifier: acme.Identifier{
+			Value: "example.com",
+		},
+		Challenges: []acme.Challenge{
+			{Type: challenge.DNS01.String()},
+		},
+	}
+
+	for i := 0; i < 5; i++ {
+		err = chlg.PreSolve(authz)
+		require.Error(t, err)
+	}
+
+	assert.Equal(t, 2, provider.calls)
+	assert.True(t, errors.Is(err, ErrCircuitBreakerOpen))
+}
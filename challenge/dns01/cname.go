@@ -1,6 +1,11 @@
 package dns01
 
-import "github.com/miekg/dns"
+import (
+	"fmt"
+	"strings"
+
+	"github.com/miekg/dns"
+)
 
 // Update FQDN with CNAME if any.
 func updateDomainWithCName(r *dns.Msg, fqdn string) string {
@@ -14,3 +19,50 @@ func updateDomainWithCName(r *dns.Msg, fqdn string) string {
 
 	return fqdn
 }
+
+// FollowCNAME returns the target of the CNAME records of the fqdn, queried through the recursive nameservers.
+// The CNAME chain of the answer is followed.
+// The fqdn is returned unchanged when it is not a CNAME, when the query fails, or when the chain is a loop.
+func FollowCNAME(fqdn string) string {
+	r, err := dnsQuery(fqdn, dns.TypeCNAME, recursiveNameservers, true)
+	if err != nil || r.Rcode != dns.RcodeSuccess {
+		return fqdn
+	}
+
+	seen := map[string]bool{fqdn: true}
+
+	target := fqdn
+	for {
+		next := updateDomainWithCName(r, target)
+		if next == target {
+			return target
+		}
+
+		if seen[next] {
+			return fqdn
+		}
+
+		seen[next] = true
+		target = next
+	}
+}
+
+// checkCNAMETarget verifies that the zone of the TXT record is managed by the provider,
+// when the challenge name is redirected by a CNAME (LEGO_EXPERIMENTAL_CNAME_SUPPORT).
+func checkCNAMETarget(domain, fqdn string, lister ZoneLister) error {
+	challengeFqdn := fmt.Sprintf("_acme-challenge.%s.", domain)
+	if strings.EqualFold(fqdn, challengeFqdn) {
+		return nil
+	}
+
+	zones, err := lister.Zones()
+	if err != nil {
+		return fmt.Errorf("could not get the zones of the provider: %w", err)
+	}
+
+	if FindMostSpecificZone(fqdn, zones) == "" {
+		return fmt.Errorf("%s is a CNAME to %s which is not in a zone managed by the provider", challengeFqdn, fqdn)
+	}
+
+	return nil
+}
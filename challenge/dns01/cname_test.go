@@ -0,0 +1,170 @@
+package dns01
+
+import (
+	"crypto/rand"
+	"crypto/rsa"
+	"net/http"
+	"os"
+	"testing"
+
+	"github.com/go-acme/lego/v4/acme"
+	"github.com/go-acme/lego/v4/acme/api"
+	"github.com/go-acme/lego/v4/challenge"
+	"github.com/go-acme/lego/v4/platform/tester"
+	"github.com/miekg/dns"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type providerCountZonesMock struct {
+	providerCountMock
+	zones []string
+}
+
+func (p *providerCountZonesMock) Zones() ([]string, error) { return p.zones, nil }
+
+func Test_checkCNAMETarget(t *testing.T) {
+	testCases := []struct {
+		desc     string
+		domain   string
+		fqdn     string
+		zones    []string
+		expected string
+	}{
+		{
+			desc:   "no CNAME",
+			domain: "www.example.com",
+			fqdn:   "_acme-challenge.www.example.com.",
+			zones:  []string{"example.org"},
+		},
+		{
+			desc:   "CNAME to a managed zone",
+			domain: "www.example.com",
+			fqdn:   "_acme-challenge.example.net.",
+			zones:  []string{"example.com", "example.net"},
+		},
+		{
+			desc:   "CNAME to a managed sub-zone",
+			domain: "www.example.com",
+			fqdn:   "www.acme.example.net.",
+			zones:  []string{"acme.example.net."},
+		},
+		{
+			desc:     "CNAME to an unmanaged zone",
+			domain:   "www.example.com",
+			fqdn:     "_acme-challenge.example.net.",
+			zones:    []string{"example.com"},
+			expected: "_acme-challenge.www.example.com. is a CNAME to _acme-challenge.example.net. which is not in a zone managed by the provider",
+		},
+	}
+
+	for _, test := range testCases {
+		t.Run(test.desc, func(t *testing.T) {
+			err := checkCNAMETarget(test.domain, test.fqdn, &providerZonesMock{zones: test.zones})
+			if test.expected == "" {
+				require.NoError(t, err)
+			} else {
+				require.EqualError(t, err, test.expected)
+			}
+		})
+	}
+}
+
+func TestChallenge_PreSolve_cnameUnmanagedZone(t *testing.T) {
+	_, apiURL, tearDown := tester.SetupFakeAPI()
+	defer tearDown()
+
+	privateKey, err := rsa.GenerateKey(rand.Reader, 512)
+	require.NoError(t, err)
+
+	core, err := api.New(http.DefaultClient, "lego-test", apiURL+"/dir", "", privateKey)
+	require.NoError(t, err)
+
+	addr := startFakeDNSServer(t, "udp", func(req *dns.Msg) *dns.Msg {
+		m := new(dns.Msg)
+		m.SetReply(req)
+		m.Answer = append(m.Answer, &dns.CNAME{
+			Hdr:    dns.RR_Header{Name: req.Question[0].Name, Rrtype: dns.TypeCNAME, Class: dns.ClassINET, Ttl: 0},
+			Target: "_acme-challenge.example.net.",
+		})
+		return m
+	})
+
+	backup := recursiveNameservers
+	defer func() { recursiveNameservers = backup }()
+
+	defer os.Unsetenv("LEGO_EXPERIMENTAL_CNAME_SUPPORT")
+	os.Setenv("LEGO_EXPERIMENTAL_CNAME_SUPPORT", "true")
+
+	provider := &providerCountZonesMock{zones: []string{"example.com"}}
+
+	chlg := NewChallenge(core, nil, provider, AddRecursiveNameservers([]string{addr}))
+
+	authz := acme.Authorization{
+		Identifier: acme.Identifier{
+			Value: "www.example.com",
+		},
+		Challenges: []acme.Challenge{
+			{Type: challenge.DNS01.String()},
+		},
+	}
+
+	err = chlg.PreSolve(authz)
+	require.EqualError(t, err, "[www.example.com] acme: _acme-challenge.www.example.com. is a CNAME to _acme-challenge.example.net. which is not in a zone managed by the provider")
+
+	assert.Equal(t, 0, provider.calls)
+}
+
+func TestFollowCNAME(t *testing.T) {
+	testCases := []struct {
+		desc     string
+		answers  []dns.RR
+		expected string
+	}{
+		{
+			desc:     "no CNAME",
+			expected: "_acme-challenge.example.com.",
+		},
+		{
+			desc: "CNAME",
+			answers: []dns.RR{
+				&dns.CNAME{Hdr: dns.RR_Header{Name: "_acme-challenge.example.com.", Rrtype: dns.TypeCNAME, Class: dns.ClassINET}, Target: "example.com.acme.example.net."},
+			},
+			expected: "example.com.acme.example.net.",
+		},
+		{
+			desc: "CNAME chain",
+			answers: []dns.RR{
+				&dns.CNAME{Hdr: dns.RR_Header{Name: "_acme-challenge.example.com.", Rrtype: dns.TypeCNAME, Class: dns.ClassINET}, Target: "_acme-challenge.example.org."},
+				&dns.CNAME{Hdr: dns.RR_Header{Name: "_acme-challenge.example.org.", Rrtype: dns.TypeCNAME, Class: dns.ClassINET}, Target: "example.com.acme.example.net."},
+			},
+			expected: "example.com.acme.example.net.",
+		},
+		{
+			desc: "CNAME loop",
+			answers: []dns.RR{
+				&dns.CNAME{Hdr: dns.RR_Header{Name: "_acme-challenge.example.com.", Rrtype: dns.TypeCNAME, Class: dns.ClassINET}, Target: "_acme-challenge.example.org."},
+				&dns.CNAME{Hdr: dns.RR_Header{Name: "_acme-challenge.example.org.", Rrtype: dns.TypeCNAME, Class: dns.ClassINET}, Target: "_acme-challenge.example.com."},
+			},
+			expected: "_acme-challenge.example.com.",
+		},
+	}
+
+	for _, test := range testCases {
+		t.Run(test.desc, func(t *testing.T) {
+			addr := startFakeDNSServer(t, "udp", func(req *dns.Msg) *dns.Msg {
+				m := new(dns.Msg)
+				m.SetReply(req)
+				m.Answer = test.answers
+				return m
+			})
+
+			backup := recursiveNameservers
+			defer func() { recursiveNameservers = backup }()
+
+			recursiveNameservers = []string{addr}
+
+			assert.Equal(t, test.expected, FollowCNAME("_acme-challenge.example.com."))
+		})
+	}
+}
@@ -1,19 +1,22 @@
 package dns01
 
 import (
+	"context"
 	"crypto/sha256"
 	"encoding/base64"
+	"errors"
 	"fmt"
 	"os"
 	"strconv"
+	"strings"
 	"time"
 
 	"github.com/go-acme/lego/v4/acme"
 	"github.com/go-acme/lego/v4/acme/api"
 	"github.com/go-acme/lego/v4/challenge"
 	"github.com/go-acme/lego/v4/log"
+	"github.com/go-acme/lego/v4/platform/config/env"
 	"github.com/go-acme/lego/v4/platform/wait"
-	"github.com/miekg/dns"
 )
 
 const (
@@ -27,6 +30,9 @@ const (
 	DefaultTTL = 120
 )
 
+// envPollInitialDelay the delay (in seconds) before the first propagation check.
+const envPollInitialDelay = "LEGO_POLL_INITIAL_DELAY"
+
 type ValidateFunc func(core *api.Core, domain string, chlng acme.Challenge) error
 
 type ChallengeOption func(*Challenge) error
@@ -49,6 +55,20 @@ type Challenge struct {
 	provider   challenge.Provider
 	preCheck   preCheck
 	dnsTimeout time.Duration
+
+	crossCheckZones bool
+	breaker         *circuitBreaker
+	initialDelay    time.Duration
+	emit            EventEmitter
+	txtMaxLength    int
+	view            string
+	progress        PropagationProgressFunc
+
+	probeNegativeTTL   bool
+	verifyStoredRecord bool
+	strictDelegation   bool
+
+	recordSuffix string
 }
 
 func NewChallenge(core *api.Core, validate ValidateFunc, provider challenge.Provider, opts ...ChallengeOption) *Challenge {
@@ -58,6 +78,11 @@ func NewChallenge(core *api.Core, validate ValidateFunc, provider challenge.Prov
 		provider:   provider,
 		preCheck:   newPreCheck(),
 		dnsTimeout: 10 * time.Second,
+
+		initialDelay:     env.GetOrDefaultSecond(envPollInitialDelay, 0),
+		strictDelegation: env.GetOrDefaultBool(envStrictDelegation, false),
+		emit:             noopEventEmitter,
+		progress:         noopPropagationProgress,
 	}
 
 	for _, opt := range opts {
@@ -70,6 +95,40 @@ func NewChallenge(core *api.Core, validate ValidateFunc, provider challenge.Prov
 	return chlg
 }
 
+// AddPropagationInitialDelay defines the delay before the first DNS propagation check.
+// By default, the first check is done after the polling interval.
+// The delay can also be defined with the environment variable LEGO_POLL_INITIAL_DELAY (in seconds).
+func AddPropagationInitialDelay(delay time.Duration) ChallengeOption {
+	return func(chlg *Challenge) error {
+		chlg.initialDelay = delay
+		return nil
+	}
+}
+
+// AddRecordSuffix defines a suffix appended to the name of the challenge records (ex: a delegation zone).
+// The TXT record of `example.com` is then `_acme-challenge.example.com.<suffix>.`,
+// and `_acme-challenge.example.com` must be a CNAME to this name.
+func AddRecordSuffix(suffix string) ChallengeOption {
+	return func(chlg *Challenge) error {
+		suffix = strings.Trim(suffix, ".")
+		if suffix == "" {
+			return errors.New("invalid record suffix: empty")
+		}
+
+		chlg.recordSuffix = suffix
+		return nil
+	}
+}
+
+// recordDomain returns the domain given to the provider and used to compute the name of the challenge record.
+func (c *Challenge) recordDomain(domain string) string {
+	if c.recordSuffix == "" {
+		return domain
+	}
+
+	return domain + "." + c.recordSuffix
+}
+
 // PreSolve just submits the txt record to the dns provider.
 // It does not validate record propagation, or do anything at all with the acme server.
 func (c *Challenge) PreSolve(authz acme.Authorization) error {
@@ -85,21 +144,77 @@ func (c *Challenge) PreSolve(authz acme.Authorization) error {
 		return fmt.Errorf("[%s] acme: no DNS Provider configured", domain)
 	}
 
+	err = c.selectView()
+	if err != nil {
+		return fmt.Errorf("[%s] acme: %w", domain, err)
+	}
+
 	// Generate the Key Authorization for the challenge
 	keyAuth, err := c.core.GetKeyAuthorization(chlng.Token)
 	if err != nil {
 		return err
 	}
 
-	err = c.provider.Present(authz.Identifier.Value, chlng.Token, keyAuth)
+	recordDomain := c.recordDomain(authz.Identifier.Value)
+
+	fqdn, value := GetRecord(recordDomain, keyAuth)
+
+	if c.txtMaxLength > 0 {
+		err = CheckTXTValueLength(value, c.txtMaxLength)
+		if err != nil {
+			return fmt.Errorf("[%s] acme: %w", domain, err)
+		}
+	}
+
+	if lister, ok := c.provider.(ZoneLister); ok {
+		err = checkCNAMETarget(recordDomain, fqdn, lister)
+		if err != nil {
+			return fmt.Errorf("[%s] acme: %w", domain, err)
+		}
+
+		if c.crossCheckZones {
+			if errC := crossCheckZone(fqdn, lister); errC != nil {
+				log.Warnf("[%s] acme: zone cross-check: %v", domain, errC)
+			}
+		}
+	}
+
+	if c.strictDelegation {
+		err = checkDelegation(fqdn, c.provider)
+		if err != nil {
+			return fmt.Errorf("[%s] acme: strict delegation: %w", domain, err)
+		}
+	}
+
+	c.emitEvent(EventPresentStarted, domain, fqdn, nil)
+
+	err = c.breaker.call(func() error {
+		return c.provider.Present(recordDomain, chlng.Token, keyAuth)
+	})
+
+	c.emitEvent(EventPresentDone, domain, fqdn, err)
+
 	if err != nil {
 		return fmt.Errorf("[%s] acme: error presenting token: %w", domain, err)
 	}
 
+	if reader, ok := c.provider.(StoredValuesReader); ok && c.verifyStoredRecord {
+		err = verifyStoredRecord(fqdn, value, reader)
+		if err != nil {
+			return fmt.Errorf("[%s] acme: %w", domain, err)
+		}
+	}
+
 	return nil
 }
 
 func (c *Challenge) Solve(authz acme.Authorization) error {
+	return c.SolveWithContext(context.Background(), authz)
+}
+
+// SolveWithContext waits for the propagation of the TXT record, then asks the CA to validate the challenge.
+// The propagation check and the validation stop when the context is done.
+func (c *Challenge) SolveWithContext(ctx context.Context, authz acme.Authorization) error {
 	domain := challenge.GetTargetedDomain(authz)
 	log.Infof("[%s] acme: Trying to solve DNS-01", domain)
 
@@ -114,7 +229,7 @@ func (c *Challenge) Solve(authz acme.Authorization) error {
 		return err
 	}
 
-	fqdn, value := GetRecord(authz.Identifier.Value, keyAuth)
+	fqdn, value := GetRecord(c.recordDomain(authz.Identifier.Value), keyAuth)
 
 	var timeout, interval time.Duration
 	switch provider := c.provider.(type) {
@@ -124,28 +239,80 @@ func (c *Challenge) Solve(authz acme.Authorization) error {
 		timeout, interval = DefaultPropagationTimeout, DefaultPollingInterval
 	}
 
-	log.Infof("[%s] acme: Checking DNS record propagation using %+v", domain, recursiveNameservers)
+	status, _ := c.provider.(PropagationStatusProvider)
+
+	var nameservers []string
+	// the nameservers of the zone known by the provider are not used to check the delegation by the parent zone.
+	if p, ok := c.provider.(NameserversProvider); ok && !c.preCheck.useDelegation {
+		nameservers = p.Nameservers(fqdn)
+	}
+
+	switch {
+	case status != nil && len(nameservers) > 0:
+		log.Infof("[%s] acme: Checking DNS record propagation using the provider API, then the nameservers of the zone %+v", domain, nameservers)
+	case status != nil:
+		log.Infof("[%s] acme: Checking DNS record propagation using the provider API", domain)
+	case len(nameservers) > 0:
+		log.Infof("[%s] acme: Checking DNS record propagation using the nameservers of the zone %+v", domain, nameservers)
+	default:
+		log.Infof("[%s] acme: Checking DNS record propagation using %+v", domain, recursiveNameservers)
+	}
+
+	if c.probeNegativeTTL {
+		c.reportNegativeTTL(domain, fqdn, timeout)
+	}
+
+	waitStart := time.Now()
+
+	delay := interval
+	if c.initialDelay > 0 {
+		log.Infof("[%s] acme: Waiting %s before the first DNS propagation check", domain, c.initialDelay)
+		delay = c.initialDelay
+	}
+
+	select {
+	case <-time.After(delay):
+	case <-ctx.Done():
+		c.core.Metrics().PropagationWait(time.Since(waitStart), false)
+		return fmt.Errorf("[%s] acme: %w", domain, ctx.Err())
+	}
 
-	time.Sleep(interval)
+	start := time.Now()
+	var attempt int
+	var lastErr error
 
-	err = wait.For("propagation", timeout, interval, func() (bool, error) {
-		stop, errP := c.preCheck.call(domain, fqdn, value)
+	err = wait.ForWithContext(ctx, "propagation", timeout, interval, func() (bool, error) {
+		stop, errP := c.preCheck.call(ctx, domain, fqdn, value, nameservers, status)
 		if !stop || errP != nil {
 			log.Infof("[%s] acme: Waiting for DNS record propagation.", domain)
+		} else {
+			c.emitEvent(EventPropagationObserved, domain, fqdn, nil)
 		}
+
+		attempt++
+		c.progress(newPropagationProgress(domain, fqdn, attempt, time.Since(start), timeout, nameservers, stop, errP))
+
+		if errP != nil {
+			lastErr = errP
+		}
+
 		return stop, errP
 	})
+
+	c.core.Metrics().PropagationWait(time.Since(waitStart), err == nil)
+
 	if err != nil {
-		return err
+		return newPropagationError(domain, fqdn, value, lastErr, err)
 	}
 
 	chlng.KeyAuthorization = keyAuth
-	return c.validate(c.core, domain, chlng)
+	return c.validate(c.core.WithContext(ctx), domain, chlng)
 }
 
 // CleanUp cleans the challenge.
 func (c *Challenge) CleanUp(authz acme.Authorization) error {
-	log.Infof("[%s] acme: Cleaning DNS-01 challenge", challenge.GetTargetedDomain(authz))
+	domain := challenge.GetTargetedDomain(authz)
+	log.Infof("[%s] acme: Cleaning DNS-01 challenge", domain)
 
 	chlng, err := challenge.FindChallenge(challenge.DNS01, authz)
 	if err != nil {
@@ -157,7 +324,16 @@ func (c *Challenge) CleanUp(authz acme.Authorization) error {
 		return err
 	}
 
-	return c.provider.CleanUp(authz.Identifier.Value, chlng.Token, keyAuth)
+	recordDomain := c.recordDomain(authz.Identifier.Value)
+
+	err = c.breaker.call(func() error {
+		return c.provider.CleanUp(recordDomain, chlng.Token, keyAuth)
+	})
+
+	fqdn, _ := GetRecord(recordDomain, keyAuth)
+	c.emitEvent(EventCleanupDone, domain, fqdn, err)
+
+	return err
 }
 
 func (c *Challenge) Sequential() (bool, time.Duration) {
@@ -179,12 +355,35 @@ func GetRecord(domain, keyAuth string) (fqdn, value string) {
 	fqdn = fmt.Sprintf("_acme-challenge.%s.", domain)
 
 	if ok, _ := strconv.ParseBool(os.Getenv("LEGO_EXPERIMENTAL_CNAME_SUPPORT")); ok {
-		r, err := dnsQuery(fqdn, dns.TypeCNAME, recursiveNameservers, true)
 		// Check if the domain has CNAME then return that
-		if err == nil && r.Rcode == dns.RcodeSuccess {
-			fqdn = updateDomainWithCName(r, fqdn)
-		}
+		fqdn = FollowCNAME(fqdn)
 	}
 
 	return
 }
+
+// ChallengeInfo contains the details of the DNS record which will fulfill the `dns-01` challenge.
+type ChallengeInfo struct {
+	// FQDN the name of the record (ex: `_acme-challenge.example.com.`).
+	FQDN string
+	// Value the value of the record.
+	Value string
+	// Type the type of the record (always `TXT`).
+	Type string
+	// TTL the recommended TTL of the record, in seconds.
+	TTL int
+}
+
+// GetChallengeInfo returns the details of the DNS record which will fulfill the `dns-01` challenge.
+// It is intended for the DNS integrations not implemented as a Provider.
+// The FQDN is computed like GetRecord, the wildcard prefix of the domain (`*.`) is ignored.
+func GetChallengeInfo(domain, keyAuth string) ChallengeInfo {
+	fqdn, value := GetRecord(strings.TrimPrefix(domain, "*."), keyAuth)
+
+	return ChallengeInfo{
+		FQDN:  fqdn,
+		Value: value,
+		Type:  "TXT",
+		TTL:   DefaultTTL,
+	}
+}
@@ -5,6 +5,7 @@ import (
 	"crypto/rsa"
 	"errors"
 	"net/http"
+	"os"
 	"testing"
 	"time"
 
@@ -12,6 +13,7 @@ import (
 	"github.com/go-acme/lego/v4/acme/api"
 	"github.com/go-acme/lego/v4/challenge"
 	"github.com/go-acme/lego/v4/platform/tester"
+	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 )
 
@@ -284,3 +286,229 @@ func TestChallenge_CleanUp(t *testing.T) {
 		})
 	}
 }
+
+func TestChallenge_Solve_initialDelay(t *testing.T) {
+	_, apiURL, tearDown := tester.SetupFakeAPI()
+	defer tearDown()
+
+	privateKey, err := rsa.GenerateKey(rand.Reader, 512)
+	require.NoError(t, err)
+
+	core, err := api.New(http.DefaultClient, "lego-test", apiURL+"/dir", "", privateKey)
+	require.NoError(t, err)
+
+	testCases := []struct {
+		desc     string
+		envVar   string
+		option   ChallengeOption
+		expected time.Duration
+	}{
+		{
+			desc:     "default (polling interval)",
+			expected: 10 * time.Millisecond,
+		},
+		{
+			desc:     "option",
+			option:   AddPropagationInitialDelay(500 * time.Millisecond),
+			expected: 500 * time.Millisecond,
+		},
+		{
+			desc:     "environment variable",
+			envVar:   "1",
+			expected: 1 * time.Second,
+		},
+	}
+
+	for _, test := range testCases {
+		t.Run(test.desc, func(t *testing.T) {
+			if test.envVar != "" {
+				defer os.Unsetenv(envPollInitialDelay)
+				os.Setenv(envPollInitialDelay, test.envVar)
+			}
+
+			var firstCheck time.Time
+			preCheck := func(_, _, _ string, _ PreCheckFunc) (bool, error) {
+				if firstCheck.IsZero() {
+					firstCheck = time.Now()
+				}
+				return true, nil
+			}
+
+			options := []ChallengeOption{WrapPreCheck(preCheck)}
+			if test.option != nil {
+				options = append(options, test.option)
+			}
+
+			provider := &providerTimeoutMock{timeout: 5 * time.Second, interval: 10 * time.Millisecond}
+			validate := func(_ *api.Core, _ string, _ acme.Challenge) error { return nil }
+
+			chlg := NewChallenge(core, validate, provider, options...)
+
+			authz := acme.Authorization{
+				Identifier: acme.Identifier{
+					Value: "example.com",
+				},
+				Challenges: []acme.Challenge{
+					{Type: challenge.DNS01.String()},
+				},
+			}
+
+			start := time.Now()
+
+			err = chlg.Solve(authz)
+			require.NoError(t, err)
+
+			elapsed := firstCheck.Sub(start)
+			assert.GreaterOrEqual(t, int64(elapsed), int64(test.expected))
+			assert.Less(t, int64(elapsed), int64(test.expected+400*time.Millisecond))
+		})
+	}
+}
+
+func TestGetRecord(t *testing.T) {
+	fqdn, value := GetRecord("example.com", "token.key")
+
+	assert.Equal(t, "_acme-challenge.example.com.", fqdn)
+	assert.Equal(t, "BBQUgcxf5weD7GT5jGRqmNsvAZXUWBoqPngIzDdoBFs", value)
+}
+
+type providerDomainsMock struct {
+	presented, cleaned []string
+}
+
+func (p *providerDomainsMock) Present(domain, token, keyAuth string) error {
+	p.presented = append(p.presented, domain)
+	return nil
+}
+
+func (p *providerDomainsMock) CleanUp(domain, token, keyAuth string) error {
+	p.cleaned = append(p.cleaned, domain)
+	return nil
+}
+
+func TestAddRecordSuffix(t *testing.T) {
+	testCases := []struct {
+		desc     string
+		suffix   string
+		expected string
+		err      string
+	}{
+		{
+			desc:     "suffix",
+			suffix:   "acme-delegation.net",
+			expected: "acme-delegation.net",
+		},
+		{
+			desc:     "suffix with dots",
+			suffix:   ".acme-delegation.net.",
+			expected: "acme-delegation.net",
+		},
+		{
+			desc:   "empty",
+			suffix: ".",
+			err:    "invalid record suffix: empty",
+		},
+	}
+
+	for _, test := range testCases {
+		t.Run(test.desc, func(t *testing.T) {
+			chlg := &Challenge{}
+
+			err := AddRecordSuffix(test.suffix)(chlg)
+			if test.err != "" {
+				require.EqualError(t, err, test.err)
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, test.expected, chlg.recordSuffix)
+		})
+	}
+}
+
+func TestChallenge_recordSuffix(t *testing.T) {
+	_, apiURL, tearDown := tester.SetupFakeAPI()
+	defer tearDown()
+
+	privateKey, err := rsa.GenerateKey(rand.Reader, 512)
+	require.NoError(t, err)
+
+	core, err := api.New(http.DefaultClient, "lego-test", apiURL+"/dir", "", privateKey)
+	require.NoError(t, err)
+
+	provider := &providerDomainsMock{}
+
+	chlg := NewChallenge(core, nil, provider, AddRecordSuffix("acme-delegation.net"))
+
+	authz := acme.Authorization{
+		Identifier: acme.Identifier{
+			Value: "example.com",
+		},
+		Challenges: []acme.Challenge{
+			{Type: challenge.DNS01.String()},
+		},
+	}
+
+	err = chlg.PreSolve(authz)
+	require.NoError(t, err)
+
+	err = chlg.CleanUp(authz)
+	require.NoError(t, err)
+
+	assert.Equal(t, []string{"example.com.acme-delegation.net"}, provider.presented)
+	assert.Equal(t, []string{"example.com.acme-delegation.net"}, provider.cleaned)
+
+	fqdn, _ := GetRecord(chlg.recordDomain("example.com"), "token.key")
+	assert.Equal(t, "_acme-challenge.example.com.acme-delegation.net.", fqdn)
+}
+
+func TestGetChallengeInfo(t *testing.T) {
+	testCases := []struct {
+		desc     string
+		domain   string
+		keyAuth  string
+		expected ChallengeInfo
+	}{
+		{
+			desc:    "default",
+			domain:  "example.com",
+			keyAuth: "token.key",
+			expected: ChallengeInfo{
+				FQDN:  "_acme-challenge.example.com.",
+				Value: "BBQUgcxf5weD7GT5jGRqmNsvAZXUWBoqPngIzDdoBFs",
+				Type:  "TXT",
+				TTL:   120,
+			},
+		},
+		{
+			desc:    "wildcard",
+			domain:  "*.example.com",
+			keyAuth: "token.key",
+			expected: ChallengeInfo{
+				FQDN:  "_acme-challenge.example.com.",
+				Value: "BBQUgcxf5weD7GT5jGRqmNsvAZXUWBoqPngIzDdoBFs",
+				Type:  "TXT",
+				TTL:   120,
+			},
+		},
+		{
+			desc:    "subdomain",
+			domain:  "www.example.com",
+			keyAuth: "123d==",
+			expected: ChallengeInfo{
+				FQDN:  "_acme-challenge.www.example.com.",
+				Value: "ADw2sEd82DUgXcQ9hNBZThJs7zVJkR5v9JeSbAb9mZY",
+				Type:  "TXT",
+				TTL:   120,
+			},
+		},
+	}
+
+	for _, test := range testCases {
+		t.Run(test.desc, func(t *testing.T) {
+			info := GetChallengeInfo(test.domain, test.keyAuth)
+
+			assert.Equal(t, test.expected, info)
+		})
+	}
+}
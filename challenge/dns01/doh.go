@@ -0,0 +1,61 @@
+package dns01
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+
+	"github.com/miekg/dns"
+)
+
+const dohMediaType = "application/dns-message"
+
+// sendDoHQuery sends a DNS query over HTTPS (RFC 8484).
+func sendDoHQuery(ctx context.Context, m *dns.Msg, endpoint string) (*dns.Msg, error) {
+	// https://tools.ietf.org/html/rfc8484#section-4.1
+	msg := m.Copy()
+	msg.Id = 0
+
+	raw, err := msg.Pack()
+	if err != nil {
+		return nil, fmt.Errorf("failed to pack the DNS message: %w", err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, endpoint, bytes.NewReader(raw))
+	if err != nil {
+		return nil, err
+	}
+
+	req.Header.Set("Content-Type", dohMediaType)
+	req.Header.Set("Accept", dohMediaType)
+
+	client := &http.Client{Timeout: dnsTimeout}
+
+	resp, err := client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+
+	defer func() { _ = resp.Body.Close() }()
+
+	body, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return nil, err
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("DoH server %s returned %d: %s", endpoint, resp.StatusCode, string(body))
+	}
+
+	in := new(dns.Msg)
+	err = in.Unpack(body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to unpack the DNS response from %s: %w", endpoint, err)
+	}
+
+	in.Id = m.Id
+
+	return in, nil
+}
@@ -0,0 +1,87 @@
+package dns01
+
+import (
+	"errors"
+	"fmt"
+	"time"
+)
+
+// EventType the type of a challenge lifecycle event.
+type EventType int
+
+// Challenge lifecycle events.
+const (
+	// EventPresentStarted the TXT record is about to be created.
+	EventPresentStarted EventType = iota
+	// EventPresentDone the provider has created the TXT record (Event.Err is set on failure).
+	EventPresentDone
+	// EventPropagationObserved the TXT record has been found by the propagation check.
+	EventPropagationObserved
+	// EventCleanupDone the provider has removed the TXT record (Event.Err is set on failure).
+	EventCleanupDone
+)
+
+func (e EventType) String() string {
+	switch e {
+	case EventPresentStarted:
+		return "PresentStarted"
+	case EventPresentDone:
+		return "PresentDone"
+	case EventPropagationObserved:
+		return "PropagationObserved"
+	case EventCleanupDone:
+		return "CleanupDone"
+	default:
+		return fmt.Sprintf("EventType(%d)", int(e))
+	}
+}
+
+// Event a challenge lifecycle event.
+type Event struct {
+	Type   EventType
+	Domain string
+	FQDN   string
+	Err    error
+	Time   time.Time
+}
+
+// EventEmitter publishes the challenge lifecycle events.
+// It is called synchronously by the challenge, so it must not block.
+type EventEmitter func(event Event)
+
+// ChannelEventEmitter publishes the events on a channel.
+// The events are dropped if the channel is not ready to receive them,
+// a buffered channel should be used to receive all the events.
+func ChannelEventEmitter(ch chan<- Event) EventEmitter {
+	return func(event Event) {
+		select {
+		case ch <- event:
+		default:
+		}
+	}
+}
+
+// AddEventEmitter defines an emitter for the challenge lifecycle events.
+// By default, the events are not published.
+func AddEventEmitter(emitter EventEmitter) ChallengeOption {
+	return func(chlg *Challenge) error {
+		if emitter == nil {
+			return errors.New("event emitter is nil")
+		}
+
+		chlg.emit = emitter
+		return nil
+	}
+}
+
+func noopEventEmitter(Event) {}
+
+func (c *Challenge) emitEvent(eventType EventType, domain, fqdn string, err error) {
+	c.emit(Event{
+		Type:   eventType,
+		Domain: domain,
+		FQDN:   fqdn,
+		Err:    err,
+		Time:   time.Now(),
+	})
+}
@@ -0,0 +1,96 @@
+package dns01
+
+import (
+	"crypto/rand"
+	"crypto/rsa"
+	"errors"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/go-acme/lego/v4/acme"
+	"github.com/go-acme/lego/v4/acme/api"
+	"github.com/go-acme/lego/v4/challenge"
+	"github.com/go-acme/lego/v4/platform/tester"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestChallenge_events(t *testing.T) {
+	_, apiURL, tearDown := tester.SetupFakeAPI()
+	defer tearDown()
+
+	privateKey, err := rsa.GenerateKey(rand.Reader, 512)
+	require.NoError(t, err)
+
+	core, err := api.New(http.DefaultClient, "lego-test", apiURL+"/dir", "", privateKey)
+	require.NoError(t, err)
+
+	var checks int
+	preCheck := func(_, _, _ string, _ PreCheckFunc) (bool, error) {
+		checks++
+		return checks > 1, nil
+	}
+
+	events := make(chan Event, 10)
+
+	provider := &providerTimeoutMock{
+		cleanUp:  errors.New("OOPS"),
+		timeout:  time.Second,
+		interval: time.Millisecond,
+	}
+	validate := func(_ *api.Core, _ string, _ acme.Challenge) error { return nil }
+
+	chlg := NewChallenge(core, validate, provider, WrapPreCheck(preCheck), AddEventEmitter(ChannelEventEmitter(events)))
+
+	authz := acme.Authorization{
+		Identifier: acme.Identifier{
+			Value: "example.com",
+		},
+		Challenges: []acme.Challenge{
+			{Type: challenge.DNS01.String()},
+		},
+	}
+
+	err = chlg.PreSolve(authz)
+	require.NoError(t, err)
+
+	err = chlg.Solve(authz)
+	require.NoError(t, err)
+
+	err = chlg.CleanUp(authz)
+	require.EqualError(t, err, "OOPS")
+
+	close(events)
+
+	var types []EventType
+	for event := range events {
+		assert.Equal(t, "example.com", event.Domain)
+		assert.Equal(t, "_acme-challenge.example.com.", event.FQDN)
+		assert.False(t, event.Time.IsZero())
+
+		if event.Type == EventCleanupDone {
+			assert.EqualError(t, event.Err, "OOPS")
+		} else {
+			assert.NoError(t, event.Err)
+		}
+
+		types = append(types, event.Type)
+	}
+
+	expected := []EventType{EventPresentStarted, EventPresentDone, EventPropagationObserved, EventCleanupDone}
+	assert.Equal(t, expected, types)
+}
+
+func TestChannelEventEmitter_full(t *testing.T) {
+	events := make(chan Event, 1)
+
+	emit := ChannelEventEmitter(events)
+
+	// must not block.
+	emit(Event{Type: EventPresentStarted})
+	emit(Event{Type: EventPresentDone})
+
+	require.Len(t, events, 1)
+	assert.Equal(t, EventPresentStarted, (<-events).Type)
+}
@@ -0,0 +1,98 @@
+package dns01
+
+import (
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/go-acme/lego/v4/challenge"
+	"github.com/go-acme/lego/v4/log"
+)
+
+// FailoverProvider presents the TXT record with a primary provider,
+// and falls back to a secondary provider if the primary fails.
+// The records are removed by the provider that has presented them.
+type FailoverProvider struct {
+	primary   challenge.Provider
+	secondary challenge.Provider
+
+	handlers   map[string]challenge.Provider
+	handlersMu sync.Mutex
+}
+
+// NewFailoverProvider creates a FailoverProvider.
+func NewFailoverProvider(primary, secondary challenge.Provider) *FailoverProvider {
+	return &FailoverProvider{
+		primary:   primary,
+		secondary: secondary,
+		handlers:  make(map[string]challenge.Provider),
+	}
+}
+
+// Present creates the TXT record with the primary provider, or with the secondary provider if the primary fails.
+func (f *FailoverProvider) Present(domain, token, keyAuth string) error {
+	handler := f.primary
+
+	err := f.primary.Present(domain, token, keyAuth)
+	if err != nil {
+		log.Warnf("[%s] acme: the primary DNS provider failed, trying the secondary: %v", domain, err)
+
+		errS := f.secondary.Present(domain, token, keyAuth)
+		if errS != nil {
+			return fmt.Errorf("primary: %v, secondary: %w", err, errS)
+		}
+
+		handler = f.secondary
+	}
+
+	f.handlersMu.Lock()
+	f.handlers[handlerKey(domain, token)] = handler
+	f.handlersMu.Unlock()
+
+	return nil
+}
+
+// CleanUp removes the TXT record with the provider that has created it.
+// If the record is unknown, the primary provider is used.
+func (f *FailoverProvider) CleanUp(domain, token, keyAuth string) error {
+	key := handlerKey(domain, token)
+
+	f.handlersMu.Lock()
+	handler, ok := f.handlers[key]
+	delete(f.handlers, key)
+	f.handlersMu.Unlock()
+
+	if !ok {
+		handler = f.primary
+	}
+
+	return handler.CleanUp(domain, token, keyAuth)
+}
+
+// Timeout returns the longest timeout and the shortest interval of the two providers.
+func (f *FailoverProvider) Timeout() (timeout, interval time.Duration) {
+	timeout, interval = providerTimeout(f.primary)
+	timeoutS, intervalS := providerTimeout(f.secondary)
+
+	if timeoutS > timeout {
+		timeout = timeoutS
+	}
+
+	if intervalS < interval {
+		interval = intervalS
+	}
+
+	return timeout, interval
+}
+
+func providerTimeout(provider challenge.Provider) (timeout, interval time.Duration) {
+	if p, ok := provider.(challenge.ProviderTimeout); ok {
+		return p.Timeout()
+	}
+
+	return DefaultPropagationTimeout, DefaultPollingInterval
+}
+
+func handlerKey(domain, token string) string {
+	return domain + "|" + token
+}
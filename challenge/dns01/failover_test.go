@@ -0,0 +1,88 @@
+package dns01
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type providerRecorderMock struct {
+	present  error
+	presents []string
+	cleanUps []string
+}
+
+func (p *providerRecorderMock) Present(domain, token, keyAuth string) error {
+	p.presents = append(p.presents, domain)
+	return p.present
+}
+
+func (p *providerRecorderMock) CleanUp(domain, token, keyAuth string) error {
+	p.cleanUps = append(p.cleanUps, domain)
+	return nil
+}
+
+func TestFailoverProvider(t *testing.T) {
+	primary := &providerRecorderMock{}
+	secondary := &providerRecorderMock{}
+
+	provider := NewFailoverProvider(primary, secondary)
+
+	err := provider.Present("a.example.com", "tokenA", "keyAuthA")
+	require.NoError(t, err)
+
+	// the primary fails.
+	primary.present = errors.New("OOPS")
+
+	err = provider.Present("b.example.com", "tokenB", "keyAuthB")
+	require.NoError(t, err)
+
+	assert.Equal(t, []string{"a.example.com", "b.example.com"}, primary.presents)
+	assert.Equal(t, []string{"b.example.com"}, secondary.presents)
+
+	err = provider.CleanUp("b.example.com", "tokenB", "keyAuthB")
+	require.NoError(t, err)
+
+	err = provider.CleanUp("a.example.com", "tokenA", "keyAuthA")
+	require.NoError(t, err)
+
+	assert.Equal(t, []string{"a.example.com"}, primary.cleanUps)
+	assert.Equal(t, []string{"b.example.com"}, secondary.cleanUps)
+}
+
+func TestFailoverProvider_Present_error(t *testing.T) {
+	primary := &providerRecorderMock{present: errors.New("OOPS")}
+	secondary := &providerRecorderMock{present: errors.New("BOOM")}
+
+	provider := NewFailoverProvider(primary, secondary)
+
+	err := provider.Present("example.com", "token", "keyAuth")
+	require.EqualError(t, err, "primary: OOPS, secondary: BOOM")
+}
+
+func TestFailoverProvider_CleanUp_unknown(t *testing.T) {
+	primary := &providerRecorderMock{}
+	secondary := &providerRecorderMock{}
+
+	provider := NewFailoverProvider(primary, secondary)
+
+	err := provider.CleanUp("example.com", "token", "keyAuth")
+	require.NoError(t, err)
+
+	assert.Equal(t, []string{"example.com"}, primary.cleanUps)
+	assert.Empty(t, secondary.cleanUps)
+}
+
+func TestFailoverProvider_Timeout(t *testing.T) {
+	primary := &providerTimeoutMock{timeout: 2 * time.Minute, interval: 10 * time.Second}
+	secondary := &providerRecorderMock{}
+
+	provider := NewFailoverProvider(primary, secondary)
+
+	timeout, interval := provider.Timeout()
+	assert.Equal(t, 2*time.Minute, timeout)
+	assert.Equal(t, DefaultPollingInterval, interval)
+}
@@ -0,0 +1,120 @@
+package dns01
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/go-acme/lego/v4/log"
+)
+
+// fqdnCacheFileEntry is the serialized form of a soaCacheEntry.
+type fqdnCacheFileEntry struct {
+	Zone      string    `json:"zone"`
+	PrimaryNs string    `json:"primaryNs"`
+	Expires   time.Time `json:"expires"`
+}
+
+// SaveFqdnCache writes the fresh entries of the cache of fqdn to zone mappings to a file (JSON).
+// The file is replaced atomically.
+func SaveFqdnCache(path string) error {
+	entries := map[string]fqdnCacheFileEntry{}
+
+	muFqdnSoaCache.Lock()
+	for fqdn, ent := range fqdnSoaCache {
+		if ent.isExpired() {
+			continue
+		}
+
+		entries[fqdn] = fqdnCacheFileEntry{Zone: ent.zone, PrimaryNs: ent.primaryNs, Expires: ent.expires}
+	}
+	muFqdnSoaCache.Unlock()
+
+	data, err := json.Marshal(entries)
+	if err != nil {
+		return fmt.Errorf("failed to marshal the FQDN cache: %w", err)
+	}
+
+	tmp, err := ioutil.TempFile(filepath.Dir(path), filepath.Base(path)+".*.tmp")
+	if err != nil {
+		return fmt.Errorf("failed to create the FQDN cache file: %w", err)
+	}
+
+	defer func() { _ = os.Remove(tmp.Name()) }()
+
+	_, err = tmp.Write(data)
+	if errC := tmp.Close(); err == nil {
+		err = errC
+	}
+	if err != nil {
+		return fmt.Errorf("failed to write the FQDN cache file: %w", err)
+	}
+
+	return os.Rename(tmp.Name(), path)
+}
+
+// LoadFqdnCache adds the entries of a file written by SaveFqdnCache to the cache of fqdn to zone mappings.
+// The expired entries are ignored, and the entries already in the cache are kept.
+// A missing file is not an error.
+func LoadFqdnCache(path string) error {
+	data, err := ioutil.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("failed to read the FQDN cache file: %w", err)
+	}
+
+	var entries map[string]fqdnCacheFileEntry
+	err = json.Unmarshal(data, &entries)
+	if err != nil {
+		return fmt.Errorf("failed to unmarshal the FQDN cache file: %w", err)
+	}
+
+	muFqdnSoaCache.Lock()
+	defer muFqdnSoaCache.Unlock()
+
+	for fqdn, entry := range entries {
+		ent := &soaCacheEntry{zone: entry.Zone, primaryNs: entry.PrimaryNs, expires: entry.Expires}
+		if ent.isExpired() {
+			continue
+		}
+
+		if _, ok := fqdnSoaCache[fqdn]; !ok {
+			fqdnSoaCache[fqdn] = ent
+		}
+	}
+
+	return nil
+}
+
+// SaveFqdnCachePeriodically saves the cache of fqdn to zone mappings to a file every interval,
+// and one last time when the context is done.
+// It blocks until the context is done and returns the error of the last save,
+// the errors of the periodic saves are logged.
+// Combined with LoadFqdnCache at startup, it allows a long-running process to keep the cache between restarts.
+func SaveFqdnCachePeriodically(ctx context.Context, path string, interval time.Duration) error {
+	if interval <= 0 {
+		return fmt.Errorf("invalid FQDN cache save interval: %s", interval)
+	}
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return SaveFqdnCache(path)
+		case <-ticker.C:
+			err := SaveFqdnCache(path)
+			if err != nil {
+				log.Warnf("acme: %v", err)
+			}
+		}
+	}
+}
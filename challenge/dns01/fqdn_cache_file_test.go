@@ -0,0 +1,154 @@
+package dns01
+
+import (
+	"context"
+	"encoding/json"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func setupFqdnCacheFile(t *testing.T) string {
+	t.Helper()
+
+	ClearFqdnCache()
+	t.Cleanup(ClearFqdnCache)
+
+	return filepath.Join(t.TempDir(), "fqdn-cache.json")
+}
+
+func TestSaveFqdnCache_LoadFqdnCache(t *testing.T) {
+	path := setupFqdnCacheFile(t)
+
+	seedFqdnCache("_acme-challenge.example.com.", "example.com.")
+	seedFqdnCache("_acme-challenge.example.org.", "example.org.")
+
+	err := SaveFqdnCache(path)
+	require.NoError(t, err)
+
+	// simulates a restart.
+	ClearFqdnCache()
+
+	err = LoadFqdnCache(path)
+	require.NoError(t, err)
+
+	zone, err := FindZoneByFqdn("_acme-challenge.example.com.")
+	require.NoError(t, err)
+	assert.Equal(t, "example.com.", zone)
+
+	zone, err = FindZoneByFqdn("_acme-challenge.example.org.")
+	require.NoError(t, err)
+	assert.Equal(t, "example.org.", zone)
+}
+
+func TestSaveFqdnCache_expired(t *testing.T) {
+	path := setupFqdnCacheFile(t)
+
+	seedFqdnCache("_acme-challenge.example.com.", "example.com.")
+
+	muFqdnSoaCache.Lock()
+	fqdnSoaCache["_acme-challenge.example.org."] = &soaCacheEntry{zone: "example.org.", expires: time.Now().Add(-time.Minute)}
+	muFqdnSoaCache.Unlock()
+
+	err := SaveFqdnCache(path)
+	require.NoError(t, err)
+
+	data, err := ioutil.ReadFile(path)
+	require.NoError(t, err)
+
+	var entries map[string]fqdnCacheFileEntry
+	err = json.Unmarshal(data, &entries)
+	require.NoError(t, err)
+
+	assert.Len(t, entries, 1)
+	assert.Contains(t, entries, "_acme-challenge.example.com.")
+}
+
+func TestLoadFqdnCache_expired(t *testing.T) {
+	path := setupFqdnCacheFile(t)
+
+	// the entry of example.org has expired while the process was stopped.
+	entries := map[string]fqdnCacheFileEntry{
+		"_acme-challenge.example.com.": {Zone: "example.com.", PrimaryNs: "ns1.example.com.", Expires: time.Now().Add(time.Hour)},
+		"_acme-challenge.example.org.": {Zone: "example.org.", PrimaryNs: "ns1.example.org.", Expires: time.Now().Add(-time.Minute)},
+	}
+
+	data, err := json.Marshal(entries)
+	require.NoError(t, err)
+
+	err = ioutil.WriteFile(path, data, 0o600)
+	require.NoError(t, err)
+
+	err = LoadFqdnCache(path)
+	require.NoError(t, err)
+
+	muFqdnSoaCache.Lock()
+	defer muFqdnSoaCache.Unlock()
+
+	assert.Len(t, fqdnSoaCache, 1)
+	require.Contains(t, fqdnSoaCache, "_acme-challenge.example.com.")
+	assert.Equal(t, "example.com.", fqdnSoaCache["_acme-challenge.example.com."].zone)
+	assert.Equal(t, "ns1.example.com.", fqdnSoaCache["_acme-challenge.example.com."].primaryNs)
+}
+
+func TestLoadFqdnCache_missingFile(t *testing.T) {
+	path := setupFqdnCacheFile(t)
+
+	err := LoadFqdnCache(path)
+	require.NoError(t, err)
+}
+
+func TestLoadFqdnCache_invalidFile(t *testing.T) {
+	path := setupFqdnCacheFile(t)
+
+	err := ioutil.WriteFile(path, []byte("not JSON"), 0o600)
+	require.NoError(t, err)
+
+	err = LoadFqdnCache(path)
+	require.Error(t, err)
+}
+
+func TestSaveFqdnCachePeriodically(t *testing.T) {
+	path := setupFqdnCacheFile(t)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	errCh := make(chan error, 1)
+	go func() { errCh <- SaveFqdnCachePeriodically(ctx, path, 10*time.Millisecond) }()
+
+	seedFqdnCache("_acme-challenge.example.com.", "example.com.")
+
+	assert.Eventually(t, func() bool {
+		_, errS := os.Stat(path)
+		return errS == nil
+	}, 5*time.Second, 10*time.Millisecond)
+
+	seedFqdnCache("_acme-challenge.example.org.", "example.org.")
+
+	// the last save happens when the context is done.
+	cancel()
+	require.NoError(t, <-errCh)
+
+	ClearFqdnCache()
+
+	err := LoadFqdnCache(path)
+	require.NoError(t, err)
+
+	muFqdnSoaCache.Lock()
+	defer muFqdnSoaCache.Unlock()
+
+	assert.Len(t, fqdnSoaCache, 2)
+}
+
+func TestSaveFqdnCachePeriodically_invalidInterval(t *testing.T) {
+	path := setupFqdnCacheFile(t)
+
+	err := SaveFqdnCachePeriodically(context.Background(), path, 0)
+	require.EqualError(t, err, "invalid FQDN cache save interval: 0s")
+}
@@ -0,0 +1,17 @@
+package dns01
+
+import "strings"
+
+// PresentIfAbsent calls present only if the TXT value is not already one of the existing values.
+// It allows the providers appending the values to an existing record
+// to re-run Present (e.g. after a restart) without duplicating the value.
+// The existing values can be quoted.
+func PresentIfAbsent(existing []string, value string, present func() error) error {
+	for _, v := range existing {
+		if strings.Trim(v, `"`) == value {
+			return nil
+		}
+	}
+
+	return present()
+}
@@ -0,0 +1,60 @@
+package dns01
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPresentIfAbsent(t *testing.T) {
+	testCases := []struct {
+		desc     string
+		existing []string
+		value    string
+		expected bool
+	}{
+		{
+			desc:     "no existing values",
+			value:    "foo",
+			expected: true,
+		},
+		{
+			desc:     "other values",
+			existing: []string{"bar", "baz"},
+			value:    "foo",
+			expected: true,
+		},
+		{
+			desc:     "already present",
+			existing: []string{"bar", "foo"},
+			value:    "foo",
+		},
+		{
+			desc:     "already present, quoted",
+			existing: []string{`"foo"`},
+			value:    "foo",
+		},
+	}
+
+	for _, test := range testCases {
+		t.Run(test.desc, func(t *testing.T) {
+			var called bool
+			err := PresentIfAbsent(test.existing, test.value, func() error {
+				called = true
+				return nil
+			})
+			require.NoError(t, err)
+
+			assert.Equal(t, test.expected, called)
+		})
+	}
+}
+
+func TestPresentIfAbsent_error(t *testing.T) {
+	err := PresentIfAbsent([]string{"bar"}, "foo", func() error {
+		return errors.New("OOPS")
+	})
+	require.EqualError(t, err, "OOPS")
+}
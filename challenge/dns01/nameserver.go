@@ -1,9 +1,12 @@
 package dns01
 
 import (
+	"context"
 	"errors"
 	"fmt"
 	"net"
+	"os"
+	"strconv"
 	"strings"
 	"sync"
 	"time"
@@ -13,9 +16,31 @@ import (
 
 const defaultResolvConf = "/etc/resolv.conf"
 
+// envDisableFqdnCache disables the cache of the SOA records: the nameservers are queried for each zone lookup.
+const envDisableFqdnCache = "LEGO_DISABLE_FQDN_CACHE"
+
+// Nameserver transport schemes.
+const (
+	schemeUDP   = "udp://"
+	schemeTCP   = "tcp://"
+	schemeHTTPS = "https://"
+)
+
 // dnsTimeout is used to override the default DNS timeout of 10 seconds.
 var dnsTimeout = 10 * time.Second
 
+// DefaultSOAMaxDepth is the default maximum number of labels walked to find the SOA record of a FQDN.
+const DefaultSOAMaxDepth = 16
+
+// ErrSOAMaxDepthExceeded is returned when the SOA record is not found within the maximum number of labels.
+var ErrSOAMaxDepthExceeded = errors.New("maximum SOA discovery depth exceeded")
+
+// soaRetries is the number of retries of a SOA query after a transient failure (network error or SERVFAIL).
+var soaRetries = 0
+
+// soaRetryDelay is the delay before the first retry of a SOA query, doubled after each retry.
+var soaRetryDelay = 500 * time.Millisecond
+
 var (
 	fqdnSoaCache   = map[string]*soaCacheEntry{}
 	muFqdnSoaCache sync.Mutex
@@ -63,6 +88,33 @@ func AddDNSTimeout(timeout time.Duration) ChallengeOption {
 	}
 }
 
+// AddSOAMaxDepth sets the maximum number of labels walked (one query per label) to find the SOA record of a FQDN,
+// during the propagation check of the challenge.
+func AddSOAMaxDepth(depth int) ChallengeOption {
+	return func(chlg *Challenge) error {
+		if depth <= 0 {
+			return fmt.Errorf("invalid SOA discovery depth: %d", depth)
+		}
+
+		chlg.preCheck.soaMaxDepth = depth
+		return nil
+	}
+}
+
+// AddSOARetries sets the number of retries of the SOA queries used to find the zone of a FQDN,
+// after a transient failure (network error or SERVFAIL).
+// A definitive answer (ex: NXDOMAIN) is never retried.
+func AddSOARetries(retries int) ChallengeOption {
+	return func(_ *Challenge) error {
+		if retries < 0 {
+			return fmt.Errorf("invalid SOA retries: %d", retries)
+		}
+
+		soaRetries = retries
+		return nil
+	}
+}
+
 func AddRecursiveNameservers(nameservers []string) ChallengeOption {
 	return func(_ *Challenge) error {
 		recursiveNameservers = ParseNameservers(nameservers)
@@ -80,28 +132,57 @@ func getNameservers(path string, defaults []string) []string {
 	return ParseNameservers(config.Servers)
 }
 
+// ParseNameservers normalizes the nameservers.
+// A nameserver can be prefixed by a transport scheme:
+//  - "udp://" (default): UDP, with a fallback to TCP if the response is truncated.
+//  - "tcp://": TCP only.
+//  - "https://": DNS over HTTPS (RFC 8484), the nameserver must be the full URL of the DoH endpoint.
 func ParseNameservers(servers []string) []string {
 	var resolvers []string
 	for _, resolver := range servers {
+		if strings.HasPrefix(resolver, schemeHTTPS) {
+			resolvers = append(resolvers, resolver)
+			continue
+		}
+
+		scheme, host := splitScheme(resolver)
+
 		// ensure all servers have a port number
-		if _, _, err := net.SplitHostPort(resolver); err != nil {
-			resolvers = append(resolvers, net.JoinHostPort(resolver, "53"))
+		if _, _, err := net.SplitHostPort(host); err != nil {
+			resolvers = append(resolvers, scheme+net.JoinHostPort(host, "53"))
 		} else {
-			resolvers = append(resolvers, resolver)
+			resolvers = append(resolvers, scheme+host)
 		}
 	}
 	return resolvers
 }
 
-// lookupNameservers returns the authoritative nameservers for the given fqdn.
-func lookupNameservers(fqdn string) ([]string, error) {
-	var authoritativeNss []string
+// splitScheme splits the nameserver into the transport scheme (if any) and the address.
+func splitScheme(ns string) (scheme, address string) {
+	for _, s := range []string{schemeUDP, schemeTCP} {
+		if strings.HasPrefix(ns, s) {
+			return s, strings.TrimPrefix(ns, s)
+		}
+	}
+
+	return "", ns
+}
 
-	zone, err := FindZoneByFqdn(fqdn)
+// lookupNameservers returns the authoritative nameservers for the given fqdn.
+// The zone is found by walking at most maxDepth labels.
+func lookupNameservers(fqdn string, maxDepth int) ([]string, error) {
+	zone, err := findZoneByFqdn(fqdn, recursiveNameservers, maxDepth)
 	if err != nil {
 		return nil, fmt.Errorf("could not determine the zone: %w", err)
 	}
 
+	return lookupZoneNameservers(zone)
+}
+
+// lookupZoneNameservers returns the nameservers of the zone, as published in DNS.
+func lookupZoneNameservers(zone string) ([]string, error) {
+	var authoritativeNss []string
+
 	r, err := dnsQuery(zone, dns.TypeNS, recursiveNameservers, true)
 	if err != nil {
 		return nil, err
@@ -128,7 +209,7 @@ func FindPrimaryNsByFqdn(fqdn string) (string, error) {
 // FindPrimaryNsByFqdnCustom determines the primary nameserver of the zone apex for the given fqdn
 // by recursing up the domain labels until the nameserver returns a SOA record in the answer section.
 func FindPrimaryNsByFqdnCustom(fqdn string, nameservers []string) (string, error) {
-	soa, err := lookupSoaByFqdn(fqdn, nameservers)
+	soa, err := lookupSoaByFqdn(fqdn, nameservers, DefaultSOAMaxDepth)
 	if err != nil {
 		return "", err
 	}
@@ -144,40 +225,58 @@ func FindZoneByFqdn(fqdn string) (string, error) {
 // FindZoneByFqdnCustom determines the zone apex for the given fqdn
 // by recursing up the domain labels until the nameserver returns a SOA record in the answer section.
 func FindZoneByFqdnCustom(fqdn string, nameservers []string) (string, error) {
-	soa, err := lookupSoaByFqdn(fqdn, nameservers)
+	return findZoneByFqdn(fqdn, nameservers, DefaultSOAMaxDepth)
+}
+
+// findZoneByFqdn determines the zone apex for the given fqdn, walking at most maxDepth labels.
+func findZoneByFqdn(fqdn string, nameservers []string, maxDepth int) (string, error) {
+	soa, err := lookupSoaByFqdn(fqdn, nameservers, maxDepth)
 	if err != nil {
 		return "", err
 	}
 	return soa.zone, nil
 }
 
-func lookupSoaByFqdn(fqdn string, nameservers []string) (*soaCacheEntry, error) {
+func lookupSoaByFqdn(fqdn string, nameservers []string, maxDepth int) (*soaCacheEntry, error) {
+	if ok, _ := strconv.ParseBool(os.Getenv(envDisableFqdnCache)); ok {
+		return fetchSoaByFqdn(fqdn, nameservers, maxDepth)
+	}
+
 	muFqdnSoaCache.Lock()
-	defer muFqdnSoaCache.Unlock()
+	ent := fqdnSoaCache[fqdn]
+	muFqdnSoaCache.Unlock()
 
 	// Do we have it cached and is it still fresh?
-	if ent := fqdnSoaCache[fqdn]; ent != nil && !ent.isExpired() {
+	if ent != nil && !ent.isExpired() {
 		return ent, nil
 	}
 
-	ent, err := fetchSoaByFqdn(fqdn, nameservers)
+	// the queries (and their retries) are done outside the lock, to not block the lookups of the other FQDNs.
+	ent, err := fetchSoaByFqdn(fqdn, nameservers, maxDepth)
 	if err != nil {
 		return nil, err
 	}
 
+	muFqdnSoaCache.Lock()
 	fqdnSoaCache[fqdn] = ent
+	muFqdnSoaCache.Unlock()
+
 	return ent, nil
 }
 
-func fetchSoaByFqdn(fqdn string, nameservers []string) (*soaCacheEntry, error) {
+func fetchSoaByFqdn(fqdn string, nameservers []string, maxDepth int) (*soaCacheEntry, error) {
 	var err error
 	var in *dns.Msg
 
 	labelIndexes := dns.Split(fqdn)
-	for _, index := range labelIndexes {
+	for i, index := range labelIndexes {
+		if i >= maxDepth {
+			return nil, fmt.Errorf("could not find the start of authority for %s: %w: %d labels walked", fqdn, ErrSOAMaxDepthExceeded, maxDepth)
+		}
+
 		domain := fqdn[index:]
 
-		in, err = dnsQuery(domain, dns.TypeSOA, nameservers, true)
+		in, err = querySOA(domain, nameservers)
 		if err != nil {
 			continue
 		}
@@ -215,6 +314,31 @@ func fetchSoaByFqdn(fqdn string, nameservers []string) (*soaCacheEntry, error) {
 	return nil, fmt.Errorf("could not find the start of authority for %s%s", fqdn, formatDNSError(in, err))
 }
 
+// querySOA queries the SOA record of the domain, and retries with backoff after a transient failure.
+func querySOA(domain string, nameservers []string) (*dns.Msg, error) {
+	delay := soaRetryDelay
+
+	for attempt := 0; ; attempt++ {
+		in, err := dnsQuery(domain, dns.TypeSOA, nameservers, true)
+		if attempt >= soaRetries || !isTransientDNSFailure(in, err) {
+			return in, err
+		}
+
+		time.Sleep(delay)
+		delay *= 2
+	}
+}
+
+// isTransientDNSFailure checks if a DNS query failed in a way that a retry could fix.
+// NXDOMAIN and the other definitive answers are not transient.
+func isTransientDNSFailure(in *dns.Msg, err error) bool {
+	if err != nil {
+		return true
+	}
+
+	return in == nil || in.Rcode == dns.RcodeServerFailure
+}
+
 // dnsMsgContainsCNAME checks for a CNAME answer in msg.
 func dnsMsgContainsCNAME(msg *dns.Msg) bool {
 	for _, ans := range msg.Answer {
@@ -226,16 +350,25 @@ func dnsMsgContainsCNAME(msg *dns.Msg) bool {
 }
 
 func dnsQuery(fqdn string, rtype uint16, nameservers []string, recursive bool) (*dns.Msg, error) {
+	return dnsQueryContext(context.Background(), fqdn, rtype, nameservers, recursive)
+}
+
+// dnsQueryContext is dnsQuery, the in-flight query is stopped when the context is done.
+func dnsQueryContext(ctx context.Context, fqdn string, rtype uint16, nameservers []string, recursive bool) (*dns.Msg, error) {
 	m := createDNSMsg(fqdn, rtype, recursive)
 
 	var in *dns.Msg
 	var err error
 
 	for _, ns := range nameservers {
-		in, err = sendDNSQuery(m, ns)
+		in, err = sendDNSQuery(ctx, m, ns)
 		if err == nil && len(in.Answer) > 0 {
 			break
 		}
+
+		if ctx.Err() != nil {
+			return nil, ctx.Err()
+		}
 	}
 	return in, err
 }
@@ -252,14 +385,59 @@ func createDNSMsg(fqdn string, rtype uint16, recursive bool) *dns.Msg {
 	return m
 }
 
-func sendDNSQuery(m *dns.Msg, ns string) (*dns.Msg, error) {
+func sendDNSQuery(ctx context.Context, m *dns.Msg, ns string) (*dns.Msg, error) {
+	if strings.HasPrefix(ns, schemeHTTPS) {
+		return sendDoHQuery(ctx, m, ns)
+	}
+
+	scheme, ns := splitScheme(ns)
+	if scheme == schemeTCP {
+		tcp := &dns.Client{Net: "tcp", Timeout: dnsTimeout}
+		return exchange(ctx, tcp, m, ns)
+	}
+
 	udp := &dns.Client{Net: "udp", Timeout: dnsTimeout}
-	in, _, err := udp.Exchange(m, ns)
+	in, err := exchange(ctx, udp, m, ns)
 
 	if in != nil && in.Truncated {
 		tcp := &dns.Client{Net: "tcp", Timeout: dnsTimeout}
 		// If the TCP request succeeds, the err will reset to nil
-		in, _, err = tcp.Exchange(m, ns)
+		in, err = exchange(ctx, tcp, m, ns)
+	}
+
+	return in, err
+}
+
+// exchange sends the query to the nameserver.
+// The connection is closed when the context is done, which stops the in-flight query
+// (dns.Client.ExchangeContext only applies the deadline of the context).
+func exchange(ctx context.Context, client *dns.Client, m *dns.Msg, ns string) (*dns.Msg, error) {
+	if ctx.Done() == nil {
+		in, _, err := client.Exchange(m, ns)
+		return in, err
+	}
+
+	co, err := client.Dial(ns)
+	if err != nil {
+		return nil, err
+	}
+
+	defer func() { _ = co.Close() }()
+
+	done := make(chan struct{})
+	defer close(done)
+
+	go func() {
+		select {
+		case <-ctx.Done():
+			_ = co.Close()
+		case <-done:
+		}
+	}()
+
+	in, _, err := client.ExchangeWithConn(m, co)
+	if ctx.Err() != nil {
+		return nil, ctx.Err()
 	}
 
 	return in, err
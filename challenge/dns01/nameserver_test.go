@@ -1,9 +1,14 @@
 package dns01
 
 import (
+	"errors"
+	"os"
 	"sort"
+	"sync/atomic"
 	"testing"
+	"time"
 
+	"github.com/miekg/dns"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 )
@@ -32,7 +37,7 @@ func TestLookupNameserversOK(t *testing.T) {
 		t.Run(test.fqdn, func(t *testing.T) {
 			t.Parallel()
 
-			nss, err := lookupNameservers(test.fqdn)
+			nss, err := lookupNameservers(test.fqdn, DefaultSOAMaxDepth)
 			require.NoError(t, err)
 
 			sort.Strings(nss)
@@ -61,7 +66,7 @@ func TestLookupNameserversErr(t *testing.T) {
 		t.Run(test.desc, func(t *testing.T) {
 			t.Parallel()
 
-			_, err := lookupNameservers(test.fqdn)
+			_, err := lookupNameservers(test.fqdn, DefaultSOAMaxDepth)
 			require.Error(t, err)
 			assert.Contains(t, err.Error(), test.error)
 		})
@@ -197,3 +202,332 @@ func TestResolveConfServers(t *testing.T) {
 		})
 	}
 }
+
+func TestParseNameservers(t *testing.T) {
+	testCases := []struct {
+		desc     string
+		servers  []string
+		expected []string
+	}{
+		{
+			desc:     "without port",
+			servers:  []string{"8.8.8.8", "ns1.example.com"},
+			expected: []string{"8.8.8.8:53", "ns1.example.com:53"},
+		},
+		{
+			desc:     "with port",
+			servers:  []string{"8.8.8.8:5353"},
+			expected: []string{"8.8.8.8:5353"},
+		},
+		{
+			desc:     "IPv6",
+			servers:  []string{"2001:4860:4860::8888", "[2001:4860:4860::8844]:53"},
+			expected: []string{"[2001:4860:4860::8888]:53", "[2001:4860:4860::8844]:53"},
+		},
+		{
+			desc:     "with transport",
+			servers:  []string{"udp://8.8.8.8", "tcp://1.1.1.1:5353", "https://dns.google/dns-query"},
+			expected: []string{"udp://8.8.8.8:53", "tcp://1.1.1.1:5353", "https://dns.google/dns-query"},
+		},
+	}
+
+	for _, test := range testCases {
+		t.Run(test.desc, func(t *testing.T) {
+			assert.Equal(t, test.expected, ParseNameservers(test.servers))
+		})
+	}
+}
+
+func Test_dnsQuery_transports(t *testing.T) {
+	empty := txtAnswer()
+
+	udpServer := startFakeDNSServer(t, "udp", txtAnswer("udp"))
+	tcpServer := startFakeDNSServer(t, "tcp", txtAnswer("tcp"))
+	emptyUDPServer := startFakeDNSServer(t, "udp", empty)
+	dohServer := startFakeDoHServer(t, txtAnswer("doh"))
+
+	testCases := []struct {
+		desc        string
+		nameservers []string
+		expected    string
+	}{
+		{
+			desc:        "UDP (implicit)",
+			nameservers: []string{udpServer},
+			expected:    "udp",
+		},
+		{
+			desc:        "UDP",
+			nameservers: []string{"udp://" + udpServer},
+			expected:    "udp",
+		},
+		{
+			desc:        "TCP",
+			nameservers: []string{"tcp://" + tcpServer},
+			expected:    "tcp",
+		},
+		{
+			desc:        "DoH",
+			nameservers: []string{dohServer},
+			expected:    "doh",
+		},
+		{
+			desc:        "UDP without answer then DoH",
+			nameservers: []string{emptyUDPServer, dohServer},
+			expected:    "doh",
+		},
+		{
+			desc:        "DoH then UDP",
+			nameservers: []string{dohServer, udpServer},
+			expected:    "doh",
+		},
+	}
+
+	for _, test := range testCases {
+		t.Run(test.desc, func(t *testing.T) {
+			r, err := dnsQuery("_acme-challenge.example.com.", dns.TypeTXT, ParseNameservers(test.nameservers), true)
+			require.NoError(t, err)
+
+			require.Len(t, r.Answer, 1)
+			txt, ok := r.Answer[0].(*dns.TXT)
+			require.True(t, ok)
+
+			assert.Equal(t, []string{test.expected}, txt.Txt)
+		})
+	}
+}
+
+func TestFindZoneByFqdnCustom_soaMaxDepth(t *testing.T) {
+	var queries int32
+	resolver := func(req *dns.Msg) *dns.Msg {
+		atomic.AddInt32(&queries, 1)
+
+		m := new(dns.Msg)
+		m.SetReply(req)
+
+		if req.Question[0].Name != "example.com." {
+			m.Rcode = dns.RcodeNameError
+			return m
+		}
+
+		m.Answer = append(m.Answer, &dns.SOA{
+			Hdr:     dns.RR_Header{Name: "example.com.", Rrtype: dns.TypeSOA, Class: dns.ClassINET, Ttl: 60},
+			Ns:      "ns1.example.com.",
+			Mbox:    "admin.example.com.",
+			Refresh: 60,
+		})
+
+		return m
+	}
+
+	nameservers := []string{startFakeDNSServer(t, "udp", resolver)}
+
+	ClearFqdnCache()
+	defer ClearFqdnCache()
+
+	zone, err := findZoneByFqdn("_acme-challenge.a.b.example.com.", nameservers, 5)
+	require.NoError(t, err)
+	assert.Equal(t, "example.com.", zone)
+	assert.Equal(t, int32(4), atomic.LoadInt32(&queries))
+
+	atomic.StoreInt32(&queries, 0)
+
+	_, err = findZoneByFqdn("_acme-challenge.a.b.c.d.e.f.g.example.com.", nameservers, 5)
+	require.Error(t, err)
+	assert.True(t, errors.Is(err, ErrSOAMaxDepthExceeded))
+	assert.EqualError(t, err, "could not find the start of authority for _acme-challenge.a.b.c.d.e.f.g.example.com.: maximum SOA discovery depth exceeded: 5 labels walked")
+	assert.Equal(t, int32(5), atomic.LoadInt32(&queries))
+}
+
+func TestAddSOAMaxDepth(t *testing.T) {
+	chlg := &Challenge{preCheck: newPreCheck()}
+
+	assert.Equal(t, DefaultSOAMaxDepth, chlg.preCheck.soaMaxDepth)
+
+	err := AddSOAMaxDepth(0)(chlg)
+	require.EqualError(t, err, "invalid SOA discovery depth: 0")
+
+	err = AddSOAMaxDepth(5)(chlg)
+	require.NoError(t, err)
+
+	assert.Equal(t, 5, chlg.preCheck.soaMaxDepth)
+}
+
+func TestFindZoneByFqdnCustom_disableFqdnCache(t *testing.T) {
+	var queries int32
+	resolver := func(req *dns.Msg) *dns.Msg {
+		atomic.AddInt32(&queries, 1)
+
+		m := new(dns.Msg)
+		m.SetReply(req)
+
+		m.Answer = append(m.Answer, &dns.SOA{
+			Hdr:     dns.RR_Header{Name: "example.com.", Rrtype: dns.TypeSOA, Class: dns.ClassINET, Ttl: 60},
+			Ns:      "ns1.example.com.",
+			Mbox:    "admin.example.com.",
+			Refresh: 3600,
+		})
+
+		return m
+	}
+
+	nameservers := []string{startFakeDNSServer(t, "udp", resolver)}
+
+	testCases := []struct {
+		desc     string
+		envVar   string
+		expected int32
+	}{
+		{
+			desc:     "cache enabled",
+			expected: 1,
+		},
+		{
+			desc:     "cache disabled",
+			envVar:   "true",
+			expected: 3,
+		},
+	}
+
+	for _, test := range testCases {
+		t.Run(test.desc, func(t *testing.T) {
+			ClearFqdnCache()
+			defer ClearFqdnCache()
+
+			if test.envVar != "" {
+				defer os.Unsetenv(envDisableFqdnCache)
+				os.Setenv(envDisableFqdnCache, test.envVar)
+			}
+
+			atomic.StoreInt32(&queries, 0)
+
+			for i := 0; i < 3; i++ {
+				zone, err := FindZoneByFqdnCustom("example.com.", nameservers)
+				require.NoError(t, err)
+				assert.Equal(t, "example.com.", zone)
+			}
+
+			assert.Equal(t, test.expected, atomic.LoadInt32(&queries))
+
+			muFqdnSoaCache.Lock()
+			defer muFqdnSoaCache.Unlock()
+
+			if test.envVar != "" {
+				assert.Empty(t, fqdnSoaCache)
+			}
+		})
+	}
+}
+
+func TestFindZoneByFqdnCustom_soaRetries(t *testing.T) {
+	soaAnswer := func(req *dns.Msg) *dns.Msg {
+		m := new(dns.Msg)
+		m.SetReply(req)
+
+		m.Answer = append(m.Answer, &dns.SOA{
+			Hdr:     dns.RR_Header{Name: "example.com.", Rrtype: dns.TypeSOA, Class: dns.ClassINET, Ttl: 60},
+			Ns:      "ns1.example.com.",
+			Mbox:    "admin.example.com.",
+			Refresh: 60,
+		})
+
+		return m
+	}
+
+	testCases := []struct {
+		desc            string
+		failure         func(m *dns.Msg)
+		failures        int32
+		retries         int
+		expected        string
+		expectedError   string
+		expectedQueries int32
+	}{
+		{
+			desc:            "SERVFAIL then success",
+			failure:         func(m *dns.Msg) { m.Rcode = dns.RcodeServerFailure },
+			failures:        2,
+			retries:         2,
+			expected:        "example.com.",
+			expectedQueries: 3,
+		},
+		{
+			desc:            "SERVFAIL without retries",
+			failure:         func(m *dns.Msg) { m.Rcode = dns.RcodeServerFailure },
+			failures:        1,
+			expectedError:   "unexpected response code 'SERVFAIL' for example.com.",
+			expectedQueries: 1,
+		},
+		{
+			desc:            "SERVFAIL after the retries",
+			failure:         func(m *dns.Msg) { m.Rcode = dns.RcodeServerFailure },
+			failures:        3,
+			retries:         2,
+			expectedError:   "unexpected response code 'SERVFAIL' for example.com.",
+			expectedQueries: 3,
+		},
+		{
+			desc:            "network error then success",
+			failure:         func(m *dns.Msg) { m.Id++ },
+			failures:        1,
+			retries:         1,
+			expected:        "example.com.",
+			expectedQueries: 2,
+		},
+		{
+			// one query per label: example.com. and com.
+			desc:            "NXDOMAIN is not retried",
+			failure:         func(m *dns.Msg) { m.Rcode = dns.RcodeNameError; m.Answer = nil },
+			failures:        3,
+			retries:         2,
+			expectedError:   "could not find the start of authority for example.com.: NXDOMAIN",
+			expectedQueries: 2,
+		},
+	}
+
+	defer func(delay time.Duration) {
+		soaRetries = 0
+		soaRetryDelay = delay
+	}(soaRetryDelay)
+
+	soaRetryDelay = time.Millisecond
+
+	for _, test := range testCases {
+		t.Run(test.desc, func(t *testing.T) {
+			ClearFqdnCache()
+			defer ClearFqdnCache()
+
+			var queries int32
+			resolver := func(req *dns.Msg) *dns.Msg {
+				current := atomic.AddInt32(&queries, 1)
+
+				m := soaAnswer(req)
+				if current <= test.failures {
+					test.failure(m)
+				}
+
+				return m
+			}
+
+			nameservers := []string{startFakeDNSServer(t, "udp", resolver)}
+
+			err := AddSOARetries(test.retries)(nil)
+			require.NoError(t, err)
+
+			zone, err := FindZoneByFqdnCustom("example.com.", nameservers)
+			if test.expectedError != "" {
+				require.EqualError(t, err, test.expectedError)
+			} else {
+				require.NoError(t, err)
+				assert.Equal(t, test.expected, zone)
+			}
+
+			assert.Equal(t, test.expectedQueries, atomic.LoadInt32(&queries))
+		})
+	}
+}
+
+func TestAddSOARetries_invalid(t *testing.T) {
+	err := AddSOARetries(-1)(nil)
+	require.EqualError(t, err, "invalid SOA retries: -1")
+}
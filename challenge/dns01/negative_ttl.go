@@ -0,0 +1,89 @@
+package dns01
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/go-acme/lego/v4/log"
+	"github.com/miekg/dns"
+)
+
+// ProbeNegativeTTL enables a diagnostic reporting the negative cache TTL of the zone of the TXT record.
+// A random sibling of the TXT record is queried to get the SOA record of the negative answer.
+// When a resolver has cached a NXDOMAIN for the TXT record (ex: query before the creation of the record),
+// the record is not visible through this resolver until the negative TTL expires.
+func ProbeNegativeTTL() ChallengeOption {
+	return func(chlg *Challenge) error {
+		chlg.probeNegativeTTL = true
+		return nil
+	}
+}
+
+// reportNegativeTTL logs the negative cache TTL of the zone of the TXT record.
+func (c *Challenge) reportNegativeTTL(domain, fqdn string, timeout time.Duration) {
+	ttl, err := findNegativeTTL(fqdn, recursiveNameservers)
+	if err != nil {
+		log.Warnf("[%s] acme: could not determine the negative cache TTL: %v", domain, err)
+		return
+	}
+
+	log.Infof("[%s] acme: The negative cache TTL of the zone is %s", domain, ttl)
+
+	if ttl > timeout {
+		log.Warnf("[%s] acme: The negative cache TTL (%s) is greater than the propagation timeout (%s), a NXDOMAIN cached by a resolver can outlast the propagation check", domain, ttl, timeout)
+	}
+}
+
+// findNegativeTTL queries a random sibling of the fqdn and returns the negative cache TTL of the answer (RFC 2308):
+// the minimum of the TTL of the SOA record and of its MINIMUM field.
+func findNegativeTTL(fqdn string, nameservers []string) (time.Duration, error) {
+	name, err := randomSibling(fqdn)
+	if err != nil {
+		return 0, err
+	}
+
+	r, err := dnsQuery(name, dns.TypeTXT, nameservers, true)
+	if err != nil {
+		return 0, err
+	}
+
+	if r.Rcode != dns.RcodeNameError && r.Rcode != dns.RcodeSuccess {
+		return 0, fmt.Errorf("unexpected response for %s: %s", name, dns.RcodeToString[r.Rcode])
+	}
+
+	if len(r.Answer) > 0 {
+		return 0, fmt.Errorf("unexpected answer for %s (wildcard record?)", name)
+	}
+
+	for _, rr := range r.Ns {
+		if soa, ok := rr.(*dns.SOA); ok {
+			ttl := soa.Hdr.Ttl
+			if soa.Minttl < ttl {
+				ttl = soa.Minttl
+			}
+
+			return time.Duration(ttl) * time.Second, nil
+		}
+	}
+
+	return 0, fmt.Errorf("no SOA record in the negative answer for %s", name)
+}
+
+// randomSibling replaces the first label of the fqdn by a random label.
+func randomSibling(fqdn string) (string, error) {
+	index := strings.Index(fqdn, ".")
+	if index < 0 {
+		return "", fmt.Errorf("invalid FQDN: %s", fqdn)
+	}
+
+	raw := make([]byte, 8)
+	_, err := rand.Read(raw)
+	if err != nil {
+		return "", err
+	}
+
+	return "_lego-probe-" + hex.EncodeToString(raw) + fqdn[index:], nil
+}
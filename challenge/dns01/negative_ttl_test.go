@@ -0,0 +1,121 @@
+package dns01
+
+import (
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/miekg/dns"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// negativeAnswer returns a fakeResolver answering NXDOMAIN with the SOA record of the zone.
+func negativeAnswer(soaTTL, minTTL uint32) fakeResolver {
+	return func(req *dns.Msg) *dns.Msg {
+		m := new(dns.Msg)
+		m.SetRcode(req, dns.RcodeNameError)
+
+		m.Ns = append(m.Ns, &dns.SOA{
+			Hdr:    dns.RR_Header{Name: "example.com.", Rrtype: dns.TypeSOA, Class: dns.ClassINET, Ttl: soaTTL},
+			Ns:     "ns1.example.com.",
+			Mbox:   "admin.example.com.",
+			Minttl: minTTL,
+		})
+
+		return m
+	}
+}
+
+func Test_findNegativeTTL(t *testing.T) {
+	testCases := []struct {
+		desc     string
+		resolver fakeResolver
+		expected time.Duration
+		err      string
+	}{
+		{
+			desc:     "SOA MINIMUM lower than the SOA TTL",
+			resolver: negativeAnswer(3600, 300),
+			expected: 300 * time.Second,
+		},
+		{
+			desc:     "SOA TTL lower than the SOA MINIMUM",
+			resolver: negativeAnswer(60, 300),
+			expected: 60 * time.Second,
+		},
+		{
+			desc: "no SOA record",
+			resolver: func(req *dns.Msg) *dns.Msg {
+				m := new(dns.Msg)
+				m.SetRcode(req, dns.RcodeNameError)
+				return m
+			},
+			err: "no SOA record in the negative answer for _lego-probe-",
+		},
+		{
+			desc:     "wildcard record",
+			resolver: txtAnswer("wildcard"),
+			err:      "unexpected answer for _lego-probe-",
+		},
+		{
+			desc: "server failure",
+			resolver: func(req *dns.Msg) *dns.Msg {
+				m := new(dns.Msg)
+				m.SetRcode(req, dns.RcodeServerFailure)
+				return m
+			},
+			err: "unexpected response for _lego-probe-",
+		},
+	}
+
+	for _, test := range testCases {
+		t.Run(test.desc, func(t *testing.T) {
+			var mu sync.Mutex
+			var queried []string
+
+			resolver := func(req *dns.Msg) *dns.Msg {
+				mu.Lock()
+				queried = append(queried, req.Question[0].Name)
+				mu.Unlock()
+
+				return test.resolver(req)
+			}
+
+			nameservers := []string{startFakeDNSServer(t, "udp", resolver)}
+
+			ttl, err := findNegativeTTL("_acme-challenge.www.example.com.", nameservers)
+
+			mu.Lock()
+			defer mu.Unlock()
+
+			require.NotEmpty(t, queried)
+			assert.True(t, strings.HasPrefix(queried[0], "_lego-probe-"), queried[0])
+			assert.True(t, strings.HasSuffix(queried[0], ".www.example.com."), queried[0])
+
+			if test.err != "" {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), test.err)
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, test.expected, ttl)
+		})
+	}
+}
+
+func Test_randomSibling(t *testing.T) {
+	a, err := randomSibling("_acme-challenge.example.com.")
+	require.NoError(t, err)
+
+	b, err := randomSibling("_acme-challenge.example.com.")
+	require.NoError(t, err)
+
+	assert.NotEqual(t, a, b)
+	assert.Regexp(t, `^_lego-probe-[0-9a-f]{16}\.example\.com\.$`, a)
+
+	_, err = randomSibling("com")
+	require.EqualError(t, err, "invalid FQDN: com")
+}
@@ -0,0 +1,89 @@
+package dns01
+
+import (
+	"context"
+	"errors"
+	"sync"
+)
+
+// ParallelPropagationCheck queries the nameservers concurrently during each propagation check
+// (the authoritative nameservers, and the validation resolvers), instead of one after the other.
+// The latency of a check is the latency of the slowest nameserver, instead of the sum of the latencies.
+// The first failure stops the in-flight queries of the other nameservers.
+func ParallelPropagationCheck() ChallengeOption {
+	return func(chlg *Challenge) error {
+		chlg.preCheck.parallel = true
+		return nil
+	}
+}
+
+// checkAuthoritativeNssParallel queries the given nameservers concurrently for the expected TXT record.
+func checkAuthoritativeNssParallel(ctx context.Context, fqdn, value string, nameservers []string) (bool, error) {
+	answered, err := checkConcurrently(ctx, nameservers, func(ctx context.Context, ns string) error {
+		return checkAuthoritativeNs(ctx, fqdn, value, ns)
+	})
+	if err != nil {
+		return false, &nameserversError{answered: answered, total: len(nameservers), err: err}
+	}
+
+	return true, nil
+}
+
+// checkValidationResolversParallel queries the given recursive resolvers concurrently for the expected TXT record.
+func checkValidationResolversParallel(ctx context.Context, fqdn, value string, resolvers []string) (bool, error) {
+	_, err := checkConcurrently(ctx, resolvers, func(ctx context.Context, resolver string) error {
+		return checkValidationResolver(ctx, fqdn, value, resolver)
+	})
+	if err != nil {
+		return false, err
+	}
+
+	return true, nil
+}
+
+// checkConcurrently runs the check of each nameserver concurrently, and combines the results:
+// the number of nameservers for which the check succeeded,
+// and the error of the first failing nameserver (in the order of the nameservers).
+// The first failure, or the end of the parent context, cancels the checks in progress.
+func checkConcurrently(parent context.Context, nameservers []string, check func(ctx context.Context, ns string) error) (int, error) {
+	ctx, cancel := context.WithCancel(parent)
+	defer cancel()
+
+	errs := make([]error, len(nameservers))
+
+	var wg sync.WaitGroup
+	for i, ns := range nameservers {
+		wg.Add(1)
+
+		go func(i int, ns string) {
+			defer wg.Done()
+
+			errs[i] = check(ctx, ns)
+			if errs[i] != nil {
+				cancel()
+			}
+		}(i, ns)
+	}
+
+	wg.Wait()
+
+	var answered int
+	var firstErr error
+	for _, err := range errs {
+		switch {
+		case err == nil:
+			answered++
+		case errors.Is(err, context.Canceled):
+			// stopped because of the failure of another nameserver.
+		case firstErr == nil:
+			firstErr = err
+		}
+	}
+
+	if firstErr == nil && answered < len(nameservers) {
+		// stopped because of the end of the parent context.
+		firstErr = parent.Err()
+	}
+
+	return answered, firstErr
+}
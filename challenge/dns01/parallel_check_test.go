@@ -0,0 +1,143 @@
+package dns01
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/miekg/dns"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// barrierAnswer returns fakeResolvers answering only once all of them have received a query,
+// or with an empty answer after the timeout.
+func barrierAnswer(t *testing.T, count int, timeout time.Duration, value string) []fakeResolver {
+	t.Helper()
+
+	var wg sync.WaitGroup
+	wg.Add(count)
+
+	all := make(chan struct{})
+	go func() {
+		wg.Wait()
+		close(all)
+	}()
+
+	var resolvers []fakeResolver
+	for i := 0; i < count; i++ {
+		var once sync.Once
+		resolvers = append(resolvers, func(req *dns.Msg) *dns.Msg {
+			once.Do(wg.Done)
+
+			select {
+			case <-all:
+				return txtAnswer(value)(req)
+			case <-time.After(timeout):
+				return txtAnswer()(req)
+			}
+		})
+	}
+
+	return resolvers
+}
+
+func TestCheckAuthoritativeNssParallel_concurrentDispatch(t *testing.T) {
+	var nameservers []string
+	for _, resolver := range barrierAnswer(t, 3, 500*time.Millisecond, "value") {
+		nameservers = append(nameservers, startFakeDNSServer(t, "udp", resolver))
+	}
+
+	ok, err := checkAuthoritativeNssParallel(context.Background(), "_acme-challenge.example.com.", "value", nameservers)
+	require.NoError(t, err)
+	assert.True(t, ok)
+}
+
+func TestCheckAuthoritativeNss_sequentialDispatch(t *testing.T) {
+	var nameservers []string
+	for _, resolver := range barrierAnswer(t, 2, 100*time.Millisecond, "value") {
+		nameservers = append(nameservers, startFakeDNSServer(t, "udp", resolver))
+	}
+
+	// the first nameserver doesn't answer the value: the second one is queried after it.
+	ok, err := checkAuthoritativeNss(context.Background(), "_acme-challenge.example.com.", "value", nameservers)
+	require.Error(t, err)
+	assert.False(t, ok)
+}
+
+func TestCheckAuthoritativeNssParallel_combination(t *testing.T) {
+	upToDate := startFakeDNSServer(t, "udp", txtAnswer("value"))
+	// the failure is delayed: it must not cancel the queries to the up-to-date nameservers.
+	lagging := startFakeDNSServer(t, "udp", func(req *dns.Msg) *dns.Msg {
+		time.Sleep(200 * time.Millisecond)
+		return txtAnswer("old")(req)
+	})
+
+	ok, err := checkAuthoritativeNssParallel(context.Background(), "_acme-challenge.example.com.", "value", []string{upToDate, lagging, upToDate})
+	require.EqualError(t, err, "NS "+lagging+" did not return the expected TXT record [fqdn: _acme-challenge.example.com., value: value]: old")
+	assert.False(t, ok)
+
+	var nsErr *nameserversError
+	require.True(t, errors.As(err, &nsErr))
+	assert.Equal(t, 2, nsErr.answered)
+	assert.Equal(t, 3, nsErr.total)
+}
+
+func TestCheckValidationResolversParallel(t *testing.T) {
+	upToDate := startFakeDNSServer(t, "udp", txtAnswer("value"))
+	lagging := startFakeDNSServer(t, "udp", txtAnswer("old"))
+
+	ok, err := checkValidationResolversParallel(context.Background(), "_acme-challenge.example.com.", "value", []string{upToDate, upToDate})
+	require.NoError(t, err)
+	assert.True(t, ok)
+
+	ok, err = checkValidationResolversParallel(context.Background(), "_acme-challenge.example.com.", "value", []string{upToDate, lagging})
+	require.EqualError(t, err, "validation resolver "+lagging+" did not return the expected TXT record "+
+		"[fqdn: _acme-challenge.example.com., value: value]: old")
+	assert.False(t, ok)
+}
+
+func TestCheckConcurrently_cancel(t *testing.T) {
+	unblock := make(chan struct{})
+
+	var answered int32
+	hanging := startFakeDNSServer(t, "udp", func(req *dns.Msg) *dns.Msg {
+		<-unblock
+		atomic.AddInt32(&answered, 1)
+		return txtAnswer("value")(req)
+	})
+	t.Cleanup(func() { close(unblock) })
+
+	lagging := startFakeDNSServer(t, "udp", txtAnswer("old"))
+
+	start := time.Now()
+
+	ok, err := checkAuthoritativeNssParallel(context.Background(), "_acme-challenge.example.com.", "value", []string{hanging, lagging})
+	require.Error(t, err)
+	assert.False(t, ok)
+
+	// the query to the hanging nameserver has been stopped by the failure of the other one.
+	assert.Less(t, int64(time.Since(start)), int64(time.Second))
+	assert.Contains(t, err.Error(), "NS "+lagging+" did not return the expected TXT record")
+	assert.Equal(t, int32(0), atomic.LoadInt32(&answered))
+}
+
+func TestExchange_contextCanceled(t *testing.T) {
+	unblock := make(chan struct{})
+
+	hanging := startFakeDNSServer(t, "udp", func(req *dns.Msg) *dns.Msg {
+		<-unblock
+		return txtAnswer("value")(req)
+	})
+	t.Cleanup(func() { close(unblock) })
+
+	ctx, cancel := context.WithCancel(context.Background())
+
+	time.AfterFunc(50*time.Millisecond, cancel)
+
+	_, err := dnsQueryContext(ctx, "_acme-challenge.example.com.", dns.TypeTXT, []string{hanging}, false)
+	require.True(t, errors.Is(err, context.Canceled))
+}
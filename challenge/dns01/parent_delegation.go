@@ -0,0 +1,77 @@
+package dns01
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/miekg/dns"
+)
+
+// lookupDelegationNameservers returns the nameservers of the zone of the FQDN, as delegated by the parent zone
+// (the NS records of the referral returned by the nameservers of the parent zone).
+// During a migration, they can differ from the NS records published by the zone itself.
+// The zone is found by walking at most maxDepth labels.
+func lookupDelegationNameservers(fqdn string, maxDepth int) ([]string, error) {
+	zone, err := findZoneByFqdn(fqdn, recursiveNameservers, maxDepth)
+	if err != nil {
+		return nil, fmt.Errorf("could not determine the zone: %w", err)
+	}
+
+	labels := dns.SplitDomainName(zone)
+	if len(labels) < 2 {
+		return nil, fmt.Errorf("no parent zone for %s", zone)
+	}
+
+	parentZone, err := findZoneByFqdn(dns.Fqdn(strings.Join(labels[1:], ".")), recursiveNameservers, maxDepth)
+	if err != nil {
+		return nil, fmt.Errorf("could not determine the parent zone of %s: %w", zone, err)
+	}
+
+	parentNss, err := lookupZoneNameservers(parentZone)
+	if err != nil {
+		return nil, fmt.Errorf("could not determine the nameservers of the parent zone %s: %w", parentZone, err)
+	}
+
+	return queryDelegation(zone, parentNss)
+}
+
+// queryDelegation queries the nameservers of the parent zone for the delegation of the zone,
+// and returns the nameservers of the first answer.
+func queryDelegation(zone string, parentNss []string) ([]string, error) {
+	var lastErr error
+
+	for _, ns := range parentNss {
+		r, err := dnsQuery(zone, dns.TypeNS, []string{withDefaultPort(ns)}, false)
+		if err != nil {
+			lastErr = err
+			continue
+		}
+
+		if r.Rcode != dns.RcodeSuccess {
+			lastErr = fmt.Errorf("NS %s returned %s for %s", ns, dns.RcodeToString[r.Rcode], zone)
+			continue
+		}
+
+		// the delegation is in the authority section of a referral,
+		// or in the answer section when the nameserver is also authoritative for the zone.
+		var nameservers []string
+		for _, rr := range append(r.Ns, r.Answer...) {
+			if rec, ok := rr.(*dns.NS); ok && strings.EqualFold(rec.Hdr.Name, zone) {
+				nameservers = append(nameservers, strings.ToLower(rec.Ns))
+			}
+		}
+
+		if len(nameservers) > 0 {
+			return nameservers, nil
+		}
+
+		lastErr = fmt.Errorf("NS %s returned no delegation for %s", ns, zone)
+	}
+
+	if lastErr == nil {
+		lastErr = errors.New("no nameservers")
+	}
+
+	return nil, fmt.Errorf("could not determine the delegation of %s: %w", zone, lastErr)
+}
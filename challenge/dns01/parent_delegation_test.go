@@ -0,0 +1,125 @@
+package dns01
+
+import (
+	"testing"
+
+	"github.com/miekg/dns"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// referralAnswer returns a referral to the given nameservers (NS records in the authority section).
+func referralAnswer(nameservers ...string) fakeResolver {
+	return func(req *dns.Msg) *dns.Msg {
+		m := new(dns.Msg)
+		m.SetReply(req)
+
+		for _, ns := range nameservers {
+			m.Ns = append(m.Ns, &dns.NS{
+				Hdr: dns.RR_Header{Name: req.Question[0].Name, Rrtype: dns.TypeNS, Class: dns.ClassINET, Ttl: 0},
+				Ns:  ns,
+			})
+		}
+
+		return m
+	}
+}
+
+func Test_queryDelegation(t *testing.T) {
+	refused := func(req *dns.Msg) *dns.Msg {
+		m := new(dns.Msg)
+		m.SetReply(req)
+		m.Rcode = dns.RcodeRefused
+		return m
+	}
+
+	otherZone := func(req *dns.Msg) *dns.Msg {
+		m := new(dns.Msg)
+		m.SetReply(req)
+		m.Ns = append(m.Ns, &dns.NS{
+			Hdr: dns.RR_Header{Name: "com.", Rrtype: dns.TypeNS, Class: dns.ClassINET, Ttl: 0},
+			Ns:  "a.gtld-servers.net.",
+		})
+		return m
+	}
+
+	testCases := []struct {
+		desc          string
+		parents       []fakeResolver
+		expected      []string
+		expectedError string
+	}{
+		{
+			desc:     "referral",
+			parents:  []fakeResolver{referralAnswer("NS1.new-provider.net.", "ns2.new-provider.net.")},
+			expected: []string{"ns1.new-provider.net.", "ns2.new-provider.net."},
+		},
+		{
+			desc:     "authoritative answer",
+			parents:  []fakeResolver{nsAnswer("ns1.new-provider.net.")},
+			expected: []string{"ns1.new-provider.net."},
+		},
+		{
+			desc:     "first parent nameserver refuses",
+			parents:  []fakeResolver{refused, referralAnswer("ns1.new-provider.net.")},
+			expected: []string{"ns1.new-provider.net."},
+		},
+		{
+			desc:          "NS records of another zone",
+			parents:       []fakeResolver{otherZone},
+			expectedError: "could not determine the delegation of example.com.: NS 127.0.0.1:",
+		},
+		{
+			desc:          "all parent nameservers refuse",
+			parents:       []fakeResolver{refused},
+			expectedError: "REFUSED for example.com.",
+		},
+		{
+			desc:          "no parent nameservers",
+			expectedError: "could not determine the delegation of example.com.: no nameservers",
+		},
+	}
+
+	for _, test := range testCases {
+		t.Run(test.desc, func(t *testing.T) {
+			var parentNss []string
+			for _, parent := range test.parents {
+				parentNss = append(parentNss, startFakeDNSServer(t, "udp", parent))
+			}
+
+			nameservers, err := queryDelegation("example.com.", parentNss)
+			if test.expectedError != "" {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), test.expectedError)
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, test.expected, nameservers)
+		})
+	}
+}
+
+func Test_queryDelegation_parentDiffersFromChild(t *testing.T) {
+	// the zone still publishes the nameservers of the previous provider, the parent zone delegates to the new provider.
+	useRecursiveNameservers(t, startFakeDNSServer(t, "udp", nsAnswer("ns1.old-provider.net.", "ns2.old-provider.net.")))
+	parent := startFakeDNSServer(t, "udp", referralAnswer("ns1.new-provider.net.", "ns2.new-provider.net."))
+
+	child, err := lookupZoneNameservers("example.com.")
+	require.NoError(t, err)
+	assert.Equal(t, []string{"ns1.old-provider.net.", "ns2.old-provider.net."}, child)
+
+	delegation, err := queryDelegation("example.com.", []string{parent})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"ns1.new-provider.net.", "ns2.new-provider.net."}, delegation)
+}
+
+func Test_lookupDelegationNameservers_noParent(t *testing.T) {
+	ClearFqdnCache()
+	defer ClearFqdnCache()
+
+	seedFqdnCache("_acme-challenge.com.", "com.")
+
+	_, err := lookupDelegationNameservers("_acme-challenge.com.", DefaultSOAMaxDepth)
+	require.EqualError(t, err, "no parent zone for com.")
+}
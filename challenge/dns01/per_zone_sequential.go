@@ -0,0 +1,80 @@
+package dns01
+
+import (
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/go-acme/lego/v4/challenge"
+)
+
+// PerZoneSequentialProvider serializes the calls to a provider (Present and CleanUp) for the same zone,
+// the calls for different zones are still concurrent.
+// It's useful for the APIs rewriting the whole zone on each change.
+type PerZoneSequentialProvider struct {
+	inner challenge.Provider
+
+	locks   map[string]*sync.Mutex
+	locksMu sync.Mutex
+
+	// used to find the zone without DNS queries in tests.
+	findZoneByFqdn func(fqdn string) (string, error)
+}
+
+// NewPerZoneSequentialProvider creates a PerZoneSequentialProvider.
+func NewPerZoneSequentialProvider(inner challenge.Provider) *PerZoneSequentialProvider {
+	return &PerZoneSequentialProvider{
+		inner:          inner,
+		locks:          make(map[string]*sync.Mutex),
+		findZoneByFqdn: FindZoneByFqdn,
+	}
+}
+
+// Present creates the TXT record, once the other calls for the same zone are done.
+func (p *PerZoneSequentialProvider) Present(domain, token, keyAuth string) error {
+	unlock, err := p.lock(domain, keyAuth)
+	if err != nil {
+		return err
+	}
+	defer unlock()
+
+	return p.inner.Present(domain, token, keyAuth)
+}
+
+// CleanUp removes the TXT record, once the other calls for the same zone are done.
+func (p *PerZoneSequentialProvider) CleanUp(domain, token, keyAuth string) error {
+	unlock, err := p.lock(domain, keyAuth)
+	if err != nil {
+		return err
+	}
+	defer unlock()
+
+	return p.inner.CleanUp(domain, token, keyAuth)
+}
+
+// Timeout returns the timeout and the interval of the wrapped provider.
+func (p *PerZoneSequentialProvider) Timeout() (timeout, interval time.Duration) {
+	return providerTimeout(p.inner)
+}
+
+// lock acquires the lock of the zone of the domain, and returns the function releasing it.
+func (p *PerZoneSequentialProvider) lock(domain, keyAuth string) (func(), error) {
+	fqdn, _ := GetRecord(domain, keyAuth)
+
+	zone, err := p.findZoneByFqdn(fqdn)
+	if err != nil {
+		return nil, fmt.Errorf("could not find the zone of %s: %w", fqdn, err)
+	}
+
+	p.locksMu.Lock()
+	mu, ok := p.locks[zone]
+	if !ok {
+		mu = &sync.Mutex{}
+		p.locks[zone] = mu
+	}
+	p.locksMu.Unlock()
+
+	mu.Lock()
+
+	return mu.Unlock, nil
+}
@@ -0,0 +1,149 @@
+package dns01
+
+import (
+	"errors"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// providerBlockingMock blocks the calls to Present until release is closed, and records the peak of concurrent calls.
+type providerBlockingMock struct {
+	release chan struct{}
+	started chan string
+
+	mu      sync.Mutex
+	running int
+	peak    int
+}
+
+func (p *providerBlockingMock) Present(domain, _, _ string) error {
+	p.mu.Lock()
+	p.running++
+	if p.running > p.peak {
+		p.peak = p.running
+	}
+	p.mu.Unlock()
+
+	p.started <- domain
+	<-p.release
+
+	p.mu.Lock()
+	p.running--
+	p.mu.Unlock()
+
+	return nil
+}
+
+func (p *providerBlockingMock) CleanUp(_, _, _ string) error {
+	return nil
+}
+
+func setupPerZoneSequential(inner *providerBlockingMock) *PerZoneSequentialProvider {
+	provider := NewPerZoneSequentialProvider(inner)
+	provider.findZoneByFqdn = func(fqdn string) (string, error) {
+		// the zone is the last 2 labels.
+		labels := strings.Split(strings.TrimSuffix(fqdn, "."), ".")
+		return strings.Join(labels[len(labels)-2:], ".") + ".", nil
+	}
+
+	return provider
+}
+
+func TestPerZoneSequentialProvider_Present_sameZone(t *testing.T) {
+	inner := &providerBlockingMock{release: make(chan struct{}), started: make(chan string, 2)}
+	provider := setupPerZoneSequential(inner)
+
+	var wg sync.WaitGroup
+	for _, domain := range []string{"a.example.com", "b.example.com"} {
+		wg.Add(1)
+		go func(domain string) {
+			defer wg.Done()
+			assert.NoError(t, provider.Present(domain, "token", "keyAuth"))
+		}(domain)
+	}
+
+	<-inner.started
+
+	// the second call must wait for the first one.
+	select {
+	case domain := <-inner.started:
+		t.Fatalf("the call for %s was not serialized", domain)
+	case <-time.After(100 * time.Millisecond):
+	}
+
+	close(inner.release)
+	<-inner.started
+	wg.Wait()
+
+	assert.Equal(t, 1, inner.peak)
+}
+
+func TestPerZoneSequentialProvider_Present_differentZones(t *testing.T) {
+	inner := &providerBlockingMock{release: make(chan struct{}), started: make(chan string, 2)}
+	provider := setupPerZoneSequential(inner)
+
+	var wg sync.WaitGroup
+	for _, domain := range []string{"a.example.com", "a.example.org"} {
+		wg.Add(1)
+		go func(domain string) {
+			defer wg.Done()
+			assert.NoError(t, provider.Present(domain, "token", "keyAuth"))
+		}(domain)
+	}
+
+	// the two calls must run at the same time.
+	for i := 0; i < 2; i++ {
+		select {
+		case <-inner.started:
+		case <-time.After(5 * time.Second):
+			t.Fatal("the calls for different zones were serialized")
+		}
+	}
+
+	close(inner.release)
+	wg.Wait()
+
+	assert.Equal(t, 2, inner.peak)
+}
+
+func TestPerZoneSequentialProvider_Present_zoneError(t *testing.T) {
+	inner := &providerRecorderMock{}
+
+	provider := NewPerZoneSequentialProvider(inner)
+	provider.findZoneByFqdn = func(_ string) (string, error) {
+		return "", errors.New("OOPS")
+	}
+
+	err := provider.Present("example.com", "token", "keyAuth")
+	require.EqualError(t, err, "could not find the zone of _acme-challenge.example.com.: OOPS")
+
+	assert.Empty(t, inner.presents)
+}
+
+func TestPerZoneSequentialProvider_CleanUp(t *testing.T) {
+	inner := &providerRecorderMock{}
+
+	provider := NewPerZoneSequentialProvider(inner)
+	provider.findZoneByFqdn = func(_ string) (string, error) {
+		return "example.com.", nil
+	}
+
+	err := provider.CleanUp("example.com", "token", "keyAuth")
+	require.NoError(t, err)
+
+	assert.Equal(t, []string{"example.com"}, inner.cleanUps)
+}
+
+func TestPerZoneSequentialProvider_Timeout(t *testing.T) {
+	inner := &providerTimeoutMock{timeout: 2 * time.Minute, interval: 10 * time.Second}
+
+	timeout, interval := NewPerZoneSequentialProvider(inner).Timeout()
+
+	assert.Equal(t, 2*time.Minute, timeout)
+	assert.Equal(t, 10*time.Second, interval)
+}
@@ -0,0 +1,62 @@
+package dns01
+
+import (
+	"context"
+	"fmt"
+	"math/rand"
+	"time"
+)
+
+// Poll calls fn until it reports done, with an exponential backoff between the calls.
+// The first delay is interval, then it is doubled after each call up to max.
+// A random jitter (up to half of the delay) is subtracted from each delay.
+//
+// Polling stops when fn returns an error (the error is returned),
+// or when the context is done (the context error is returned).
+// It allows the providers to wait for the consistency of their API (ex: a change applied asynchronously).
+func Poll(ctx context.Context, interval, max time.Duration, fn func() (bool, error)) error {
+	if interval <= 0 {
+		return fmt.Errorf("invalid poll interval: %s", interval)
+	}
+
+	if max < interval {
+		max = interval
+	}
+
+	delay := interval
+
+	for {
+		done, err := fn()
+		if err != nil {
+			return err
+		}
+
+		if done {
+			return nil
+		}
+
+		timer := time.NewTimer(withJitter(delay))
+
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return fmt.Errorf("poll: %w", ctx.Err())
+		case <-timer.C:
+		}
+
+		delay *= 2
+		if delay > max {
+			delay = max
+		}
+	}
+}
+
+// withJitter subtracts a random duration, up to the half of the delay.
+func withJitter(delay time.Duration) time.Duration {
+	half := int64(delay / 2)
+	if half <= 0 {
+		return delay
+	}
+
+	return delay - time.Duration(rand.Int63n(half))
+}
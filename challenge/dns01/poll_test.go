@@ -0,0 +1,74 @@
+package dns01
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPoll(t *testing.T) {
+	var calls int
+
+	err := Poll(context.Background(), time.Millisecond, 4*time.Millisecond, func() (bool, error) {
+		calls++
+		return calls == 5, nil
+	})
+	require.NoError(t, err)
+
+	assert.Equal(t, 5, calls)
+}
+
+func TestPoll_timeout(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+
+	var calls int
+
+	err := Poll(ctx, time.Millisecond, 10*time.Millisecond, func() (bool, error) {
+		calls++
+		return false, nil
+	})
+	require.Error(t, err)
+
+	assert.True(t, errors.Is(err, context.DeadlineExceeded))
+	assert.Greater(t, calls, 1)
+}
+
+func TestPoll_error(t *testing.T) {
+	var calls int
+
+	err := Poll(context.Background(), time.Millisecond, time.Second, func() (bool, error) {
+		calls++
+		if calls == 2 {
+			return false, errors.New("OOPS")
+		}
+
+		return false, nil
+	})
+	require.EqualError(t, err, "OOPS")
+
+	assert.Equal(t, 2, calls)
+}
+
+func TestPoll_invalidInterval(t *testing.T) {
+	err := Poll(context.Background(), 0, time.Second, func() (bool, error) {
+		t.Fatal("must not be called")
+		return false, nil
+	})
+	require.EqualError(t, err, "invalid poll interval: 0s")
+}
+
+func Test_withJitter(t *testing.T) {
+	for i := 0; i < 100; i++ {
+		delay := withJitter(10 * time.Second)
+
+		assert.LessOrEqual(t, int64(delay), int64(10*time.Second))
+		assert.Greater(t, int64(delay), int64(5*time.Second))
+	}
+
+	assert.Equal(t, time.Duration(1), withJitter(1))
+}
@@ -1,6 +1,7 @@
 package dns01
 
 import (
+	"context"
 	"fmt"
 	"net"
 	"strings"
@@ -30,31 +31,130 @@ func DisableCompletePropagationRequirement() ChallengeOption {
 	}
 }
 
+// NameserversProvider allows a Provider to give the authoritative nameservers of the zone
+// where the TXT record is created (ex: the NS records of the zone known by the provider API).
+// These nameservers are queried directly for the propagation check, bypassing the recursive nameservers.
+type NameserversProvider interface {
+	Nameservers(fqdn string) []string
+}
+
+// PropagationStatusProvider allows a Provider to report the propagation state of a record through its API
+// (ex: the sync status of the record on the nameservers of the provider).
+// When implemented, it is used for the propagation check instead of the DNS queries,
+// or as a first gate before the queries of the nameservers of the zone if the Provider implements NameserversProvider.
+type PropagationStatusProvider interface {
+	PropagationStatus(fqdn, value string) (bool, error)
+}
+
 type preCheck struct {
 	// checks DNS propagation before notifying ACME that the DNS challenge is ready.
 	checkFunc WrapPreCheckFunc
 	// require the TXT record to be propagated to all authoritative name servers
 	requireCompletePropagation bool
+	// recursive resolvers mirroring the resolvers of the CA, all of them must return the TXT record.
+	validationResolvers []string
+	// require the TXT record to be returned by the recursive nameservers too (PropagationBoth).
+	requireRecursive bool
+	// query the nameservers delegated by the parent zone instead of the nameservers of the zone (PropagationDelegation).
+	useDelegation bool
+	// the number of values the TXT record must contain on the authoritative nameservers (0: no requirement).
+	expectedAnswers int
+	// query the nameservers concurrently.
+	parallel bool
+	// the maximum number of labels walked to find the zone of the record.
+	soaMaxDepth int
 }
 
 func newPreCheck() preCheck {
 	return preCheck{
 		requireCompletePropagation: true,
+		soaMaxDepth:                DefaultSOAMaxDepth,
 	}
 }
 
-func (p preCheck) call(domain, fqdn, value string) (bool, error) {
+// call checks the propagation of the TXT record, the in-flight queries are stopped when the context is done.
+func (p preCheck) call(ctx context.Context, domain, fqdn, value string, nameservers []string, status PropagationStatusProvider) (bool, error) {
+	check := func(fqdn, value string) (bool, error) {
+		return p.checkDNSPropagation(ctx, fqdn, value)
+	}
+
+	switch {
+	case status != nil && len(nameservers) > 0 && !p.useDelegation:
+		check = func(fqdn, value string) (bool, error) {
+			stop, err := status.PropagationStatus(fqdn, value)
+			if !stop || err != nil {
+				return stop, err
+			}
+
+			return p.checkAuthoritativeNss(ctx, fqdn, value, nameservers)
+		}
+	case status != nil:
+		check = status.PropagationStatus
+	case len(nameservers) > 0 && !p.useDelegation:
+		check = func(fqdn, value string) (bool, error) {
+			return p.checkAuthoritativeNss(ctx, fqdn, value, nameservers)
+		}
+	}
+
+	if p.requireRecursive {
+		authoritativeCheck := check
+		check = func(fqdn, value string) (bool, error) {
+			stop, err := authoritativeCheck(fqdn, value)
+			if !stop || err != nil {
+				return stop, err
+			}
+
+			return checkRecursiveNss(fqdn, value)
+		}
+	}
+
+	if p.expectedAnswers > 0 {
+		valueCheck := check
+		check = func(fqdn, value string) (bool, error) {
+			stop, err := valueCheck(fqdn, value)
+			if !stop || err != nil {
+				return stop, err
+			}
+
+			authoritativeNss := nameservers
+			if len(authoritativeNss) == 0 {
+				authoritativeNss, err = lookupNameservers(fqdn, p.soaMaxDepth)
+				if err != nil {
+					return false, err
+				}
+			}
+
+			return checkAnswerCount(fqdn, p.expectedAnswers, authoritativeNss)
+		}
+	}
+
+	if len(p.validationResolvers) > 0 {
+		mainCheck := check
+		check = func(fqdn, value string) (bool, error) {
+			stop, err := mainCheck(fqdn, value)
+			if !stop || err != nil {
+				return stop, err
+			}
+
+			if p.parallel {
+				return checkValidationResolversParallel(ctx, fqdn, value, p.validationResolvers)
+			}
+
+			return checkValidationResolvers(ctx, fqdn, value, p.validationResolvers)
+		}
+	}
+
 	if p.checkFunc == nil {
-		return p.checkDNSPropagation(fqdn, value)
+		return check(fqdn, value)
 	}
 
-	return p.checkFunc(domain, fqdn, value, p.checkDNSPropagation)
+	return p.checkFunc(domain, fqdn, value, check)
 }
 
 // checkDNSPropagation checks if the expected TXT record has been propagated to all authoritative nameservers.
-func (p preCheck) checkDNSPropagation(fqdn, value string) (bool, error) {
+func (p preCheck) checkDNSPropagation(ctx context.Context, fqdn, value string) (bool, error) {
 	// Initial attempt to resolve at the recursive NS
-	r, err := dnsQuery(fqdn, dns.TypeTXT, recursiveNameservers, true)
+	r, err := dnsQueryContext(ctx, fqdn, dns.TypeTXT, recursiveNameservers, true)
 	if err != nil {
 		return false, err
 	}
@@ -67,44 +167,80 @@ func (p preCheck) checkDNSPropagation(fqdn, value string) (bool, error) {
 		fqdn = updateDomainWithCName(r, fqdn)
 	}
 
-	authoritativeNss, err := lookupNameservers(fqdn)
+	lookup := lookupNameservers
+	if p.useDelegation {
+		lookup = lookupDelegationNameservers
+	}
+
+	authoritativeNss, err := lookup(fqdn, p.soaMaxDepth)
 	if err != nil {
 		return false, err
 	}
 
-	return checkAuthoritativeNss(fqdn, value, authoritativeNss)
+	return p.checkAuthoritativeNss(ctx, fqdn, value, authoritativeNss)
+}
+
+// checkAuthoritativeNss queries the given nameservers for the expected TXT record, one after the other or concurrently.
+func (p preCheck) checkAuthoritativeNss(ctx context.Context, fqdn, value string, nameservers []string) (bool, error) {
+	if p.parallel {
+		return checkAuthoritativeNssParallel(ctx, fqdn, value, nameservers)
+	}
+
+	return checkAuthoritativeNss(ctx, fqdn, value, nameservers)
+}
+
+// withDefaultPort adds the default DNS port to the nameserver if needed.
+func withDefaultPort(ns string) string {
+	if _, _, err := net.SplitHostPort(ns); err != nil {
+		return net.JoinHostPort(ns, "53")
+	}
+
+	return ns
+}
+
+// nameserversError reports how many of the authoritative nameservers returned the expected TXT record before a failure.
+type nameserversError struct {
+	answered int
+	total    int
+	err      error
+}
+
+func (e *nameserversError) Error() string {
+	return e.err.Error()
+}
+
+func (e *nameserversError) Unwrap() error {
+	return e.err
 }
 
 // checkAuthoritativeNss queries each of the given nameservers for the expected TXT record.
-func checkAuthoritativeNss(fqdn, value string, nameservers []string) (bool, error) {
-	for _, ns := range nameservers {
-		r, err := dnsQuery(fqdn, dns.TypeTXT, []string{net.JoinHostPort(ns, "53")}, false)
+func checkAuthoritativeNss(ctx context.Context, fqdn, value string, nameservers []string) (bool, error) {
+	for i, ns := range nameservers {
+		err := checkAuthoritativeNs(ctx, fqdn, value, ns)
 		if err != nil {
-			return false, err
+			return false, &nameserversError{answered: i, total: len(nameservers), err: err}
 		}
+	}
 
-		if r.Rcode != dns.RcodeSuccess {
-			return false, fmt.Errorf("NS %s returned %s for %s", ns, dns.RcodeToString[r.Rcode], fqdn)
-		}
+	return true, nil
+}
 
-		var records []string
+// checkAuthoritativeNs queries the nameserver for the expected TXT record.
+func checkAuthoritativeNs(ctx context.Context, fqdn, value, ns string) error {
+	r, err := dnsQueryContext(ctx, fqdn, dns.TypeTXT, []string{withDefaultPort(ns)}, false)
+	if err != nil {
+		return queryError(ns, err)
+	}
 
-		var found bool
-		for _, rr := range r.Answer {
-			if txt, ok := rr.(*dns.TXT); ok {
-				record := strings.Join(txt.Txt, "")
-				records = append(records, record)
-				if record == value {
-					found = true
-					break
-				}
-			}
-		}
+	if r.Rcode != dns.RcodeSuccess {
+		return rcodeError(ns, r.Rcode, fmt.Errorf("NS %s returned %s for %s", ns, dns.RcodeToString[r.Rcode], fqdn))
+	}
 
-		if !found {
-			return false, fmt.Errorf("NS %s did not return the expected TXT record [fqdn: %s, value: %s]: %s", ns, fqdn, value, strings.Join(records, " ,"))
-		}
+	records, found := findTXTValue(r, value)
+	if !found {
+		return recordsError(ns, records,
+			fmt.Errorf("NS %s did not return the expected TXT record [fqdn: %s, value: %s]: %s", ns, fqdn, value, strings.Join(records, " ,")))
 	}
 
-	return true, nil
+	return nil
 }
@@ -1,8 +1,19 @@
 package dns01
 
 import (
+	"context"
+	"crypto/rand"
+	"crypto/rsa"
+	"net/http"
+	"sync"
 	"testing"
+	"time"
 
+	"github.com/go-acme/lego/v4/acme"
+	"github.com/go-acme/lego/v4/acme/api"
+	"github.com/go-acme/lego/v4/challenge"
+	"github.com/go-acme/lego/v4/platform/tester"
+	"github.com/miekg/dns"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 )
@@ -35,7 +46,7 @@ func TestCheckDNSPropagation(t *testing.T) {
 
 			check := newPreCheck()
 
-			ok, err := check.checkDNSPropagation(test.fqdn, test.value)
+			ok, err := check.checkDNSPropagation(context.Background(), test.fqdn, test.value)
 			if test.expectError {
 				assert.Errorf(t, err, "PreCheckDNS must failed for %s", test.fqdn)
 				assert.False(t, ok, "PreCheckDNS must failed for %s", test.fqdn)
@@ -74,7 +85,7 @@ func TestCheckAuthoritativeNss(t *testing.T) {
 			t.Parallel()
 			ClearFqdnCache()
 
-			ok, _ := checkAuthoritativeNss(test.fqdn, test.value, test.ns)
+			ok, _ := checkAuthoritativeNss(context.Background(), test.fqdn, test.value, test.ns)
 			assert.Equal(t, test.expected, ok, test.fqdn)
 		})
 	}
@@ -109,9 +120,135 @@ func TestCheckAuthoritativeNssErr(t *testing.T) {
 			t.Parallel()
 			ClearFqdnCache()
 
-			_, err := checkAuthoritativeNss(test.fqdn, test.value, test.ns)
+			_, err := checkAuthoritativeNss(context.Background(), test.fqdn, test.value, test.ns)
 			require.Error(t, err)
 			assert.Contains(t, err.Error(), test.error)
 		})
 	}
 }
+
+type providerNameserversMock struct {
+	providerTimeoutMock
+	nameservers []string
+}
+
+func (p *providerNameserversMock) Nameservers(_ string) []string { return p.nameservers }
+
+func TestChallenge_Solve_providerNameservers(t *testing.T) {
+	_, apiURL, tearDown := tester.SetupFakeAPI()
+	defer tearDown()
+
+	privateKey, err := rsa.GenerateKey(rand.Reader, 512)
+	require.NoError(t, err)
+
+	core, err := api.New(http.DefaultClient, "lego-test", apiURL+"/dir", "", privateKey)
+	require.NoError(t, err)
+
+	keyAuth, err := core.GetKeyAuthorization("token")
+	require.NoError(t, err)
+
+	fqdn, value := GetRecord("example.com", keyAuth)
+
+	var mu sync.Mutex
+	var queried []string
+	resolver := func(req *dns.Msg) *dns.Msg {
+		mu.Lock()
+		queried = append(queried, req.Question[0].Name)
+		mu.Unlock()
+
+		return txtAnswer(value)(req)
+	}
+
+	provider := &providerNameserversMock{
+		providerTimeoutMock: providerTimeoutMock{timeout: 2 * time.Second, interval: 10 * time.Millisecond},
+		nameservers:         []string{startFakeDNSServer(t, "udp", resolver)},
+	}
+
+	validate := func(_ *api.Core, _ string, _ acme.Challenge) error { return nil }
+
+	chlg := NewChallenge(core, validate, provider)
+
+	authz := acme.Authorization{
+		Identifier: acme.Identifier{
+			Value: "example.com",
+		},
+		Challenges: []acme.Challenge{
+			{Type: challenge.DNS01.String(), Token: "token"},
+		},
+	}
+
+	err = chlg.Solve(authz)
+	require.NoError(t, err)
+
+	mu.Lock()
+	defer mu.Unlock()
+
+	assert.Equal(t, []string{fqdn}, queried)
+}
+
+type providerPropagationStatusMock struct {
+	providerNameserversMock
+	checks []string
+}
+
+func (p *providerPropagationStatusMock) PropagationStatus(fqdn, value string) (bool, error) {
+	p.checks = append(p.checks, fqdn+" "+value)
+	return len(p.checks) > 1, nil
+}
+
+func TestChallenge_Solve_providerPropagationStatus(t *testing.T) {
+	_, apiURL, tearDown := tester.SetupFakeAPI()
+	defer tearDown()
+
+	privateKey, err := rsa.GenerateKey(rand.Reader, 512)
+	require.NoError(t, err)
+
+	core, err := api.New(http.DefaultClient, "lego-test", apiURL+"/dir", "", privateKey)
+	require.NoError(t, err)
+
+	keyAuth, err := core.GetKeyAuthorization("token")
+	require.NoError(t, err)
+
+	fqdn, value := GetRecord("example.com", keyAuth)
+
+	var mu sync.Mutex
+	var queried []string
+	resolver := func(req *dns.Msg) *dns.Msg {
+		mu.Lock()
+		queried = append(queried, req.Question[0].Name)
+		mu.Unlock()
+
+		return txtAnswer(value)(req)
+	}
+
+	provider := &providerPropagationStatusMock{
+		providerNameserversMock: providerNameserversMock{
+			providerTimeoutMock: providerTimeoutMock{timeout: 2 * time.Second, interval: 10 * time.Millisecond},
+			nameservers:         []string{startFakeDNSServer(t, "udp", resolver)},
+		},
+	}
+
+	validate := func(_ *api.Core, _ string, _ acme.Challenge) error { return nil }
+
+	chlg := NewChallenge(core, validate, provider)
+
+	authz := acme.Authorization{
+		Identifier: acme.Identifier{
+			Value: "example.com",
+		},
+		Challenges: []acme.Challenge{
+			{Type: challenge.DNS01.String(), Token: "token"},
+		},
+	}
+
+	err = chlg.Solve(authz)
+	require.NoError(t, err)
+
+	assert.Equal(t, []string{fqdn + " " + value, fqdn + " " + value}, provider.checks)
+
+	mu.Lock()
+	defer mu.Unlock()
+
+	// the nameservers of the zone are queried once the API reports the record.
+	assert.Equal(t, []string{fqdn}, queried)
+}
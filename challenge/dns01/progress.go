@@ -0,0 +1,69 @@
+package dns01
+
+import (
+	"errors"
+	"time"
+)
+
+// PropagationProgress the state of the DNS propagation check, reported after each check.
+type PropagationProgress struct {
+	Domain string
+	FQDN   string
+	// Attempt the number of the check (starting at 1).
+	Attempt   int
+	Elapsed   time.Duration
+	Remaining time.Duration
+	// Answered the number of authoritative nameservers returning the expected TXT record, out of Nameservers.
+	// Both are 0 when unknown (ex: the propagation status is reported by the provider).
+	Answered    int
+	Nameservers int
+	Propagated  bool
+	Err         error
+}
+
+// PropagationProgressFunc receives the progress of the DNS propagation check.
+// It is called synchronously by the challenge, so it must not block.
+type PropagationProgressFunc func(progress PropagationProgress)
+
+// AddPropagationProgress defines a callback receiving the progress of the DNS propagation check.
+// By default, the progress is not reported.
+func AddPropagationProgress(fn PropagationProgressFunc) ChallengeOption {
+	return func(chlg *Challenge) error {
+		if fn == nil {
+			return errors.New("propagation progress callback is nil")
+		}
+
+		chlg.progress = fn
+		return nil
+	}
+}
+
+func noopPropagationProgress(PropagationProgress) {}
+
+func newPropagationProgress(domain, fqdn string, attempt int, elapsed, timeout time.Duration, nameservers []string, stop bool, err error) PropagationProgress {
+	progress := PropagationProgress{
+		Domain:     domain,
+		FQDN:       fqdn,
+		Attempt:    attempt,
+		Elapsed:    elapsed,
+		Remaining:  timeout - elapsed,
+		Propagated: stop && err == nil,
+		Err:        err,
+	}
+
+	if progress.Remaining < 0 {
+		progress.Remaining = 0
+	}
+
+	var nsErr *nameserversError
+	switch {
+	case errors.As(err, &nsErr):
+		progress.Answered = nsErr.answered
+		progress.Nameservers = nsErr.total
+	case progress.Propagated && len(nameservers) > 0:
+		progress.Answered = len(nameservers)
+		progress.Nameservers = len(nameservers)
+	}
+
+	return progress
+}
@@ -0,0 +1,98 @@
+package dns01
+
+import (
+	"crypto/rand"
+	"crypto/rsa"
+	"net/http"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/go-acme/lego/v4/acme"
+	"github.com/go-acme/lego/v4/acme/api"
+	"github.com/go-acme/lego/v4/challenge"
+	"github.com/go-acme/lego/v4/platform/tester"
+	"github.com/miekg/dns"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestChallenge_Solve_propagationProgress(t *testing.T) {
+	_, apiURL, tearDown := tester.SetupFakeAPI()
+	defer tearDown()
+
+	privateKey, err := rsa.GenerateKey(rand.Reader, 512)
+	require.NoError(t, err)
+
+	core, err := api.New(http.DefaultClient, "lego-test", apiURL+"/dir", "", privateKey)
+	require.NoError(t, err)
+
+	keyAuth, err := core.GetKeyAuthorization("token")
+	require.NoError(t, err)
+
+	fqdn, value := GetRecord("example.com", keyAuth)
+
+	// the second nameserver returns the record from the third query.
+	var queries int32
+	lagging := func(req *dns.Msg) *dns.Msg {
+		if atomic.AddInt32(&queries, 1) < 3 {
+			return txtAnswer()(req)
+		}
+		return txtAnswer(value)(req)
+	}
+
+	provider := &providerNameserversMock{
+		providerTimeoutMock: providerTimeoutMock{timeout: 2 * time.Second, interval: 10 * time.Millisecond},
+		nameservers: []string{
+			startFakeDNSServer(t, "udp", txtAnswer(value)),
+			startFakeDNSServer(t, "udp", lagging),
+		},
+	}
+
+	validate := func(_ *api.Core, _ string, _ acme.Challenge) error { return nil }
+
+	var progresses []PropagationProgress
+	chlg := NewChallenge(core, validate, provider, AddPropagationProgress(func(progress PropagationProgress) {
+		progresses = append(progresses, progress)
+	}))
+
+	authz := acme.Authorization{
+		Identifier: acme.Identifier{
+			Value: "example.com",
+		},
+		Challenges: []acme.Challenge{
+			{Type: challenge.DNS01.String(), Token: "token"},
+		},
+	}
+
+	err = chlg.Solve(authz)
+	require.NoError(t, err)
+
+	require.Len(t, progresses, 3)
+
+	for i, progress := range progresses {
+		assert.Equal(t, "example.com", progress.Domain)
+		assert.Equal(t, fqdn, progress.FQDN)
+		assert.Equal(t, i+1, progress.Attempt)
+		assert.Equal(t, 2, progress.Nameservers)
+
+		if i > 0 {
+			assert.Greater(t, int64(progress.Elapsed), int64(progresses[i-1].Elapsed))
+			assert.Less(t, int64(progress.Remaining), int64(progresses[i-1].Remaining))
+		}
+	}
+
+	assert.Equal(t, 1, progresses[0].Answered)
+	assert.False(t, progresses[0].Propagated)
+	assert.Error(t, progresses[0].Err)
+
+	last := progresses[len(progresses)-1]
+	assert.Equal(t, 2, last.Answered)
+	assert.True(t, last.Propagated)
+	assert.NoError(t, last.Err)
+}
+
+func TestAddPropagationProgress_nil(t *testing.T) {
+	err := AddPropagationProgress(nil)(&Challenge{})
+	require.EqualError(t, err, "propagation progress callback is nil")
+}
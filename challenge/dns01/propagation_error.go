@@ -0,0 +1,108 @@
+package dns01
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/miekg/dns"
+)
+
+// PropagationFailure is the category of the failure of the DNS propagation check.
+type PropagationFailure string
+
+const (
+	// PropagationNoAnswer the nameservers never returned the TXT record (ex: NXDOMAIN, empty answer).
+	PropagationNoAnswer PropagationFailure = "no answer"
+	// PropagationMismatch the nameservers returned the TXT record without the expected value
+	// (ex: a stale value, or not the expected number of values).
+	PropagationMismatch PropagationFailure = "mismatch"
+	// PropagationQueryError the DNS queries failed (ex: network error, SERVFAIL, REFUSED).
+	PropagationQueryError PropagationFailure = "query error"
+)
+
+// PropagationError is returned when the DNS propagation check times out.
+// It reports the category of the failure of the last check.
+type PropagationError struct {
+	Failure PropagationFailure
+	Domain  string
+	FQDN    string
+	Value   string
+	// Nameserver the nameserver (or resolver) of the last failure, empty when unknown.
+	Nameserver string
+	// Records the TXT values returned by the nameserver, when the failure is PropagationMismatch.
+	Records []string
+	Err     error
+}
+
+func (e *PropagationError) Error() string {
+	return fmt.Sprintf("propagation check failed (%s): %v", e.Failure, e.Err)
+}
+
+func (e *PropagationError) Unwrap() error {
+	return e.Err
+}
+
+// newPropagationError categorizes the failure of the propagation check (err) from the error of the last failed check.
+// Without error (ex: the propagation status reported by the provider), the record has never been seen.
+func newPropagationError(domain, fqdn, value string, lastErr, err error) *PropagationError {
+	pErr := &PropagationError{
+		Failure: PropagationNoAnswer,
+		Domain:  domain,
+		FQDN:    fqdn,
+		Value:   value,
+		Err:     err,
+	}
+
+	var rErr *recordCheckError
+	switch {
+	case errors.As(lastErr, &rErr):
+		pErr.Failure = rErr.failure
+		pErr.Nameserver = rErr.nameserver
+		pErr.Records = rErr.records
+	case lastErr != nil:
+		// the last check failed without details (ex: the lookup of the authoritative nameservers).
+		pErr.Failure = PropagationQueryError
+	}
+
+	return pErr
+}
+
+// recordCheckError is the failure of the check of the TXT record on a nameserver.
+type recordCheckError struct {
+	failure    PropagationFailure
+	nameserver string
+	records    []string
+	err        error
+}
+
+func (e *recordCheckError) Error() string {
+	return e.err.Error()
+}
+
+func (e *recordCheckError) Unwrap() error {
+	return e.err
+}
+
+// queryError reports a failed DNS query.
+func queryError(ns string, err error) error {
+	return &recordCheckError{failure: PropagationQueryError, nameserver: ns, err: err}
+}
+
+// rcodeError reports an unsuccessful response: NXDOMAIN means that the record doesn't exist (yet).
+func rcodeError(ns string, rcode int, err error) error {
+	failure := PropagationQueryError
+	if rcode == dns.RcodeNameError {
+		failure = PropagationNoAnswer
+	}
+
+	return &recordCheckError{failure: failure, nameserver: ns, err: err}
+}
+
+// recordsError reports a response without the expected TXT values.
+func recordsError(ns string, records []string, err error) error {
+	if len(records) == 0 {
+		return &recordCheckError{failure: PropagationNoAnswer, nameserver: ns, err: err}
+	}
+
+	return &recordCheckError{failure: PropagationMismatch, nameserver: ns, records: records, err: err}
+}
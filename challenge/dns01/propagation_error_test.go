@@ -0,0 +1,154 @@
+package dns01
+
+import (
+	"crypto/rand"
+	"crypto/rsa"
+	"errors"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/go-acme/lego/v4/acme"
+	"github.com/go-acme/lego/v4/acme/api"
+	"github.com/go-acme/lego/v4/challenge"
+	"github.com/go-acme/lego/v4/platform/tester"
+	"github.com/miekg/dns"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestChallenge_Solve_propagationError(t *testing.T) {
+	_, apiURL, tearDown := tester.SetupFakeAPI()
+	defer tearDown()
+
+	privateKey, err := rsa.GenerateKey(rand.Reader, 512)
+	require.NoError(t, err)
+
+	core, err := api.New(http.DefaultClient, "lego-test", apiURL+"/dir", "", privateKey)
+	require.NoError(t, err)
+
+	keyAuth, err := core.GetKeyAuthorization("token")
+	require.NoError(t, err)
+
+	fqdn, value := GetRecord("example.com", keyAuth)
+
+	rcodeAnswer := func(rcode int) fakeResolver {
+		return func(req *dns.Msg) *dns.Msg {
+			m := new(dns.Msg)
+			m.SetReply(req)
+			m.Rcode = rcode
+			return m
+		}
+	}
+
+	testCases := []struct {
+		desc            string
+		resolver        fakeResolver
+		expected        PropagationFailure
+		expectedRecords []string
+	}{
+		{
+			desc:     "empty answer",
+			resolver: txtAnswer(),
+			expected: PropagationNoAnswer,
+		},
+		{
+			desc:     "NXDOMAIN",
+			resolver: rcodeAnswer(dns.RcodeNameError),
+			expected: PropagationNoAnswer,
+		},
+		{
+			desc:            "other value",
+			resolver:        txtAnswer("stale"),
+			expected:        PropagationMismatch,
+			expectedRecords: []string{"stale"},
+		},
+		{
+			desc:     "SERVFAIL",
+			resolver: rcodeAnswer(dns.RcodeServerFailure),
+			expected: PropagationQueryError,
+		},
+		{
+			desc: "network error",
+			resolver: func(req *dns.Msg) *dns.Msg {
+				m := txtAnswer(value)(req)
+				// an answer to another query.
+				m.Id++
+				return m
+			},
+			expected: PropagationQueryError,
+		},
+	}
+
+	for _, test := range testCases {
+		t.Run(test.desc, func(t *testing.T) {
+			ns := startFakeDNSServer(t, "udp", test.resolver)
+
+			provider := &providerNameserversMock{
+				providerTimeoutMock: providerTimeoutMock{timeout: 50 * time.Millisecond, interval: 10 * time.Millisecond},
+				nameservers:         []string{ns},
+			}
+
+			validate := func(_ *api.Core, _ string, _ acme.Challenge) error { return nil }
+
+			chlg := NewChallenge(core, validate, provider)
+
+			authz := acme.Authorization{
+				Identifier: acme.Identifier{
+					Value: "example.com",
+				},
+				Challenges: []acme.Challenge{
+					{Type: challenge.DNS01.String(), Token: "token"},
+				},
+			}
+
+			err = chlg.Solve(authz)
+			require.Error(t, err)
+
+			var pErr *PropagationError
+			require.True(t, errors.As(err, &pErr))
+
+			assert.Equal(t, test.expected, pErr.Failure)
+			assert.Equal(t, "example.com", pErr.Domain)
+			assert.Equal(t, fqdn, pErr.FQDN)
+			assert.Equal(t, value, pErr.Value)
+			assert.Equal(t, ns, pErr.Nameserver)
+			assert.Equal(t, test.expectedRecords, pErr.Records)
+			assert.Contains(t, err.Error(), "propagation check failed ("+string(test.expected)+"): time limit exceeded")
+		})
+	}
+}
+
+func Test_newPropagationError(t *testing.T) {
+	timeoutErr := errors.New("time limit exceeded")
+
+	testCases := []struct {
+		desc     string
+		lastErr  error
+		expected PropagationFailure
+	}{
+		{
+			desc:     "no error",
+			expected: PropagationNoAnswer,
+		},
+		{
+			desc:     "error without details",
+			lastErr:  errors.New("could not determine the zone"),
+			expected: PropagationQueryError,
+		},
+		{
+			desc:     "wrapped record error",
+			lastErr:  &nameserversError{err: recordsError("ns1", []string{"a", "b"}, errors.New("mismatch"))},
+			expected: PropagationMismatch,
+		},
+	}
+
+	for _, test := range testCases {
+		t.Run(test.desc, func(t *testing.T) {
+			pErr := newPropagationError("example.com", "_acme-challenge.example.com.", "value", test.lastErr, timeoutErr)
+
+			assert.Equal(t, test.expected, pErr.Failure)
+			assert.True(t, errors.Is(pErr, timeoutErr))
+		})
+	}
+}
@@ -0,0 +1,78 @@
+package dns01
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/miekg/dns"
+)
+
+// PropagationMode defines the nameservers which must return the TXT record for the propagation check.
+type PropagationMode string
+
+const (
+	// PropagationAuthoritative the TXT record must be returned by the authoritative nameservers (default).
+	PropagationAuthoritative PropagationMode = "authoritative"
+
+	// PropagationBoth the TXT record must be returned by the authoritative nameservers and by the recursive nameservers.
+	// It catches stale recursive caches as well as a lagging replication between the authoritative nameservers.
+	PropagationBoth PropagationMode = "both"
+
+	// PropagationDelegation the TXT record must be returned by the nameservers delegated by the parent zone,
+	// instead of the nameservers published by the zone itself.
+	// It mirrors the resolution of the CA while the delegation differs from the zone (ex: during a migration).
+	PropagationDelegation PropagationMode = "delegation"
+)
+
+// SetPropagationMode defines the nameservers which must return the TXT record for the propagation check.
+func SetPropagationMode(mode PropagationMode) ChallengeOption {
+	return func(chlg *Challenge) error {
+		switch mode {
+		case PropagationAuthoritative, PropagationBoth, PropagationDelegation:
+			chlg.preCheck.requireRecursive = mode == PropagationBoth
+			chlg.preCheck.useDelegation = mode == PropagationDelegation
+			return nil
+		default:
+			return fmt.Errorf("unknown propagation mode: %s", mode)
+		}
+	}
+}
+
+// checkRecursiveNss queries the recursive nameservers for the expected TXT record.
+func checkRecursiveNss(fqdn, value string) (bool, error) {
+	r, err := dnsQuery(fqdn, dns.TypeTXT, recursiveNameservers, true)
+	if err != nil {
+		return false, queryError("", fmt.Errorf("recursive nameservers: %w", err))
+	}
+
+	if r.Rcode != dns.RcodeSuccess {
+		return false, rcodeError("", r.Rcode, fmt.Errorf("recursive nameservers returned %s for %s", dns.RcodeToString[r.Rcode], fqdn))
+	}
+
+	records, found := findTXTValue(r, value)
+	if !found {
+		return false, recordsError("", records,
+			fmt.Errorf("recursive nameservers did not return the expected TXT record [fqdn: %s, value: %s]: %s",
+				fqdn, value, strings.Join(records, " ,")))
+	}
+
+	return true, nil
+}
+
+// findTXTValue looks for the value in the TXT records of the answer.
+// It returns the values read until the value is found.
+func findTXTValue(r *dns.Msg, value string) ([]string, bool) {
+	var records []string
+
+	for _, rr := range r.Answer {
+		if txt, ok := rr.(*dns.TXT); ok {
+			record := strings.Join(txt.Txt, "")
+			records = append(records, record)
+			if record == value {
+				return records, true
+			}
+		}
+	}
+
+	return records, false
+}
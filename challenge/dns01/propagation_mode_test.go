@@ -0,0 +1,146 @@
+package dns01
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/rsa"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/go-acme/lego/v4/acme"
+	"github.com/go-acme/lego/v4/acme/api"
+	"github.com/go-acme/lego/v4/challenge"
+	"github.com/go-acme/lego/v4/platform/tester"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// useRecursiveNameservers replaces the recursive nameservers for the duration of the test.
+func useRecursiveNameservers(t *testing.T, nameservers ...string) {
+	t.Helper()
+
+	original := recursiveNameservers
+	t.Cleanup(func() { recursiveNameservers = original })
+
+	recursiveNameservers = nameservers
+}
+
+func TestSetPropagationMode(t *testing.T) {
+	chlg := &Challenge{preCheck: newPreCheck()}
+
+	err := SetPropagationMode(PropagationBoth)(chlg)
+	require.NoError(t, err)
+	assert.True(t, chlg.preCheck.requireRecursive)
+
+	err = SetPropagationMode(PropagationDelegation)(chlg)
+	require.NoError(t, err)
+	assert.False(t, chlg.preCheck.requireRecursive)
+	assert.True(t, chlg.preCheck.useDelegation)
+
+	err = SetPropagationMode(PropagationAuthoritative)(chlg)
+	require.NoError(t, err)
+	assert.False(t, chlg.preCheck.requireRecursive)
+	assert.False(t, chlg.preCheck.useDelegation)
+
+	err = SetPropagationMode("recursive")(chlg)
+	require.EqualError(t, err, "unknown propagation mode: recursive")
+}
+
+func TestPreCheck_call_propagationBoth(t *testing.T) {
+	testCases := []struct {
+		desc          string
+		authoritative fakeResolver
+		recursive     fakeResolver
+		expected      bool
+		expectedErr   string
+	}{
+		{
+			desc:          "both agree",
+			authoritative: txtAnswer("value"),
+			recursive:     txtAnswer("value"),
+			expected:      true,
+		},
+		{
+			desc:          "stale recursive",
+			authoritative: txtAnswer("value"),
+			recursive:     txtAnswer("old"),
+			expectedErr:   "recursive nameservers did not return the expected TXT record [fqdn: _acme-challenge.example.com., value: value]: old",
+		},
+		{
+			desc:          "lagging authoritative",
+			authoritative: txtAnswer(),
+			recursive:     txtAnswer("value"),
+			expectedErr:   "did not return the expected TXT record [fqdn: _acme-challenge.example.com., value: value]: ",
+		},
+	}
+
+	for _, test := range testCases {
+		t.Run(test.desc, func(t *testing.T) {
+			useRecursiveNameservers(t, startFakeDNSServer(t, "udp", test.recursive))
+
+			authoritative := startFakeDNSServer(t, "udp", test.authoritative)
+
+			p := newPreCheck()
+			p.requireRecursive = true
+
+			ok, err := p.call(context.Background(), "example.com", "_acme-challenge.example.com.", "value", []string{authoritative}, nil)
+			if test.expectedErr == "" {
+				require.NoError(t, err)
+			} else {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), test.expectedErr)
+			}
+
+			assert.Equal(t, test.expected, ok)
+		})
+	}
+}
+
+func TestChallenge_Solve_propagationBoth(t *testing.T) {
+	_, apiURL, tearDown := tester.SetupFakeAPI()
+	defer tearDown()
+
+	privateKey, err := rsa.GenerateKey(rand.Reader, 512)
+	require.NoError(t, err)
+
+	core, err := api.New(http.DefaultClient, "lego-test", apiURL+"/dir", "", privateKey)
+	require.NoError(t, err)
+
+	keyAuth, err := core.GetKeyAuthorization("token")
+	require.NoError(t, err)
+
+	_, value := GetRecord("example.com", keyAuth)
+
+	// the recursive nameservers disagree with the authoritative nameservers for the first queries.
+	recursive, recursiveQueries := laggingAnswer(2, value)
+	useRecursiveNameservers(t, startFakeDNSServer(t, "udp", recursive))
+
+	provider := &providerNameserversMock{
+		providerTimeoutMock: providerTimeoutMock{timeout: 2 * time.Second, interval: 10 * time.Millisecond},
+		nameservers:         []string{startFakeDNSServer(t, "udp", txtAnswer(value))},
+	}
+
+	var validated bool
+	validate := func(_ *api.Core, _ string, _ acme.Challenge) error {
+		validated = true
+		return nil
+	}
+
+	chlg := NewChallenge(core, validate, provider, SetPropagationMode(PropagationBoth))
+
+	authz := acme.Authorization{
+		Identifier: acme.Identifier{
+			Value: "example.com",
+		},
+		Challenges: []acme.Challenge{
+			{Type: challenge.DNS01.String(), Token: "token"},
+		},
+	}
+
+	err = chlg.Solve(authz)
+	require.NoError(t, err)
+
+	assert.True(t, validated)
+	assert.Equal(t, 3, recursiveQueries())
+}
@@ -0,0 +1,21 @@
+package dns01
+
+import "github.com/go-acme/lego/v4/challenge"
+
+// Resetter allows a Provider keeping a per-challenge state to be reused across many certificates.
+//
+// A provider can be constructed (and authenticated) once, and then used for any number of certificates:
+// Present and CleanUp are called for each challenge.
+// Reset drops the state left by the challenges of the previous certificates
+// (ex: a challenge interrupted before its cleanup).
+// It must not be called while challenges are being solved with the provider.
+type Resetter interface {
+	Reset()
+}
+
+// ResetProvider resets the per-challenge state of the provider, if it implements Resetter.
+func ResetProvider(provider challenge.Provider) {
+	if r, ok := provider.(Resetter); ok {
+		r.Reset()
+	}
+}
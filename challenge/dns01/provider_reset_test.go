@@ -0,0 +1,28 @@
+package dns01
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type providerResetterMock struct {
+	providerMock
+	resets int
+}
+
+func (p *providerResetterMock) Reset() {
+	p.resets++
+}
+
+func TestResetProvider(t *testing.T) {
+	provider := &providerResetterMock{}
+
+	ResetProvider(provider)
+	ResetProvider(provider)
+
+	assert.Equal(t, 2, provider.resets)
+
+	// no-op for a provider without state.
+	ResetProvider(&providerMock{})
+}
@@ -0,0 +1,25 @@
+package dns01
+
+// RecordHandle describes a TXT record created by a provider.
+// It is serializable (ex: JSON) to allow the cleanup of the record later,
+// possibly by another process, without the state kept by the provider between Present and CleanUp.
+type RecordHandle struct {
+	// Zone is the zone of the record, as known by the provider.
+	Zone string `json:"zone"`
+	// Name is the FQDN of the record.
+	Name string `json:"name"`
+	// Value is the value of the challenge.
+	Value string `json:"value"`
+	// RecordID is the ID of the record, if the provider has one.
+	RecordID string `json:"recordId,omitempty"`
+}
+
+// RecordReporter allows a Provider to separate the creation of the TXT record from its cleanup.
+//
+// PresentRecord creates the TXT record, like Present, and returns a handle describing the created record.
+// CleanUpByHandle removes the value of the challenge described by the handle from the TXT record.
+// It only relies on the handle: the provider used for the cleanup can be a new instance.
+type RecordReporter interface {
+	PresentRecord(domain, token, keyAuth string) (*RecordHandle, error)
+	CleanUpByHandle(handle RecordHandle) error
+}
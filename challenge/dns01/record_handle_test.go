@@ -0,0 +1,29 @@
+package dns01
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRecordHandle_json(t *testing.T) {
+	handle := RecordHandle{
+		Zone:     "example.com",
+		Name:     "_acme-challenge.example.com.",
+		Value:    "value",
+		RecordID: "123",
+	}
+
+	raw, err := json.Marshal(handle)
+	require.NoError(t, err)
+
+	assert.JSONEq(t, `{"zone":"example.com","name":"_acme-challenge.example.com.","value":"value","recordId":"123"}`, string(raw))
+
+	var result RecordHandle
+	err = json.Unmarshal(raw, &result)
+	require.NoError(t, err)
+
+	assert.Equal(t, handle, result)
+}
@@ -0,0 +1,54 @@
+package dns01
+
+import "sync"
+
+// RecordTracker tracks the challenges for which Present failed before writing the record.
+// It allows a provider to skip the cleanup of these challenges only:
+// the record is deleted in the other cases (ex: Present failed after the write request,
+// the record has been created by a previous process, the tracker has been reset).
+type RecordTracker struct {
+	mu         sync.Mutex
+	notWritten map[string]struct{}
+}
+
+// NewRecordTracker creates a new RecordTracker.
+func NewRecordTracker() *RecordTracker {
+	return &RecordTracker{notWritten: make(map[string]struct{})}
+}
+
+// NotWritten marks the challenge: Present failed before writing the record.
+func (t *RecordTracker) NotWritten(domain, token string) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	t.notWritten[domain+"|"+token] = struct{}{}
+}
+
+// Written unmarks the challenge: Present writes the record, the record can exist even if the write request fails.
+func (t *RecordTracker) Written(domain, token string) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	delete(t.notWritten, domain+"|"+token)
+}
+
+// SkipCleanUp stops the tracking of the challenge, and reports whether Present failed before writing the record.
+func (t *RecordTracker) SkipCleanUp(domain, token string) bool {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	key := domain + "|" + token
+
+	_, ok := t.notWritten[key]
+	delete(t.notWritten, key)
+
+	return ok
+}
+
+// Reset stops the tracking of all the challenges.
+func (t *RecordTracker) Reset() {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	t.notWritten = make(map[string]struct{})
+}
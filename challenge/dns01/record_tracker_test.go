@@ -0,0 +1,49 @@
+package dns01
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRecordTracker(t *testing.T) {
+	tracker := NewRecordTracker()
+
+	// unknown challenge (ex: created by a previous process): the cleanup is not skipped.
+	assert.False(t, tracker.SkipCleanUp("example.com", "token"))
+
+	tracker.NotWritten("example.com", "token")
+	tracker.NotWritten("www.example.com", "token")
+
+	assert.True(t, tracker.SkipCleanUp("example.com", "token"))
+
+	// already released.
+	assert.False(t, tracker.SkipCleanUp("example.com", "token"))
+
+	assert.False(t, tracker.SkipCleanUp("www.example.com", "other"))
+	assert.True(t, tracker.SkipCleanUp("www.example.com", "token"))
+}
+
+func TestRecordTracker_Written(t *testing.T) {
+	tracker := NewRecordTracker()
+
+	// Present is retried after a failure.
+	tracker.NotWritten("example.com", "token")
+	tracker.Written("example.com", "token")
+
+	assert.False(t, tracker.SkipCleanUp("example.com", "token"))
+}
+
+func TestRecordTracker_Reset(t *testing.T) {
+	tracker := NewRecordTracker()
+
+	tracker.NotWritten("example.com", "token")
+
+	tracker.Reset()
+
+	assert.False(t, tracker.SkipCleanUp("example.com", "token"))
+
+	// the tracker is still usable.
+	tracker.NotWritten("example.com", "token")
+	assert.True(t, tracker.SkipCleanUp("example.com", "token"))
+}
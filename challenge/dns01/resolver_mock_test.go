@@ -0,0 +1,120 @@
+package dns01
+
+import (
+	"io/ioutil"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/miekg/dns"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeResolver builds the response of a fake DNS server.
+type fakeResolver func(req *dns.Msg) *dns.Msg
+
+// txtAnswer returns a fakeResolver answering with a TXT record.
+func txtAnswer(values ...string) fakeResolver {
+	return func(req *dns.Msg) *dns.Msg {
+		m := new(dns.Msg)
+		m.SetReply(req)
+
+		for _, value := range values {
+			m.Answer = append(m.Answer, &dns.TXT{
+				Hdr: dns.RR_Header{Name: req.Question[0].Name, Rrtype: dns.TypeTXT, Class: dns.ClassINET, Ttl: 0},
+				Txt: []string{value},
+			})
+		}
+
+		return m
+	}
+}
+
+// startFakeDNSServer starts a local DNS server ("udp" or "tcp") and returns its address.
+func startFakeDNSServer(t *testing.T, network string, resolver fakeResolver) string {
+	t.Helper()
+
+	server := &dns.Server{
+		ReadTimeout:  time.Hour,
+		WriteTimeout: time.Hour,
+		Handler: dns.HandlerFunc(func(w dns.ResponseWriter, req *dns.Msg) {
+			_ = w.WriteMsg(resolver(req))
+		}),
+	}
+
+	var addr string
+	switch network {
+	case "udp":
+		pc, err := net.ListenPacket("udp", "127.0.0.1:0")
+		require.NoError(t, err)
+
+		server.PacketConn = pc
+		addr = pc.LocalAddr().String()
+	case "tcp":
+		l, err := net.Listen("tcp", "127.0.0.1:0")
+		require.NoError(t, err)
+
+		server.Listener = l
+		addr = l.Addr().String()
+	default:
+		t.Fatalf("unsupported network: %s", network)
+	}
+
+	waitLock := sync.Mutex{}
+	waitLock.Lock()
+	server.NotifyStartedFunc = waitLock.Unlock
+
+	go func() { _ = server.ActivateAndServe() }()
+
+	waitLock.Lock()
+
+	t.Cleanup(func() { _ = server.Shutdown() })
+
+	return addr
+}
+
+// startFakeDoHServer starts a local DNS over HTTPS server and returns its URL.
+func startFakeDoHServer(t *testing.T, resolver fakeResolver) string {
+	t.Helper()
+
+	server := httptest.NewTLSServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		if req.Header.Get("Content-Type") != dohMediaType {
+			http.Error(rw, "invalid content type", http.StatusUnsupportedMediaType)
+			return
+		}
+
+		raw, err := ioutil.ReadAll(req.Body)
+		if err != nil {
+			http.Error(rw, err.Error(), http.StatusBadRequest)
+			return
+		}
+
+		msg := new(dns.Msg)
+		err = msg.Unpack(raw)
+		if err != nil {
+			http.Error(rw, err.Error(), http.StatusBadRequest)
+			return
+		}
+
+		resp, err := resolver(msg).Pack()
+		if err != nil {
+			http.Error(rw, err.Error(), http.StatusInternalServerError)
+			return
+		}
+
+		rw.Header().Set("Content-Type", dohMediaType)
+		_, _ = rw.Write(resp)
+	}))
+
+	t.Cleanup(server.Close)
+
+	// trust the certificate of the test server.
+	backup := http.DefaultTransport
+	http.DefaultTransport = server.Client().Transport
+	t.Cleanup(func() { http.DefaultTransport = backup })
+
+	return server.URL + "/dns-query"
+}
@@ -0,0 +1,38 @@
+package dns01
+
+import (
+	"fmt"
+	"strings"
+)
+
+// StoredValuesReader allows a Provider to read back through its API the values of the TXT record it has created.
+type StoredValuesReader interface {
+	StoredValues(fqdn string) ([]string, error)
+}
+
+// VerifyStoredRecord enables a verification, after Present, that the value stored by the provider
+// is the computed value of the challenge (ex: the value has not been truncated or transformed by the API).
+// The verification is only done for providers implementing StoredValuesReader.
+func VerifyStoredRecord() ChallengeOption {
+	return func(chlg *Challenge) error {
+		chlg.verifyStoredRecord = true
+		return nil
+	}
+}
+
+// verifyStoredRecord checks that the value is one of the values stored by the provider.
+func verifyStoredRecord(fqdn, value string, reader StoredValuesReader) error {
+	values, err := reader.StoredValues(fqdn)
+	if err != nil {
+		return fmt.Errorf("could not read the TXT record stored by the provider: %w", err)
+	}
+
+	for _, v := range values {
+		if v == value {
+			return nil
+		}
+	}
+
+	return fmt.Errorf("the TXT record %s stored by the provider does not contain the expected value [value: %s]: %s",
+		fqdn, value, strings.Join(values, " ,"))
+}
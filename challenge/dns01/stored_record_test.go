@@ -0,0 +1,145 @@
+package dns01
+
+import (
+	"crypto/rand"
+	"crypto/rsa"
+	"errors"
+	"net/http"
+	"testing"
+
+	"github.com/go-acme/lego/v4/acme"
+	"github.com/go-acme/lego/v4/acme/api"
+	"github.com/go-acme/lego/v4/challenge"
+	"github.com/go-acme/lego/v4/platform/tester"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type providerStoredValuesMock struct {
+	providerMock
+	values []string
+	err    error
+
+	reads []string
+}
+
+func (p *providerStoredValuesMock) StoredValues(fqdn string) ([]string, error) {
+	p.reads = append(p.reads, fqdn)
+	return p.values, p.err
+}
+
+func Test_verifyStoredRecord(t *testing.T) {
+	testCases := []struct {
+		desc     string
+		values   []string
+		err      error
+		expected string
+	}{
+		{
+			desc:   "same value",
+			values: []string{"value"},
+		},
+		{
+			desc:   "several values",
+			values: []string{"other", "value"},
+		},
+		{
+			desc:     "truncated value",
+			values:   []string{"val"},
+			expected: "the TXT record _acme-challenge.example.com. stored by the provider does not contain the expected value [value: value]: val",
+		},
+		{
+			desc:     "no value",
+			expected: "the TXT record _acme-challenge.example.com. stored by the provider does not contain the expected value [value: value]: ",
+		},
+		{
+			desc:     "read error",
+			err:      errors.New("OOPS"),
+			expected: "could not read the TXT record stored by the provider: OOPS",
+		},
+	}
+
+	for _, test := range testCases {
+		t.Run(test.desc, func(t *testing.T) {
+			provider := &providerStoredValuesMock{values: test.values, err: test.err}
+
+			err := verifyStoredRecord("_acme-challenge.example.com.", "value", provider)
+			if test.expected == "" {
+				require.NoError(t, err)
+			} else {
+				require.EqualError(t, err, test.expected)
+			}
+		})
+	}
+}
+
+func TestChallenge_PreSolve_verifyStoredRecord(t *testing.T) {
+	_, apiURL, tearDown := tester.SetupFakeAPI()
+	defer tearDown()
+
+	privateKey, err := rsa.GenerateKey(rand.Reader, 512)
+	require.NoError(t, err)
+
+	core, err := api.New(http.DefaultClient, "lego-test", apiURL+"/dir", "", privateKey)
+	require.NoError(t, err)
+
+	keyAuth, err := core.GetKeyAuthorization("token")
+	require.NoError(t, err)
+
+	fqdn, value := GetRecord("example.com", keyAuth)
+
+	testCases := []struct {
+		desc          string
+		options       []ChallengeOption
+		values        []string
+		expectedReads []string
+		expectedErr   string
+	}{
+		{
+			desc:   "disabled",
+			values: []string{"transformed"},
+		},
+		{
+			desc:          "same value",
+			options:       []ChallengeOption{VerifyStoredRecord()},
+			values:        []string{value},
+			expectedReads: []string{fqdn},
+		},
+		{
+			desc:          "different value",
+			options:       []ChallengeOption{VerifyStoredRecord()},
+			values:        []string{value[:10]},
+			expectedReads: []string{fqdn},
+			expectedErr: "[example.com] acme: the TXT record " + fqdn + " stored by the provider does not contain the expected value " +
+				"[value: " + value + "]: " + value[:10],
+		},
+	}
+
+	for _, test := range testCases {
+		t.Run(test.desc, func(t *testing.T) {
+			provider := &providerStoredValuesMock{values: test.values}
+
+			validate := func(_ *api.Core, _ string, _ acme.Challenge) error { return nil }
+
+			chlg := NewChallenge(core, validate, provider, test.options...)
+
+			authz := acme.Authorization{
+				Identifier: acme.Identifier{
+					Value: "example.com",
+				},
+				Challenges: []acme.Challenge{
+					{Type: challenge.DNS01.String(), Token: "token"},
+				},
+			}
+
+			err = chlg.PreSolve(authz)
+			if test.expectedErr == "" {
+				require.NoError(t, err)
+			} else {
+				require.EqualError(t, err, test.expectedErr)
+			}
+
+			assert.Equal(t, test.expectedReads, provider.reads)
+		})
+	}
+}
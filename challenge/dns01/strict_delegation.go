@@ -0,0 +1,72 @@
+package dns01
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/go-acme/lego/v4/challenge"
+)
+
+// envStrictDelegation enables the verification of the delegation of the challenge zone to the provider.
+const envStrictDelegation = "LEGO_STRICT_DELEGATION"
+
+// ZoneNameserversProvider allows a Provider to expose the nameservers of one of its zones.
+// It is used to verify that a zone is delegated to the provider.
+type ZoneNameserversProvider interface {
+	ZoneNameservers(zone string) ([]string, error)
+}
+
+// StrictDelegation prevents the creation of the TXT record when the zone of the challenge
+// is not delegated to the provider.
+// The delegation is verified with the nameservers of the zone (ZoneNameserversProvider),
+// or with the zones of the provider (ZoneLister).
+// The challenge fails for the providers implementing none of these interfaces.
+// It can also be enabled with the environment variable LEGO_STRICT_DELEGATION.
+func StrictDelegation() ChallengeOption {
+	return func(chlg *Challenge) error {
+		chlg.strictDelegation = true
+		return nil
+	}
+}
+
+// checkDelegation verifies that the zone of the fqdn, found through DNS, is delegated to the provider.
+func checkDelegation(fqdn string, provider challenge.Provider) error {
+	switch p := provider.(type) {
+	case ZoneNameserversProvider:
+		return checkDelegatedNameservers(fqdn, p)
+	case ZoneLister:
+		return crossCheckZone(fqdn, p)
+	default:
+		return errors.New("the provider does not expose its zones: the delegation cannot be verified")
+	}
+}
+
+// checkDelegatedNameservers compares the nameservers of the zone published in DNS with the nameservers of the provider.
+func checkDelegatedNameservers(fqdn string, provider ZoneNameserversProvider) error {
+	zone, err := FindZoneByFqdn(fqdn)
+	if err != nil {
+		return fmt.Errorf("could not determine the zone through DNS: %w", err)
+	}
+
+	expected, err := provider.ZoneNameservers(UnFqdn(zone))
+	if err != nil {
+		return fmt.Errorf("could not get the nameservers of the zone %s from the provider: %w", zone, err)
+	}
+
+	delegated, err := lookupZoneNameservers(zone)
+	if err != nil {
+		return fmt.Errorf("could not get the nameservers of the zone %s through DNS: %w", zone, err)
+	}
+
+	for _, ns := range delegated {
+		for _, e := range expected {
+			if strings.EqualFold(ToFqdn(ns), ToFqdn(e)) {
+				return nil
+			}
+		}
+	}
+
+	return fmt.Errorf("the zone %s is not delegated to the provider: nameservers %s, expected %s",
+		zone, strings.Join(delegated, ","), strings.Join(expected, ","))
+}
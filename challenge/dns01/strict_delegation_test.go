@@ -0,0 +1,214 @@
+package dns01
+
+import (
+	"crypto/rand"
+	"crypto/rsa"
+	"errors"
+	"net/http"
+	"os"
+	"testing"
+
+	"github.com/go-acme/lego/v4/acme"
+	"github.com/go-acme/lego/v4/acme/api"
+	"github.com/go-acme/lego/v4/challenge"
+	"github.com/go-acme/lego/v4/platform/tester"
+	"github.com/miekg/dns"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type providerZoneNameserversMock struct {
+	providerMock
+	nameservers map[string][]string
+
+	presented bool
+}
+
+func (p *providerZoneNameserversMock) Present(domain, token, keyAuth string) error {
+	p.presented = true
+	return nil
+}
+
+func (p *providerZoneNameserversMock) ZoneNameservers(zone string) ([]string, error) {
+	ns, ok := p.nameservers[zone]
+	if !ok {
+		return nil, errors.New("unknown zone")
+	}
+
+	return ns, nil
+}
+
+// nsAnswer returns a fakeResolver answering with NS records.
+func nsAnswer(nameservers ...string) fakeResolver {
+	return func(req *dns.Msg) *dns.Msg {
+		m := new(dns.Msg)
+		m.SetReply(req)
+
+		for _, ns := range nameservers {
+			m.Answer = append(m.Answer, &dns.NS{
+				Hdr: dns.RR_Header{Name: req.Question[0].Name, Rrtype: dns.TypeNS, Class: dns.ClassINET, Ttl: 0},
+				Ns:  ns,
+			})
+		}
+
+		return m
+	}
+}
+
+// setupDelegation seeds the SOA cache and serves the NS of the zone through a fake recursive resolver.
+func setupDelegation(t *testing.T, fqdn, zone string, nameservers ...string) {
+	t.Helper()
+
+	ClearFqdnCache()
+	t.Cleanup(ClearFqdnCache)
+
+	seedFqdnCache(fqdn, zone)
+
+	original := recursiveNameservers
+	t.Cleanup(func() { recursiveNameservers = original })
+
+	recursiveNameservers = []string{startFakeDNSServer(t, "udp", nsAnswer(nameservers...))}
+}
+
+func Test_checkDelegation(t *testing.T) {
+	testCases := []struct {
+		desc      string
+		delegated []string
+		provider  challenge.Provider
+		expected  string
+	}{
+		{
+			desc:      "delegated",
+			delegated: []string{"dns1.p01.nsone.net.", "dns2.p01.nsone.net."},
+			provider: &providerZoneNameserversMock{
+				nameservers: map[string][]string{"example.com": {"DNS1.p01.nsone.net", "dns2.p01.nsone.net"}},
+			},
+		},
+		{
+			desc:      "misdelegated",
+			delegated: []string{"ns1.other.net.", "ns2.other.net."},
+			provider: &providerZoneNameserversMock{
+				nameservers: map[string][]string{"example.com": {"dns1.p01.nsone.net", "dns2.p01.nsone.net"}},
+			},
+			expected: "the zone example.com. is not delegated to the provider: " +
+				"nameservers ns1.other.net.,ns2.other.net., expected dns1.p01.nsone.net,dns2.p01.nsone.net",
+		},
+		{
+			desc:      "zone unknown by the provider",
+			delegated: []string{"dns1.p01.nsone.net."},
+			provider:  &providerZoneNameserversMock{},
+			expected:  "could not get the nameservers of the zone example.com. from the provider: unknown zone",
+		},
+		{
+			desc:     "zones of the provider (DNS)",
+			provider: &providerZonesMock{zones: []string{"example.com"}},
+		},
+		{
+			desc:     "zone not managed by the provider (DNS)",
+			provider: &providerZonesMock{zones: []string{"example.org"}},
+			expected: "the zone example.com. found through DNS is not managed by the provider",
+		},
+		{
+			desc:     "provider without zone data",
+			provider: &providerMock{},
+			expected: "the provider does not expose its zones: the delegation cannot be verified",
+		},
+	}
+
+	for _, test := range testCases {
+		t.Run(test.desc, func(t *testing.T) {
+			setupDelegation(t, "_acme-challenge.www.example.com.", "example.com.", test.delegated...)
+
+			err := checkDelegation("_acme-challenge.www.example.com.", test.provider)
+			if test.expected == "" {
+				require.NoError(t, err)
+			} else {
+				require.EqualError(t, err, test.expected)
+			}
+		})
+	}
+}
+
+func TestChallenge_PreSolve_strictDelegation(t *testing.T) {
+	_, apiURL, tearDown := tester.SetupFakeAPI()
+	defer tearDown()
+
+	privateKey, err := rsa.GenerateKey(rand.Reader, 512)
+	require.NoError(t, err)
+
+	core, err := api.New(http.DefaultClient, "lego-test", apiURL+"/dir", "", privateKey)
+	require.NoError(t, err)
+
+	testCases := []struct {
+		desc        string
+		delegated   []string
+		options     []ChallengeOption
+		expected    bool
+		expectedErr string
+	}{
+		{
+			desc:      "delegated",
+			delegated: []string{"dns1.p01.nsone.net."},
+			options:   []ChallengeOption{StrictDelegation()},
+			expected:  true,
+		},
+		{
+			desc:        "misdelegated",
+			delegated:   []string{"ns1.other.net."},
+			options:     []ChallengeOption{StrictDelegation()},
+			expectedErr: "[example.com] acme: strict delegation: the zone example.com. is not delegated to the provider: nameservers ns1.other.net., expected dns1.p01.nsone.net",
+		},
+		{
+			desc:      "misdelegated, disabled",
+			delegated: []string{"ns1.other.net."},
+			expected:  true,
+		},
+	}
+
+	for _, test := range testCases {
+		t.Run(test.desc, func(t *testing.T) {
+			keyAuth, err := core.GetKeyAuthorization("token")
+			require.NoError(t, err)
+
+			fqdn, _ := GetRecord("example.com", keyAuth)
+
+			setupDelegation(t, fqdn, "example.com.", test.delegated...)
+
+			provider := &providerZoneNameserversMock{
+				nameservers: map[string][]string{"example.com": {"dns1.p01.nsone.net"}},
+			}
+
+			validate := func(_ *api.Core, _ string, _ acme.Challenge) error { return nil }
+
+			chlg := NewChallenge(core, validate, provider, test.options...)
+
+			authz := acme.Authorization{
+				Identifier: acme.Identifier{
+					Value: "example.com",
+				},
+				Challenges: []acme.Challenge{
+					{Type: challenge.DNS01.String(), Token: "token"},
+				},
+			}
+
+			err = chlg.PreSolve(authz)
+			if test.expectedErr == "" {
+				require.NoError(t, err)
+			} else {
+				require.EqualError(t, err, test.expectedErr)
+			}
+
+			// no record must be created in a zone which is not delegated to the provider.
+			assert.Equal(t, test.expected, provider.presented)
+		})
+	}
+}
+
+func TestNewChallenge_strictDelegationEnv(t *testing.T) {
+	defer os.Unsetenv(envStrictDelegation)
+	os.Setenv(envStrictDelegation, "true")
+
+	chlg := NewChallenge(nil, nil, &providerMock{})
+
+	assert.True(t, chlg.strictDelegation)
+}
@@ -0,0 +1,44 @@
+package dns01
+
+import "github.com/go-acme/lego/v4/challenge"
+
+// TXTStringFormat the format of the TXT value expected by the API of a provider.
+type TXTStringFormat int
+
+const (
+	// TXTSingleString the value is sent as one string, the API splits it if needed.
+	TXTSingleString TXTStringFormat = iota
+
+	// TXTSplitStrings the value is sent as a list of character-strings of at most MaxTXTStringLength characters.
+	TXTSplitStrings
+)
+
+// TXTStringFormatter allows a Provider to describe the format of the TXT value expected by its API.
+// A provider not implementing TXTStringFormatter uses TXTSingleString.
+type TXTStringFormatter interface {
+	TXTStringFormat() TXTStringFormat
+}
+
+// FormatTXTValue returns the strings to send to the API for the value of a TXT record.
+func FormatTXTValue(value string, format TXTStringFormat) []string {
+	if format != TXTSplitStrings || len(value) <= MaxTXTStringLength {
+		return []string{value}
+	}
+
+	var parts []string
+	for len(value) > MaxTXTStringLength {
+		parts = append(parts, value[:MaxTXTStringLength])
+		value = value[MaxTXTStringLength:]
+	}
+
+	return append(parts, value)
+}
+
+// FormatTXTValueFor returns the strings to send to the API of the provider for the value of a TXT record.
+func FormatTXTValueFor(provider challenge.Provider, value string) []string {
+	if f, ok := provider.(TXTStringFormatter); ok {
+		return FormatTXTValue(value, f.TXTStringFormat())
+	}
+
+	return FormatTXTValue(value, TXTSingleString)
+}
@@ -0,0 +1,76 @@
+package dns01
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type providerTXTFormatMock struct {
+	providerMock
+	format TXTStringFormat
+}
+
+func (p *providerTXTFormatMock) TXTStringFormat() TXTStringFormat {
+	return p.format
+}
+
+func TestFormatTXTValue(t *testing.T) {
+	long := strings.Repeat("a", 255) + strings.Repeat("b", 255) + "c"
+
+	testCases := []struct {
+		desc     string
+		value    string
+		format   TXTStringFormat
+		expected []string
+	}{
+		{
+			desc:     "single string",
+			value:    "value",
+			format:   TXTSingleString,
+			expected: []string{"value"},
+		},
+		{
+			desc:     "single string, long value",
+			value:    long,
+			format:   TXTSingleString,
+			expected: []string{long},
+		},
+		{
+			desc:     "split strings",
+			value:    "value",
+			format:   TXTSplitStrings,
+			expected: []string{"value"},
+		},
+		{
+			desc:     "split strings, 255 characters",
+			value:    strings.Repeat("a", 255),
+			format:   TXTSplitStrings,
+			expected: []string{strings.Repeat("a", 255)},
+		},
+		{
+			desc:     "split strings, long value",
+			value:    long,
+			format:   TXTSplitStrings,
+			expected: []string{strings.Repeat("a", 255), strings.Repeat("b", 255), "c"},
+		},
+	}
+
+	for _, test := range testCases {
+		test := test
+		t.Run(test.desc, func(t *testing.T) {
+			t.Parallel()
+
+			assert.Equal(t, test.expected, FormatTXTValue(test.value, test.format))
+		})
+	}
+}
+
+func TestFormatTXTValueFor(t *testing.T) {
+	long := strings.Repeat("a", 300)
+
+	assert.Equal(t, []string{long}, FormatTXTValueFor(&providerMock{}, long))
+	assert.Equal(t, []string{long}, FormatTXTValueFor(&providerTXTFormatMock{format: TXTSingleString}, long))
+	assert.Equal(t, []string{long[:255], long[255:]}, FormatTXTValueFor(&providerTXTFormatMock{format: TXTSplitStrings}, long))
+}
@@ -0,0 +1,34 @@
+package dns01
+
+import (
+	"errors"
+	"fmt"
+)
+
+// MaxTXTStringLength the maximum length of a character-string of a TXT record (RFC 1035).
+const MaxTXTStringLength = 255
+
+// ErrTXTValueTooLong is returned when a TXT value exceeds the maximum length.
+var ErrTXTValueTooLong = errors.New("TXT value too long")
+
+// CheckTXTValueLength returns an error if the total length of the TXT value exceeds maxLength.
+// It allows the providers to reject a value before calling their API.
+func CheckTXTValueLength(value string, maxLength int) error {
+	if len(value) > maxLength {
+		return fmt.Errorf("%w: %d characters, the maximum is %d", ErrTXTValueTooLong, len(value), maxLength)
+	}
+
+	return nil
+}
+
+// AddTXTValueMaxLength checks the length of the TXT value before calling the provider.
+func AddTXTValueMaxLength(maxLength int) ChallengeOption {
+	return func(chlg *Challenge) error {
+		if maxLength <= 0 {
+			return fmt.Errorf("invalid TXT value max length: %d", maxLength)
+		}
+
+		chlg.txtMaxLength = maxLength
+		return nil
+	}
+}
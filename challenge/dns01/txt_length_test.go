@@ -0,0 +1,108 @@
+package dns01
+
+import (
+	"crypto/rand"
+	"crypto/rsa"
+	"errors"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/go-acme/lego/v4/acme"
+	"github.com/go-acme/lego/v4/acme/api"
+	"github.com/go-acme/lego/v4/challenge"
+	"github.com/go-acme/lego/v4/platform/tester"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCheckTXTValueLength(t *testing.T) {
+	testCases := []struct {
+		desc      string
+		value     string
+		maxLength int
+		expected  string
+	}{
+		{
+			desc:      "under the limit",
+			value:     strings.Repeat("a", 254),
+			maxLength: MaxTXTStringLength,
+		},
+		{
+			desc:      "at the limit",
+			value:     strings.Repeat("a", 255),
+			maxLength: MaxTXTStringLength,
+		},
+		{
+			desc:      "over the limit",
+			value:     strings.Repeat("a", 256),
+			maxLength: MaxTXTStringLength,
+			expected:  "TXT value too long: 256 characters, the maximum is 255",
+		},
+	}
+
+	for _, test := range testCases {
+		t.Run(test.desc, func(t *testing.T) {
+			err := CheckTXTValueLength(test.value, test.maxLength)
+			if test.expected == "" {
+				require.NoError(t, err)
+			} else {
+				require.EqualError(t, err, test.expected)
+				assert.True(t, errors.Is(err, ErrTXTValueTooLong))
+			}
+		})
+	}
+}
+
+func TestChallenge_PreSolve_txtValueMaxLength(t *testing.T) {
+	_, apiURL, tearDown := tester.SetupFakeAPI()
+	defer tearDown()
+
+	privateKey, err := rsa.GenerateKey(rand.Reader, 512)
+	require.NoError(t, err)
+
+	core, err := api.New(http.DefaultClient, "lego-test", apiURL+"/dir", "", privateKey)
+	require.NoError(t, err)
+
+	authz := acme.Authorization{
+		Identifier: acme.Identifier{
+			Value: "example.com",
+		},
+		Challenges: []acme.Challenge{
+			{Type: challenge.DNS01.String()},
+		},
+	}
+
+	testCases := []struct {
+		desc      string
+		maxLength int
+		expected  string
+	}{
+		{
+			desc:      "under the limit",
+			maxLength: MaxTXTStringLength,
+		},
+		{
+			desc:      "over the limit",
+			maxLength: 10,
+			expected:  "[example.com] acme: TXT value too long: 43 characters, the maximum is 10",
+		},
+	}
+
+	for _, test := range testCases {
+		t.Run(test.desc, func(t *testing.T) {
+			provider := &providerCountMock{}
+
+			chlg := NewChallenge(core, nil, provider, AddTXTValueMaxLength(test.maxLength))
+
+			err = chlg.PreSolve(authz)
+			if test.expected == "" {
+				require.NoError(t, err)
+				assert.Equal(t, 1, provider.calls)
+			} else {
+				require.EqualError(t, err, test.expected)
+				assert.Equal(t, 0, provider.calls)
+			}
+		})
+	}
+}
@@ -0,0 +1,58 @@
+package dns01
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/miekg/dns"
+)
+
+// AddValidationResolvers defines a list of recursive resolvers intended to mirror the resolvers used by the CA
+// to validate the challenge (ex: resolvers located in the networks documented by the CA).
+// Once the propagation check succeeds, the TXT record must also be returned by all these resolvers.
+func AddValidationResolvers(resolvers []string) ChallengeOption {
+	return func(chlg *Challenge) error {
+		if len(resolvers) == 0 {
+			return errors.New("no validation resolvers")
+		}
+
+		chlg.preCheck.validationResolvers = ParseNameservers(resolvers)
+		return nil
+	}
+}
+
+// checkValidationResolvers queries each of the given recursive resolvers for the expected TXT record.
+func checkValidationResolvers(ctx context.Context, fqdn, value string, resolvers []string) (bool, error) {
+	for _, resolver := range resolvers {
+		err := checkValidationResolver(ctx, fqdn, value, resolver)
+		if err != nil {
+			return false, err
+		}
+	}
+
+	return true, nil
+}
+
+// checkValidationResolver queries the recursive resolver for the expected TXT record.
+func checkValidationResolver(ctx context.Context, fqdn, value, resolver string) error {
+	r, err := dnsQueryContext(ctx, fqdn, dns.TypeTXT, []string{resolver}, true)
+	if err != nil {
+		return queryError(resolver, fmt.Errorf("validation resolver %s: %w", resolver, err))
+	}
+
+	if r.Rcode != dns.RcodeSuccess {
+		return rcodeError(resolver, r.Rcode,
+			fmt.Errorf("validation resolver %s returned %s for %s", resolver, dns.RcodeToString[r.Rcode], fqdn))
+	}
+
+	records, found := findTXTValue(r, value)
+	if !found {
+		return recordsError(resolver, records,
+			fmt.Errorf("validation resolver %s did not return the expected TXT record [fqdn: %s, value: %s]: %s",
+				resolver, fqdn, value, strings.Join(records, " ,")))
+	}
+
+	return nil
+}
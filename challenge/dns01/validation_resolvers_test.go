@@ -0,0 +1,198 @@
+package dns01
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/rsa"
+	"errors"
+	"net/http"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/go-acme/lego/v4/acme"
+	"github.com/go-acme/lego/v4/acme/api"
+	"github.com/go-acme/lego/v4/challenge"
+	"github.com/go-acme/lego/v4/platform/tester"
+	"github.com/miekg/dns"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// laggingAnswer returns a fakeResolver answering without the TXT record for the first queries.
+func laggingAnswer(lag int, value string) (fakeResolver, func() int) {
+	var mu sync.Mutex
+	var count int
+
+	resolver := func(req *dns.Msg) *dns.Msg {
+		mu.Lock()
+		count++
+		current := count
+		mu.Unlock()
+
+		if current <= lag {
+			return txtAnswer()(req)
+		}
+
+		return txtAnswer(value)(req)
+	}
+
+	queries := func() int {
+		mu.Lock()
+		defer mu.Unlock()
+		return count
+	}
+
+	return resolver, queries
+}
+
+func TestAddValidationResolvers_empty(t *testing.T) {
+	chlg := &Challenge{}
+
+	err := AddValidationResolvers(nil)(chlg)
+	require.EqualError(t, err, "no validation resolvers")
+}
+
+func TestCheckValidationResolvers(t *testing.T) {
+	upToDate := startFakeDNSServer(t, "udp", txtAnswer("value"))
+	lagging := startFakeDNSServer(t, "udp", txtAnswer("old"))
+
+	ok, err := checkValidationResolvers(context.Background(), "_acme-challenge.example.com.", "value", []string{upToDate})
+	require.NoError(t, err)
+	assert.True(t, ok)
+
+	ok, err = checkValidationResolvers(context.Background(), "_acme-challenge.example.com.", "value", []string{upToDate, lagging})
+	require.EqualError(t, err, "validation resolver "+lagging+" did not return the expected TXT record "+
+		"[fqdn: _acme-challenge.example.com., value: value]: old")
+	assert.False(t, ok)
+}
+
+func TestCheckValidationResolvers_contextCanceled(t *testing.T) {
+	unblock := make(chan struct{})
+
+	hanging := startFakeDNSServer(t, "udp", func(req *dns.Msg) *dns.Msg {
+		<-unblock
+		return txtAnswer("value")(req)
+	})
+	t.Cleanup(func() { close(unblock) })
+
+	checks := map[string]func(ctx context.Context, fqdn, value string, resolvers []string) (bool, error){
+		"sequential": checkValidationResolvers,
+		"parallel":   checkValidationResolversParallel,
+	}
+
+	for name, check := range checks {
+		check := check
+		t.Run(name, func(t *testing.T) {
+			ctx, cancel := context.WithCancel(context.Background())
+
+			time.AfterFunc(50*time.Millisecond, cancel)
+
+			start := time.Now()
+
+			ok, err := check(ctx, "_acme-challenge.example.com.", "value", []string{hanging})
+			require.Error(t, err)
+			assert.True(t, errors.Is(err, context.Canceled))
+			assert.False(t, ok)
+
+			assert.Less(t, int64(time.Since(start)), int64(time.Second))
+		})
+	}
+}
+
+func TestChallenge_Solve_validationResolvers(t *testing.T) {
+	_, apiURL, tearDown := tester.SetupFakeAPI()
+	defer tearDown()
+
+	privateKey, err := rsa.GenerateKey(rand.Reader, 512)
+	require.NoError(t, err)
+
+	core, err := api.New(http.DefaultClient, "lego-test", apiURL+"/dir", "", privateKey)
+	require.NoError(t, err)
+
+	keyAuth, err := core.GetKeyAuthorization("token")
+	require.NoError(t, err)
+
+	_, value := GetRecord("example.com", keyAuth)
+
+	lagResolver, lagQueries := laggingAnswer(2, value)
+
+	validationResolvers := []string{
+		startFakeDNSServer(t, "udp", txtAnswer(value)),
+		startFakeDNSServer(t, "udp", lagResolver),
+	}
+
+	provider := &providerNameserversMock{
+		providerTimeoutMock: providerTimeoutMock{timeout: 2 * time.Second, interval: 10 * time.Millisecond},
+		nameservers:         []string{startFakeDNSServer(t, "udp", txtAnswer(value))},
+	}
+
+	var validated bool
+	validate := func(_ *api.Core, _ string, _ acme.Challenge) error {
+		validated = true
+		return nil
+	}
+
+	chlg := NewChallenge(core, validate, provider, AddValidationResolvers(validationResolvers))
+
+	authz := acme.Authorization{
+		Identifier: acme.Identifier{
+			Value: "example.com",
+		},
+		Challenges: []acme.Challenge{
+			{Type: challenge.DNS01.String(), Token: "token"},
+		},
+	}
+
+	err = chlg.Solve(authz)
+	require.NoError(t, err)
+
+	assert.True(t, validated)
+	assert.Equal(t, 3, lagQueries())
+}
+
+func TestChallenge_Solve_validationResolvers_timeout(t *testing.T) {
+	_, apiURL, tearDown := tester.SetupFakeAPI()
+	defer tearDown()
+
+	privateKey, err := rsa.GenerateKey(rand.Reader, 512)
+	require.NoError(t, err)
+
+	core, err := api.New(http.DefaultClient, "lego-test", apiURL+"/dir", "", privateKey)
+	require.NoError(t, err)
+
+	keyAuth, err := core.GetKeyAuthorization("token")
+	require.NoError(t, err)
+
+	_, value := GetRecord("example.com", keyAuth)
+
+	provider := &providerNameserversMock{
+		providerTimeoutMock: providerTimeoutMock{timeout: 200 * time.Millisecond, interval: 10 * time.Millisecond},
+		nameservers:         []string{startFakeDNSServer(t, "udp", txtAnswer(value))},
+	}
+
+	validate := func(_ *api.Core, _ string, _ acme.Challenge) error {
+		t.Fatal("the challenge must not be validated")
+		return nil
+	}
+
+	validationResolvers := []string{
+		startFakeDNSServer(t, "udp", txtAnswer(value)),
+		startFakeDNSServer(t, "udp", txtAnswer()),
+	}
+
+	chlg := NewChallenge(core, validate, provider, AddValidationResolvers(validationResolvers))
+
+	authz := acme.Authorization{
+		Identifier: acme.Identifier{
+			Value: "example.com",
+		},
+		Challenges: []acme.Challenge{
+			{Type: challenge.DNS01.String(), Token: "token"},
+		},
+	}
+
+	err = chlg.Solve(authz)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "did not return the expected TXT record")
+}
@@ -0,0 +1,60 @@
+package dns01
+
+import (
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"fmt"
+)
+
+// ValueEncoding is the encoding of the SHA-256 digest of the key authorization in the value of the TXT record.
+type ValueEncoding string
+
+const (
+	// EncodingBase64URL the encoding required by ACME (RFC 8555): base64url without padding.
+	EncodingBase64URL ValueEncoding = "base64url"
+	// EncodingHex the lowercase hexadecimal encoding of the digest.
+	// It is only intended for diagnostics: a CA never validates a challenge with this value.
+	EncodingHex ValueEncoding = "hex"
+)
+
+// GetRecordWithEncoding returns a DNS record like GetRecord, with the value encoded with the given encoding.
+// The ACME challenges always use GetRecord (EncodingBase64URL): the encoding only affects the explicit callers.
+func GetRecordWithEncoding(domain, keyAuth string, encoding ValueEncoding) (fqdn, value string, err error) {
+	value, err = encodeValue(keyAuth, encoding)
+	if err != nil {
+		return "", "", err
+	}
+
+	fqdn, _ = GetRecord(domain, keyAuth)
+
+	return fqdn, value, nil
+}
+
+// GetChallengeInfoWithEncoding returns the details of the DNS record like GetChallengeInfo,
+// with the value encoded with the given encoding.
+func GetChallengeInfoWithEncoding(domain, keyAuth string, encoding ValueEncoding) (ChallengeInfo, error) {
+	value, err := encodeValue(keyAuth, encoding)
+	if err != nil {
+		return ChallengeInfo{}, err
+	}
+
+	info := GetChallengeInfo(domain, keyAuth)
+	info.Value = value
+
+	return info, nil
+}
+
+// encodeValue encodes the SHA-256 digest of the key authorization.
+func encodeValue(keyAuth string, encoding ValueEncoding) (string, error) {
+	keyAuthShaBytes := sha256.Sum256([]byte(keyAuth))
+
+	switch encoding {
+	case EncodingBase64URL:
+		return base64.RawURLEncoding.EncodeToString(keyAuthShaBytes[:]), nil
+	case EncodingHex:
+		return hex.EncodeToString(keyAuthShaBytes[:]), nil
+	default:
+		return "", fmt.Errorf("unknown value encoding: %s", encoding)
+	}
+}
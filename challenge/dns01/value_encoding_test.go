@@ -0,0 +1,94 @@
+package dns01
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGetRecordWithEncoding(t *testing.T) {
+	testCases := []struct {
+		desc     string
+		encoding ValueEncoding
+		expected string
+	}{
+		{
+			desc:     "base64url",
+			encoding: EncodingBase64URL,
+			expected: "BBQUgcxf5weD7GT5jGRqmNsvAZXUWBoqPngIzDdoBFs",
+		},
+		{
+			desc:     "hex",
+			encoding: EncodingHex,
+			expected: "04141481cc5fe70783ec64f98c646a98db2f0195d4581a2a3e7808cc3768045b",
+		},
+	}
+
+	for _, test := range testCases {
+		t.Run(test.desc, func(t *testing.T) {
+			fqdn, value, err := GetRecordWithEncoding("example.com", "token.key", test.encoding)
+			require.NoError(t, err)
+
+			assert.Equal(t, "_acme-challenge.example.com.", fqdn)
+			assert.Equal(t, test.expected, value)
+		})
+	}
+}
+
+func TestGetRecordWithEncoding_unknown(t *testing.T) {
+	_, _, err := GetRecordWithEncoding("example.com", "token.key", "base32")
+	require.EqualError(t, err, "unknown value encoding: base32")
+}
+
+func TestGetRecordWithEncoding_defaultUnchanged(t *testing.T) {
+	_, _, err := GetRecordWithEncoding("example.com", "token.key", EncodingHex)
+	require.NoError(t, err)
+
+	// the ACME challenges always use the base64url encoding.
+	_, value := GetRecord("example.com", "token.key")
+	assert.Equal(t, "BBQUgcxf5weD7GT5jGRqmNsvAZXUWBoqPngIzDdoBFs", value)
+}
+
+func TestGetChallengeInfoWithEncoding(t *testing.T) {
+	testCases := []struct {
+		desc     string
+		encoding ValueEncoding
+		expected ChallengeInfo
+	}{
+		{
+			desc:     "base64url",
+			encoding: EncodingBase64URL,
+			expected: ChallengeInfo{
+				FQDN:  "_acme-challenge.www.example.com.",
+				Value: "ADw2sEd82DUgXcQ9hNBZThJs7zVJkR5v9JeSbAb9mZY",
+				Type:  "TXT",
+				TTL:   120,
+			},
+		},
+		{
+			desc:     "hex",
+			encoding: EncodingHex,
+			expected: ChallengeInfo{
+				FQDN:  "_acme-challenge.www.example.com.",
+				Value: "003c36b0477cd835205dc43d84d0594e126cef3549911e6ff497926c06fd9996",
+				Type:  "TXT",
+				TTL:   120,
+			},
+		},
+	}
+
+	for _, test := range testCases {
+		t.Run(test.desc, func(t *testing.T) {
+			info, err := GetChallengeInfoWithEncoding("*.www.example.com", "123d==", test.encoding)
+			require.NoError(t, err)
+
+			assert.Equal(t, test.expected, info)
+		})
+	}
+}
+
+func TestGetChallengeInfoWithEncoding_unknown(t *testing.T) {
+	_, err := GetChallengeInfoWithEncoding("example.com", "token.key", "base32")
+	require.EqualError(t, err, "unknown value encoding: base32")
+}
@@ -0,0 +1,42 @@
+package dns01
+
+import "fmt"
+
+// ViewSelector allows a Provider serving the zones in named views (split-horizon DNS) to select the view
+// where the challenge records are created.
+// The records must be created in the view visible from the Internet, the one queried by the ACME server.
+type ViewSelector interface {
+	SelectView(view string) error
+}
+
+// AddDNSView defines the view where the challenge records are created.
+// The provider must implement ViewSelector.
+func AddDNSView(view string) ChallengeOption {
+	return func(chlg *Challenge) error {
+		if view == "" {
+			return fmt.Errorf("invalid DNS view: %q", view)
+		}
+
+		chlg.view = view
+		return nil
+	}
+}
+
+// selectView selects the view of the challenge records, if a view is defined.
+func (c *Challenge) selectView() error {
+	if c.view == "" {
+		return nil
+	}
+
+	selector, ok := c.provider.(ViewSelector)
+	if !ok {
+		return fmt.Errorf("the DNS provider does not support the views (view: %s)", c.view)
+	}
+
+	err := selector.SelectView(c.view)
+	if err != nil {
+		return fmt.Errorf("could not select the view %s: %w", c.view, err)
+	}
+
+	return nil
+}
@@ -0,0 +1,122 @@
+package dns01
+
+import (
+	"crypto/rand"
+	"crypto/rsa"
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/go-acme/lego/v4/acme"
+	"github.com/go-acme/lego/v4/acme/api"
+	"github.com/go-acme/lego/v4/challenge"
+	"github.com/go-acme/lego/v4/platform/tester"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// providerViewsMock serves the zones in two views: "internal" (the default) and "external".
+type providerViewsMock struct {
+	view    string
+	records map[string][]string
+}
+
+func newProviderViewsMock() *providerViewsMock {
+	return &providerViewsMock{view: "internal", records: make(map[string][]string)}
+}
+
+func (p *providerViewsMock) SelectView(view string) error {
+	if view != "internal" && view != "external" {
+		return fmt.Errorf("unknown view: %s", view)
+	}
+
+	p.view = view
+	return nil
+}
+
+func (p *providerViewsMock) Present(domain, token, keyAuth string) error {
+	p.records[p.view] = append(p.records[p.view], domain)
+	return nil
+}
+
+func (p *providerViewsMock) CleanUp(domain, token, keyAuth string) error { return nil }
+
+func TestChallenge_PreSolve_view(t *testing.T) {
+	testCases := []struct {
+		desc     string
+		provider challenge.Provider
+		options  []ChallengeOption
+		expected map[string][]string
+		err      string
+	}{
+		{
+			desc:     "default view",
+			provider: newProviderViewsMock(),
+			expected: map[string][]string{"internal": {"example.com"}},
+		},
+		{
+			desc:     "external view",
+			provider: newProviderViewsMock(),
+			options:  []ChallengeOption{AddDNSView("external")},
+			expected: map[string][]string{"external": {"example.com"}},
+		},
+		{
+			desc:     "unknown view",
+			provider: newProviderViewsMock(),
+			options:  []ChallengeOption{AddDNSView("public")},
+			expected: map[string][]string{},
+			err:      "[example.com] acme: could not select the view public: unknown view: public",
+		},
+		{
+			desc:     "provider without views",
+			provider: &providerMock{},
+			options:  []ChallengeOption{AddDNSView("external")},
+			err:      "[example.com] acme: the DNS provider does not support the views (view: external)",
+		},
+	}
+
+	for _, test := range testCases {
+		t.Run(test.desc, func(t *testing.T) {
+			_, apiURL, tearDown := tester.SetupFakeAPI()
+			defer tearDown()
+
+			privateKey, err := rsa.GenerateKey(rand.Reader, 512)
+			require.NoError(t, err)
+
+			core, err := api.New(http.DefaultClient, "lego-test", apiURL+"/dir", "", privateKey)
+			require.NoError(t, err)
+
+			chlg := NewChallenge(core, nil, test.provider, test.options...)
+
+			authz := acme.Authorization{
+				Identifier: acme.Identifier{Value: "example.com"},
+				Challenges: []acme.Challenge{
+					{Type: challenge.DNS01.String(), Token: "token"},
+				},
+			}
+
+			err = chlg.PreSolve(authz)
+			if test.err != "" {
+				require.EqualError(t, err, test.err)
+			} else {
+				require.NoError(t, err)
+			}
+
+			if mock, ok := test.provider.(*providerViewsMock); ok {
+				assert.Equal(t, test.expected, mock.records)
+			}
+		})
+	}
+}
+
+func TestAddDNSView(t *testing.T) {
+	chlg := &Challenge{}
+
+	err := AddDNSView("")(chlg)
+	require.EqualError(t, err, `invalid DNS view: ""`)
+	assert.Empty(t, chlg.view)
+
+	err = AddDNSView("external")(chlg)
+	require.NoError(t, err)
+	assert.Equal(t, "external", chlg.view)
+}
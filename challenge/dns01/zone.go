@@ -0,0 +1,26 @@
+package dns01
+
+import "strings"
+
+// FindMostSpecificZone returns the most specific (longest) zone containing the fqdn.
+// The zones are the zones hosted by a provider, with or without trailing dot.
+// The matching is case-insensitive, the zone is returned as provided.
+// Returns an empty string if no zone matches.
+func FindMostSpecificZone(fqdn string, zones []string) string {
+	name := strings.ToLower(UnFqdn(fqdn))
+
+	var match, matchName string
+	for _, zone := range zones {
+		z := strings.ToLower(UnFqdn(zone))
+		if z == "" || (name != z && !strings.HasSuffix(name, "."+z)) {
+			continue
+		}
+
+		if len(z) > len(matchName) {
+			match = zone
+			matchName = z
+		}
+	}
+
+	return match
+}
@@ -0,0 +1,99 @@
+package dns01
+
+import (
+	"github.com/go-acme/lego/v4/acme"
+	"github.com/go-acme/lego/v4/challenge"
+	"github.com/go-acme/lego/v4/log"
+)
+
+// ZoneCleaner allows a Provider to remove all the challenge records of a zone in one operation.
+// It is used at the end of the issuance instead of one CleanUp call per record.
+// The zone is a FQDN (with a trailing dot).
+type ZoneCleaner interface {
+	CleanUpAll(zone string) error
+}
+
+type zoneCleanUp struct {
+	authz acme.Authorization
+	fqdn  string
+}
+
+// CleanUpAll cleans the challenges of the authorizations.
+// For providers implementing ZoneCleaner, the records are grouped by zone and removed with one CleanUpAll call per zone.
+// For providers implementing ZoneSessionProvider, the records are removed in one session.
+// It falls back to the per-record cleanup for the other providers, or when CleanUpAll fails.
+func (c *Challenge) CleanUpAll(authzs []acme.Authorization) {
+	cleaner, ok := c.provider.(ZoneCleaner)
+	if !ok {
+		if session, isSession := c.provider.(ZoneSessionProvider); isSession {
+			c.cleanUpAllInSession(session, authzs)
+			return
+		}
+
+		for _, authz := range authzs {
+			c.cleanUpOrWarn(authz)
+		}
+		return
+	}
+
+	var zones []string
+	byZone := make(map[string][]zoneCleanUp)
+
+	for _, authz := range authzs {
+		domain := challenge.GetTargetedDomain(authz)
+
+		chlng, err := challenge.FindChallenge(challenge.DNS01, authz)
+		if err != nil {
+			log.Warnf("[%s] acme: cleaning up failed: %v", domain, err)
+			continue
+		}
+
+		keyAuth, err := c.core.GetKeyAuthorization(chlng.Token)
+		if err != nil {
+			log.Warnf("[%s] acme: cleaning up failed: %v", domain, err)
+			continue
+		}
+
+		fqdn, _ := GetRecord(c.recordDomain(authz.Identifier.Value), keyAuth)
+
+		zone, err := FindZoneByFqdn(fqdn)
+		if err != nil {
+			log.Warnf("[%s] acme: could not determine the zone, falling back to the per-record cleanup: %v", domain, err)
+			c.cleanUpOrWarn(authz)
+			continue
+		}
+
+		if _, exists := byZone[zone]; !exists {
+			zones = append(zones, zone)
+		}
+
+		byZone[zone] = append(byZone[zone], zoneCleanUp{authz: authz, fqdn: fqdn})
+	}
+
+	for _, zone := range zones {
+		log.Infof("[%s] acme: Cleaning all DNS-01 challenges of the zone", zone)
+
+		err := c.breaker.call(func() error {
+			return cleaner.CleanUpAll(zone)
+		})
+		if err != nil {
+			log.Warnf("[%s] acme: cleaning up the zone failed, falling back to the per-record cleanup: %v", zone, err)
+
+			for _, item := range byZone[zone] {
+				c.cleanUpOrWarn(item.authz)
+			}
+			continue
+		}
+
+		for _, item := range byZone[zone] {
+			c.emitEvent(EventCleanupDone, challenge.GetTargetedDomain(item.authz), item.fqdn, nil)
+		}
+	}
+}
+
+func (c *Challenge) cleanUpOrWarn(authz acme.Authorization) {
+	err := c.CleanUp(authz)
+	if err != nil {
+		log.Warnf("[%s] acme: cleaning up failed: %v", challenge.GetTargetedDomain(authz), err)
+	}
+}
@@ -0,0 +1,100 @@
+package dns01
+
+import (
+	"crypto/rand"
+	"crypto/rsa"
+	"errors"
+	"net/http"
+	"testing"
+
+	"github.com/go-acme/lego/v4/acme"
+	"github.com/go-acme/lego/v4/acme/api"
+	"github.com/go-acme/lego/v4/challenge"
+	"github.com/go-acme/lego/v4/platform/tester"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type providerZoneCleanerMock struct {
+	*providerRecorderMock
+	cleanUpAll error
+	zones      []string
+}
+
+func (p *providerZoneCleanerMock) CleanUpAll(zone string) error {
+	p.zones = append(p.zones, zone)
+	return p.cleanUpAll
+}
+
+func TestChallenge_CleanUpAll(t *testing.T) {
+	testCases := []struct {
+		desc             string
+		zoneCleaner      bool
+		cleanUpAll       error
+		expectedZones    []string
+		expectedCleanUps []string
+	}{
+		{
+			desc:             "per-record cleanup",
+			expectedCleanUps: []string{"a.example.com", "b.example.com", "c.example.org"},
+		},
+		{
+			desc:          "zone cleanup",
+			zoneCleaner:   true,
+			expectedZones: []string{"example.com.", "example.org."},
+		},
+		{
+			desc:             "zone cleanup failure",
+			zoneCleaner:      true,
+			cleanUpAll:       errors.New("OOPS"),
+			expectedZones:    []string{"example.com.", "example.org."},
+			expectedCleanUps: []string{"a.example.com", "b.example.com", "c.example.org"},
+		},
+	}
+
+	for _, test := range testCases {
+		t.Run(test.desc, func(t *testing.T) {
+			_, apiURL, tearDown := tester.SetupFakeAPI()
+			defer tearDown()
+
+			privateKey, err := rsa.GenerateKey(rand.Reader, 512)
+			require.NoError(t, err)
+
+			core, err := api.New(http.DefaultClient, "lego-test", apiURL+"/dir", "", privateKey)
+			require.NoError(t, err)
+
+			ClearFqdnCache()
+			defer ClearFqdnCache()
+
+			var authzs []acme.Authorization
+			for _, domain := range []string{"a.example.com", "b.example.com", "c.example.org"} {
+				authz := acme.Authorization{
+					Identifier: acme.Identifier{Value: domain},
+					Challenges: []acme.Challenge{
+						{Type: challenge.DNS01.String(), Token: "token"},
+					},
+				}
+				authzs = append(authzs, authz)
+
+				keyAuth, err := core.GetKeyAuthorization("token")
+				require.NoError(t, err)
+
+				fqdn, _ := GetRecord(domain, keyAuth)
+				seedFqdnCache(fqdn, domain[2:]+".")
+			}
+
+			recorder := &providerRecorderMock{}
+			cleaner := &providerZoneCleanerMock{providerRecorderMock: recorder, cleanUpAll: test.cleanUpAll}
+
+			chlg := NewChallenge(core, nil, recorder)
+			if test.zoneCleaner {
+				chlg = NewChallenge(core, nil, cleaner)
+			}
+
+			chlg.CleanUpAll(authzs)
+
+			assert.Equal(t, test.expectedCleanUps, recorder.cleanUps)
+			assert.Equal(t, test.expectedZones, cleaner.zones)
+		})
+	}
+}
@@ -0,0 +1,48 @@
+package dns01
+
+import (
+	"fmt"
+	"strings"
+)
+
+// ZoneLister allows a Provider to expose the zones it manages.
+// It is used to cross-check the zone discovered through DNS against the zones known by the provider.
+type ZoneLister interface {
+	Zones() ([]string, error)
+}
+
+// CrossCheckZones enables a diagnostic comparing the zone discovered through DNS (SOA)
+// with the longest matching zone managed by the provider.
+// A warning is logged when the two sources disagree.
+// The check is only done for providers implementing ZoneLister.
+func CrossCheckZones() ChallengeOption {
+	return func(chlg *Challenge) error {
+		chlg.crossCheckZones = true
+		return nil
+	}
+}
+
+// crossCheckZone compares the zone found through DNS with the longest matching zone of the provider.
+func crossCheckZone(fqdn string, lister ZoneLister) error {
+	zones, err := lister.Zones()
+	if err != nil {
+		return fmt.Errorf("could not get the zones of the provider: %w", err)
+	}
+
+	providerZone := FindMostSpecificZone(fqdn, zones)
+
+	dnsZone, err := FindZoneByFqdn(fqdn)
+	if err != nil {
+		return fmt.Errorf("could not determine the zone through DNS: %w", err)
+	}
+
+	if providerZone == "" {
+		return fmt.Errorf("the zone %s found through DNS is not managed by the provider", dnsZone)
+	}
+
+	if !strings.EqualFold(ToFqdn(providerZone), dnsZone) {
+		return fmt.Errorf("the provider zone %s does not match the zone %s found through DNS", ToFqdn(providerZone), dnsZone)
+	}
+
+	return nil
+}
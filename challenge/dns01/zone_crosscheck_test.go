@@ -0,0 +1,128 @@
+package dns01
+
+import (
+	"bytes"
+	"crypto/rand"
+	"crypto/rsa"
+	stdlog "log"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/go-acme/lego/v4/acme"
+	"github.com/go-acme/lego/v4/acme/api"
+	"github.com/go-acme/lego/v4/challenge"
+	"github.com/go-acme/lego/v4/log"
+	"github.com/go-acme/lego/v4/platform/tester"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type providerZonesMock struct {
+	providerMock
+	zones []string
+}
+
+func (p *providerZonesMock) Zones() ([]string, error) { return p.zones, nil }
+
+// seedFqdnCache adds a fresh SOA cache entry to avoid real DNS queries.
+func seedFqdnCache(fqdn, zone string) {
+	muFqdnSoaCache.Lock()
+	fqdnSoaCache[fqdn] = &soaCacheEntry{zone: zone, primaryNs: "ns1." + zone, expires: time.Now().Add(time.Hour)}
+	muFqdnSoaCache.Unlock()
+}
+
+func Test_crossCheckZone(t *testing.T) {
+	testCases := []struct {
+		desc     string
+		fqdn     string
+		dnsZone  string
+		zones    []string
+		expected string
+	}{
+		{
+			desc:    "same zone",
+			fqdn:    "_acme-challenge.www.example.com.",
+			dnsZone: "example.com.",
+			zones:   []string{"example.org", "example.com"},
+		},
+		{
+			desc:    "longest match",
+			fqdn:    "_acme-challenge.www.sub.example.com.",
+			dnsZone: "sub.example.com.",
+			zones:   []string{"example.com", "sub.example.com."},
+		},
+		{
+			desc:     "DNS sub-zone delegated elsewhere",
+			fqdn:     "_acme-challenge.www.sub.example.com.",
+			dnsZone:  "sub.example.com.",
+			zones:    []string{"example.com"},
+			expected: "the provider zone example.com. does not match the zone sub.example.com. found through DNS",
+		},
+		{
+			desc:     "zone unknown by the provider",
+			fqdn:     "_acme-challenge.www.example.com.",
+			dnsZone:  "example.com.",
+			zones:    []string{"example.org"},
+			expected: "the zone example.com. found through DNS is not managed by the provider",
+		},
+	}
+
+	for _, test := range testCases {
+		t.Run(test.desc, func(t *testing.T) {
+			ClearFqdnCache()
+			defer ClearFqdnCache()
+
+			seedFqdnCache(test.fqdn, test.dnsZone)
+
+			err := crossCheckZone(test.fqdn, &providerZonesMock{zones: test.zones})
+			if test.expected == "" {
+				require.NoError(t, err)
+			} else {
+				require.EqualError(t, err, test.expected)
+			}
+		})
+	}
+}
+
+func TestChallenge_PreSolve_crossCheckZones(t *testing.T) {
+	_, apiURL, tearDown := tester.SetupFakeAPI()
+	defer tearDown()
+
+	privateKey, err := rsa.GenerateKey(rand.Reader, 512)
+	require.NoError(t, err)
+
+	core, err := api.New(http.DefaultClient, "lego-test", apiURL+"/dir", "", privateKey)
+	require.NoError(t, err)
+
+	backupLogger := log.Logger
+	defer func() {
+		log.Logger = backupLogger
+	}()
+
+	buf := &bytes.Buffer{}
+	log.Logger = stdlog.New(buf, "", 0)
+
+	ClearFqdnCache()
+	defer ClearFqdnCache()
+
+	seedFqdnCache("_acme-challenge.www.sub.example.com.", "sub.example.com.")
+
+	provider := &providerZonesMock{zones: []string{"example.com"}}
+
+	chlg := NewChallenge(core, nil, provider, CrossCheckZones())
+
+	authz := acme.Authorization{
+		Identifier: acme.Identifier{
+			Value: "www.sub.example.com",
+		},
+		Challenges: []acme.Challenge{
+			{Type: challenge.DNS01.String()},
+		},
+	}
+
+	err = chlg.PreSolve(authz)
+	require.NoError(t, err)
+
+	assert.Contains(t, buf.String(), "[WARN] [www.sub.example.com] acme: zone cross-check: the provider zone example.com. does not match the zone sub.example.com. found through DNS")
+}
@@ -0,0 +1,74 @@
+package dns01
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/go-acme/lego/v4/log"
+)
+
+// ZoneRewriter maps the zone discovered through DNS (SOA) to the zone the provider should write to.
+// It must return the zone unchanged when no rewrite applies.
+// The zones are FQDNs (with a trailing dot).
+type ZoneRewriter func(zone string) string
+
+// ZoneRewriterSetter is implemented by the providers supporting the zone rewrites (see AddZoneRewriter).
+// The provider uses RewriteZone to find the zone, and the name of the record, to write to.
+type ZoneRewriterSetter interface {
+	SetZoneRewriter(rewriter ZoneRewriter)
+}
+
+// ZoneRewriteTable rewrites the zones using a static table (discovered zone -> alternate zone).
+// The matching is case-insensitive, the zones of the table can be provided with or without trailing dot.
+func ZoneRewriteTable(table map[string]string) ZoneRewriter {
+	rewrites := make(map[string]string, len(table))
+	for zone, alternate := range table {
+		rewrites[strings.ToLower(ToFqdn(zone))] = ToFqdn(alternate)
+	}
+
+	return func(zone string) string {
+		if alternate, ok := rewrites[strings.ToLower(zone)]; ok {
+			return alternate
+		}
+
+		return zone
+	}
+}
+
+// AddZoneRewriter sets a hook to rewrite the zones of the records of the challenge.
+// This allows to redirect the records of a domain to a delegated zone without CNAME.
+// The rewriter is given to the provider if it implements ZoneRewriterSetter (ex: gandiv5, transip),
+// the zone discovery (FindZoneByFqdn) and the propagation check are not affected.
+func AddZoneRewriter(rewriter ZoneRewriter) ChallengeOption {
+	return func(chlg *Challenge) error {
+		setter, ok := chlg.provider.(ZoneRewriterSetter)
+		if !ok {
+			log.Warnf("the DNS provider does not support the zone rewrites")
+			return nil
+		}
+
+		setter.SetZoneRewriter(rewriter)
+
+		return nil
+	}
+}
+
+// RewriteZone applies the rewriter to the zone of the FQDN found with FindZoneByFqdn, it's used by the providers.
+// It returns the zone to write to, and the name of the record relative to this zone
+// (ex: `_acme-challenge.www` for `_acme-challenge.www.example.com.` in the zone `example.com.`).
+// The zone is unchanged if the rewriter is nil or if no rewrite applies.
+func RewriteZone(fqdn, zone string, rewriter ZoneRewriter) (alternateZone, name string, err error) {
+	fqdn, zone = ToFqdn(fqdn), ToFqdn(zone)
+
+	if !strings.HasSuffix(strings.ToLower(fqdn), "."+strings.ToLower(zone)) {
+		return "", "", fmt.Errorf("%s is not a subdomain of the zone %s", fqdn, zone)
+	}
+
+	name = fqdn[:len(fqdn)-len(zone)-1]
+
+	if rewriter == nil {
+		return zone, name, nil
+	}
+
+	return ToFqdn(rewriter(zone)), name, nil
+}
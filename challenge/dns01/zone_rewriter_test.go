@@ -0,0 +1,135 @@
+package dns01
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestZoneRewriteTable(t *testing.T) {
+	rewriter := ZoneRewriteTable(map[string]string{
+		"example.com":  "acme.example.net",
+		"Example.org.": "acme.example.net.",
+	})
+
+	testCases := []struct {
+		desc     string
+		zone     string
+		expected string
+	}{
+		{
+			desc:     "rewritten",
+			zone:     "example.com.",
+			expected: "acme.example.net.",
+		},
+		{
+			desc:     "rewritten case-insensitive",
+			zone:     "EXAMPLE.org.",
+			expected: "acme.example.net.",
+		},
+		{
+			desc:     "sub-zone not rewritten",
+			zone:     "sub.example.com.",
+			expected: "sub.example.com.",
+		},
+		{
+			desc:     "unknown zone",
+			zone:     "example.net.",
+			expected: "example.net.",
+		},
+	}
+
+	for _, test := range testCases {
+		test := test
+		t.Run(test.desc, func(t *testing.T) {
+			t.Parallel()
+
+			assert.Equal(t, test.expected, rewriter(test.zone))
+		})
+	}
+}
+
+func TestRewriteZone(t *testing.T) {
+	rewriter := ZoneRewriteTable(map[string]string{"example.com": "acme.example.net"})
+
+	testCases := []struct {
+		desc          string
+		fqdn          string
+		zone          string
+		rewriter      ZoneRewriter
+		expectedZone  string
+		expectedName  string
+		expectedError string
+	}{
+		{
+			desc:         "rewritten",
+			fqdn:         "_acme-challenge.www.example.com.",
+			zone:         "example.com.",
+			rewriter:     rewriter,
+			expectedZone: "acme.example.net.",
+			expectedName: "_acme-challenge.www",
+		},
+		{
+			desc:         "not rewritten",
+			fqdn:         "_acme-challenge.www.example.org.",
+			zone:         "example.org.",
+			rewriter:     rewriter,
+			expectedZone: "example.org.",
+			expectedName: "_acme-challenge.www",
+		},
+		{
+			desc:         "no rewriter",
+			fqdn:         "_acme-challenge.example.com.",
+			zone:         "example.com.",
+			expectedZone: "example.com.",
+			expectedName: "_acme-challenge",
+		},
+		{
+			desc:          "not in the zone",
+			fqdn:          "_acme-challenge.example.org.",
+			zone:          "example.com.",
+			rewriter:      rewriter,
+			expectedError: "_acme-challenge.example.org. is not a subdomain of the zone example.com.",
+		},
+	}
+
+	for _, test := range testCases {
+		test := test
+		t.Run(test.desc, func(t *testing.T) {
+			t.Parallel()
+
+			zone, name, err := RewriteZone(test.fqdn, test.zone, test.rewriter)
+			if test.expectedError != "" {
+				require.EqualError(t, err, test.expectedError)
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, test.expectedZone, zone)
+			assert.Equal(t, test.expectedName, name)
+		})
+	}
+}
+
+type providerZoneRewriterMock struct {
+	providerMock
+	rewriter ZoneRewriter
+}
+
+func (p *providerZoneRewriterMock) SetZoneRewriter(rewriter ZoneRewriter) { p.rewriter = rewriter }
+
+func TestAddZoneRewriter(t *testing.T) {
+	provider := &providerZoneRewriterMock{}
+
+	_ = NewChallenge(nil, nil, provider,
+		AddZoneRewriter(ZoneRewriteTable(map[string]string{"example.com": "acme.example.net"})))
+
+	require.NotNil(t, provider.rewriter)
+	assert.Equal(t, "acme.example.net.", provider.rewriter("example.com."))
+
+	// the rewriter is not shared with the other providers.
+	other := &providerZoneRewriterMock{}
+	_ = NewChallenge(nil, nil, other)
+	assert.Nil(t, other.rewriter)
+}
@@ -0,0 +1,70 @@
+package dns01
+
+import (
+	"fmt"
+
+	"github.com/go-acme/lego/v4/acme"
+	"github.com/go-acme/lego/v4/challenge"
+	"github.com/go-acme/lego/v4/log"
+)
+
+// ZoneSessionProvider allows a Provider rewriting the whole zone to apply the changes of several records at once.
+// Between BeginZoneSession and FlushZoneSession, Present and CleanUp only accumulate the changes in memory:
+// each zone is fetched once, and saved once by FlushZoneSession.
+// FlushZoneSession ends the session, even if it fails.
+type ZoneSessionProvider interface {
+	BeginZoneSession()
+	FlushZoneSession() error
+}
+
+// PreSolveAll submits the TXT records of the authorizations to the DNS provider, and returns the errors by domain.
+// For providers implementing ZoneSessionProvider, the records are presented in one session:
+// they are created by the flush at the end of the session.
+func (c *Challenge) PreSolveAll(authzs []acme.Authorization) map[string]error {
+	failures := make(map[string]error)
+
+	session, ok := c.provider.(ZoneSessionProvider)
+	if ok {
+		session.BeginZoneSession()
+	}
+
+	var presented []acme.Authorization
+	for _, authz := range authzs {
+		err := c.PreSolve(authz)
+		if err != nil {
+			failures[challenge.GetTargetedDomain(authz)] = err
+			continue
+		}
+
+		presented = append(presented, authz)
+	}
+
+	if !ok {
+		return failures
+	}
+
+	err := c.breaker.call(session.FlushZoneSession)
+	if err != nil {
+		for _, authz := range presented {
+			domain := challenge.GetTargetedDomain(authz)
+			failures[domain] = fmt.Errorf("[%s] acme: error presenting token: %w", domain, err)
+		}
+	}
+
+	return failures
+}
+
+// cleanUpAllInSession cleans the challenges of the authorizations in one session.
+// The records are removed by the flush at the end of the session.
+func (c *Challenge) cleanUpAllInSession(session ZoneSessionProvider, authzs []acme.Authorization) {
+	session.BeginZoneSession()
+
+	for _, authz := range authzs {
+		c.cleanUpOrWarn(authz)
+	}
+
+	err := c.breaker.call(session.FlushZoneSession)
+	if err != nil {
+		log.Warnf("acme: cleaning up failed: %v", err)
+	}
+}
@@ -0,0 +1,117 @@
+package dns01
+
+import (
+	"crypto/rand"
+	"crypto/rsa"
+	"errors"
+	"net/http"
+	"testing"
+
+	"github.com/go-acme/lego/v4/acme"
+	"github.com/go-acme/lego/v4/acme/api"
+	"github.com/go-acme/lego/v4/challenge"
+	"github.com/go-acme/lego/v4/platform/tester"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type providerZoneSessionMock struct {
+	providerRecorderMock
+	flush error
+
+	// the calls, in order.
+	calls []string
+}
+
+func (p *providerZoneSessionMock) Present(domain, token, keyAuth string) error {
+	p.calls = append(p.calls, "present "+domain)
+	return p.providerRecorderMock.Present(domain, token, keyAuth)
+}
+
+func (p *providerZoneSessionMock) CleanUp(domain, token, keyAuth string) error {
+	p.calls = append(p.calls, "cleanup "+domain)
+	return p.providerRecorderMock.CleanUp(domain, token, keyAuth)
+}
+
+func (p *providerZoneSessionMock) BeginZoneSession() {
+	p.calls = append(p.calls, "begin")
+}
+
+func (p *providerZoneSessionMock) FlushZoneSession() error {
+	p.calls = append(p.calls, "flush")
+	return p.flush
+}
+
+func setupZoneSessionTest(t *testing.T) (*api.Core, []acme.Authorization) {
+	t.Helper()
+
+	_, apiURL, tearDown := tester.SetupFakeAPI()
+	t.Cleanup(tearDown)
+
+	privateKey, err := rsa.GenerateKey(rand.Reader, 512)
+	require.NoError(t, err)
+
+	core, err := api.New(http.DefaultClient, "lego-test", apiURL+"/dir", "", privateKey)
+	require.NoError(t, err)
+
+	var authzs []acme.Authorization
+	for _, domain := range []string{"example.com", "a.example.com"} {
+		authzs = append(authzs, acme.Authorization{
+			Identifier: acme.Identifier{Value: domain},
+			Challenges: []acme.Challenge{
+				{Type: challenge.DNS01.String(), Token: "token"},
+			},
+		})
+	}
+
+	return core, authzs
+}
+
+func TestChallenge_PreSolveAll(t *testing.T) {
+	core, authzs := setupZoneSessionTest(t)
+
+	provider := &providerZoneSessionMock{}
+
+	failures := NewChallenge(core, nil, provider).PreSolveAll(authzs)
+	assert.Empty(t, failures)
+
+	assert.Equal(t, []string{"begin", "present example.com", "present a.example.com", "flush"}, provider.calls)
+}
+
+func TestChallenge_PreSolveAll_flushError(t *testing.T) {
+	core, authzs := setupZoneSessionTest(t)
+
+	provider := &providerZoneSessionMock{flush: errors.New("OOPS")}
+
+	failures := NewChallenge(core, nil, provider).PreSolveAll(authzs)
+
+	expected := map[string]error{
+		"example.com":   errors.New("[example.com] acme: error presenting token: OOPS"),
+		"a.example.com": errors.New("[a.example.com] acme: error presenting token: OOPS"),
+	}
+	require.Len(t, failures, len(expected))
+	for domain, err := range expected {
+		assert.EqualError(t, failures[domain], err.Error())
+	}
+}
+
+func TestChallenge_PreSolveAll_noSession(t *testing.T) {
+	core, authzs := setupZoneSessionTest(t)
+
+	provider := &providerRecorderMock{}
+
+	failures := NewChallenge(core, nil, provider).PreSolveAll(authzs)
+	assert.Empty(t, failures)
+
+	assert.Equal(t, []string{"example.com", "a.example.com"}, provider.presents)
+}
+
+func TestChallenge_CleanUpAll_session(t *testing.T) {
+	core, authzs := setupZoneSessionTest(t)
+
+	provider := &providerZoneSessionMock{}
+
+	NewChallenge(core, nil, provider).CleanUpAll(authzs)
+
+	assert.Equal(t, []string{"begin", "cleanup example.com", "cleanup a.example.com", "flush"}, provider.calls)
+}
@@ -0,0 +1,76 @@
+package dns01
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFindMostSpecificZone(t *testing.T) {
+	testCases := []struct {
+		desc     string
+		fqdn     string
+		zones    []string
+		expected string
+	}{
+		{
+			desc:     "single zone",
+			fqdn:     "_acme-challenge.www.example.com.",
+			zones:    []string{"example.com"},
+			expected: "example.com",
+		},
+		{
+			desc:     "nested zones",
+			fqdn:     "_acme-challenge.www.internal.example.com.",
+			zones:    []string{"example.com", "internal.example.com"},
+			expected: "internal.example.com",
+		},
+		{
+			desc:     "nested zones, sub-zone listed first",
+			fqdn:     "_acme-challenge.www.internal.example.com.",
+			zones:    []string{"internal.example.com.", "example.com."},
+			expected: "internal.example.com.",
+		},
+		{
+			desc:     "nested zones, name in the parent zone",
+			fqdn:     "_acme-challenge.www.example.com.",
+			zones:    []string{"internal.example.com", "example.com"},
+			expected: "example.com",
+		},
+		{
+			desc:     "deeply nested zones",
+			fqdn:     "_acme-challenge.a.b.c.example.com.",
+			zones:    []string{"c.example.com", "example.com", "b.c.example.com", "x.b.c.example.com"},
+			expected: "b.c.example.com",
+		},
+		{
+			desc:     "label boundary",
+			fqdn:     "_acme-challenge.myexample.com.",
+			zones:    []string{"example.com"},
+			expected: "",
+		},
+		{
+			desc:     "case insensitive",
+			fqdn:     "_acme-challenge.WWW.Example.com.",
+			zones:    []string{"EXAMPLE.COM"},
+			expected: "EXAMPLE.COM",
+		},
+		{
+			desc:     "the fqdn is the zone",
+			fqdn:     "example.com.",
+			zones:    []string{"example.com"},
+			expected: "example.com",
+		},
+		{
+			desc:  "no zones",
+			fqdn:  "_acme-challenge.example.com.",
+			zones: nil,
+		},
+	}
+
+	for _, test := range testCases {
+		t.Run(test.desc, func(t *testing.T) {
+			assert.Equal(t, test.expected, FindMostSpecificZone(test.fqdn, test.zones))
+		})
+	}
+}
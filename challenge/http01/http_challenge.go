@@ -1,6 +1,7 @@
 package http01
 
 import (
+	"context"
 	"fmt"
 
 	"github.com/go-acme/lego/v4/acme"
@@ -16,18 +17,40 @@ func ChallengePath(token string) string {
 	return "/.well-known/acme-challenge/" + token
 }
 
+type ChallengeOption func(*Challenge) error
+
 type Challenge struct {
 	core     *api.Core
 	validate ValidateFunc
 	provider challenge.Provider
+
+	cleanUpOnPresentError bool
 }
 
-func NewChallenge(core *api.Core, validate ValidateFunc, provider challenge.Provider) *Challenge {
-	return &Challenge{
+func NewChallenge(core *api.Core, validate ValidateFunc, provider challenge.Provider, opts ...ChallengeOption) *Challenge {
+	chlg := &Challenge{
 		core:     core,
 		validate: validate,
 		provider: provider,
 	}
+
+	for _, opt := range opts {
+		err := opt(chlg)
+		if err != nil {
+			log.Infof("challenge option error: %v", err)
+		}
+	}
+
+	return chlg
+}
+
+// CleanUpOnPresentError calls the CleanUp method of the provider even if Present returned an error.
+// A provider failing in the middle of Present can leave a partially created resource.
+func CleanUpOnPresentError() ChallengeOption {
+	return func(chlg *Challenge) error {
+		chlg.cleanUpOnPresentError = true
+		return nil
+	}
 }
 
 func (c *Challenge) SetProvider(provider challenge.Provider) {
@@ -35,6 +58,12 @@ func (c *Challenge) SetProvider(provider challenge.Provider) {
 }
 
 func (c *Challenge) Solve(authz acme.Authorization) error {
+	return c.SolveWithContext(context.Background(), authz)
+}
+
+// SolveWithContext presents the challenge, then asks the CA to validate it.
+// The validation stops when the context is done.
+func (c *Challenge) SolveWithContext(ctx context.Context, authz acme.Authorization) error {
 	domain := challenge.GetTargetedDomain(authz)
 	log.Infof("[%s] acme: Trying to solve HTTP-01", domain)
 
@@ -51,15 +80,21 @@ func (c *Challenge) Solve(authz acme.Authorization) error {
 
 	err = c.provider.Present(authz.Identifier.Value, chlng.Token, keyAuth)
 	if err != nil {
+		if c.cleanUpOnPresentError {
+			c.cleanUp(authz, chlng.Token, keyAuth)
+		}
+
 		return fmt.Errorf("[%s] acme: error presenting token: %w", domain, err)
 	}
-	defer func() {
-		err := c.provider.CleanUp(authz.Identifier.Value, chlng.Token, keyAuth)
-		if err != nil {
-			log.Warnf("[%s] acme: cleaning up failed: %v", domain, err)
-		}
-	}()
+	defer c.cleanUp(authz, chlng.Token, keyAuth)
 
 	chlng.KeyAuthorization = keyAuth
-	return c.validate(c.core, domain, chlng)
+	return c.validate(c.core.WithContext(ctx), domain, chlng)
+}
+
+func (c *Challenge) cleanUp(authz acme.Authorization, token, keyAuth string) {
+	err := c.provider.CleanUp(authz.Identifier.Value, token, keyAuth)
+	if err != nil {
+		log.Warnf("[%s] acme: cleaning up failed: %v", challenge.GetTargetedDomain(authz), err)
+	}
 }
@@ -3,6 +3,7 @@ package http01
 import (
 	"crypto/rand"
 	"crypto/rsa"
+	"errors"
 	"fmt"
 	"io/ioutil"
 	"net/http"
@@ -320,3 +321,67 @@ func testServeWithProxy(t *testing.T, header, extra *testProxyHeader, expectErro
 		require.NoError(t, err)
 	}
 }
+
+// providerFailingMock fails to present the token, after the creation of a partial resource.
+type providerFailingMock struct {
+	cleanedUp bool
+}
+
+func (p *providerFailingMock) Present(domain, token, keyAuth string) error {
+	return errors.New("partially presented")
+}
+
+func (p *providerFailingMock) CleanUp(domain, token, keyAuth string) error {
+	p.cleanedUp = true
+	return nil
+}
+
+func TestChallenge_Solve_presentError(t *testing.T) {
+	testCases := []struct {
+		desc      string
+		options   []ChallengeOption
+		cleanedUp bool
+	}{
+		{
+			desc: "default",
+		},
+		{
+			desc:      "clean up on present error",
+			options:   []ChallengeOption{CleanUpOnPresentError()},
+			cleanedUp: true,
+		},
+	}
+
+	for _, test := range testCases {
+		t.Run(test.desc, func(t *testing.T) {
+			_, apiURL, tearDown := tester.SetupFakeAPI()
+			defer tearDown()
+
+			privateKey, err := rsa.GenerateKey(rand.Reader, 512)
+			require.NoError(t, err)
+
+			core, err := api.New(http.DefaultClient, "lego-test", apiURL+"/dir", "", privateKey)
+			require.NoError(t, err)
+
+			provider := &providerFailingMock{}
+
+			validate := func(_ *api.Core, _ string, _ acme.Challenge) error { return nil }
+
+			solver := NewChallenge(core, validate, provider, test.options...)
+
+			authz := acme.Authorization{
+				Identifier: acme.Identifier{
+					Value: "example.com",
+				},
+				Challenges: []acme.Challenge{
+					{Type: challenge.HTTP01.String(), Token: "http3"},
+				},
+			}
+
+			err = solver.Solve(authz)
+			require.EqualError(t, err, "[example.com] acme: error presenting token: partially presented")
+
+			assert.Equal(t, test.cleanedUp, provider.cleanedUp)
+		})
+	}
+}
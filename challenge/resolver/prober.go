@@ -1,6 +1,7 @@
 package resolver
 
 import (
+	"context"
 	"fmt"
 	"time"
 
@@ -14,25 +15,42 @@ type solver interface {
 	Solve(authorization acme.Authorization) error
 }
 
+// Interface for challenges which can be abandoned when the context is done.
+type contextSolver interface {
+	SolveWithContext(ctx context.Context, authorization acme.Authorization) error
+}
+
 // Interface for challenges like dns, where we can set a record in advance for ALL challenges.
 // This saves quite a bit of time vs creating the records and solving them serially.
 type preSolver interface {
 	PreSolve(authorization acme.Authorization) error
 }
 
+// Interface for challenges like dns, where all the challenges can be submitted in one operation.
+// Returns the errors by domain.
+type batchPreSolver interface {
+	PreSolveAll(authorizations []acme.Authorization) map[string]error
+}
+
 // Interface for challenges like dns, where we can solve all the challenges before to delete them.
 type cleanup interface {
 	CleanUp(authorization acme.Authorization) error
 }
 
+// Interface for challenges like dns, where all the challenges can be deleted in one operation.
+type batchCleanup interface {
+	CleanUpAll(authorizations []acme.Authorization)
+}
+
 type sequential interface {
 	Sequential() (bool, time.Duration)
 }
 
 // an authz with the solver we have chosen and the index of the challenge associated with it.
 type selectedAuthSolver struct {
-	authz  acme.Authorization
-	solver solver
+	authz         acme.Authorization
+	solver        solver
+	challengeType challenge.Type
 }
 
 type Prober struct {
@@ -48,6 +66,13 @@ func NewProber(solverManager *SolverManager) *Prober {
 // Solve Looks through the challenge combinations to find a solvable match.
 // Then solves the challenges in series and returns.
 func (p *Prober) Solve(authorizations []acme.Authorization) error {
+	return p.SolveWithContext(context.Background(), authorizations)
+}
+
+// SolveWithContext Looks through the challenge combinations to find a solvable match.
+// Then solves the challenges in series and returns.
+// The challenges not solved yet fail when the context is done, the created challenges are still cleaned up.
+func (p *Prober) SolveWithContext(ctx context.Context, authorizations []acme.Authorization) error {
 	failures := make(obtainError)
 
 	var authSolvers []*selectedAuthSolver
@@ -63,8 +88,8 @@ func (p *Prober) Solve(authorizations []acme.Authorization) error {
 			continue
 		}
 
-		if solvr := p.solverManager.chooseSolver(authz); solvr != nil {
-			authSolver := &selectedAuthSolver{authz: authz, solver: solvr}
+		if solvr, chlgType := p.solverManager.chooseSolver(authz); solvr != nil {
+			authSolver := &selectedAuthSolver{authz: authz, solver: solvr, challengeType: chlgType}
 
 			switch s := solvr.(type) {
 			case sequential:
@@ -81,9 +106,11 @@ func (p *Prober) Solve(authorizations []acme.Authorization) error {
 		}
 	}
 
-	parallelSolve(authSolvers, failures)
+	parallelSolve(ctx, authSolvers, failures)
 
-	sequentialSolve(authSolversSequential, failures)
+	sequentialSolve(ctx, authSolversSequential, failures)
+
+	p.notify(append(authSolvers, authSolversSequential...), failures)
 
 	// Be careful not to return an empty failures map,
 	// for even an empty obtainError is a non-nil error value
@@ -93,11 +120,39 @@ func (p *Prober) Solve(authorizations []acme.Authorization) error {
 	return nil
 }
 
-func sequentialSolve(authSolvers []*selectedAuthSolver, failures obtainError) {
+// notify calls the challenge callbacks, and records the metrics, with the results of the solvers.
+func (p *Prober) notify(authSolvers []*selectedAuthSolver, failures obtainError) {
+	callbacks := p.solverManager.callbacks
+	recorder := p.solverManager.metrics
+
+	for _, authSolver := range authSolvers {
+		domain := challenge.GetTargetedDomain(authSolver.authz)
+
+		recorder.ChallengeSolved(authSolver.challengeType.String(), failures[domain] == nil)
+
+		if err := failures[domain]; err != nil {
+			if callbacks.OnChallengeFailed != nil {
+				callbacks.OnChallengeFailed(domain, authSolver.challengeType, err)
+			}
+			continue
+		}
+
+		if callbacks.OnChallengeValidated != nil {
+			callbacks.OnChallengeValidated(domain, authSolver.challengeType)
+		}
+	}
+}
+
+func sequentialSolve(ctx context.Context, authSolvers []*selectedAuthSolver, failures obtainError) {
 	for i, authSolver := range authSolvers {
 		// Submit the challenge
 		domain := challenge.GetTargetedDomain(authSolver.authz)
 
+		if ctx.Err() != nil {
+			failures[domain] = ctx.Err()
+			continue
+		}
+
 		if solvr, ok := authSolver.solver.(preSolver); ok {
 			err := solvr.PreSolve(authSolver.authz)
 			if err != nil {
@@ -108,7 +163,7 @@ func sequentialSolve(authSolvers []*selectedAuthSolver, failures obtainError) {
 		}
 
 		// Solve challenge
-		err := authSolver.solver.Solve(authSolver.authz)
+		err := solve(ctx, authSolver)
 		if err != nil {
 			failures[domain] = err
 			cleanUp(authSolver.solver, authSolver.authz)
@@ -122,28 +177,22 @@ func sequentialSolve(authSolvers []*selectedAuthSolver, failures obtainError) {
 			solvr := authSolver.solver.(sequential)
 			_, interval := solvr.Sequential()
 			log.Infof("sequence: wait for %s", interval)
-			time.Sleep(interval)
+
+			select {
+			case <-time.After(interval):
+			case <-ctx.Done():
+			}
 		}
 	}
 }
 
-func parallelSolve(authSolvers []*selectedAuthSolver, failures obtainError) {
+func parallelSolve(ctx context.Context, authSolvers []*selectedAuthSolver, failures obtainError) {
 	// For all valid preSolvers, first submit the challenges so they have max time to propagate
-	for _, authSolver := range authSolvers {
-		authz := authSolver.authz
-		if solvr, ok := authSolver.solver.(preSolver); ok {
-			err := solvr.PreSolve(authz)
-			if err != nil {
-				failures[challenge.GetTargetedDomain(authz)] = err
-			}
-		}
-	}
+	preSolveAll(authSolvers, failures)
 
 	defer func() {
 		// Clean all created TXT records
-		for _, authSolver := range authSolvers {
-			cleanUp(authSolver.solver, authSolver.authz)
-		}
+		cleanUpAll(authSolvers)
 	}()
 
 	// Finally solve all challenges for real
@@ -155,13 +204,60 @@ func parallelSolve(authSolvers []*selectedAuthSolver, failures obtainError) {
 			continue
 		}
 
-		err := authSolver.solver.Solve(authz)
+		if ctx.Err() != nil {
+			failures[domain] = ctx.Err()
+			continue
+		}
+
+		err := solve(ctx, authSolver)
 		if err != nil {
 			failures[domain] = err
 		}
 	}
 }
 
+// preSolveAll submits the challenges of the solvers.
+// The authorizations of a solver implementing batchPreSolver are submitted in one call.
+func preSolveAll(authSolvers []*selectedAuthSolver, failures obtainError) {
+	var solvers []batchPreSolver
+	batches := make(map[batchPreSolver][]acme.Authorization)
+
+	for _, authSolver := range authSolvers {
+		authz := authSolver.authz
+
+		if solvr, ok := authSolver.solver.(batchPreSolver); ok {
+			if _, exists := batches[solvr]; !exists {
+				solvers = append(solvers, solvr)
+			}
+
+			batches[solvr] = append(batches[solvr], authz)
+			continue
+		}
+
+		if solvr, ok := authSolver.solver.(preSolver); ok {
+			err := solvr.PreSolve(authz)
+			if err != nil {
+				failures[challenge.GetTargetedDomain(authz)] = err
+			}
+		}
+	}
+
+	for _, solvr := range solvers {
+		for domain, err := range solvr.PreSolveAll(batches[solvr]) {
+			failures[domain] = err
+		}
+	}
+}
+
+// solve solves the challenge, with the context if the solver supports it.
+func solve(ctx context.Context, authSolver *selectedAuthSolver) error {
+	if s, ok := authSolver.solver.(contextSolver); ok {
+		return s.SolveWithContext(ctx, authSolver.authz)
+	}
+
+	return authSolver.solver.Solve(authSolver.authz)
+}
+
 func cleanUp(solvr solver, authz acme.Authorization) {
 	if solvr, ok := solvr.(cleanup); ok {
 		domain := challenge.GetTargetedDomain(authz)
@@ -171,3 +267,28 @@ func cleanUp(solvr solver, authz acme.Authorization) {
 		}
 	}
 }
+
+// cleanUpAll cleans up the authorizations of the solvers.
+// The authorizations of a solver implementing batchCleanup are cleaned up in one call.
+func cleanUpAll(authSolvers []*selectedAuthSolver) {
+	var solvers []batchCleanup
+	batches := make(map[batchCleanup][]acme.Authorization)
+
+	for _, authSolver := range authSolvers {
+		solvr, ok := authSolver.solver.(batchCleanup)
+		if !ok {
+			cleanUp(authSolver.solver, authSolver.authz)
+			continue
+		}
+
+		if _, exists := batches[solvr]; !exists {
+			solvers = append(solvers, solvr)
+		}
+
+		batches[solvr] = append(batches[solvr], authSolver.authz)
+	}
+
+	for _, solvr := range solvers {
+		solvr.CleanUpAll(batches[solvr])
+	}
+}
@@ -1,10 +1,13 @@
 package resolver
 
 import (
+	"context"
+	"fmt"
 	"time"
 
 	"github.com/go-acme/lego/v4/acme"
 	"github.com/go-acme/lego/v4/challenge"
+	"github.com/go-acme/lego/v4/metrics"
 )
 
 type preSolverMock struct {
@@ -25,6 +28,42 @@ func (s *preSolverMock) CleanUp(authorization acme.Authorization) error {
 	return s.cleanUp[authorization.Identifier.Value]
 }
 
+type batchCleanUpMock struct {
+	preSolverMock
+	cleanUpAll [][]string
+}
+
+func (s *batchCleanUpMock) CleanUpAll(authorizations []acme.Authorization) {
+	var domains []string
+	for _, authz := range authorizations {
+		domains = append(domains, authz.Identifier.Value)
+	}
+
+	s.cleanUpAll = append(s.cleanUpAll, domains)
+}
+
+type batchPreSolveMock struct {
+	preSolverMock
+	preSolveAll [][]string
+}
+
+func (s *batchPreSolveMock) PreSolveAll(authorizations []acme.Authorization) map[string]error {
+	var domains []string
+	failures := make(map[string]error)
+
+	for _, authz := range authorizations {
+		domains = append(domains, authz.Identifier.Value)
+
+		if err := s.preSolve[authz.Identifier.Value]; err != nil {
+			failures[authz.Identifier.Value] = err
+		}
+	}
+
+	s.preSolveAll = append(s.preSolveAll, domains)
+
+	return failures
+}
+
 func createStubAuthorizationHTTP01(domain, status string) acme.Authorization {
 	return acme.Authorization{
 		Status:  status,
@@ -42,3 +81,34 @@ func createStubAuthorizationHTTP01(domain, status string) acme.Authorization {
 		},
 	}
 }
+
+type contextSolverMock struct {
+	preSolverMock
+	solved  []string
+	cleaned []string
+}
+
+func (s *contextSolverMock) SolveWithContext(ctx context.Context, authorization acme.Authorization) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	s.solved = append(s.solved, authorization.Identifier.Value)
+
+	return s.solve[authorization.Identifier.Value]
+}
+
+func (s *contextSolverMock) CleanUp(authorization acme.Authorization) error {
+	s.cleaned = append(s.cleaned, authorization.Identifier.Value)
+
+	return s.cleanUp[authorization.Identifier.Value]
+}
+
+type metricsRecorderMock struct {
+	metrics.NoopRecorder
+	challenges []string
+}
+
+func (m *metricsRecorderMock) ChallengeSolved(challengeType string, success bool) {
+	m.challenges = append(m.challenges, fmt.Sprintf("%s %t", challengeType, success))
+}
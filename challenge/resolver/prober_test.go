@@ -1,11 +1,15 @@
 package resolver
 
 import (
+	"context"
 	"errors"
+	"fmt"
 	"testing"
 
 	"github.com/go-acme/lego/v4/acme"
 	"github.com/go-acme/lego/v4/challenge"
+	"github.com/go-acme/lego/v4/metrics"
+	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 )
 
@@ -104,7 +108,7 @@ func TestProber_Solve(t *testing.T) {
 			t.Parallel()
 
 			prober := &Prober{
-				solverManager: &SolverManager{solvers: test.solvers},
+				solverManager: &SolverManager{solvers: test.solvers, metrics: metrics.NoopRecorder{}},
 			}
 
 			err := prober.Solve(test.authz)
@@ -116,3 +120,163 @@ func TestProber_Solve(t *testing.T) {
 		})
 	}
 }
+
+func TestProber_Solve_batchCleanUp(t *testing.T) {
+	solvr := &batchCleanUpMock{
+		preSolverMock: preSolverMock{
+			preSolve: map[string]error{},
+			solve:    map[string]error{},
+			cleanUp:  map[string]error{},
+		},
+	}
+
+	prober := &Prober{
+		solverManager: &SolverManager{solvers: map[challenge.Type]solver{challenge.HTTP01: solvr}, metrics: metrics.NoopRecorder{}},
+	}
+
+	err := prober.Solve([]acme.Authorization{
+		createStubAuthorizationHTTP01("acme.wtf", acme.StatusProcessing),
+		createStubAuthorizationHTTP01("lego.wtf", acme.StatusProcessing),
+		createStubAuthorizationHTTP01("mydomain.wtf", acme.StatusProcessing),
+	})
+	require.NoError(t, err)
+
+	assert.Equal(t, [][]string{{"acme.wtf", "lego.wtf", "mydomain.wtf"}}, solvr.cleanUpAll)
+}
+
+func TestProber_Solve_batchPreSolve(t *testing.T) {
+	solvr := &batchPreSolveMock{
+		preSolverMock: preSolverMock{
+			preSolve: map[string]error{
+				"lego.wtf": errors.New("OOPS"),
+			},
+			solve:   map[string]error{},
+			cleanUp: map[string]error{},
+		},
+	}
+
+	prober := &Prober{
+		solverManager: &SolverManager{solvers: map[challenge.Type]solver{challenge.HTTP01: solvr}, metrics: metrics.NoopRecorder{}},
+	}
+
+	err := prober.Solve([]acme.Authorization{
+		createStubAuthorizationHTTP01("acme.wtf", acme.StatusProcessing),
+		createStubAuthorizationHTTP01("lego.wtf", acme.StatusProcessing),
+		createStubAuthorizationHTTP01("mydomain.wtf", acme.StatusProcessing),
+	})
+	require.EqualError(t, err, "error: one or more domains had a problem:\n[lego.wtf] OOPS\n")
+
+	assert.Equal(t, [][]string{{"acme.wtf", "lego.wtf", "mydomain.wtf"}}, solvr.preSolveAll)
+}
+
+func TestProber_SolveWithContext(t *testing.T) {
+	solvr := &contextSolverMock{
+		preSolverMock: preSolverMock{
+			preSolve: map[string]error{},
+			solve:    map[string]error{},
+			cleanUp:  map[string]error{},
+		},
+	}
+
+	prober := &Prober{
+		solverManager: &SolverManager{solvers: map[challenge.Type]solver{challenge.HTTP01: solvr}, metrics: metrics.NoopRecorder{}},
+	}
+
+	err := prober.SolveWithContext(context.Background(), []acme.Authorization{
+		createStubAuthorizationHTTP01("acme.wtf", acme.StatusProcessing),
+		createStubAuthorizationHTTP01("lego.wtf", acme.StatusProcessing),
+	})
+	require.NoError(t, err)
+
+	assert.ElementsMatch(t, []string{"acme.wtf", "lego.wtf"}, solvr.solved)
+}
+
+func TestProber_SolveWithContext_canceled(t *testing.T) {
+	solvr := &contextSolverMock{
+		preSolverMock: preSolverMock{
+			preSolve: map[string]error{},
+			solve:    map[string]error{},
+			cleanUp:  map[string]error{},
+		},
+	}
+
+	prober := &Prober{
+		solverManager: &SolverManager{solvers: map[challenge.Type]solver{challenge.HTTP01: solvr}, metrics: metrics.NoopRecorder{}},
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	err := prober.SolveWithContext(ctx, []acme.Authorization{
+		createStubAuthorizationHTTP01("acme.wtf", acme.StatusProcessing),
+		createStubAuthorizationHTTP01("lego.wtf", acme.StatusProcessing),
+	})
+	require.EqualError(t, err, "error: one or more domains had a problem:\n[acme.wtf] context canceled\n[lego.wtf] context canceled\n")
+
+	assert.Empty(t, solvr.solved)
+	// the records are cleaned up even if the context is done.
+	assert.ElementsMatch(t, []string{"acme.wtf", "lego.wtf"}, solvr.cleaned)
+}
+
+func TestProber_Solve_metrics(t *testing.T) {
+	recorder := &metricsRecorderMock{}
+
+	solvr := &preSolverMock{
+		preSolve: map[string]error{},
+		solve: map[string]error{
+			"lego.wtf": errors.New("OOPS"),
+		},
+		cleanUp: map[string]error{},
+	}
+
+	prober := &Prober{
+		solverManager: &SolverManager{
+			solvers: map[challenge.Type]solver{challenge.HTTP01: solvr},
+			metrics: recorder,
+		},
+	}
+
+	err := prober.Solve([]acme.Authorization{
+		createStubAuthorizationHTTP01("acme.wtf", acme.StatusProcessing),
+		createStubAuthorizationHTTP01("lego.wtf", acme.StatusProcessing),
+	})
+	require.Error(t, err)
+
+	assert.Equal(t, []string{"http-01 true", "http-01 false"}, recorder.challenges)
+}
+
+func TestProber_Solve_callbacks(t *testing.T) {
+	var events []string
+
+	solvr := &preSolverMock{
+		preSolve: map[string]error{},
+		solve: map[string]error{
+			"lego.wtf": errors.New("OOPS"),
+		},
+		cleanUp: map[string]error{},
+	}
+
+	manager := &SolverManager{solvers: map[challenge.Type]solver{challenge.HTTP01: solvr}, metrics: metrics.NoopRecorder{}}
+	manager.SetChallengeCallbacks(ChallengeCallbacks{
+		OnChallengeValidated: func(domain string, chlgType challenge.Type) {
+			events = append(events, fmt.Sprintf("validated %s %s", domain, chlgType))
+		},
+		OnChallengeFailed: func(domain string, chlgType challenge.Type, err error) {
+			events = append(events, fmt.Sprintf("failed %s %s: %v", domain, chlgType, err))
+		},
+	})
+
+	prober := &Prober{solverManager: manager}
+
+	err := prober.Solve([]acme.Authorization{
+		createStubAuthorizationHTTP01("acme.wtf", acme.StatusProcessing),
+		createStubAuthorizationHTTP01("lego.wtf", acme.StatusProcessing),
+	})
+	require.Error(t, err)
+
+	expected := []string{
+		"validated acme.wtf http-01",
+		"failed lego.wtf http-01: OOPS",
+	}
+	assert.Equal(t, expected, events)
+}
@@ -16,6 +16,7 @@ import (
 	"github.com/go-acme/lego/v4/challenge/http01"
 	"github.com/go-acme/lego/v4/challenge/tlsalpn01"
 	"github.com/go-acme/lego/v4/log"
+	"github.com/go-acme/lego/v4/metrics"
 )
 
 type byType []acme.Challenge
@@ -24,43 +25,64 @@ func (a byType) Len() int           { return len(a) }
 func (a byType) Swap(i, j int)      { a[i], a[j] = a[j], a[i] }
 func (a byType) Less(i, j int) bool { return a[i].Type > a[j].Type }
 
+// ChallengeCallbacks the callbacks of the challenge lifecycle.
+// They are called synchronously by the solvers, so they must not block.
+type ChallengeCallbacks struct {
+	// OnChallengePresented is called when a challenge is ready to be validated by the CA.
+	OnChallengePresented func(domain string, chlgType challenge.Type)
+
+	// OnChallengeValidated is called when a challenge has been validated by the CA.
+	OnChallengeValidated func(domain string, chlgType challenge.Type)
+
+	// OnChallengeFailed is called when a challenge cannot be presented or validated.
+	OnChallengeFailed func(domain string, chlgType challenge.Type, err error)
+}
+
 type SolverManager struct {
-	core    *api.Core
-	solvers map[challenge.Type]solver
+	core      *api.Core
+	solvers   map[challenge.Type]solver
+	callbacks ChallengeCallbacks
+	metrics   metrics.Recorder
 }
 
 func NewSolversManager(core *api.Core) *SolverManager {
 	return &SolverManager{
 		solvers: map[challenge.Type]solver{},
 		core:    core,
+		metrics: core.Metrics(),
 	}
 }
 
 // SetHTTP01Provider specifies a custom provider p that can solve the given HTTP-01 challenge.
-func (c *SolverManager) SetHTTP01Provider(p challenge.Provider) error {
-	c.solvers[challenge.HTTP01] = http01.NewChallenge(c.core, validate, p)
+func (c *SolverManager) SetHTTP01Provider(p challenge.Provider, opts ...http01.ChallengeOption) error {
+	c.solvers[challenge.HTTP01] = http01.NewChallenge(c.core, c.validate, p, opts...)
 	return nil
 }
 
 // SetTLSALPN01Provider specifies a custom provider p that can solve the given TLS-ALPN-01 challenge.
-func (c *SolverManager) SetTLSALPN01Provider(p challenge.Provider) error {
-	c.solvers[challenge.TLSALPN01] = tlsalpn01.NewChallenge(c.core, validate, p)
+func (c *SolverManager) SetTLSALPN01Provider(p challenge.Provider, opts ...tlsalpn01.ChallengeOption) error {
+	c.solvers[challenge.TLSALPN01] = tlsalpn01.NewChallenge(c.core, c.validate, p, opts...)
 	return nil
 }
 
 // SetDNS01Provider specifies a custom provider p that can solve the given DNS-01 challenge.
 func (c *SolverManager) SetDNS01Provider(p challenge.Provider, opts ...dns01.ChallengeOption) error {
-	c.solvers[challenge.DNS01] = dns01.NewChallenge(c.core, validate, p, opts...)
+	c.solvers[challenge.DNS01] = dns01.NewChallenge(c.core, c.validate, p, opts...)
 	return nil
 }
 
+// SetChallengeCallbacks defines the callbacks of the challenge lifecycle.
+func (c *SolverManager) SetChallengeCallbacks(callbacks ChallengeCallbacks) {
+	c.callbacks = callbacks
+}
+
 // Remove Remove a challenge type from the available solvers.
 func (c *SolverManager) Remove(chlgType challenge.Type) {
 	delete(c.solvers, chlgType)
 }
 
 // Checks all challenges from the server in order and returns the first matching solver.
-func (c *SolverManager) chooseSolver(authz acme.Authorization) solver {
+func (c *SolverManager) chooseSolver(authz acme.Authorization) (solver, challenge.Type) {
 	// Allow to have a deterministic challenge order
 	sort.Sort(byType(authz.Challenges))
 
@@ -68,12 +90,21 @@ func (c *SolverManager) chooseSolver(authz acme.Authorization) solver {
 	for _, chlg := range authz.Challenges {
 		if solvr, ok := c.solvers[challenge.Type(chlg.Type)]; ok {
 			log.Infof("[%s] acme: use %s solver", domain, chlg.Type)
-			return solvr
+			return solvr, challenge.Type(chlg.Type)
 		}
 		log.Infof("[%s] acme: Could not find solver for: %s", domain, chlg.Type)
 	}
 
-	return nil
+	return nil, ""
+}
+
+// validate asks the CA to validate the presented challenge.
+func (c *SolverManager) validate(core *api.Core, domain string, chlg acme.Challenge) error {
+	if c.callbacks.OnChallengePresented != nil {
+		c.callbacks.OnChallengePresented(domain, challenge.Type(chlg.Type))
+	}
+
+	return validate(core, domain, chlg)
 }
 
 func validate(core *api.Core, domain string, chlg acme.Challenge) error {
@@ -107,7 +138,7 @@ func validate(core *api.Core, domain string, chlg acme.Challenge) error {
 	bo.MaxInterval = 10 * initialInterval
 	bo.MaxElapsedTime = 100 * initialInterval
 
-	ctx, cancel := context.WithCancel(context.Background())
+	ctx, cancel := context.WithCancel(core.Context())
 
 	// After the path is sent, the ACME server will access our server.
 	// Repeatedly check the server for an updated status on our request.
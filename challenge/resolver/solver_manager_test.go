@@ -1,6 +1,7 @@
 package resolver
 
 import (
+	"context"
 	"crypto/rand"
 	"crypto/rsa"
 	"fmt"
@@ -11,6 +12,7 @@ import (
 
 	"github.com/go-acme/lego/v4/acme"
 	"github.com/go-acme/lego/v4/acme/api"
+	"github.com/go-acme/lego/v4/challenge"
 	"github.com/go-acme/lego/v4/platform/tester"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -177,3 +179,31 @@ func validateNoBody(privateKey *rsa.PrivateKey, r *http.Request) error {
 	}
 	return nil
 }
+
+func TestSolverManager_validate_onChallengePresented(t *testing.T) {
+	_, apiURL, tearDown := tester.SetupFakeAPI()
+	defer tearDown()
+
+	privateKey, _ := rsa.GenerateKey(rand.Reader, 512)
+
+	core, err := api.New(http.DefaultClient, "lego-test", apiURL+"/dir", "", privateKey)
+	require.NoError(t, err)
+
+	var presented []string
+
+	manager := NewSolversManager(core)
+	manager.SetChallengeCallbacks(ChallengeCallbacks{
+		OnChallengePresented: func(domain string, chlgType challenge.Type) {
+			presented = append(presented, domain+" "+chlgType.String())
+		},
+	})
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	// the request to the CA fails, the challenge is presented before.
+	err = manager.validate(core.WithContext(ctx), "example.com", acme.Challenge{Type: "http-01", URL: apiURL + "/chlg"})
+	require.Error(t, err)
+
+	assert.Equal(t, []string{"example.com http-01"}, presented)
+}
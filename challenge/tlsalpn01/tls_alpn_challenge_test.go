@@ -7,6 +7,7 @@ import (
 	"crypto/subtle"
 	"crypto/tls"
 	"encoding/asn1"
+	"errors"
 	"net/http"
 	"testing"
 
@@ -118,3 +119,67 @@ func TestChallengeInvalidPort(t *testing.T) {
 	assert.Contains(t, err.Error(), "invalid port")
 	assert.Contains(t, err.Error(), "123456")
 }
+
+// providerFailingMock fails to present the token, after the creation of a partial resource.
+type providerFailingMock struct {
+	cleanedUp bool
+}
+
+func (p *providerFailingMock) Present(domain, token, keyAuth string) error {
+	return errors.New("partially presented")
+}
+
+func (p *providerFailingMock) CleanUp(domain, token, keyAuth string) error {
+	p.cleanedUp = true
+	return nil
+}
+
+func TestChallenge_Solve_presentError(t *testing.T) {
+	testCases := []struct {
+		desc      string
+		options   []ChallengeOption
+		cleanedUp bool
+	}{
+		{
+			desc: "default",
+		},
+		{
+			desc:      "clean up on present error",
+			options:   []ChallengeOption{CleanUpOnPresentError()},
+			cleanedUp: true,
+		},
+	}
+
+	for _, test := range testCases {
+		t.Run(test.desc, func(t *testing.T) {
+			_, apiURL, tearDown := tester.SetupFakeAPI()
+			defer tearDown()
+
+			privateKey, err := rsa.GenerateKey(rand.Reader, 512)
+			require.NoError(t, err)
+
+			core, err := api.New(http.DefaultClient, "lego-test", apiURL+"/dir", "", privateKey)
+			require.NoError(t, err)
+
+			provider := &providerFailingMock{}
+
+			validate := func(_ *api.Core, _ string, _ acme.Challenge) error { return nil }
+
+			solver := NewChallenge(core, validate, provider, test.options...)
+
+			authz := acme.Authorization{
+				Identifier: acme.Identifier{
+					Value: "example.com",
+				},
+				Challenges: []acme.Challenge{
+					{Type: challenge.TLSALPN01.String(), Token: "tlsalpn3"},
+				},
+			}
+
+			err = solver.Solve(authz)
+			require.EqualError(t, err, "[example.com] acme: error presenting token: partially presented")
+
+			assert.Equal(t, test.cleanedUp, provider.cleanedUp)
+		})
+	}
+}
@@ -30,4 +30,9 @@ func (a *Account) GetRegistration() *registration.Resource {
 	return a.Registration
 }
 
+// SetPrivateKey sets the account key (account key rollover).
+func (a *Account) SetPrivateKey(privateKey crypto.PrivateKey) {
+	a.key = privateKey
+}
+
 /** End **/
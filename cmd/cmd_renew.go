@@ -3,8 +3,10 @@ package cmd
 import (
 	"crypto"
 	"crypto/x509"
+	"errors"
 	"time"
 
+	"github.com/go-acme/lego/v4/acme/api"
 	"github.com/go-acme/lego/v4/certcrypto"
 	"github.com/go-acme/lego/v4/certificate"
 	"github.com/go-acme/lego/v4/lego"
@@ -62,6 +64,18 @@ func createRenew() cli.Command {
 				Name:  "preferred-chain",
 				Usage: "If the CA offers multiple certificate chains, prefer the chain with an issuer matching this Subject Common Name. If no match, the default offered chain will be used.",
 			},
+			cli.StringFlag{
+				Name:  "profile",
+				Usage: "If the CA offers multiple certificate profiles (ex: \"shortlived\"), request the certificate with this profile.",
+			},
+			cli.BoolFlag{
+				Name:  "ari-enable",
+				Usage: "Use the renewal information (ARI) of the CA to decide when to renew the certificate, in addition to the number of days left. The CA is also notified of the replaced certificate.",
+			},
+			cli.DurationFlag{
+				Name:  "ari-wait-to-renew-duration",
+				Usage: "The maximum duration to wait for the renewal time suggested by the CA (ARI), before renewing the certificate.",
+			},
 		},
 	}
 }
@@ -103,7 +117,20 @@ func renewForDomains(ctx *cli.Context, client *lego.Client, certsStorage *Certif
 
 	cert := certificates[0]
 
-	if !needRenewal(cert, domain, ctx.Int("days")) {
+	var ariRenewalTime *time.Time
+	if ctx.Bool("ari-enable") {
+		ariRenewalTime = getARIRenewalTime(ctx, cert, domain, client)
+		if ariRenewalTime != nil {
+			now := time.Now().UTC()
+			// Figure out if we need to sleep before renewing.
+			if ariRenewalTime.After(now) {
+				log.Infof("[%s] Sleeping %s until renewal time %s", domain, ariRenewalTime.Sub(now), ariRenewalTime)
+				time.Sleep(ariRenewalTime.Sub(now))
+			}
+		}
+	}
+
+	if ariRenewalTime == nil && !needRenewal(cert, domain, ctx.Int("days")) {
 		return nil
 	}
 
@@ -132,7 +159,13 @@ func renewForDomains(ctx *cli.Context, client *lego.Client, certsStorage *Certif
 		PrivateKey:     privateKey,
 		MustStaple:     ctx.Bool("must-staple"),
 		PreferredChain: ctx.String("preferred-chain"),
+		Profile:        ctx.String("profile"),
+	}
+
+	if ctx.Bool("ari-enable") {
+		request.ReplacesCertID = getARICertID(cert, domain)
 	}
+
 	certRes, err := client.Certificate.Obtain(request)
 	if err != nil {
 		log.Fatal(err)
@@ -165,7 +198,20 @@ func renewForCSR(ctx *cli.Context, client *lego.Client, certsStorage *Certificat
 
 	cert := certificates[0]
 
-	if !needRenewal(cert, domain, ctx.Int("days")) {
+	var ariRenewalTime *time.Time
+	if ctx.Bool("ari-enable") {
+		ariRenewalTime = getARIRenewalTime(ctx, cert, domain, client)
+		if ariRenewalTime != nil {
+			now := time.Now().UTC()
+			// Figure out if we need to sleep before renewing.
+			if ariRenewalTime.After(now) {
+				log.Infof("[%s] Sleeping %s until renewal time %s", domain, ariRenewalTime.Sub(now), ariRenewalTime)
+				time.Sleep(ariRenewalTime.Sub(now))
+			}
+		}
+	}
+
+	if ariRenewalTime == nil && !needRenewal(cert, domain, ctx.Int("days")) {
 		return nil
 	}
 
@@ -173,11 +219,18 @@ func renewForCSR(ctx *cli.Context, client *lego.Client, certsStorage *Certificat
 	timeLeft := cert.NotAfter.Sub(time.Now().UTC())
 	log.Infof("[%s] acme: Trying renewal with %d hours remaining", domain, int(timeLeft.Hours()))
 
-	certRes, err := client.Certificate.ObtainForCSR(certificate.ObtainForCSRRequest{
+	request := certificate.ObtainForCSRRequest{
 		CSR:            csr,
 		Bundle:         bundle,
 		PreferredChain: ctx.String("preferred-chain"),
-	})
+		Profile:        ctx.String("profile"),
+	}
+
+	if ctx.Bool("ari-enable") {
+		request.ReplacesCertID = getARICertID(cert, domain)
+	}
+
+	certRes, err := client.Certificate.ObtainForCSR(request)
 	if err != nil {
 		log.Fatal(err)
 	}
@@ -208,6 +261,50 @@ func needRenewal(x509Cert *x509.Certificate, domain string, days int) bool {
 	return true
 }
 
+// getARIRenewalTime checks if the certificate needs to be renewed using the renewalInfo endpoint (ARI).
+// It returns nil if the certificate does not need to be renewed yet, or if the renewal information cannot be fetched.
+func getARIRenewalTime(ctx *cli.Context, cert *x509.Certificate, domain string, client *lego.Client) *time.Time {
+	if cert.IsCA {
+		log.Fatalf("[%s] Certificate bundle starts with a CA certificate", domain)
+	}
+
+	renewalInfo, err := client.Certificate.GetRenewalInfo(certificate.RenewalInfoRequest{Cert: cert})
+	if err != nil {
+		if errors.Is(err, api.ErrNoARI) {
+			// The server does not advertise a renewal info endpoint.
+			log.Warnf("[%s] acme: %v", domain, err)
+			return nil
+		}
+		log.Warnf("[%s] acme: calling renewal info endpoint: %v", domain, err)
+		return nil
+	}
+
+	now := time.Now().UTC()
+	renewalTime := renewalInfo.ShouldRenewAt(now, ctx.Duration("ari-wait-to-renew-duration"))
+	if renewalTime == nil {
+		log.Infof("[%s] acme: renewalInfo endpoint indicates that renewal is not needed", domain)
+		return nil
+	}
+	log.Infof("[%s] acme: renewalInfo endpoint indicates that renewal is needed", domain)
+
+	if renewalInfo.ExplanationURL != "" {
+		log.Infof("[%s] acme: renewalInfo endpoint provided an explanation: %s", domain, renewalInfo.ExplanationURL)
+	}
+
+	return renewalTime
+}
+
+// getARICertID returns the ARI certificate identifier of the replaced certificate, or an empty string if it cannot be built.
+func getARICertID(cert *x509.Certificate, domain string) string {
+	certID, err := certificate.MakeARICertID(cert)
+	if err != nil {
+		log.Warnf("[%s] acme: the ARI certificate ID of the replaced certificate cannot be built: %v", domain, err)
+		return ""
+	}
+
+	return certID
+}
+
 func merge(prevDomains, nextDomains []string) []string {
 	for _, next := range nextDomains {
 		var found bool
@@ -1,6 +1,8 @@
 package cmd
 
 import (
+	"github.com/go-acme/lego/v4/certcrypto"
+	"github.com/go-acme/lego/v4/lego"
 	"github.com/go-acme/lego/v4/log"
 	"github.com/urfave/cli"
 )
@@ -15,6 +17,16 @@ func createRevoke() cli.Command {
 				Name:  "keep, k",
 				Usage: "Keep the certificates after the revocation instead of archiving them.",
 			},
+			cli.UintFlag{
+				Name: "reason",
+				Usage: "Identifies the reason for the certificate revocation. See https://tools.ietf.org/html/rfc5280#section-5.3.1." +
+					" Valid values are: 0 (unspecified), 1 (keyCompromise), 2 (cACompromise), 3 (affiliationChanged), 4 (superseded)," +
+					" 5 (cessationOfOperation), 6 (certificateHold), 8 (removeFromCRL), 9 (privilegeWithdrawn), or 10 (aACompromise).",
+			},
+			cli.BoolFlag{
+				Name:  "cert-key",
+				Usage: "Sign the revocation with the private key of the certificate instead of the account key (ex: the account is not available).",
+			},
 		},
 	}
 }
@@ -22,13 +34,19 @@ func createRevoke() cli.Command {
 func revoke(ctx *cli.Context) error {
 	acc, client := setup(ctx, NewAccountsStorage(ctx))
 
-	if acc.Registration == nil {
+	if acc.Registration == nil && !ctx.Bool("cert-key") {
 		log.Fatalf("Account %s is not registered. Use 'run' to register a new account.\n", acc.Email)
 	}
 
 	certsStorage := NewCertificatesStorage(ctx)
 	certsStorage.CreateRootFolder()
 
+	var reason *uint
+	if ctx.IsSet("reason") {
+		value := ctx.Uint("reason")
+		reason = &value
+	}
+
 	for _, domain := range ctx.GlobalStringSlice("domains") {
 		log.Printf("Trying to revoke certificate for domain %s", domain)
 
@@ -37,7 +55,11 @@ func revoke(ctx *cli.Context) error {
 			log.Fatalf("Error while revoking the certificate for domain %s\n\t%v", domain, err)
 		}
 
-		err = client.Certificate.Revoke(certBytes)
+		if ctx.Bool("cert-key") {
+			err = revokeWithCertificateKey(client, certsStorage, domain, certBytes, reason)
+		} else {
+			err = client.Certificate.RevokeWithReason(certBytes, reason)
+		}
 		if err != nil {
 			log.Fatalf("Error while revoking the certificate for domain %s\n\t%v", domain, err)
 		}
@@ -60,3 +82,17 @@ func revoke(ctx *cli.Context) error {
 
 	return nil
 }
+
+func revokeWithCertificateKey(client *lego.Client, certsStorage *CertificatesStorage, domain string, certBytes []byte, reason *uint) error {
+	keyBytes, err := certsStorage.ReadFile(domain, ".key")
+	if err != nil {
+		return err
+	}
+
+	privateKey, err := certcrypto.ParsePEMPrivateKey(keyBytes)
+	if err != nil {
+		return err
+	}
+
+	return client.Certificate.RevokeWithCertificateKey(certBytes, privateKey, reason)
+}
@@ -47,6 +47,10 @@ func createRun() cli.Command {
 				Name:  "preferred-chain",
 				Usage: "If the CA offers multiple certificate chains, prefer the chain with an issuer matching this Subject Common Name. If no match, the default offered chain will be used.",
 			},
+			cli.StringFlag{
+				Name:  "profile",
+				Usage: "If the CA offers multiple certificate profiles (ex: \"shortlived\"), request the certificate with this profile.",
+			},
 		},
 	}
 }
@@ -141,15 +145,18 @@ func register(ctx *cli.Context, client *lego.Client) (*registration.Resource, er
 	if ctx.GlobalBool("eab") {
 		kid := ctx.GlobalString("kid")
 		hmacEncoded := ctx.GlobalString("hmac")
+		hmacFile := ctx.GlobalString("hmac-file")
 
-		if kid == "" || hmacEncoded == "" {
-			log.Fatalf("Requires arguments --kid and --hmac.")
+		if kid == "" || (hmacEncoded == "" && hmacFile == "") {
+			log.Fatalf("Requires arguments --kid and --hmac (or --hmac-file).")
 		}
 
 		return client.Registration.RegisterWithExternalAccountBinding(registration.RegisterEABOptions{
 			TermsOfServiceAgreed: accepted,
 			Kid:                  kid,
 			HmacEncoded:          hmacEncoded,
+			HmacFile:             hmacFile,
+			Algorithm:            ctx.GlobalString("eab-algorithm"),
 		})
 	}
 
@@ -167,6 +174,7 @@ func obtainCertificate(ctx *cli.Context, client *lego.Client) (*certificate.Reso
 			Bundle:         bundle,
 			MustStaple:     ctx.Bool("must-staple"),
 			PreferredChain: ctx.String("preferred-chain"),
+			Profile:        ctx.String("profile"),
 		}
 		return client.Certificate.Obtain(request)
 	}
@@ -182,5 +190,6 @@ func obtainCertificate(ctx *cli.Context, client *lego.Client) (*certificate.Reso
 		CSR:            csr,
 		Bundle:         bundle,
 		PreferredChain: ctx.String("preferred-chain"),
+		Profile:        ctx.String("profile"),
 	})
 }
@@ -1,6 +1,7 @@
 package cmd
 
 import (
+	"github.com/go-acme/lego/v4/challenge/dns01"
 	"github.com/go-acme/lego/v4/lego"
 	"github.com/urfave/cli"
 )
@@ -30,7 +31,7 @@ func CreateFlags(defaultPath string) []cli.Flag {
 		},
 		cli.BoolFlag{
 			Name:  "eab",
-			Usage: "Use External Account Binding for account registration. Requires --kid and --hmac.",
+			Usage: "Use External Account Binding for account registration. Requires --kid and --hmac (or --hmac-file).",
 		},
 		cli.StringFlag{
 			Name:  "kid",
@@ -40,6 +41,15 @@ func CreateFlags(defaultPath string) []cli.Flag {
 			Name:  "hmac",
 			Usage: "MAC key from External CA. Should be in Base64 URL Encoding without padding format. Used for External Account Binding.",
 		},
+		cli.StringFlag{
+			Name:  "hmac-file",
+			Usage: "File containing the MAC key from External CA (alternative to --hmac). Used for External Account Binding.",
+		},
+		cli.StringFlag{
+			Name:  "eab-algorithm",
+			Value: "HS256",
+			Usage: "MAC algorithm used for External Account Binding. Supported: HS256, HS384, HS512.",
+		},
 		cli.StringFlag{
 			Name:  "key-type, k",
 			Value: "ec256",
@@ -94,9 +104,51 @@ func CreateFlags(defaultPath string) []cli.Flag {
 			Name:  "dns.disable-cp",
 			Usage: "By setting this flag to true, disables the need to wait the propagation of the TXT record to all authoritative name servers.",
 		},
+		cli.BoolFlag{
+			Name:  "dns.cross-check-zones",
+			Usage: "By setting this flag to true, the zone found through DNS is compared with the zones managed by the DNS provider, and a warning is displayed when they disagree.",
+		},
+		cli.BoolFlag{
+			Name:  "dns.probe-negative-ttl",
+			Usage: "By setting this flag to true, the negative cache TTL of the zone is displayed before the propagation check (a NXDOMAIN cached by a resolver hides the TXT record until this TTL expires).",
+		},
+		cli.BoolFlag{
+			Name:  "dns.verify-stored",
+			Usage: "By setting this flag to true, the TXT record is read back through the API of the DNS provider after its creation, and the challenge fails if the stored value differs from the expected value. Only for the DNS providers supporting it.",
+		},
+		cli.BoolFlag{
+			Name:  "dns.parallel-check",
+			Usage: "By setting this flag to true, the nameservers are queried concurrently during each propagation check, instead of one after the other.",
+		},
 		cli.StringSliceFlag{
 			Name:  "dns.resolvers",
-			Usage: "Set the resolvers to use for performing recursive DNS queries. Supported: host:port. The default is to use the system resolvers, or Google's DNS resolvers if the system's cannot be determined.",
+			Usage: "Set the resolvers to use for performing recursive DNS queries. Supported: host:port, udp://host:port, tcp://host:port, https://host/path (DNS over HTTPS). The default is to use the system resolvers, or Google's DNS resolvers if the system's cannot be determined.",
+		},
+		cli.StringSliceFlag{
+			Name:  "dns.ca-resolvers",
+			Usage: "Set recursive resolvers mirroring the resolvers used by the CA for the validation. After the propagation check, all of them must return the TXT record. Supported: same formats as --dns.resolvers.",
+		},
+		cli.StringFlag{
+			Name:  "dns.propagation",
+			Usage: "Set the nameservers which must return the TXT record for the propagation check. Supported: authoritative (the authoritative nameservers), both (the authoritative and the recursive nameservers), delegation (the nameservers delegated by the parent zone).",
+			Value: string(dns01.PropagationAuthoritative),
+		},
+		cli.IntFlag{
+			Name:  "dns.txt-answers",
+			Usage: "Set the number of values the TXT record must contain on all the authoritative nameservers before the end of the propagation check (ex: 2 for a domain and its wildcard).",
+		},
+		cli.StringFlag{
+			Name:  "dns.view",
+			Usage: "Set the view (split-horizon DNS) where the TXT records are created. Only for the DNS providers supporting the views.",
+		},
+		cli.IntFlag{
+			Name:  "dns.soa-max-depth",
+			Usage: "Set the maximum number of labels walked (one DNS query per label) to find the zone of a domain.",
+			Value: dns01.DefaultSOAMaxDepth,
+		},
+		cli.IntFlag{
+			Name:  "dns.soa-retries",
+			Usage: "Set the number of retries (with backoff) of the DNS queries used to find the zone of a domain, after a transient failure (network error or SERVFAIL).",
 		},
 		cli.IntFlag{
 			Name:  "http-timeout",
@@ -54,7 +54,7 @@ func newClient(ctx *cli.Context, acc registration.User, keyType certcrypto.KeyTy
 	}
 
 	if client.GetExternalAccountRequired() && !ctx.GlobalIsSet("eab") {
-		log.Fatal("Server requires External Account Binding. Use --eab with --kid and --hmac.")
+		log.Fatal("Server requires External Account Binding. Use --eab with --kid and --hmac (or --hmac-file).")
 	}
 
 	return client
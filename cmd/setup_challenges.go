@@ -2,6 +2,7 @@ package cmd
 
 import (
 	"net"
+	"os"
 	"strings"
 	"time"
 
@@ -17,6 +18,9 @@ import (
 	"github.com/urfave/cli"
 )
 
+// envRecordSuffix the suffix appended to the name of the challenge records (ex: a delegation zone).
+const envRecordSuffix = "LEGO_DNS_RECORD_SUFFIX"
+
 func setupChallenges(ctx *cli.Context, client *lego.Client) {
 	if !ctx.GlobalBool("http") && !ctx.GlobalBool("tls") && !ctx.GlobalIsSet("dns") {
 		log.Fatal("No challenge selected. You must specify at least one challenge: `--http`, `--tls`, `--dns`.")
@@ -115,8 +119,30 @@ func setupDNS(ctx *cli.Context, client *lego.Client) {
 	err = client.Challenge.SetDNS01Provider(provider,
 		dns01.CondOption(len(servers) > 0,
 			dns01.AddRecursiveNameservers(dns01.ParseNameservers(ctx.GlobalStringSlice("dns.resolvers")))),
+		dns01.CondOption(ctx.GlobalIsSet("dns.ca-resolvers"),
+			dns01.AddValidationResolvers(ctx.GlobalStringSlice("dns.ca-resolvers"))),
+		dns01.CondOption(ctx.GlobalIsSet("dns.propagation"),
+			dns01.SetPropagationMode(dns01.PropagationMode(ctx.GlobalString("dns.propagation")))),
+		dns01.CondOption(ctx.GlobalIsSet("dns.txt-answers"),
+			dns01.ExpectTXTAnswerCount(ctx.GlobalInt("dns.txt-answers"))),
 		dns01.CondOption(ctx.GlobalBool("dns.disable-cp"),
 			dns01.DisableCompletePropagationRequirement()),
+		dns01.CondOption(ctx.GlobalBool("dns.cross-check-zones"),
+			dns01.CrossCheckZones()),
+		dns01.CondOption(ctx.GlobalBool("dns.probe-negative-ttl"),
+			dns01.ProbeNegativeTTL()),
+		dns01.CondOption(ctx.GlobalBool("dns.verify-stored"),
+			dns01.VerifyStoredRecord()),
+		dns01.CondOption(ctx.GlobalBool("dns.parallel-check"),
+			dns01.ParallelPropagationCheck()),
+		dns01.CondOption(ctx.GlobalIsSet("dns.soa-max-depth"),
+			dns01.AddSOAMaxDepth(ctx.GlobalInt("dns.soa-max-depth"))),
+		dns01.CondOption(ctx.GlobalIsSet("dns.soa-retries"),
+			dns01.AddSOARetries(ctx.GlobalInt("dns.soa-retries"))),
+		dns01.CondOption(ctx.GlobalIsSet("dns.view"),
+			dns01.AddDNSView(ctx.GlobalString("dns.view"))),
+		dns01.CondOption(os.Getenv(envRecordSuffix) != "",
+			dns01.AddRecordSuffix(os.Getenv(envRecordSuffix))),
 		dns01.CondOption(ctx.GlobalIsSet("dns-timeout"),
 			dns01.AddDNSTimeout(time.Duration(ctx.GlobalInt("dns-timeout"))*time.Second)),
 	)
@@ -23,6 +23,7 @@ func allDNSCodes() string {
 		"bindman",
 		"bluecat",
 		"checkdomain",
+		"civo",
 		"clouddns",
 		"cloudflare",
 		"cloudns",
@@ -50,15 +51,19 @@ func allDNSCodes() string {
 		"glesys",
 		"godaddy",
 		"hetzner",
+		"hexonet",
 		"hostingde",
 		"httpreq",
 		"hyperone",
 		"iij",
+		"infomaniak",
 		"inwx",
+		"ionos",
 		"joker",
 		"lightsail",
 		"linode",
 		"liquidweb",
+		"loopia",
 		"luadns",
 		"mydnsjp",
 		"mythicbeasts",
@@ -73,6 +78,7 @@ func allDNSCodes() string {
 		"otc",
 		"ovh",
 		"pdns",
+		"porkbun",
 		"rackspace",
 		"regru",
 		"rfc2136",
@@ -82,6 +88,7 @@ func allDNSCodes() string {
 		"scaleway",
 		"selectel",
 		"servercow",
+		"simply",
 		"stackpath",
 		"transip",
 		"vegadns",
@@ -90,6 +97,7 @@ func allDNSCodes() string {
 		"vultr",
 		"yandex",
 		"zoneee",
+		"zonefile",
 		"zonomi",
 	}
 	sort.Strings(providers)
@@ -223,6 +231,7 @@ func displayDNSHelp(name string) error {
 		ew.writeln(`	- "AZURE_METADATA_ENDPOINT":	Metadata Service endpoint URL`)
 		ew.writeln(`	- "AZURE_POLLING_INTERVAL":	Time between DNS propagation check`)
 		ew.writeln(`	- "AZURE_PROPAGATION_TIMEOUT":	Maximum waiting time for DNS propagation`)
+		ew.writeln(`	- "AZURE_RECORD_METADATA":	Metadata set on the created record sets (ex: 'key1:value1,key2:value2')`)
 		ew.writeln(`	- "AZURE_TTL":	The TTL of the TXT record used for the DNS challenge`)
 
 		ew.writeln()
@@ -292,6 +301,26 @@ func displayDNSHelp(name string) error {
 		ew.writeln()
 		ew.writeln(`More information: https://go-acme.github.io/lego/dns/checkdomain`)
 
+	case "civo":
+		// generated from: providers/dns/civo/civo.toml
+		ew.writeln(`Configuration for Civo.`)
+		ew.writeln(`Code:	'civo'`)
+		ew.writeln(`Since:	'v4.1.0'`)
+		ew.writeln()
+
+		ew.writeln(`Credentials:`)
+		ew.writeln(`	- "CIVO_TOKEN":	Authentication token`)
+		ew.writeln()
+
+		ew.writeln(`Additional Configuration:`)
+		ew.writeln(`	- "CIVO_HTTP_TIMEOUT":	API request timeout`)
+		ew.writeln(`	- "CIVO_POLLING_INTERVAL":	Time between DNS propagation check`)
+		ew.writeln(`	- "CIVO_PROPAGATION_TIMEOUT":	Maximum waiting time for DNS propagation`)
+		ew.writeln(`	- "CIVO_TTL":	The TTL of the TXT record used for the DNS challenge (minimum: 600)`)
+
+		ew.writeln()
+		ew.writeln(`More information: https://go-acme.github.io/lego/dns/civo`)
+
 	case "clouddns":
 		// generated from: providers/dns/clouddns/clouddns.toml
 		ew.writeln(`Configuration for CloudDNS.`)
@@ -550,7 +579,7 @@ func displayDNSHelp(name string) error {
 		ew.writeln(`	- "DNSPOD_HTTP_TIMEOUT":	API request timeout`)
 		ew.writeln(`	- "DNSPOD_POLLING_INTERVAL":	Time between DNS propagation check`)
 		ew.writeln(`	- "DNSPOD_PROPAGATION_TIMEOUT":	Maximum waiting time for DNS propagation`)
-		ew.writeln(`	- "DNSPOD_TTL":	The TTL of the TXT record used for the DNS challenge`)
+		ew.writeln(`	- "DNSPOD_TTL":	The TTL of the TXT record used for the DNS challenge (minimum: 600)`)
 
 		ew.writeln()
 		ew.writeln(`More information: https://go-acme.github.io/lego/dns/dnspod`)
@@ -785,7 +814,7 @@ func displayDNSHelp(name string) error {
 
 		ew.writeln(`Credentials:`)
 		ew.writeln(`	- "Application Default Credentials":	[Documentation](https://cloud.google.com/docs/authentication/production#providing_credentials_to_your_application)`)
-		ew.writeln(`	- "GCE_PROJECT":	Project name (by default, the project name is auto-detected by using the metadata service)`)
+		ew.writeln(`	- "GCE_PROJECT":	Project name of the DNS zones (by default, the project of the credentials or the project auto-detected by using the metadata service)`)
 		ew.writeln(`	- "GCE_SERVICE_ACCOUNT":	Account`)
 		ew.writeln(`	- "GCE_SERVICE_ACCOUNT_FILE":	Account file path`)
 		ew.writeln()
@@ -795,6 +824,7 @@ func displayDNSHelp(name string) error {
 		ew.writeln(`	- "GCE_POLLING_INTERVAL":	Time between DNS propagation check`)
 		ew.writeln(`	- "GCE_PROPAGATION_TIMEOUT":	Maximum waiting time for DNS propagation`)
 		ew.writeln(`	- "GCE_TTL":	The TTL of the TXT record used for the DNS challenge`)
+		ew.writeln(`	- "GCE_ZONE_PROJECTS":	Projects of the zones in another project than GCE_PROJECT, ex: shared VPC (format: zone1:project1,zone2:project2)`)
 
 		ew.writeln()
 		ew.writeln(`More information: https://go-acme.github.io/lego/dns/gcloud`)
@@ -863,6 +893,29 @@ func displayDNSHelp(name string) error {
 		ew.writeln()
 		ew.writeln(`More information: https://go-acme.github.io/lego/dns/hetzner`)
 
+	case "hexonet":
+		// generated from: providers/dns/hexonet/hexonet.toml
+		ew.writeln(`Configuration for Hexonet.`)
+		ew.writeln(`Code:	'hexonet'`)
+		ew.writeln(`Since:	'v4.1.0'`)
+		ew.writeln()
+
+		ew.writeln(`Credentials:`)
+		ew.writeln(`	- "HEXONET_PASSWORD":	Account password`)
+		ew.writeln(`	- "HEXONET_USERNAME":	Account username`)
+		ew.writeln()
+
+		ew.writeln(`Additional Configuration:`)
+		ew.writeln(`	- "HEXONET_API_URL":	API endpoint (default: https://api.ispapi.net/api/call.cgi)`)
+		ew.writeln(`	- "HEXONET_ENTITY":	API system: 54cd (live system, default) or 1234 (OT&E test system)`)
+		ew.writeln(`	- "HEXONET_HTTP_TIMEOUT":	API request timeout`)
+		ew.writeln(`	- "HEXONET_POLLING_INTERVAL":	Time between DNS propagation check`)
+		ew.writeln(`	- "HEXONET_PROPAGATION_TIMEOUT":	Maximum waiting time for DNS propagation`)
+		ew.writeln(`	- "HEXONET_TTL":	The TTL of the TXT record used for the DNS challenge`)
+
+		ew.writeln()
+		ew.writeln(`More information: https://go-acme.github.io/lego/dns/hexonet`)
+
 	case "hostingde":
 		// generated from: providers/dns/hostingde/hostingde.toml
 		ew.writeln(`Configuration for Hosting.de.`)
@@ -945,6 +998,27 @@ func displayDNSHelp(name string) error {
 		ew.writeln()
 		ew.writeln(`More information: https://go-acme.github.io/lego/dns/iij`)
 
+	case "infomaniak":
+		// generated from: providers/dns/infomaniak/infomaniak.toml
+		ew.writeln(`Configuration for Infomaniak.`)
+		ew.writeln(`Code:	'infomaniak'`)
+		ew.writeln(`Since:	'v4.1.0'`)
+		ew.writeln()
+
+		ew.writeln(`Credentials:`)
+		ew.writeln(`	- "INFOMANIAK_ACCESS_TOKEN":	Access token`)
+		ew.writeln()
+
+		ew.writeln(`Additional Configuration:`)
+		ew.writeln(`	- "INFOMANIAK_ENDPOINT":	API endpoint (default: https://api.infomaniak.com)`)
+		ew.writeln(`	- "INFOMANIAK_HTTP_TIMEOUT":	API request timeout`)
+		ew.writeln(`	- "INFOMANIAK_POLLING_INTERVAL":	Time between DNS propagation check`)
+		ew.writeln(`	- "INFOMANIAK_PROPAGATION_TIMEOUT":	Maximum waiting time for DNS propagation`)
+		ew.writeln(`	- "INFOMANIAK_TTL":	The TTL of the TXT record used for the DNS challenge (minimum: 300)`)
+
+		ew.writeln()
+		ew.writeln(`More information: https://go-acme.github.io/lego/dns/infomaniak`)
+
 	case "inwx":
 		// generated from: providers/dns/inwx/inwx.toml
 		ew.writeln(`Configuration for INWX.`)
@@ -967,6 +1041,26 @@ func displayDNSHelp(name string) error {
 		ew.writeln()
 		ew.writeln(`More information: https://go-acme.github.io/lego/dns/inwx`)
 
+	case "ionos":
+		// generated from: providers/dns/ionos/ionos.toml
+		ew.writeln(`Configuration for IONOS.`)
+		ew.writeln(`Code:	'ionos'`)
+		ew.writeln(`Since:	'v4.1.0'`)
+		ew.writeln()
+
+		ew.writeln(`Credentials:`)
+		ew.writeln(`	- "IONOS_API_KEY":	API key '<prefix>.<secret>' https://developer.hosting.ionos.com/docs/getstarted`)
+		ew.writeln()
+
+		ew.writeln(`Additional Configuration:`)
+		ew.writeln(`	- "IONOS_HTTP_TIMEOUT":	API request timeout`)
+		ew.writeln(`	- "IONOS_POLLING_INTERVAL":	Time between DNS propagation check`)
+		ew.writeln(`	- "IONOS_PROPAGATION_TIMEOUT":	Maximum waiting time for DNS propagation`)
+		ew.writeln(`	- "IONOS_TTL":	The TTL of the TXT record used for the DNS challenge (minimum: 300)`)
+
+		ew.writeln()
+		ew.writeln(`More information: https://go-acme.github.io/lego/dns/ionos`)
+
 	case "joker":
 		// generated from: providers/dns/joker/joker.toml
 		ew.writeln(`Configuration for Joker.`)
@@ -1052,6 +1146,28 @@ func displayDNSHelp(name string) error {
 		ew.writeln()
 		ew.writeln(`More information: https://go-acme.github.io/lego/dns/liquidweb`)
 
+	case "loopia":
+		// generated from: providers/dns/loopia/loopia.toml
+		ew.writeln(`Configuration for Loopia.`)
+		ew.writeln(`Code:	'loopia'`)
+		ew.writeln(`Since:	'v4.1.0'`)
+		ew.writeln()
+
+		ew.writeln(`Credentials:`)
+		ew.writeln(`	- "LOOPIA_API_PASSWORD":	API password`)
+		ew.writeln(`	- "LOOPIA_API_USER":	API username`)
+		ew.writeln()
+
+		ew.writeln(`Additional Configuration:`)
+		ew.writeln(`	- "LOOPIA_API_URL":	API endpoint (default: https://api.loopia.se/RPCSERV)`)
+		ew.writeln(`	- "LOOPIA_HTTP_TIMEOUT":	API request timeout`)
+		ew.writeln(`	- "LOOPIA_POLLING_INTERVAL":	Time between DNS propagation check`)
+		ew.writeln(`	- "LOOPIA_PROPAGATION_TIMEOUT":	Maximum waiting time for DNS propagation`)
+		ew.writeln(`	- "LOOPIA_TTL":	The TTL of the TXT record used for the DNS challenge (minimum: 300)`)
+
+		ew.writeln()
+		ew.writeln(`More information: https://go-acme.github.io/lego/dns/loopia`)
+
 	case "luadns":
 		// generated from: providers/dns/luadns/luadns.toml
 		ew.writeln(`Configuration for LuaDNS.`)
@@ -1109,11 +1225,11 @@ func displayDNSHelp(name string) error {
 
 		ew.writeln(`Additional Configuration:`)
 		ew.writeln(`	- "MYTHICBEASTS_API_ENDPOINT":	The endpoint for the API (must implement v2)`)
+		ew.writeln(`	- "MYTHICBEASTS_AUTH_API_ENDPOINT":	The endpoint for Mythic Beasts' Authentication`)
 		ew.writeln(`	- "MYTHICBEASTS_HTTP_TIMEOUT":	API request timeout`)
 		ew.writeln(`	- "MYTHICBEASTS_POLLING_INTERVAL":	Time between DNS propagation check`)
 		ew.writeln(`	- "MYTHICBEASTS_PROPAGATION_TIMEOUT":	Maximum waiting time for DNS propagation`)
 		ew.writeln(`	- "MYTHICBEASTS_TTL":	The TTL of the TXT record used for the DNS challenge`)
-		ew.writeln(`	- "MYTHICBEASYS_AUTH_API_ENDPOINT":	The endpoint for Mythic Beasts' Authentication`)
 
 		ew.writeln()
 		ew.writeln(`More information: https://go-acme.github.io/lego/dns/mythicbeasts`)
@@ -1175,6 +1291,7 @@ func displayDNSHelp(name string) error {
 		ew.writeln(`Additional Configuration:`)
 		ew.writeln(`	- "NAMESILO_POLLING_INTERVAL":	Time between DNS propagation check`)
 		ew.writeln(`	- "NAMESILO_PROPAGATION_TIMEOUT":	Maximum waiting time for DNS propagation, it is better to set larger than 15m`)
+		ew.writeln(`	- "NAMESILO_SANDBOX":	Activate the sandbox (boolean)`)
 		ew.writeln(`	- "NAMESILO_TTL":	The TTL of the TXT record used for the DNS challenge, should be in [3600, 2592000]`)
 
 		ew.writeln()
@@ -1235,9 +1352,11 @@ func displayDNSHelp(name string) error {
 		ew.writeln()
 
 		ew.writeln(`Additional Configuration:`)
+		ew.writeln(`	- "NIFCLOUD_DNS_ENDPOINT":	The endpoint of the DNS API, takes precedence over 'NIFCLOUD_REGION'`)
 		ew.writeln(`	- "NIFCLOUD_HTTP_TIMEOUT":	API request timeout`)
 		ew.writeln(`	- "NIFCLOUD_POLLING_INTERVAL":	Time between DNS propagation check`)
 		ew.writeln(`	- "NIFCLOUD_PROPAGATION_TIMEOUT":	Maximum waiting time for DNS propagation`)
+		ew.writeln(`	- "NIFCLOUD_REGION":	The region of the DNS API endpoint (ex: 'jp-west-1'), the default endpoint is used if not set`)
 		ew.writeln(`	- "NIFCLOUD_TTL":	The TTL of the TXT record used for the DNS challenge`)
 
 		ew.writeln()
@@ -1323,7 +1442,7 @@ func displayDNSHelp(name string) error {
 		ew.writeln(`	- "OVH_APPLICATION_KEY":	Application key`)
 		ew.writeln(`	- "OVH_APPLICATION_SECRET":	Application secret`)
 		ew.writeln(`	- "OVH_CONSUMER_KEY":	Consumer key`)
-		ew.writeln(`	- "OVH_ENDPOINT":	Endpoint URL (ovh-eu or ovh-ca)`)
+		ew.writeln(`	- "OVH_ENDPOINT":	Endpoint URL (ovh-eu, ovh-ca or ovh-us)`)
 		ew.writeln()
 
 		ew.writeln(`Additional Configuration:`)
@@ -1356,6 +1475,27 @@ func displayDNSHelp(name string) error {
 		ew.writeln()
 		ew.writeln(`More information: https://go-acme.github.io/lego/dns/pdns`)
 
+	case "porkbun":
+		// generated from: providers/dns/porkbun/porkbun.toml
+		ew.writeln(`Configuration for Porkbun.`)
+		ew.writeln(`Code:	'porkbun'`)
+		ew.writeln(`Since:	'v4.1.0'`)
+		ew.writeln()
+
+		ew.writeln(`Credentials:`)
+		ew.writeln(`	- "PORKBUN_API_KEY":	API key`)
+		ew.writeln(`	- "PORKBUN_SECRET_API_KEY":	secret API key`)
+		ew.writeln()
+
+		ew.writeln(`Additional Configuration:`)
+		ew.writeln(`	- "PORKBUN_HTTP_TIMEOUT":	API request timeout`)
+		ew.writeln(`	- "PORKBUN_POLLING_INTERVAL":	Time between DNS propagation check`)
+		ew.writeln(`	- "PORKBUN_PROPAGATION_TIMEOUT":	Maximum waiting time for DNS propagation`)
+		ew.writeln(`	- "PORKBUN_TTL":	The TTL of the TXT record used for the DNS challenge`)
+
+		ew.writeln()
+		ew.writeln(`More information: https://go-acme.github.io/lego/dns/porkbun`)
+
 	case "rackspace":
 		// generated from: providers/dns/rackspace/rackspace.toml
 		ew.writeln(`Configuration for Rackspace.`)
@@ -1459,6 +1599,7 @@ func displayDNSHelp(name string) error {
 		ew.writeln()
 
 		ew.writeln(`Additional Configuration:`)
+		ew.writeln(`	- "AWS_CHANGE_COMMENT":	The comment of the change batches, for the audit trails. The placeholders '{action}' ('present' or 'cleanup') and '{domain}' are replaced (Default: 'Managed by Lego: {action} {domain}')`)
 		ew.writeln(`	- "AWS_MAX_RETRIES":	The number of maximum returns the service will use to make an individual API request`)
 		ew.writeln(`	- "AWS_POLLING_INTERVAL":	Time between DNS propagation check`)
 		ew.writeln(`	- "AWS_PROPAGATION_TIMEOUT":	Maximum waiting time for DNS propagation`)
@@ -1552,6 +1693,28 @@ func displayDNSHelp(name string) error {
 		ew.writeln()
 		ew.writeln(`More information: https://go-acme.github.io/lego/dns/servercow`)
 
+	case "simply":
+		// generated from: providers/dns/simply/simply.toml
+		ew.writeln(`Configuration for Simply.com.`)
+		ew.writeln(`Code:	'simply'`)
+		ew.writeln(`Since:	'v4.1.0'`)
+		ew.writeln()
+
+		ew.writeln(`Credentials:`)
+		ew.writeln(`	- "SIMPLY_ACCOUNT_NAME":	Account name`)
+		ew.writeln(`	- "SIMPLY_API_KEY":	API key`)
+		ew.writeln()
+
+		ew.writeln(`Additional Configuration:`)
+		ew.writeln(`	- "SIMPLY_API_URL":	API endpoint (default: https://api.simply.com/2/)`)
+		ew.writeln(`	- "SIMPLY_HTTP_TIMEOUT":	API request timeout`)
+		ew.writeln(`	- "SIMPLY_POLLING_INTERVAL":	Time between DNS propagation check`)
+		ew.writeln(`	- "SIMPLY_PROPAGATION_TIMEOUT":	Maximum waiting time for DNS propagation`)
+		ew.writeln(`	- "SIMPLY_TTL":	The TTL of the TXT record used for the DNS challenge (minimum: 60)`)
+
+		ew.writeln()
+		ew.writeln(`More information: https://go-acme.github.io/lego/dns/simply`)
+
 	case "stackpath":
 		// generated from: providers/dns/stackpath/stackpath.toml
 		ew.writeln(`Configuration for Stackpath.`)
@@ -1721,6 +1884,25 @@ func displayDNSHelp(name string) error {
 		ew.writeln()
 		ew.writeln(`More information: https://go-acme.github.io/lego/dns/zoneee`)
 
+	case "zonefile":
+		// generated from: providers/dns/zonefile/zonefile.toml
+		ew.writeln(`Configuration for Zone file.`)
+		ew.writeln(`Code:	'zonefile'`)
+		ew.writeln(`Since:	'v4.1.0'`)
+		ew.writeln()
+
+		ew.writeln(`Credentials:`)
+		ew.writeln(`	- "ZONEFILE_PATH":	The path of the zone file fragment`)
+		ew.writeln()
+
+		ew.writeln(`Additional Configuration:`)
+		ew.writeln(`	- "ZONEFILE_POLLING_INTERVAL":	Time between DNS propagation check`)
+		ew.writeln(`	- "ZONEFILE_PROPAGATION_TIMEOUT":	Maximum waiting time for DNS propagation`)
+		ew.writeln(`	- "ZONEFILE_TTL":	The TTL of the TXT record used for the DNS challenge`)
+
+		ew.writeln()
+		ew.writeln(`More information: https://go-acme.github.io/lego/dns/zonefile`)
+
 	case "zonomi":
 		// generated from: providers/dns/zonomi/zonomi.toml
 		ew.writeln(`Configuration for Zonomi.`)
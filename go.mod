@@ -23,6 +23,7 @@ require (
 	github.com/gophercloud/gophercloud v0.7.0
 	github.com/gophercloud/utils v0.0.0-20200508015959-b0167b94122c
 	github.com/iij/doapi v0.0.0-20190504054126-0bbf12d6d7df
+	github.com/kolo/xmlrpc v0.0.0-20200310150728-e0350524596b
 	github.com/konsorten/go-windows-terminal-sequences v1.0.2 // indirect
 	github.com/labbsr0x/bindman-dns-webhook v1.0.2
 	github.com/linode/linodego v0.21.0
@@ -37,6 +38,7 @@ require (
 	github.com/oracle/oci-go-sdk v24.2.0+incompatible
 	github.com/ovh/go-ovh v1.1.0
 	github.com/pquerna/otp v1.2.0
+	github.com/prometheus/client_golang v1.1.0
 	github.com/rainycape/memcache v0.0.0-20150622160815-1031fa0ce2f2
 	github.com/sacloud/libsacloud v1.36.2
 	github.com/stretchr/testify v1.6.1
@@ -46,6 +48,7 @@ require (
 	golang.org/x/crypto v0.0.0-20200622213623-75b288015ac9
 	golang.org/x/net v0.0.0-20200822124328-c89045814202
 	golang.org/x/oauth2 v0.0.0-20200107190931-bf48bf16ab8d
+	golang.org/x/time v0.0.0-20200630173020-3af7569d3a1e
 	google.golang.org/api v0.20.0
 	gopkg.in/ns1/ns1-go.v2 v2.4.2
 	gopkg.in/square/go-jose.v2 v2.5.1
@@ -4,6 +4,7 @@ import (
 	"errors"
 	"net/url"
 
+	"github.com/go-acme/lego/v4/acme"
 	"github.com/go-acme/lego/v4/acme/api"
 	"github.com/go-acme/lego/v4/certificate"
 	"github.com/go-acme/lego/v4/challenge/resolver"
@@ -45,15 +46,36 @@ func NewClient(config *Config) (*Client, error) {
 		kid = reg.URI
 	}
 
-	core, err := api.New(config.HTTPClient, config.UserAgent, config.CADirURL, kid, privateKey)
+	httpClient := api.WrapHTTPClient(config.HTTPClient, middlewares(config)...)
+
+	core, err := api.NewWithDirectoryCache(httpClient, config.UserAgent, config.CADirURL, kid, privateKey, config.DirectoryCache)
 	if err != nil {
 		return nil, err
 	}
 
+	core = core.WithRetryOptions(config.Retry).WithMetricsRecorder(config.Metrics)
+	core.SetNoncePool(config.NoncePool)
+
 	solversManager := resolver.NewSolversManager(core)
+	solversManager.SetChallengeCallbacks(resolver.ChallengeCallbacks{
+		OnChallengePresented: config.Callbacks.OnChallengePresented,
+		OnChallengeValidated: config.Callbacks.OnChallengeValidated,
+		OnChallengeFailed:    config.Callbacks.OnChallengeFailed,
+	})
 
 	prober := resolver.NewProber(solversManager)
-	certifier := certificate.NewCertifier(core, prober, certificate.CertifierOptions{KeyType: config.Certificate.KeyType, Timeout: config.Certificate.Timeout})
+	options := certificate.CertifierOptions{
+		KeyType:        config.Certificate.KeyType,
+		Timeout:        config.Certificate.Timeout,
+		PreferredChain: config.Certificate.PreferredChain,
+		BatchWorkers:   config.Certificate.BatchWorkers,
+		ARIReplaces:    config.Certificate.ARIReplaces,
+
+		OnOrderCreated:      config.Callbacks.OnOrderCreated,
+		OnCertificateIssued: config.Callbacks.OnCertificateIssued,
+	}
+
+	certifier := certificate.NewCertifier(core, prober, options)
 
 	return &Client{
 		Certificate:  certifier,
@@ -63,12 +85,50 @@ func NewClient(config *Config) (*Client, error) {
 	}, nil
 }
 
+// GetMeta returns the metadata of the Directory (ToS URL, website, CAA identities, etc.).
+func (c *Client) GetMeta() acme.Meta {
+	return c.core.GetDirectory().Meta
+}
+
 // GetToSURL returns the current ToS URL from the Directory.
 func (c *Client) GetToSURL() string {
 	return c.core.GetDirectory().Meta.TermsOfService
 }
 
+// GetProfiles returns the certificate profiles advertised by the Directory (name and description).
+func (c *Client) GetProfiles() map[string]string {
+	return c.core.GetDirectory().Meta.Profiles
+}
+
+// PreAuthorize creates and validates the authorizations of the domains ahead of the certificate requests,
+// if the CA supports pre-authorization (newAuthz).
+// The challenges of the next certificate requests are skipped for these domains.
+func (c *Client) PreAuthorize(domains ...string) error {
+	return c.Certificate.PreAuthorize(domains)
+}
+
+// DeactivateAuthorization deactivates an authorization (ex: a pending authorization which will not be completed).
+func (c *Client) DeactivateAuthorization(authzURL string) error {
+	return c.Certificate.DeactivateAuthorization(authzURL)
+}
+
 // GetExternalAccountRequired returns the External Account Binding requirement of the Directory.
 func (c *Client) GetExternalAccountRequired() bool {
 	return c.core.GetDirectory().Meta.ExternalAccountRequired
 }
+
+// middlewares returns the middlewares of the HTTP client.
+// The headers are added first, so they are seen by the middlewares of the configuration.
+func middlewares(config *Config) []api.Middleware {
+	var middlewares []api.Middleware
+
+	if len(config.Headers) > 0 {
+		middlewares = append(middlewares, api.SetHeaders(config.Headers))
+	}
+
+	if config.UserAgentSuffix != "" {
+		middlewares = append(middlewares, api.AppendUserAgent(config.UserAgentSuffix))
+	}
+
+	return append(middlewares, config.Middlewares...)
+}
@@ -10,7 +10,12 @@ import (
 	"os"
 	"time"
 
+	"github.com/go-acme/lego/v4/acme"
+	"github.com/go-acme/lego/v4/acme/api"
 	"github.com/go-acme/lego/v4/certcrypto"
+	"github.com/go-acme/lego/v4/certificate"
+	"github.com/go-acme/lego/v4/challenge"
+	"github.com/go-acme/lego/v4/metrics"
 	"github.com/go-acme/lego/v4/registration"
 )
 
@@ -40,6 +45,55 @@ type Config struct {
 	UserAgent   string
 	HTTPClient  *http.Client
 	Certificate CertificateConfig
+
+	// Retry the options of the retries of the requests to the ACME server (badNonce and 5xx errors).
+	Retry api.RetryOptions
+
+	// NoncePool the options of the pool of nonces (prefetch for the high-volume issuance).
+	NoncePool api.NoncePoolOptions
+
+	// DirectoryCache the cache of the directories of the ACME servers, it can be shared by the clients
+	// (see api.NewDirectoryCache for the refresh interval).
+	// The directory is fetched for each client if nil.
+	DirectoryCache *api.DirectoryCache
+
+	// UserAgentSuffix is appended to the User-Agent of the requests to the ACME server.
+	UserAgentSuffix string
+
+	// Headers the static headers added to all the requests to the ACME server
+	// (ex: identification headers required by a CA or a corporate proxy).
+	Headers http.Header
+
+	// Middlewares wrap the transport of the HTTP client used to communicate with the ACME server.
+	// The first middleware is the outermost.
+	Middlewares []api.Middleware
+
+	// Callbacks the callbacks of the order and challenge lifecycle.
+	Callbacks Callbacks
+
+	// Metrics the recorder of the metrics of the issuance lifecycle.
+	// The metrics are discarded if nil.
+	Metrics metrics.Recorder
+}
+
+// Callbacks the callbacks of the order and challenge lifecycle.
+// They are called synchronously, so they must not block.
+// The nil callbacks are ignored.
+type Callbacks struct {
+	// OnOrderCreated is called when an order has been created by the CA.
+	OnOrderCreated func(order acme.ExtendedOrder)
+
+	// OnChallengePresented is called when a challenge is ready to be validated by the CA.
+	OnChallengePresented func(domain string, chlgType challenge.Type)
+
+	// OnChallengeValidated is called when a challenge has been validated by the CA.
+	OnChallengeValidated func(domain string, chlgType challenge.Type)
+
+	// OnChallengeFailed is called when a challenge cannot be presented or validated.
+	OnChallengeFailed func(domain string, chlgType challenge.Type, err error)
+
+	// OnCertificateIssued is called when a certificate has been issued by the CA.
+	OnCertificateIssued func(res *certificate.Resource)
 }
 
 func NewConfig(user registration.User) *Config {
@@ -57,6 +111,17 @@ func NewConfig(user registration.User) *Config {
 type CertificateConfig struct {
 	KeyType certcrypto.KeyType
 	Timeout time.Duration
+
+	// PreferredChain if the CA offers multiple certificate chains,
+	// the chain with an issuer matching this Common Name (ex: "ISRG Root X1") is preferred.
+	// The preferred chain of an ObtainRequest takes precedence.
+	PreferredChain string
+
+	// BatchWorkers the number of certificates obtained concurrently by Certifier.ObtainBatch.
+	BatchWorkers int
+
+	// ARIReplaces if true, Certifier.Renew notifies the CA supporting ARI of the certificate replaced by the new one.
+	ARIReplaces bool
 }
 
 // createDefaultHTTPClient Creates an HTTP client with a reasonable timeout value
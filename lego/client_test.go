@@ -4,8 +4,11 @@ import (
 	"crypto"
 	"crypto/rand"
 	"crypto/rsa"
+	"net/http"
+	"strings"
 	"testing"
 
+	"github.com/go-acme/lego/v4/acme/api"
 	"github.com/go-acme/lego/v4/platform/tester"
 	"github.com/go-acme/lego/v4/registration"
 	"github.com/stretchr/testify/assert"
@@ -35,6 +38,79 @@ func TestNewClient(t *testing.T) {
 	assert.NotNil(t, client)
 }
 
+func TestNewClient_middlewares(t *testing.T) {
+	_, apiURL, tearDown := tester.SetupFakeAPI()
+	defer tearDown()
+
+	keyBits := 32 // small value keeps test fast
+	key, err := rsa.GenerateKey(rand.Reader, keyBits)
+	require.NoError(t, err, "Could not generate test key")
+
+	user := mockUser{
+		email:      "test@test.com",
+		regres:     new(registration.Resource),
+		privatekey: key,
+	}
+
+	var paths []string
+
+	config := NewConfig(user)
+	config.CADirURL = apiURL + "/dir"
+	config.Middlewares = []api.Middleware{
+		func(next http.RoundTripper) http.RoundTripper {
+			return api.RoundTripperFunc(func(req *http.Request) (*http.Response, error) {
+				paths = append(paths, req.URL.Path)
+				return next.RoundTrip(req)
+			})
+		},
+	}
+
+	_, err = NewClient(config)
+	require.NoError(t, err, "Could not create client")
+
+	assert.Equal(t, []string{"/dir"}, paths)
+}
+
+func TestNewClient_headers(t *testing.T) {
+	_, apiURL, tearDown := tester.SetupFakeAPI()
+	defer tearDown()
+
+	keyBits := 32 // small value keeps test fast
+	key, err := rsa.GenerateKey(rand.Reader, keyBits)
+	require.NoError(t, err, "Could not generate test key")
+
+	user := mockUser{
+		email:      "test@test.com",
+		regres:     new(registration.Resource),
+		privatekey: key,
+	}
+
+	var userAgent, clientID string
+
+	config := NewConfig(user)
+	config.CADirURL = apiURL + "/dir"
+	config.UserAgent = "lego-test"
+	config.UserAgentSuffix = "my-company/1.0"
+	config.Headers = http.Header{"X-Client-Id": []string{"lego-1"}}
+	config.Middlewares = []api.Middleware{
+		func(next http.RoundTripper) http.RoundTripper {
+			return api.RoundTripperFunc(func(req *http.Request) (*http.Response, error) {
+				// the headers are seen by the middlewares of the configuration.
+				clientID = req.Header.Get("X-Client-Id")
+				userAgent = req.UserAgent()
+				return next.RoundTrip(req)
+			})
+		},
+	}
+
+	_, err = NewClient(config)
+	require.NoError(t, err, "Could not create client")
+
+	assert.Equal(t, "lego-1", clientID)
+	assert.True(t, strings.HasPrefix(userAgent, "lego-test "), userAgent)
+	assert.True(t, strings.HasSuffix(userAgent, " my-company/1.0"), userAgent)
+}
+
 type mockUser struct {
 	email      string
 	regres     *registration.Resource
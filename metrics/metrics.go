@@ -0,0 +1,32 @@
+// Package metrics provides hooks to observe the issuance lifecycle (orders, challenges, DNS propagation, requests to the CA).
+package metrics
+
+import "time"
+
+// Recorder interface for the metrics of the issuance lifecycle.
+// The methods are called concurrently.
+type Recorder interface {
+	// OrderCreated is called when an order is created by the CA.
+	OrderCreated()
+
+	// ChallengeSolved is called when a challenge (ex: "dns-01") is solved, or failed.
+	ChallengeSolved(challengeType string, success bool)
+
+	// PropagationWait is called with the time spent waiting for the propagation of a DNS record (dns-01).
+	PropagationWait(duration time.Duration, success bool)
+
+	// CARequest is called with the latency of a request to the CA.
+	// The status code is 0 if the request failed without response.
+	CARequest(method string, statusCode int, duration time.Duration)
+}
+
+// NoopRecorder discards the metrics.
+type NoopRecorder struct{}
+
+func (NoopRecorder) OrderCreated() {}
+
+func (NoopRecorder) ChallengeSolved(string, bool) {}
+
+func (NoopRecorder) PropagationWait(time.Duration, bool) {}
+
+func (NoopRecorder) CARequest(string, int, time.Duration) {}
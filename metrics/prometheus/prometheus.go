@@ -0,0 +1,92 @@
+// Package prometheus provides a metrics.Recorder backed by Prometheus collectors.
+package prometheus
+
+import (
+	"strconv"
+	"time"
+
+	"github.com/go-acme/lego/v4/metrics"
+	prom "github.com/prometheus/client_golang/prometheus"
+)
+
+// Buckets (in seconds) of the histograms of the Recorder.
+var (
+	// CARequestBuckets the buckets of the latency of the requests to the CA.
+	CARequestBuckets = []float64{0.005, 0.01, 0.025, 0.05, 0.1, 0.25, 0.5, 1, 2.5, 5, 10}
+
+	// PropagationWaitBuckets the buckets of the DNS propagation waits.
+	PropagationWaitBuckets = []float64{1, 2, 5, 10, 30, 60, 120, 300, 600}
+)
+
+var _ metrics.Recorder = (*Recorder)(nil)
+
+// Recorder a metrics.Recorder updating Prometheus collectors.
+//
+// The metrics:
+//   - lego_orders_created_total (counter)
+//   - lego_challenges_solved_total{type,result} (counter)
+//   - lego_dns_propagation_wait_seconds{result} (histogram)
+//   - lego_ca_request_duration_seconds{method,code} (histogram)
+type Recorder struct {
+	ordersCreated    prom.Counter
+	challengesSolved *prom.CounterVec
+	propagationWaits *prom.HistogramVec
+	caRequests       *prom.HistogramVec
+}
+
+// NewRecorder creates a Recorder, and registers its collectors with the registerer
+// (ex: prometheus.DefaultRegisterer).
+func NewRecorder(registerer prom.Registerer) (*Recorder, error) {
+	r := &Recorder{
+		ordersCreated: prom.NewCounter(prom.CounterOpts{
+			Name: "lego_orders_created_total",
+			Help: "The number of orders created by the CA.",
+		}),
+		challengesSolved: prom.NewCounterVec(prom.CounterOpts{
+			Name: "lego_challenges_solved_total",
+			Help: "The number of challenges solved (or failed), by type.",
+		}, []string{"type", "result"}),
+		propagationWaits: prom.NewHistogramVec(prom.HistogramOpts{
+			Name:    "lego_dns_propagation_wait_seconds",
+			Help:    "The time spent waiting for the propagation of the DNS records.",
+			Buckets: PropagationWaitBuckets,
+		}, []string{"result"}),
+		caRequests: prom.NewHistogramVec(prom.HistogramOpts{
+			Name:    "lego_ca_request_duration_seconds",
+			Help:    "The latency of the requests to the CA.",
+			Buckets: CARequestBuckets,
+		}, []string{"method", "code"}),
+	}
+
+	for _, collector := range []prom.Collector{r.ordersCreated, r.challengesSolved, r.propagationWaits, r.caRequests} {
+		if err := registerer.Register(collector); err != nil {
+			return nil, err
+		}
+	}
+
+	return r, nil
+}
+
+func (r *Recorder) OrderCreated() {
+	r.ordersCreated.Inc()
+}
+
+func (r *Recorder) ChallengeSolved(challengeType string, success bool) {
+	r.challengesSolved.WithLabelValues(challengeType, result(success)).Inc()
+}
+
+func (r *Recorder) PropagationWait(duration time.Duration, success bool) {
+	r.propagationWaits.WithLabelValues(result(success)).Observe(duration.Seconds())
+}
+
+func (r *Recorder) CARequest(method string, statusCode int, duration time.Duration) {
+	r.caRequests.WithLabelValues(method, strconv.Itoa(statusCode)).Observe(duration.Seconds())
+}
+
+func result(success bool) string {
+	if success {
+		return "success"
+	}
+
+	return "failure"
+}
@@ -0,0 +1,85 @@
+package prometheus
+
+import (
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	prom "github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRecorder(t *testing.T) {
+	registry := prom.NewRegistry()
+
+	recorder, err := NewRecorder(registry)
+	require.NoError(t, err)
+
+	recorder.OrderCreated()
+	recorder.OrderCreated()
+	recorder.ChallengeSolved("dns-01", true)
+	recorder.ChallengeSolved("dns-01", false)
+	recorder.ChallengeSolved("http-01", true)
+	recorder.PropagationWait(3*time.Second, true)
+	recorder.CARequest(http.MethodPost, http.StatusCreated, 200*time.Millisecond)
+
+	assert.Equal(t, float64(2), testutil.ToFloat64(recorder.ordersCreated))
+	assert.Equal(t, float64(1), testutil.ToFloat64(recorder.challengesSolved.WithLabelValues("dns-01", "success")))
+	assert.Equal(t, float64(1), testutil.ToFloat64(recorder.challengesSolved.WithLabelValues("dns-01", "failure")))
+	assert.Equal(t, float64(1), testutil.ToFloat64(recorder.challengesSolved.WithLabelValues("http-01", "success")))
+
+	expected := `
+# HELP lego_dns_propagation_wait_seconds The time spent waiting for the propagation of the DNS records.
+# TYPE lego_dns_propagation_wait_seconds histogram
+lego_dns_propagation_wait_seconds_bucket{result="success",le="1"} 0
+lego_dns_propagation_wait_seconds_bucket{result="success",le="2"} 0
+lego_dns_propagation_wait_seconds_bucket{result="success",le="5"} 1
+lego_dns_propagation_wait_seconds_bucket{result="success",le="10"} 1
+lego_dns_propagation_wait_seconds_bucket{result="success",le="30"} 1
+lego_dns_propagation_wait_seconds_bucket{result="success",le="60"} 1
+lego_dns_propagation_wait_seconds_bucket{result="success",le="120"} 1
+lego_dns_propagation_wait_seconds_bucket{result="success",le="300"} 1
+lego_dns_propagation_wait_seconds_bucket{result="success",le="600"} 1
+lego_dns_propagation_wait_seconds_bucket{result="success",le="+Inf"} 1
+lego_dns_propagation_wait_seconds_sum{result="success"} 3
+lego_dns_propagation_wait_seconds_count{result="success"} 1
+`
+	err = testutil.CollectAndCompare(recorder.propagationWaits, strings.NewReader(expected))
+	require.NoError(t, err)
+
+	families, err := registry.Gather()
+	require.NoError(t, err)
+
+	var names []string
+	for _, family := range families {
+		names = append(names, family.GetName())
+
+		if family.GetName() == "lego_ca_request_duration_seconds" {
+			require.Len(t, family.GetMetric(), 1)
+
+			histogram := family.GetMetric()[0].GetHistogram()
+			assert.Equal(t, uint64(1), histogram.GetSampleCount())
+			assert.InDelta(t, 0.2, histogram.GetSampleSum(), 0.0001)
+		}
+	}
+
+	assert.Equal(t, []string{
+		"lego_ca_request_duration_seconds",
+		"lego_challenges_solved_total",
+		"lego_dns_propagation_wait_seconds",
+		"lego_orders_created_total",
+	}, names)
+}
+
+func TestNewRecorder_alreadyRegistered(t *testing.T) {
+	registry := prom.NewRegistry()
+
+	_, err := NewRecorder(registry)
+	require.NoError(t, err)
+
+	_, err = NewRecorder(registry)
+	require.Error(t, err)
+}
@@ -23,8 +23,10 @@ func SetupFakeAPI() (*http.ServeMux, string, func()) {
 			NewNonceURL:   ts.URL + "/nonce",
 			NewAccountURL: ts.URL + "/account",
 			NewOrderURL:   ts.URL + "/newOrder",
+			NewAuthzURL:   ts.URL + "/newAuthz",
 			RevokeCertURL: ts.URL + "/revokeCert",
 			KeyChangeURL:  ts.URL + "/keyChange",
+			RenewalInfo:   ts.URL + "/renewalInfo",
 		})
 
 		mux.HandleFunc("/nonce", func(w http.ResponseWriter, r *http.Request) {
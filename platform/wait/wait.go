@@ -1,6 +1,7 @@
 package wait
 
 import (
+	"context"
 	"fmt"
 	"time"
 
@@ -9,6 +10,12 @@ import (
 
 // For polls the given function 'f', once every 'interval', up to 'timeout'.
 func For(msg string, timeout, interval time.Duration, f func() (bool, error)) error {
+	return ForWithContext(context.Background(), msg, timeout, interval, f)
+}
+
+// ForWithContext polls the given function 'f', once every 'interval', up to 'timeout'.
+// It stops when the context is done.
+func ForWithContext(ctx context.Context, msg string, timeout, interval time.Duration, f func() (bool, error)) error {
 	log.Infof("Wait for %s [timeout: %s, interval: %s]", msg, timeout, interval)
 
 	var lastErr error
@@ -17,6 +24,8 @@ func For(msg string, timeout, interval time.Duration, f func() (bool, error)) er
 		select {
 		case <-timeUp:
 			return fmt.Errorf("time limit exceeded: last error: %w", lastErr)
+		case <-ctx.Done():
+			return fmt.Errorf("%w: last error: %v", ctx.Err(), lastErr)
 		default:
 		}
 
@@ -28,6 +37,9 @@ func For(msg string, timeout, interval time.Duration, f func() (bool, error)) er
 			lastErr = err
 		}
 
-		time.Sleep(interval)
+		select {
+		case <-time.After(interval):
+		case <-ctx.Done():
+		}
 	}
 }
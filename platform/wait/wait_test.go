@@ -1,6 +1,8 @@
 package wait
 
 import (
+	"context"
+	"errors"
 	"testing"
 	"time"
 )
@@ -24,3 +26,22 @@ func TestForTimeout(t *testing.T) {
 		}
 	}
 }
+
+func TestForWithContext_canceled(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+
+	var calls int
+	err := ForWithContext(ctx, "", 10*time.Second, 5*time.Second, func() (bool, error) {
+		calls++
+		cancel()
+		return false, errors.New("not ready")
+	})
+
+	if !errors.Is(err, context.Canceled) {
+		t.Errorf("expected context canceled error; got %v", err)
+	}
+
+	if calls != 1 {
+		t.Errorf("expected 1 call; got %d", calls)
+	}
+}
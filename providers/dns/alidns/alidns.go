@@ -5,6 +5,7 @@ import (
 	"errors"
 	"fmt"
 	"strings"
+	"sync"
 	"time"
 
 	"github.com/aliyun/alibaba-cloud-sdk-go/sdk"
@@ -13,6 +14,7 @@ import (
 	"github.com/aliyun/alibaba-cloud-sdk-go/services/alidns"
 	"github.com/go-acme/lego/v4/challenge/dns01"
 	"github.com/go-acme/lego/v4/platform/config/env"
+	"github.com/go-acme/lego/v4/providers/dns/internal/useragent"
 	"golang.org/x/net/idna"
 )
 
@@ -53,10 +55,19 @@ func NewDefaultConfig() *Config {
 	}
 }
 
+// The maximum number of records by page accepted by the API.
+const maxPageSize = 500
+
 // DNSProvider implements the challenge.Provider interface.
 type DNSProvider struct {
 	config *Config
 	client *alidns.Client
+
+	// findZoneByFqdn determines the DNS zone of an fqdn. It is overridden during tests.
+	findZoneByFqdn func(fqdn string) (string, error)
+
+	recordIDs   map[string]string
+	recordIDsMu sync.Mutex
 }
 
 // NewDNSProvider returns a DNSProvider instance configured for Alibaba Cloud DNS.
@@ -98,7 +109,16 @@ func NewDNSProviderConfig(config *Config) (*DNSProvider, error) {
 		return nil, fmt.Errorf("alicloud: credentials failed: %w", err)
 	}
 
-	return &DNSProvider{config: config, client: client}, nil
+	if ua := useragent.Get(); ua != "" {
+		client.SetTransport(&useragent.Transport{UserAgent: ua})
+	}
+
+	return &DNSProvider{
+		config:         config,
+		client:         client,
+		findZoneByFqdn: dns01.FindZoneByFqdn,
+		recordIDs:      make(map[string]string),
+	}, nil
 }
 
 // Timeout returns the timeout and interval to use when checking for DNS propagation.
@@ -121,35 +141,54 @@ func (d *DNSProvider) Present(domain, token, keyAuth string) error {
 		return err
 	}
 
-	_, err = d.client.AddDomainRecord(recordAttributes)
+	response, err := d.client.AddDomainRecord(recordAttributes)
 	if err != nil {
 		return fmt.Errorf("alicloud: API call failed: %w", err)
 	}
+
+	d.recordIDsMu.Lock()
+	d.recordIDs[token] = response.RecordId
+	d.recordIDsMu.Unlock()
+
 	return nil
 }
 
 // CleanUp removes the TXT record matching the specified parameters.
 func (d *DNSProvider) CleanUp(domain, token, keyAuth string) error {
-	fqdn, _ := dns01.GetRecord(domain, keyAuth)
+	fqdn, value := dns01.GetRecord(domain, keyAuth)
 
-	records, err := d.findTxtRecords(domain, fqdn)
-	if err != nil {
-		return fmt.Errorf("alicloud: %w", err)
-	}
+	// gets the record's unique ID from when we created it
+	d.recordIDsMu.Lock()
+	recordID, ok := d.recordIDs[token]
+	d.recordIDsMu.Unlock()
 
-	_, err = d.getHostedZone(domain)
-	if err != nil {
-		return fmt.Errorf("alicloud: %w", err)
-	}
-
-	for _, rec := range records {
-		request := alidns.CreateDeleteDomainRecordRequest()
-		request.RecordId = rec.RecordId
-		_, err = d.client.DeleteDomainRecord(request)
+	if !ok {
+		// the record has not been created by this instance of the provider: it is found by its name and its value,
+		// the other values of the same name are kept.
+		var err error
+		recordID, err = d.findTxtRecordID(domain, fqdn, value)
 		if err != nil {
 			return fmt.Errorf("alicloud: %w", err)
 		}
+
+		if recordID == "" {
+			return nil
+		}
+	}
+
+	request := alidns.CreateDeleteDomainRecordRequest()
+	request.RecordId = recordID
+
+	_, err := d.client.DeleteDomainRecord(request)
+	if err != nil {
+		return fmt.Errorf("alicloud: failed to delete TXT record: fqdn=%s, recordID=%s: %w", fqdn, recordID, err)
 	}
+
+	// deletes record ID from map
+	d.recordIDsMu.Lock()
+	delete(d.recordIDs, token)
+	d.recordIDsMu.Unlock()
+
 	return nil
 }
 
@@ -176,7 +215,7 @@ func (d *DNSProvider) getHostedZone(domain string) (string, error) {
 		startPage++
 	}
 
-	authZone, err := dns01.FindZoneByFqdn(dns01.ToFqdn(domain))
+	authZone, err := d.findZoneByFqdn(dns01.ToFqdn(domain))
 	if err != nil {
 		return "", err
 	}
@@ -212,34 +251,61 @@ func (d *DNSProvider) newTxtRecord(zone, fqdn, value string) (*alidns.AddDomainR
 	return request, nil
 }
 
-func (d *DNSProvider) findTxtRecords(domain, fqdn string) ([]alidns.Record, error) {
+// findTxtRecordID returns the ID of the TXT record with the given value, or an empty ID if the record doesn't exist.
+func (d *DNSProvider) findTxtRecordID(domain, fqdn, value string) (string, error) {
 	zoneName, err := d.getHostedZone(domain)
 	if err != nil {
-		return nil, err
+		return "", err
 	}
 
+	recordName, err := extractRecordName(fqdn, zoneName)
+	if err != nil {
+		return "", err
+	}
+
+	records, err := d.findTxtRecords(zoneName, recordName)
+	if err != nil {
+		return "", err
+	}
+
+	for _, record := range records {
+		if record.Value == value {
+			return record.RecordId, nil
+		}
+	}
+
+	return "", nil
+}
+
+// findTxtRecords returns the TXT records of the name, from all the pages of the records of the zone.
+func (d *DNSProvider) findTxtRecords(zoneName, recordName string) ([]alidns.Record, error) {
 	request := alidns.CreateDescribeDomainRecordsRequest()
 	request.DomainName = zoneName
-	request.PageSize = requests.NewInteger(500)
+	request.RRKeyWord = recordName
+	request.TypeKeyWord = "TXT"
+	request.PageSize = requests.NewInteger(maxPageSize)
 
 	var records []alidns.Record
 
-	result, err := d.client.DescribeDomainRecords(request)
-	if err != nil {
-		return records, fmt.Errorf("API call has failed: %w", err)
-	}
+	for page := 1; ; page++ {
+		request.PageNumber = requests.NewInteger(page)
 
-	recordName, err := extractRecordName(fqdn, zoneName)
-	if err != nil {
-		return nil, err
-	}
+		result, err := d.client.DescribeDomainRecords(request)
+		if err != nil {
+			return nil, fmt.Errorf("API call has failed: %w", err)
+		}
+
+		// the keywords are a fuzzy search.
+		for _, record := range result.DomainRecords.Record {
+			if record.RR == recordName && record.Type == "TXT" {
+				records = append(records, record)
+			}
+		}
 
-	for _, record := range result.DomainRecords.Record {
-		if record.RR == recordName {
-			records = append(records, record)
+		if len(result.DomainRecords.Record) == 0 || result.PageNumber*result.PageSize >= result.TotalCount {
+			return records, nil
 		}
 	}
-	return records, nil
 }
 
 func extractRecordName(fqdn, zone string) (string, error) {
@@ -1,10 +1,17 @@
 package alidns
 
 import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
 	"testing"
 	"time"
 
+	"github.com/aliyun/alibaba-cloud-sdk-go/services/alidns"
 	"github.com/go-acme/lego/v4/platform/tester"
+	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 )
 
@@ -123,6 +130,144 @@ func TestNewDNSProviderConfig(t *testing.T) {
 	}
 }
 
+// fakeAPI is a fake AliDNS API managing the zone example.com, the records are listed by pages of 2 records.
+type fakeAPI struct {
+	records []alidns.Record
+	added   []alidns.Record
+	deleted []string
+	pages   []int
+}
+
+func (f *fakeAPI) ServeHTTP(rw http.ResponseWriter, req *http.Request) {
+	var result interface{}
+
+	switch req.FormValue("Action") {
+	case "DescribeDomains":
+		result = map[string]interface{}{
+			"TotalCount": 1, "PageNumber": 1, "PageSize": 20,
+			"Domains": map[string]interface{}{
+				"Domain": []alidns.Domain{{DomainId: "zone-id", DomainName: "example.com"}},
+			},
+		}
+
+	case "AddDomainRecord":
+		f.added = append(f.added, alidns.Record{RR: req.FormValue("RR"), Type: req.FormValue("Type"), Value: req.FormValue("Value")})
+		result = map[string]interface{}{"RequestId": "request-id", "RecordId": "new-id"}
+
+	case "DescribeDomainRecords":
+		const pageSize = 2
+
+		page, _ := strconv.Atoi(req.FormValue("PageNumber"))
+		f.pages = append(f.pages, page)
+
+		var records []alidns.Record
+		for i := (page - 1) * pageSize; i < page*pageSize && i < len(f.records); i++ {
+			records = append(records, f.records[i])
+		}
+
+		result = map[string]interface{}{
+			"TotalCount": len(f.records), "PageNumber": page, "PageSize": pageSize,
+			"DomainRecords": map[string]interface{}{"Record": records},
+		}
+
+	case "DeleteDomainRecord":
+		f.deleted = append(f.deleted, req.FormValue("RecordId"))
+		result = map[string]interface{}{"RequestId": "request-id", "RecordId": req.FormValue("RecordId")}
+
+	default:
+		http.Error(rw, `{"Code":"InvalidAction"}`, http.StatusBadRequest)
+		return
+	}
+
+	_ = json.NewEncoder(rw).Encode(result)
+}
+
+func setupTest(t *testing.T, api *fakeAPI) *DNSProvider {
+	t.Helper()
+
+	server := httptest.NewServer(api)
+	t.Cleanup(server.Close)
+
+	config := NewDefaultConfig()
+	config.APIKey = "123"
+	config.SecretKey = "456"
+
+	provider, err := NewDNSProviderConfig(config)
+	require.NoError(t, err)
+
+	provider.client.Domain = strings.TrimPrefix(server.URL, "http://")
+	provider.findZoneByFqdn = func(_ string) (string, error) { return "example.com.", nil }
+
+	return provider
+}
+
+func TestDNSProvider_Present(t *testing.T) {
+	api := &fakeAPI{}
+	provider := setupTest(t, api)
+
+	err := provider.Present("www.example.com", "token", "123d==")
+	require.NoError(t, err)
+
+	expected := []alidns.Record{{RR: "_acme-challenge.www", Type: "TXT", Value: "ADw2sEd82DUgXcQ9hNBZThJs7zVJkR5v9JeSbAb9mZY"}}
+	assert.Equal(t, expected, api.added)
+
+	assert.Equal(t, map[string]string{"token": "new-id"}, provider.recordIDs)
+}
+
+func TestDNSProvider_CleanUp_recordID(t *testing.T) {
+	// the wildcard and the domain share the name of the record.
+	api := &fakeAPI{
+		records: []alidns.Record{
+			{RecordId: "wildcard-id", RR: "_acme-challenge", Type: "TXT", Value: "other"},
+			{RecordId: "new-id", RR: "_acme-challenge", Type: "TXT", Value: "ADw2sEd82DUgXcQ9hNBZThJs7zVJkR5v9JeSbAb9mZY"},
+		},
+	}
+	provider := setupTest(t, api)
+
+	provider.recordIDs["token"] = "new-id"
+
+	err := provider.CleanUp("example.com", "token", "123d==")
+	require.NoError(t, err)
+
+	assert.Equal(t, []string{"new-id"}, api.deleted)
+	assert.Empty(t, api.pages)
+	assert.Empty(t, provider.recordIDs)
+}
+
+func TestDNSProvider_CleanUp_multiPage(t *testing.T) {
+	api := &fakeAPI{
+		records: []alidns.Record{
+			{RecordId: "1", RR: "www", Type: "A", Value: "192.0.2.1"},
+			{RecordId: "2", RR: "_acme-challenge.www", Type: "TXT", Value: "other"},
+			{RecordId: "3", RR: "_acme-challenge.www.sub", Type: "TXT", Value: "ADw2sEd82DUgXcQ9hNBZThJs7zVJkR5v9JeSbAb9mZY"},
+			{RecordId: "4", RR: "_acme-challenge", Type: "TXT", Value: "ADw2sEd82DUgXcQ9hNBZThJs7zVJkR5v9JeSbAb9mZY"},
+			{RecordId: "5", RR: "_acme-challenge.www", Type: "TXT", Value: "ADw2sEd82DUgXcQ9hNBZThJs7zVJkR5v9JeSbAb9mZY"},
+		},
+	}
+	provider := setupTest(t, api)
+
+	// the record has been created by another instance of the provider.
+	err := provider.CleanUp("www.example.com", "token", "123d==")
+	require.NoError(t, err)
+
+	assert.Equal(t, []int{1, 2, 3}, api.pages)
+	assert.Equal(t, []string{"5"}, api.deleted)
+}
+
+func TestDNSProvider_CleanUp_notFound(t *testing.T) {
+	api := &fakeAPI{
+		records: []alidns.Record{
+			{RecordId: "2", RR: "_acme-challenge.www", Type: "TXT", Value: "other"},
+		},
+	}
+	provider := setupTest(t, api)
+
+	err := provider.CleanUp("www.example.com", "token", "123d==")
+	require.NoError(t, err)
+
+	assert.Empty(t, api.deleted)
+}
+
 func TestLivePresent(t *testing.T) {
 	if !envTest.IsLiveTest() {
 		t.Skip("skipping live test")
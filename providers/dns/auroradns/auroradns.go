@@ -9,6 +9,7 @@ import (
 
 	"github.com/go-acme/lego/v4/challenge/dns01"
 	"github.com/go-acme/lego/v4/platform/config/env"
+	"github.com/go-acme/lego/v4/providers/dns/internal/useragent"
 	"github.com/nrdcg/auroradns"
 )
 
@@ -90,6 +91,10 @@ func NewDNSProviderConfig(config *Config) (*DNSProvider, error) {
 		return nil, fmt.Errorf("aurora: %w", err)
 	}
 
+	if ua := useragent.Get(); ua != "" {
+		tr.Transport = &useragent.Transport{UserAgent: ua}
+	}
+
 	client, err := auroradns.NewClient(tr.Client(), auroradns.WithBaseURL(config.BaseURL))
 	if err != nil {
 		return nil, fmt.Errorf("aurora: %w", err)
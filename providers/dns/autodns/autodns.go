@@ -6,10 +6,13 @@ import (
 	"fmt"
 	"net/http"
 	"net/url"
+	"strings"
 	"time"
 
 	"github.com/go-acme/lego/v4/challenge/dns01"
 	"github.com/go-acme/lego/v4/platform/config/env"
+	"github.com/go-acme/lego/v4/providers/dns/internal/useragent"
+	"github.com/miekg/dns"
 )
 
 // Environment variables names.
@@ -54,9 +57,9 @@ func NewDefaultConfig() *Config {
 		TTL:                env.GetOrDefaultInt(EnvTTL, defaultTTL),
 		PropagationTimeout: env.GetOrDefaultSecond(EnvPropagationTimeout, 2*time.Minute),
 		PollingInterval:    env.GetOrDefaultSecond(EnvPollingInterval, 2*time.Second),
-		HTTPClient: &http.Client{
+		HTTPClient: useragent.Wrap(&http.Client{
 			Timeout: env.GetOrDefaultSecond(EnvHTTPTimeout, 30*time.Second),
-		},
+		}),
 	}
 }
 
@@ -107,14 +110,19 @@ func (d *DNSProvider) Timeout() (timeout, interval time.Duration) {
 func (d *DNSProvider) Present(domain, token, keyAuth string) error {
 	fqdn, value := dns01.GetRecord(domain, keyAuth)
 
+	zone, err := d.findZone(fqdn)
+	if err != nil {
+		return fmt.Errorf("autodns: %w", err)
+	}
+
 	records := []*ResourceRecord{{
-		Name:  fqdn,
+		Name:  extractRecordName(fqdn, zone),
 		TTL:   int64(d.config.TTL),
 		Type:  "TXT",
 		Value: value,
 	}}
 
-	_, err := d.addTxtRecord(domain, records)
+	_, err = d.addTxtRecord(zone, records)
 	if err != nil {
 		return fmt.Errorf("autodns: %w", err)
 	}
@@ -126,16 +134,53 @@ func (d *DNSProvider) Present(domain, token, keyAuth string) error {
 func (d *DNSProvider) CleanUp(domain, token, keyAuth string) error {
 	fqdn, value := dns01.GetRecord(domain, keyAuth)
 
+	zone, err := d.findZone(fqdn)
+	if err != nil {
+		return fmt.Errorf("autodns: %w", err)
+	}
+
 	records := []*ResourceRecord{{
-		Name:  fqdn,
+		Name:  extractRecordName(fqdn, zone),
 		TTL:   int64(d.config.TTL),
 		Type:  "TXT",
 		Value: value,
 	}}
 
-	if err := d.removeTXTRecord(domain, records); err != nil {
+	if err := d.removeTXTRecord(zone, records); err != nil {
 		return fmt.Errorf("autodns: %w", err)
 	}
 
 	return nil
 }
+
+// findZone finds the most specific zone of the account for the FQDN.
+func (d *DNSProvider) findZone(fqdn string) (string, error) {
+	name := dns01.UnFqdn(fqdn)
+
+	var candidates []string
+	for _, index := range dns.Split(name) {
+		candidates = append(candidates, name[index:])
+	}
+
+	zones, err := d.searchZones(candidates)
+	if err != nil {
+		return "", fmt.Errorf("failed to search zones: %w", err)
+	}
+
+	var origins []string
+	for _, z := range zones {
+		origins = append(origins, dns01.UnFqdn(z.Name))
+	}
+
+	zone := dns01.FindMostSpecificZone(fqdn, origins)
+	if zone == "" {
+		return "", fmt.Errorf("no zone found for %s", fqdn)
+	}
+
+	return zone, nil
+}
+
+// extractRecordName returns the name of the record relative to the zone.
+func extractRecordName(fqdn, zone string) string {
+	return strings.TrimSuffix(dns01.UnFqdn(fqdn), "."+zone)
+}
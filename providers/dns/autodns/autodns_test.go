@@ -1,6 +1,10 @@
 package autodns
 
 import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
 	"testing"
 
 	"github.com/go-acme/lego/v4/platform/tester"
@@ -126,6 +130,153 @@ func TestNewDNSProviderConfig(t *testing.T) {
 	}
 }
 
+func setupTest(t *testing.T) (*DNSProvider, *http.ServeMux) {
+	t.Helper()
+
+	mux := http.NewServeMux()
+	server := httptest.NewServer(mux)
+	t.Cleanup(server.Close)
+
+	mux.HandleFunc("/zone/_search", func(rw http.ResponseWriter, req *http.Request) {
+		if req.Method != http.MethodPost {
+			http.Error(rw, "unsupported method", http.StatusMethodNotAllowed)
+			return
+		}
+
+		if req.Header.Get("X-Domainrobot-Context") != "4" {
+			http.Error(rw, "invalid context", http.StatusBadRequest)
+			return
+		}
+
+		query := &Query{}
+		err := json.NewDecoder(req.Body).Decode(query)
+		if err != nil {
+			http.Error(rw, err.Error(), http.StatusBadRequest)
+			return
+		}
+
+		// the zones of the account.
+		zones := map[string]bool{"example.com": true, "sub.example.com": true}
+
+		resp := &DataZoneResponse{}
+		for _, filter := range query.Filters {
+			if zones[filter.Value] {
+				resp.Data = append(resp.Data, &Zone{Name: filter.Value})
+			}
+		}
+
+		_ = json.NewEncoder(rw).Encode(resp)
+	})
+
+	config := NewDefaultConfig()
+	config.Username = "user"
+	config.Password = "secret"
+	config.Endpoint, _ = url.Parse(server.URL + "/")
+
+	provider, err := NewDNSProviderConfig(config)
+	require.NoError(t, err)
+
+	return provider, mux
+}
+
+func TestDNSProvider_Present(t *testing.T) {
+	testCases := []struct {
+		desc         string
+		domain       string
+		expectedPath string
+		expectedName string
+	}{
+		{
+			desc:         "zone apex",
+			domain:       "example.com",
+			expectedPath: "/zone/example.com/_stream",
+			expectedName: "_acme-challenge",
+		},
+		{
+			desc:         "sub-domain",
+			domain:       "www.example.com",
+			expectedPath: "/zone/example.com/_stream",
+			expectedName: "_acme-challenge.www",
+		},
+		{
+			desc:         "sub-zone",
+			domain:       "www.sub.example.com",
+			expectedPath: "/zone/sub.example.com/_stream",
+			expectedName: "_acme-challenge.www",
+		},
+	}
+
+	for _, test := range testCases {
+		t.Run(test.desc, func(t *testing.T) {
+			provider, mux := setupTest(t)
+
+			var stream *ZoneStream
+			mux.HandleFunc(test.expectedPath, func(rw http.ResponseWriter, req *http.Request) {
+				user, password, ok := req.BasicAuth()
+				if !ok || user != "user" || password != "secret" {
+					http.Error(rw, "invalid credentials", http.StatusUnauthorized)
+					return
+				}
+
+				err := json.NewDecoder(req.Body).Decode(&stream)
+				if err != nil {
+					http.Error(rw, err.Error(), http.StatusBadRequest)
+					return
+				}
+
+				_, _ = rw.Write([]byte(`{}`))
+			})
+
+			err := provider.Present(test.domain, "", "123d==")
+			require.NoError(t, err)
+
+			require.NotNil(t, stream)
+			assert.Empty(t, stream.Removes)
+			require.Len(t, stream.Adds, 1)
+
+			expected := &ResourceRecord{
+				Name:  test.expectedName,
+				TTL:   600,
+				Type:  "TXT",
+				Value: "ADw2sEd82DUgXcQ9hNBZThJs7zVJkR5v9JeSbAb9mZY",
+			}
+			assert.Equal(t, expected, stream.Adds[0])
+		})
+	}
+}
+
+func TestDNSProvider_CleanUp(t *testing.T) {
+	provider, mux := setupTest(t)
+
+	var stream *ZoneStream
+	mux.HandleFunc("/zone/example.com/_stream", func(rw http.ResponseWriter, req *http.Request) {
+		err := json.NewDecoder(req.Body).Decode(&stream)
+		if err != nil {
+			http.Error(rw, err.Error(), http.StatusBadRequest)
+			return
+		}
+
+		_, _ = rw.Write([]byte(`{}`))
+	})
+
+	err := provider.CleanUp("example.com", "", "123d==")
+	require.NoError(t, err)
+
+	require.NotNil(t, stream)
+	assert.Empty(t, stream.Adds)
+	require.Len(t, stream.Removes, 1)
+
+	assert.Equal(t, "_acme-challenge", stream.Removes[0].Name)
+	assert.Equal(t, "ADw2sEd82DUgXcQ9hNBZThJs7zVJkR5v9JeSbAb9mZY", stream.Removes[0].Value)
+}
+
+func TestDNSProvider_Present_unknownZone(t *testing.T) {
+	provider, _ := setupTest(t)
+
+	err := provider.Present("example.org", "", "123d==")
+	require.EqualError(t, err, "autodns: no zone found for _acme-challenge.example.org.")
+}
+
 func TestLivePresent(t *testing.T) {
 	if !envTest.IsLiveTest() {
 		t.Skip("skipping live test")
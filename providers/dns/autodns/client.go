@@ -62,11 +62,53 @@ type Zone struct {
 	VirtualNameServer string            `json:"virtualNameServer"`
 }
 
+// Query is an autodns search query.
+type Query struct {
+	Filters []*QueryFilter `json:"filters"`
+}
+
+// QueryFilter is an autodns search query filter.
+type QueryFilter struct {
+	Key      string `json:"key"`
+	Value    string `json:"value"`
+	Operator string `json:"operator"`
+	Link     string `json:"link,omitempty"`
+}
+
 type ZoneStream struct {
 	Adds    []*ResourceRecord `json:"adds"`
 	Removes []*ResourceRecord `json:"rems"`
 }
 
+// searchZones searches the zones of the account matching one of the names.
+func (d *DNSProvider) searchZones(names []string) ([]*Zone, error) {
+	query := &Query{}
+	for _, name := range names {
+		query.Filters = append(query.Filters, &QueryFilter{Key: "name", Value: name, Operator: "EQUAL", Link: "OR"})
+	}
+
+	reqBody := &bytes.Buffer{}
+	if err := json.NewEncoder(reqBody).Encode(query); err != nil {
+		return nil, err
+	}
+
+	req, err := d.makeRequest(http.MethodPost, path.Join("zone", "_search"), reqBody)
+	if err != nil {
+		return nil, err
+	}
+
+	var resp *DataZoneResponse
+	if err := d.sendRequest(req, &resp); err != nil {
+		return nil, err
+	}
+
+	if resp == nil {
+		return nil, nil
+	}
+
+	return resp.Data, nil
+}
+
 func (d *DNSProvider) addTxtRecord(domain string, records []*ResourceRecord) (*Zone, error) {
 	zoneStream := &ZoneStream{Adds: records}
 
@@ -18,10 +18,14 @@ import (
 	"github.com/Azure/go-autorest/autorest/to"
 	"github.com/go-acme/lego/v4/challenge/dns01"
 	"github.com/go-acme/lego/v4/platform/config/env"
+	"github.com/go-acme/lego/v4/providers/dns/internal/useragent"
 )
 
 const defaultMetadataEndpoint = "http://169.254.169.254"
 
+// maxConcurrentUpdateRetries the number of attempts to update a record set modified concurrently.
+const maxConcurrentUpdateRetries = 5
+
 // Environment variables names.
 const (
 	envNamespace = "AZURE_"
@@ -33,6 +37,7 @@ const (
 	EnvTenantID         = envNamespace + "TENANT_ID"
 	EnvClientID         = envNamespace + "CLIENT_ID"
 	EnvClientSecret     = envNamespace + "CLIENT_SECRET"
+	EnvRecordMetadata   = envNamespace + "RECORD_METADATA"
 
 	EnvTTL                = envNamespace + "TTL"
 	EnvPropagationTimeout = envNamespace + "PROPAGATION_TIMEOUT"
@@ -53,6 +58,9 @@ type Config struct {
 	ResourceManagerEndpoint string
 	ActiveDirectoryEndpoint string
 
+	// RecordMetadata the metadata (key/value) set on the created record sets.
+	RecordMetadata map[string]string
+
 	PropagationTimeout time.Duration
 	PollingInterval    time.Duration
 	TTL                int
@@ -113,6 +121,12 @@ func NewDNSProvider() (*DNSProvider, error) {
 	config.ClientID = env.GetOrFile(EnvClientID)
 	config.TenantID = env.GetOrFile(EnvTenantID)
 
+	metadata, err := parseRecordMetadata(env.GetOrFile(EnvRecordMetadata))
+	if err != nil {
+		return nil, fmt.Errorf("azure: %w", err)
+	}
+	config.RecordMetadata = metadata
+
 	return NewDNSProviderConfig(config)
 }
 
@@ -123,7 +137,7 @@ func NewDNSProviderConfig(config *Config) (*DNSProvider, error) {
 	}
 
 	if config.HTTPClient == nil {
-		config.HTTPClient = http.DefaultClient
+		config.HTTPClient = useragent.Wrap(&http.Client{})
 	}
 
 	authorizer, err := getAuthorizer(config)
@@ -174,18 +188,63 @@ func (d *DNSProvider) Present(domain, token, keyAuth string) error {
 		return fmt.Errorf("azure: %w", err)
 	}
 
+	relative := toRelativeRecord(fqdn, dns01.ToFqdn(zone))
+
+	err = d.addTXTRecord(ctx, zone, relative, value)
+	if err != nil {
+		return fmt.Errorf("azure: %w", err)
+	}
+	return nil
+}
+
+// CleanUp removes the TXT record matching the specified parameters.
+func (d *DNSProvider) CleanUp(domain, token, keyAuth string) error {
+	ctx := context.Background()
+	fqdn, _ := dns01.GetRecord(domain, keyAuth)
+
+	zone, err := d.getHostedZoneID(ctx, fqdn)
+	if err != nil {
+		return fmt.Errorf("azure: %w", err)
+	}
+
+	relative := toRelativeRecord(fqdn, dns01.ToFqdn(zone))
 	rsc := dns.NewRecordSetsClientWithBaseURI(d.config.ResourceManagerEndpoint, d.config.SubscriptionID)
 	rsc.Authorizer = d.authorizer
+	setUserAgent(&rsc.Client)
 
-	relative := toRelativeRecord(fqdn, dns01.ToFqdn(zone))
+	_, err = rsc.Delete(ctx, d.config.ResourceGroup, zone, relative, dns.TXT, "")
+	if err != nil {
+		return fmt.Errorf("azure: %w", err)
+	}
+	return nil
+}
 
+// Adds the value to the TXT record set, creating the record set if needed.
+// The update is conditioned by the ETag of the record set read before (optimistic concurrency):
+// if the record set has been modified in between (ex: the base and wildcard challenges of a domain),
+// the record set is read again, and the values are merged.
+func (d *DNSProvider) addTXTRecord(ctx context.Context, zone, relative, value string) error {
+	rsc := dns.NewRecordSetsClientWithBaseURI(d.config.ResourceManagerEndpoint, d.config.SubscriptionID)
+	rsc.Authorizer = d.authorizer
+	setUserAgent(&rsc.Client)
+
+	var err error
+	for attempt := 0; attempt < maxConcurrentUpdateRetries; attempt++ {
+		err = d.updateTXTRecordSet(ctx, rsc, zone, relative, value)
+		if !hasStatusCode(err, http.StatusPreconditionFailed) {
+			return err
+		}
+	}
+
+	return fmt.Errorf("the record set has been modified concurrently %d times: %w", maxConcurrentUpdateRetries, err)
+}
+
+// Reads the TXT record set, and writes it with the value, only if it has not been modified in between.
+func (d *DNSProvider) updateTXTRecordSet(ctx context.Context, rsc dns.RecordSetsClient, zone, relative, value string) error {
 	// Get existing record set
 	rset, err := rsc.Get(ctx, d.config.ResourceGroup, zone, relative, dns.TXT)
-	if err != nil {
-		detailedError, ok := err.(autorest.DetailedError)
-		if !ok || detailedError.StatusCode != http.StatusNotFound {
-			return fmt.Errorf("azure: %w", err)
-		}
+	if err != nil && !hasStatusCode(err, http.StatusNotFound) {
+		return err
 	}
 
 	// Construct unique TXT records using map
@@ -209,35 +268,18 @@ func (d *DNSProvider) Present(domain, token, keyAuth string) error {
 		RecordSetProperties: &dns.RecordSetProperties{
 			TTL:        to.Int64Ptr(int64(d.config.TTL)),
 			TxtRecords: &txtRecords,
+			Metadata:   toMetadata(d.config.RecordMetadata),
 		},
 	}
 
-	_, err = rsc.CreateOrUpdate(ctx, d.config.ResourceGroup, zone, relative, dns.TXT, rec, "", "")
-	if err != nil {
-		return fmt.Errorf("azure: %w", err)
+	// The record set must be unchanged (If-Match), or still missing (If-None-Match).
+	ifMatch, ifNoneMatch := to.String(rset.Etag), ""
+	if ifMatch == "" {
+		ifNoneMatch = "*"
 	}
-	return nil
-}
 
-// CleanUp removes the TXT record matching the specified parameters.
-func (d *DNSProvider) CleanUp(domain, token, keyAuth string) error {
-	ctx := context.Background()
-	fqdn, _ := dns01.GetRecord(domain, keyAuth)
-
-	zone, err := d.getHostedZoneID(ctx, fqdn)
-	if err != nil {
-		return fmt.Errorf("azure: %w", err)
-	}
-
-	relative := toRelativeRecord(fqdn, dns01.ToFqdn(zone))
-	rsc := dns.NewRecordSetsClientWithBaseURI(d.config.ResourceManagerEndpoint, d.config.SubscriptionID)
-	rsc.Authorizer = d.authorizer
-
-	_, err = rsc.Delete(ctx, d.config.ResourceGroup, zone, relative, dns.TXT, "")
-	if err != nil {
-		return fmt.Errorf("azure: %w", err)
-	}
-	return nil
+	_, err = rsc.CreateOrUpdate(ctx, d.config.ResourceGroup, zone, relative, dns.TXT, rec, ifMatch, ifNoneMatch)
+	return err
 }
 
 // Checks that azure has a zone for this domain name.
@@ -249,6 +291,7 @@ func (d *DNSProvider) getHostedZoneID(ctx context.Context, fqdn string) (string,
 
 	dc := dns.NewZonesClientWithBaseURI(d.config.ResourceManagerEndpoint, d.config.SubscriptionID)
 	dc.Authorizer = d.authorizer
+	setUserAgent(&dc.Client)
 
 	zone, err := dc.Get(ctx, d.config.ResourceGroup, dns01.UnFqdn(authZone))
 	if err != nil {
@@ -259,11 +302,54 @@ func (d *DNSProvider) getHostedZoneID(ctx context.Context, fqdn string) (string,
 	return to.String(zone.Name), nil
 }
 
+// Checks if the error is an API error with the status code.
+func hasStatusCode(err error, statusCode int) bool {
+	var detailedError autorest.DetailedError
+	if !errors.As(err, &detailedError) {
+		return false
+	}
+
+	return detailedError.StatusCode == statusCode
+}
+
 // Returns the relative record to the domain.
 func toRelativeRecord(domain, zone string) string {
 	return dns01.UnFqdn(strings.TrimSuffix(domain, zone))
 }
 
+// Converts the metadata to the format expected by the Azure SDK.
+func toMetadata(metadata map[string]string) map[string]*string {
+	if len(metadata) == 0 {
+		return nil
+	}
+
+	result := make(map[string]*string, len(metadata))
+	for k, v := range metadata {
+		result[k] = to.StringPtr(v)
+	}
+
+	return result
+}
+
+// Parses the record metadata (ex: "key1:value1,key2:value2").
+func parseRecordMetadata(raw string) (map[string]string, error) {
+	if raw == "" {
+		return nil, nil
+	}
+
+	metadata := make(map[string]string)
+	for _, item := range strings.Split(raw, ",") {
+		parts := strings.SplitN(item, ":", 2)
+		if len(parts) != 2 || strings.TrimSpace(parts[0]) == "" {
+			return nil, fmt.Errorf("invalid record metadata: %q", item)
+		}
+
+		metadata[strings.TrimSpace(parts[0])] = strings.TrimSpace(parts[1])
+	}
+
+	return metadata, nil
+}
+
 func getAuthorizer(config *Config) (autorest.Authorizer, error) {
 	if config.ClientID != "" && config.ClientSecret != "" && config.TenantID != "" {
 		credentialsConfig := auth.ClientCredentialsConfig{
@@ -321,3 +407,10 @@ func getMetadata(config *Config, field string) (string, error) {
 
 	return string(respBody), nil
 }
+
+// setUserAgent overrides the User-Agent of the Azure client with the User-Agent defined by LEGO_USER_AGENT, if any.
+func setUserAgent(client *autorest.Client) {
+	if ua := useragent.Get(); ua != "" {
+		client.UserAgent = ua
+	}
+}
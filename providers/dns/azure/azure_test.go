@@ -1,12 +1,18 @@
 package azure
 
 import (
+	"context"
+	"encoding/json"
 	"net/http"
 	"net/http/httptest"
 	"testing"
 	"time"
 
+	"github.com/Azure/azure-sdk-for-go/services/dns/mgmt/2017-09-01/dns"
+	"github.com/Azure/go-autorest/autorest"
+	"github.com/Azure/go-autorest/autorest/to"
 	"github.com/go-acme/lego/v4/platform/tester"
+	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 )
 
@@ -18,7 +24,8 @@ var envTest = tester.NewEnvTest(
 	EnvClientSecret,
 	EnvSubscriptionID,
 	EnvTenantID,
-	EnvResourceGroup).
+	EnvResourceGroup,
+	EnvRecordMetadata).
 	WithDomain(envDomain)
 
 func TestNewDNSProvider(t *testing.T) {
@@ -155,6 +162,232 @@ func TestNewDNSProviderConfig(t *testing.T) {
 	}
 }
 
+func TestDNSProvider_addTXTRecord(t *testing.T) {
+	testCases := []struct {
+		desc     string
+		metadata map[string]string
+		expected map[string]interface{}
+	}{
+		{
+			desc: "without metadata",
+		},
+		{
+			desc:     "with metadata",
+			metadata: map[string]string{"owner": "lego", "cleanup": "true"},
+			expected: map[string]interface{}{"owner": "lego", "cleanup": "true"},
+		},
+	}
+
+	for _, test := range testCases {
+		t.Run(test.desc, func(t *testing.T) {
+			handler := http.NewServeMux()
+			server := httptest.NewServer(handler)
+			defer server.Close()
+
+			var body map[string]map[string]interface{}
+			handler.HandleFunc("/subscriptions/sub/resourceGroups/group/providers/Microsoft.Network/dnsZones/example.com/TXT/_acme-challenge",
+				func(rw http.ResponseWriter, req *http.Request) {
+					switch req.Method {
+					case http.MethodGet:
+						http.Error(rw, `{"error":{"code":"NotFound"}}`, http.StatusNotFound)
+					case http.MethodPut:
+						err := json.NewDecoder(req.Body).Decode(&body)
+						if err != nil {
+							http.Error(rw, err.Error(), http.StatusBadRequest)
+							return
+						}
+
+						rw.WriteHeader(http.StatusCreated)
+						_, _ = rw.Write([]byte(`{}`))
+					default:
+						http.Error(rw, "unsupported method", http.StatusMethodNotAllowed)
+					}
+				})
+
+			config := NewDefaultConfig()
+			config.SubscriptionID = "sub"
+			config.ResourceGroup = "group"
+			config.ResourceManagerEndpoint = server.URL
+			config.RecordMetadata = test.metadata
+
+			provider := &DNSProvider{config: config, authorizer: autorest.NullAuthorizer{}}
+
+			err := provider.addTXTRecord(context.Background(), "example.com", "_acme-challenge", "value")
+			require.NoError(t, err)
+
+			require.NotNil(t, body)
+			require.NotNil(t, body["properties"])
+
+			if test.expected == nil {
+				assert.NotContains(t, body["properties"], "metadata")
+			} else {
+				assert.Equal(t, test.expected, body["properties"]["metadata"])
+			}
+		})
+	}
+}
+
+func TestDNSProvider_addTXTRecord_concurrentModification(t *testing.T) {
+	handler := http.NewServeMux()
+	server := httptest.NewServer(handler)
+	defer server.Close()
+
+	// the record set contains the value of another challenge.
+	etag := "etag1"
+	values := []string{"other"}
+
+	var puts []string
+	handler.HandleFunc("/subscriptions/sub/resourceGroups/group/providers/Microsoft.Network/dnsZones/example.com/TXT/_acme-challenge",
+		func(rw http.ResponseWriter, req *http.Request) {
+			switch req.Method {
+			case http.MethodGet:
+				writeRecordSet(rw, etag, values)
+
+			case http.MethodPut:
+				ifMatch := req.Header.Get("If-Match")
+				puts = append(puts, ifMatch)
+
+				// simulates a concurrent modification between the read and the write of the first attempt.
+				if len(puts) == 1 {
+					etag = "etag2"
+					values = append(values, "wildcard")
+				}
+
+				if ifMatch != etag {
+					http.Error(rw, `{"error":{"code":"PreconditionFailed"}}`, http.StatusPreconditionFailed)
+					return
+				}
+
+				var body dns.RecordSet
+				err := json.NewDecoder(req.Body).Decode(&body)
+				if err != nil {
+					http.Error(rw, err.Error(), http.StatusBadRequest)
+					return
+				}
+
+				values = nil
+				for _, record := range *body.TxtRecords {
+					values = append(values, (*record.Value)...)
+				}
+
+				etag = "etag3"
+				writeRecordSet(rw, etag, values)
+
+			default:
+				http.Error(rw, "unsupported method", http.StatusMethodNotAllowed)
+			}
+		})
+
+	config := NewDefaultConfig()
+	config.SubscriptionID = "sub"
+	config.ResourceGroup = "group"
+	config.ResourceManagerEndpoint = server.URL
+
+	provider := &DNSProvider{config: config, authorizer: autorest.NullAuthorizer{}}
+
+	err := provider.addTXTRecord(context.Background(), "example.com", "_acme-challenge", "value")
+	require.NoError(t, err)
+
+	assert.Equal(t, []string{"etag1", "etag2"}, puts)
+
+	// the value written concurrently is kept.
+	assert.ElementsMatch(t, []string{"other", "wildcard", "value"}, values)
+}
+
+func TestDNSProvider_addTXTRecord_create(t *testing.T) {
+	handler := http.NewServeMux()
+	server := httptest.NewServer(handler)
+	defer server.Close()
+
+	var ifNoneMatch []string
+	handler.HandleFunc("/subscriptions/sub/resourceGroups/group/providers/Microsoft.Network/dnsZones/example.com/TXT/_acme-challenge",
+		func(rw http.ResponseWriter, req *http.Request) {
+			switch req.Method {
+			case http.MethodGet:
+				http.Error(rw, `{"error":{"code":"NotFound"}}`, http.StatusNotFound)
+			case http.MethodPut:
+				ifNoneMatch = append(ifNoneMatch, req.Header.Get("If-None-Match"))
+
+				// the record set is created concurrently, and still doesn't exist when read again.
+				http.Error(rw, `{"error":{"code":"PreconditionFailed"}}`, http.StatusPreconditionFailed)
+			default:
+				http.Error(rw, "unsupported method", http.StatusMethodNotAllowed)
+			}
+		})
+
+	config := NewDefaultConfig()
+	config.SubscriptionID = "sub"
+	config.ResourceGroup = "group"
+	config.ResourceManagerEndpoint = server.URL
+
+	provider := &DNSProvider{config: config, authorizer: autorest.NullAuthorizer{}}
+
+	err := provider.addTXTRecord(context.Background(), "example.com", "_acme-challenge", "value")
+	require.Error(t, err)
+
+	assert.Contains(t, err.Error(), "the record set has been modified concurrently 5 times")
+	assert.Equal(t, []string{"*", "*", "*", "*", "*"}, ifNoneMatch)
+}
+
+func writeRecordSet(rw http.ResponseWriter, etag string, values []string) {
+	var records []dns.TxtRecord
+	for _, value := range values {
+		records = append(records, dns.TxtRecord{Value: &[]string{value}})
+	}
+
+	rset := dns.RecordSet{
+		Name: to.StringPtr("_acme-challenge"),
+		Etag: to.StringPtr(etag),
+		RecordSetProperties: &dns.RecordSetProperties{
+			TTL:        to.Int64Ptr(60),
+			TxtRecords: &records,
+		},
+	}
+
+	_ = json.NewEncoder(rw).Encode(rset)
+}
+
+func Test_parseRecordMetadata(t *testing.T) {
+	testCases := []struct {
+		desc     string
+		raw      string
+		expected map[string]string
+		err      string
+	}{
+		{
+			desc: "empty",
+		},
+		{
+			desc:     "one value",
+			raw:      "owner:lego",
+			expected: map[string]string{"owner": "lego"},
+		},
+		{
+			desc:     "multiple values",
+			raw:      "owner:lego, purpose:acme:dns-01",
+			expected: map[string]string{"owner": "lego", "purpose": "acme:dns-01"},
+		},
+		{
+			desc: "missing value",
+			raw:  "owner",
+			err:  `invalid record metadata: "owner"`,
+		},
+	}
+
+	for _, test := range testCases {
+		t.Run(test.desc, func(t *testing.T) {
+			metadata, err := parseRecordMetadata(test.raw)
+			if test.err != "" {
+				require.EqualError(t, err, test.err)
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, test.expected, metadata)
+		})
+	}
+}
+
 func TestLivePresent(t *testing.T) {
 	if !envTest.IsLiveTest() {
 		t.Skip("skipping live test")
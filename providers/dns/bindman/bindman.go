@@ -9,6 +9,7 @@ import (
 
 	"github.com/go-acme/lego/v4/challenge/dns01"
 	"github.com/go-acme/lego/v4/platform/config/env"
+	"github.com/go-acme/lego/v4/providers/dns/internal/useragent"
 	"github.com/labbsr0x/bindman-dns-webhook/src/client"
 )
 
@@ -36,9 +37,9 @@ func NewDefaultConfig() *Config {
 	return &Config{
 		PropagationTimeout: env.GetOrDefaultSecond(EnvPropagationTimeout, dns01.DefaultPropagationTimeout),
 		PollingInterval:    env.GetOrDefaultSecond(EnvPollingInterval, dns01.DefaultPollingInterval),
-		HTTPClient: &http.Client{
+		HTTPClient: useragent.Wrap(&http.Client{
 			Timeout: env.GetOrDefaultSecond(EnvHTTPTimeout, time.Minute),
-		},
+		}),
 	}
 }
 
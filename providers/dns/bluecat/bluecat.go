@@ -12,6 +12,7 @@ import (
 
 	"github.com/go-acme/lego/v4/challenge/dns01"
 	"github.com/go-acme/lego/v4/platform/config/env"
+	"github.com/go-acme/lego/v4/providers/dns/internal/useragent"
 )
 
 const (
@@ -56,9 +57,9 @@ func NewDefaultConfig() *Config {
 		TTL:                env.GetOrDefaultInt(EnvTTL, dns01.DefaultTTL),
 		PropagationTimeout: env.GetOrDefaultSecond(EnvPropagationTimeout, dns01.DefaultPropagationTimeout),
 		PollingInterval:    env.GetOrDefaultSecond(EnvPollingInterval, dns01.DefaultPollingInterval),
-		HTTPClient: &http.Client{
+		HTTPClient: useragent.Wrap(&http.Client{
 			Timeout: env.GetOrDefaultSecond(EnvHTTPTimeout, 30*time.Second),
-		},
+		}),
 	}
 }
 
@@ -209,6 +210,13 @@ func (d *DNSProvider) CleanUp(domain, token, keyAuth string) error {
 	return d.logout()
 }
 
+// SelectView selects the DNS view of the TXT records, overriding the view of the configuration.
+// Implements dns01.ViewSelector.
+func (d *DNSProvider) SelectView(view string) error {
+	d.config.DNSView = view
+	return nil
+}
+
 // Timeout returns the timeout and interval to use when checking for DNS propagation.
 // Adjusting here to cope with spikes in propagation times.
 func (d *DNSProvider) Timeout() (timeout, interval time.Duration) {
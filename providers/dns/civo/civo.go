@@ -0,0 +1,214 @@
+// Package civo implements a DNS provider for solving the DNS-01 challenge using Civo.
+package civo
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/go-acme/lego/v4/challenge/dns01"
+	"github.com/go-acme/lego/v4/platform/config/env"
+	"github.com/go-acme/lego/v4/providers/dns/civo/internal"
+	"github.com/go-acme/lego/v4/providers/dns/internal/useragent"
+)
+
+// The minimum TTL accepted by the API.
+const minTTL = 600
+
+// Environment variables names.
+const (
+	envNamespace = "CIVO_"
+
+	EnvToken = envNamespace + "TOKEN"
+
+	EnvTTL                = envNamespace + "TTL"
+	EnvPropagationTimeout = envNamespace + "PROPAGATION_TIMEOUT"
+	EnvPollingInterval    = envNamespace + "POLLING_INTERVAL"
+	EnvHTTPTimeout        = envNamespace + "HTTP_TIMEOUT"
+)
+
+// Config is used to configure the creation of the DNSProvider.
+type Config struct {
+	Token              string
+	PropagationTimeout time.Duration
+	PollingInterval    time.Duration
+	TTL                int
+	HTTPClient         *http.Client
+}
+
+// NewDefaultConfig returns a default configuration for the DNSProvider.
+func NewDefaultConfig() *Config {
+	return &Config{
+		TTL:                env.GetOrDefaultInt(EnvTTL, minTTL),
+		PropagationTimeout: env.GetOrDefaultSecond(EnvPropagationTimeout, dns01.DefaultPropagationTimeout),
+		PollingInterval:    env.GetOrDefaultSecond(EnvPollingInterval, dns01.DefaultPollingInterval),
+		HTTPClient: useragent.Wrap(&http.Client{
+			Timeout: env.GetOrDefaultSecond(EnvHTTPTimeout, 30*time.Second),
+		}),
+	}
+}
+
+// DNSProvider implements the challenge.Provider interface.
+type DNSProvider struct {
+	config *Config
+	client *internal.Client
+
+	records   map[string]txtRecord
+	recordsMu sync.Mutex
+}
+
+type txtRecord struct {
+	domainID string
+	id       string
+}
+
+// NewDNSProvider returns a DNSProvider instance configured for Civo.
+// Credentials must be passed in the environment variable: CIVO_TOKEN.
+func NewDNSProvider() (*DNSProvider, error) {
+	values, err := env.Get(EnvToken)
+	if err != nil {
+		return nil, fmt.Errorf("civo: %w", err)
+	}
+
+	config := NewDefaultConfig()
+	config.Token = values[EnvToken]
+
+	return NewDNSProviderConfig(config)
+}
+
+// NewDNSProviderConfig return a DNSProvider instance configured for Civo.
+func NewDNSProviderConfig(config *Config) (*DNSProvider, error) {
+	if config == nil {
+		return nil, errors.New("civo: the configuration of the DNS provider is nil")
+	}
+
+	if config.Token == "" {
+		return nil, errors.New("civo: missing token")
+	}
+
+	if config.TTL < minTTL {
+		return nil, fmt.Errorf("civo: invalid TTL, TTL (%d) must be greater than %d", config.TTL, minTTL)
+	}
+
+	client := internal.NewClient(config.Token)
+
+	if config.HTTPClient != nil {
+		client.HTTPClient = config.HTTPClient
+	}
+
+	return &DNSProvider{
+		config:  config,
+		client:  client,
+		records: make(map[string]txtRecord),
+	}, nil
+}
+
+// Timeout returns the timeout and interval to use when checking for DNS propagation.
+// Adjusting here to cope with spikes in propagation times.
+func (d *DNSProvider) Timeout() (timeout, interval time.Duration) {
+	return d.config.PropagationTimeout, d.config.PollingInterval
+}
+
+// Present creates a TXT record to fulfill the dns-01 challenge.
+func (d *DNSProvider) Present(domain, token, keyAuth string) error {
+	fqdn, value := dns01.GetRecord(domain, keyAuth)
+
+	zone, err := d.findDomain(fqdn)
+	if err != nil {
+		return fmt.Errorf("civo: %w", err)
+	}
+
+	record := internal.DNSRecord{
+		Name:  extractRecordName(fqdn, zone.Name),
+		Value: value,
+		Type:  "TXT",
+		TTL:   d.config.TTL,
+	}
+
+	resp, err := d.client.CreateRecord(zone.ID, record)
+	if err != nil {
+		return fmt.Errorf("civo: failed to create TXT record: fqdn=%s, domain=%s: %w", fqdn, zone.Name, err)
+	}
+
+	d.recordsMu.Lock()
+	d.records[token] = txtRecord{domainID: zone.ID, id: resp.ID}
+	d.recordsMu.Unlock()
+
+	return nil
+}
+
+// CleanUp removes the TXT record matching the specified parameters.
+func (d *DNSProvider) CleanUp(domain, token, keyAuth string) error {
+	fqdn, _ := dns01.GetRecord(domain, keyAuth)
+
+	// gets the record's unique ID from when we created it
+	d.recordsMu.Lock()
+	record, ok := d.records[token]
+	d.recordsMu.Unlock()
+	if !ok {
+		return fmt.Errorf("civo: unknown record ID for '%s'", fqdn)
+	}
+
+	err := d.client.DeleteRecord(record.domainID, record.id)
+	if err != nil {
+		return fmt.Errorf("civo: failed to delete TXT record: fqdn=%s, recordID=%s: %w", fqdn, record.id, err)
+	}
+
+	// deletes record ID from map
+	d.recordsMu.Lock()
+	delete(d.records, token)
+	d.recordsMu.Unlock()
+
+	return nil
+}
+
+// Zones returns the domains of the account.
+func (d *DNSProvider) Zones() ([]string, error) {
+	domains, err := d.client.ListDomains()
+	if err != nil {
+		return nil, fmt.Errorf("civo: failed to get domains: %w", err)
+	}
+
+	var zones []string
+	for _, domain := range domains {
+		zones = append(zones, domain.Name)
+	}
+
+	return zones, nil
+}
+
+// findDomain finds the most specific domain of the account containing the fqdn.
+func (d *DNSProvider) findDomain(fqdn string) (*internal.Domain, error) {
+	domains, err := d.client.ListDomains()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get domains: %w", err)
+	}
+
+	var names []string
+	for _, domain := range domains {
+		names = append(names, domain.Name)
+	}
+
+	name := dns01.FindMostSpecificZone(fqdn, names)
+
+	for _, domain := range domains {
+		if name != "" && domain.Name == name {
+			return &domain, nil
+		}
+	}
+
+	return nil, fmt.Errorf("no domain found for %s", dns01.UnFqdn(fqdn))
+}
+
+// extractRecordName returns the name of the record relatively to the domain.
+func extractRecordName(fqdn, domain string) string {
+	name := dns01.UnFqdn(fqdn)
+	if strings.EqualFold(name, domain) {
+		return ""
+	}
+
+	return strings.TrimSuffix(name, "."+domain)
+}
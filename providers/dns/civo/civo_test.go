@@ -0,0 +1,227 @@
+package civo
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/go-acme/lego/v4/platform/tester"
+	"github.com/go-acme/lego/v4/providers/dns/civo/internal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const envDomain = envNamespace + "DOMAIN"
+
+var envTest = tester.NewEnvTest(EnvToken).WithDomain(envDomain)
+
+func TestNewDNSProvider(t *testing.T) {
+	testCases := []struct {
+		desc     string
+		envVars  map[string]string
+		expected string
+	}{
+		{
+			desc: "success",
+			envVars: map[string]string{
+				EnvToken: "123",
+			},
+		},
+		{
+			desc: "missing credentials",
+			envVars: map[string]string{
+				EnvToken: "",
+			},
+			expected: "civo: some credentials information are missing: CIVO_TOKEN",
+		},
+	}
+
+	for _, test := range testCases {
+		t.Run(test.desc, func(t *testing.T) {
+			defer envTest.RestoreEnv()
+			envTest.ClearEnv()
+
+			envTest.Apply(test.envVars)
+
+			p, err := NewDNSProvider()
+
+			if len(test.expected) == 0 {
+				require.NoError(t, err)
+				require.NotNil(t, p)
+				require.NotNil(t, p.config)
+				require.NotNil(t, p.client)
+			} else {
+				require.EqualError(t, err, test.expected)
+			}
+		})
+	}
+}
+
+func TestNewDNSProviderConfig(t *testing.T) {
+	testCases := []struct {
+		desc     string
+		token    string
+		ttl      int
+		expected string
+	}{
+		{
+			desc:  "success",
+			token: "123",
+			ttl:   minTTL,
+		},
+		{
+			desc:     "missing credentials",
+			ttl:      minTTL,
+			expected: "civo: missing token",
+		},
+		{
+			desc:     "invalid TTL",
+			token:    "123",
+			ttl:      120,
+			expected: "civo: invalid TTL, TTL (120) must be greater than 600",
+		},
+	}
+
+	for _, test := range testCases {
+		t.Run(test.desc, func(t *testing.T) {
+			config := NewDefaultConfig()
+			config.Token = test.token
+			config.TTL = test.ttl
+
+			p, err := NewDNSProviderConfig(config)
+
+			if len(test.expected) == 0 {
+				require.NoError(t, err)
+				require.NotNil(t, p)
+				require.NotNil(t, p.config)
+				require.NotNil(t, p.client)
+			} else {
+				require.EqualError(t, err, test.expected)
+			}
+		})
+	}
+}
+
+func setupTest(t *testing.T) (*DNSProvider, *http.ServeMux) {
+	t.Helper()
+
+	mux := http.NewServeMux()
+	server := httptest.NewServer(mux)
+	t.Cleanup(server.Close)
+
+	mux.HandleFunc("/dns", func(rw http.ResponseWriter, req *http.Request) {
+		_, _ = fmt.Fprint(rw, `[{"id":"domainA","name":"example.com"},{"id":"domainB","name":"sub.example.com"}]`)
+	})
+
+	config := NewDefaultConfig()
+	config.Token = "secret"
+	config.TTL = 3600
+
+	provider, err := NewDNSProviderConfig(config)
+	require.NoError(t, err)
+
+	provider.client.BaseURL = server.URL
+
+	return provider, mux
+}
+
+func TestDNSProvider_Present(t *testing.T) {
+	provider, mux := setupTest(t)
+
+	mux.HandleFunc("/dns/domainB/records", func(rw http.ResponseWriter, req *http.Request) {
+		if req.Method != http.MethodPost {
+			http.Error(rw, fmt.Sprintf("unsupported method: %s", req.Method), http.StatusMethodNotAllowed)
+			return
+		}
+
+		record := internal.DNSRecord{}
+		err := json.NewDecoder(req.Body).Decode(&record)
+		if err != nil {
+			http.Error(rw, err.Error(), http.StatusBadRequest)
+			return
+		}
+
+		expected := internal.DNSRecord{
+			Name:  "_acme-challenge.www",
+			Value: "ADw2sEd82DUgXcQ9hNBZThJs7zVJkR5v9JeSbAb9mZY",
+			Type:  "TXT",
+			TTL:   3600,
+		}
+		assert.Equal(t, expected, record)
+
+		_, _ = fmt.Fprint(rw, `{"id":"recordA","domain_id":"domainB","name":"_acme-challenge.www","type":"TXT","ttl":3600}`)
+	})
+
+	err := provider.Present("www.sub.example.com", "token", "123d==")
+	require.NoError(t, err)
+
+	assert.Equal(t, map[string]txtRecord{"token": {domainID: "domainB", id: "recordA"}}, provider.records)
+}
+
+func TestDNSProvider_Present_unknownDomain(t *testing.T) {
+	provider, _ := setupTest(t)
+
+	err := provider.Present("www.example.org", "token", "123d==")
+	require.EqualError(t, err, "civo: no domain found for _acme-challenge.www.example.org")
+}
+
+func TestDNSProvider_CleanUp(t *testing.T) {
+	provider, mux := setupTest(t)
+
+	var deleted bool
+	mux.HandleFunc("/dns/domainA/records/recordA", func(rw http.ResponseWriter, req *http.Request) {
+		if req.Method != http.MethodDelete {
+			http.Error(rw, fmt.Sprintf("unsupported method: %s", req.Method), http.StatusMethodNotAllowed)
+			return
+		}
+
+		deleted = true
+
+		_, _ = fmt.Fprint(rw, `{"result":"success"}`)
+	})
+
+	provider.records["token"] = txtRecord{domainID: "domainA", id: "recordA"}
+
+	err := provider.CleanUp("www.example.com", "token", "123d==")
+	require.NoError(t, err)
+
+	assert.True(t, deleted)
+	assert.Empty(t, provider.records)
+}
+
+func TestDNSProvider_CleanUp_unknownRecord(t *testing.T) {
+	provider, _ := setupTest(t)
+
+	err := provider.CleanUp("www.example.com", "token", "123d==")
+	require.EqualError(t, err, "civo: unknown record ID for '_acme-challenge.www.example.com.'")
+}
+
+func TestDNSProvider_Zones(t *testing.T) {
+	provider, _ := setupTest(t)
+
+	zones, err := provider.Zones()
+	require.NoError(t, err)
+
+	assert.Equal(t, []string{"example.com", "sub.example.com"}, zones)
+}
+
+func TestLivePresentAndCleanUp(t *testing.T) {
+	if !envTest.IsLiveTest() {
+		t.Skip("skipping live test")
+	}
+
+	envTest.RestoreEnv()
+	provider, err := NewDNSProvider()
+	require.NoError(t, err)
+
+	err = provider.Present(envTest.GetDomain(), "", "123d==")
+	require.NoError(t, err)
+
+	time.Sleep(1 * time.Second)
+
+	err = provider.CleanUp(envTest.GetDomain(), "", "123d==")
+	require.NoError(t, err)
+}
@@ -0,0 +1,139 @@
+package internal
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"path"
+)
+
+const defaultBaseURL = "https://api.civo.com/v2"
+
+// Client the Civo DNS API client.
+type Client struct {
+	HTTPClient *http.Client
+	BaseURL    string
+
+	token string
+}
+
+// NewClient creates a new Client.
+func NewClient(token string) *Client {
+	return &Client{
+		HTTPClient: http.DefaultClient,
+		BaseURL:    defaultBaseURL,
+		token:      token,
+	}
+}
+
+// ListDomains gets the domains of the account.
+func (c *Client) ListDomains() ([]Domain, error) {
+	var domains []Domain
+	err := c.do(http.MethodGet, nil, &domains, "dns")
+	if err != nil {
+		return nil, err
+	}
+
+	return domains, nil
+}
+
+// ListRecords gets the DNS records of a domain.
+func (c *Client) ListRecords(domainID string) ([]DNSRecord, error) {
+	var records []DNSRecord
+	err := c.do(http.MethodGet, nil, &records, "dns", domainID, "records")
+	if err != nil {
+		return nil, err
+	}
+
+	return records, nil
+}
+
+// CreateRecord creates a DNS record.
+func (c *Client) CreateRecord(domainID string, record DNSRecord) (*DNSRecord, error) {
+	body, err := json.Marshal(record)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal request body: %w", err)
+	}
+
+	var result DNSRecord
+	err = c.do(http.MethodPost, bytes.NewReader(body), &result, "dns", domainID, "records")
+	if err != nil {
+		return nil, err
+	}
+
+	return &result, nil
+}
+
+// DeleteRecord deletes a DNS record.
+func (c *Client) DeleteRecord(domainID, recordID string) error {
+	return c.do(http.MethodDelete, nil, nil, "dns", domainID, "records", recordID)
+}
+
+func (c *Client) do(method string, body io.Reader, result interface{}, parts ...string) error {
+	endpoint, err := c.createEndpoint(parts...)
+	if err != nil {
+		return fmt.Errorf("failed to parse endpoint: %w", err)
+	}
+
+	req, err := http.NewRequest(method, endpoint, body)
+	if err != nil {
+		return fmt.Errorf("failed to create request: %w", err)
+	}
+
+	req.Header.Set("Accept", "application/json")
+	req.Header.Set("Authorization", fmt.Sprintf("bearer %s", c.token))
+
+	if body != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+
+	resp, err := c.HTTPClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("API call failed: %w", err)
+	}
+
+	defer func() { _ = resp.Body.Close() }()
+
+	raw, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return fmt.Errorf("failed to read response body: %w", err)
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		var apiErr APIError
+		if json.Unmarshal(raw, &apiErr) == nil && apiErr.Code != "" {
+			return fmt.Errorf("invalid status code: %s: %w", resp.Status, apiErr)
+		}
+
+		return fmt.Errorf("invalid status code: %s: %s", resp.Status, string(raw))
+	}
+
+	if result == nil {
+		return nil
+	}
+
+	err = json.Unmarshal(raw, result)
+	if err != nil {
+		return fmt.Errorf("failed to unmarshal response body: %w", err)
+	}
+
+	return nil
+}
+
+func (c *Client) createEndpoint(parts ...string) (string, error) {
+	base, err := url.Parse(c.BaseURL)
+	if err != nil {
+		return "", fmt.Errorf("failed to parse base URL: %w", err)
+	}
+
+	endpoint, err := base.Parse(path.Join(base.Path, path.Join(parts...)))
+	if err != nil {
+		return "", fmt.Errorf("failed to parse endpoint path: %w", err)
+	}
+
+	return endpoint.String(), nil
+}
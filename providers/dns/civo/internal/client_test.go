@@ -0,0 +1,146 @@
+package internal
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func setupTest(t *testing.T, pattern, method, filename string, status int) *Client {
+	t.Helper()
+
+	mux := http.NewServeMux()
+	server := httptest.NewServer(mux)
+	t.Cleanup(server.Close)
+
+	mux.HandleFunc(pattern, func(rw http.ResponseWriter, req *http.Request) {
+		if req.Method != method {
+			http.Error(rw, fmt.Sprintf("unsupported method: %s", req.Method), http.StatusMethodNotAllowed)
+			return
+		}
+
+		auth := req.Header.Get("Authorization")
+		if auth != "bearer secret" {
+			http.Error(rw, fmt.Sprintf("invalid token: %s", auth), http.StatusUnauthorized)
+			return
+		}
+
+		if filename == "" {
+			rw.WriteHeader(status)
+			return
+		}
+
+		file, err := os.Open(filename)
+		if err != nil {
+			http.Error(rw, err.Error(), http.StatusInternalServerError)
+			return
+		}
+		defer func() { _ = file.Close() }()
+
+		rw.WriteHeader(status)
+		_, _ = io.Copy(rw, file)
+	})
+
+	client := NewClient("secret")
+	client.BaseURL = server.URL
+
+	return client
+}
+
+func TestClient_ListDomains(t *testing.T) {
+	client := setupTest(t, "/dns", http.MethodGet, "./fixtures/list_domains.json", http.StatusOK)
+
+	domains, err := client.ListDomains()
+	require.NoError(t, err)
+
+	expected := []Domain{
+		{ID: "7088fcea-7658-43e6-97fa-273f901978fd", AccountID: "e7b9a5c4-3f1a-4d9b-8a5e-0c2b1d4f6a8e", Name: "example.com"},
+		{ID: "3c9f1d2e-5b7a-4c8d-9e0f-1a2b3c4d5e6f", AccountID: "e7b9a5c4-3f1a-4d9b-8a5e-0c2b1d4f6a8e", Name: "sub.example.com"},
+	}
+
+	assert.Equal(t, expected, domains)
+}
+
+func TestClient_ListRecords(t *testing.T) {
+	client := setupTest(t, "/dns/domainA/records", http.MethodGet, "./fixtures/list_records.json", http.StatusOK)
+
+	records, err := client.ListRecords("domainA")
+	require.NoError(t, err)
+
+	expected := []DNSRecord{
+		{
+			ID:       "76cc107f-fbef-4e2b-b97f-f5d34f4075d3",
+			DomainID: "7088fcea-7658-43e6-97fa-273f901978fd",
+			Name:     "www",
+			Value:    "10.0.0.1",
+			Type:     "A",
+			TTL:      600,
+		},
+		{
+			ID:       "a2b3c4d5-e6f7-4a8b-9c0d-1e2f3a4b5c6d",
+			DomainID: "7088fcea-7658-43e6-97fa-273f901978fd",
+			Name:     "_acme-challenge",
+			Value:    "txtxtxtxtxtxt",
+			Type:     "TXT",
+			TTL:      600,
+		},
+	}
+
+	assert.Equal(t, expected, records)
+}
+
+func TestClient_CreateRecord(t *testing.T) {
+	client := setupTest(t, "/dns/domainA/records", http.MethodPost, "./fixtures/create_record.json", http.StatusOK)
+
+	record := DNSRecord{
+		Name:  "_acme-challenge",
+		Value: "txtxtxtxtxtxt",
+		Type:  "TXT",
+		TTL:   600,
+	}
+
+	result, err := client.CreateRecord("domainA", record)
+	require.NoError(t, err)
+
+	expected := &DNSRecord{
+		ID:       "a2b3c4d5-e6f7-4a8b-9c0d-1e2f3a4b5c6d",
+		DomainID: "7088fcea-7658-43e6-97fa-273f901978fd",
+		Name:     "_acme-challenge",
+		Value:    "txtxtxtxtxtxt",
+		Type:     "TXT",
+		TTL:      600,
+	}
+
+	assert.Equal(t, expected, result)
+}
+
+func TestClient_DeleteRecord(t *testing.T) {
+	client := setupTest(t, "/dns/domainA/records/recordA", http.MethodDelete, "", http.StatusOK)
+
+	err := client.DeleteRecord("domainA", "recordA")
+	require.NoError(t, err)
+}
+
+func TestClient_DeleteRecord_error(t *testing.T) {
+	mux := http.NewServeMux()
+	server := httptest.NewServer(mux)
+	t.Cleanup(server.Close)
+
+	mux.HandleFunc("/dns/domainA/records/recordA", func(rw http.ResponseWriter, req *http.Request) {
+		rw.WriteHeader(http.StatusNotFound)
+		_ = json.NewEncoder(rw).Encode(APIError{Code: "database_dns_record_not_found", Reason: "Failed to find the DNS record"})
+	})
+
+	client := NewClient("secret")
+	client.BaseURL = server.URL
+
+	err := client.DeleteRecord("domainA", "recordA")
+	require.EqualError(t, err, "invalid status code: 404 Not Found: database_dns_record_not_found: Failed to find the DNS record")
+}
@@ -0,0 +1,31 @@
+package internal
+
+import "fmt"
+
+// Domain a domain (DNS zone) of the account.
+type Domain struct {
+	ID        string `json:"id,omitempty"`
+	AccountID string `json:"account_id,omitempty"`
+	Name      string `json:"name,omitempty"`
+}
+
+// DNSRecord DNS record representation.
+type DNSRecord struct {
+	ID       string `json:"id,omitempty"`
+	DomainID string `json:"domain_id,omitempty"`
+	Name     string `json:"name,omitempty"`
+	Value    string `json:"value,omitempty"`
+	Type     string `json:"type,omitempty"`
+	Priority int    `json:"priority,omitempty"`
+	TTL      int    `json:"ttl,omitempty"`
+}
+
+// APIError an error returned by the API.
+type APIError struct {
+	Code   string `json:"code"`
+	Reason string `json:"reason"`
+}
+
+func (a APIError) Error() string {
+	return fmt.Sprintf("%s: %s", a.Code, a.Reason)
+}
@@ -5,6 +5,7 @@ import (
 	"errors"
 	"fmt"
 	"net/http"
+	"strings"
 	"sync"
 	"time"
 
@@ -12,6 +13,8 @@ import (
 	"github.com/go-acme/lego/v4/challenge/dns01"
 	"github.com/go-acme/lego/v4/log"
 	"github.com/go-acme/lego/v4/platform/config/env"
+	"github.com/go-acme/lego/v4/providers/dns/internal/useragent"
+	"github.com/miekg/dns"
 )
 
 const (
@@ -38,9 +41,9 @@ func NewDefaultConfig() *Config {
 		TTL:                env.GetOrDefaultInt("CLOUDFLARE_TTL", minTTL),
 		PropagationTimeout: env.GetOrDefaultSecond("CLOUDFLARE_PROPAGATION_TIMEOUT", 2*time.Minute),
 		PollingInterval:    env.GetOrDefaultSecond("CLOUDFLARE_POLLING_INTERVAL", 2*time.Second),
-		HTTPClient: &http.Client{
+		HTTPClient: useragent.Wrap(&http.Client{
 			Timeout: env.GetOrDefaultSecond("CLOUDFLARE_HTTP_TIMEOUT", 30*time.Second),
-		},
+		}),
 	}
 }
 
@@ -49,6 +52,11 @@ type DNSProvider struct {
 	client *metaClient
 	config *Config
 
+	// findZoneByFqdn determines the DNS zone of an fqdn. It is overridden during tests.
+	findZoneByFqdn func(fqdn string) (string, error)
+	// followCNAME returns the target of the CNAME of an fqdn. It is overridden during tests.
+	followCNAME func(fqdn string) string
+
 	recordIDs   map[string]string
 	recordIDsMu sync.Mutex
 }
@@ -106,9 +114,11 @@ func NewDNSProviderConfig(config *Config) (*DNSProvider, error) {
 	}
 
 	return &DNSProvider{
-		client:    client,
-		config:    config,
-		recordIDs: make(map[string]string),
+		client:         client,
+		config:         config,
+		findZoneByFqdn: dns01.FindZoneByFqdn,
+		followCNAME:    dns01.FollowCNAME,
+		recordIDs:      make(map[string]string),
 	}, nil
 }
 
@@ -122,16 +132,11 @@ func (d *DNSProvider) Timeout() (timeout, interval time.Duration) {
 func (d *DNSProvider) Present(domain, token, keyAuth string) error {
 	fqdn, value := dns01.GetRecord(domain, keyAuth)
 
-	authZone, err := dns01.FindZoneByFqdn(fqdn)
+	fqdn, zoneID, err := d.findZone(fqdn)
 	if err != nil {
 		return fmt.Errorf("cloudflare: %w", err)
 	}
 
-	zoneID, err := d.client.ZoneIDByName(authZone)
-	if err != nil {
-		return fmt.Errorf("cloudflare: failed to find zone %s: %w", authZone, err)
-	}
-
 	dnsRecord := cloudflare.DNSRecord{
 		Type:    "TXT",
 		Name:    dns01.UnFqdn(fqdn),
@@ -161,16 +166,11 @@ func (d *DNSProvider) Present(domain, token, keyAuth string) error {
 func (d *DNSProvider) CleanUp(domain, token, keyAuth string) error {
 	fqdn, _ := dns01.GetRecord(domain, keyAuth)
 
-	authZone, err := dns01.FindZoneByFqdn(fqdn)
+	fqdn, zoneID, err := d.findZone(fqdn)
 	if err != nil {
 		return fmt.Errorf("cloudflare: %w", err)
 	}
 
-	zoneID, err := d.client.ZoneIDByName(authZone)
-	if err != nil {
-		return fmt.Errorf("cloudflare: failed to find zone %s: %w", authZone, err)
-	}
-
 	// get the record's unique ID from when we created it
 	d.recordIDsMu.Lock()
 	recordID, ok := d.recordIDs[token]
@@ -191,3 +191,65 @@ func (d *DNSProvider) CleanUp(domain, token, keyAuth string) error {
 
 	return nil
 }
+
+// findZone returns the FQDN of the TXT record and the ID of its zone.
+// When the zone of the FQDN is not managed by the account, the CNAME of the FQDN is followed,
+// the challenge can be delegated to a zone of the account (ex: _acme-challenge.example.com CNAME example.com.acme.example.net).
+func (d *DNSProvider) findZone(fqdn string) (string, string, error) {
+	authZone, err := d.findZoneByFqdn(fqdn)
+	if err != nil {
+		return "", "", err
+	}
+
+	zoneID, err := d.client.ZoneIDByName(authZone)
+	if err == nil {
+		return fqdn, zoneID, nil
+	}
+
+	if zone, id, ok := d.findZoneBelow(fqdn, authZone); ok {
+		log.Infof("cloudflare: the zone of %s is %s (found through DNS: %s)", fqdn, zone, authZone)
+		return fqdn, id, nil
+	}
+
+	target := d.followCNAME(fqdn)
+	if target == fqdn {
+		return "", "", fmt.Errorf("failed to find zone %s: %w", authZone, err)
+	}
+
+	targetZone, err := d.findZoneByFqdn(target)
+	if err != nil {
+		return "", "", fmt.Errorf("failed to find the zone of the CNAME target %s: %w", target, err)
+	}
+
+	zoneID, err = d.client.ZoneIDByName(targetZone)
+	if err != nil {
+		return "", "", fmt.Errorf("failed to find zone %s (CNAME target %s): %w", targetZone, target, err)
+	}
+
+	log.Infof("cloudflare: %s is a CNAME to %s, using the zone %s", fqdn, target, targetZone)
+
+	return target, zoneID, nil
+}
+
+// findZoneBelow looks for a zone of the account containing the FQDN, below the zone found through DNS (the most specific first).
+// The zone found through DNS can be a parent of the actual zone when the SOA lookup skips a CNAME at the apex
+// (ex: the flattened apex example.com CNAME example.herokudns.com gives the zone com.).
+func (d *DNSProvider) findZoneBelow(fqdn, authZone string) (string, string, bool) {
+	indexes := dns.Split(fqdn)
+
+	for i, index := range indexes {
+		name := fqdn[index:]
+
+		// the TLD is never a zone of the account.
+		if i == len(indexes)-1 || strings.EqualFold(name, authZone) {
+			break
+		}
+
+		zoneID, err := d.client.ZoneIDByName(name)
+		if err == nil {
+			return name, zoneID, true
+		}
+	}
+
+	return "", "", false
+}
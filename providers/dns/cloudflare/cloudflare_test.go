@@ -1,6 +1,10 @@
 package cloudflare
 
 import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
 	"testing"
 	"time"
 
@@ -265,6 +269,213 @@ func TestNewDNSProviderConfig(t *testing.T) {
 	}
 }
 
+// setupTest creates a provider using a fake API managing the zones "acme.othersite.net" and "example.net" only.
+// The zones and the CNAME records are resolved with the tables.
+func setupTest(t *testing.T, zones map[string]string, cnames map[string]string) (*DNSProvider, *http.ServeMux) {
+	t.Helper()
+
+	mux := http.NewServeMux()
+	server := httptest.NewServer(mux)
+	t.Cleanup(server.Close)
+
+	mux.HandleFunc("/zones", func(rw http.ResponseWriter, req *http.Request) {
+		result := "[]"
+		switch req.URL.Query().Get("name") {
+		case "acme.othersite.net":
+			result = `[{"id":"zone-id","name":"acme.othersite.net"}]`
+		case "example.net":
+			result = `[{"id":"apex-zone-id","name":"example.net"}]`
+		}
+
+		_, _ = fmt.Fprintf(rw, `{"success":true,"errors":[],"messages":[],"result":%s}`, result)
+	})
+
+	config := NewDefaultConfig()
+	config.AuthToken = "secret"
+
+	provider, err := NewDNSProviderConfig(config)
+	require.NoError(t, err)
+
+	provider.client.clientEdit.BaseURL = server.URL
+	provider.client.clientRead.BaseURL = server.URL
+
+	provider.findZoneByFqdn = func(fqdn string) (string, error) {
+		zone, ok := zones[fqdn]
+		if !ok {
+			return "", fmt.Errorf("no zone for %s", fqdn)
+		}
+		return zone, nil
+	}
+
+	provider.followCNAME = func(fqdn string) string {
+		if target, ok := cnames[fqdn]; ok {
+			return target
+		}
+		return fqdn
+	}
+
+	return provider, mux
+}
+
+func TestDNSProvider_findZone(t *testing.T) {
+	testCases := []struct {
+		desc         string
+		fqdn         string
+		zones        map[string]string
+		cnames       map[string]string
+		expectedFqdn string
+		expectedID   string
+		expected     string
+	}{
+		{
+			desc:         "managed zone",
+			fqdn:         "_acme-challenge.example.com.acme.othersite.net.",
+			zones:        map[string]string{"_acme-challenge.example.com.acme.othersite.net.": "acme.othersite.net."},
+			expectedFqdn: "_acme-challenge.example.com.acme.othersite.net.",
+			expectedID:   "zone-id",
+		},
+		{
+			desc: "CNAME to a managed zone",
+			fqdn: "_acme-challenge.example.com.",
+			zones: map[string]string{
+				"_acme-challenge.example.com.":    "example.com.",
+				"example.com.acme.othersite.net.": "acme.othersite.net.",
+			},
+			cnames:       map[string]string{"_acme-challenge.example.com.": "example.com.acme.othersite.net."},
+			expectedFqdn: "example.com.acme.othersite.net.",
+			expectedID:   "zone-id",
+		},
+		{
+			// the SOA lookup skips the CNAME at the apex: the zone found through DNS is the TLD.
+			desc:         "flattened apex",
+			fqdn:         "_acme-challenge.example.net.",
+			zones:        map[string]string{"_acme-challenge.example.net.": "net."},
+			expectedFqdn: "_acme-challenge.example.net.",
+			expectedID:   "apex-zone-id",
+		},
+		{
+			desc:         "subdomain of a flattened apex",
+			fqdn:         "_acme-challenge.www.example.net.",
+			zones:        map[string]string{"_acme-challenge.www.example.net.": "net."},
+			expectedFqdn: "_acme-challenge.www.example.net.",
+			expectedID:   "apex-zone-id",
+		},
+		{
+			desc:         "flattened apex found through DNS",
+			fqdn:         "_acme-challenge.example.net.",
+			zones:        map[string]string{"_acme-challenge.example.net.": "example.net."},
+			expectedFqdn: "_acme-challenge.example.net.",
+			expectedID:   "apex-zone-id",
+		},
+		{
+			// the zones above the zone found through DNS are not used (ex: a subdomain delegated to another provider).
+			desc:     "unmanaged subzone of a managed zone",
+			fqdn:     "_acme-challenge.sub.example.net.",
+			zones:    map[string]string{"_acme-challenge.sub.example.net.": "sub.example.net."},
+			expected: "failed to find zone sub.example.net.: Zone could not be found",
+		},
+		{
+			desc:     "unmanaged zone without CNAME",
+			fqdn:     "_acme-challenge.example.com.",
+			zones:    map[string]string{"_acme-challenge.example.com.": "example.com."},
+			expected: "failed to find zone example.com.: Zone could not be found",
+		},
+		{
+			desc: "CNAME to an unmanaged zone",
+			fqdn: "_acme-challenge.example.com.",
+			zones: map[string]string{
+				"_acme-challenge.example.com.":  "example.com.",
+				"example.com.acme.example.org.": "example.org.",
+			},
+			cnames:   map[string]string{"_acme-challenge.example.com.": "example.com.acme.example.org."},
+			expected: "failed to find zone example.org. (CNAME target example.com.acme.example.org.): Zone could not be found",
+		},
+	}
+
+	for _, test := range testCases {
+		t.Run(test.desc, func(t *testing.T) {
+			provider, _ := setupTest(t, test.zones, test.cnames)
+
+			fqdn, zoneID, err := provider.findZone(test.fqdn)
+			if test.expected != "" {
+				require.EqualError(t, err, test.expected)
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, test.expectedFqdn, fqdn)
+			assert.Equal(t, test.expectedID, zoneID)
+		})
+	}
+}
+
+func TestDNSProvider_Present_delegatedZone(t *testing.T) {
+	provider, mux := setupTest(t,
+		map[string]string{
+			"_acme-challenge.example.com.":    "example.com.",
+			"example.com.acme.othersite.net.": "acme.othersite.net.",
+		},
+		map[string]string{"_acme-challenge.example.com.": "example.com.acme.othersite.net."},
+	)
+
+	var created []string
+	mux.HandleFunc("/zones/zone-id/dns_records", func(rw http.ResponseWriter, req *http.Request) {
+		var record struct {
+			Name string `json:"name"`
+		}
+		err := json.NewDecoder(req.Body).Decode(&record)
+		if err != nil {
+			http.Error(rw, err.Error(), http.StatusBadRequest)
+			return
+		}
+
+		created = append(created, record.Name)
+
+		_, _ = fmt.Fprint(rw, `{"success":true,"errors":[],"messages":[],"result":{"id":"record-id"}}`)
+	})
+
+	var deleted int
+	mux.HandleFunc("/zones/zone-id/dns_records/record-id", func(rw http.ResponseWriter, req *http.Request) {
+		deleted++
+		_, _ = fmt.Fprint(rw, `{"success":true,"errors":[],"messages":[],"result":{"id":"record-id"}}`)
+	})
+
+	err := provider.Present("example.com", "token", "123d==")
+	require.NoError(t, err)
+
+	assert.Equal(t, []string{"example.com.acme.othersite.net"}, created)
+
+	err = provider.CleanUp("example.com", "token", "123d==")
+	require.NoError(t, err)
+
+	assert.Equal(t, 1, deleted)
+}
+
+func TestDNSProvider_Present_flattenedApex(t *testing.T) {
+	provider, mux := setupTest(t, map[string]string{"_acme-challenge.example.net.": "net."}, nil)
+
+	var created []string
+	mux.HandleFunc("/zones/apex-zone-id/dns_records", func(rw http.ResponseWriter, req *http.Request) {
+		var record struct {
+			Name string `json:"name"`
+		}
+		err := json.NewDecoder(req.Body).Decode(&record)
+		if err != nil {
+			http.Error(rw, err.Error(), http.StatusBadRequest)
+			return
+		}
+
+		created = append(created, record.Name)
+
+		_, _ = fmt.Fprint(rw, `{"success":true,"errors":[],"messages":[],"result":{"id":"record-id"}}`)
+	})
+
+	err := provider.Present("example.net", "token", "123d==")
+	require.NoError(t, err)
+
+	assert.Equal(t, []string{"_acme-challenge.example.net"}, created)
+}
+
 func TestLivePresent(t *testing.T) {
 	if !envTest.IsLiveTest() {
 		t.Skip("skipping live test")
@@ -5,11 +5,13 @@ import (
 	"errors"
 	"fmt"
 	"net/http"
+	"sync"
 	"time"
 
 	"github.com/go-acme/lego/v4/challenge/dns01"
 	"github.com/go-acme/lego/v4/platform/config/env"
 	"github.com/go-acme/lego/v4/providers/dns/cloudns/internal"
+	"github.com/go-acme/lego/v4/providers/dns/internal/useragent"
 )
 
 // Environment variables names.
@@ -43,9 +45,9 @@ func NewDefaultConfig() *Config {
 		TTL:                env.GetOrDefaultInt(EnvTTL, 60),
 		PropagationTimeout: env.GetOrDefaultSecond(EnvPropagationTimeout, 120*time.Second),
 		PollingInterval:    env.GetOrDefaultSecond(EnvPollingInterval, 4*time.Second),
-		HTTPClient: &http.Client{
+		HTTPClient: useragent.Wrap(&http.Client{
 			Timeout: env.GetOrDefaultSecond(EnvHTTPTimeout, 30*time.Second),
-		},
+		}),
 	}
 }
 
@@ -53,6 +55,15 @@ func NewDefaultConfig() *Config {
 type DNSProvider struct {
 	config *Config
 	client *internal.Client
+
+	records   map[string]txtRecord
+	recordsMu sync.Mutex
+}
+
+// txtRecord the reference of a TXT record created by Present.
+type txtRecord struct {
+	zoneName string
+	id       int
 }
 
 // NewDNSProvider returns a DNSProvider instance configured for ClouDNS.
@@ -95,7 +106,11 @@ func NewDNSProviderConfig(config *Config) (*DNSProvider, error) {
 
 	client.HTTPClient = config.HTTPClient
 
-	return &DNSProvider{client: client, config: config}, nil
+	return &DNSProvider{
+		client:  client,
+		config:  config,
+		records: make(map[string]txtRecord),
+	}, nil
 }
 
 // Present creates a TXT record to fulfill the dns-01 challenge.
@@ -107,36 +122,37 @@ func (d *DNSProvider) Present(domain, token, keyAuth string) error {
 		return fmt.Errorf("ClouDNS: %w", err)
 	}
 
-	err = d.client.AddTxtRecord(zone.Name, fqdn, value, d.config.TTL)
+	recordID, err := d.client.AddTxtRecord(zone.Name, fqdn, value, d.config.TTL)
 	if err != nil {
 		return fmt.Errorf("ClouDNS: %w", err)
 	}
 
+	d.recordsMu.Lock()
+	d.records[token] = txtRecord{zoneName: zone.Name, id: recordID}
+	d.recordsMu.Unlock()
+
 	return nil
 }
 
 // CleanUp removes the TXT record matching the specified parameters.
 func (d *DNSProvider) CleanUp(domain, token, keyAuth string) error {
-	fqdn, _ := dns01.GetRecord(domain, keyAuth)
+	d.recordsMu.Lock()
+	record, ok := d.records[token]
+	d.recordsMu.Unlock()
 
-	zone, err := d.client.GetZone(fqdn)
-	if err != nil {
-		return fmt.Errorf("ClouDNS: %w", err)
+	if !ok {
+		return fmt.Errorf("ClouDNS: unknown record ID for '%s'", token)
 	}
 
-	record, err := d.client.FindTxtRecord(zone.Name, fqdn)
+	err := d.client.RemoveTxtRecord(record.id, record.zoneName)
 	if err != nil {
 		return fmt.Errorf("ClouDNS: %w", err)
 	}
 
-	if record == nil {
-		return nil
-	}
+	d.recordsMu.Lock()
+	delete(d.records, token)
+	d.recordsMu.Unlock()
 
-	err = d.client.RemoveTxtRecord(record.ID, zone.Name)
-	if err != nil {
-		return fmt.Errorf("ClouDNS: %w", err)
-	}
 	return nil
 }
 
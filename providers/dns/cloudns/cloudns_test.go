@@ -1,10 +1,14 @@
 package cloudns
 
 import (
+	"net/http"
+	"net/http/httptest"
+	"net/url"
 	"testing"
 	"time"
 
 	"github.com/go-acme/lego/v4/platform/tester"
+	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 )
 
@@ -163,6 +167,40 @@ func TestNewDNSProviderConfig(t *testing.T) {
 	}
 }
 
+func TestDNSProvider_CleanUp(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		if req.Method != http.MethodPost || req.URL.Path != "/delete-record.json" {
+			http.Error(rw, "unexpected request", http.StatusBadRequest)
+			return
+		}
+
+		assert.Equal(t, "bar.com", req.URL.Query().Get("domain-name"))
+		assert.Equal(t, "5769228", req.URL.Query().Get("record-id"))
+
+		_, _ = rw.Write([]byte(`{"status":"Success","statusDescription":"The record was deleted successfully."}`))
+	}))
+	defer server.Close()
+
+	config := NewDefaultConfig()
+	config.AuthID = "123"
+	config.AuthPassword = "456"
+
+	provider, err := NewDNSProviderConfig(config)
+	require.NoError(t, err)
+
+	provider.client.BaseURL, _ = url.Parse(server.URL + "/")
+
+	provider.records["token"] = txtRecord{zoneName: "bar.com", id: 5769228}
+
+	err = provider.CleanUp("foo.bar.com", "token", "123d==")
+	require.NoError(t, err)
+
+	assert.Empty(t, provider.records)
+
+	err = provider.CleanUp("foo.bar.com", "token", "123d==")
+	require.EqualError(t, err, "ClouDNS: unknown record ID for 'token'")
+}
+
 func TestLivePresent(t *testing.T) {
 	if !envTest.IsLiveTest() {
 		t.Skip("skipping live test")
@@ -1,6 +1,7 @@
 package internal
 
 import (
+	"context"
 	"encoding/json"
 	"errors"
 	"fmt"
@@ -10,16 +11,29 @@ import (
 	"strconv"
 	"strings"
 
+	"time"
+
 	"github.com/go-acme/lego/v4/challenge/dns01"
+	"golang.org/x/time/rate"
 )
 
 const defaultBaseURL = "https://api.cloudns.net/dns/"
 
+// requestsPerMinute the number of requests allowed by the API per minute.
+const requestsPerMinute = 60
+
 type apiResponse struct {
 	Status            string `json:"status"`
 	StatusDescription string `json:"statusDescription"`
 }
 
+type addRecordResponse struct {
+	apiResponse
+	Data struct {
+		ID int `json:"id"`
+	} `json:"data"`
+}
+
 type Zone struct {
 	Name   string
 	Type   string
@@ -61,6 +75,7 @@ func NewClient(authID, subAuthID, authPassword string) (*Client, error) {
 		authPassword: authPassword,
 		HTTPClient:   &http.Client{},
 		BaseURL:      baseURL,
+		limiter:      rate.NewLimiter(rate.Every(time.Minute/requestsPerMinute), 1),
 	}, nil
 }
 
@@ -71,6 +86,8 @@ type Client struct {
 	authPassword string
 	HTTPClient   *http.Client
 	BaseURL      *url.URL
+
+	limiter *rate.Limiter
 }
 
 // GetZone Get domain name information for a FQDN.
@@ -146,8 +163,8 @@ func (c *Client) FindTxtRecord(zoneName, fqdn string) (*TXTRecord, error) {
 	return nil, nil
 }
 
-// AddTxtRecord add a TXT record.
-func (c *Client) AddTxtRecord(zoneName, fqdn, value string, ttl int) error {
+// AddTxtRecord add a TXT record and returns its ID.
+func (c *Client) AddTxtRecord(zoneName, fqdn, value string, ttl int) (int, error) {
 	host := dns01.UnFqdn(strings.TrimSuffix(dns01.UnFqdn(fqdn), zoneName))
 
 	reqURL := *c.BaseURL
@@ -163,19 +180,19 @@ func (c *Client) AddTxtRecord(zoneName, fqdn, value string, ttl int) error {
 
 	raw, err := c.doRequest(http.MethodPost, &reqURL)
 	if err != nil {
-		return err
+		return 0, err
 	}
 
-	resp := apiResponse{}
+	resp := addRecordResponse{}
 	if err = json.Unmarshal(raw, &resp); err != nil {
-		return fmt.Errorf("apiResponse unmarshaling error: %w: %s", err, string(raw))
+		return 0, fmt.Errorf("apiResponse unmarshaling error: %w: %s", err, string(raw))
 	}
 
 	if resp.Status != "Success" {
-		return fmt.Errorf("fail to add TXT record: %s %s", resp.Status, resp.StatusDescription)
+		return 0, fmt.Errorf("fail to add TXT record: %s %s", resp.Status, resp.StatusDescription)
 	}
 
-	return nil
+	return resp.Data.ID, nil
 }
 
 // RemoveTxtRecord remove a TXT record.
@@ -206,6 +223,12 @@ func (c *Client) RemoveTxtRecord(recordID int, zoneName string) error {
 }
 
 func (c *Client) doRequest(method string, url *url.URL) (json.RawMessage, error) {
+	// the API rejects the requests above its rate limit.
+	err := c.limiter.Wait(context.Background())
+	if err != nil {
+		return nil, err
+	}
+
 	req, err := c.buildRequest(method, url)
 	if err != nil {
 		return nil, err
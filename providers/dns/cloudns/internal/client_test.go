@@ -6,9 +6,11 @@ import (
 	"net/http/httptest"
 	"net/url"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+	"golang.org/x/time/rate"
 )
 
 func handlerMock(method string, jsonData []byte) http.Handler {
@@ -160,8 +162,9 @@ func TestClientFindTxtRecord(t *testing.T) {
 
 func TestClientAddTxtRecord(t *testing.T) {
 	type expected struct {
-		Query string
-		Error string
+		Query    string
+		RecordID int
+		Error    string
 	}
 
 	testCases := []struct {
@@ -187,9 +190,10 @@ func TestClientAddTxtRecord(t *testing.T) {
 			authFQDN:    "_acme-challenge.foo.bar.com.",
 			value:       "txtTXTtxtTXTtxtTXTtxtTXT",
 			ttl:         60,
-			apiResponse: []byte(`{"status":"Success","statusDescription":"The record was added successfully."}`),
+			apiResponse: []byte(`{"status":"Success","statusDescription":"The record was added successfully.","data":{"id":5769228}}`),
 			expected: expected{
-				Query: `auth-id=myAuthID&auth-password=myAuthPassword&domain-name=bar.com&host=_acme-challenge.foo&record=txtTXTtxtTXTtxtTXTtxtTXT&record-type=TXT&ttl=60`,
+				Query:    `auth-id=myAuthID&auth-password=myAuthPassword&domain-name=bar.com&host=_acme-challenge.foo&record=txtTXTtxtTXTtxtTXTtxtTXT&record-type=TXT&ttl=60`,
+				RecordID: 5769228,
 			},
 		},
 		{
@@ -262,13 +266,80 @@ func TestClientAddTxtRecord(t *testing.T) {
 			mockBaseURL, _ := url.Parse(fmt.Sprintf("%s/", server.URL))
 			client.BaseURL = mockBaseURL
 
-			err = client.AddTxtRecord(test.zone.Name, test.authFQDN, test.value, test.ttl)
+			recordID, err := client.AddTxtRecord(test.zone.Name, test.authFQDN, test.value, test.ttl)
 
 			if test.expected.Error != "" {
 				require.EqualError(t, err, test.expected.Error)
+			} else {
+				require.NoError(t, err)
+				assert.Equal(t, test.expected.RecordID, recordID)
+			}
+		})
+	}
+}
+
+func TestClientRemoveTxtRecord(t *testing.T) {
+	testCases := []struct {
+		desc        string
+		apiResponse []byte
+		expected    string
+	}{
+		{
+			desc:        "success",
+			apiResponse: []byte(`{"status":"Success","statusDescription":"The record was deleted successfully."}`),
+		},
+		{
+			desc:        "invalid status",
+			apiResponse: []byte(`{"status":"Failed","statusDescription":"Invalid record-id param."}`),
+			expected:    "fail to add TXT record: Failed Invalid record-id param.",
+		},
+	}
+
+	for _, test := range testCases {
+		t.Run(test.desc, func(t *testing.T) {
+			server := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+				assert.Equal(t, "/delete-record.json", req.URL.Path)
+				assert.Equal(t, `auth-id=myAuthID&auth-password=myAuthPassword&domain-name=bar.com&record-id=5769228`, req.URL.RawQuery)
+
+				handlerMock(http.MethodPost, test.apiResponse).ServeHTTP(rw, req)
+			}))
+			defer server.Close()
+
+			client, err := NewClient("myAuthID", "", "myAuthPassword")
+			require.NoError(t, err)
+
+			mockBaseURL, _ := url.Parse(fmt.Sprintf("%s/", server.URL))
+			client.BaseURL = mockBaseURL
+
+			err = client.RemoveTxtRecord(5769228, "bar.com")
+
+			if test.expected != "" {
+				require.EqualError(t, err, test.expected)
 			} else {
 				require.NoError(t, err)
 			}
 		})
 	}
 }
+
+func TestClient_rateLimit(t *testing.T) {
+	server := httptest.NewServer(handlerMock(http.MethodGet, []byte(`[]`)))
+	defer server.Close()
+
+	client, err := NewClient("myAuthID", "", "myAuthPassword")
+	require.NoError(t, err)
+
+	mockBaseURL, _ := url.Parse(fmt.Sprintf("%s/", server.URL))
+	client.BaseURL = mockBaseURL
+	client.limiter = rate.NewLimiter(rate.Every(100*time.Millisecond), 1)
+
+	start := time.Now()
+
+	for i := 0; i < 4; i++ {
+		_, err = client.FindTxtRecord("bar.com", "_acme-challenge.bar.com.")
+		require.NoError(t, err)
+	}
+
+	// the first request is not delayed.
+	assert.GreaterOrEqual(t, int64(time.Since(start)), int64(300*time.Millisecond))
+}
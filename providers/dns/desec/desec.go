@@ -5,10 +5,12 @@ import (
 	"errors"
 	"fmt"
 	"net/http"
+	"strings"
 	"time"
 
 	"github.com/go-acme/lego/v4/challenge/dns01"
 	"github.com/go-acme/lego/v4/platform/config/env"
+	"github.com/go-acme/lego/v4/providers/dns/internal/useragent"
 	"github.com/nrdcg/desec"
 )
 
@@ -39,9 +41,9 @@ func NewDefaultConfig() *Config {
 		TTL:                env.GetOrDefaultInt(EnvTTL, 300),
 		PropagationTimeout: env.GetOrDefaultSecond(EnvPropagationTimeout, dns01.DefaultPropagationTimeout),
 		PollingInterval:    env.GetOrDefaultSecond(EnvPollingInterval, dns01.DefaultPollingInterval),
-		HTTPClient: &http.Client{
+		HTTPClient: useragent.Wrap(&http.Client{
 			Timeout: env.GetOrDefaultSecond(EnvHTTPTimeout, 30*time.Second),
-		},
+		}),
 	}
 }
 
@@ -92,18 +94,18 @@ func (d *DNSProvider) Timeout() (timeout, interval time.Duration) {
 }
 
 // Present creates a TXT record using the specified parameters.
+// deSEC stores all the values of a name/type pair in a single RRSet,
+// so the value is merged into the existing RRSet when there is one.
 func (d *DNSProvider) Present(domain, token, keyAuth string) error {
 	fqdn, value := dns01.GetRecord(domain, keyAuth)
 	quotedValue := fmt.Sprintf(`"%s"`, value)
 
-	authZone, err := dns01.FindZoneByFqdn(fqdn)
+	domainName, err := d.findDomainName(fqdn)
 	if err != nil {
 		return fmt.Errorf("desec: could not find zone for domain %q and fqdn %q : %w", domain, fqdn, err)
 	}
 
-	recordName := getRecordName(fqdn, authZone)
-
-	domainName := dns01.UnFqdn(authZone)
+	recordName := getRecordName(fqdn, domainName)
 
 	rrSet, err := d.client.Records.Get(domainName, recordName, "TXT")
 	if err != nil {
@@ -127,6 +129,13 @@ func (d *DNSProvider) Present(domain, token, keyAuth string) error {
 		return nil
 	}
 
+	for _, record := range rrSet.Records {
+		if record == quotedValue {
+			// the value is already in the RRSet.
+			return nil
+		}
+	}
+
 	// update
 	records := append(rrSet.Records, quotedValue)
 
@@ -142,14 +151,12 @@ func (d *DNSProvider) Present(domain, token, keyAuth string) error {
 func (d *DNSProvider) CleanUp(domain, token, keyAuth string) error {
 	fqdn, value := dns01.GetRecord(domain, keyAuth)
 
-	authZone, err := dns01.FindZoneByFqdn(fqdn)
+	domainName, err := d.findDomainName(fqdn)
 	if err != nil {
 		return fmt.Errorf("desec: could not find zone for domain %q and fqdn %q : %w", domain, fqdn, err)
 	}
 
-	recordName := getRecordName(fqdn, authZone)
-
-	domainName := dns01.UnFqdn(authZone)
+	recordName := getRecordName(fqdn, domainName)
 
 	rrSet, err := d.client.Records.Get(domainName, recordName, "TXT")
 	if err != nil {
@@ -163,6 +170,15 @@ func (d *DNSProvider) CleanUp(domain, token, keyAuth string) error {
 		}
 	}
 
+	if len(records) == 0 {
+		err = d.client.Records.Delete(domainName, recordName, "TXT")
+		if err != nil {
+			return fmt.Errorf("desec: failed to delete records: domainName=%s, recordName=%s: %w", domainName, recordName, err)
+		}
+
+		return nil
+	}
+
 	_, err = d.client.Records.Update(domainName, recordName, "TXT", desec.RRSet{Records: records})
 	if err != nil {
 		return fmt.Errorf("desec: failed to update records: domainName=%s, recordName=%s: %w", domainName, recordName, err)
@@ -171,6 +187,42 @@ func (d *DNSProvider) CleanUp(domain, token, keyAuth string) error {
 	return nil
 }
 
-func getRecordName(fqdn, authZone string) string {
-	return fqdn[0 : len(fqdn)-len(authZone)-1]
+// Zones returns the domains of the account.
+func (d *DNSProvider) Zones() ([]string, error) {
+	domains, err := d.client.Domains.GetAll()
+	if err != nil {
+		return nil, fmt.Errorf("desec: failed to get domains: %w", err)
+	}
+
+	var zones []string
+	for _, domain := range domains {
+		zones = append(zones, domain.Name)
+	}
+
+	return zones, nil
+}
+
+// findDomainName finds the longest domain of the account matching the FQDN.
+func (d *DNSProvider) findDomainName(fqdn string) (string, error) {
+	domains, err := d.client.Domains.GetAll()
+	if err != nil {
+		return "", fmt.Errorf("failed to get domains: %w", err)
+	}
+
+	var names []string
+	for _, domain := range domains {
+		names = append(names, domain.Name)
+	}
+
+	domainName := dns01.FindMostSpecificZone(fqdn, names)
+	if domainName == "" {
+		return "", fmt.Errorf("no domain found for %s", dns01.UnFqdn(fqdn))
+	}
+
+	return domainName, nil
+}
+
+// getRecordName returns the subname of the FQDN relatively to the domain name.
+func getRecordName(fqdn, domainName string) string {
+	return strings.TrimSuffix(dns01.UnFqdn(fqdn), "."+domainName)
 }
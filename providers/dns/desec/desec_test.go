@@ -1,10 +1,16 @@
 package desec
 
 import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
 	"testing"
 	"time"
 
 	"github.com/go-acme/lego/v4/platform/tester"
+	"github.com/nrdcg/desec"
+	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 )
 
@@ -12,6 +18,32 @@ const envDomain = envNamespace + "DOMAIN"
 
 var envTest = tester.NewEnvTest(EnvToken).WithDomain(envDomain)
 
+func setupTest() (*DNSProvider, *http.ServeMux, func()) {
+	handler := http.NewServeMux()
+	server := httptest.NewServer(handler)
+
+	handler.HandleFunc("/domains/", func(rw http.ResponseWriter, req *http.Request) {
+		if req.Method != http.MethodGet {
+			http.Error(rw, fmt.Sprintf("unsupported method: %s", req.Method), http.StatusMethodNotAllowed)
+			return
+		}
+
+		_, _ = fmt.Fprint(rw, `[{"name":"example.com"},{"name":"sub.example.com"},{"name":"example.org"}]`)
+	})
+
+	config := NewDefaultConfig()
+	config.Token = "secret"
+
+	provider, err := NewDNSProviderConfig(config)
+	if err != nil {
+		panic(err)
+	}
+
+	provider.client.BaseURL = server.URL + "/"
+
+	return provider, handler, server.Close
+}
+
 func TestNewDNSProvider(t *testing.T) {
 	testCases := []struct {
 		desc     string
@@ -87,6 +119,148 @@ func TestNewDNSProviderConfig(t *testing.T) {
 	}
 }
 
+func TestDNSProvider_Present_create(t *testing.T) {
+	provider, mux, tearDown := setupTest()
+	defer tearDown()
+
+	mux.HandleFunc("/domains/sub.example.com/rrsets/_acme-challenge.foo/TXT/", func(rw http.ResponseWriter, req *http.Request) {
+		assert.Equal(t, http.MethodGet, req.Method)
+
+		http.Error(rw, `{"detail":"Not found."}`, http.StatusNotFound)
+	})
+
+	var created desec.RRSet
+	mux.HandleFunc("/domains/sub.example.com/rrsets/", func(rw http.ResponseWriter, req *http.Request) {
+		assert.Equal(t, http.MethodPost, req.Method)
+
+		err := json.NewDecoder(req.Body).Decode(&created)
+		if err != nil {
+			http.Error(rw, err.Error(), http.StatusBadRequest)
+			return
+		}
+
+		rw.WriteHeader(http.StatusCreated)
+		_ = json.NewEncoder(rw).Encode(created)
+	})
+
+	err := provider.Present("foo.sub.example.com", "", "123d==")
+	require.NoError(t, err)
+
+	expected := desec.RRSet{
+		Domain:  "sub.example.com",
+		SubName: "_acme-challenge.foo",
+		Type:    "TXT",
+		Records: []string{`"ADw2sEd82DUgXcQ9hNBZThJs7zVJkR5v9JeSbAb9mZY"`},
+		TTL:     300,
+	}
+	assert.Equal(t, expected, created)
+}
+
+func TestDNSProvider_Present_merge(t *testing.T) {
+	provider, mux, tearDown := setupTest()
+	defer tearDown()
+
+	var updated desec.RRSet
+	mux.HandleFunc("/domains/example.com/rrsets/_acme-challenge/TXT/", func(rw http.ResponseWriter, req *http.Request) {
+		switch req.Method {
+		case http.MethodGet:
+			_, _ = fmt.Fprint(rw, `{"domain":"example.com","subname":"_acme-challenge","type":"TXT","records":["\"first\""],"ttl":300}`)
+		case http.MethodPatch:
+			err := json.NewDecoder(req.Body).Decode(&updated)
+			if err != nil {
+				http.Error(rw, err.Error(), http.StatusBadRequest)
+				return
+			}
+
+			_ = json.NewEncoder(rw).Encode(updated)
+		default:
+			http.Error(rw, fmt.Sprintf("unsupported method: %s", req.Method), http.StatusMethodNotAllowed)
+		}
+	})
+
+	err := provider.Present("example.com", "", "123d==")
+	require.NoError(t, err)
+
+	expected := []string{`"first"`, `"ADw2sEd82DUgXcQ9hNBZThJs7zVJkR5v9JeSbAb9mZY"`}
+	assert.Equal(t, expected, updated.Records)
+}
+
+func TestDNSProvider_Present_alreadyMerged(t *testing.T) {
+	provider, mux, tearDown := setupTest()
+	defer tearDown()
+
+	mux.HandleFunc("/domains/example.com/rrsets/_acme-challenge/TXT/", func(rw http.ResponseWriter, req *http.Request) {
+		if req.Method != http.MethodGet {
+			http.Error(rw, fmt.Sprintf("unexpected method: %s", req.Method), http.StatusMethodNotAllowed)
+			return
+		}
+
+		_, _ = fmt.Fprint(rw, `{"domain":"example.com","subname":"_acme-challenge","type":"TXT","records":["\"ADw2sEd82DUgXcQ9hNBZThJs7zVJkR5v9JeSbAb9mZY\""],"ttl":300}`)
+	})
+
+	err := provider.Present("example.com", "", "123d==")
+	require.NoError(t, err)
+}
+
+func TestDNSProvider_Present_unknownDomain(t *testing.T) {
+	provider, _, tearDown := setupTest()
+	defer tearDown()
+
+	err := provider.Present("example.net", "", "123d==")
+	require.EqualError(t, err, `desec: could not find zone for domain "example.net" and fqdn "_acme-challenge.example.net." : no domain found for _acme-challenge.example.net`)
+}
+
+func TestDNSProvider_CleanUp(t *testing.T) {
+	provider, mux, tearDown := setupTest()
+	defer tearDown()
+
+	var updated desec.RRSet
+	mux.HandleFunc("/domains/example.com/rrsets/_acme-challenge/TXT/", func(rw http.ResponseWriter, req *http.Request) {
+		switch req.Method {
+		case http.MethodGet:
+			_, _ = fmt.Fprint(rw, `{"domain":"example.com","subname":"_acme-challenge","type":"TXT","records":["\"first\"","\"ADw2sEd82DUgXcQ9hNBZThJs7zVJkR5v9JeSbAb9mZY\""],"ttl":300}`)
+		case http.MethodPatch:
+			err := json.NewDecoder(req.Body).Decode(&updated)
+			if err != nil {
+				http.Error(rw, err.Error(), http.StatusBadRequest)
+				return
+			}
+
+			_ = json.NewEncoder(rw).Encode(updated)
+		default:
+			http.Error(rw, fmt.Sprintf("unsupported method: %s", req.Method), http.StatusMethodNotAllowed)
+		}
+	})
+
+	err := provider.CleanUp("example.com", "", "123d==")
+	require.NoError(t, err)
+
+	assert.Equal(t, []string{`"first"`}, updated.Records)
+}
+
+func TestDNSProvider_CleanUp_lastValue(t *testing.T) {
+	provider, mux, tearDown := setupTest()
+	defer tearDown()
+
+	var deleted bool
+	mux.HandleFunc("/domains/example.com/rrsets/_acme-challenge/TXT/", func(rw http.ResponseWriter, req *http.Request) {
+		switch req.Method {
+		case http.MethodGet:
+			_, _ = fmt.Fprint(rw, `{"domain":"example.com","subname":"_acme-challenge","type":"TXT","records":["\"ADw2sEd82DUgXcQ9hNBZThJs7zVJkR5v9JeSbAb9mZY\""],"ttl":300}`)
+		case http.MethodDelete:
+			deleted = true
+			rw.WriteHeader(http.StatusNoContent)
+		default:
+			http.Error(rw, fmt.Sprintf("unsupported method: %s", req.Method), http.StatusMethodNotAllowed)
+		}
+	})
+
+	err := provider.CleanUp("example.com", "", "123d==")
+	require.NoError(t, err)
+
+	assert.True(t, deleted)
+}
+
 func TestLivePresent(t *testing.T) {
 	if !envTest.IsLiveTest() {
 		t.Skip("skipping live test")
@@ -11,6 +11,7 @@ import (
 
 	"github.com/go-acme/lego/v4/challenge/dns01"
 	"github.com/go-acme/lego/v4/platform/config/env"
+	"github.com/go-acme/lego/v4/providers/dns/internal/useragent"
 	"github.com/gophercloud/gophercloud"
 	"github.com/gophercloud/gophercloud/openstack"
 	"github.com/gophercloud/gophercloud/openstack/dns/v2/recordsets"
@@ -102,7 +103,14 @@ func NewDNSProviderConfig(config *Config) (*DNSProvider, error) {
 		return nil, errors.New("designate: the configuration of the DNS provider is nil")
 	}
 
-	provider, err := openstack.AuthenticatedClient(config.opts)
+	provider, err := openstack.NewClient(config.opts.IdentityEndpoint)
+	if err != nil {
+		return nil, fmt.Errorf("designate: failed to create the client: %w", err)
+	}
+
+	useragent.Wrap(&provider.HTTPClient)
+
+	err = openstack.Authenticate(provider, config.opts)
 	if err != nil {
 		return nil, fmt.Errorf("designate: failed to authenticate: %w", err)
 	}
@@ -14,6 +14,9 @@ import (
 
 const defaultBaseURL = "https://api.digitalocean.com"
 
+// minTTL is the minimum TTL accepted by the API.
+const minTTL = 30
+
 // txtRecordResponse represents a response from DO's API after making a TXT record.
 type txtRecordResponse struct {
 	DomainRecord record `json:"domain_record"`
@@ -57,13 +60,14 @@ func (d *DNSProvider) removeTxtRecord(domain string, recordID int) error {
 	return nil
 }
 
-func (d *DNSProvider) addTxtRecord(fqdn, value string) (*txtRecordResponse, error) {
-	authZone, err := dns01.FindZoneByFqdn(dns01.ToFqdn(fqdn))
-	if err != nil {
-		return nil, fmt.Errorf("could not determine zone for domain %q: %w", fqdn, err)
+// addTxtRecord creates the TXT record, the TTL is clamped to the minimum accepted by the API.
+func (d *DNSProvider) addTxtRecord(authZone, fqdn, value string) (*txtRecordResponse, error) {
+	ttl := d.config.TTL
+	if ttl < minTTL {
+		ttl = minTTL
 	}
 
-	reqData := record{Type: "TXT", Name: fqdn, Data: value, TTL: d.config.TTL}
+	reqData := record{Type: "TXT", Name: fqdn, Data: value, TTL: ttl}
 	body, err := json.Marshal(reqData)
 	if err != nil {
 		return nil, err
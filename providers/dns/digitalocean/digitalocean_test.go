@@ -169,3 +169,55 @@ func TestDNSProvider_CleanUp(t *testing.T) {
 	err := provider.CleanUp("example.com", "token", "")
 	require.NoError(t, err, "fail to remove TXT record")
 }
+
+func TestDNSProvider_addTxtRecord(t *testing.T) {
+	testCases := []struct {
+		desc        string
+		ttl         int
+		expectedTTL int
+	}{
+		{
+			desc:        "TTL",
+			ttl:         600,
+			expectedTTL: 600,
+		},
+		{
+			desc:        "minimum TTL",
+			ttl:         30,
+			expectedTTL: 30,
+		},
+		{
+			desc:        "TTL below the minimum",
+			ttl:         10,
+			expectedTTL: 30,
+		},
+	}
+
+	for _, test := range testCases {
+		t.Run(test.desc, func(t *testing.T) {
+			provider, mux, tearDown := setupTest()
+			defer tearDown()
+
+			provider.config.TTL = test.ttl
+
+			mux.HandleFunc("/v2/domains/example.com/records", func(w http.ResponseWriter, r *http.Request) {
+				reqBody, err := ioutil.ReadAll(r.Body)
+				if err != nil {
+					http.Error(w, err.Error(), http.StatusInternalServerError)
+					return
+				}
+
+				expectedReqBody := fmt.Sprintf(`{"type":"TXT","name":"_acme-challenge.example.com.","data":"w6uP8Tcg6K2QR905Rms8iXTlksL6OD1KOWBxTK7wxPI","ttl":%d}`, test.expectedTTL)
+				assert.Equal(t, expectedReqBody, string(reqBody))
+
+				w.WriteHeader(http.StatusCreated)
+				_, _ = fmt.Fprint(w, `{"domain_record":{"id":1234567,"type":"TXT","name":"_acme-challenge"}}`)
+			})
+
+			resp, err := provider.addTxtRecord("example.com.", "_acme-challenge.example.com.", "w6uP8Tcg6K2QR905Rms8iXTlksL6OD1KOWBxTK7wxPI")
+			require.NoError(t, err)
+
+			assert.Equal(t, 1234567, resp.DomainRecord.ID)
+		})
+	}
+}
@@ -12,6 +12,7 @@ import (
 	"github.com/dnsimple/dnsimple-go/dnsimple"
 	"github.com/go-acme/lego/v4/challenge/dns01"
 	"github.com/go-acme/lego/v4/platform/config/env"
+	"github.com/go-acme/lego/v4/providers/dns/internal/useragent"
 	"golang.org/x/oauth2"
 )
 
@@ -77,6 +78,10 @@ func NewDNSProviderConfig(config *Config) (*DNSProvider, error) {
 	client := dnsimple.NewClient(oauth2.NewClient(context.Background(), ts))
 	client.SetUserAgent("go-acme/lego")
 
+	if ua := useragent.Get(); ua != "" {
+		client.SetUserAgent(ua)
+	}
+
 	if config.BaseURL != "" {
 		client.BaseURL = config.BaseURL
 	}
@@ -7,11 +7,19 @@ import (
 	"fmt"
 	"net/http"
 	"strings"
+	"sync"
 	"time"
 
 	"github.com/go-acme/lego/v4/challenge/dns01"
 	"github.com/go-acme/lego/v4/platform/config/env"
 	"github.com/go-acme/lego/v4/providers/dns/dnsmadeeasy/internal"
+	"github.com/go-acme/lego/v4/providers/dns/internal/useragent"
+)
+
+// API endpoints.
+const (
+	defaultBaseURL = "https://api.dnsmadeeasy.com/V2.0"
+	sandboxBaseURL = "https://api.sandbox.dnsmadeeasy.com/V2.0"
 )
 
 // Environment variables names.
@@ -46,12 +54,12 @@ func NewDefaultConfig() *Config {
 		TTL:                env.GetOrDefaultInt(EnvTTL, dns01.DefaultTTL),
 		PropagationTimeout: env.GetOrDefaultSecond(EnvPropagationTimeout, dns01.DefaultPropagationTimeout),
 		PollingInterval:    env.GetOrDefaultSecond(EnvPollingInterval, dns01.DefaultPollingInterval),
-		HTTPClient: &http.Client{
+		HTTPClient: useragent.Wrap(&http.Client{
 			Timeout: env.GetOrDefaultSecond(EnvHTTPTimeout, 10*time.Second),
 			Transport: &http.Transport{
 				TLSClientConfig: &tls.Config{InsecureSkipVerify: true},
 			},
-		},
+		}),
 	}
 }
 
@@ -59,6 +67,9 @@ func NewDefaultConfig() *Config {
 type DNSProvider struct {
 	config *Config
 	client *internal.Client
+
+	records   map[string]*internal.Record
+	recordsMu sync.Mutex
 }
 
 // NewDNSProvider returns a DNSProvider instance configured for DNSMadeEasy DNS.
@@ -86,12 +97,12 @@ func NewDNSProviderConfig(config *Config) (*DNSProvider, error) {
 
 	var baseURL string
 	if config.Sandbox {
-		baseURL = "https://api.sandbox.dnsmadeeasy.com/V2.0"
+		baseURL = sandboxBaseURL
 	} else {
 		if len(config.BaseURL) > 0 {
 			baseURL = config.BaseURL
 		} else {
-			baseURL = "https://api.dnsmadeeasy.com/V2.0"
+			baseURL = defaultBaseURL
 		}
 	}
 
@@ -104,8 +115,9 @@ func NewDNSProviderConfig(config *Config) (*DNSProvider, error) {
 	client.BaseURL = baseURL
 
 	return &DNSProvider{
-		client: client,
-		config: config,
+		client:  client,
+		config:  config,
+		records: make(map[string]*internal.Record),
 	}, nil
 }
 
@@ -128,45 +140,45 @@ func (d *DNSProvider) Present(domainName, token, keyAuth string) error {
 	name := strings.Replace(fqdn, "."+authZone, "", 1)
 	record := &internal.Record{Type: "TXT", Name: name, Value: value, TTL: d.config.TTL}
 
-	err = d.client.CreateRecord(domain, record)
+	created, err := d.client.CreateRecord(domain, record)
 	if err != nil {
 		return fmt.Errorf("dnsmadeeasy: unable to create record for %s: %w", name, err)
 	}
+
+	if created.SourceID == 0 {
+		created.SourceID = domain.ID
+	}
+
+	d.recordsMu.Lock()
+	d.records[token] = created
+	d.recordsMu.Unlock()
+
 	return nil
 }
 
-// CleanUp removes the TXT records matching the specified parameters.
+// CleanUp removes the TXT record matching the specified parameters.
 func (d *DNSProvider) CleanUp(domainName, token, keyAuth string) error {
 	fqdn, _ := dns01.GetRecord(domainName, keyAuth)
 
-	authZone, err := dns01.FindZoneByFqdn(fqdn)
-	if err != nil {
-		return fmt.Errorf("dnsmadeeasy: unable to find zone for %s: %w", fqdn, err)
+	// get the record from when we created it
+	d.recordsMu.Lock()
+	record, ok := d.records[token]
+	d.recordsMu.Unlock()
+	if !ok {
+		return fmt.Errorf("dnsmadeeasy: unknown record ID for '%s'", fqdn)
 	}
 
-	// fetch the domain details
-	domain, err := d.client.GetDomain(authZone)
-	if err != nil {
-		return fmt.Errorf("dnsmadeeasy: unable to get domain for zone %s: %w", authZone, err)
-	}
-
-	// find matching records
-	name := strings.Replace(fqdn, "."+authZone, "", 1)
-	records, err := d.client.GetRecords(domain, name, "TXT")
+	err := d.client.DeleteRecord(*record)
 	if err != nil {
-		return fmt.Errorf("dnsmadeeasy: unable to get records for domain %s: %w", domain.Name, err)
+		return fmt.Errorf("dnsmadeeasy: unable to delete record [id=%d, name=%s]: %w", record.ID, record.Name, err)
 	}
 
-	// delete records
-	var lastError error
-	for _, record := range *records {
-		err = d.client.DeleteRecord(record)
-		if err != nil {
-			lastError = fmt.Errorf("dnsmadeeasy: unable to delete record [id=%d, name=%s]: %w", record.ID, record.Name, err)
-		}
-	}
+	// Delete record from map
+	d.recordsMu.Lock()
+	delete(d.records, token)
+	d.recordsMu.Unlock()
 
-	return lastError
+	return nil
 }
 
 // Timeout returns the timeout and interval to use when checking for DNS propagation.
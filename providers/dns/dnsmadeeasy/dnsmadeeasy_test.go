@@ -1,10 +1,14 @@
 package dnsmadeeasy
 
 import (
+	"net/http"
+	"net/http/httptest"
 	"os"
 	"testing"
 
 	"github.com/go-acme/lego/v4/platform/tester"
+	"github.com/go-acme/lego/v4/providers/dns/dnsmadeeasy/internal"
+	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 )
 
@@ -127,6 +131,90 @@ func TestNewDNSProviderConfig(t *testing.T) {
 	}
 }
 
+func TestNewDNSProviderConfig_endpoint(t *testing.T) {
+	testCases := []struct {
+		desc     string
+		sandbox  bool
+		baseURL  string
+		expected string
+	}{
+		{
+			desc:     "production",
+			expected: defaultBaseURL,
+		},
+		{
+			desc:     "sandbox",
+			sandbox:  true,
+			expected: sandboxBaseURL,
+		},
+		{
+			desc:     "custom base URL",
+			baseURL:  "https://example.com/V2.0",
+			expected: "https://example.com/V2.0",
+		},
+		{
+			desc:     "sandbox has priority over the custom base URL",
+			sandbox:  true,
+			baseURL:  "https://example.com/V2.0",
+			expected: sandboxBaseURL,
+		},
+	}
+
+	for _, test := range testCases {
+		t.Run(test.desc, func(t *testing.T) {
+			config := NewDefaultConfig()
+			config.APIKey = "123"
+			config.APISecret = "456"
+			config.Sandbox = test.sandbox
+			config.BaseURL = test.baseURL
+
+			p, err := NewDNSProviderConfig(config)
+			require.NoError(t, err)
+
+			assert.Equal(t, test.expected, p.client.BaseURL)
+		})
+	}
+}
+
+func TestDNSProvider_CleanUp(t *testing.T) {
+	handler := http.NewServeMux()
+	server := httptest.NewServer(handler)
+	defer server.Close()
+
+	var deleted bool
+	handler.HandleFunc("/dns/managed/12/records/34", func(rw http.ResponseWriter, req *http.Request) {
+		if req.Method != http.MethodDelete {
+			http.Error(rw, "unsupported method", http.StatusMethodNotAllowed)
+			return
+		}
+
+		deleted = true
+	})
+
+	handler.HandleFunc("/", func(rw http.ResponseWriter, req *http.Request) {
+		http.Error(rw, "unexpected request: "+req.URL.String(), http.StatusBadRequest)
+	})
+
+	config := NewDefaultConfig()
+	config.APIKey = "123"
+	config.APISecret = "456"
+	config.BaseURL = server.URL
+
+	provider, err := NewDNSProviderConfig(config)
+	require.NoError(t, err)
+
+	provider.records["token"] = &internal.Record{ID: 34, SourceID: 12, Name: "_acme-challenge"}
+
+	err = provider.CleanUp("example.com", "token", "123d==")
+	require.NoError(t, err)
+
+	assert.True(t, deleted)
+	assert.NotContains(t, provider.records, "token")
+
+	err = provider.CleanUp("example.com", "token", "123d==")
+	require.EqualError(t, err, "dnsmadeeasy: unknown record ID for '_acme-challenge.example.com.'")
+}
+
 func TestLivePresentAndCleanup(t *testing.T) {
 	if !envTest.IsLiveTest() {
 		t.Skip("skipping live test")
@@ -98,16 +98,22 @@ func (c *Client) GetRecords(domain *Domain, recordName, recordType string) (*[]R
 }
 
 // CreateRecord creates a TXT records.
-func (c *Client) CreateRecord(domain *Domain, record *Record) error {
+func (c *Client) CreateRecord(domain *Domain, record *Record) (*Record, error) {
 	url := fmt.Sprintf("%s/%d/%s", "/dns/managed", domain.ID, "records")
 
 	resp, err := c.sendRequest(http.MethodPost, url, record)
 	if err != nil {
-		return err
+		return nil, err
 	}
 	defer resp.Body.Close()
 
-	return nil
+	created := &Record{}
+	err = json.NewDecoder(resp.Body).Decode(created)
+	if err != nil {
+		return nil, err
+	}
+
+	return created, nil
 }
 
 // DeleteRecord deletes a TXT records.
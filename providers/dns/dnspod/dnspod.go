@@ -7,13 +7,18 @@ import (
 	"net/http"
 	"strconv"
 	"strings"
+	"sync"
 	"time"
 
 	"github.com/go-acme/lego/v4/challenge/dns01"
 	"github.com/go-acme/lego/v4/platform/config/env"
+	"github.com/go-acme/lego/v4/providers/dns/internal/useragent"
 	"github.com/nrdcg/dnspod-go"
 )
 
+// The minimum TTL accepted by the API.
+const minTTL = 600
+
 // Environment variables names.
 const (
 	envNamespace = "DNSPOD_"
@@ -38,12 +43,12 @@ type Config struct {
 // NewDefaultConfig returns a default configuration for the DNSProvider.
 func NewDefaultConfig() *Config {
 	return &Config{
-		TTL:                env.GetOrDefaultInt(EnvTTL, 600),
+		TTL:                env.GetOrDefaultInt(EnvTTL, minTTL),
 		PropagationTimeout: env.GetOrDefaultSecond(EnvPropagationTimeout, dns01.DefaultPropagationTimeout),
 		PollingInterval:    env.GetOrDefaultSecond(EnvPollingInterval, dns01.DefaultPollingInterval),
-		HTTPClient: &http.Client{
-			Timeout: env.GetOrDefaultSecond(EnvHTTPTimeout, 30),
-		},
+		HTTPClient: useragent.Wrap(&http.Client{
+			Timeout: env.GetOrDefaultSecond(EnvHTTPTimeout, 30*time.Second),
+		}),
 	}
 }
 
@@ -51,6 +56,14 @@ func NewDefaultConfig() *Config {
 type DNSProvider struct {
 	config *Config
 	client *dnspod.Client
+
+	records   map[string]txtRecord
+	recordsMu sync.Mutex
+}
+
+type txtRecord struct {
+	zoneID string
+	id     string
 }
 
 // NewDNSProvider returns a DNSProvider instance configured for dnspod.
@@ -82,7 +95,11 @@ func NewDNSProviderConfig(config *Config) (*DNSProvider, error) {
 	client := dnspod.NewClient(params)
 	client.HTTPClient = config.HTTPClient
 
-	return &DNSProvider{client: client, config: config}, nil
+	return &DNSProvider{
+		client:  client,
+		config:  config,
+		records: make(map[string]txtRecord),
+	}, nil
 }
 
 // Present creates a TXT record to fulfill the dns-01 challenge.
@@ -93,12 +110,16 @@ func (d *DNSProvider) Present(domain, token, keyAuth string) error {
 		return err
 	}
 
-	recordAttributes := d.newTxtRecord(zoneName, fqdn, value, d.config.TTL)
-	_, _, err = d.client.Records.Create(zoneID, *recordAttributes)
+	recordAttributes := d.newTxtRecord(zoneName, fqdn, value, d.ttl())
+	record, _, err := d.client.Records.Create(zoneID, *recordAttributes)
 	if err != nil {
 		return fmt.Errorf("API call failed: %w", err)
 	}
 
+	d.recordsMu.Lock()
+	d.records[token] = txtRecord{zoneID: zoneID, id: record.ID}
+	d.recordsMu.Unlock()
+
 	return nil
 }
 
@@ -106,22 +127,24 @@ func (d *DNSProvider) Present(domain, token, keyAuth string) error {
 func (d *DNSProvider) CleanUp(domain, token, keyAuth string) error {
 	fqdn, _ := dns01.GetRecord(domain, keyAuth)
 
-	records, err := d.findTxtRecords(domain, fqdn)
-	if err != nil {
-		return err
+	// gets the record's unique ID from when we created it
+	d.recordsMu.Lock()
+	record, ok := d.records[token]
+	d.recordsMu.Unlock()
+	if !ok {
+		return fmt.Errorf("dnspod: unknown record ID for '%s'", fqdn)
 	}
 
-	zoneID, _, err := d.getHostedZone(domain)
+	_, err := d.client.Records.Delete(record.zoneID, record.id)
 	if err != nil {
-		return err
+		return fmt.Errorf("dnspod: failed to delete the TXT record %s: %w", record.id, err)
 	}
 
-	for _, rec := range records {
-		_, err := d.client.Records.Delete(zoneID, rec.ID)
-		if err != nil {
-			return err
-		}
-	}
+	// deletes record ID from map
+	d.recordsMu.Lock()
+	delete(d.records, token)
+	d.recordsMu.Unlock()
+
 	return nil
 }
 
@@ -168,27 +191,13 @@ func (d *DNSProvider) newTxtRecord(zone, fqdn, value string, ttl int) *dnspod.Re
 	}
 }
 
-func (d *DNSProvider) findTxtRecords(domain, fqdn string) ([]dnspod.Record, error) {
-	zoneID, zoneName, err := d.getHostedZone(domain)
-	if err != nil {
-		return nil, err
-	}
-
-	var records []dnspod.Record
-	result, _, err := d.client.Records.List(zoneID, "")
-	if err != nil {
-		return records, fmt.Errorf("API call has failed: %w", err)
-	}
-
-	recordName := extractRecordName(fqdn, zoneName)
-
-	for _, record := range result {
-		if record.Name == recordName {
-			records = append(records, record)
-		}
+// ttl returns the TTL of the configuration, clamped to the minimum accepted by the API.
+func (d *DNSProvider) ttl() int {
+	if d.config.TTL < minTTL {
+		return minTTL
 	}
 
-	return records, nil
+	return d.config.TTL
 }
 
 func extractRecordName(fqdn, zone string) string {
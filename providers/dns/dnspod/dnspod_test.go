@@ -1,10 +1,15 @@
 package dnspod
 
 import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
 	"testing"
 	"time"
 
 	"github.com/go-acme/lego/v4/platform/tester"
+	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 )
 
@@ -90,20 +95,100 @@ func TestNewDNSProviderConfig(t *testing.T) {
 	}
 }
 
-func TestLivePresent(t *testing.T) {
-	if !envTest.IsLiveTest() {
-		t.Skip("skipping live test")
+func TestDNSProvider_newTxtRecord_ttl(t *testing.T) {
+	testCases := []struct {
+		desc     string
+		ttl      int
+		expected string
+	}{
+		{
+			desc:     "default",
+			ttl:      NewDefaultConfig().TTL,
+			expected: "600",
+		},
+		{
+			desc:     "greater than the minimum",
+			ttl:      3600,
+			expected: "3600",
+		},
+		{
+			desc:     "lower than the minimum",
+			ttl:      120,
+			expected: "600",
+		},
 	}
 
-	envTest.RestoreEnv()
-	provider, err := NewDNSProvider()
+	for _, test := range testCases {
+		t.Run(test.desc, func(t *testing.T) {
+			config := NewDefaultConfig()
+			config.LoginToken = "token"
+			config.TTL = test.ttl
+
+			p, err := NewDNSProviderConfig(config)
+			require.NoError(t, err)
+
+			record := p.newTxtRecord("example.com", "_acme-challenge.www.example.com.", "value", p.ttl())
+
+			assert.Equal(t, "_acme-challenge.www", record.Name)
+			assert.Equal(t, test.expected, record.TTL)
+		})
+	}
+}
+
+func TestDNSProvider_CleanUp(t *testing.T) {
+	mux := http.NewServeMux()
+	server := httptest.NewServer(mux)
+	t.Cleanup(server.Close)
+
+	var deleted url.Values
+	mux.HandleFunc("/Record.Remove", func(rw http.ResponseWriter, req *http.Request) {
+		if req.Method != http.MethodPost {
+			http.Error(rw, fmt.Sprintf("unsupported method: %s", req.Method), http.StatusMethodNotAllowed)
+			return
+		}
+
+		err := req.ParseForm()
+		if err != nil {
+			http.Error(rw, err.Error(), http.StatusBadRequest)
+			return
+		}
+
+		deleted = req.PostForm
+
+		_, _ = fmt.Fprint(rw, `{"status":{"code":"1","message":"Action completed successful"}}`)
+	})
+
+	config := NewDefaultConfig()
+	config.LoginToken = "token"
+
+	p, err := NewDNSProviderConfig(config)
 	require.NoError(t, err)
 
-	err = provider.Present(envTest.GetDomain(), "", "123d==")
+	p.client.BaseURL = server.URL + "/"
+
+	p.records["token"] = txtRecord{zoneID: "123", id: "456"}
+
+	err = p.CleanUp("www.example.com", "token", "123d==")
+	require.NoError(t, err)
+
+	require.NotNil(t, deleted)
+	assert.Equal(t, "123", deleted.Get("domain_id"))
+	assert.Equal(t, "456", deleted.Get("record_id"))
+	assert.Empty(t, p.records)
+}
+
+func TestDNSProvider_CleanUp_unknownRecord(t *testing.T) {
+	config := NewDefaultConfig()
+	config.LoginToken = "token"
+
+	p, err := NewDNSProviderConfig(config)
 	require.NoError(t, err)
+
+	err = p.CleanUp("www.example.com", "token", "123d==")
+	require.EqualError(t, err, "dnspod: unknown record ID for '_acme-challenge.www.example.com.'")
 }
 
-func TestLiveCleanUp(t *testing.T) {
+func TestLivePresentAndCleanUp(t *testing.T) {
 	if !envTest.IsLiveTest() {
 		t.Skip("skipping live test")
 	}
@@ -112,6 +197,9 @@ func TestLiveCleanUp(t *testing.T) {
 	provider, err := NewDNSProvider()
 	require.NoError(t, err)
 
+	err = provider.Present(envTest.GetDomain(), "", "123d==")
+	require.NoError(t, err)
+
 	time.Sleep(1 * time.Second)
 
 	err = provider.CleanUp(envTest.GetDomain(), "", "123d==")
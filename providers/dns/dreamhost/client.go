@@ -1,6 +1,7 @@
 package dreamhost
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 	"io/ioutil"
@@ -43,6 +44,11 @@ func (d *DNSProvider) buildQuery(action, domain, txt string) (*url.URL, error) {
 // updateTxtRecord will either add or remove a TXT record.
 // action is either cmdAddRecord or cmdRemoveRecord.
 func (d *DNSProvider) updateTxtRecord(u fmt.Stringer) error {
+	err := d.limiter.Wait(context.Background())
+	if err != nil {
+		return err
+	}
+
 	resp, err := d.config.HTTPClient.Get(u.String())
 	if err != nil {
 		return err
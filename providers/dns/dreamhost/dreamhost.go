@@ -11,6 +11,8 @@ import (
 
 	"github.com/go-acme/lego/v4/challenge/dns01"
 	"github.com/go-acme/lego/v4/platform/config/env"
+	"github.com/go-acme/lego/v4/providers/dns/internal/useragent"
+	"golang.org/x/time/rate"
 )
 
 // Environment variables names.
@@ -39,15 +41,22 @@ func NewDefaultConfig() *Config {
 		BaseURL:            defaultBaseURL,
 		PropagationTimeout: env.GetOrDefaultSecond(EnvPropagationTimeout, 60*time.Minute),
 		PollingInterval:    env.GetOrDefaultSecond(EnvPollingInterval, 1*time.Minute),
-		HTTPClient: &http.Client{
+		HTTPClient: useragent.Wrap(&http.Client{
 			Timeout: env.GetOrDefaultSecond(EnvHTTPTimeout, 30*time.Second),
-		},
+		}),
 	}
 }
 
+// The API quota is low: the requests are limited to 1 every 2 seconds, with bursts of 3 requests.
+const (
+	limitInterval = 2 * time.Second
+	limitBurst    = 3
+)
+
 // DNSProvider implements the challenge.Provider interface.
 type DNSProvider struct {
-	config *Config
+	config  *Config
+	limiter *rate.Limiter
 }
 
 // NewDNSProvider returns a new DNS provider using
@@ -78,7 +87,10 @@ func NewDNSProviderConfig(config *Config) (*DNSProvider, error) {
 		config.BaseURL = defaultBaseURL
 	}
 
-	return &DNSProvider{config: config}, nil
+	return &DNSProvider{
+		config:  config,
+		limiter: rate.NewLimiter(rate.Every(limitInterval), limitBurst),
+	}, nil
 }
 
 // Present creates a TXT record using the specified parameters.
@@ -10,6 +10,7 @@ import (
 	"github.com/go-acme/lego/v4/platform/tester"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+	"golang.org/x/time/rate"
 )
 
 const envDomain = envNamespace + "DOMAIN"
@@ -179,6 +180,39 @@ func TestDNSProvider_Cleanup(t *testing.T) {
 	require.NoError(t, err, "failed to remove TXT record")
 }
 
+func TestDNSProvider_rateLimit(t *testing.T) {
+	provider, mux, tearDown := setupTest()
+	defer tearDown()
+
+	var calls []time.Time
+	mux.HandleFunc("/", func(w http.ResponseWriter, r *http.Request) {
+		calls = append(calls, time.Now())
+
+		_, err := fmt.Fprintf(w, `{"data":"record_added","result":"success"}`)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+		}
+	})
+
+	provider.limiter = rate.NewLimiter(rate.Every(100*time.Millisecond), 2)
+
+	start := time.Now()
+
+	for i := 0; i < 4; i++ {
+		err := provider.Present("example.com", "", fakeChallengeToken)
+		require.NoError(t, err)
+	}
+
+	require.Len(t, calls, 4)
+
+	// the burst is not delayed.
+	assert.Less(t, int64(calls[1].Sub(start)), int64(100*time.Millisecond))
+
+	// the next requests are paced.
+	assert.GreaterOrEqual(t, int64(calls[2].Sub(start)), int64(90*time.Millisecond))
+	assert.GreaterOrEqual(t, int64(calls[3].Sub(start)), int64(190*time.Millisecond))
+}
+
 func TestLivePresentAndCleanUp(t *testing.T) {
 	if !envTest.IsLiveTest() {
 		t.Skip("skipping live test")
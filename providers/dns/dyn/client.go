@@ -6,6 +6,7 @@ import (
 	"errors"
 	"fmt"
 	"net/http"
+	"strings"
 )
 
 const defaultBaseURL = "https://api.dynect.net/REST"
@@ -24,6 +25,13 @@ type dynResponse struct {
 	Messages json.RawMessage `json:"msgs"`
 }
 
+type dynMessage struct {
+	Info      string `json:"INFO"`
+	Source    string `json:"SOURCE"`
+	ErrorCode string `json:"ERR_CD"`
+	Level     string `json:"LVL"`
+}
+
 type credentials struct {
 	Customer string `json:"customer_name"`
 	User     string `json:"user_name"`
@@ -67,7 +75,7 @@ func (d *DNSProvider) logout() error {
 		return nil
 	}
 
-	url := fmt.Sprintf("%s/Session", defaultBaseURL)
+	url := fmt.Sprintf("%s/Session", d.baseURL)
 	req, err := http.NewRequest(http.MethodDelete, url, nil)
 	if err != nil {
 		return err
@@ -99,7 +107,7 @@ func (d *DNSProvider) publish(zone, notes string) error {
 }
 
 func (d *DNSProvider) sendRequest(method, resource string, payload interface{}) (*dynResponse, error) {
-	url := fmt.Sprintf("%s/%s", defaultBaseURL, resource)
+	url := fmt.Sprintf("%s/%s", d.baseURL, resource)
 
 	body, err := json.Marshal(payload)
 	if err != nil {
@@ -132,6 +140,10 @@ func (d *DNSProvider) sendRequest(method, resource string, payload interface{})
 	}
 
 	if resp.StatusCode >= 400 {
+		if errA := toAuthError(dynRes.Messages); errA != nil {
+			return nil, errA
+		}
+
 		return nil, fmt.Errorf("API request failed with HTTP status code %d: %s", resp.StatusCode, dynRes.Messages)
 	} else if resp.StatusCode == 307 {
 		// TODO add support for HTTP 307 response and long running jobs
@@ -139,9 +151,31 @@ func (d *DNSProvider) sendRequest(method, resource string, payload interface{})
 	}
 
 	if dynRes.Status == "failure" {
+		if errA := toAuthError(dynRes.Messages); errA != nil {
+			return nil, errA
+		}
+
 		// TODO add better error handling
 		return nil, fmt.Errorf("API request failed: %s", dynRes.Messages)
 	}
 
 	return &dynRes, nil
 }
+
+// toAuthError returns a clear error if the messages contain a login failure.
+// A login failure is reported for an invalid customer name, user name, or password,
+// without telling which one is wrong.
+func toAuthError(raw json.RawMessage) error {
+	var messages []dynMessage
+	if err := json.Unmarshal(raw, &messages); err != nil {
+		return nil
+	}
+
+	for _, msg := range messages {
+		if msg.ErrorCode == "INVALID_DATA" && strings.HasPrefix(msg.Info, "login:") {
+			return fmt.Errorf("authentication failed: check the customer name, the user name and the password: %s", msg.Info)
+		}
+	}
+
+	return nil
+}
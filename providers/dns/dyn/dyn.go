@@ -6,10 +6,12 @@ import (
 	"fmt"
 	"net/http"
 	"strconv"
+	"strings"
 	"time"
 
 	"github.com/go-acme/lego/v4/challenge/dns01"
 	"github.com/go-acme/lego/v4/platform/config/env"
+	"github.com/go-acme/lego/v4/providers/dns/internal/useragent"
 )
 
 // Environment variables names.
@@ -43,16 +45,17 @@ func NewDefaultConfig() *Config {
 		TTL:                env.GetOrDefaultInt(EnvTTL, dns01.DefaultTTL),
 		PropagationTimeout: env.GetOrDefaultSecond(EnvPropagationTimeout, dns01.DefaultPropagationTimeout),
 		PollingInterval:    env.GetOrDefaultSecond(EnvPollingInterval, dns01.DefaultPollingInterval),
-		HTTPClient: &http.Client{
+		HTTPClient: useragent.Wrap(&http.Client{
 			Timeout: env.GetOrDefaultSecond(EnvHTTPTimeout, 10*time.Second),
-		},
+		}),
 	}
 }
 
 // DNSProvider implements the challenge.Provider interface.
 type DNSProvider struct {
-	config *Config
-	token  string
+	config  *Config
+	baseURL string
+	token   string
 }
 
 // NewDNSProvider returns a DNSProvider instance configured for Dyn DNS.
@@ -78,11 +81,22 @@ func NewDNSProviderConfig(config *Config) (*DNSProvider, error) {
 		return nil, errors.New("dyn: the configuration of the DNS provider is nil")
 	}
 
-	if config.CustomerName == "" || config.UserName == "" || config.Password == "" {
-		return nil, errors.New("dyn: credentials missing")
+	var missing []string
+	if config.CustomerName == "" {
+		missing = append(missing, "customer name")
+	}
+	if config.UserName == "" {
+		missing = append(missing, "user name")
+	}
+	if config.Password == "" {
+		missing = append(missing, "password")
+	}
+
+	if len(missing) > 0 {
+		return nil, fmt.Errorf("dyn: credentials missing: %s", strings.Join(missing, ", "))
 	}
 
-	return &DNSProvider{config: config}, nil
+	return &DNSProvider{config: config, baseURL: defaultBaseURL}, nil
 }
 
 // Present creates a TXT record using the specified parameters.
@@ -135,7 +149,7 @@ func (d *DNSProvider) CleanUp(domain, token, keyAuth string) error {
 	}
 
 	resource := fmt.Sprintf("TXTRecord/%s/%s/", authZone, fqdn)
-	url := fmt.Sprintf("%s/%s", defaultBaseURL, resource)
+	url := fmt.Sprintf("%s/%s", d.baseURL, resource)
 
 	req, err := http.NewRequest(http.MethodDelete, url, nil)
 	if err != nil {
@@ -1,10 +1,15 @@
 package dyn
 
 import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
 	"testing"
 	"time"
 
 	"github.com/go-acme/lego/v4/platform/tester"
+	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 )
 
@@ -104,28 +109,28 @@ func TestNewDNSProviderConfig(t *testing.T) {
 		},
 		{
 			desc:     "missing credentials",
-			expected: "dyn: credentials missing",
+			expected: "dyn: credentials missing: customer name, user name, password",
 		},
 		{
 			desc:         "missing customer name",
 			customerName: "",
 			password:     "B",
 			userName:     "C",
-			expected:     "dyn: credentials missing",
+			expected:     "dyn: credentials missing: customer name",
 		},
 		{
 			desc:         "missing password",
 			customerName: "A",
 			password:     "",
 			userName:     "C",
-			expected:     "dyn: credentials missing",
+			expected:     "dyn: credentials missing: password",
 		},
 		{
 			desc:         "missing username",
 			customerName: "A",
 			password:     "B",
 			userName:     "",
-			expected:     "dyn: credentials missing",
+			expected:     "dyn: credentials missing: user name",
 		},
 	}
 
@@ -149,6 +154,78 @@ func TestNewDNSProviderConfig(t *testing.T) {
 	}
 }
 
+func TestDNSProvider_login(t *testing.T) {
+	testCases := []struct {
+		desc       string
+		statusCode int
+		response   string
+		expected   string
+	}{
+		{
+			desc:       "success",
+			statusCode: http.StatusOK,
+			response:   `{"status":"success","data":{"token":"secret","version":"3.7.0"},"job_id":1,"msgs":[{"INFO":"login: Login successful","SOURCE":"BLL","ERR_CD":null,"LVL":"INFO"}]}`,
+		},
+		{
+			desc:       "invalid credentials",
+			statusCode: http.StatusBadRequest,
+			response:   `{"status":"failure","data":{},"job_id":1,"msgs":[{"INFO":"login: Credentials you entered did not match those in our database. Please try again","SOURCE":"BLL","ERR_CD":"INVALID_DATA","LVL":"ERROR"},{"INFO":"login: There was a problem with your credentials","SOURCE":"BLL","ERR_CD":null,"LVL":"INFO"}]}`,
+			expected:   "authentication failed: check the customer name, the user name and the password: login: Credentials you entered did not match those in our database. Please try again",
+		},
+		{
+			desc:       "other error",
+			statusCode: http.StatusBadRequest,
+			response:   `{"status":"failure","data":{},"job_id":1,"msgs":[{"INFO":"customer_name: Required field","SOURCE":"API-B","ERR_CD":"MISSING_DATA","LVL":"ERROR"}]}`,
+			expected:   `API request failed with HTTP status code 400: [{"INFO":"customer_name: Required field","SOURCE":"API-B","ERR_CD":"MISSING_DATA","LVL":"ERROR"}]`,
+		},
+	}
+
+	for _, test := range testCases {
+		t.Run(test.desc, func(t *testing.T) {
+			mux := http.NewServeMux()
+			server := httptest.NewServer(mux)
+			defer server.Close()
+
+			mux.HandleFunc("/REST/Session", func(rw http.ResponseWriter, req *http.Request) {
+				if req.Method != http.MethodPost {
+					http.Error(rw, fmt.Sprintf("unsupported method: %s", req.Method), http.StatusMethodNotAllowed)
+					return
+				}
+
+				creds := credentials{}
+				err := json.NewDecoder(req.Body).Decode(&creds)
+				if err != nil {
+					http.Error(rw, err.Error(), http.StatusBadRequest)
+					return
+				}
+
+				assert.Equal(t, credentials{Customer: "A", User: "B", Pass: "C"}, creds)
+
+				rw.WriteHeader(test.statusCode)
+				_, _ = fmt.Fprint(rw, test.response)
+			})
+
+			config := NewDefaultConfig()
+			config.CustomerName = "A"
+			config.UserName = "B"
+			config.Password = "C"
+
+			p, err := NewDNSProviderConfig(config)
+			require.NoError(t, err)
+
+			p.baseURL = server.URL + "/REST"
+
+			err = p.login()
+			if test.expected == "" {
+				require.NoError(t, err)
+				assert.Equal(t, "secret", p.token)
+			} else {
+				require.EqualError(t, err, test.expected)
+			}
+		})
+	}
+}
+
 func TestLivePresent(t *testing.T) {
 	if !envTest.IsLiveTest() {
 		t.Skip("skipping live test")
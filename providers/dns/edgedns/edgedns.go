@@ -5,6 +5,7 @@ import (
 	"errors"
 	"fmt"
 	"strings"
+	"sync"
 	"time"
 
 	configdns "github.com/akamai/AkamaiOPEN-edgegrid-golang/configdns-v2"
@@ -53,7 +54,16 @@ func NewDefaultConfig() *Config {
 
 // DNSProvider implements the challenge.Provider interface.
 type DNSProvider struct {
-	config *Config
+	config  *Config
+	tracker *dns01.RecordTracker
+
+	session   *zoneSession
+	sessionMu sync.Mutex
+
+	// the API calls, overridden during tests.
+	findZone         func(domain string) (string, error)
+	getRecordsets    func(zone string) ([]configdns.Recordset, error)
+	updateRecordsets func(zone string, recordsets []configdns.Recordset) error
 }
 
 // NewDNSProvider uses the supplied environment variables to return a DNSProvider instance:
@@ -85,7 +95,13 @@ func NewDNSProviderConfig(config *Config) (*DNSProvider, error) {
 
 	configdns.Init(config.Config)
 
-	return &DNSProvider{config: config}, nil
+	return &DNSProvider{
+		config:           config,
+		tracker:          dns01.NewRecordTracker(),
+		findZone:         findZone,
+		getRecordsets:    getRecordsets,
+		updateRecordsets: updateRecordsets,
+	}, nil
 }
 
 // Timeout returns the timeout and interval to use when checking for DNS propagation.
@@ -94,24 +110,48 @@ func (d *DNSProvider) Timeout() (timeout, interval time.Duration) {
 	return d.config.PropagationTimeout, d.config.PollingInterval
 }
 
+// Reset drops the per-challenge state (failed records) left by the previous certificates.
+// It allows to reuse the provider across many certificates.
+func (d *DNSProvider) Reset() {
+	d.tracker.Reset()
+}
+
 // Present creates a TXT record to fulfill the dns-01 challenge.
 func (d *DNSProvider) Present(domain, token, keyAuth string) error {
 	fqdn, value := dns01.GetRecord(domain, keyAuth)
 
-	zone, err := findZone(domain)
+	zone, err := d.findZone(domain)
 	if err != nil {
+		d.tracker.NotWritten(domain, token)
 		return fmt.Errorf("edgedns: %w", err)
 	}
 
+	if d.inSession() {
+		// the record sets are written when the session is flushed.
+		err = d.presentInSession(zone, fqdn, value)
+		if err != nil {
+			d.tracker.NotWritten(domain, token)
+			return fmt.Errorf("edgedns: %w", err)
+		}
+
+		d.tracker.Written(domain, token)
+
+		return nil
+	}
+
 	record, err := configdns.GetRecord(zone, fqdn, "TXT")
 	if err != nil && !isNotFound(err) {
+		d.tracker.NotWritten(domain, token)
 		return fmt.Errorf("edgedns: %w", err)
 	}
 
 	if err == nil && record == nil {
+		d.tracker.NotWritten(domain, token)
 		return fmt.Errorf("edgedns: unknown error")
 	}
 
+	d.tracker.Written(domain, token)
+
 	if record != nil {
 		log.Infof("TXT record already exists. Updating target")
 
@@ -127,6 +167,8 @@ func (d *DNSProvider) Present(domain, token, keyAuth string) error {
 		if err != nil {
 			return fmt.Errorf("edgedns: %w", err)
 		}
+
+		return nil
 	}
 
 	record = &configdns.RecordBody{
@@ -146,13 +188,27 @@ func (d *DNSProvider) Present(domain, token, keyAuth string) error {
 
 // CleanUp removes the record matching the specified parameters.
 func (d *DNSProvider) CleanUp(domain, token, keyAuth string) error {
+	// Present failed before writing the record: nothing to delete.
+	if d.tracker.SkipCleanUp(domain, token) {
+		return nil
+	}
+
 	fqdn, value := dns01.GetRecord(domain, keyAuth)
 
-	zone, err := findZone(domain)
+	zone, err := d.findZone(domain)
 	if err != nil {
 		return fmt.Errorf("edgedns: %w", err)
 	}
 
+	if d.inSession() {
+		err = d.cleanUpInSession(zone, fqdn, value)
+		if err != nil {
+			return fmt.Errorf("edgedns: %w", err)
+		}
+
+		return nil
+	}
+
 	existingRec, err := configdns.GetRecord(zone, fqdn, "TXT")
 	if err != nil {
 		if isNotFound(err) {
@@ -199,7 +255,29 @@ func (d *DNSProvider) CleanUp(domain, token, keyAuth string) error {
 	return nil
 }
 
+// findZone finds the most specific zone of the account for the domain.
+// Falls back to the zone found through DNS if the zones cannot be listed.
 func findZone(domain string) (string, error) {
+	resp, err := configdns.ListZones(configdns.ZoneListQueryArgs{ShowAll: true})
+	if err != nil {
+		log.Infof("edgedns: unable to list the zones, falling back to DNS: %v", err)
+		return findZoneByDNS(domain)
+	}
+
+	var names []string
+	for _, zone := range resp.Zones {
+		names = append(names, zone.Zone)
+	}
+
+	zone := dns01.FindMostSpecificZone(domain, names)
+	if zone == "" {
+		return "", fmt.Errorf("no zone found for %s", domain)
+	}
+
+	return dns01.UnFqdn(zone), nil
+}
+
+func findZoneByDNS(domain string) (string, error) {
 	zone, err := dns01.FindZoneByFqdn(dns01.ToFqdn(domain))
 	if err != nil {
 		return "", err
@@ -4,6 +4,8 @@ import (
 	"testing"
 	"time"
 
+	configdns "github.com/akamai/AkamaiOPEN-edgegrid-golang/configdns-v2"
+	"github.com/go-acme/lego/v4/challenge/dns01"
 	"github.com/go-acme/lego/v4/platform/tester"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -210,6 +212,192 @@ func TestDNSProvider_findZone(t *testing.T) {
 	}
 }
 
+func TestDNSProvider_CleanUp_presentFailed(t *testing.T) {
+	config := NewDefaultConfig()
+	config.Host = "127.0.0.1:1"
+	config.ClientToken = "token"
+	config.ClientSecret = "secret"
+	config.AccessToken = "access"
+
+	provider, err := NewDNSProviderConfig(config)
+	require.NoError(t, err)
+
+	// the API is unreachable: the record is not created.
+	err = provider.Present("example.com", "token", "123d==")
+	require.Error(t, err)
+
+	// no API call: the cleanup would fail otherwise.
+	err = provider.CleanUp("example.com", "token", "123d==")
+	require.NoError(t, err)
+}
+
+// zoneSessionAPI is a fake API holding the record sets of the zone example.com.
+type zoneSessionAPI struct {
+	recordsets []configdns.Recordset
+	fetches    int
+	updates    int
+}
+
+func setupZoneSessionTest(t *testing.T, api *zoneSessionAPI) *DNSProvider {
+	t.Helper()
+
+	config := NewDefaultConfig()
+	config.Host = "127.0.0.1:1"
+	config.ClientToken = "token"
+	config.ClientSecret = "secret"
+	config.AccessToken = "access"
+	config.TTL = 120
+
+	provider, err := NewDNSProviderConfig(config)
+	require.NoError(t, err)
+
+	provider.findZone = func(_ string) (string, error) {
+		return "example.com", nil
+	}
+
+	provider.getRecordsets = func(zone string) ([]configdns.Recordset, error) {
+		api.fetches++
+		return append([]configdns.Recordset(nil), api.recordsets...), nil
+	}
+
+	provider.updateRecordsets = func(zone string, recordsets []configdns.Recordset) error {
+		api.updates++
+		api.recordsets = recordsets
+		return nil
+	}
+
+	return provider
+}
+
+func TestDNSProvider_zoneSession(t *testing.T) {
+	api := &zoneSessionAPI{
+		recordsets: []configdns.Recordset{
+			{Name: "example.com", Type: "A", TTL: 300, Rdata: []string{"192.0.2.1"}},
+		},
+	}
+
+	provider := setupZoneSessionTest(t, api)
+
+	// a multi-SAN certificate: example.com, *.example.com (same record name), www.example.com.
+	provider.BeginZoneSession()
+
+	require.NoError(t, provider.Present("example.com", "token1", "123d=="))
+	require.NoError(t, provider.Present("example.com", "token2", "456d=="))
+	require.NoError(t, provider.Present("www.example.com", "token3", "789d=="))
+
+	assert.Equal(t, 1, api.fetches)
+	assert.Equal(t, 0, api.updates)
+
+	require.NoError(t, provider.FlushZoneSession())
+
+	assert.Equal(t, 1, api.fetches)
+	assert.Equal(t, 1, api.updates)
+
+	_, value1 := dns01.GetRecord("example.com", "123d==")
+	_, value2 := dns01.GetRecord("example.com", "456d==")
+	_, value3 := dns01.GetRecord("www.example.com", "789d==")
+
+	expected := []configdns.Recordset{
+		{Name: "example.com", Type: "A", TTL: 300, Rdata: []string{"192.0.2.1"}},
+		{Name: "_acme-challenge.example.com", Type: "TXT", TTL: 120, Rdata: []string{`"` + value1 + `"`, `"` + value2 + `"`}},
+		{Name: "_acme-challenge.www.example.com", Type: "TXT", TTL: 120, Rdata: []string{`"` + value3 + `"`}},
+	}
+	assert.Equal(t, expected, api.recordsets)
+
+	provider.BeginZoneSession()
+
+	require.NoError(t, provider.CleanUp("example.com", "token1", "123d=="))
+	require.NoError(t, provider.CleanUp("example.com", "token2", "456d=="))
+	require.NoError(t, provider.CleanUp("www.example.com", "token3", "789d=="))
+
+	require.NoError(t, provider.FlushZoneSession())
+
+	assert.Equal(t, 2, api.fetches)
+	assert.Equal(t, 2, api.updates)
+
+	expected = []configdns.Recordset{
+		{Name: "example.com", Type: "A", TTL: 300, Rdata: []string{"192.0.2.1"}},
+	}
+	assert.Equal(t, expected, api.recordsets)
+}
+
+func TestDNSProvider_zoneSession_sharedName(t *testing.T) {
+	api := &zoneSessionAPI{
+		recordsets: []configdns.Recordset{
+			{Name: "_acme-challenge.example.com", Type: "TXT", TTL: 120, Rdata: []string{`"other"`}},
+		},
+	}
+
+	provider := setupZoneSessionTest(t, api)
+
+	provider.BeginZoneSession()
+	require.NoError(t, provider.Present("example.com", "token", "123d=="))
+	require.NoError(t, provider.FlushZoneSession())
+
+	provider.BeginZoneSession()
+	require.NoError(t, provider.CleanUp("example.com", "token", "123d=="))
+	require.NoError(t, provider.FlushZoneSession())
+
+	// the value of another process is kept.
+	expected := []configdns.Recordset{
+		{Name: "_acme-challenge.example.com", Type: "TXT", TTL: 120, Rdata: []string{`"other"`}},
+	}
+	assert.Equal(t, expected, api.recordsets)
+}
+
+func TestDNSProvider_CleanUp_restarted(t *testing.T) {
+	_, value := dns01.GetRecord("example.com", "123d==")
+
+	// the record has been created by a previous process.
+	api := &zoneSessionAPI{
+		recordsets: []configdns.Recordset{
+			{Name: "_acme-challenge.example.com", Type: "TXT", TTL: 120, Rdata: []string{`"` + value + `"`}},
+		},
+	}
+
+	provider := setupZoneSessionTest(t, api)
+
+	provider.BeginZoneSession()
+	require.NoError(t, provider.CleanUp("example.com", "token", "123d=="))
+	require.NoError(t, provider.FlushZoneSession())
+
+	assert.Empty(t, api.recordsets)
+}
+
+func TestDNSProvider_Reset(t *testing.T) {
+	api := &zoneSessionAPI{}
+
+	provider := setupZoneSessionTest(t, api)
+
+	// first certificate: the record has been created, but the cleanup has been interrupted.
+	provider.BeginZoneSession()
+	require.NoError(t, provider.Present("example.com", "token1", "123d=="))
+	require.NoError(t, provider.FlushZoneSession())
+
+	provider.Reset()
+
+	// second certificate, with the same provider: the record of the first certificate is still removed.
+	provider.BeginZoneSession()
+	require.NoError(t, provider.Present("www.example.com", "token2", "456d=="))
+	require.NoError(t, provider.CleanUp("www.example.com", "token2", "456d=="))
+	require.NoError(t, provider.CleanUp("example.com", "token1", "123d=="))
+	require.NoError(t, provider.FlushZoneSession())
+
+	assert.Empty(t, api.recordsets)
+}
+
+func TestDNSProvider_FlushZoneSession_noChange(t *testing.T) {
+	api := &zoneSessionAPI{}
+
+	provider := setupZoneSessionTest(t, api)
+
+	provider.BeginZoneSession()
+	require.NoError(t, provider.FlushZoneSession())
+
+	assert.Equal(t, 0, api.fetches)
+	assert.Equal(t, 0, api.updates)
+}
+
 func TestLivePresent(t *testing.T) {
 	if !envTest.IsLiveTest() {
 		t.Skip("skipping live test")
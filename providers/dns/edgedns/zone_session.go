@@ -0,0 +1,173 @@
+package edgedns
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	configdns "github.com/akamai/AkamaiOPEN-edgegrid-golang/configdns-v2"
+	"github.com/go-acme/lego/v4/challenge/dns01"
+)
+
+// zoneSession holds the record sets of the zones changed during a session.
+type zoneSession struct {
+	zones map[string]*sessionZone
+}
+
+type sessionZone struct {
+	recordsets []configdns.Recordset
+	changed    bool
+}
+
+// BeginZoneSession starts a session: Present and CleanUp only change the record sets in memory,
+// each zone is fetched once.
+func (d *DNSProvider) BeginZoneSession() {
+	d.sessionMu.Lock()
+	d.session = &zoneSession{zones: make(map[string]*sessionZone)}
+	d.sessionMu.Unlock()
+}
+
+// FlushZoneSession saves the record sets of each changed zone with one update, and ends the session.
+func (d *DNSProvider) FlushZoneSession() error {
+	d.sessionMu.Lock()
+	session := d.session
+	d.session = nil
+	d.sessionMu.Unlock()
+
+	if session == nil {
+		return nil
+	}
+
+	var zones []string
+	for zone, state := range session.zones {
+		if state.changed {
+			zones = append(zones, zone)
+		}
+	}
+
+	sort.Strings(zones)
+
+	for _, zone := range zones {
+		err := d.updateRecordsets(zone, session.zones[zone].recordsets)
+		if err != nil {
+			return fmt.Errorf("edgedns: failed to update the record sets of %s: %w", zone, err)
+		}
+	}
+
+	return nil
+}
+
+func (d *DNSProvider) inSession() bool {
+	d.sessionMu.Lock()
+	defer d.sessionMu.Unlock()
+
+	return d.session != nil
+}
+
+// presentInSession adds the value to the TXT record set of the fqdn.
+func (d *DNSProvider) presentInSession(zone, fqdn, value string) error {
+	d.sessionMu.Lock()
+	defer d.sessionMu.Unlock()
+
+	state, err := d.sessionZone(zone)
+	if err != nil {
+		return err
+	}
+
+	name := dns01.UnFqdn(fqdn)
+
+	for i, recordset := range state.recordsets {
+		if recordset.Type != "TXT" || !strings.EqualFold(recordset.Name, name) {
+			continue
+		}
+
+		if containsValue(recordset.Rdata, value) {
+			return nil
+		}
+
+		state.recordsets[i].Rdata = append(recordset.Rdata, `"`+value+`"`)
+		state.recordsets[i].TTL = d.config.TTL
+		state.changed = true
+
+		return nil
+	}
+
+	state.recordsets = append(state.recordsets, configdns.Recordset{
+		Name:  name,
+		Type:  "TXT",
+		TTL:   d.config.TTL,
+		Rdata: []string{`"` + value + `"`},
+	})
+	state.changed = true
+
+	return nil
+}
+
+// cleanUpInSession removes the value from the TXT record set of the fqdn,
+// and removes the record set when it has no other value.
+func (d *DNSProvider) cleanUpInSession(zone, fqdn, value string) error {
+	d.sessionMu.Lock()
+	defer d.sessionMu.Unlock()
+
+	state, err := d.sessionZone(zone)
+	if err != nil {
+		return err
+	}
+
+	name := dns01.UnFqdn(fqdn)
+
+	for i, recordset := range state.recordsets {
+		if recordset.Type != "TXT" || !strings.EqualFold(recordset.Name, name) || !containsValue(recordset.Rdata, value) {
+			continue
+		}
+
+		var rdata []string
+		for _, val := range recordset.Rdata {
+			if strings.Trim(val, `"`) != value {
+				rdata = append(rdata, val)
+			}
+		}
+
+		if len(rdata) > 0 {
+			state.recordsets[i].Rdata = rdata
+		} else {
+			state.recordsets = append(state.recordsets[:i], state.recordsets[i+1:]...)
+		}
+
+		state.changed = true
+
+		return nil
+	}
+
+	return nil
+}
+
+// sessionZone returns the record sets of the zone, fetched on the first use during the session.
+func (d *DNSProvider) sessionZone(zone string) (*sessionZone, error) {
+	if state, ok := d.session.zones[zone]; ok {
+		return state, nil
+	}
+
+	recordsets, err := d.getRecordsets(zone)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get the record sets of %s: %w", zone, err)
+	}
+
+	state := &sessionZone{recordsets: recordsets}
+	d.session.zones[zone] = state
+
+	return state, nil
+}
+
+func getRecordsets(zone string) ([]configdns.Recordset, error) {
+	resp, err := configdns.GetRecordsets(zone, configdns.RecordsetQueryArgs{ShowAll: true})
+	if err != nil {
+		return nil, err
+	}
+
+	return resp.Recordsets, nil
+}
+
+func updateRecordsets(zone string, recordsets []configdns.Recordset) error {
+	return (&configdns.Recordsets{Recordsets: recordsets}).Update(zone)
+}
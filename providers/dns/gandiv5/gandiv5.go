@@ -5,12 +5,12 @@ import (
 	"errors"
 	"fmt"
 	"net/http"
-	"strings"
 	"sync"
 	"time"
 
 	"github.com/go-acme/lego/v4/challenge/dns01"
 	"github.com/go-acme/lego/v4/platform/config/env"
+	"github.com/go-acme/lego/v4/providers/dns/internal/useragent"
 )
 
 // Gandi API reference:       http://doc.livedns.gandi.net/
@@ -55,9 +55,9 @@ func NewDefaultConfig() *Config {
 		TTL:                env.GetOrDefaultInt(EnvTTL, minTTL),
 		PropagationTimeout: env.GetOrDefaultSecond(EnvPropagationTimeout, 20*time.Minute),
 		PollingInterval:    env.GetOrDefaultSecond(EnvPollingInterval, 20*time.Second),
-		HTTPClient: &http.Client{
+		HTTPClient: useragent.Wrap(&http.Client{
 			Timeout: env.GetOrDefaultSecond(EnvHTTPTimeout, 10*time.Second),
-		},
+		}),
 	}
 }
 
@@ -68,6 +68,7 @@ type DNSProvider struct {
 	inProgressMu    sync.Mutex
 	// findZoneByFqdn determines the DNS zone of an fqdn. It is overridden during tests.
 	findZoneByFqdn func(fqdn string) (string, error)
+	zoneRewriter   dns01.ZoneRewriter
 }
 
 // NewDNSProvider returns a DNSProvider instance configured for Gandi.
@@ -109,6 +110,11 @@ func NewDNSProviderConfig(config *Config) (*DNSProvider, error) {
 	}, nil
 }
 
+// SetZoneRewriter sets the rewriter of the zones of the records (see dns01.AddZoneRewriter).
+func (d *DNSProvider) SetZoneRewriter(rewriter dns01.ZoneRewriter) {
+	d.zoneRewriter = rewriter
+}
+
 // Present creates a TXT record using the specified parameters.
 func (d *DNSProvider) Present(domain, token, keyAuth string) error {
 	fqdn, value := dns01.GetRecord(domain, keyAuth)
@@ -119,12 +125,11 @@ func (d *DNSProvider) Present(domain, token, keyAuth string) error {
 		return fmt.Errorf("gandiv5: findZoneByFqdn failure: %w", err)
 	}
 
-	// determine name of TXT record
-	if !strings.HasSuffix(
-		strings.ToLower(fqdn), strings.ToLower("."+authZone)) {
-		return fmt.Errorf("gandiv5: unexpected authZone %s for fqdn %s", authZone, fqdn)
+	// determine name of TXT record, and the zone to write to (see dns01.AddZoneRewriter)
+	authZone, name, err := dns01.RewriteZone(fqdn, authZone, d.zoneRewriter)
+	if err != nil {
+		return fmt.Errorf("gandiv5: unexpected authZone: %w", err)
 	}
-	name := fqdn[:len(fqdn)-len("."+authZone)]
 
 	// acquire lock and check there is not a challenge already in
 	// progress for this value of authZone
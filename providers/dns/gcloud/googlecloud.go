@@ -8,6 +8,7 @@ import (
 	"io/ioutil"
 	"net/http"
 	"strconv"
+	"strings"
 	"time"
 
 	"cloud.google.com/go/compute/metadata"
@@ -15,7 +16,9 @@ import (
 	"github.com/go-acme/lego/v4/log"
 	"github.com/go-acme/lego/v4/platform/config/env"
 	"github.com/go-acme/lego/v4/platform/wait"
+	"github.com/go-acme/lego/v4/providers/dns/internal/useragent"
 	"golang.org/x/net/context"
+	"golang.org/x/oauth2"
 	"golang.org/x/oauth2/google"
 	"google.golang.org/api/dns/v1"
 	"google.golang.org/api/googleapi"
@@ -32,6 +35,7 @@ const (
 
 	EnvServiceAccount   = envNamespace + "SERVICE_ACCOUNT"
 	EnvProject          = envNamespace + "PROJECT"
+	EnvZoneProjects     = envNamespace + "ZONE_PROJECTS"
 	EnvAllowPrivateZone = envNamespace + "ALLOW_PRIVATE_ZONE"
 	EnvDebug            = envNamespace + "DEBUG"
 
@@ -49,6 +53,10 @@ type Config struct {
 	PollingInterval    time.Duration
 	TTL                int
 	HTTPClient         *http.Client
+
+	// ZoneProjects the projects of the managed zones (zone DNS name => project),
+	// used when a zone is in another project than Project (ex: the host project of a shared VPC).
+	ZoneProjects map[string]string
 }
 
 // NewDefaultConfig returns a default configuration for the DNSProvider.
@@ -66,10 +74,13 @@ func NewDefaultConfig() *Config {
 type DNSProvider struct {
 	config *Config
 	client *dns.Service
+
+	// findZoneByFqdn determines the DNS zone of an fqdn. It is overridden during tests.
+	findZoneByFqdn func(fqdn string) (string, error)
 }
 
 // NewDNSProvider returns a DNSProvider instance configured for Google Cloud DNS.
-// By default, the project name is auto-detected by using the metadata service,
+// By default, the project name is the project of the credentials, or is auto-detected by using the metadata service,
 // it can be overridden using the GCE_PROJECT environment variable.
 // A Service Account can be passed in the environment variable: GCE_SERVICE_ACCOUNT
 // or by specifying the keyfile location: GCE_SERVICE_ACCOUNT_FILE.
@@ -80,25 +91,41 @@ func NewDNSProvider() (*DNSProvider, error) {
 	}
 
 	// Use default credentials.
-	project := env.GetOrDefaultString(EnvProject, autodetectProjectID())
+	project := env.GetOrDefaultString(EnvProject, "")
 	return NewDNSProviderCredentials(project)
 }
 
-// NewDNSProviderCredentials uses the supplied credentials
+// NewDNSProviderCredentials uses the Application Default Credentials
 // to return a DNSProvider instance configured for Google Cloud DNS.
+// The project is the project of the zones, it can be different from the project of the credentials.
+// If the project is empty, the project of the credentials is used.
 func NewDNSProviderCredentials(project string) (*DNSProvider, error) {
+	credentials, err := google.FindDefaultCredentials(context.Background(), dns.NdevClouddnsReadwriteScope)
+	if err != nil {
+		return nil, fmt.Errorf("googlecloud: unable to get Google Cloud client: %w", err)
+	}
+
+	if project == "" {
+		project = credentials.ProjectID
+	}
+
+	if project == "" {
+		project = autodetectProjectID()
+	}
+
 	if project == "" {
 		return nil, errors.New("googlecloud: project name missing")
 	}
 
-	client, err := google.DefaultClient(context.Background(), dns.NdevClouddnsReadwriteScope)
+	zoneProjects, err := parseZoneProjects(env.GetOrFile(EnvZoneProjects))
 	if err != nil {
-		return nil, fmt.Errorf("googlecloud: unable to get Google Cloud client: %w", err)
+		return nil, fmt.Errorf("googlecloud: %w", err)
 	}
 
 	config := NewDefaultConfig()
 	config.Project = project
-	config.HTTPClient = client
+	config.ZoneProjects = zoneProjects
+	config.HTTPClient = useragent.Wrap(oauth2.NewClient(context.Background(), credentials.TokenSource))
 
 	return NewDNSProviderConfig(config)
 }
@@ -129,10 +156,16 @@ func NewDNSProviderServiceAccountKey(saKey []byte) (*DNSProvider, error) {
 	if err != nil {
 		return nil, fmt.Errorf("googlecloud: unable to acquire config: %w", err)
 	}
-	client := conf.Client(context.Background())
+	client := useragent.Wrap(conf.Client(context.Background()))
+
+	zoneProjects, err := parseZoneProjects(env.GetOrFile(EnvZoneProjects))
+	if err != nil {
+		return nil, fmt.Errorf("googlecloud: %w", err)
+	}
 
 	config := NewDefaultConfig()
 	config.Project = project
+	config.ZoneProjects = zoneProjects
 	config.HTTPClient = client
 
 	return NewDNSProviderConfig(config)
@@ -167,20 +200,20 @@ func NewDNSProviderConfig(config *Config) (*DNSProvider, error) {
 		return nil, fmt.Errorf("googlecloud: unable to create Google Cloud DNS service: %w", err)
 	}
 
-	return &DNSProvider{config: config, client: svc}, nil
+	return &DNSProvider{config: config, client: svc, findZoneByFqdn: dns01.FindZoneByFqdn}, nil
 }
 
 // Present creates a TXT record to fulfill the dns-01 challenge.
 func (d *DNSProvider) Present(domain, token, keyAuth string) error {
 	fqdn, value := dns01.GetRecord(domain, keyAuth)
 
-	zone, err := d.getHostedZone(fqdn)
+	project, zone, err := d.getHostedZone(fqdn)
 	if err != nil {
 		return fmt.Errorf("googlecloud: %w", err)
 	}
 
 	// Look for existing records.
-	existingRrSet, err := d.findTxtRecords(zone, fqdn)
+	existingRrSet, err := d.findTxtRecords(project, zone, fqdn)
 	if err != nil {
 		return fmt.Errorf("googlecloud: %w", err)
 	}
@@ -201,7 +234,7 @@ func (d *DNSProvider) Present(domain, token, keyAuth string) error {
 
 	// Attempt to delete the existing records before adding the new one.
 	if len(existingRrSet) > 0 {
-		if err = d.applyChanges(zone, &dns.Change{Deletions: existingRrSet}); err != nil {
+		if err = d.applyChanges(project, zone, &dns.Change{Deletions: existingRrSet}); err != nil {
 			return fmt.Errorf("googlecloud: %w", err)
 		}
 	}
@@ -226,20 +259,20 @@ func (d *DNSProvider) Present(domain, token, keyAuth string) error {
 		Additions: []*dns.ResourceRecordSet{rec},
 	}
 
-	if err = d.applyChanges(zone, change); err != nil {
+	if err = d.applyChanges(project, zone, change); err != nil {
 		return fmt.Errorf("googlecloud: %w", err)
 	}
 
 	return nil
 }
 
-func (d *DNSProvider) applyChanges(zone string, change *dns.Change) error {
+func (d *DNSProvider) applyChanges(project, zone string, change *dns.Change) error {
 	if d.config.Debug {
 		data, _ := json.Marshal(change)
 		log.Printf("change (Create): %s", string(data))
 	}
 
-	chg, err := d.client.Changes.Create(d.config.Project, zone, change).Do()
+	chg, err := d.client.Changes.Create(project, zone, change).Do()
 	if err != nil {
 		if v, ok := err.(*googleapi.Error); ok {
 			if v.Code == http.StatusNotFound {
@@ -264,7 +297,7 @@ func (d *DNSProvider) applyChanges(zone string, change *dns.Change) error {
 			log.Printf("change (Get): %s", string(data))
 		}
 
-		chg, err = d.client.Changes.Get(d.config.Project, zone, chgID).Do()
+		chg, err = d.client.Changes.Get(project, zone, chgID).Do()
 		if err != nil {
 			data, _ := json.Marshal(change)
 			return false, fmt.Errorf("failed to get changes [zone %s, change %s]: %w", zone, string(data), err)
@@ -282,12 +315,12 @@ func (d *DNSProvider) applyChanges(zone string, change *dns.Change) error {
 func (d *DNSProvider) CleanUp(domain, token, keyAuth string) error {
 	fqdn, _ := dns01.GetRecord(domain, keyAuth)
 
-	zone, err := d.getHostedZone(fqdn)
+	project, zone, err := d.getHostedZone(fqdn)
 	if err != nil {
 		return fmt.Errorf("googlecloud: %w", err)
 	}
 
-	records, err := d.findTxtRecords(zone, fqdn)
+	records, err := d.findTxtRecords(project, zone, fqdn)
 	if err != nil {
 		return fmt.Errorf("googlecloud: %w", err)
 	}
@@ -296,7 +329,7 @@ func (d *DNSProvider) CleanUp(domain, token, keyAuth string) error {
 		return nil
 	}
 
-	_, err = d.client.Changes.Create(d.config.Project, zone, &dns.Change{Deletions: records}).Do()
+	_, err = d.client.Changes.Create(project, zone, &dns.Change{Deletions: records}).Do()
 	if err != nil {
 		return fmt.Errorf("googlecloud: %w", err)
 	}
@@ -309,40 +342,53 @@ func (d *DNSProvider) Timeout() (timeout, interval time.Duration) {
 	return d.config.PropagationTimeout, d.config.PollingInterval
 }
 
-// getHostedZone returns the managed-zone.
-func (d *DNSProvider) getHostedZone(domain string) (string, error) {
-	authZone, err := dns01.FindZoneByFqdn(dns01.ToFqdn(domain))
+// getHostedZone returns the project and the name of the managed-zone.
+func (d *DNSProvider) getHostedZone(domain string) (string, string, error) {
+	authZone, err := d.findZoneByFqdn(dns01.ToFqdn(domain))
 	if err != nil {
-		return "", err
+		return "", "", err
 	}
 
+	project := d.zoneProject(authZone)
+
 	zones, err := d.client.ManagedZones.
-		List(d.config.Project).
+		List(project).
 		DnsName(authZone).
 		Do()
 	if err != nil {
-		return "", fmt.Errorf("API call failed: %w", err)
+		return "", "", fmt.Errorf("API call failed: %w", err)
 	}
 
 	if len(zones.ManagedZones) == 0 {
-		return "", fmt.Errorf("no matching domain found for domain %s", authZone)
+		return "", "", fmt.Errorf("no matching domain found for domain %s (project %s)", authZone, project)
 	}
 
 	for _, z := range zones.ManagedZones {
 		if z.Visibility == "public" || z.Visibility == "" || (z.Visibility == "private" && d.config.AllowPrivateZone) {
-			return z.Name, nil
+			return project, z.Name, nil
 		}
 	}
 
 	if d.config.AllowPrivateZone {
-		return "", fmt.Errorf("no public or private zone found for domain %s", authZone)
+		return "", "", fmt.Errorf("no public or private zone found for domain %s", authZone)
+	}
+
+	return "", "", fmt.Errorf("no public zone found for domain %s", authZone)
+}
+
+// zoneProject returns the project of the zone, the project of the configuration by default.
+func (d *DNSProvider) zoneProject(authZone string) string {
+	for zone, project := range d.config.ZoneProjects {
+		if strings.EqualFold(dns01.ToFqdn(zone), authZone) {
+			return project
+		}
 	}
 
-	return "", fmt.Errorf("no public zone found for domain %s", authZone)
+	return d.config.Project
 }
 
-func (d *DNSProvider) findTxtRecords(zone, fqdn string) ([]*dns.ResourceRecordSet, error) {
-	recs, err := d.client.ResourceRecordSets.List(d.config.Project, zone).Name(fqdn).Type("TXT").Do()
+func (d *DNSProvider) findTxtRecords(project, zone, fqdn string) ([]*dns.ResourceRecordSet, error) {
+	recs, err := d.client.ResourceRecordSets.List(project, zone).Name(fqdn).Type("TXT").Do()
 	if err != nil {
 		return nil, err
 	}
@@ -350,6 +396,25 @@ func (d *DNSProvider) findTxtRecords(zone, fqdn string) ([]*dns.ResourceRecordSe
 	return recs.Rrsets, nil
 }
 
+// Parses the projects of the zones (ex: "example.com:host-project,example.org:other-project").
+func parseZoneProjects(raw string) (map[string]string, error) {
+	if raw == "" {
+		return nil, nil
+	}
+
+	zoneProjects := make(map[string]string)
+	for _, item := range strings.Split(raw, ",") {
+		parts := strings.SplitN(item, ":", 2)
+		if len(parts) != 2 || strings.TrimSpace(parts[0]) == "" || strings.TrimSpace(parts[1]) == "" {
+			return nil, fmt.Errorf("invalid zone project: %q", item)
+		}
+
+		zoneProjects[strings.TrimSpace(parts[0])] = strings.TrimSpace(parts[1])
+	}
+
+	return zoneProjects, nil
+}
+
 func mustUnquote(raw string) string {
 	clean, err := strconv.Unquote(raw)
 	if err != nil {
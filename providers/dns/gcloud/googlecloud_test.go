@@ -10,10 +10,12 @@ import (
 	"time"
 
 	"github.com/go-acme/lego/v4/platform/tester"
+	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 	"golang.org/x/net/context"
 	"golang.org/x/oauth2/google"
 	"google.golang.org/api/dns/v1"
+	"google.golang.org/api/option"
 )
 
 const (
@@ -27,6 +29,7 @@ const (
 
 var envTest = tester.NewEnvTest(
 	EnvProject,
+	EnvZoneProjects,
 	envServiceAccountFile,
 	envGoogleApplicationCredentials,
 	envMetadataHost,
@@ -101,6 +104,153 @@ func TestNewDNSProvider(t *testing.T) {
 	}
 }
 
+func TestNewDNSProvider_project(t *testing.T) {
+	testCases := []struct {
+		desc     string
+		envVars  map[string]string
+		expected string
+	}{
+		{
+			desc: "project of the credentials",
+			envVars: map[string]string{
+				envGoogleApplicationCredentials: "fixtures/gce_account_service_file.json",
+			},
+			expected: "A",
+		},
+		{
+			desc: "override",
+			envVars: map[string]string{
+				EnvProject:                      "dns-project",
+				envGoogleApplicationCredentials: "fixtures/gce_account_service_file.json",
+			},
+			expected: "dns-project",
+		},
+	}
+
+	for _, test := range testCases {
+		t.Run(test.desc, func(t *testing.T) {
+			defer envTest.RestoreEnv()
+			envTest.ClearEnv()
+
+			envTest.Apply(test.envVars)
+
+			p, err := NewDNSProvider()
+			require.NoError(t, err)
+
+			assert.Equal(t, test.expected, p.config.Project)
+
+			// the API calls use the project of the configuration.
+			mux := http.NewServeMux()
+			server := httptest.NewServer(mux)
+			defer server.Close()
+
+			mux.HandleFunc("/"+test.expected+"/managedZones/test/rrsets", func(w http.ResponseWriter, r *http.Request) {
+				err := json.NewEncoder(w).Encode(&dns.ResourceRecordSetsListResponse{})
+				if err != nil {
+					http.Error(w, err.Error(), http.StatusInternalServerError)
+					return
+				}
+			})
+
+			// the credentials of the fixture cannot be used to get a token.
+			p.client, err = dns.NewService(context.Background(), option.WithHTTPClient(&http.Client{}))
+			require.NoError(t, err)
+
+			p.client.BasePath = server.URL
+
+			_, err = p.findTxtRecords(p.config.Project, "test", "_acme-challenge.lego.wtf.")
+			require.NoError(t, err)
+		})
+	}
+}
+
+func TestNewDNSProvider_zoneProjects(t *testing.T) {
+	defer envTest.RestoreEnv()
+	envTest.ClearEnv()
+
+	envTest.Apply(map[string]string{
+		EnvProject:                      "service-project",
+		EnvZoneProjects:                 "example.com:host-project, example.org:other-project",
+		envGoogleApplicationCredentials: "fixtures/gce_account_service_file.json",
+	})
+
+	p, err := NewDNSProvider()
+	require.NoError(t, err)
+
+	expected := map[string]string{
+		"example.com": "host-project",
+		"example.org": "other-project",
+	}
+	assert.Equal(t, expected, p.config.ZoneProjects)
+}
+
+func TestParseZoneProjects(t *testing.T) {
+	testCases := []struct {
+		desc     string
+		raw      string
+		expected map[string]string
+		err      string
+	}{
+		{
+			desc: "empty",
+		},
+		{
+			desc:     "one zone",
+			raw:      "example.com:host-project",
+			expected: map[string]string{"example.com": "host-project"},
+		},
+		{
+			desc:     "several zones",
+			raw:      "example.com:host-project,example.org.:other-project",
+			expected: map[string]string{"example.com": "host-project", "example.org.": "other-project"},
+		},
+		{
+			desc: "missing project",
+			raw:  "example.com:host-project,example.org",
+			err:  `invalid zone project: "example.org"`,
+		},
+		{
+			desc: "empty project",
+			raw:  "example.com:",
+			err:  `invalid zone project: "example.com:"`,
+		},
+	}
+
+	for _, test := range testCases {
+		test := test
+		t.Run(test.desc, func(t *testing.T) {
+			t.Parallel()
+
+			zoneProjects, err := parseZoneProjects(test.raw)
+			if test.err != "" {
+				require.EqualError(t, err, test.err)
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, test.expected, zoneProjects)
+		})
+	}
+}
+
+func TestDNSProvider_zoneProject(t *testing.T) {
+	config := NewDefaultConfig()
+	config.HTTPClient = &http.Client{}
+	config.Project = "service-project"
+	config.ZoneProjects = map[string]string{
+		"example.com":  "host-project",
+		"example.org.": "other-project",
+	}
+
+	p, err := NewDNSProviderConfig(config)
+	require.NoError(t, err)
+
+	assert.Equal(t, "host-project", p.zoneProject("example.com."))
+	assert.Equal(t, "host-project", p.zoneProject("EXAMPLE.com."))
+	assert.Equal(t, "other-project", p.zoneProject("example.org."))
+	assert.Equal(t, "service-project", p.zoneProject("example.net."))
+}
+
 func TestNewDNSProviderConfig(t *testing.T) {
 	testCases := []struct {
 		desc     string
@@ -383,6 +533,84 @@ func TestPresentSkipExistingRR(t *testing.T) {
 	require.NoError(t, err)
 }
 
+func TestPresentZoneProject(t *testing.T) {
+	mux := http.NewServeMux()
+
+	// getHostedZone: /host-project/managedZones?alt=json&dnsName=lego.wtf.
+	mux.HandleFunc("/host-project/managedZones", func(w http.ResponseWriter, r *http.Request) {
+		mzlrs := &dns.ManagedZonesListResponse{
+			ManagedZones: []*dns.ManagedZone{
+				{Name: "test", Visibility: "public"},
+			},
+		}
+
+		err := json.NewEncoder(w).Encode(mzlrs)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+	})
+
+	// findTxtRecords: /host-project/managedZones/test/rrsets?alt=json&name=_acme-challenge.lego.wtf.&type=TXT
+	mux.HandleFunc("/host-project/managedZones/test/rrsets", func(w http.ResponseWriter, r *http.Request) {
+		err := json.NewEncoder(w).Encode(&dns.ResourceRecordSetsListResponse{})
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+	})
+
+	var created bool
+
+	// applyChanges [Create]: /host-project/managedZones/test/changes?alt=json
+	mux.HandleFunc("/host-project/managedZones/test/changes", func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost {
+			http.Error(w, http.StatusText(http.StatusMethodNotAllowed), http.StatusMethodNotAllowed)
+			return
+		}
+
+		var chgReq dns.Change
+		if err := json.NewDecoder(r.Body).Decode(&chgReq); err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+
+		created = true
+
+		chgResp := chgReq
+		chgResp.Status = changeStatusDone
+
+		if err := json.NewEncoder(w).Encode(chgResp); err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+	})
+
+	// the project of the configuration must not be used for the zone.
+	mux.HandleFunc("/service-project/", func(w http.ResponseWriter, r *http.Request) {
+		http.Error(w, "unexpected project", http.StatusForbidden)
+	})
+
+	server := httptest.NewServer(mux)
+	defer server.Close()
+
+	config := NewDefaultConfig()
+	config.HTTPClient = &http.Client{}
+	config.Project = "service-project"
+	config.ZoneProjects = map[string]string{"lego.wtf": "host-project"}
+
+	p, err := NewDNSProviderConfig(config)
+	require.NoError(t, err)
+
+	p.client.BasePath = server.URL
+	p.findZoneByFqdn = func(_ string) (string, error) { return "lego.wtf.", nil }
+
+	err = p.Present("lego.wtf", "", "")
+	require.NoError(t, err)
+
+	assert.True(t, created)
+}
+
 func TestLivePresent(t *testing.T) {
 	if !envTest.IsLiveTest() {
 		t.Skip("skipping live test")
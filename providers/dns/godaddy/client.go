@@ -8,6 +8,14 @@ import (
 	"io/ioutil"
 	"net/http"
 	"path"
+	"strconv"
+	"time"
+)
+
+const (
+	maxRetries        = 5
+	defaultRetryDelay = 1 * time.Second
+	maxRetryDelay     = 1 * time.Minute
 )
 
 // DNSRecord a DNS record.
@@ -68,8 +76,11 @@ func (d *DNSProvider) updateTxtRecords(records []DNSRecord, domainZone, recordNa
 	return nil
 }
 
+// makeRequest sends a request to the API.
+// The requests are throttled below the rate limit of the API,
+// and the rate-limited requests (429 Too Many Requests) are retried after the delay requested by the API.
 func (d *DNSProvider) makeRequest(method, uri string, body io.Reader) (*http.Response, error) {
-	req, err := http.NewRequest(method, fmt.Sprintf("%s%s", defaultBaseURL, uri), body)
+	req, err := http.NewRequest(method, fmt.Sprintf("%s%s", d.config.BaseURL, uri), body)
 	if err != nil {
 		return nil, err
 	}
@@ -78,5 +89,63 @@ func (d *DNSProvider) makeRequest(method, uri string, body io.Reader) (*http.Res
 	req.Header.Set("Content-Type", "application/json")
 	req.Header.Set("Authorization", fmt.Sprintf("sso-key %s:%s", d.config.APIKey, d.config.APISecret))
 
-	return d.config.HTTPClient.Do(req)
+	for attempt := 0; ; attempt++ {
+		err = d.limiter.Wait(req.Context())
+		if err != nil {
+			return nil, err
+		}
+
+		resp, err := d.config.HTTPClient.Do(req)
+		if err != nil || resp.StatusCode != http.StatusTooManyRequests || attempt >= maxRetries {
+			return resp, err
+		}
+
+		// the body is read to find the delay, and must be restored for the caller.
+		raw, _ := ioutil.ReadAll(resp.Body)
+		_ = resp.Body.Close()
+		resp.Body = ioutil.NopCloser(bytes.NewReader(raw))
+
+		delay, ok := retryDelay(resp.Header, raw, attempt)
+		if !ok {
+			// ex: the daily quota is exhausted.
+			return resp, nil
+		}
+
+		time.Sleep(delay)
+
+		if req.GetBody != nil {
+			req.Body, err = req.GetBody()
+			if err != nil {
+				return nil, err
+			}
+		}
+	}
+}
+
+// retryDelay returns the delay before retrying a rate-limited request.
+// The delay is read from the Retry-After header (seconds or HTTP date), or from the `retryAfterSec` field of the body,
+// and defaults to an exponential delay.
+// Returns false if the delay is too long to wait for.
+func retryDelay(header http.Header, body []byte, attempt int) (time.Duration, bool) {
+	delay := defaultRetryDelay << attempt
+
+	var apiErr struct {
+		RetryAfterSec *int `json:"retryAfterSec"`
+	}
+
+	if value := header.Get("Retry-After"); value != "" {
+		if seconds, err := strconv.Atoi(value); err == nil && seconds >= 0 {
+			delay = time.Duration(seconds) * time.Second
+		} else if date, err := http.ParseTime(value); err == nil {
+			delay = time.Until(date)
+		}
+	} else if json.Unmarshal(body, &apiErr) == nil && apiErr.RetryAfterSec != nil && *apiErr.RetryAfterSec >= 0 {
+		delay = time.Duration(*apiErr.RetryAfterSec) * time.Second
+	}
+
+	if delay < 0 {
+		return 0, true
+	}
+
+	return delay, delay <= maxRetryDelay
 }
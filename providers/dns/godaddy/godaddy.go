@@ -10,12 +10,18 @@ import (
 
 	"github.com/go-acme/lego/v4/challenge/dns01"
 	"github.com/go-acme/lego/v4/platform/config/env"
+	"github.com/go-acme/lego/v4/providers/dns/internal/useragent"
+	"golang.org/x/time/rate"
 )
 
 const (
 	// defaultBaseURL represents the API endpoint to call.
 	defaultBaseURL = "https://api.godaddy.com"
 	minTTL         = 600
+
+	// requestsPerMinute the number of requests sent per minute,
+	// conservatively below the limit of the API (60 requests per minute).
+	requestsPerMinute = 50
 )
 
 // Environment variables names.
@@ -33,6 +39,7 @@ const (
 
 // Config is used to configure the creation of the DNSProvider.
 type Config struct {
+	BaseURL            string
 	APIKey             string
 	APISecret          string
 	PropagationTimeout time.Duration
@@ -44,18 +51,20 @@ type Config struct {
 // NewDefaultConfig returns a default configuration for the DNSProvider.
 func NewDefaultConfig() *Config {
 	return &Config{
+		BaseURL:            defaultBaseURL,
 		TTL:                env.GetOrDefaultInt(EnvTTL, minTTL),
 		PropagationTimeout: env.GetOrDefaultSecond(EnvPropagationTimeout, 120*time.Second),
 		PollingInterval:    env.GetOrDefaultSecond(EnvPollingInterval, 2*time.Second),
-		HTTPClient: &http.Client{
+		HTTPClient: useragent.Wrap(&http.Client{
 			Timeout: env.GetOrDefaultSecond(EnvHTTPTimeout, 30*time.Second),
-		},
+		}),
 	}
 }
 
 // DNSProvider implements the challenge.Provider interface.
 type DNSProvider struct {
-	config *Config
+	config  *Config
+	limiter *rate.Limiter
 }
 
 // NewDNSProvider returns a DNSProvider instance configured for godaddy.
@@ -84,11 +93,18 @@ func NewDNSProviderConfig(config *Config) (*DNSProvider, error) {
 		return nil, errors.New("godaddy: credentials missing")
 	}
 
+	if config.BaseURL == "" {
+		config.BaseURL = defaultBaseURL
+	}
+
 	if config.TTL < minTTL {
 		return nil, fmt.Errorf("godaddy: invalid TTL, TTL (%d) must be greater than %d", config.TTL, minTTL)
 	}
 
-	return &DNSProvider{config: config}, nil
+	return &DNSProvider{
+		config:  config,
+		limiter: rate.NewLimiter(rate.Every(time.Minute/requestsPerMinute), 5),
+	}, nil
 }
 
 // Timeout returns the timeout and interval to use when checking for DNS
@@ -108,13 +124,48 @@ func (d *DNSProvider) Present(domain, token, keyAuth string) error {
 
 	recordName := extractRecordName(fqdn, domainZone)
 
+	err = d.addTxtRecord(domainZone, recordName, value)
+	if err != nil {
+		return fmt.Errorf("godaddy: %w", err)
+	}
+
+	return nil
+}
+
+// CleanUp removes the record matching the specified parameters.
+func (d *DNSProvider) CleanUp(domain, token, keyAuth string) error {
+	fqdn, value := dns01.GetRecord(domain, keyAuth)
+
+	domainZone, err := getZone(fqdn)
+	if err != nil {
+		return fmt.Errorf("godaddy: failed to get zone: %w", err)
+	}
+
+	recordName := extractRecordName(fqdn, domainZone)
+
+	err = d.removeTxtRecord(domainZone, recordName, value)
+	if err != nil {
+		return fmt.Errorf("godaddy: %w", err)
+	}
+
+	return nil
+}
+
+// addTxtRecord adds the value to the TXT records of the name.
+// GoDaddy replaces all the records of a name/type, so the existing values must be sent with the new one.
+func (d *DNSProvider) addTxtRecord(domainZone, recordName, value string) error {
 	records, err := d.getRecords(domainZone, "TXT", recordName)
 	if err != nil {
-		return fmt.Errorf("godaddy: failed to get TXT records: %w", err)
+		return fmt.Errorf("failed to get TXT records: %w", err)
 	}
 
 	var newRecords []DNSRecord
 	for _, record := range records {
+		if record.Data == value {
+			// the value already exists.
+			return nil
+		}
+
 		if record.Data != "" {
 			newRecords = append(newRecords, record)
 		}
@@ -130,40 +181,53 @@ func (d *DNSProvider) Present(domain, token, keyAuth string) error {
 
 	err = d.updateTxtRecords(newRecords, domainZone, recordName)
 	if err != nil {
-		return fmt.Errorf("godaddy: failed to add TXT record: %w", err)
+		return fmt.Errorf("failed to add TXT record: %w", err)
 	}
 
 	return nil
 }
 
-// CleanUp removes the record matching the specified parameters.
-func (d *DNSProvider) CleanUp(domain, token, keyAuth string) error {
-	fqdn, value := dns01.GetRecord(domain, keyAuth)
-
-	domainZone, err := getZone(fqdn)
+// removeTxtRecord removes the value from the TXT records of the name.
+func (d *DNSProvider) removeTxtRecord(domainZone, recordName, value string) error {
+	records, err := d.getRecords(domainZone, "TXT", recordName)
 	if err != nil {
-		return fmt.Errorf("godaddy: failed to get zone: %w", err)
+		return fmt.Errorf("failed to get TXT records: %w", err)
 	}
 
-	recordName := extractRecordName(fqdn, domainZone)
+	if len(records) == 0 {
+		return nil
+	}
 
-	records, err := d.getRecords(domainZone, "TXT", recordName)
-	if err != nil {
-		return fmt.Errorf("godaddy: failed to get TXT records: %w", err)
+	var recordsKeep []DNSRecord
+	for _, record := range records {
+		if record.Data != value && record.Data != "" {
+			recordsKeep = append(recordsKeep, record)
+		}
 	}
 
-	if len(records) == 0 {
+	// The other values of the name are kept.
+	if len(recordsKeep) > 0 {
+		err = d.updateTxtRecords(recordsKeep, domainZone, recordName)
+		if err != nil {
+			return fmt.Errorf("failed to remove TXT record: %w", err)
+		}
+
 		return nil
 	}
 
+	// The name must be removed: all the TXT records of the zone must be replaced.
 	allTxtRecords, err := d.getRecords(domainZone, "TXT", "")
 	if err != nil {
-		return fmt.Errorf("godaddy: failed to get all TXT records: %w", err)
+		return fmt.Errorf("failed to get all TXT records: %w", err)
 	}
 
-	var recordsKeep []DNSRecord
+	recordsKeep = nil
 	for _, record := range allTxtRecords {
-		if record.Data != value && record.Data != "" {
+		if record.Name == recordName && record.Data == value {
+			continue
+		}
+
+		if record.Data != "" {
 			recordsKeep = append(recordsKeep, record)
 		}
 	}
@@ -176,7 +240,7 @@ func (d *DNSProvider) CleanUp(domain, token, keyAuth string) error {
 
 	err = d.updateTxtRecords(recordsKeep, domainZone, "")
 	if err != nil {
-		return fmt.Errorf("godaddy: failed to remove TXT record: %w", err)
+		return fmt.Errorf("failed to remove TXT record: %w", err)
 	}
 
 	return nil
@@ -1,10 +1,19 @@
 package godaddy
 
 import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
 	"testing"
+	"time"
 
 	"github.com/go-acme/lego/v4/platform/tester"
+	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+	"golang.org/x/time/rate"
 )
 
 const envDomain = envNamespace + "DOMAIN"
@@ -120,6 +129,255 @@ func TestNewDNSProviderConfig(t *testing.T) {
 	}
 }
 
+// fakeZone simulates the replace semantics of the GoDaddy API for the TXT records of a zone.
+type fakeZone struct {
+	mu      sync.Mutex
+	records []DNSRecord
+}
+
+func (z *fakeZone) ServeHTTP(rw http.ResponseWriter, req *http.Request) {
+	z.mu.Lock()
+	defer z.mu.Unlock()
+
+	name := strings.Trim(strings.TrimPrefix(req.URL.Path, "/v1/domains/example.com/records/TXT"), "/")
+
+	switch req.Method {
+	case http.MethodGet:
+		records := make([]DNSRecord, 0)
+		for _, record := range z.records {
+			if name == "" || record.Name == name {
+				records = append(records, record)
+			}
+		}
+
+		_ = json.NewEncoder(rw).Encode(records)
+
+	case http.MethodPut:
+		var records []DNSRecord
+		err := json.NewDecoder(req.Body).Decode(&records)
+		if err != nil {
+			http.Error(rw, err.Error(), http.StatusBadRequest)
+			return
+		}
+
+		var keep []DNSRecord
+		for _, record := range z.records {
+			if name != "" && record.Name != name {
+				keep = append(keep, record)
+			}
+		}
+
+		for _, record := range records {
+			if record.Name == "" {
+				record.Name = name
+			}
+			if record.Data != "" {
+				keep = append(keep, record)
+			}
+		}
+
+		z.records = keep
+
+	default:
+		http.Error(rw, "unsupported method", http.StatusMethodNotAllowed)
+	}
+}
+
+func (z *fakeZone) values(name string) []string {
+	z.mu.Lock()
+	defer z.mu.Unlock()
+
+	var values []string
+	for _, record := range z.records {
+		if record.Name == name {
+			values = append(values, record.Data)
+		}
+	}
+
+	return values
+}
+
+func setupTest(t *testing.T, zone *fakeZone) *DNSProvider {
+	t.Helper()
+
+	mux := http.NewServeMux()
+	mux.Handle("/v1/domains/example.com/records/TXT", zone)
+	mux.Handle("/v1/domains/example.com/records/TXT/", zone)
+
+	server := httptest.NewServer(mux)
+	t.Cleanup(server.Close)
+
+	config := NewDefaultConfig()
+	config.APIKey = "key"
+	config.APISecret = "secret"
+	config.BaseURL = server.URL
+
+	provider, err := NewDNSProviderConfig(config)
+	require.NoError(t, err)
+
+	provider.limiter = rate.NewLimiter(rate.Every(time.Millisecond), 1)
+
+	return provider
+}
+
+func TestDNSProvider_addTxtRecord_removeTxtRecord(t *testing.T) {
+	zone := &fakeZone{records: []DNSRecord{
+		{Type: "TXT", Name: "other", Data: "keep", TTL: 600},
+	}}
+
+	provider := setupTest(t, zone)
+
+	// base and wildcard challenges use the same name.
+	err := provider.addTxtRecord("example.com", "_acme-challenge", "base")
+	require.NoError(t, err)
+
+	err = provider.addTxtRecord("example.com", "_acme-challenge", "wildcard")
+	require.NoError(t, err)
+
+	assert.Equal(t, []string{"base", "wildcard"}, zone.values("_acme-challenge"))
+
+	// presenting the same value twice doesn't duplicate it.
+	err = provider.addTxtRecord("example.com", "_acme-challenge", "wildcard")
+	require.NoError(t, err)
+
+	assert.Equal(t, []string{"base", "wildcard"}, zone.values("_acme-challenge"))
+
+	err = provider.removeTxtRecord("example.com", "_acme-challenge", "base")
+	require.NoError(t, err)
+
+	assert.Equal(t, []string{"wildcard"}, zone.values("_acme-challenge"))
+
+	err = provider.removeTxtRecord("example.com", "_acme-challenge", "wildcard")
+	require.NoError(t, err)
+
+	assert.Empty(t, zone.values("_acme-challenge"))
+	assert.Equal(t, []string{"keep"}, zone.values("other"))
+}
+
+func TestDNSProvider_addTxtRecord_rateLimited(t *testing.T) {
+	zone := &fakeZone{}
+
+	var calls int
+	mux := http.NewServeMux()
+	mux.HandleFunc("/v1/domains/example.com/records/TXT/_acme-challenge", func(rw http.ResponseWriter, req *http.Request) {
+		calls++
+
+		// the first request of each method is rate-limited.
+		if calls%2 == 1 {
+			rw.Header().Set("Retry-After", "0")
+			rw.WriteHeader(http.StatusTooManyRequests)
+			_, _ = fmt.Fprint(rw, `{"code":"TOO_MANY_REQUESTS","message":"Too many requests"}`)
+			return
+		}
+
+		zone.ServeHTTP(rw, req)
+	})
+
+	server := httptest.NewServer(mux)
+	t.Cleanup(server.Close)
+
+	config := NewDefaultConfig()
+	config.APIKey = "key"
+	config.APISecret = "secret"
+	config.BaseURL = server.URL
+
+	provider, err := NewDNSProviderConfig(config)
+	require.NoError(t, err)
+
+	provider.limiter = rate.NewLimiter(rate.Every(time.Millisecond), 1)
+
+	err = provider.addTxtRecord("example.com", "_acme-challenge", "value")
+	require.NoError(t, err)
+
+	// GET and PUT are both retried once.
+	assert.Equal(t, 4, calls)
+	assert.Equal(t, []string{"value"}, zone.values("_acme-challenge"))
+}
+
+func TestDNSProvider_addTxtRecord_dailyQuota(t *testing.T) {
+	var calls int
+	mux := http.NewServeMux()
+	mux.HandleFunc("/v1/domains/example.com/records/TXT/_acme-challenge", func(rw http.ResponseWriter, req *http.Request) {
+		calls++
+
+		rw.WriteHeader(http.StatusTooManyRequests)
+		_, _ = fmt.Fprint(rw, `{"code":"TOO_MANY_REQUESTS","message":"Too many requests","retryAfterSec":43200}`)
+	})
+
+	server := httptest.NewServer(mux)
+	t.Cleanup(server.Close)
+
+	config := NewDefaultConfig()
+	config.APIKey = "key"
+	config.APISecret = "secret"
+	config.BaseURL = server.URL
+
+	provider, err := NewDNSProviderConfig(config)
+	require.NoError(t, err)
+
+	err = provider.addTxtRecord("example.com", "_acme-challenge", "value")
+	require.Error(t, err)
+
+	assert.Contains(t, err.Error(), "Status: 429")
+	assert.Equal(t, 1, calls)
+}
+
+func Test_retryDelay(t *testing.T) {
+	testCases := []struct {
+		desc          string
+		header        http.Header
+		body          string
+		attempt       int
+		expected      time.Duration
+		expectedRetry bool
+	}{
+		{
+			desc:          "no delay",
+			attempt:       2,
+			expected:      4 * time.Second,
+			expectedRetry: true,
+		},
+		{
+			desc:          "Retry-After seconds",
+			header:        http.Header{"Retry-After": []string{"30"}},
+			expected:      30 * time.Second,
+			expectedRetry: true,
+		},
+		{
+			desc:          "retryAfterSec",
+			body:          `{"code":"TOO_MANY_REQUESTS","retryAfterSec":20}`,
+			expected:      20 * time.Second,
+			expectedRetry: true,
+		},
+		{
+			desc:          "Retry-After takes precedence",
+			header:        http.Header{"Retry-After": []string{"10"}},
+			body:          `{"code":"TOO_MANY_REQUESTS","retryAfterSec":20}`,
+			expected:      10 * time.Second,
+			expectedRetry: true,
+		},
+		{
+			desc:     "too long",
+			body:     `{"code":"TOO_MANY_REQUESTS","retryAfterSec":43200}`,
+			expected: 12 * time.Hour,
+		},
+	}
+
+	for _, test := range testCases {
+		t.Run(test.desc, func(t *testing.T) {
+			header := test.header
+			if header == nil {
+				header = http.Header{}
+			}
+
+			delay, retry := retryDelay(header, []byte(test.body), test.attempt)
+
+			assert.Equal(t, test.expected, delay)
+			assert.Equal(t, test.expectedRetry, retry)
+		})
+	}
+}
+
 func TestLivePresent(t *testing.T) {
 	if !envTest.IsLiveTest() {
 		t.Skip("skipping live test")
@@ -0,0 +1,100 @@
+package internal
+
+import (
+	"net/http"
+	"strconv"
+	"time"
+)
+
+const (
+	defaultMaxRetries = 5
+	defaultRetryDelay = 1 * time.Second
+	maxRetryDelay     = 1 * time.Minute
+)
+
+// RetryTransport retries the requests rate-limited by the API (429 Too Many Requests).
+// It waits for the delay of the Retry-After header, or for an exponential delay if the header is missing.
+type RetryTransport struct {
+	// Base is the underlying transport (http.DefaultTransport if nil).
+	Base http.RoundTripper
+
+	MaxRetries int
+}
+
+// WithRetry returns a copy of the client, with a RetryTransport around its transport.
+func WithRetry(client *http.Client) *http.Client {
+	c := *client
+	c.Transport = &RetryTransport{Base: client.Transport, MaxRetries: defaultMaxRetries}
+
+	return &c
+}
+
+// RoundTrip implements http.RoundTripper.
+func (t *RetryTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	resp, err := t.base().RoundTrip(req)
+
+	for attempt := 0; attempt < t.MaxRetries; attempt++ {
+		if err != nil || resp.StatusCode != http.StatusTooManyRequests {
+			return resp, err
+		}
+
+		// the body of the request has already been consumed and cannot be sent again.
+		if req.Body != nil && req.GetBody == nil {
+			return resp, nil
+		}
+
+		delay := retryDelay(resp, attempt)
+
+		_ = resp.Body.Close()
+
+		select {
+		case <-req.Context().Done():
+			return nil, req.Context().Err()
+		case <-time.After(delay):
+		}
+
+		r := req.Clone(req.Context())
+		if req.GetBody != nil {
+			r.Body, err = req.GetBody()
+			if err != nil {
+				return nil, err
+			}
+		}
+
+		resp, err = t.base().RoundTrip(r)
+	}
+
+	return resp, err
+}
+
+func (t *RetryTransport) base() http.RoundTripper {
+	if t.Base != nil {
+		return t.Base
+	}
+
+	return http.DefaultTransport
+}
+
+// retryDelay returns the delay before the next attempt.
+// The Retry-After header can be a number of seconds or an HTTP date.
+func retryDelay(resp *http.Response, attempt int) time.Duration {
+	delay := defaultRetryDelay << attempt
+
+	if value := resp.Header.Get("Retry-After"); value != "" {
+		if seconds, err := strconv.Atoi(value); err == nil && seconds >= 0 {
+			delay = time.Duration(seconds) * time.Second
+		} else if date, err := http.ParseTime(value); err == nil {
+			delay = time.Until(date)
+		}
+	}
+
+	if delay < 0 {
+		return 0
+	}
+
+	if delay > maxRetryDelay {
+		return maxRetryDelay
+	}
+
+	return delay
+}
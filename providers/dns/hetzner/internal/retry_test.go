@@ -0,0 +1,170 @@
+package internal
+
+import (
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// rateLimited responds 429 Too Many Requests to the first requests, then delegates to the handler.
+func rateLimited(limit int, retryAfter string, next http.HandlerFunc) (http.HandlerFunc, func() int) {
+	var calls int
+
+	handler := func(rw http.ResponseWriter, req *http.Request) {
+		calls++
+
+		if calls <= limit {
+			rw.Header().Set("Retry-After", retryAfter)
+			http.Error(rw, `{"message":"rate limit exceeded"}`, http.StatusTooManyRequests)
+			return
+		}
+
+		next(rw, req)
+	}
+
+	return handler, func() int { return calls }
+}
+
+func TestClient_CreateRecord_rateLimited(t *testing.T) {
+	mux := http.NewServeMux()
+	server := httptest.NewServer(mux)
+	t.Cleanup(server.Close)
+
+	var bodies []string
+	handler, calls := rateLimited(1, "0", func(rw http.ResponseWriter, req *http.Request) {
+		_, _ = rw.Write([]byte(`{"record":{}}`))
+	})
+
+	mux.HandleFunc("/api/v1/records", func(rw http.ResponseWriter, req *http.Request) {
+		if req.Method != http.MethodPost {
+			http.Error(rw, "unsupported method: "+req.Method, http.StatusMethodNotAllowed)
+			return
+		}
+
+		body, _ := ioutil.ReadAll(req.Body)
+		bodies = append(bodies, string(body))
+
+		handler(rw, req)
+	})
+
+	client := NewClient("secret")
+	client.BaseURL = server.URL
+	client.HTTPClient = WithRetry(server.Client())
+
+	err := client.CreateRecord(DNSRecord{Name: "test", Type: "TXT", Value: "txttxttxt", TTL: 600, ZoneID: "zoneA"})
+	require.NoError(t, err)
+
+	assert.Equal(t, 2, calls())
+
+	// the body is sent again.
+	require.Len(t, bodies, 2)
+	assert.Equal(t, bodies[0], bodies[1])
+}
+
+func TestClient_DeleteRecord_rateLimited(t *testing.T) {
+	mux := http.NewServeMux()
+	server := httptest.NewServer(mux)
+	t.Cleanup(server.Close)
+
+	handler, calls := rateLimited(1, "1", func(rw http.ResponseWriter, req *http.Request) {})
+
+	mux.HandleFunc("/api/v1/records/recordID", func(rw http.ResponseWriter, req *http.Request) {
+		if req.Method != http.MethodDelete {
+			http.Error(rw, "unsupported method: "+req.Method, http.StatusMethodNotAllowed)
+			return
+		}
+
+		handler(rw, req)
+	})
+
+	client := NewClient("secret")
+	client.BaseURL = server.URL
+	client.HTTPClient = WithRetry(server.Client())
+
+	start := time.Now()
+
+	err := client.DeleteRecord("recordID")
+	require.NoError(t, err)
+
+	assert.Equal(t, 2, calls())
+
+	// honors the Retry-After header.
+	assert.GreaterOrEqual(t, int64(time.Since(start)), int64(time.Second))
+}
+
+func TestClient_DeleteRecord_rateLimitedTooManyTimes(t *testing.T) {
+	mux := http.NewServeMux()
+	server := httptest.NewServer(mux)
+	t.Cleanup(server.Close)
+
+	handler, calls := rateLimited(10, "0", func(rw http.ResponseWriter, req *http.Request) {})
+
+	mux.HandleFunc("/api/v1/records/recordID", handler)
+
+	client := NewClient("secret")
+	client.BaseURL = server.URL
+	client.HTTPClient = &http.Client{Transport: &RetryTransport{MaxRetries: 2}}
+
+	err := client.DeleteRecord("recordID")
+	require.Error(t, err)
+
+	assert.Equal(t, 3, calls())
+}
+
+func Test_retryDelay(t *testing.T) {
+	testCases := []struct {
+		desc       string
+		retryAfter string
+		attempt    int
+		expected   time.Duration
+	}{
+		{
+			desc:     "no header",
+			expected: time.Second,
+		},
+		{
+			desc:     "no header, exponential",
+			attempt:  2,
+			expected: 4 * time.Second,
+		},
+		{
+			desc:       "seconds",
+			retryAfter: "3",
+			expected:   3 * time.Second,
+		},
+		{
+			desc:       "past date",
+			retryAfter: "Wed, 21 Oct 2015 07:28:00 GMT",
+			expected:   0,
+		},
+		{
+			desc:       "too long",
+			retryAfter: "3600",
+			expected:   maxRetryDelay,
+		},
+		{
+			desc:       "invalid value",
+			retryAfter: "soon",
+			expected:   time.Second,
+		},
+	}
+
+	for _, test := range testCases {
+		test := test
+		t.Run(test.desc, func(t *testing.T) {
+			t.Parallel()
+
+			resp := &http.Response{Header: http.Header{}}
+			if test.retryAfter != "" {
+				resp.Header.Set("Retry-After", test.retryAfter)
+			}
+
+			assert.Equal(t, test.expected, retryDelay(resp, test.attempt))
+		})
+	}
+}
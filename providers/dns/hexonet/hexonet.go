@@ -0,0 +1,168 @@
+// Package hexonet implements a DNS provider for solving the DNS-01 challenge using Hexonet (CentralNic Reseller).
+package hexonet
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/go-acme/lego/v4/challenge/dns01"
+	"github.com/go-acme/lego/v4/platform/config/env"
+	"github.com/go-acme/lego/v4/providers/dns/hexonet/internal"
+	"github.com/go-acme/lego/v4/providers/dns/internal/useragent"
+)
+
+// Environment variables names.
+const (
+	envNamespace = "HEXONET_"
+
+	EnvUsername = envNamespace + "USERNAME"
+	EnvPassword = envNamespace + "PASSWORD"
+	EnvAPIURL   = envNamespace + "API_URL"
+	EnvEntity   = envNamespace + "ENTITY"
+
+	EnvTTL                = envNamespace + "TTL"
+	EnvPropagationTimeout = envNamespace + "PROPAGATION_TIMEOUT"
+	EnvPollingInterval    = envNamespace + "POLLING_INTERVAL"
+	EnvHTTPTimeout        = envNamespace + "HTTP_TIMEOUT"
+)
+
+// Config is used to configure the creation of the DNSProvider.
+type Config struct {
+	BaseURL            string
+	Entity             string
+	Username           string
+	Password           string
+	PropagationTimeout time.Duration
+	PollingInterval    time.Duration
+	TTL                int
+	HTTPClient         *http.Client
+}
+
+// NewDefaultConfig returns a default configuration for the DNSProvider.
+func NewDefaultConfig() *Config {
+	return &Config{
+		BaseURL:            env.GetOrDefaultString(EnvAPIURL, internal.DefaultBaseURL),
+		Entity:             env.GetOrDefaultString(EnvEntity, ""),
+		TTL:                env.GetOrDefaultInt(EnvTTL, 300),
+		PropagationTimeout: env.GetOrDefaultSecond(EnvPropagationTimeout, dns01.DefaultPropagationTimeout),
+		PollingInterval:    env.GetOrDefaultSecond(EnvPollingInterval, dns01.DefaultPollingInterval),
+		HTTPClient: useragent.Wrap(&http.Client{
+			Timeout: env.GetOrDefaultSecond(EnvHTTPTimeout, 30*time.Second),
+		}),
+	}
+}
+
+// DNSProvider implements the challenge.Provider interface.
+type DNSProvider struct {
+	config *Config
+	client *internal.Client
+}
+
+// NewDNSProvider returns a DNSProvider instance configured for Hexonet.
+// Credentials must be passed in the environment variables: HEXONET_USERNAME, HEXONET_PASSWORD.
+func NewDNSProvider() (*DNSProvider, error) {
+	values, err := env.Get(EnvUsername, EnvPassword)
+	if err != nil {
+		return nil, fmt.Errorf("hexonet: %w", err)
+	}
+
+	config := NewDefaultConfig()
+	config.Username = values[EnvUsername]
+	config.Password = values[EnvPassword]
+
+	return NewDNSProviderConfig(config)
+}
+
+// NewDNSProviderConfig return a DNSProvider instance configured for Hexonet.
+func NewDNSProviderConfig(config *Config) (*DNSProvider, error) {
+	if config == nil {
+		return nil, errors.New("hexonet: the configuration of the DNS provider is nil")
+	}
+
+	if config.Username == "" || config.Password == "" {
+		return nil, errors.New("hexonet: credentials missing")
+	}
+
+	client := internal.NewClient(config.Username, config.Password)
+
+	if config.BaseURL != "" {
+		client.BaseURL = config.BaseURL
+	}
+
+	if config.Entity != "" {
+		client.Entity = config.Entity
+	}
+
+	if config.HTTPClient != nil {
+		client.HTTPClient = config.HTTPClient
+	}
+
+	return &DNSProvider{config: config, client: client}, nil
+}
+
+// Timeout returns the timeout and interval to use when checking for DNS propagation.
+// Adjusting here to cope with spikes in propagation times.
+func (d *DNSProvider) Timeout() (timeout, interval time.Duration) {
+	return d.config.PropagationTimeout, d.config.PollingInterval
+}
+
+// Present creates a TXT record to fulfill the dns-01 challenge.
+func (d *DNSProvider) Present(domain, token, keyAuth string) error {
+	fqdn, value := dns01.GetRecord(domain, keyAuth)
+
+	zone, err := d.findZone(fqdn)
+	if err != nil {
+		return fmt.Errorf("hexonet: %w", err)
+	}
+
+	err = d.client.AddTXTRecord(zone, fqdn, d.config.TTL, value)
+	if err != nil {
+		return fmt.Errorf("hexonet: failed to create TXT record: fqdn=%s, zone=%s: %w", fqdn, zone, err)
+	}
+
+	return nil
+}
+
+// CleanUp removes the TXT record matching the specified parameters.
+func (d *DNSProvider) CleanUp(domain, token, keyAuth string) error {
+	fqdn, value := dns01.GetRecord(domain, keyAuth)
+
+	zone, err := d.findZone(fqdn)
+	if err != nil {
+		return fmt.Errorf("hexonet: %w", err)
+	}
+
+	err = d.client.RemoveTXTRecord(zone, fqdn, d.config.TTL, value)
+	if err != nil {
+		return fmt.Errorf("hexonet: failed to delete TXT record: fqdn=%s, zone=%s: %w", fqdn, zone, err)
+	}
+
+	return nil
+}
+
+// Zones returns the DNS zones of the account.
+func (d *DNSProvider) Zones() ([]string, error) {
+	zones, err := d.client.GetZones()
+	if err != nil {
+		return nil, fmt.Errorf("hexonet: failed to get zones: %w", err)
+	}
+
+	return zones, nil
+}
+
+// findZone finds the most specific zone of the account containing the fqdn.
+func (d *DNSProvider) findZone(fqdn string) (string, error) {
+	zones, err := d.client.GetZones()
+	if err != nil {
+		return "", fmt.Errorf("failed to get zones: %w", err)
+	}
+
+	zone := dns01.FindMostSpecificZone(fqdn, zones)
+	if zone == "" {
+		return "", fmt.Errorf("no zone found for %s", dns01.UnFqdn(fqdn))
+	}
+
+	return zone, nil
+}
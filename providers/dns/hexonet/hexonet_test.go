@@ -0,0 +1,242 @@
+package hexonet
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/go-acme/lego/v4/platform/tester"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const envDomain = envNamespace + "DOMAIN"
+
+var envTest = tester.NewEnvTest(EnvUsername, EnvPassword, EnvAPIURL, EnvEntity).WithDomain(envDomain)
+
+const (
+	responseOK    = "[RESPONSE]\ncode = 200\ndescription = Command completed successfully\nEOF\n"
+	responseZones = "[RESPONSE]\ncode = 200\ndescription = Command completed successfully\n" +
+		"property[dnszone][0] = example.com.\nproperty[dnszone][1] = sub.example.com.\nproperty[total][0] = 2\nEOF\n"
+	responseObjectNotFound = "[RESPONSE]\ncode = 545\ndescription = Entity reference not found\nEOF\n"
+)
+
+func TestNewDNSProvider(t *testing.T) {
+	testCases := []struct {
+		desc     string
+		envVars  map[string]string
+		expected string
+	}{
+		{
+			desc: "success",
+			envVars: map[string]string{
+				EnvUsername: "user",
+				EnvPassword: "secret",
+			},
+		},
+		{
+			desc: "missing credentials",
+			envVars: map[string]string{
+				EnvUsername: "",
+				EnvPassword: "",
+			},
+			expected: "hexonet: some credentials information are missing: HEXONET_USERNAME,HEXONET_PASSWORD",
+		},
+		{
+			desc: "missing username",
+			envVars: map[string]string{
+				EnvUsername: "",
+				EnvPassword: "secret",
+			},
+			expected: "hexonet: some credentials information are missing: HEXONET_USERNAME",
+		},
+		{
+			desc: "missing password",
+			envVars: map[string]string{
+				EnvUsername: "user",
+				EnvPassword: "",
+			},
+			expected: "hexonet: some credentials information are missing: HEXONET_PASSWORD",
+		},
+	}
+
+	for _, test := range testCases {
+		t.Run(test.desc, func(t *testing.T) {
+			defer envTest.RestoreEnv()
+			envTest.ClearEnv()
+
+			envTest.Apply(test.envVars)
+
+			p, err := NewDNSProvider()
+
+			if len(test.expected) == 0 {
+				require.NoError(t, err)
+				require.NotNil(t, p)
+				require.NotNil(t, p.config)
+				require.NotNil(t, p.client)
+			} else {
+				require.EqualError(t, err, test.expected)
+			}
+		})
+	}
+}
+
+func TestNewDNSProviderConfig(t *testing.T) {
+	testCases := []struct {
+		desc     string
+		username string
+		password string
+		expected string
+	}{
+		{
+			desc:     "success",
+			username: "user",
+			password: "secret",
+		},
+		{
+			desc:     "missing credentials",
+			expected: "hexonet: credentials missing",
+		},
+		{
+			desc:     "missing password",
+			username: "user",
+			expected: "hexonet: credentials missing",
+		},
+	}
+
+	for _, test := range testCases {
+		t.Run(test.desc, func(t *testing.T) {
+			config := NewDefaultConfig()
+			config.Username = test.username
+			config.Password = test.password
+
+			p, err := NewDNSProviderConfig(config)
+
+			if len(test.expected) == 0 {
+				require.NoError(t, err)
+				require.NotNil(t, p)
+				require.NotNil(t, p.config)
+				require.NotNil(t, p.client)
+			} else {
+				require.EqualError(t, err, test.expected)
+			}
+		})
+	}
+}
+
+func setupTest(t *testing.T, handler func(command string) string) (*DNSProvider, *[]string) {
+	t.Helper()
+
+	var commands []string
+
+	server := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		if req.FormValue("s_login") != "user" || req.FormValue("s_pw") != "secret" {
+			http.Error(rw, "invalid credentials", http.StatusUnauthorized)
+			return
+		}
+
+		command := req.FormValue("s_command")
+		if strings.HasPrefix(command, "COMMAND = QueryDNSZoneList\n") {
+			_, _ = fmt.Fprint(rw, responseZones)
+			return
+		}
+
+		commands = append(commands, command)
+
+		if handler == nil {
+			http.Error(rw, fmt.Sprintf("unexpected command: %s", command), http.StatusBadRequest)
+			return
+		}
+
+		_, _ = fmt.Fprint(rw, handler(command))
+	}))
+	t.Cleanup(server.Close)
+
+	config := NewDefaultConfig()
+	config.BaseURL = server.URL
+	config.Username = "user"
+	config.Password = "secret"
+
+	provider, err := NewDNSProviderConfig(config)
+	require.NoError(t, err)
+
+	return provider, &commands
+}
+
+func TestDNSProvider_Present(t *testing.T) {
+	provider, commands := setupTest(t, func(_ string) string { return responseOK })
+
+	err := provider.Present("www.sub.example.com", "token", "123d==")
+	require.NoError(t, err)
+
+	expected := "COMMAND = UpdateDNSZone\n" +
+		"ADDRR0 = _acme-challenge.www.sub.example.com. 300 IN TXT \"ADw2sEd82DUgXcQ9hNBZThJs7zVJkR5v9JeSbAb9mZY\"\n" +
+		"DNSZONE = sub.example.com."
+	assert.Equal(t, []string{expected}, *commands)
+}
+
+func TestDNSProvider_Present_unknownZone(t *testing.T) {
+	provider, _ := setupTest(t, nil)
+
+	err := provider.Present("www.example.org", "token", "123d==")
+	require.EqualError(t, err, "hexonet: no zone found for _acme-challenge.www.example.org")
+}
+
+func TestDNSProvider_Present_error(t *testing.T) {
+	provider, _ := setupTest(t, func(_ string) string { return responseObjectNotFound })
+
+	err := provider.Present("www.example.com", "token", "123d==")
+	require.EqualError(t, err, "hexonet: failed to create TXT record: fqdn=_acme-challenge.www.example.com., zone=example.com.: UpdateDNSZone: 545: Entity reference not found")
+}
+
+func TestDNSProvider_CleanUp(t *testing.T) {
+	provider, commands := setupTest(t, func(_ string) string { return responseOK })
+
+	err := provider.CleanUp("www.example.com", "token", "123d==")
+	require.NoError(t, err)
+
+	expected := "COMMAND = UpdateDNSZone\n" +
+		"DELRR0 = _acme-challenge.www.example.com. 300 IN TXT \"ADw2sEd82DUgXcQ9hNBZThJs7zVJkR5v9JeSbAb9mZY\"\n" +
+		"DNSZONE = example.com."
+	assert.Equal(t, []string{expected}, *commands)
+}
+
+func TestDNSProvider_Zones(t *testing.T) {
+	provider, _ := setupTest(t, nil)
+
+	zones, err := provider.Zones()
+	require.NoError(t, err)
+
+	assert.Equal(t, []string{"example.com.", "sub.example.com."}, zones)
+}
+
+func TestLivePresent(t *testing.T) {
+	if !envTest.IsLiveTest() {
+		t.Skip("skipping live test")
+	}
+
+	envTest.RestoreEnv()
+	provider, err := NewDNSProvider()
+	require.NoError(t, err)
+
+	err = provider.Present(envTest.GetDomain(), "", "123d==")
+	require.NoError(t, err)
+}
+
+func TestLiveCleanUp(t *testing.T) {
+	if !envTest.IsLiveTest() {
+		t.Skip("skipping live test")
+	}
+
+	envTest.RestoreEnv()
+	provider, err := NewDNSProvider()
+	require.NoError(t, err)
+
+	time.Sleep(1 * time.Second)
+
+	err = provider.CleanUp(envTest.GetDomain(), "", "123d==")
+	require.NoError(t, err)
+}
@@ -0,0 +1,155 @@
+package internal
+
+import (
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// DefaultBaseURL the URL of the ISPAPI.
+const DefaultBaseURL = "https://api.ispapi.net/api/call.cgi"
+
+// the entity of the live system (1234 is the OT&E test system).
+const defaultEntity = "54cd"
+
+// the maximum number of zones returned by a QueryDNSZoneList command.
+const zonesPageSize = 100
+
+// Client the Hexonet ISPAPI client.
+type Client struct {
+	HTTPClient *http.Client
+	BaseURL    string
+	Entity     string
+
+	login    string
+	password string
+}
+
+// NewClient creates a new Client.
+func NewClient(login, password string) *Client {
+	return &Client{
+		HTTPClient: http.DefaultClient,
+		BaseURL:    DefaultBaseURL,
+		Entity:     defaultEntity,
+		login:      login,
+		password:   password,
+	}
+}
+
+// GetZones gets the DNS zones of the account.
+// https://github.com/hexonet/hexonet-api-documentation/blob/master/API/DNS/ZONE/QUERYDNSZONELIST.md
+func (c *Client) GetZones() ([]string, error) {
+	var zones []string
+
+	for first := 0; ; first += zonesPageSize {
+		resp, err := c.Call("QueryDNSZoneList", map[string]string{
+			"FIRST": strconv.Itoa(first),
+			"LIMIT": strconv.Itoa(zonesPageSize),
+		})
+		if err != nil {
+			return nil, err
+		}
+
+		page := resp.Property("DNSZONE")
+		zones = append(zones, page...)
+
+		total, err := strconv.Atoi(firstValue(resp.Property("TOTAL")))
+		if err != nil || len(page) == 0 || len(zones) >= total {
+			return zones, nil
+		}
+	}
+}
+
+// AddTXTRecord adds a TXT record to a zone.
+// https://github.com/hexonet/hexonet-api-documentation/blob/master/API/DNS/ZONE/UPDATEDNSZONE.md
+func (c *Client) AddTXTRecord(zone, fqdn string, ttl int, value string) error {
+	_, err := c.Call("UpdateDNSZone", map[string]string{
+		"DNSZONE": zone,
+		"ADDRR0":  txtRR(fqdn, ttl, value),
+	})
+
+	return err
+}
+
+// RemoveTXTRecord removes a TXT record from a zone.
+// https://github.com/hexonet/hexonet-api-documentation/blob/master/API/DNS/ZONE/UPDATEDNSZONE.md
+func (c *Client) RemoveTXTRecord(zone, fqdn string, ttl int, value string) error {
+	_, err := c.Call("UpdateDNSZone", map[string]string{
+		"DNSZONE": zone,
+		"DELRR0":  txtRR(fqdn, ttl, value),
+	})
+
+	return err
+}
+
+// Call sends a command to the API, and returns an APIError if the command has not succeeded.
+func (c *Client) Call(command string, params map[string]string) (*Response, error) {
+	form := url.Values{}
+	form.Set("s_entity", c.Entity)
+	form.Set("s_login", c.login)
+	form.Set("s_pw", c.password)
+	form.Set("s_command", buildCommand(command, params))
+
+	httpResp, err := c.HTTPClient.PostForm(c.BaseURL, form)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", command, err)
+	}
+
+	defer func() { _ = httpResp.Body.Close() }()
+
+	raw, err := ioutil.ReadAll(httpResp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("%s: failed to read response body: %w", command, err)
+	}
+
+	if httpResp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("%s: unexpected status code: %d: %s", command, httpResp.StatusCode, strings.TrimSpace(string(raw)))
+	}
+
+	resp, err := parseResponse(string(raw))
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", command, err)
+	}
+
+	// the codes 2xx are the successful commands, 4xx the temporary errors, and 5xx the permanent errors.
+	if resp.Code < 200 || resp.Code >= 300 {
+		return nil, fmt.Errorf("%s: %w", command, APIError{Code: resp.Code, Description: resp.Description})
+	}
+
+	return resp, nil
+}
+
+// buildCommand creates the plain text command (one "key = value" by line).
+func buildCommand(command string, params map[string]string) string {
+	lines := []string{"COMMAND = " + command}
+
+	var keys []string
+	for key := range params {
+		keys = append(keys, key)
+	}
+
+	sort.Strings(keys)
+
+	for _, key := range keys {
+		lines = append(lines, fmt.Sprintf("%s = %s", key, params[key]))
+	}
+
+	return strings.Join(lines, "\n")
+}
+
+// txtRR creates a TXT resource record in the zone file format.
+func txtRR(fqdn string, ttl int, value string) string {
+	return fmt.Sprintf("%s %d IN TXT %q", fqdn, ttl, value)
+}
+
+func firstValue(values []string) string {
+	if len(values) == 0 {
+		return ""
+	}
+
+	return values[0]
+}
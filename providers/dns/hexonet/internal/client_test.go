@@ -0,0 +1,161 @@
+package internal
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func setupTest(t *testing.T, command, filename string) (*Client, *[]string) {
+	t.Helper()
+
+	mux := http.NewServeMux()
+	server := httptest.NewServer(mux)
+	t.Cleanup(server.Close)
+
+	var commands []string
+
+	mux.HandleFunc("/", func(rw http.ResponseWriter, req *http.Request) {
+		if req.Method != http.MethodPost {
+			http.Error(rw, fmt.Sprintf("unsupported method: %s", req.Method), http.StatusMethodNotAllowed)
+			return
+		}
+
+		if req.FormValue("s_entity") != "54cd" || req.FormValue("s_login") != "user" || req.FormValue("s_pw") != "secret" {
+			http.Error(rw, "invalid credentials", http.StatusUnauthorized)
+			return
+		}
+
+		cmd := req.FormValue("s_command")
+		if !strings.HasPrefix(cmd, "COMMAND = "+command+"\n") {
+			http.Error(rw, fmt.Sprintf("unexpected command: %s", cmd), http.StatusBadRequest)
+			return
+		}
+
+		commands = append(commands, cmd)
+
+		file, err := os.Open(filename)
+		if err != nil {
+			http.Error(rw, err.Error(), http.StatusInternalServerError)
+			return
+		}
+		defer func() { _ = file.Close() }()
+
+		_, _ = io.Copy(rw, file)
+	})
+
+	client := NewClient("user", "secret")
+	client.BaseURL = server.URL
+
+	return client, &commands
+}
+
+func TestClient_GetZones(t *testing.T) {
+	client, commands := setupTest(t, "QueryDNSZoneList", "./fixtures/QueryDNSZoneList.txt")
+
+	zones, err := client.GetZones()
+	require.NoError(t, err)
+
+	assert.Equal(t, []string{"example.com.", "sub.example.com."}, zones)
+	assert.Equal(t, []string{"COMMAND = QueryDNSZoneList\nFIRST = 0\nLIMIT = 100"}, *commands)
+}
+
+func TestClient_AddTXTRecord(t *testing.T) {
+	client, commands := setupTest(t, "UpdateDNSZone", "./fixtures/ok.txt")
+
+	err := client.AddTXTRecord("example.com.", "_acme-challenge.www.example.com.", 300, "txtTXTtxt")
+	require.NoError(t, err)
+
+	expected := "COMMAND = UpdateDNSZone\nADDRR0 = _acme-challenge.www.example.com. 300 IN TXT \"txtTXTtxt\"\nDNSZONE = example.com."
+	assert.Equal(t, []string{expected}, *commands)
+}
+
+func TestClient_RemoveTXTRecord(t *testing.T) {
+	client, commands := setupTest(t, "UpdateDNSZone", "./fixtures/ok.txt")
+
+	err := client.RemoveTXTRecord("example.com.", "_acme-challenge.www.example.com.", 300, "txtTXTtxt")
+	require.NoError(t, err)
+
+	expected := "COMMAND = UpdateDNSZone\nDELRR0 = _acme-challenge.www.example.com. 300 IN TXT \"txtTXTtxt\"\nDNSZONE = example.com."
+	assert.Equal(t, []string{expected}, *commands)
+}
+
+func TestClient_Call_error(t *testing.T) {
+	client, _ := setupTest(t, "QueryDNSZoneList", "./fixtures/auth_error.txt")
+
+	_, err := client.GetZones()
+	require.EqualError(t, err, "QueryDNSZoneList: 530: Authentication failed")
+
+	var apiErr APIError
+	require.True(t, errors.As(err, &apiErr))
+	assert.Equal(t, 530, apiErr.Code)
+}
+
+func Test_parseResponse(t *testing.T) {
+	testCases := []struct {
+		desc          string
+		raw           string
+		expected      *Response
+		expectedError string
+	}{
+		{
+			desc: "properties",
+			raw:  "[RESPONSE]\ncode = 200\ndescription = Command completed successfully\nproperty[DNSZONE][1] = b.com.\nproperty[dnszone][0] = a.com.\nproperty[total][0] = 2\nEOF\n",
+			expected: &Response{
+				Code:        200,
+				Description: "Command completed successfully",
+				Properties: map[string][]string{
+					"DNSZONE": {"a.com.", "b.com."},
+					"TOTAL":   {"2"},
+				},
+			},
+		},
+		{
+			desc: "value with an equal sign",
+			raw:  "[RESPONSE]\ncode = 200\ndescription = OK\nproperty[rr][0] = @ 300 IN TXT \"a=b\"\nEOF\n",
+			expected: &Response{
+				Code:        200,
+				Description: "OK",
+				Properties: map[string][]string{
+					"RR": {`@ 300 IN TXT "a=b"`},
+				},
+			},
+		},
+		{
+			desc:          "no code",
+			raw:           "<html>error</html>",
+			expectedError: "invalid response: <html>error</html>",
+		},
+		{
+			desc:          "invalid code",
+			raw:           "[RESPONSE]\ncode = abc\nEOF\n",
+			expectedError: "invalid response code: abc",
+		},
+		{
+			desc:          "invalid property",
+			raw:           "[RESPONSE]\ncode = 200\nproperty[rr] = a\nEOF\n",
+			expectedError: "invalid property: property[rr]",
+		},
+	}
+
+	for _, test := range testCases {
+		t.Run(test.desc, func(t *testing.T) {
+			resp, err := parseResponse(test.raw)
+			if test.expectedError != "" {
+				require.EqualError(t, err, test.expectedError)
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, test.expected, resp)
+		})
+	}
+}
@@ -0,0 +1,112 @@
+package internal
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// Response is a parsed response of the ISPAPI.
+type Response struct {
+	Code        int
+	Description string
+	// the properties of the response, by uppercase name.
+	Properties map[string][]string
+}
+
+// Property returns the values of a property.
+func (r *Response) Property(name string) []string {
+	return r.Properties[strings.ToUpper(name)]
+}
+
+// APIError is the error returned by a command.
+type APIError struct {
+	Code        int
+	Description string
+}
+
+func (e APIError) Error() string {
+	return fmt.Sprintf("%d: %s", e.Code, e.Description)
+}
+
+// parseResponse parses the plain text response of the ISPAPI:
+//
+//	[RESPONSE]
+//	code = 200
+//	description = Command completed successfully
+//	property[dnszone][0] = example.com.
+//	EOF
+func parseResponse(raw string) (*Response, error) {
+	resp := &Response{Properties: make(map[string][]string)}
+
+	var hasCode bool
+	for _, line := range strings.Split(raw, "\n") {
+		line = strings.TrimSpace(line)
+		if line == "" || line == "[RESPONSE]" || line == "EOF" {
+			continue
+		}
+
+		key, value, ok := splitLine(line)
+		if !ok {
+			continue
+		}
+
+		switch {
+		case strings.EqualFold(key, "code"):
+			code, err := strconv.Atoi(value)
+			if err != nil {
+				return nil, fmt.Errorf("invalid response code: %s", value)
+			}
+
+			resp.Code = code
+			hasCode = true
+
+		case strings.EqualFold(key, "description"):
+			resp.Description = value
+
+		case strings.HasPrefix(strings.ToLower(key), "property["):
+			name, index, err := parsePropertyKey(key)
+			if err != nil {
+				return nil, err
+			}
+
+			values := resp.Properties[name]
+			for len(values) <= index {
+				values = append(values, "")
+			}
+
+			values[index] = value
+			resp.Properties[name] = values
+		}
+	}
+
+	if !hasCode {
+		return nil, fmt.Errorf("invalid response: %s", strings.TrimSpace(raw))
+	}
+
+	return resp, nil
+}
+
+func splitLine(line string) (string, string, bool) {
+	parts := strings.SplitN(line, "=", 2)
+	if len(parts) != 2 {
+		return "", "", false
+	}
+
+	return strings.TrimSpace(parts[0]), strings.TrimSpace(parts[1]), true
+}
+
+// parsePropertyKey parses a property key (ex: property[dnszone][0]).
+func parsePropertyKey(key string) (string, int, error) {
+	parts := strings.Split(strings.TrimSuffix(key[len("property["):], "]"), "][")
+	if len(parts) != 2 {
+		return "", 0, fmt.Errorf("invalid property: %s", key)
+	}
+
+	index, err := strconv.Atoi(parts[1])
+	if err != nil || index < 0 {
+		return "", 0, fmt.Errorf("invalid property index: %s", key)
+	}
+
+	return strings.ToUpper(parts[0]), index, nil
+}
@@ -11,13 +11,17 @@ import (
 	"time"
 
 	"github.com/cenkalti/backoff/v4"
+	"github.com/go-acme/lego/v4/platform/wait"
 )
 
 const defaultBaseURL = "https://secure.hosting.de/api/dns/v1/json"
 
+// dnsSecModeAutomatic the zone is signed by hosting.de, the zone is re-signed and deployed after each update.
+const dnsSecModeAutomatic = "automatic"
+
 // https://www.hosting.de/api/?json#list-zoneconfigs
 func (d *DNSProvider) listZoneConfigs(findRequest ZoneConfigsFindRequest) (*ZoneConfigsFindResponse, error) {
-	uri := defaultBaseURL + "/zoneConfigsFind"
+	uri := d.baseURL + "/zoneConfigsFind"
 
 	findResponse := &ZoneConfigsFindResponse{}
 
@@ -39,7 +43,7 @@ func (d *DNSProvider) listZoneConfigs(findRequest ZoneConfigsFindRequest) (*Zone
 
 // https://www.hosting.de/api/?json#updating-zones
 func (d *DNSProvider) updateZone(updateRequest ZoneUpdateRequest) (*ZoneUpdateResponse, error) {
-	uri := defaultBaseURL + "/zoneUpdate"
+	uri := d.baseURL + "/zoneUpdate"
 
 	// but we'll need the ID later to delete the record
 	updateResponse := &ZoneUpdateResponse{}
@@ -91,6 +95,23 @@ func (d *DNSProvider) getZone(findRequest ZoneConfigsFindRequest) (*ZoneConfig,
 	return zoneConfig, nil
 }
 
+// waitForZoneDeployment waits until the zone is active again after an update,
+// i.e. the zone has been re-signed and deployed.
+func (d *DNSProvider) waitForZoneDeployment(findRequest ZoneConfigsFindRequest) error {
+	return wait.For("hostingde zone deployment", d.config.PropagationTimeout, d.config.PollingInterval, func() (bool, error) {
+		findResponse, err := d.listZoneConfigs(findRequest)
+		if err != nil {
+			return false, err
+		}
+
+		if findResponse.Response.Data[0].Status != "active" {
+			return false, fmt.Errorf("unexpected status: %q", findResponse.Response.Data[0].Status)
+		}
+
+		return true, nil
+	})
+}
+
 func (d *DNSProvider) post(uri string, request, response interface{}) ([]byte, error) {
 	body, err := json.Marshal(request)
 	if err != nil {
@@ -10,6 +10,7 @@ import (
 
 	"github.com/go-acme/lego/v4/challenge/dns01"
 	"github.com/go-acme/lego/v4/platform/config/env"
+	"github.com/go-acme/lego/v4/providers/dns/internal/useragent"
 )
 
 // Environment variables names.
@@ -41,15 +42,16 @@ func NewDefaultConfig() *Config {
 		TTL:                env.GetOrDefaultInt(EnvTTL, dns01.DefaultTTL),
 		PropagationTimeout: env.GetOrDefaultSecond(EnvPropagationTimeout, 2*time.Minute),
 		PollingInterval:    env.GetOrDefaultSecond(EnvPollingInterval, 2*time.Second),
-		HTTPClient: &http.Client{
+		HTTPClient: useragent.Wrap(&http.Client{
 			Timeout: env.GetOrDefaultSecond(EnvHTTPTimeout, 30*time.Second),
-		},
+		}),
 	}
 }
 
 // DNSProvider implements the challenge.Provider interface.
 type DNSProvider struct {
 	config      *Config
+	baseURL     string
 	recordIDs   map[string]string
 	recordIDsMu sync.Mutex
 }
@@ -86,6 +88,7 @@ func NewDNSProviderConfig(config *Config) (*DNSProvider, error) {
 
 	return &DNSProvider{
 		config:    config,
+		baseURL:   defaultBaseURL,
 		recordIDs: make(map[string]string),
 	}, nil
 }
@@ -147,6 +150,14 @@ func (d *DNSProvider) Present(domain, token, keyAuth string) error {
 		return fmt.Errorf("hostingde: error getting ID of just created record, for domain %s", domain)
 	}
 
+	// the TXT record is only valid once the signed zone has been re-signed.
+	if zoneConfig.DNSSecMode == dnsSecModeAutomatic {
+		err = d.waitForZoneDeployment(zonesFind)
+		if err != nil {
+			return fmt.Errorf("hostingde: waiting for the DNSSEC signing of the zone %s: %w", d.config.ZoneName, err)
+		}
+	}
+
 	return nil
 }
 
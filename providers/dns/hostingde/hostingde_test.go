@@ -1,10 +1,14 @@
 package hostingde
 
 import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
 	"testing"
 	"time"
 
 	"github.com/go-acme/lego/v4/platform/tester"
+	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 )
 
@@ -123,6 +127,67 @@ func TestNewDNSProviderConfig(t *testing.T) {
 	}
 }
 
+func TestDNSProvider_Present(t *testing.T) {
+	testCases := []struct {
+		desc          string
+		dnsSecMode    string
+		statuses      []string
+		expectedFinds int
+	}{
+		{
+			desc:          "unsigned zone",
+			dnsSecMode:    "off",
+			statuses:      []string{"active"},
+			expectedFinds: 1,
+		},
+		{
+			desc:          "signed zone",
+			dnsSecMode:    "automatic",
+			statuses:      []string{"active", "blocked", "active"},
+			expectedFinds: 3,
+		},
+	}
+
+	for _, test := range testCases {
+		t.Run(test.desc, func(t *testing.T) {
+			mux := http.NewServeMux()
+			server := httptest.NewServer(mux)
+			defer server.Close()
+
+			var finds int
+			mux.HandleFunc("/zoneConfigsFind", func(rw http.ResponseWriter, req *http.Request) {
+				status := test.statuses[len(test.statuses)-1]
+				if finds < len(test.statuses) {
+					status = test.statuses[finds]
+				}
+				finds++
+
+				_, _ = fmt.Fprintf(rw, `{"status":"success","response":{"data":[{"id":"1","status":%q,"name":"example.com","dnsSecMode":%q}]}}`, status, test.dnsSecMode)
+			})
+
+			mux.HandleFunc("/zoneUpdate", func(rw http.ResponseWriter, req *http.Request) {
+				_, _ = fmt.Fprint(rw, `{"status":"pending","response":{"records":[{"id":"123","name":"_acme-challenge.example.com","type":"TXT","content":"\"ADw2sEd82DUgXcQ9hNBZThJs7zVJkR5v9JeSbAb9mZY\""}]}}`)
+			})
+
+			config := NewDefaultConfig()
+			config.APIKey = "secret"
+			config.ZoneName = "example.com"
+			config.PollingInterval = 10 * time.Millisecond
+
+			provider, err := NewDNSProviderConfig(config)
+			require.NoError(t, err)
+
+			provider.baseURL = server.URL
+
+			err = provider.Present("example.com", "", "123d==")
+			require.NoError(t, err)
+
+			assert.Equal(t, test.expectedFinds, finds)
+			assert.Equal(t, "123", provider.recordIDs["_acme-challenge.example.com."])
+		})
+	}
+}
+
 func TestLivePresent(t *testing.T) {
 	if !envTest.IsLiveTest() {
 		t.Skip("skipping live test")
@@ -0,0 +1,219 @@
+// Package infomaniak implements a DNS provider for solving the DNS-01 challenge using Infomaniak DNS.
+package infomaniak
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/go-acme/lego/v4/challenge/dns01"
+	"github.com/go-acme/lego/v4/platform/config/env"
+	"github.com/go-acme/lego/v4/providers/dns/infomaniak/internal"
+	"github.com/go-acme/lego/v4/providers/dns/internal/useragent"
+)
+
+// The minimum TTL accepted by the API.
+const minTTL = 300
+
+// Environment variables names.
+const (
+	envNamespace = "INFOMANIAK_"
+
+	EnvEndpoint    = envNamespace + "ENDPOINT"
+	EnvAccessToken = envNamespace + "ACCESS_TOKEN"
+
+	EnvTTL                = envNamespace + "TTL"
+	EnvPropagationTimeout = envNamespace + "PROPAGATION_TIMEOUT"
+	EnvPollingInterval    = envNamespace + "POLLING_INTERVAL"
+	EnvHTTPTimeout        = envNamespace + "HTTP_TIMEOUT"
+)
+
+// Config is used to configure the creation of the DNSProvider.
+type Config struct {
+	APIEndpoint        string
+	AccessToken        string
+	PropagationTimeout time.Duration
+	PollingInterval    time.Duration
+	TTL                int
+	HTTPClient         *http.Client
+}
+
+// NewDefaultConfig returns a default configuration for the DNSProvider.
+func NewDefaultConfig() *Config {
+	return &Config{
+		APIEndpoint:        env.GetOrDefaultString(EnvEndpoint, internal.DefaultBaseURL),
+		TTL:                env.GetOrDefaultInt(EnvTTL, 7200),
+		PropagationTimeout: env.GetOrDefaultSecond(EnvPropagationTimeout, dns01.DefaultPropagationTimeout),
+		PollingInterval:    env.GetOrDefaultSecond(EnvPollingInterval, dns01.DefaultPollingInterval),
+		HTTPClient: useragent.Wrap(&http.Client{
+			Timeout: env.GetOrDefaultSecond(EnvHTTPTimeout, 30*time.Second),
+		}),
+	}
+}
+
+// DNSProvider implements the challenge.Provider interface.
+type DNSProvider struct {
+	config *Config
+	client *internal.Client
+
+	records   map[string]txtRecord
+	recordsMu sync.Mutex
+}
+
+type txtRecord struct {
+	domainID uint64
+	id       string
+}
+
+// NewDNSProvider returns a DNSProvider instance configured for Infomaniak.
+// Credentials must be passed in the environment variable: INFOMANIAK_ACCESS_TOKEN.
+func NewDNSProvider() (*DNSProvider, error) {
+	values, err := env.Get(EnvAccessToken)
+	if err != nil {
+		return nil, fmt.Errorf("infomaniak: %w", err)
+	}
+
+	config := NewDefaultConfig()
+	config.AccessToken = values[EnvAccessToken]
+
+	return NewDNSProviderConfig(config)
+}
+
+// NewDNSProviderConfig return a DNSProvider instance configured for Infomaniak.
+func NewDNSProviderConfig(config *Config) (*DNSProvider, error) {
+	if config == nil {
+		return nil, errors.New("infomaniak: the configuration of the DNS provider is nil")
+	}
+
+	if config.AccessToken == "" {
+		return nil, errors.New("infomaniak: missing access token")
+	}
+
+	if config.TTL < minTTL {
+		return nil, fmt.Errorf("infomaniak: invalid TTL, TTL (%d) must be greater than %d", config.TTL, minTTL)
+	}
+
+	client := internal.NewClient(config.AccessToken)
+
+	if config.APIEndpoint != "" {
+		client.BaseURL = config.APIEndpoint
+	}
+
+	if config.HTTPClient != nil {
+		client.HTTPClient = config.HTTPClient
+	}
+
+	return &DNSProvider{
+		config:  config,
+		client:  client,
+		records: make(map[string]txtRecord),
+	}, nil
+}
+
+// Timeout returns the timeout and interval to use when checking for DNS propagation.
+// Adjusting here to cope with spikes in propagation times.
+func (d *DNSProvider) Timeout() (timeout, interval time.Duration) {
+	return d.config.PropagationTimeout, d.config.PollingInterval
+}
+
+// Present creates a TXT record to fulfill the dns-01 challenge.
+func (d *DNSProvider) Present(domain, token, keyAuth string) error {
+	fqdn, value := dns01.GetRecord(domain, keyAuth)
+
+	ikDomain, err := d.findDomain(fqdn)
+	if err != nil {
+		return fmt.Errorf("infomaniak: %w", err)
+	}
+
+	record := internal.Record{
+		Source: extractRecordName(fqdn, ikDomain.CustomerName),
+		Target: value,
+		Type:   "TXT",
+		TTL:    d.config.TTL,
+	}
+
+	id, err := d.client.CreateRecord(ikDomain.ID, record)
+	if err != nil {
+		return fmt.Errorf("infomaniak: failed to create TXT record: fqdn=%s, domain=%s: %w", fqdn, ikDomain.CustomerName, err)
+	}
+
+	d.recordsMu.Lock()
+	d.records[token] = txtRecord{domainID: ikDomain.ID, id: id}
+	d.recordsMu.Unlock()
+
+	return nil
+}
+
+// CleanUp removes the TXT record matching the specified parameters.
+func (d *DNSProvider) CleanUp(domain, token, keyAuth string) error {
+	fqdn, _ := dns01.GetRecord(domain, keyAuth)
+
+	// gets the record's unique ID from when we created it
+	d.recordsMu.Lock()
+	record, ok := d.records[token]
+	d.recordsMu.Unlock()
+	if !ok {
+		return fmt.Errorf("infomaniak: unknown record ID for '%s'", fqdn)
+	}
+
+	err := d.client.DeleteRecord(record.domainID, record.id)
+	if err != nil {
+		return fmt.Errorf("infomaniak: failed to delete TXT record: fqdn=%s, recordID=%s: %w", fqdn, record.id, err)
+	}
+
+	// deletes record ID from map
+	d.recordsMu.Lock()
+	delete(d.records, token)
+	d.recordsMu.Unlock()
+
+	return nil
+}
+
+// Zones returns the domains of the account.
+func (d *DNSProvider) Zones() ([]string, error) {
+	domains, err := d.client.GetDomains()
+	if err != nil {
+		return nil, fmt.Errorf("infomaniak: failed to get domains: %w", err)
+	}
+
+	var zones []string
+	for _, domain := range domains {
+		zones = append(zones, domain.CustomerName)
+	}
+
+	return zones, nil
+}
+
+// findDomain finds the domain of the account which is the most specific for the fqdn.
+func (d *DNSProvider) findDomain(fqdn string) (internal.DNSDomain, error) {
+	domains, err := d.client.GetDomains()
+	if err != nil {
+		return internal.DNSDomain{}, fmt.Errorf("failed to get domains: %w", err)
+	}
+
+	var names []string
+	for _, domain := range domains {
+		names = append(names, domain.CustomerName)
+	}
+
+	name := dns01.FindMostSpecificZone(fqdn, names)
+	if name == "" {
+		return internal.DNSDomain{}, fmt.Errorf("no domain found for %s", dns01.UnFqdn(fqdn))
+	}
+
+	for _, domain := range domains {
+		if domain.CustomerName == name {
+			return domain, nil
+		}
+	}
+
+	return internal.DNSDomain{}, fmt.Errorf("no domain found for %s", dns01.UnFqdn(fqdn))
+}
+
+// extractRecordName returns the name of the record relatively to the domain.
+func extractRecordName(fqdn, domain string) string {
+	return strings.TrimSuffix(dns01.UnFqdn(fqdn), "."+domain)
+}
@@ -0,0 +1,243 @@
+package infomaniak
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/go-acme/lego/v4/platform/tester"
+	"github.com/go-acme/lego/v4/providers/dns/infomaniak/internal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const envDomain = envNamespace + "DOMAIN"
+
+var envTest = tester.NewEnvTest(EnvEndpoint, EnvAccessToken).WithDomain(envDomain)
+
+const responseDomains = `{"result":"success","data":[` +
+	`{"id":123,"service_name":"domain","customer_name":"example.com"},` +
+	`{"id":456,"service_name":"domain","customer_name":"sub.example.com"}` +
+	`]}`
+
+func TestNewDNSProvider(t *testing.T) {
+	testCases := []struct {
+		desc     string
+		envVars  map[string]string
+		expected string
+	}{
+		{
+			desc: "success",
+			envVars: map[string]string{
+				EnvAccessToken: "secret",
+			},
+		},
+		{
+			desc: "missing access token",
+			envVars: map[string]string{
+				EnvAccessToken: "",
+			},
+			expected: "infomaniak: some credentials information are missing: INFOMANIAK_ACCESS_TOKEN",
+		},
+	}
+
+	for _, test := range testCases {
+		t.Run(test.desc, func(t *testing.T) {
+			defer envTest.RestoreEnv()
+			envTest.ClearEnv()
+
+			envTest.Apply(test.envVars)
+
+			p, err := NewDNSProvider()
+
+			if len(test.expected) == 0 {
+				require.NoError(t, err)
+				require.NotNil(t, p)
+				require.NotNil(t, p.config)
+				require.NotNil(t, p.client)
+			} else {
+				require.EqualError(t, err, test.expected)
+			}
+		})
+	}
+}
+
+func TestNewDNSProviderConfig(t *testing.T) {
+	testCases := []struct {
+		desc        string
+		accessToken string
+		ttl         int
+		expected    string
+	}{
+		{
+			desc:        "success",
+			accessToken: "secret",
+			ttl:         minTTL,
+		},
+		{
+			desc:     "missing access token",
+			ttl:      minTTL,
+			expected: "infomaniak: missing access token",
+		},
+		{
+			desc:        "invalid TTL",
+			accessToken: "secret",
+			ttl:         60,
+			expected:    "infomaniak: invalid TTL, TTL (60) must be greater than 300",
+		},
+	}
+
+	for _, test := range testCases {
+		t.Run(test.desc, func(t *testing.T) {
+			config := NewDefaultConfig()
+			config.AccessToken = test.accessToken
+			config.TTL = test.ttl
+
+			p, err := NewDNSProviderConfig(config)
+
+			if len(test.expected) == 0 {
+				require.NoError(t, err)
+				require.NotNil(t, p)
+				require.NotNil(t, p.config)
+				require.NotNil(t, p.client)
+			} else {
+				require.EqualError(t, err, test.expected)
+			}
+		})
+	}
+}
+
+func setupTest(t *testing.T) (*DNSProvider, *http.ServeMux) {
+	t.Helper()
+
+	mux := http.NewServeMux()
+	server := httptest.NewServer(mux)
+	t.Cleanup(server.Close)
+
+	mux.HandleFunc("/1/product", func(rw http.ResponseWriter, req *http.Request) {
+		_, _ = fmt.Fprint(rw, responseDomains)
+	})
+
+	config := NewDefaultConfig()
+	config.APIEndpoint = server.URL
+	config.AccessToken = "secret"
+	config.TTL = minTTL
+
+	provider, err := NewDNSProviderConfig(config)
+	require.NoError(t, err)
+
+	return provider, mux
+}
+
+func TestDNSProvider_Present(t *testing.T) {
+	provider, mux := setupTest(t)
+
+	var created []internal.Record
+	mux.HandleFunc("/1/domain/456/dns/record", func(rw http.ResponseWriter, req *http.Request) {
+		if req.Method != http.MethodPost {
+			http.Error(rw, fmt.Sprintf("unsupported method: %s", req.Method), http.StatusMethodNotAllowed)
+			return
+		}
+
+		var record internal.Record
+		err := json.NewDecoder(req.Body).Decode(&record)
+		if err != nil {
+			http.Error(rw, err.Error(), http.StatusBadRequest)
+			return
+		}
+
+		created = append(created, record)
+
+		_, _ = fmt.Fprint(rw, `{"result":"success","data":"789"}`)
+	})
+
+	err := provider.Present("www.sub.example.com", "token", "123d==")
+	require.NoError(t, err)
+
+	expected := []internal.Record{
+		{Source: "_acme-challenge.www", Target: "ADw2sEd82DUgXcQ9hNBZThJs7zVJkR5v9JeSbAb9mZY", Type: "TXT", TTL: minTTL},
+	}
+	assert.Equal(t, expected, created)
+
+	assert.Equal(t, map[string]txtRecord{"token": {domainID: 456, id: "789"}}, provider.records)
+}
+
+func TestDNSProvider_Present_unknownDomain(t *testing.T) {
+	provider, _ := setupTest(t)
+
+	err := provider.Present("www.example.org", "token", "123d==")
+	require.EqualError(t, err, "infomaniak: no domain found for _acme-challenge.www.example.org")
+}
+
+func TestDNSProvider_Present_error(t *testing.T) {
+	provider, mux := setupTest(t)
+
+	mux.HandleFunc("/1/domain/123/dns/record", func(rw http.ResponseWriter, req *http.Request) {
+		rw.WriteHeader(http.StatusUnprocessableEntity)
+		_, _ = fmt.Fprint(rw, `{"result":"error","error":{"code":"validation_failed","description":"Invalid TTL"}}`)
+	})
+
+	err := provider.Present("example.com", "token", "123d==")
+	require.EqualError(t, err, "infomaniak: failed to create TXT record: fqdn=_acme-challenge.example.com., domain=example.com: validation_failed: Invalid TTL")
+}
+
+func TestDNSProvider_CleanUp(t *testing.T) {
+	provider, mux := setupTest(t)
+
+	var deleted int
+	mux.HandleFunc("/1/domain/123/dns/record/789", func(rw http.ResponseWriter, req *http.Request) {
+		if req.Method != http.MethodDelete {
+			http.Error(rw, fmt.Sprintf("unsupported method: %s", req.Method), http.StatusMethodNotAllowed)
+			return
+		}
+
+		deleted++
+
+		_, _ = fmt.Fprint(rw, `{"result":"success","data":true}`)
+	})
+
+	provider.records["token"] = txtRecord{domainID: 123, id: "789"}
+
+	err := provider.CleanUp("example.com", "token", "123d==")
+	require.NoError(t, err)
+
+	assert.Equal(t, 1, deleted)
+	assert.Empty(t, provider.records)
+}
+
+func TestDNSProvider_CleanUp_unknownRecord(t *testing.T) {
+	provider, _ := setupTest(t)
+
+	err := provider.CleanUp("www.example.com", "token", "123d==")
+	require.EqualError(t, err, "infomaniak: unknown record ID for '_acme-challenge.www.example.com.'")
+}
+
+func TestDNSProvider_Zones(t *testing.T) {
+	provider, _ := setupTest(t)
+
+	zones, err := provider.Zones()
+	require.NoError(t, err)
+
+	assert.Equal(t, []string{"example.com", "sub.example.com"}, zones)
+}
+
+func TestLivePresentAndCleanUp(t *testing.T) {
+	if !envTest.IsLiveTest() {
+		t.Skip("skipping live test")
+	}
+
+	envTest.RestoreEnv()
+	provider, err := NewDNSProvider()
+	require.NoError(t, err)
+
+	err = provider.Present(envTest.GetDomain(), "", "123d==")
+	require.NoError(t, err)
+
+	time.Sleep(1 * time.Second)
+
+	err = provider.CleanUp(envTest.GetDomain(), "", "123d==")
+	require.NoError(t, err)
+}
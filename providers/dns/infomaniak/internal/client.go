@@ -0,0 +1,144 @@
+package internal
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"path"
+	"strconv"
+	"strings"
+)
+
+// DefaultBaseURL the URL of the API.
+const DefaultBaseURL = "https://api.infomaniak.com"
+
+// Client the Infomaniak API client.
+type Client struct {
+	HTTPClient *http.Client
+	BaseURL    string
+
+	accessToken string
+}
+
+// NewClient creates a new Client.
+func NewClient(accessToken string) *Client {
+	return &Client{
+		HTTPClient:  http.DefaultClient,
+		BaseURL:     DefaultBaseURL,
+		accessToken: accessToken,
+	}
+}
+
+// GetDomains gets the domains of the account.
+// https://developer.infomaniak.com/docs/api/get/1/product
+func (c *Client) GetDomains() ([]DNSDomain, error) {
+	query := url.Values{}
+	query.Set("service_name", "domain")
+
+	var domains []DNSDomain
+	err := c.do(http.MethodGet, query, nil, &domains, "1", "product")
+	if err != nil {
+		return nil, err
+	}
+
+	return domains, nil
+}
+
+// CreateRecord creates a DNS record in a domain, and returns the ID of the record.
+// https://developer.infomaniak.com/docs/api/post/1/domain/%7Bdomain%7D/dns/record
+func (c *Client) CreateRecord(domainID uint64, record Record) (string, error) {
+	body, err := json.Marshal(record)
+	if err != nil {
+		return "", fmt.Errorf("failed to marshal request body: %w", err)
+	}
+
+	var recordID string
+	err = c.do(http.MethodPost, nil, bytes.NewReader(body), &recordID, "1", "domain", strconv.FormatUint(domainID, 10), "dns", "record")
+	if err != nil {
+		return "", err
+	}
+
+	return recordID, nil
+}
+
+// DeleteRecord deletes a DNS record of a domain.
+// https://developer.infomaniak.com/docs/api/delete/1/domain/%7Bdomain%7D/dns/record/%7Brecord%7D
+func (c *Client) DeleteRecord(domainID uint64, recordID string) error {
+	return c.do(http.MethodDelete, nil, nil, nil, "1", "domain", strconv.FormatUint(domainID, 10), "dns", "record", recordID)
+}
+
+func (c *Client) do(method string, query url.Values, body io.Reader, result interface{}, parts ...string) error {
+	endpoint, err := c.createEndpoint(parts...)
+	if err != nil {
+		return fmt.Errorf("failed to create endpoint: %w", err)
+	}
+
+	endpoint.RawQuery = query.Encode()
+
+	req, err := http.NewRequest(method, endpoint.String(), body)
+	if err != nil {
+		return err
+	}
+
+	req.Header.Set("Accept", "application/json")
+	if body != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+
+	req.Header.Set("Authorization", "Bearer "+c.accessToken)
+
+	resp, err := c.HTTPClient.Do(req)
+	if err != nil {
+		return err
+	}
+
+	defer func() { _ = resp.Body.Close() }()
+
+	raw, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return fmt.Errorf("failed to read response body: %w", err)
+	}
+
+	var apiResp apiResponse
+	err = json.Unmarshal(raw, &apiResp)
+	if err != nil {
+		return fmt.Errorf("unexpected response: %d: %s", resp.StatusCode, strings.TrimSpace(string(raw)))
+	}
+
+	if apiResp.Result != "success" {
+		if apiResp.Error != nil {
+			return *apiResp.Error
+		}
+
+		return fmt.Errorf("unexpected response: %d: %s", resp.StatusCode, strings.TrimSpace(string(raw)))
+	}
+
+	if result == nil || len(apiResp.Data) == 0 {
+		return nil
+	}
+
+	err = json.Unmarshal(apiResp.Data, result)
+	if err != nil {
+		return fmt.Errorf("failed to unmarshal response data: %w", err)
+	}
+
+	return nil
+}
+
+func (c *Client) createEndpoint(parts ...string) (*url.URL, error) {
+	baseURL, err := url.Parse(c.BaseURL)
+	if err != nil {
+		return nil, err
+	}
+
+	escaped := make([]string, len(parts))
+	for i, part := range parts {
+		escaped[i] = url.PathEscape(part)
+	}
+
+	return baseURL.Parse(path.Join(escaped...))
+}
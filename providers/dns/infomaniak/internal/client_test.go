@@ -0,0 +1,126 @@
+package internal
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func setupTest(t *testing.T) (*Client, *http.ServeMux) {
+	t.Helper()
+
+	mux := http.NewServeMux()
+	server := httptest.NewServer(mux)
+	t.Cleanup(server.Close)
+
+	client := NewClient("secret")
+	client.BaseURL = server.URL
+
+	return client, mux
+}
+
+// fixtureHandler checks the method and the access token, and writes the fixture.
+func fixtureHandler(method string, status int, filename string) http.HandlerFunc {
+	return func(rw http.ResponseWriter, req *http.Request) {
+		if req.Method != method {
+			http.Error(rw, fmt.Sprintf("unsupported method: %s", req.Method), http.StatusMethodNotAllowed)
+			return
+		}
+
+		if req.Header.Get("Authorization") != "Bearer secret" {
+			rw.WriteHeader(http.StatusUnauthorized)
+			_, _ = fmt.Fprint(rw, `{"result":"error","error":{"code":"not_authorized","description":"Authorization required"}}`)
+			return
+		}
+
+		file, err := os.Open(filename)
+		if err != nil {
+			http.Error(rw, err.Error(), http.StatusInternalServerError)
+			return
+		}
+		defer func() { _ = file.Close() }()
+
+		rw.WriteHeader(status)
+		_, _ = io.Copy(rw, file)
+	}
+}
+
+func TestClient_GetDomains(t *testing.T) {
+	client, mux := setupTest(t)
+
+	mux.HandleFunc("/1/product", func(rw http.ResponseWriter, req *http.Request) {
+		if req.URL.Query().Get("service_name") != "domain" {
+			http.Error(rw, fmt.Sprintf("unexpected query: %s", req.URL.RawQuery), http.StatusBadRequest)
+			return
+		}
+
+		fixtureHandler(http.MethodGet, http.StatusOK, "./fixtures/domains.json")(rw, req)
+	})
+
+	domains, err := client.GetDomains()
+	require.NoError(t, err)
+
+	expected := []DNSDomain{
+		{ID: 123, CustomerName: "example.com"},
+		{ID: 456, CustomerName: "sub.example.com"},
+	}
+	assert.Equal(t, expected, domains)
+}
+
+func TestClient_CreateRecord(t *testing.T) {
+	client, mux := setupTest(t)
+
+	mux.HandleFunc("/1/domain/123/dns/record", func(rw http.ResponseWriter, req *http.Request) {
+		var record Record
+		err := json.NewDecoder(req.Body).Decode(&record)
+		if err != nil {
+			http.Error(rw, err.Error(), http.StatusBadRequest)
+			return
+		}
+
+		expected := Record{Source: "_acme-challenge", Target: "txtTXTtxt", Type: "TXT", TTL: 300}
+		if record != expected {
+			http.Error(rw, fmt.Sprintf("unexpected record: %+v", record), http.StatusBadRequest)
+			return
+		}
+
+		fixtureHandler(http.MethodPost, http.StatusOK, "./fixtures/create_record.json")(rw, req)
+	})
+
+	id, err := client.CreateRecord(123, Record{Source: "_acme-challenge", Target: "txtTXTtxt", Type: "TXT", TTL: 300})
+	require.NoError(t, err)
+
+	assert.Equal(t, "789", id)
+}
+
+func TestClient_DeleteRecord(t *testing.T) {
+	client, mux := setupTest(t)
+	mux.HandleFunc("/1/domain/123/dns/record/789", fixtureHandler(http.MethodDelete, http.StatusOK, "./fixtures/delete_record.json"))
+
+	err := client.DeleteRecord(123, "789")
+	require.NoError(t, err)
+}
+
+func TestClient_DeleteRecord_error(t *testing.T) {
+	client, mux := setupTest(t)
+	mux.HandleFunc("/1/domain/123/dns/record/789", fixtureHandler(http.MethodDelete, http.StatusNotFound, "./fixtures/error.json"))
+
+	err := client.DeleteRecord(123, "789")
+	require.EqualError(t, err, "not_found: Record not found")
+}
+
+func TestClient_invalidAccessToken(t *testing.T) {
+	client, mux := setupTest(t)
+	mux.HandleFunc("/1/product", fixtureHandler(http.MethodGet, http.StatusOK, "./fixtures/domains.json"))
+	client.accessToken = "invalid"
+
+	_, err := client.GetDomains()
+	require.EqualError(t, err, "not_authorized: Authorization required")
+}
@@ -0,0 +1,37 @@
+package internal
+
+import (
+	"encoding/json"
+	"fmt"
+)
+
+// APIError is the error returned by the API.
+type APIError struct {
+	Code        string `json:"code"`
+	Description string `json:"description,omitempty"`
+}
+
+func (e APIError) Error() string {
+	return fmt.Sprintf("%s: %s", e.Code, e.Description)
+}
+
+type apiResponse struct {
+	Result string          `json:"result"`
+	Data   json.RawMessage `json:"data,omitempty"`
+	Error  *APIError       `json:"error,omitempty"`
+}
+
+// DNSDomain a domain of the account.
+type DNSDomain struct {
+	ID           uint64 `json:"id"`
+	CustomerName string `json:"customer_name"`
+}
+
+// Record a DNS record.
+type Record struct {
+	ID     string `json:"id,omitempty"`
+	Source string `json:"source"`
+	Target string `json:"target"`
+	Type   string `json:"type"`
+	TTL    int    `json:"ttl"`
+}
@@ -0,0 +1,57 @@
+// Package useragent allows to override the User-Agent sent by the DNS providers.
+package useragent
+
+import (
+	"net/http"
+	"os"
+)
+
+// EnvUserAgent is the environment variable used to override the User-Agent of the HTTP clients of the DNS providers.
+const EnvUserAgent = "LEGO_USER_AGENT"
+
+// Get returns the User-Agent defined by LEGO_USER_AGENT, or an empty string.
+func Get() string {
+	return os.Getenv(EnvUserAgent)
+}
+
+// Transport sets the User-Agent header of the requests before delegating them to Base.
+type Transport struct {
+	UserAgent string
+
+	// Base is the underlying transport (http.DefaultTransport if nil).
+	Base http.RoundTripper
+}
+
+// RoundTrip implements http.RoundTripper.
+func (t *Transport) RoundTrip(req *http.Request) (*http.Response, error) {
+	if t.UserAgent == "" {
+		return t.base().RoundTrip(req)
+	}
+
+	// a RoundTripper must not modify the request.
+	r := req.Clone(req.Context())
+	r.Header.Set("User-Agent", t.UserAgent)
+
+	return t.base().RoundTrip(r)
+}
+
+func (t *Transport) base() http.RoundTripper {
+	if t.Base != nil {
+		return t.Base
+	}
+
+	return http.DefaultTransport
+}
+
+// Wrap wraps the transport of the client with a Transport using the User-Agent defined by LEGO_USER_AGENT.
+// The client is returned unchanged if LEGO_USER_AGENT is not defined.
+func Wrap(client *http.Client) *http.Client {
+	ua := Get()
+	if client == nil || ua == "" {
+		return client
+	}
+
+	client.Transport = &Transport{UserAgent: ua, Base: client.Transport}
+
+	return client
+}
@@ -0,0 +1,77 @@
+package useragent
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/go-acme/lego/v4/platform/tester"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var envTest = tester.NewEnvTest(EnvUserAgent)
+
+func setupTest(t *testing.T) (*httptest.Server, *string) {
+	t.Helper()
+
+	var userAgent string
+	server := httptest.NewServer(http.HandlerFunc(func(_ http.ResponseWriter, req *http.Request) {
+		userAgent = req.Header.Get("User-Agent")
+	}))
+	t.Cleanup(server.Close)
+
+	return server, &userAgent
+}
+
+func TestWrap(t *testing.T) {
+	server, userAgent := setupTest(t)
+
+	defer envTest.RestoreEnv()
+	envTest.Apply(map[string]string{EnvUserAgent: "my-automation/1.0"})
+
+	client := Wrap(&http.Client{})
+
+	req, err := http.NewRequest(http.MethodGet, server.URL, nil)
+	require.NoError(t, err)
+	req.Header.Set("User-Agent", "provider-sdk/2.0")
+
+	resp, err := client.Do(req)
+	require.NoError(t, err)
+	_ = resp.Body.Close()
+
+	assert.Equal(t, "my-automation/1.0", *userAgent)
+	assert.Equal(t, "provider-sdk/2.0", req.Header.Get("User-Agent"), "the original request must not be modified")
+}
+
+func TestWrap_notDefined(t *testing.T) {
+	server, userAgent := setupTest(t)
+
+	defer envTest.RestoreEnv()
+	envTest.ClearEnv()
+
+	client := Wrap(&http.Client{})
+	assert.Nil(t, client.Transport)
+
+	req, err := http.NewRequest(http.MethodGet, server.URL, nil)
+	require.NoError(t, err)
+	req.Header.Set("User-Agent", "provider-sdk/2.0")
+
+	resp, err := client.Do(req)
+	require.NoError(t, err)
+	_ = resp.Body.Close()
+
+	assert.Equal(t, "provider-sdk/2.0", *userAgent)
+}
+
+func TestWrap_keepBaseTransport(t *testing.T) {
+	defer envTest.RestoreEnv()
+	envTest.Apply(map[string]string{EnvUserAgent: "my-automation/1.0"})
+
+	base := &http.Transport{}
+	client := Wrap(&http.Client{Transport: base})
+
+	transport, ok := client.Transport.(*Transport)
+	require.True(t, ok)
+	assert.Same(t, base, transport.Base)
+}
@@ -4,15 +4,24 @@ package inwx
 import (
 	"errors"
 	"fmt"
+	"sync"
 	"time"
 
 	"github.com/go-acme/lego/v4/challenge/dns01"
 	"github.com/go-acme/lego/v4/log"
 	"github.com/go-acme/lego/v4/platform/config/env"
+	"github.com/go-acme/lego/v4/providers/dns/internal/useragent"
+	"github.com/kolo/xmlrpc"
 	"github.com/nrdcg/goinwx"
 	"github.com/pquerna/otp/totp"
 )
 
+// The minimum TTL accepted by the API.
+const (
+	minTTL        = 300
+	minSandboxTTL = 3600
+)
+
 // Environment variables names.
 const (
 	envNamespace = "INWX_"
@@ -52,6 +61,9 @@ func NewDefaultConfig() *Config {
 type DNSProvider struct {
 	config *Config
 	client *goinwx.Client
+
+	recordIDs   map[string]int
+	recordIDsMu sync.Mutex
 }
 
 // NewDNSProvider returns a DNSProvider instance configured for Dyn DNS.
@@ -87,7 +99,25 @@ func NewDNSProviderConfig(config *Config) (*DNSProvider, error) {
 
 	client := goinwx.NewClient(config.Username, config.Password, &goinwx.ClientOptions{Sandbox: config.Sandbox})
 
-	return &DNSProvider{config: config, client: client}, nil
+	if ua := useragent.Get(); ua != "" {
+		baseURL := goinwx.APIBaseURL
+		if config.Sandbox {
+			baseURL = goinwx.APISandboxBaseURL
+		}
+
+		rpcClient, err := xmlrpc.NewClient(baseURL, &useragent.Transport{UserAgent: ua})
+		if err != nil {
+			return nil, fmt.Errorf("inwx: %w", err)
+		}
+
+		client.RPCClient = rpcClient
+	}
+
+	return &DNSProvider{
+		config:    config,
+		client:    client,
+		recordIDs: make(map[string]int),
+	}, nil
 }
 
 // Present creates a TXT record using the specified parameters.
@@ -99,43 +129,14 @@ func (d *DNSProvider) Present(domain, token, keyAuth string) error {
 		return fmt.Errorf("inwx: %w", err)
 	}
 
-	info, err := d.client.Account.Login()
-	if err != nil {
-		return fmt.Errorf("inwx: %w", err)
-	}
-
-	defer func() {
-		errL := d.client.Account.Logout()
-		if errL != nil {
-			log.Infof("inwx: failed to logout: %v", errL)
-		}
-	}()
-
-	err = d.twoFactorAuth(info)
+	recordID, err := d.addTxtRecord(dns01.UnFqdn(authZone), dns01.UnFqdn(fqdn), value)
 	if err != nil {
 		return fmt.Errorf("inwx: %w", err)
 	}
 
-	request := &goinwx.NameserverRecordRequest{
-		Domain:  dns01.UnFqdn(authZone),
-		Name:    dns01.UnFqdn(fqdn),
-		Type:    "TXT",
-		Content: value,
-		TTL:     d.config.TTL,
-	}
-
-	_, err = d.client.Nameservers.CreateRecord(request)
-	if err != nil {
-		switch er := err.(type) {
-		case *goinwx.ErrorResponse:
-			if er.Message == "Object exists" {
-				return nil
-			}
-			return fmt.Errorf("inwx: %w", err)
-		default:
-			return fmt.Errorf("inwx: %w", err)
-		}
-	}
+	d.recordIDsMu.Lock()
+	d.recordIDs[token] = recordID
+	d.recordIDsMu.Unlock()
 
 	return nil
 }
@@ -144,9 +145,12 @@ func (d *DNSProvider) Present(domain, token, keyAuth string) error {
 func (d *DNSProvider) CleanUp(domain, token, keyAuth string) error {
 	fqdn, _ := dns01.GetRecord(domain, keyAuth)
 
-	authZone, err := dns01.FindZoneByFqdn(fqdn)
-	if err != nil {
-		return fmt.Errorf("inwx: %w", err)
+	// gets the record's unique ID from when we created it
+	d.recordIDsMu.Lock()
+	recordID, ok := d.recordIDs[token]
+	d.recordIDsMu.Unlock()
+	if !ok {
+		return fmt.Errorf("inwx: unknown record ID for '%s'", fqdn)
 	}
 
 	info, err := d.client.Account.Login()
@@ -166,24 +170,17 @@ func (d *DNSProvider) CleanUp(domain, token, keyAuth string) error {
 		return fmt.Errorf("inwx: %w", err)
 	}
 
-	response, err := d.client.Nameservers.Info(&goinwx.NameserverInfoRequest{
-		Domain: dns01.UnFqdn(authZone),
-		Name:   dns01.UnFqdn(fqdn),
-		Type:   "TXT",
-	})
+	err = d.client.Nameservers.DeleteRecord(recordID)
 	if err != nil {
 		return fmt.Errorf("inwx: %w", err)
 	}
 
-	var lastErr error
-	for _, record := range response.Records {
-		err = d.client.Nameservers.DeleteRecord(record.ID)
-		if err != nil {
-			lastErr = fmt.Errorf("inwx: %w", err)
-		}
-	}
+	// deletes record ID from map
+	d.recordIDsMu.Lock()
+	delete(d.recordIDs, token)
+	d.recordIDsMu.Unlock()
 
-	return lastErr
+	return nil
 }
 
 // Timeout returns the timeout and interval to use when checking for DNS propagation.
@@ -208,3 +205,78 @@ func (d *DNSProvider) twoFactorAuth(info *goinwx.LoginResponse) error {
 
 	return d.client.Account.Unlock(tan)
 }
+
+// addTxtRecord creates the TXT record and returns its ID.
+// The TTL is clamped to the minimum accepted by the API (higher in sandbox mode).
+func (d *DNSProvider) addTxtRecord(zone, name, value string) (int, error) {
+	info, err := d.client.Account.Login()
+	if err != nil {
+		return 0, err
+	}
+
+	defer func() {
+		errL := d.client.Account.Logout()
+		if errL != nil {
+			log.Infof("inwx: failed to logout: %v", errL)
+		}
+	}()
+
+	err = d.twoFactorAuth(info)
+	if err != nil {
+		return 0, err
+	}
+
+	request := &goinwx.NameserverRecordRequest{
+		Domain:  zone,
+		Name:    name,
+		Type:    "TXT",
+		Content: value,
+		TTL:     d.ttl(),
+	}
+
+	recordID, err := d.client.Nameservers.CreateRecord(request)
+	if err != nil {
+		var er *goinwx.ErrorResponse
+		if !errors.As(err, &er) || er.Message != "Object exists" {
+			return 0, err
+		}
+
+		// the record has been created by a previous run.
+		return d.findRecordID(zone, name, value)
+	}
+
+	return recordID, nil
+}
+
+func (d *DNSProvider) findRecordID(zone, name, value string) (int, error) {
+	response, err := d.client.Nameservers.Info(&goinwx.NameserverInfoRequest{
+		Domain:  zone,
+		Name:    name,
+		Type:    "TXT",
+		Content: value,
+	})
+	if err != nil {
+		return 0, err
+	}
+
+	for _, record := range response.Records {
+		if record.Content == value {
+			return record.ID, nil
+		}
+	}
+
+	return 0, fmt.Errorf("the existing record %s cannot be found", name)
+}
+
+func (d *DNSProvider) ttl() int {
+	min := minTTL
+	if d.config.Sandbox {
+		min = minSandboxTTL
+	}
+
+	if d.config.TTL < min {
+		return min
+	}
+
+	return d.config.TTL
+}
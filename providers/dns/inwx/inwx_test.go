@@ -1,9 +1,17 @@
 package inwx
 
 import (
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"regexp"
 	"testing"
 
 	"github.com/go-acme/lego/v4/platform/tester"
+	"github.com/nrdcg/goinwx"
+	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 )
 
@@ -116,6 +124,161 @@ func TestNewDNSProviderConfig(t *testing.T) {
 	}
 }
 
+const responseTemplate = `<?xml version="1.0" encoding="UTF-8"?>
+<methodResponse><params><param><value><struct>
+<member><name>code</name><value><int>%d</int></value></member>
+<member><name>msg</name><value><string>%s</string></value></member>
+<member><name>resData</name><value><struct>%s</struct></value></member>
+</struct></value></param></params></methodResponse>`
+
+var methodNameRegexp = regexp.MustCompile(`<methodName>([^<]+)</methodName>`)
+
+// setupTest starts a fake XML-RPC API, the handlers return the resData members of the response of a method.
+// The bodies of the requests are recorded by method.
+func setupTest(t *testing.T, config *Config, handlers map[string]func() (int, string, string)) (*DNSProvider, map[string][]string) {
+	t.Helper()
+
+	requests := make(map[string][]string)
+
+	server := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		raw, err := ioutil.ReadAll(req.Body)
+		if err != nil {
+			http.Error(rw, err.Error(), http.StatusBadRequest)
+			return
+		}
+
+		match := methodNameRegexp.FindSubmatch(raw)
+		if match == nil {
+			http.Error(rw, "missing method name", http.StatusBadRequest)
+			return
+		}
+
+		method := string(match[1])
+		requests[method] = append(requests[method], string(raw))
+
+		code, msg, data := 1000, "Command completed successfully", ""
+		if handler, ok := handlers[method]; ok {
+			code, msg, data = handler()
+		}
+
+		_, _ = fmt.Fprintf(rw, responseTemplate, code, msg, data)
+	}))
+	t.Cleanup(server.Close)
+
+	config.Username = "user"
+	config.Password = "secret"
+
+	provider, err := NewDNSProviderConfig(config)
+	require.NoError(t, err)
+
+	baseURL, err := url.Parse(server.URL + "/xmlrpc/")
+	require.NoError(t, err)
+
+	provider.client = goinwx.NewClient(config.Username, config.Password, &goinwx.ClientOptions{BaseURL: baseURL})
+
+	return provider, requests
+}
+
+func TestDNSProvider_addTxtRecord(t *testing.T) {
+	testCases := []struct {
+		desc        string
+		sandbox     bool
+		ttl         int
+		expectedTTL int
+	}{
+		{
+			desc:        "TTL",
+			ttl:         600,
+			expectedTTL: 600,
+		},
+		{
+			desc:        "TTL below the minimum",
+			ttl:         60,
+			expectedTTL: 300,
+		},
+		{
+			desc:        "TTL below the minimum of the sandbox",
+			sandbox:     true,
+			ttl:         600,
+			expectedTTL: 3600,
+		},
+	}
+
+	for _, test := range testCases {
+		t.Run(test.desc, func(t *testing.T) {
+			config := NewDefaultConfig()
+			config.Sandbox = test.sandbox
+			config.TTL = test.ttl
+
+			provider, requests := setupTest(t, config, map[string]func() (int, string, string){
+				"nameserver.createRecord": func() (int, string, string) {
+					return 1000, "Command completed successfully", `<member><name>id</name><value><int>42</int></value></member>`
+				},
+			})
+
+			recordID, err := provider.addTxtRecord("example.com", "_acme-challenge.example.com", "txtTXTtxt")
+			require.NoError(t, err)
+
+			assert.Equal(t, 42, recordID)
+
+			require.Len(t, requests["nameserver.createRecord"], 1)
+			assert.Contains(t, requests["nameserver.createRecord"][0], fmt.Sprintf(`<name>ttl</name><value><int>%d</int></value>`, test.expectedTTL))
+		})
+	}
+}
+
+func TestDNSProvider_addTxtRecord_exists(t *testing.T) {
+	provider, requests := setupTest(t, NewDefaultConfig(), map[string]func() (int, string, string){
+		"nameserver.createRecord": func() (int, string, string) {
+			return 2302, "Object exists", ""
+		},
+		"nameserver.info": func() (int, string, string) {
+			return 1000, "Command completed successfully", `<member><name>record</name><value><array><data>
+<value><struct>
+<member><name>id</name><value><int>41</int></value></member>
+<member><name>content</name><value><string>other</string></value></member>
+</struct></value>
+<value><struct>
+<member><name>id</name><value><int>42</int></value></member>
+<member><name>content</name><value><string>txtTXTtxt</string></value></member>
+</struct></value>
+</data></array></value></member>`
+		},
+	})
+
+	recordID, err := provider.addTxtRecord("example.com", "_acme-challenge.example.com", "txtTXTtxt")
+	require.NoError(t, err)
+
+	assert.Equal(t, 42, recordID)
+	assert.Len(t, requests["nameserver.info"], 1)
+}
+
+func TestDNSProvider_CleanUp(t *testing.T) {
+	provider, requests := setupTest(t, NewDefaultConfig(), nil)
+
+	provider.recordIDs["token"] = 42
+
+	err := provider.CleanUp("example.com", "token", "123d==")
+	require.NoError(t, err)
+
+	require.Len(t, requests["nameserver.deleteRecord"], 1)
+	assert.Contains(t, requests["nameserver.deleteRecord"][0], `<name>id</name><value><int>42</int></value>`)
+
+	// the other records of the name are not deleted.
+	assert.Empty(t, requests["nameserver.info"])
+
+	assert.Empty(t, provider.recordIDs)
+}
+
+func TestDNSProvider_CleanUp_unknownRecord(t *testing.T) {
+	provider, requests := setupTest(t, NewDefaultConfig(), nil)
+
+	err := provider.CleanUp("example.com", "token", "123d==")
+	require.EqualError(t, err, "inwx: unknown record ID for '_acme-challenge.example.com.'")
+
+	assert.Empty(t, requests)
+}
+
 func TestLivePresentAndCleanup(t *testing.T) {
 	if !envTest.IsLiveTest() {
 		t.Skip("skipping live test")
@@ -0,0 +1,130 @@
+package internal
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"path"
+)
+
+const defaultBaseURL = "https://api.hosting.ionos.com/dns"
+
+// Client the IONOS DNS API client.
+type Client struct {
+	HTTPClient *http.Client
+	BaseURL    string
+
+	apiKey string
+}
+
+// NewClient creates a new Client.
+// The API key is composed of the public prefix and of the secret: `prefix.secret`.
+func NewClient(apiKey string) *Client {
+	return &Client{
+		HTTPClient: http.DefaultClient,
+		BaseURL:    defaultBaseURL,
+		apiKey:     apiKey,
+	}
+}
+
+// ListZones gets the zones of the account.
+// https://developer.hosting.ionos.com/docs/dns
+func (c *Client) ListZones() ([]Zone, error) {
+	var zones []Zone
+	err := c.do(http.MethodGet, nil, &zones, "v1", "zones")
+	if err != nil {
+		return nil, err
+	}
+
+	return zones, nil
+}
+
+// CreateRecords creates DNS records in a zone.
+func (c *Client) CreateRecords(zoneID string, records []Record) ([]Record, error) {
+	body, err := json.Marshal(records)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal request body: %w", err)
+	}
+
+	var result []Record
+	err = c.do(http.MethodPost, bytes.NewReader(body), &result, "v1", "zones", zoneID, "records")
+	if err != nil {
+		return nil, err
+	}
+
+	return result, nil
+}
+
+// DeleteRecord deletes a DNS record.
+func (c *Client) DeleteRecord(zoneID, recordID string) error {
+	return c.do(http.MethodDelete, nil, nil, "v1", "zones", zoneID, "records", recordID)
+}
+
+func (c *Client) do(method string, body io.Reader, result interface{}, parts ...string) error {
+	endpoint, err := c.createEndpoint(parts...)
+	if err != nil {
+		return fmt.Errorf("failed to parse endpoint: %w", err)
+	}
+
+	req, err := http.NewRequest(method, endpoint, body)
+	if err != nil {
+		return fmt.Errorf("failed to create request: %w", err)
+	}
+
+	req.Header.Set("Accept", "application/json")
+	req.Header.Set("X-API-Key", c.apiKey)
+
+	if body != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+
+	resp, err := c.HTTPClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("API call failed: %w", err)
+	}
+
+	defer func() { _ = resp.Body.Close() }()
+
+	raw, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return fmt.Errorf("failed to read response body: %w", err)
+	}
+
+	if resp.StatusCode < http.StatusOK || resp.StatusCode >= http.StatusMultipleChoices {
+		var apiErrs APIErrors
+		if json.Unmarshal(raw, &apiErrs) == nil && len(apiErrs) > 0 {
+			return fmt.Errorf("invalid status code: %s: %w", resp.Status, apiErrs)
+		}
+
+		return fmt.Errorf("invalid status code: %s: %s", resp.Status, string(raw))
+	}
+
+	if result == nil {
+		return nil
+	}
+
+	err = json.Unmarshal(raw, result)
+	if err != nil {
+		return fmt.Errorf("failed to unmarshal response body: %w", err)
+	}
+
+	return nil
+}
+
+func (c *Client) createEndpoint(parts ...string) (string, error) {
+	base, err := url.Parse(c.BaseURL)
+	if err != nil {
+		return "", fmt.Errorf("failed to parse base URL: %w", err)
+	}
+
+	endpoint, err := base.Parse(path.Join(base.Path, path.Join(parts...)))
+	if err != nil {
+		return "", fmt.Errorf("failed to parse endpoint path: %w", err)
+	}
+
+	return endpoint.String(), nil
+}
@@ -0,0 +1,106 @@
+package internal
+
+import (
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func setupTest(t *testing.T, pattern, method, filename string, status int) *Client {
+	t.Helper()
+
+	mux := http.NewServeMux()
+	server := httptest.NewServer(mux)
+	t.Cleanup(server.Close)
+
+	mux.HandleFunc(pattern, func(rw http.ResponseWriter, req *http.Request) {
+		if req.Method != method {
+			http.Error(rw, fmt.Sprintf("unsupported method: %s", req.Method), http.StatusMethodNotAllowed)
+			return
+		}
+
+		apiKey := req.Header.Get("X-API-Key")
+		if apiKey != "prefix.secret" {
+			http.Error(rw, fmt.Sprintf("invalid API key: %s", apiKey), http.StatusUnauthorized)
+			return
+		}
+
+		if filename == "" {
+			rw.WriteHeader(status)
+			return
+		}
+
+		file, err := os.Open(filename)
+		if err != nil {
+			http.Error(rw, err.Error(), http.StatusInternalServerError)
+			return
+		}
+		defer func() { _ = file.Close() }()
+
+		rw.WriteHeader(status)
+		_, _ = io.Copy(rw, file)
+	})
+
+	client := NewClient("prefix.secret")
+	client.BaseURL = server.URL
+
+	return client
+}
+
+func TestClient_ListZones(t *testing.T) {
+	client := setupTest(t, "/v1/zones", http.MethodGet, "./fixtures/list_zones.json", http.StatusOK)
+
+	zones, err := client.ListZones()
+	require.NoError(t, err)
+
+	expected := []Zone{
+		{ID: "11af3414-ebba-11e9-8df5-66fbe8a334b4", Name: "example.com", Type: "NATIVE"},
+		{ID: "22bf3414-ebba-11e9-8df5-66fbe8a334b4", Name: "sub.example.com", Type: "NATIVE"},
+	}
+
+	assert.Equal(t, expected, zones)
+}
+
+func TestClient_ListZones_error(t *testing.T) {
+	client := setupTest(t, "/v1/zones", http.MethodGet, "./fixtures/error.json", http.StatusUnauthorized)
+
+	_, err := client.ListZones()
+	require.EqualError(t, err, "invalid status code: 401 Unauthorized: UNAUTHORIZED: The customer is not authorized to do this operation.")
+}
+
+func TestClient_CreateRecords(t *testing.T) {
+	client := setupTest(t, "/v1/zones/zoneA/records", http.MethodPost, "./fixtures/create_records.json", http.StatusCreated)
+
+	records := []Record{{
+		Name:    "_acme-challenge.example.com",
+		Content: "w6uP8Tcg6K2QR905Rms8iXTlksL6OD1KOWBxTK7wxPI",
+		TTL:     300,
+		Type:    "TXT",
+	}}
+
+	result, err := client.CreateRecords("zoneA", records)
+	require.NoError(t, err)
+
+	expected := []Record{{
+		ID:      "22af3414-abbe-9e11-5df5-66fbe8e334b4",
+		Name:    "_acme-challenge.example.com",
+		Content: "w6uP8Tcg6K2QR905Rms8iXTlksL6OD1KOWBxTK7wxPI",
+		TTL:     300,
+		Type:    "TXT",
+	}}
+
+	assert.Equal(t, expected, result)
+}
+
+func TestClient_DeleteRecord(t *testing.T) {
+	client := setupTest(t, "/v1/zones/zoneA/records/recordA", http.MethodDelete, "", http.StatusOK)
+
+	err := client.DeleteRecord("zoneA", "recordA")
+	require.NoError(t, err)
+}
@@ -0,0 +1,45 @@
+package internal
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Zone a DNS zone of the account.
+type Zone struct {
+	ID   string `json:"id,omitempty"`
+	Name string `json:"name,omitempty"`
+	Type string `json:"type,omitempty"`
+}
+
+// Record DNS record representation.
+type Record struct {
+	ID       string `json:"id,omitempty"`
+	Name     string `json:"name,omitempty"`
+	Content  string `json:"content,omitempty"`
+	TTL      int    `json:"ttl,omitempty"`
+	Type     string `json:"type,omitempty"`
+	Disabled bool   `json:"disabled,omitempty"`
+}
+
+// APIError an error returned by the API.
+type APIError struct {
+	Code    string `json:"code"`
+	Message string `json:"message"`
+}
+
+func (a APIError) Error() string {
+	return fmt.Sprintf("%s: %s", a.Code, a.Message)
+}
+
+// APIErrors the errors returned by the API.
+type APIErrors []APIError
+
+func (a APIErrors) Error() string {
+	var msg []string
+	for _, e := range a {
+		msg = append(msg, e.Error())
+	}
+
+	return strings.Join(msg, ", ")
+}
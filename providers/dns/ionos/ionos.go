@@ -0,0 +1,212 @@
+// Package ionos implements a DNS provider for solving the DNS-01 challenge using IONOS.
+package ionos
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/go-acme/lego/v4/challenge/dns01"
+	"github.com/go-acme/lego/v4/platform/config/env"
+	"github.com/go-acme/lego/v4/providers/dns/internal/useragent"
+	"github.com/go-acme/lego/v4/providers/dns/ionos/internal"
+)
+
+// The minimum TTL accepted by the API.
+const minTTL = 300
+
+// Environment variables names.
+const (
+	envNamespace = "IONOS_"
+
+	EnvAPIKey = envNamespace + "API_KEY"
+
+	EnvTTL                = envNamespace + "TTL"
+	EnvPropagationTimeout = envNamespace + "PROPAGATION_TIMEOUT"
+	EnvPollingInterval    = envNamespace + "POLLING_INTERVAL"
+	EnvHTTPTimeout        = envNamespace + "HTTP_TIMEOUT"
+)
+
+// Config is used to configure the creation of the DNSProvider.
+type Config struct {
+	APIKey             string
+	PropagationTimeout time.Duration
+	PollingInterval    time.Duration
+	TTL                int
+	HTTPClient         *http.Client
+}
+
+// NewDefaultConfig returns a default configuration for the DNSProvider.
+func NewDefaultConfig() *Config {
+	return &Config{
+		TTL:                env.GetOrDefaultInt(EnvTTL, minTTL),
+		PropagationTimeout: env.GetOrDefaultSecond(EnvPropagationTimeout, dns01.DefaultPropagationTimeout),
+		PollingInterval:    env.GetOrDefaultSecond(EnvPollingInterval, dns01.DefaultPollingInterval),
+		HTTPClient: useragent.Wrap(&http.Client{
+			Timeout: env.GetOrDefaultSecond(EnvHTTPTimeout, 30*time.Second),
+		}),
+	}
+}
+
+// DNSProvider implements the challenge.Provider interface.
+type DNSProvider struct {
+	config *Config
+	client *internal.Client
+
+	records   map[string]txtRecord
+	recordsMu sync.Mutex
+}
+
+type txtRecord struct {
+	zoneID string
+	id     string
+}
+
+// NewDNSProvider returns a DNSProvider instance configured for IONOS.
+// Credentials must be passed in the environment variable: IONOS_API_KEY.
+func NewDNSProvider() (*DNSProvider, error) {
+	values, err := env.Get(EnvAPIKey)
+	if err != nil {
+		return nil, fmt.Errorf("ionos: %w", err)
+	}
+
+	config := NewDefaultConfig()
+	config.APIKey = values[EnvAPIKey]
+
+	return NewDNSProviderConfig(config)
+}
+
+// NewDNSProviderConfig return a DNSProvider instance configured for IONOS.
+func NewDNSProviderConfig(config *Config) (*DNSProvider, error) {
+	if config == nil {
+		return nil, errors.New("ionos: the configuration of the DNS provider is nil")
+	}
+
+	if config.APIKey == "" {
+		return nil, errors.New("ionos: credentials missing")
+	}
+
+	if !strings.Contains(config.APIKey, ".") {
+		return nil, errors.New("ionos: invalid API key, the format must be prefix.secret")
+	}
+
+	if config.TTL < minTTL {
+		return nil, fmt.Errorf("ionos: invalid TTL, TTL (%d) must be greater than %d", config.TTL, minTTL)
+	}
+
+	client := internal.NewClient(config.APIKey)
+
+	if config.HTTPClient != nil {
+		client.HTTPClient = config.HTTPClient
+	}
+
+	return &DNSProvider{
+		config:  config,
+		client:  client,
+		records: make(map[string]txtRecord),
+	}, nil
+}
+
+// Timeout returns the timeout and interval to use when checking for DNS propagation.
+// Adjusting here to cope with spikes in propagation times.
+func (d *DNSProvider) Timeout() (timeout, interval time.Duration) {
+	return d.config.PropagationTimeout, d.config.PollingInterval
+}
+
+// Present creates a TXT record to fulfill the dns-01 challenge.
+func (d *DNSProvider) Present(domain, token, keyAuth string) error {
+	fqdn, value := dns01.GetRecord(domain, keyAuth)
+
+	zone, err := d.findZone(fqdn)
+	if err != nil {
+		return fmt.Errorf("ionos: %w", err)
+	}
+
+	record := internal.Record{
+		Name:    dns01.UnFqdn(fqdn),
+		Content: value,
+		TTL:     d.config.TTL,
+		Type:    "TXT",
+	}
+
+	records, err := d.client.CreateRecords(zone.ID, []internal.Record{record})
+	if err != nil {
+		return fmt.Errorf("ionos: failed to create TXT record: fqdn=%s, zone=%s: %w", fqdn, zone.Name, err)
+	}
+
+	if len(records) == 0 {
+		return fmt.Errorf("ionos: no record created: fqdn=%s, zone=%s", fqdn, zone.Name)
+	}
+
+	d.recordsMu.Lock()
+	d.records[token] = txtRecord{zoneID: zone.ID, id: records[0].ID}
+	d.recordsMu.Unlock()
+
+	return nil
+}
+
+// CleanUp removes the TXT record matching the specified parameters.
+func (d *DNSProvider) CleanUp(domain, token, keyAuth string) error {
+	fqdn, _ := dns01.GetRecord(domain, keyAuth)
+
+	// gets the record's unique ID from when we created it
+	d.recordsMu.Lock()
+	record, ok := d.records[token]
+	d.recordsMu.Unlock()
+	if !ok {
+		return fmt.Errorf("ionos: unknown record ID for '%s'", fqdn)
+	}
+
+	err := d.client.DeleteRecord(record.zoneID, record.id)
+	if err != nil {
+		return fmt.Errorf("ionos: failed to delete TXT record: fqdn=%s, recordID=%s: %w", fqdn, record.id, err)
+	}
+
+	// deletes record ID from map
+	d.recordsMu.Lock()
+	delete(d.records, token)
+	d.recordsMu.Unlock()
+
+	return nil
+}
+
+// Zones returns the zones of the account.
+func (d *DNSProvider) Zones() ([]string, error) {
+	zones, err := d.client.ListZones()
+	if err != nil {
+		return nil, fmt.Errorf("ionos: failed to get zones: %w", err)
+	}
+
+	var names []string
+	for _, zone := range zones {
+		names = append(names, zone.Name)
+	}
+
+	return names, nil
+}
+
+// findZone finds the most specific zone of the account containing the fqdn.
+func (d *DNSProvider) findZone(fqdn string) (*internal.Zone, error) {
+	zones, err := d.client.ListZones()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get zones: %w", err)
+	}
+
+	var names []string
+	for _, zone := range zones {
+		names = append(names, zone.Name)
+	}
+
+	name := dns01.FindMostSpecificZone(fqdn, names)
+
+	for _, zone := range zones {
+		if name != "" && zone.Name == name {
+			return &zone, nil
+		}
+	}
+
+	return nil, fmt.Errorf("no zone found for %s", dns01.UnFqdn(fqdn))
+}
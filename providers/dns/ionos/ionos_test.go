@@ -0,0 +1,232 @@
+package ionos
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/go-acme/lego/v4/platform/tester"
+	"github.com/go-acme/lego/v4/providers/dns/ionos/internal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const envDomain = envNamespace + "DOMAIN"
+
+var envTest = tester.NewEnvTest(EnvAPIKey).WithDomain(envDomain)
+
+func TestNewDNSProvider(t *testing.T) {
+	testCases := []struct {
+		desc     string
+		envVars  map[string]string
+		expected string
+	}{
+		{
+			desc: "success",
+			envVars: map[string]string{
+				EnvAPIKey: "prefix.secret",
+			},
+		},
+		{
+			desc: "missing credentials",
+			envVars: map[string]string{
+				EnvAPIKey: "",
+			},
+			expected: "ionos: some credentials information are missing: IONOS_API_KEY",
+		},
+	}
+
+	for _, test := range testCases {
+		t.Run(test.desc, func(t *testing.T) {
+			defer envTest.RestoreEnv()
+			envTest.ClearEnv()
+
+			envTest.Apply(test.envVars)
+
+			p, err := NewDNSProvider()
+
+			if len(test.expected) == 0 {
+				require.NoError(t, err)
+				require.NotNil(t, p)
+				require.NotNil(t, p.config)
+				require.NotNil(t, p.client)
+			} else {
+				require.EqualError(t, err, test.expected)
+			}
+		})
+	}
+}
+
+func TestNewDNSProviderConfig(t *testing.T) {
+	testCases := []struct {
+		desc     string
+		apiKey   string
+		ttl      int
+		expected string
+	}{
+		{
+			desc:   "success",
+			apiKey: "prefix.secret",
+			ttl:    minTTL,
+		},
+		{
+			desc:     "missing credentials",
+			ttl:      minTTL,
+			expected: "ionos: credentials missing",
+		},
+		{
+			desc:     "invalid API key",
+			apiKey:   "secret",
+			ttl:      minTTL,
+			expected: "ionos: invalid API key, the format must be prefix.secret",
+		},
+		{
+			desc:     "invalid TTL",
+			apiKey:   "prefix.secret",
+			ttl:      120,
+			expected: "ionos: invalid TTL, TTL (120) must be greater than 300",
+		},
+	}
+
+	for _, test := range testCases {
+		t.Run(test.desc, func(t *testing.T) {
+			config := NewDefaultConfig()
+			config.APIKey = test.apiKey
+			config.TTL = test.ttl
+
+			p, err := NewDNSProviderConfig(config)
+
+			if len(test.expected) == 0 {
+				require.NoError(t, err)
+				require.NotNil(t, p)
+				require.NotNil(t, p.config)
+				require.NotNil(t, p.client)
+			} else {
+				require.EqualError(t, err, test.expected)
+			}
+		})
+	}
+}
+
+func setupTest(t *testing.T) (*DNSProvider, *http.ServeMux) {
+	t.Helper()
+
+	mux := http.NewServeMux()
+	server := httptest.NewServer(mux)
+	t.Cleanup(server.Close)
+
+	mux.HandleFunc("/v1/zones", func(rw http.ResponseWriter, req *http.Request) {
+		_, _ = fmt.Fprint(rw, `[{"id":"zoneA","name":"example.com","type":"NATIVE"},{"id":"zoneB","name":"sub.example.com","type":"NATIVE"}]`)
+	})
+
+	config := NewDefaultConfig()
+	config.APIKey = "prefix.secret"
+	config.TTL = 3600
+
+	provider, err := NewDNSProviderConfig(config)
+	require.NoError(t, err)
+
+	provider.client.BaseURL = server.URL
+
+	return provider, mux
+}
+
+func TestDNSProvider_Present(t *testing.T) {
+	provider, mux := setupTest(t)
+
+	mux.HandleFunc("/v1/zones/zoneB/records", func(rw http.ResponseWriter, req *http.Request) {
+		if req.Method != http.MethodPost {
+			http.Error(rw, fmt.Sprintf("unsupported method: %s", req.Method), http.StatusMethodNotAllowed)
+			return
+		}
+
+		var records []internal.Record
+		err := json.NewDecoder(req.Body).Decode(&records)
+		if err != nil {
+			http.Error(rw, err.Error(), http.StatusBadRequest)
+			return
+		}
+
+		expected := []internal.Record{{
+			Name:    "_acme-challenge.www.sub.example.com",
+			Content: "ADw2sEd82DUgXcQ9hNBZThJs7zVJkR5v9JeSbAb9mZY",
+			TTL:     3600,
+			Type:    "TXT",
+		}}
+		assert.Equal(t, expected, records)
+
+		rw.WriteHeader(http.StatusCreated)
+		_, _ = fmt.Fprint(rw, `[{"id":"recordA","name":"_acme-challenge.www.sub.example.com","type":"TXT","ttl":3600}]`)
+	})
+
+	err := provider.Present("www.sub.example.com", "token", "123d==")
+	require.NoError(t, err)
+
+	assert.Equal(t, map[string]txtRecord{"token": {zoneID: "zoneB", id: "recordA"}}, provider.records)
+}
+
+func TestDNSProvider_Present_unknownZone(t *testing.T) {
+	provider, _ := setupTest(t)
+
+	err := provider.Present("www.example.org", "token", "123d==")
+	require.EqualError(t, err, "ionos: no zone found for _acme-challenge.www.example.org")
+}
+
+func TestDNSProvider_CleanUp(t *testing.T) {
+	provider, mux := setupTest(t)
+
+	var deleted bool
+	mux.HandleFunc("/v1/zones/zoneA/records/recordA", func(rw http.ResponseWriter, req *http.Request) {
+		if req.Method != http.MethodDelete {
+			http.Error(rw, fmt.Sprintf("unsupported method: %s", req.Method), http.StatusMethodNotAllowed)
+			return
+		}
+
+		deleted = true
+	})
+
+	provider.records["token"] = txtRecord{zoneID: "zoneA", id: "recordA"}
+
+	err := provider.CleanUp("www.example.com", "token", "123d==")
+	require.NoError(t, err)
+
+	assert.True(t, deleted)
+	assert.Empty(t, provider.records)
+}
+
+func TestDNSProvider_CleanUp_unknownRecord(t *testing.T) {
+	provider, _ := setupTest(t)
+
+	err := provider.CleanUp("www.example.com", "token", "123d==")
+	require.EqualError(t, err, "ionos: unknown record ID for '_acme-challenge.www.example.com.'")
+}
+
+func TestDNSProvider_Zones(t *testing.T) {
+	provider, _ := setupTest(t)
+
+	zones, err := provider.Zones()
+	require.NoError(t, err)
+
+	assert.Equal(t, []string{"example.com", "sub.example.com"}, zones)
+}
+
+func TestLivePresentAndCleanUp(t *testing.T) {
+	if !envTest.IsLiveTest() {
+		t.Skip("skipping live test")
+	}
+
+	envTest.RestoreEnv()
+	provider, err := NewDNSProvider()
+	require.NoError(t, err)
+
+	err = provider.Present(envTest.GetDomain(), "", "123d==")
+	require.NoError(t, err)
+
+	time.Sleep(1 * time.Second)
+
+	err = provider.CleanUp(envTest.GetDomain(), "", "123d==")
+	require.NoError(t, err)
+}
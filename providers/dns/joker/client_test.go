@@ -531,3 +531,55 @@ func Test_fixTxtLines(t *testing.T) {
 		})
 	}
 }
+
+func TestDNSProvider_addTxtRecord(t *testing.T) {
+	testCases := []struct {
+		desc     string
+		ttl      int
+		expected string
+	}{
+		{
+			desc:     "configured TTL",
+			ttl:      300,
+			expected: "@ A 0 192.0.2.2 3600\n_acme-challenge TXT 0 \"test\" 300",
+		},
+		{
+			desc:     "TTL below the minimum",
+			ttl:      10,
+			expected: "@ A 0 192.0.2.2 3600\n_acme-challenge TXT 0 \"test\" 60",
+		},
+	}
+
+	for _, test := range testCases {
+		t.Run(test.desc, func(t *testing.T) {
+			mux, server := setup()
+			defer server.Close()
+
+			mux.HandleFunc("/login", func(w http.ResponseWriter, r *http.Request) {
+				_, _ = io.WriteString(w, "Status-Code: 0\nStatus-Text: OK\nAuth-Sid: 123\n\ncom\nnet")
+			})
+			mux.HandleFunc("/dns-zone-get", func(w http.ResponseWriter, r *http.Request) {
+				_, _ = io.WriteString(w, "Status-Code: 0\nStatus-Text: OK\n\n@ A 0 192.0.2.2 3600")
+			})
+
+			var zone string
+			mux.HandleFunc("/dns-zone-put", func(w http.ResponseWriter, r *http.Request) {
+				zone = r.FormValue("zone")
+				_, _ = io.WriteString(w, "Status-Code: 0\nStatus-Text: OK\n\n")
+			})
+
+			config := NewDefaultConfig()
+			config.BaseURL = server.URL
+			config.APIKey = correctAPIKey
+			config.TTL = test.ttl
+
+			p, err := NewDNSProviderConfig(config)
+			require.NoError(t, err)
+
+			err = p.addTxtRecord("example.com", "_acme-challenge", "test")
+			require.NoError(t, err)
+
+			assert.Equal(t, test.expected, zone)
+		})
+	}
+}
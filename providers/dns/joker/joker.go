@@ -11,8 +11,12 @@ import (
 	"github.com/go-acme/lego/v4/challenge/dns01"
 	"github.com/go-acme/lego/v4/log"
 	"github.com/go-acme/lego/v4/platform/config/env"
+	"github.com/go-acme/lego/v4/providers/dns/internal/useragent"
 )
 
+// minTTL is the minimum TTL accepted by the DMAPI.
+const minTTL = 60
+
 // Environment variables names.
 const (
 	envNamespace = "JOKER_"
@@ -50,9 +54,9 @@ func NewDefaultConfig() *Config {
 		TTL:                env.GetOrDefaultInt(EnvTTL, dns01.DefaultTTL),
 		PropagationTimeout: env.GetOrDefaultSecond(EnvPropagationTimeout, dns01.DefaultPropagationTimeout),
 		PollingInterval:    env.GetOrDefaultSecond(EnvPollingInterval, dns01.DefaultPollingInterval),
-		HTTPClient: &http.Client{
+		HTTPClient: useragent.Wrap(&http.Client{
 			Timeout: env.GetOrDefaultSecond(EnvHTTPTimeout, 60*time.Second),
-		},
+		}),
 	}
 }
 
@@ -120,24 +124,7 @@ func (d *DNSProvider) Present(domain, token, keyAuth string) error {
 		log.Infof("[%s] joker: adding TXT record %q to zone %q with value %q", domain, relative, zone, value)
 	}
 
-	response, err := d.login()
-	if err != nil {
-		return formatResponseError(response, err)
-	}
-
-	response, err = d.getZone(zone)
-	if err != nil || response.StatusCode != 0 {
-		return formatResponseError(response, err)
-	}
-
-	dnsZone := addTxtEntryToZone(response.Body, relative, value, d.config.TTL)
-
-	response, err = d.putZone(zone, dnsZone)
-	if err != nil || response.StatusCode != 0 {
-		return formatResponseError(response, err)
-	}
-
-	return nil
+	return d.addTxtRecord(zone, relative, value)
 }
 
 // CleanUp removes a TXT record used for a previous DNS challenge.
@@ -185,6 +172,33 @@ func (d *DNSProvider) CleanUp(domain, token, keyAuth string) error {
 	return nil
 }
 
+// addTxtRecord adds the TXT record to the zone, the TTL is clamped to the minimum accepted by the DMAPI.
+func (d *DNSProvider) addTxtRecord(zone, relative, value string) error {
+	ttl := d.config.TTL
+	if ttl < minTTL {
+		ttl = minTTL
+	}
+
+	response, err := d.login()
+	if err != nil {
+		return formatResponseError(response, err)
+	}
+
+	response, err = d.getZone(zone)
+	if err != nil || response.StatusCode != 0 {
+		return formatResponseError(response, err)
+	}
+
+	dnsZone := addTxtEntryToZone(response.Body, relative, value, ttl)
+
+	response, err = d.putZone(zone, dnsZone)
+	if err != nil || response.StatusCode != 0 {
+		return formatResponseError(response, err)
+	}
+
+	return nil
+}
+
 func getRelative(fqdn, zone string) string {
 	return dns01.UnFqdn(strings.TrimSuffix(fqdn, dns01.ToFqdn(zone)))
 }
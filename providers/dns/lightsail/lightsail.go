@@ -14,6 +14,7 @@ import (
 	"github.com/aws/aws-sdk-go/service/lightsail"
 	"github.com/go-acme/lego/v4/challenge/dns01"
 	"github.com/go-acme/lego/v4/platform/config/env"
+	"github.com/go-acme/lego/v4/providers/dns/internal/useragent"
 )
 
 const (
@@ -107,6 +108,13 @@ func NewDNSProviderConfig(config *Config) (*DNSProvider, error) {
 		return nil, err
 	}
 
+	if ua := useragent.Get(); ua != "" {
+		// the handlers of the session are not shared with the other sessions.
+		sess.Handlers.Build.PushBack(func(r *request.Request) {
+			r.HTTPRequest.Header.Set("User-Agent", ua)
+		})
+	}
+
 	return &DNSProvider{
 		config: config,
 		client: lightsail.New(sess),
@@ -12,6 +12,7 @@ import (
 
 	"github.com/go-acme/lego/v4/challenge/dns01"
 	"github.com/go-acme/lego/v4/platform/config/env"
+	"github.com/go-acme/lego/v4/providers/dns/internal/useragent"
 	"github.com/linode/linodego"
 	"golang.org/x/oauth2"
 )
@@ -103,6 +104,10 @@ func NewDNSProviderConfig(config *Config) (*DNSProvider, error) {
 	client := linodego.NewClient(oauth2Client)
 	client.SetUserAgent("lego-dns https://github.com/linode/linodego")
 
+	if ua := useragent.Get(); ua != "" {
+		client.SetUserAgent(ua)
+	}
+
 	return &DNSProvider{
 		config: config,
 		client: &client,
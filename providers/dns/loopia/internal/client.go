@@ -0,0 +1,206 @@
+package internal
+
+import (
+	"bytes"
+	"encoding/xml"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"strings"
+)
+
+// DefaultBaseURL the URL of the XML-RPC API.
+const DefaultBaseURL = "https://api.loopia.se/RPCSERV"
+
+const statusOK = "OK"
+
+// Client the Loopia XML-RPC API client.
+type Client struct {
+	HTTPClient *http.Client
+	BaseURL    string
+
+	apiUser     string
+	apiPassword string
+}
+
+// NewClient creates a new Client.
+func NewClient(apiUser, apiPassword string) *Client {
+	return &Client{
+		HTTPClient:  http.DefaultClient,
+		BaseURL:     DefaultBaseURL,
+		apiUser:     apiUser,
+		apiPassword: apiPassword,
+	}
+}
+
+// GetDomains gets the domains of the account.
+// https://www.loopia.com/api/getdomains/
+func (c *Client) GetDomains() ([]Domain, error) {
+	resp := &responseStructs{}
+	err := c.rpcCall(c.methodCall("getDomains"), resp)
+	if err != nil {
+		return nil, err
+	}
+
+	var domains []Domain
+	for _, s := range resp.Structs {
+		var domain Domain
+		for _, m := range s.Members {
+			if m.Name == "domain" {
+				domain.Domain = m.String
+			}
+		}
+
+		domains = append(domains, domain)
+	}
+
+	return domains, nil
+}
+
+// AddTXTRecord adds a TXT record to a subdomain, the subdomain is created if needed.
+// https://www.loopia.com/api/addzonerecord/
+func (c *Client) AddTXTRecord(domain, subdomain string, ttl int, value string) error {
+	call := c.methodCall("addZoneRecord",
+		paramString{Value: domain},
+		paramString{Value: subdomain},
+		paramStruct{
+			StructMembers: []structMember{
+				structMemberString{Name: "type", Value: "TXT"},
+				structMemberInt{Name: "ttl", Value: ttl},
+				structMemberInt{Name: "priority", Value: 0},
+				structMemberString{Name: "rdata", Value: value},
+				structMemberInt{Name: "record_id", Value: 0},
+			},
+		},
+	)
+
+	return c.statusCall(call)
+}
+
+// GetTXTRecords gets the TXT records of a subdomain.
+// https://www.loopia.com/api/getzonerecords/
+func (c *Client) GetTXTRecords(domain, subdomain string) ([]RecordObj, error) {
+	call := c.methodCall("getZoneRecords",
+		paramString{Value: domain},
+		paramString{Value: subdomain},
+	)
+
+	resp := &responseStructs{}
+	err := c.rpcCall(call, resp)
+	if err != nil {
+		return nil, err
+	}
+
+	var records []RecordObj
+	for _, s := range resp.Structs {
+		var record RecordObj
+		for _, m := range s.Members {
+			switch m.Name {
+			case "type":
+				record.Type = m.String
+			case "ttl":
+				record.TTL = m.Int
+			case "priority":
+				record.Priority = m.Int
+			case "rdata":
+				record.Rdata = m.String
+			case "record_id":
+				record.RecordID = m.Int
+			}
+		}
+
+		if record.Type == "TXT" {
+			records = append(records, record)
+		}
+	}
+
+	return records, nil
+}
+
+// RemoveTXTRecord removes a record of a subdomain.
+// https://www.loopia.com/api/removezonerecord/
+func (c *Client) RemoveTXTRecord(domain, subdomain string, recordID int) error {
+	call := c.methodCall("removeZoneRecord",
+		paramString{Value: domain},
+		paramString{Value: subdomain},
+		paramInt{Value: recordID},
+	)
+
+	return c.statusCall(call)
+}
+
+// RemoveSubdomain removes a subdomain.
+// https://www.loopia.com/api/removesubdomain/
+func (c *Client) RemoveSubdomain(domain, subdomain string) error {
+	call := c.methodCall("removeSubdomain",
+		paramString{Value: domain},
+		paramString{Value: subdomain},
+	)
+
+	return c.statusCall(call)
+}
+
+// methodCall creates a method call with the credentials parameters (the customer number is empty for the own account).
+func (c *Client) methodCall(name string, params ...param) *methodCall {
+	return &methodCall{
+		MethodName: name,
+		Params: append([]param{
+			paramString{Value: c.apiUser},
+			paramString{Value: c.apiPassword},
+			paramString{Value: ""},
+		}, params...),
+	}
+}
+
+// statusCall makes a call returning a status (OK or an error code).
+func (c *Client) statusCall(call *methodCall) error {
+	resp := &responseString{}
+	err := c.rpcCall(call, resp)
+	if err != nil {
+		return err
+	}
+
+	if resp.Value != statusOK {
+		return fmt.Errorf("unexpected status: %s: %s", call.MethodName, resp.Value)
+	}
+
+	return nil
+}
+
+// rpcCall makes an XML-RPC call to the API by marshaling the call to XML,
+// and unmarshals the response into resp.
+func (c *Client) rpcCall(call *methodCall, resp response) error {
+	body, err := xml.MarshalIndent(call, "", "  ")
+	if err != nil {
+		return fmt.Errorf("marshal error: %w", err)
+	}
+
+	body = append([]byte(`<?xml version="1.0"?>`+"\n"), body...)
+
+	httpResp, err := c.HTTPClient.Post(c.BaseURL, "text/xml", bytes.NewReader(body))
+	if err != nil {
+		return fmt.Errorf("HTTP Post Error: %w", err)
+	}
+
+	defer func() { _ = httpResp.Body.Close() }()
+
+	raw, err := ioutil.ReadAll(httpResp.Body)
+	if err != nil {
+		return fmt.Errorf("failed to read response body: %w", err)
+	}
+
+	if httpResp.StatusCode != http.StatusOK {
+		return fmt.Errorf("HTTP Post Error: %d: %s", httpResp.StatusCode, strings.TrimSpace(string(raw)))
+	}
+
+	err = xml.Unmarshal(raw, resp)
+	if err != nil {
+		return fmt.Errorf("unmarshal error: %w", err)
+	}
+
+	if resp.faultCode() != 0 {
+		return RPCError{FaultCode: resp.faultCode(), FaultString: resp.faultString()}
+	}
+
+	return nil
+}
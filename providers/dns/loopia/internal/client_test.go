@@ -0,0 +1,170 @@
+package internal
+
+import (
+	"encoding/xml"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type testCall struct {
+	MethodName string      `xml:"methodName"`
+	Params     []testParam `xml:"params>param"`
+}
+
+type testParam struct {
+	String  string       `xml:"value>string"`
+	Int     int          `xml:"value>int"`
+	Members []testMember `xml:"value>struct>member"`
+}
+
+type testMember struct {
+	Name   string `xml:"name"`
+	String string `xml:"value>string"`
+	Int    int    `xml:"value>int"`
+}
+
+func setupTest(t *testing.T, method, filename string, check func(t *testing.T, params []testParam)) *Client {
+	t.Helper()
+
+	mux := http.NewServeMux()
+	server := httptest.NewServer(mux)
+	t.Cleanup(server.Close)
+
+	mux.HandleFunc("/", func(rw http.ResponseWriter, req *http.Request) {
+		if req.Method != http.MethodPost {
+			http.Error(rw, fmt.Sprintf("unsupported method: %s", req.Method), http.StatusMethodNotAllowed)
+			return
+		}
+
+		call := testCall{}
+		err := xml.NewDecoder(req.Body).Decode(&call)
+		if err != nil {
+			http.Error(rw, err.Error(), http.StatusBadRequest)
+			return
+		}
+
+		if call.MethodName != method {
+			http.Error(rw, fmt.Sprintf("unexpected method call: %s", call.MethodName), http.StatusBadRequest)
+			return
+		}
+
+		if len(call.Params) < 3 || call.Params[0].String != "user" || call.Params[1].String != "secret" || call.Params[2].String != "" {
+			http.Error(rw, "invalid credentials", http.StatusUnauthorized)
+			return
+		}
+
+		if check != nil {
+			check(t, call.Params[3:])
+		}
+
+		file, err := os.Open(filename)
+		if err != nil {
+			http.Error(rw, err.Error(), http.StatusInternalServerError)
+			return
+		}
+		defer func() { _ = file.Close() }()
+
+		_, _ = io.Copy(rw, file)
+	})
+
+	client := NewClient("user", "secret")
+	client.BaseURL = server.URL
+
+	return client
+}
+
+func TestClient_GetDomains(t *testing.T) {
+	client := setupTest(t, "getDomains", "./fixtures/getDomains.xml", nil)
+
+	domains, err := client.GetDomains()
+	require.NoError(t, err)
+
+	expected := []Domain{{Domain: "example.com"}, {Domain: "sub.example.com"}}
+	assert.Equal(t, expected, domains)
+}
+
+func TestClient_GetDomains_fault(t *testing.T) {
+	client := setupTest(t, "getDomains", "./fixtures/fault.xml", nil)
+
+	_, err := client.GetDomains()
+	require.EqualError(t, err, "RPC Error: (623) Method not found")
+}
+
+func TestClient_AddTXTRecord(t *testing.T) {
+	client := setupTest(t, "addZoneRecord", "./fixtures/ok.xml", func(t *testing.T, params []testParam) {
+		t.Helper()
+
+		require.Len(t, params, 3)
+		assert.Equal(t, "example.com", params[0].String)
+		assert.Equal(t, "_acme-challenge", params[1].String)
+
+		expected := []testMember{
+			{Name: "type", String: "TXT"},
+			{Name: "ttl", Int: 300},
+			{Name: "priority"},
+			{Name: "rdata", String: "txtTXTtxtTXTtxtTXT"},
+			{Name: "record_id"},
+		}
+		assert.Equal(t, expected, params[2].Members)
+	})
+
+	err := client.AddTXTRecord("example.com", "_acme-challenge", 300, "txtTXTtxtTXTtxtTXT")
+	require.NoError(t, err)
+}
+
+func TestClient_AddTXTRecord_error(t *testing.T) {
+	client := setupTest(t, "addZoneRecord", "./fixtures/auth_error.xml", nil)
+
+	err := client.AddTXTRecord("example.com", "_acme-challenge", 300, "txtTXTtxtTXTtxtTXT")
+	require.EqualError(t, err, "unexpected status: addZoneRecord: AUTH_ERROR")
+}
+
+func TestClient_GetTXTRecords(t *testing.T) {
+	client := setupTest(t, "getZoneRecords", "./fixtures/getZoneRecords.xml", func(t *testing.T, params []testParam) {
+		t.Helper()
+
+		require.Len(t, params, 2)
+		assert.Equal(t, "example.com", params[0].String)
+		assert.Equal(t, "_acme-challenge", params[1].String)
+	})
+
+	records, err := client.GetTXTRecords("example.com", "_acme-challenge")
+	require.NoError(t, err)
+
+	expected := []RecordObj{{Type: "TXT", TTL: 300, Rdata: "txtTXTtxtTXTtxtTXT", RecordID: 12345678}}
+	assert.Equal(t, expected, records)
+}
+
+func TestClient_RemoveTXTRecord(t *testing.T) {
+	client := setupTest(t, "removeZoneRecord", "./fixtures/ok.xml", func(t *testing.T, params []testParam) {
+		t.Helper()
+
+		require.Len(t, params, 3)
+		assert.Equal(t, "example.com", params[0].String)
+		assert.Equal(t, "_acme-challenge", params[1].String)
+		assert.Equal(t, 12345678, params[2].Int)
+	})
+
+	err := client.RemoveTXTRecord("example.com", "_acme-challenge", 12345678)
+	require.NoError(t, err)
+}
+
+func TestClient_RemoveSubdomain(t *testing.T) {
+	client := setupTest(t, "removeSubdomain", "./fixtures/ok.xml", func(t *testing.T, params []testParam) {
+		t.Helper()
+
+		require.Len(t, params, 2)
+		assert.Equal(t, "example.com", params[0].String)
+		assert.Equal(t, "_acme-challenge", params[1].String)
+	})
+
+	err := client.RemoveSubdomain("example.com", "_acme-challenge")
+	require.NoError(t, err)
+}
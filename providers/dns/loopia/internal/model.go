@@ -0,0 +1,110 @@
+package internal
+
+import (
+	"encoding/xml"
+	"fmt"
+)
+
+// types for XML-RPC method calls and parameters
+
+type param interface {
+	param()
+}
+
+type paramString struct {
+	XMLName xml.Name `xml:"param"`
+	Value   string   `xml:"value>string"`
+}
+
+type paramInt struct {
+	XMLName xml.Name `xml:"param"`
+	Value   int      `xml:"value>int"`
+}
+
+type paramStruct struct {
+	XMLName       xml.Name       `xml:"param"`
+	StructMembers []structMember `xml:"value>struct>member"`
+}
+
+type structMember interface {
+	structMember()
+}
+
+type structMemberString struct {
+	Name  string `xml:"name"`
+	Value string `xml:"value>string"`
+}
+
+type structMemberInt struct {
+	Name  string `xml:"name"`
+	Value int    `xml:"value>int"`
+}
+
+func (p paramString) param()               {}
+func (p paramInt) param()                  {}
+func (p paramStruct) param()               {}
+func (m structMemberString) structMember() {}
+func (m structMemberInt) structMember()    {}
+
+type methodCall struct {
+	XMLName    xml.Name `xml:"methodCall"`
+	MethodName string   `xml:"methodName"`
+	Params     []param  `xml:"params>param"`
+}
+
+// types for XML-RPC responses
+
+type response interface {
+	faultCode() int
+	faultString() string
+}
+
+type responseFault struct {
+	FaultCode   int    `xml:"fault>value>struct>member>value>int"`
+	FaultString string `xml:"fault>value>struct>member>value>string"`
+}
+
+func (r responseFault) faultCode() int      { return r.FaultCode }
+func (r responseFault) faultString() string { return r.FaultString }
+
+type responseString struct {
+	responseFault
+	Value string `xml:"params>param>value>string"`
+}
+
+type member struct {
+	Name   string `xml:"name"`
+	String string `xml:"value>string"`
+	Int    int    `xml:"value>int"`
+}
+
+type responseStructs struct {
+	responseFault
+	Structs []struct {
+		Members []member `xml:"struct>member"`
+	} `xml:"params>param>value>array>data>value"`
+}
+
+// RPCError an XML-RPC fault.
+type RPCError struct {
+	FaultCode   int
+	FaultString string
+}
+
+func (e RPCError) Error() string {
+	return fmt.Sprintf("RPC Error: (%d) %s", e.FaultCode, e.FaultString)
+}
+
+// Domain a domain of the account.
+type Domain struct {
+	Domain string
+}
+
+// RecordObj a DNS record of a subdomain.
+type RecordObj struct {
+	Type     string
+	TTL      int
+	Priority int
+	Rdata    string
+	RecordID int
+}
@@ -0,0 +1,245 @@
+// Package loopia implements a DNS provider for solving the DNS-01 challenge using Loopia.
+package loopia
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/go-acme/lego/v4/challenge/dns01"
+	"github.com/go-acme/lego/v4/platform/config/env"
+	"github.com/go-acme/lego/v4/providers/dns/internal/useragent"
+	"github.com/go-acme/lego/v4/providers/dns/loopia/internal"
+)
+
+// The minimum TTL accepted by the API.
+const minTTL = 300
+
+// Environment variables names.
+const (
+	envNamespace = "LOOPIA_"
+
+	EnvAPIUser     = envNamespace + "API_USER"
+	EnvAPIPassword = envNamespace + "API_PASSWORD"
+	EnvAPIURL      = envNamespace + "API_URL"
+
+	EnvTTL                = envNamespace + "TTL"
+	EnvPropagationTimeout = envNamespace + "PROPAGATION_TIMEOUT"
+	EnvPollingInterval    = envNamespace + "POLLING_INTERVAL"
+	EnvHTTPTimeout        = envNamespace + "HTTP_TIMEOUT"
+)
+
+// Config is used to configure the creation of the DNSProvider.
+type Config struct {
+	BaseURL            string
+	APIUser            string
+	APIPassword        string
+	PropagationTimeout time.Duration
+	PollingInterval    time.Duration
+	TTL                int
+	HTTPClient         *http.Client
+}
+
+// NewDefaultConfig returns a default configuration for the DNSProvider.
+func NewDefaultConfig() *Config {
+	return &Config{
+		BaseURL:            env.GetOrDefaultString(EnvAPIURL, internal.DefaultBaseURL),
+		TTL:                env.GetOrDefaultInt(EnvTTL, minTTL),
+		PropagationTimeout: env.GetOrDefaultSecond(EnvPropagationTimeout, 40*time.Minute),
+		PollingInterval:    env.GetOrDefaultSecond(EnvPollingInterval, 60*time.Second),
+		HTTPClient: useragent.Wrap(&http.Client{
+			Timeout: env.GetOrDefaultSecond(EnvHTTPTimeout, 30*time.Second),
+		}),
+	}
+}
+
+// DNSProvider implements the challenge.Provider interface.
+type DNSProvider struct {
+	config *Config
+	client *internal.Client
+
+	records   map[string]txtRecord
+	recordsMu sync.Mutex
+}
+
+type txtRecord struct {
+	domain    string
+	subdomain string
+	id        int
+}
+
+// NewDNSProvider returns a DNSProvider instance configured for Loopia.
+// Credentials must be passed in the environment variables: LOOPIA_API_USER, LOOPIA_API_PASSWORD.
+func NewDNSProvider() (*DNSProvider, error) {
+	values, err := env.Get(EnvAPIUser, EnvAPIPassword)
+	if err != nil {
+		return nil, fmt.Errorf("loopia: %w", err)
+	}
+
+	config := NewDefaultConfig()
+	config.APIUser = values[EnvAPIUser]
+	config.APIPassword = values[EnvAPIPassword]
+
+	return NewDNSProviderConfig(config)
+}
+
+// NewDNSProviderConfig return a DNSProvider instance configured for Loopia.
+func NewDNSProviderConfig(config *Config) (*DNSProvider, error) {
+	if config == nil {
+		return nil, errors.New("loopia: the configuration of the DNS provider is nil")
+	}
+
+	if config.APIUser == "" || config.APIPassword == "" {
+		return nil, errors.New("loopia: credentials missing")
+	}
+
+	if config.TTL < minTTL {
+		return nil, fmt.Errorf("loopia: invalid TTL, TTL (%d) must be greater than %d", config.TTL, minTTL)
+	}
+
+	client := internal.NewClient(config.APIUser, config.APIPassword)
+
+	if config.BaseURL != "" {
+		client.BaseURL = config.BaseURL
+	}
+
+	if config.HTTPClient != nil {
+		client.HTTPClient = config.HTTPClient
+	}
+
+	return &DNSProvider{
+		config:  config,
+		client:  client,
+		records: make(map[string]txtRecord),
+	}, nil
+}
+
+// Timeout returns the timeout and interval to use when checking for DNS propagation.
+// Adjusting here to cope with spikes in propagation times.
+func (d *DNSProvider) Timeout() (timeout, interval time.Duration) {
+	return d.config.PropagationTimeout, d.config.PollingInterval
+}
+
+// Present creates a TXT record to fulfill the dns-01 challenge.
+func (d *DNSProvider) Present(domain, token, keyAuth string) error {
+	fqdn, value := dns01.GetRecord(domain, keyAuth)
+
+	zone, err := d.findDomain(fqdn)
+	if err != nil {
+		return fmt.Errorf("loopia: %w", err)
+	}
+
+	subdomain := extractRecordName(fqdn, zone)
+
+	err = d.client.AddTXTRecord(zone, subdomain, d.config.TTL, value)
+	if err != nil {
+		return fmt.Errorf("loopia: failed to create TXT record: fqdn=%s, domain=%s: %w", fqdn, zone, err)
+	}
+
+	// the API doesn't return the ID of the created record.
+	records, err := d.client.GetTXTRecords(zone, subdomain)
+	if err != nil {
+		return fmt.Errorf("loopia: failed to get TXT records: fqdn=%s, domain=%s: %w", fqdn, zone, err)
+	}
+
+	for _, r := range records {
+		if r.Rdata == value {
+			d.recordsMu.Lock()
+			d.records[token] = txtRecord{domain: zone, subdomain: subdomain, id: r.RecordID}
+			d.recordsMu.Unlock()
+
+			return nil
+		}
+	}
+
+	return fmt.Errorf("loopia: failed to find the created TXT record: fqdn=%s, domain=%s", fqdn, zone)
+}
+
+// CleanUp removes the TXT record matching the specified parameters.
+func (d *DNSProvider) CleanUp(domain, token, keyAuth string) error {
+	fqdn, _ := dns01.GetRecord(domain, keyAuth)
+
+	// gets the record's unique ID from when we created it
+	d.recordsMu.Lock()
+	record, ok := d.records[token]
+	d.recordsMu.Unlock()
+	if !ok {
+		return fmt.Errorf("loopia: unknown record ID for '%s'", fqdn)
+	}
+
+	err := d.client.RemoveTXTRecord(record.domain, record.subdomain, record.id)
+	if err != nil {
+		return fmt.Errorf("loopia: failed to delete TXT record: fqdn=%s, recordID=%d: %w", fqdn, record.id, err)
+	}
+
+	// deletes record ID from map
+	d.recordsMu.Lock()
+	delete(d.records, token)
+	d.recordsMu.Unlock()
+
+	// the subdomain is only removed when it doesn't contain any other record.
+	records, err := d.client.GetTXTRecords(record.domain, record.subdomain)
+	if err != nil {
+		return fmt.Errorf("loopia: failed to get TXT records: fqdn=%s, domain=%s: %w", fqdn, record.domain, err)
+	}
+
+	if len(records) > 0 {
+		return nil
+	}
+
+	err = d.client.RemoveSubdomain(record.domain, record.subdomain)
+	if err != nil {
+		return fmt.Errorf("loopia: failed to remove subdomain: fqdn=%s, domain=%s: %w", fqdn, record.domain, err)
+	}
+
+	return nil
+}
+
+// Zones returns the domains of the account.
+func (d *DNSProvider) Zones() ([]string, error) {
+	domains, err := d.client.GetDomains()
+	if err != nil {
+		return nil, fmt.Errorf("loopia: failed to get domains: %w", err)
+	}
+
+	var zones []string
+	for _, domain := range domains {
+		zones = append(zones, domain.Domain)
+	}
+
+	return zones, nil
+}
+
+// findDomain finds the most specific domain of the account containing the fqdn.
+func (d *DNSProvider) findDomain(fqdn string) (string, error) {
+	domains, err := d.client.GetDomains()
+	if err != nil {
+		return "", fmt.Errorf("failed to get domains: %w", err)
+	}
+
+	var names []string
+	for _, domain := range domains {
+		names = append(names, domain.Domain)
+	}
+
+	name := dns01.FindMostSpecificZone(fqdn, names)
+	if name == "" {
+		return "", fmt.Errorf("no domain found for %s", dns01.UnFqdn(fqdn))
+	}
+
+	return name, nil
+}
+
+// extractRecordName returns the name of the subdomain relatively to the domain.
+// The apex of the domain is represented by "@".
+func extractRecordName(fqdn, domain string) string {
+	name := dns01.UnFqdn(fqdn)
+	if strings.EqualFold(name, domain) {
+		return "@"
+	}
+
+	return strings.TrimSuffix(name, "."+domain)
+}
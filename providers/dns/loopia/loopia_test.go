@@ -0,0 +1,314 @@
+package loopia
+
+import (
+	"encoding/xml"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/go-acme/lego/v4/platform/tester"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const envDomain = envNamespace + "DOMAIN"
+
+var envTest = tester.NewEnvTest(EnvAPIUser, EnvAPIPassword, EnvAPIURL).WithDomain(envDomain)
+
+const (
+	responseOK      = `<?xml version="1.0" encoding="UTF-8"?><methodResponse><params><param><value><string>OK</string></value></param></params></methodResponse>`
+	responseDomains = `<?xml version="1.0" encoding="UTF-8"?><methodResponse><params><param><value><array><data>` +
+		`<value><struct><member><name>domain</name><value><string>example.com</string></value></member></struct></value>` +
+		`<value><struct><member><name>domain</name><value><string>sub.example.com</string></value></member></struct></value>` +
+		`</data></array></value></param></params></methodResponse>`
+	responseEmptyRecords = `<?xml version="1.0" encoding="UTF-8"?><methodResponse><params><param><value><array><data>` +
+		`</data></array></value></param></params></methodResponse>`
+)
+
+func responseRecords(value string, id int) string {
+	return `<?xml version="1.0" encoding="UTF-8"?><methodResponse><params><param><value><array><data>` +
+		`<value><struct>` +
+		`<member><name>type</name><value><string>TXT</string></value></member>` +
+		`<member><name>ttl</name><value><int>300</int></value></member>` +
+		`<member><name>priority</name><value><int>0</int></value></member>` +
+		`<member><name>rdata</name><value><string>` + value + `</string></value></member>` +
+		fmt.Sprintf(`<member><name>record_id</name><value><int>%d</int></value></member>`, id) +
+		`</struct></value>` +
+		`</data></array></value></param></params></methodResponse>`
+}
+
+type methodCall struct {
+	MethodName string `xml:"methodName"`
+	Params     []struct {
+		String string `xml:"value>string"`
+		Int    int    `xml:"value>int"`
+	} `xml:"params>param"`
+}
+
+func TestNewDNSProvider(t *testing.T) {
+	testCases := []struct {
+		desc     string
+		envVars  map[string]string
+		expected string
+	}{
+		{
+			desc: "success",
+			envVars: map[string]string{
+				EnvAPIUser:     "user",
+				EnvAPIPassword: "secret",
+			},
+		},
+		{
+			desc: "missing credentials",
+			envVars: map[string]string{
+				EnvAPIUser:     "",
+				EnvAPIPassword: "",
+			},
+			expected: "loopia: some credentials information are missing: LOOPIA_API_USER,LOOPIA_API_PASSWORD",
+		},
+		{
+			desc: "missing user",
+			envVars: map[string]string{
+				EnvAPIUser:     "",
+				EnvAPIPassword: "secret",
+			},
+			expected: "loopia: some credentials information are missing: LOOPIA_API_USER",
+		},
+		{
+			desc: "missing password",
+			envVars: map[string]string{
+				EnvAPIUser:     "user",
+				EnvAPIPassword: "",
+			},
+			expected: "loopia: some credentials information are missing: LOOPIA_API_PASSWORD",
+		},
+	}
+
+	for _, test := range testCases {
+		t.Run(test.desc, func(t *testing.T) {
+			defer envTest.RestoreEnv()
+			envTest.ClearEnv()
+
+			envTest.Apply(test.envVars)
+
+			p, err := NewDNSProvider()
+
+			if len(test.expected) == 0 {
+				require.NoError(t, err)
+				require.NotNil(t, p)
+				require.NotNil(t, p.config)
+				require.NotNil(t, p.client)
+			} else {
+				require.EqualError(t, err, test.expected)
+			}
+		})
+	}
+}
+
+func TestNewDNSProviderConfig(t *testing.T) {
+	testCases := []struct {
+		desc     string
+		user     string
+		password string
+		ttl      int
+		expected string
+	}{
+		{
+			desc:     "success",
+			user:     "user",
+			password: "secret",
+			ttl:      minTTL,
+		},
+		{
+			desc:     "missing credentials",
+			ttl:      minTTL,
+			expected: "loopia: credentials missing",
+		},
+		{
+			desc:     "missing password",
+			user:     "user",
+			ttl:      minTTL,
+			expected: "loopia: credentials missing",
+		},
+		{
+			desc:     "invalid TTL",
+			user:     "user",
+			password: "secret",
+			ttl:      120,
+			expected: "loopia: invalid TTL, TTL (120) must be greater than 300",
+		},
+	}
+
+	for _, test := range testCases {
+		t.Run(test.desc, func(t *testing.T) {
+			config := NewDefaultConfig()
+			config.APIUser = test.user
+			config.APIPassword = test.password
+			config.TTL = test.ttl
+
+			p, err := NewDNSProviderConfig(config)
+
+			if len(test.expected) == 0 {
+				require.NoError(t, err)
+				require.NotNil(t, p)
+				require.NotNil(t, p.config)
+				require.NotNil(t, p.client)
+			} else {
+				require.EqualError(t, err, test.expected)
+			}
+		})
+	}
+}
+
+func setupTest(t *testing.T, handlers map[string]func(call methodCall) string) (*DNSProvider, *[]string) {
+	t.Helper()
+
+	var calls []string
+
+	server := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		call := methodCall{}
+		err := xml.NewDecoder(req.Body).Decode(&call)
+		if err != nil {
+			http.Error(rw, err.Error(), http.StatusBadRequest)
+			return
+		}
+
+		if call.MethodName == "getDomains" {
+			_, _ = fmt.Fprint(rw, responseDomains)
+			return
+		}
+
+		calls = append(calls, call.MethodName)
+
+		handler, ok := handlers[call.MethodName]
+		if !ok {
+			http.Error(rw, fmt.Sprintf("unexpected method call: %s", call.MethodName), http.StatusBadRequest)
+			return
+		}
+
+		_, _ = fmt.Fprint(rw, handler(call))
+	}))
+	t.Cleanup(server.Close)
+
+	config := NewDefaultConfig()
+	config.BaseURL = server.URL
+	config.APIUser = "user"
+	config.APIPassword = "secret"
+
+	provider, err := NewDNSProviderConfig(config)
+	require.NoError(t, err)
+
+	return provider, &calls
+}
+
+func TestDNSProvider_Present(t *testing.T) {
+	value := "ADw2sEd82DUgXcQ9hNBZThJs7zVJkR5v9JeSbAb9mZY"
+
+	provider, calls := setupTest(t, map[string]func(call methodCall) string{
+		"addZoneRecord": func(call methodCall) string {
+			assert.Equal(t, "sub.example.com", call.Params[3].String)
+			assert.Equal(t, "_acme-challenge.www", call.Params[4].String)
+			return responseOK
+		},
+		"getZoneRecords": func(call methodCall) string {
+			return responseRecords(value, 12345678)
+		},
+	})
+
+	err := provider.Present("www.sub.example.com", "token", "123d==")
+	require.NoError(t, err)
+
+	assert.Equal(t, []string{"addZoneRecord", "getZoneRecords"}, *calls)
+
+	expected := map[string]txtRecord{
+		"token": {domain: "sub.example.com", subdomain: "_acme-challenge.www", id: 12345678},
+	}
+	assert.Equal(t, expected, provider.records)
+}
+
+func TestDNSProvider_Present_unknownDomain(t *testing.T) {
+	provider, _ := setupTest(t, nil)
+
+	err := provider.Present("www.example.org", "token", "123d==")
+	require.EqualError(t, err, "loopia: no domain found for _acme-challenge.www.example.org")
+}
+
+func TestDNSProvider_Present_recordNotFound(t *testing.T) {
+	provider, _ := setupTest(t, map[string]func(call methodCall) string{
+		"addZoneRecord":  func(_ methodCall) string { return responseOK },
+		"getZoneRecords": func(_ methodCall) string { return responseEmptyRecords },
+	})
+
+	err := provider.Present("www.example.com", "token", "123d==")
+	require.EqualError(t, err, "loopia: failed to find the created TXT record: fqdn=_acme-challenge.www.example.com., domain=example.com")
+}
+
+func TestDNSProvider_CleanUp(t *testing.T) {
+	provider, calls := setupTest(t, map[string]func(call methodCall) string{
+		"removeZoneRecord": func(call methodCall) string {
+			assert.Equal(t, 12345678, call.Params[5].Int)
+			return responseOK
+		},
+		"getZoneRecords":  func(_ methodCall) string { return responseEmptyRecords },
+		"removeSubdomain": func(_ methodCall) string { return responseOK },
+	})
+
+	provider.records["token"] = txtRecord{domain: "example.com", subdomain: "_acme-challenge.www", id: 12345678}
+
+	err := provider.CleanUp("www.example.com", "token", "123d==")
+	require.NoError(t, err)
+
+	assert.Equal(t, []string{"removeZoneRecord", "getZoneRecords", "removeSubdomain"}, *calls)
+	assert.Empty(t, provider.records)
+}
+
+func TestDNSProvider_CleanUp_otherRecords(t *testing.T) {
+	provider, calls := setupTest(t, map[string]func(call methodCall) string{
+		"removeZoneRecord": func(_ methodCall) string { return responseOK },
+		"getZoneRecords":   func(_ methodCall) string { return responseRecords("other", 12345679) },
+	})
+
+	provider.records["token"] = txtRecord{domain: "example.com", subdomain: "_acme-challenge.www", id: 12345678}
+
+	err := provider.CleanUp("www.example.com", "token", "123d==")
+	require.NoError(t, err)
+
+	assert.Equal(t, []string{"removeZoneRecord", "getZoneRecords"}, *calls)
+	assert.Empty(t, provider.records)
+}
+
+func TestDNSProvider_CleanUp_unknownRecord(t *testing.T) {
+	provider, _ := setupTest(t, nil)
+
+	err := provider.CleanUp("www.example.com", "token", "123d==")
+	require.EqualError(t, err, "loopia: unknown record ID for '_acme-challenge.www.example.com.'")
+}
+
+func TestDNSProvider_Zones(t *testing.T) {
+	provider, _ := setupTest(t, nil)
+
+	zones, err := provider.Zones()
+	require.NoError(t, err)
+
+	assert.Equal(t, []string{"example.com", "sub.example.com"}, zones)
+}
+
+func TestLivePresentAndCleanUp(t *testing.T) {
+	if !envTest.IsLiveTest() {
+		t.Skip("skipping live test")
+	}
+
+	envTest.RestoreEnv()
+	provider, err := NewDNSProvider()
+	require.NoError(t, err)
+
+	err = provider.Present(envTest.GetDomain(), "", "123d==")
+	require.NoError(t, err)
+
+	time.Sleep(1 * time.Second)
+
+	err = provider.CleanUp(envTest.GetDomain(), "", "123d==")
+	require.NoError(t, err)
+}
@@ -9,6 +9,7 @@ import (
 	"net/http"
 	"path"
 	"strings"
+	"time"
 )
 
 const (
@@ -16,6 +17,9 @@ const (
 	authBaseURL = "https://auth.mythic-beasts.com/login"
 )
 
+// tokenExpiryMargin the token is renewed before the end of its lifetime, to avoid using it while it expires.
+const tokenExpiryMargin = 30 * time.Second
+
 type authResponse struct {
 	// The bearer token for use in API requests
 	Token string `json:"access_token"`
@@ -58,10 +62,18 @@ type deleteTXTResponse struct {
 	Message string `json:"message"`
 }
 
+type zonesResponse struct {
+	Zones []string `json:"zones"`
+}
+
 // Logs into mythic beasts and acquires a bearer token for use in future API calls.
+// The token is reused until the end of its lifetime.
 // https://www.mythic-beasts.com/support/api/auth#sec-obtaining-a-token
 func (d *DNSProvider) login() error {
-	if d.token != "" {
+	d.tokenMu.Lock()
+	defer d.tokenMu.Unlock()
+
+	if d.token != "" && time.Now().Before(d.tokenExpiresAt) {
 		// Already authenticated, stop now
 		return nil
 	}
@@ -114,6 +126,7 @@ func (d *DNSProvider) login() error {
 	}
 
 	d.token = authResp.Token
+	d.tokenExpiresAt = time.Now().Add(time.Duration(authResp.Lifetime)*time.Second - tokenExpiryMargin)
 
 	// Success
 	return nil
@@ -121,7 +134,8 @@ func (d *DNSProvider) login() error {
 
 // https://www.mythic-beasts.com/support/api/dnsv2#ep-get-zoneszonerecords
 func (d *DNSProvider) createTXTRecord(zone, leaf, value string) error {
-	if d.token == "" {
+	token := d.getToken()
+	if token == "" {
 		return fmt.Errorf("createTXTRecord: not logged in")
 	}
 
@@ -149,7 +163,7 @@ func (d *DNSProvider) createTXTRecord(zone, leaf, value string) error {
 		return fmt.Errorf("createTXTRecord: %w", err)
 	}
 
-	req.Header.Add("Authorization", fmt.Sprintf("Bearer %s", d.token))
+	req.Header.Add("Authorization", fmt.Sprintf("Bearer %s", token))
 	req.Header.Add("Content-Type", "application/json")
 
 	resp, err := d.config.HTTPClient.Do(req)
@@ -184,7 +198,8 @@ func (d *DNSProvider) createTXTRecord(zone, leaf, value string) error {
 
 // https://www.mythic-beasts.com/support/api/dnsv2#ep-delete-zoneszonerecords
 func (d *DNSProvider) removeTXTRecord(zone, leaf, value string) error {
-	if d.token == "" {
+	token := d.getToken()
+	if token == "" {
 		return fmt.Errorf("removeTXTRecord: not logged in")
 	}
 
@@ -202,7 +217,7 @@ func (d *DNSProvider) removeTXTRecord(zone, leaf, value string) error {
 		return fmt.Errorf("removeTXTRecord: %w", err)
 	}
 
-	req.Header.Add("Authorization", fmt.Sprintf("Bearer %s", d.token))
+	req.Header.Add("Authorization", fmt.Sprintf("Bearer %s", token))
 
 	resp, err := d.config.HTTPClient.Do(req)
 	if err != nil {
@@ -233,3 +248,54 @@ func (d *DNSProvider) removeTXTRecord(zone, leaf, value string) error {
 	// Success
 	return nil
 }
+
+// https://www.mythic-beasts.com/support/api/dnsv2#ep-get-zones
+func (d *DNSProvider) listZones() ([]string, error) {
+	token := d.getToken()
+	if token == "" {
+		return nil, fmt.Errorf("listZones: not logged in")
+	}
+
+	endpoint, err := d.config.APIEndpoint.Parse(path.Join(d.config.APIEndpoint.Path, "zones"))
+	if err != nil {
+		return nil, fmt.Errorf("listZones: failed to parse URL: %w", err)
+	}
+
+	req, err := http.NewRequest(http.MethodGet, endpoint.String(), nil)
+	if err != nil {
+		return nil, fmt.Errorf("listZones: %w", err)
+	}
+
+	req.Header.Add("Authorization", fmt.Sprintf("Bearer %s", token))
+
+	resp, err := d.config.HTTPClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("listZones: unable to perform HTTP request: %w", err)
+	}
+
+	defer func() { _ = resp.Body.Close() }()
+
+	body, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("listZones: %w", err)
+	}
+
+	if resp.StatusCode != 200 {
+		return nil, fmt.Errorf("listZones: error in API: %d", resp.StatusCode)
+	}
+
+	zonesResp := zonesResponse{}
+	err = json.Unmarshal(body, &zonesResp)
+	if err != nil {
+		return nil, fmt.Errorf("listZones: error parsing response: %w", err)
+	}
+
+	return zonesResp.Zones, nil
+}
+
+func (d *DNSProvider) getToken() string {
+	d.tokenMu.Lock()
+	defer d.tokenMu.Unlock()
+
+	return d.token
+}
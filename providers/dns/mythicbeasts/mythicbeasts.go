@@ -6,10 +6,14 @@ import (
 	"fmt"
 	"net/http"
 	"net/url"
+	"strings"
+	"sync"
 	"time"
 
 	"github.com/go-acme/lego/v4/challenge/dns01"
+	"github.com/go-acme/lego/v4/log"
 	"github.com/go-acme/lego/v4/platform/config/env"
+	"github.com/go-acme/lego/v4/providers/dns/internal/useragent"
 )
 
 // Environment variables names.
@@ -57,21 +61,24 @@ func NewDefaultConfig() (*Config, error) {
 		PollingInterval:    env.GetOrDefaultSecond(EnvPollingInterval, dns01.DefaultPollingInterval),
 		APIEndpoint:        apiEndpoint,
 		AuthAPIEndpoint:    authEndpoint,
-		HTTPClient: &http.Client{
+		HTTPClient: useragent.Wrap(&http.Client{
 			Timeout: env.GetOrDefaultSecond(EnvHTTPTimeout, 10*time.Second),
-		},
+		}),
 	}, nil
 }
 
 // DNSProvider implements the challenge.Provider interface.
 type DNSProvider struct {
 	config *Config
-	token  string
+
+	token          string
+	tokenExpiresAt time.Time
+	tokenMu        sync.Mutex
 }
 
 // NewDNSProvider returns a DNSProvider instance configured for mythicbeasts DNSv2 API.
 // Credentials must be passed in the environment variables:
-// MYTHICBEASTS_USER_NAME and MYTHICBEASTS_PASSWORD.
+// MYTHICBEASTS_USERNAME and MYTHICBEASTS_PASSWORD.
 func NewDNSProvider() (*DNSProvider, error) {
 	values, err := env.Get(EnvUserName, EnvPassword)
 	if err != nil {
@@ -105,21 +112,17 @@ func NewDNSProviderConfig(config *Config) (*DNSProvider, error) {
 func (d *DNSProvider) Present(domain, token, keyAuth string) error {
 	fqdn, value := dns01.GetRecord(domain, keyAuth)
 
-	authZone, err := dns01.FindZoneByFqdn(fqdn)
+	err := d.login()
 	if err != nil {
 		return fmt.Errorf("mythicbeasts: %w", err)
 	}
 
-	leaf := fqdn[:len(fqdn)-(len(authZone)+1)]
-
-	authZone = dns01.UnFqdn(authZone)
-
-	err = d.login()
+	authZone, err := d.findZone(fqdn)
 	if err != nil {
 		return fmt.Errorf("mythicbeasts: %w", err)
 	}
 
-	err = d.createTXTRecord(authZone, leaf, value)
+	err = d.createTXTRecord(authZone, extractRecordName(fqdn, authZone), value)
 	if err != nil {
 		return fmt.Errorf("mythicbeasts: %w", err)
 	}
@@ -131,24 +134,74 @@ func (d *DNSProvider) Present(domain, token, keyAuth string) error {
 func (d *DNSProvider) CleanUp(domain, token, keyAuth string) error {
 	fqdn, value := dns01.GetRecord(domain, keyAuth)
 
-	authZone, err := dns01.FindZoneByFqdn(fqdn)
+	err := d.login()
 	if err != nil {
 		return fmt.Errorf("mythicbeasts: %w", err)
 	}
 
-	leaf := fqdn[:len(fqdn)-(len(authZone)+1)]
-
-	authZone = dns01.UnFqdn(authZone)
-
-	err = d.login()
+	authZone, err := d.findZone(fqdn)
 	if err != nil {
 		return fmt.Errorf("mythicbeasts: %w", err)
 	}
 
-	err = d.removeTXTRecord(authZone, leaf, value)
+	err = d.removeTXTRecord(authZone, extractRecordName(fqdn, authZone), value)
 	if err != nil {
 		return fmt.Errorf("mythicbeasts: %w", err)
 	}
 
 	return nil
 }
+
+// Timeout returns the timeout and interval to use when checking for DNS propagation.
+// Adjusting here to cope with spikes in propagation times.
+func (d *DNSProvider) Timeout() (timeout, interval time.Duration) {
+	return d.config.PropagationTimeout, d.config.PollingInterval
+}
+
+// Zones returns the zones of the account.
+func (d *DNSProvider) Zones() ([]string, error) {
+	err := d.login()
+	if err != nil {
+		return nil, fmt.Errorf("mythicbeasts: %w", err)
+	}
+
+	zones, err := d.listZones()
+	if err != nil {
+		return nil, fmt.Errorf("mythicbeasts: %w", err)
+	}
+
+	return zones, nil
+}
+
+// findZone finds the most specific zone of the account for the fqdn.
+// Falls back to the zone found through DNS if the zones cannot be listed (ex: an API key restricted to some records).
+func (d *DNSProvider) findZone(fqdn string) (string, error) {
+	zones, err := d.listZones()
+	if err != nil {
+		log.Infof("mythicbeasts: unable to list the zones, falling back to DNS: %v", err)
+
+		authZone, errZone := dns01.FindZoneByFqdn(fqdn)
+		if errZone != nil {
+			return "", errZone
+		}
+
+		return dns01.UnFqdn(authZone), nil
+	}
+
+	authZone := dns01.FindMostSpecificZone(fqdn, zones)
+	if authZone == "" {
+		return "", fmt.Errorf("no zone found for %s", fqdn)
+	}
+
+	return dns01.UnFqdn(authZone), nil
+}
+
+// extractRecordName returns the name of the record relatively to the zone.
+func extractRecordName(fqdn, zone string) string {
+	name := dns01.UnFqdn(fqdn)
+	if strings.EqualFold(name, zone) {
+		return "@"
+	}
+
+	return strings.TrimSuffix(name, "."+zone)
+}
@@ -1,10 +1,16 @@
 package mythicbeasts
 
 import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
 	"testing"
 	"time"
 
 	"github.com/go-acme/lego/v4/platform/tester"
+	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 )
 
@@ -124,6 +130,166 @@ func TestNewDNSProviderConfig(t *testing.T) {
 	}
 }
 
+func setupTest(t *testing.T, lifetime int) (*DNSProvider, *http.ServeMux, *int) {
+	t.Helper()
+
+	mux := http.NewServeMux()
+	server := httptest.NewServer(mux)
+	t.Cleanup(server.Close)
+
+	var logins int
+	mux.HandleFunc("/login", func(rw http.ResponseWriter, req *http.Request) {
+		if req.Method != http.MethodPost {
+			http.Error(rw, fmt.Sprintf("unsupported method: %s", req.Method), http.StatusMethodNotAllowed)
+			return
+		}
+
+		username, password, ok := req.BasicAuth()
+		if !ok || username != "user" || password != "secret" {
+			rw.WriteHeader(http.StatusUnauthorized)
+			_, _ = fmt.Fprint(rw, `{"error":"invalid_client","error_description":"Invalid client credentials"}`)
+			return
+		}
+
+		err := req.ParseForm()
+		if err != nil || req.PostForm.Get("grant_type") != "client_credentials" {
+			http.Error(rw, "invalid grant type", http.StatusBadRequest)
+			return
+		}
+
+		logins++
+
+		_, _ = fmt.Fprintf(rw, `{"access_token":"token%d","expires_in":%d,"token_type":"bearer"}`, logins, lifetime)
+	})
+
+	mux.HandleFunc("/dns/v2/zones", func(rw http.ResponseWriter, req *http.Request) {
+		if req.Header.Get("Authorization") != fmt.Sprintf("Bearer token%d", logins) {
+			http.Error(rw, "invalid token", http.StatusUnauthorized)
+			return
+		}
+
+		_, _ = fmt.Fprint(rw, `{"zones":["example.com","sub.example.com"]}`)
+	})
+
+	config, err := NewDefaultConfig()
+	require.NoError(t, err)
+
+	config.UserName = "user"
+	config.Password = "secret"
+	config.APIEndpoint, err = url.Parse(server.URL + "/dns/v2")
+	require.NoError(t, err)
+	config.AuthAPIEndpoint, err = url.Parse(server.URL + "/login")
+	require.NoError(t, err)
+
+	provider, err := NewDNSProviderConfig(config)
+	require.NoError(t, err)
+
+	return provider, mux, &logins
+}
+
+func TestDNSProvider_Present(t *testing.T) {
+	provider, mux, logins := setupTest(t, 300)
+
+	mux.HandleFunc("/dns/v2/zones/sub.example.com/records/_acme-challenge.www/TXT", func(rw http.ResponseWriter, req *http.Request) {
+		if req.Method != http.MethodPost {
+			http.Error(rw, fmt.Sprintf("unsupported method: %s", req.Method), http.StatusMethodNotAllowed)
+			return
+		}
+
+		if req.Header.Get("Authorization") != "Bearer token1" {
+			http.Error(rw, "invalid token", http.StatusUnauthorized)
+			return
+		}
+
+		createReq := createTXTRequest{}
+		err := json.NewDecoder(req.Body).Decode(&createReq)
+		if err != nil {
+			http.Error(rw, err.Error(), http.StatusBadRequest)
+			return
+		}
+
+		expected := createTXTRequest{Records: []createTXTRecord{{
+			Host: "_acme-challenge.www",
+			TTL:  120,
+			Type: "TXT",
+			Data: "ADw2sEd82DUgXcQ9hNBZThJs7zVJkR5v9JeSbAb9mZY",
+		}}}
+		assert.Equal(t, expected, createReq)
+
+		_, _ = fmt.Fprint(rw, `{"records_added":1,"records_removed":0,"message":"1 record added"}`)
+	})
+
+	err := provider.Present("www.sub.example.com", "token", "123d==")
+	require.NoError(t, err)
+
+	// the token is reused.
+	err = provider.Present("www.sub.example.com", "token", "123d==")
+	require.NoError(t, err)
+
+	assert.Equal(t, 1, *logins)
+}
+
+func TestDNSProvider_Present_tokenExpired(t *testing.T) {
+	// the lifetime of the tokens is shorter than the expiry margin: a new token is requested for each call.
+	provider, mux, logins := setupTest(t, 10)
+
+	mux.HandleFunc("/dns/v2/zones/example.com/records/_acme-challenge/TXT", func(rw http.ResponseWriter, req *http.Request) {
+		if req.Header.Get("Authorization") != fmt.Sprintf("Bearer token%d", *logins) {
+			http.Error(rw, "invalid token", http.StatusUnauthorized)
+			return
+		}
+
+		_, _ = fmt.Fprint(rw, `{"records_added":1,"records_removed":0,"message":"1 record added"}`)
+	})
+
+	err := provider.Present("example.com", "token", "123d==")
+	require.NoError(t, err)
+
+	err = provider.Present("example.com", "token", "123d==")
+	require.NoError(t, err)
+
+	assert.Equal(t, 2, *logins)
+}
+
+func TestDNSProvider_Present_invalidCredentials(t *testing.T) {
+	provider, _, _ := setupTest(t, 300)
+
+	provider.config.Password = "wrong"
+
+	err := provider.Present("www.example.com", "token", "123d==")
+	require.EqualError(t, err, "mythicbeasts: login: 401: invalid_client: Invalid client credentials")
+}
+
+func TestDNSProvider_CleanUp(t *testing.T) {
+	provider, mux, _ := setupTest(t, 300)
+
+	mux.HandleFunc("/dns/v2/zones/example.com/records/_acme-challenge.www/TXT", func(rw http.ResponseWriter, req *http.Request) {
+		if req.Method != http.MethodDelete {
+			http.Error(rw, fmt.Sprintf("unsupported method: %s", req.Method), http.StatusMethodNotAllowed)
+			return
+		}
+
+		if req.URL.Query().Get("data") != "ADw2sEd82DUgXcQ9hNBZThJs7zVJkR5v9JeSbAb9mZY" {
+			http.Error(rw, "unexpected data", http.StatusBadRequest)
+			return
+		}
+
+		_, _ = fmt.Fprint(rw, `{"records_removed":1,"message":"1 record removed"}`)
+	})
+
+	err := provider.CleanUp("www.example.com", "token", "123d==")
+	require.NoError(t, err)
+}
+
+func TestDNSProvider_Zones(t *testing.T) {
+	provider, _, _ := setupTest(t, 300)
+
+	zones, err := provider.Zones()
+	require.NoError(t, err)
+
+	assert.Equal(t, []string{"example.com", "sub.example.com"}, zones)
+}
+
 func TestLivePresent(t *testing.T) {
 	if !envTest.IsLiveTest() {
 		t.Skip("skipping live test")
@@ -5,10 +5,12 @@ import (
 	"errors"
 	"fmt"
 	"strings"
+	"sync"
 	"time"
 
 	"github.com/go-acme/lego/v4/challenge/dns01"
 	"github.com/go-acme/lego/v4/platform/config/env"
+	"github.com/go-acme/lego/v4/providers/dns/internal/useragent"
 	"github.com/nrdcg/namesilo"
 )
 
@@ -21,7 +23,8 @@ const (
 const (
 	envNamespace = "NAMESILO_"
 
-	EnvAPIKey = envNamespace + "API_KEY"
+	EnvAPIKey  = envNamespace + "API_KEY"
+	EnvSandbox = envNamespace + "SANDBOX"
 
 	EnvTTL                = envNamespace + "TTL"
 	EnvPropagationTimeout = envNamespace + "PROPAGATION_TIMEOUT"
@@ -31,6 +34,7 @@ const (
 // Config is used to configure the creation of the DNSProvider.
 type Config struct {
 	APIKey             string
+	Sandbox            bool
 	PropagationTimeout time.Duration
 	PollingInterval    time.Duration
 	TTL                int
@@ -42,6 +46,7 @@ func NewDefaultConfig() *Config {
 		TTL:                env.GetOrDefaultInt(EnvTTL, defaultTTL),
 		PropagationTimeout: env.GetOrDefaultSecond(EnvPropagationTimeout, dns01.DefaultPropagationTimeout),
 		PollingInterval:    env.GetOrDefaultSecond(EnvPollingInterval, dns01.DefaultPollingInterval),
+		Sandbox:            env.GetOrDefaultBool(EnvSandbox, false),
 	}
 }
 
@@ -49,6 +54,14 @@ func NewDefaultConfig() *Config {
 type DNSProvider struct {
 	client *namesilo.Client
 	config *Config
+
+	records   map[string]txtRecord
+	recordsMu sync.Mutex
+}
+
+type txtRecord struct {
+	zone string
+	id   string
 }
 
 // NewDNSProvider returns a DNSProvider instance configured for namesilo.
@@ -82,7 +95,20 @@ func NewDNSProviderConfig(config *Config) (*DNSProvider, error) {
 		return nil, fmt.Errorf("namesilo: %w", err)
 	}
 
-	return &DNSProvider{client: namesilo.NewClient(transport.Client()), config: config}, nil
+	if ua := useragent.Get(); ua != "" {
+		transport.Transport = &useragent.Transport{UserAgent: ua}
+	}
+
+	client := namesilo.NewClient(transport.Client())
+	if config.Sandbox {
+		client.Endpoint = namesilo.SandboxAPIEndpoint
+	}
+
+	return &DNSProvider{
+		client:  client,
+		config:  config,
+		records: make(map[string]txtRecord),
+	}, nil
 }
 
 // Present creates a TXT record to fulfill the dns-01 challenge.
@@ -94,7 +120,7 @@ func (d *DNSProvider) Present(domain, token, keyAuth string) error {
 		return fmt.Errorf("namesilo: %w", err)
 	}
 
-	_, err = d.client.DnsAddRecord(&namesilo.DnsAddRecordParams{
+	resp, err := d.client.DnsAddRecord(&namesilo.DnsAddRecordParams{
 		Domain: zoneName,
 		Type:   "TXT",
 		Host:   getRecordName(fqdn, zoneName),
@@ -104,6 +130,11 @@ func (d *DNSProvider) Present(domain, token, keyAuth string) error {
 	if err != nil {
 		return fmt.Errorf("namesilo: failed to add record %w", err)
 	}
+
+	d.recordsMu.Lock()
+	d.records[token] = txtRecord{zone: zoneName, id: resp.Reply.RecordID}
+	d.recordsMu.Unlock()
+
 	return nil
 }
 
@@ -111,27 +142,25 @@ func (d *DNSProvider) Present(domain, token, keyAuth string) error {
 func (d *DNSProvider) CleanUp(domain, token, keyAuth string) error {
 	fqdn, _ := dns01.GetRecord(domain, keyAuth)
 
-	zoneName, err := getZoneNameByDomain(domain)
-	if err != nil {
-		return fmt.Errorf("namesilo: %w", err)
+	// gets the record's unique ID from when we created it
+	d.recordsMu.Lock()
+	record, ok := d.records[token]
+	d.recordsMu.Unlock()
+	if !ok {
+		return fmt.Errorf("namesilo: unknown record ID for '%s'", fqdn)
 	}
 
-	resp, err := d.client.DnsListRecords(&namesilo.DnsListRecordsParams{Domain: zoneName})
+	_, err := d.client.DnsDeleteRecord(&namesilo.DnsDeleteRecordParams{Domain: record.zone, ID: record.id})
 	if err != nil {
 		return fmt.Errorf("namesilo: %w", err)
 	}
 
-	var lastErr error
-	name := getRecordName(fqdn, zoneName)
-	for _, r := range resp.Reply.ResourceRecord {
-		if r.Type == "TXT" && (r.Host == name || r.Host == dns01.UnFqdn(fqdn)) {
-			_, err := d.client.DnsDeleteRecord(&namesilo.DnsDeleteRecordParams{Domain: zoneName, ID: r.RecordID})
-			if err != nil {
-				lastErr = fmt.Errorf("namesilo: %w", err)
-			}
-		}
-	}
-	return lastErr
+	// deletes record ID from map
+	d.recordsMu.Lock()
+	delete(d.records, token)
+	d.recordsMu.Unlock()
+
+	return nil
 }
 
 // Timeout returns the timeout and interval to use when checking for DNS propagation.
@@ -1,10 +1,15 @@
 package namesilo
 
 import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
 	"testing"
 	"time"
 
 	"github.com/go-acme/lego/v4/platform/tester"
+	"github.com/nrdcg/namesilo"
+	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 )
 
@@ -12,7 +17,8 @@ const envDomain = envNamespace + "DOMAIN"
 
 var envTest = tester.NewEnvTest(
 	EnvTTL,
-	EnvAPIKey).
+	EnvAPIKey,
+	EnvSandbox).
 	WithDomain(envDomain)
 
 func TestNewDNSProvider(t *testing.T) {
@@ -106,6 +112,85 @@ func TestNewDNSProviderConfig(t *testing.T) {
 	}
 }
 
+func TestNewDNSProviderConfig_sandbox(t *testing.T) {
+	testCases := []struct {
+		desc     string
+		sandbox  bool
+		expected string
+	}{
+		{
+			desc:     "production",
+			expected: namesilo.DefaultAPIEndpoint,
+		},
+		{
+			desc:     "sandbox",
+			sandbox:  true,
+			expected: namesilo.SandboxAPIEndpoint,
+		},
+	}
+
+	for _, test := range testCases {
+		t.Run(test.desc, func(t *testing.T) {
+			config := NewDefaultConfig()
+			config.APIKey = "A"
+			config.TTL = defaultTTL
+			config.Sandbox = test.sandbox
+
+			p, err := NewDNSProviderConfig(config)
+			require.NoError(t, err)
+
+			assert.Equal(t, test.expected, p.client.Endpoint)
+		})
+	}
+}
+
+func TestDNSProvider_CleanUp(t *testing.T) {
+	mux := http.NewServeMux()
+	server := httptest.NewServer(mux)
+	t.Cleanup(server.Close)
+
+	var deleted []string
+	mux.HandleFunc("/dnsDeleteRecord", func(rw http.ResponseWriter, req *http.Request) {
+		assert.Equal(t, "example.com", req.URL.Query().Get("domain"))
+		deleted = append(deleted, req.URL.Query().Get("rrid"))
+
+		_, _ = fmt.Fprint(rw, `<namesilo><request><operation>dnsDeleteRecord</operation></request><reply><code>300</code><detail>success</detail></reply></namesilo>`)
+	})
+
+	mux.HandleFunc("/dnsListRecords", func(rw http.ResponseWriter, req *http.Request) {
+		http.Error(rw, "the records must not be listed", http.StatusBadRequest)
+	})
+
+	config := NewDefaultConfig()
+	config.APIKey = "secret"
+	config.TTL = defaultTTL
+
+	provider, err := NewDNSProviderConfig(config)
+	require.NoError(t, err)
+
+	provider.client.Endpoint = server.URL
+
+	provider.records["token"] = txtRecord{zone: "example.com", id: "1a2b3c"}
+
+	err = provider.CleanUp("www.example.com", "token", "123d==")
+	require.NoError(t, err)
+
+	assert.Equal(t, []string{"1a2b3c"}, deleted)
+	assert.Empty(t, provider.records)
+}
+
+func TestDNSProvider_CleanUp_unknownRecord(t *testing.T) {
+	config := NewDefaultConfig()
+	config.APIKey = "secret"
+	config.TTL = defaultTTL
+
+	provider, err := NewDNSProviderConfig(config)
+	require.NoError(t, err)
+
+	err = provider.CleanUp("www.example.com", "token", "123d==")
+	require.EqualError(t, err, "namesilo: unknown record ID for '_acme-challenge.www.example.com.'")
+}
+
 func TestLivePresent(t *testing.T) {
 	if !envTest.IsLiveTest() {
 		t.Skip("skipping live test")
@@ -182,9 +182,10 @@ func (c *Client) Logout(sessionID string) error {
 	return nil
 }
 
-// UpdateDNSRecord performs an update of the DNSRecords as specified by the netcup WSDL.
+// UpdateDNSRecord performs an update of the DNSRecords as specified by the netcup WSDL
+// returns the DNSRecords of the DNS-Zone after the update.
 // https://ccp.netcup.net/run/webservice/servers/endpoint.php
-func (c *Client) UpdateDNSRecord(sessionID, domainName string, records []DNSRecord) error {
+func (c *Client) UpdateDNSRecord(sessionID, domainName string, records []DNSRecord) ([]DNSRecord, error) {
 	payload := &Request{
 		Action: "updateDnsRecords",
 		Param: UpdateDNSRecordsRequest{
@@ -197,12 +198,13 @@ func (c *Client) UpdateDNSRecord(sessionID, domainName string, records []DNSReco
 		},
 	}
 
-	err := c.doRequest(payload, nil)
+	var responseData DNSRecordSet
+	err := c.doRequest(payload, &responseData)
 	if err != nil {
-		return fmt.Errorf("error when sending the request: %w", err)
+		return nil, fmt.Errorf("error when sending the request: %w", err)
 	}
 
-	return nil
+	return responseData.DNSRecords, nil
 }
 
 // GetDNSRecords retrieves all dns records of an DNS-Zone as specified by the netcup WSDL
@@ -315,11 +317,11 @@ func decodeResponseMsg(resp *http.Response) (*ResponseMsg, error) {
 }
 
 // GetDNSRecordIdx searches a given array of DNSRecords for a given DNSRecord
-// equivalence is determined by Destination and RecortType attributes
+// equivalence is determined by Hostname, Destination and RecortType attributes
 // returns index of given DNSRecord in given array of DNSRecords.
 func GetDNSRecordIdx(records []DNSRecord, record DNSRecord) (int, error) {
 	for index, element := range records {
-		if record.Destination == element.Destination && record.RecordType == element.RecordType {
+		if record.Hostname == element.Hostname && record.Destination == element.Destination && record.RecordType == element.RecordType {
 			return index, nil
 		}
 	}
@@ -104,6 +104,19 @@ func TestGetDNSRecordIdx(t *testing.T) {
 			},
 			expectError: true,
 		},
+		{
+			desc: "wrong Hostname",
+			record: DNSRecord{
+				ID:           12345,
+				Hostname:     "wrong",
+				RecordType:   "TXT",
+				Priority:     "0",
+				Destination:  "randomtext",
+				DeleteRecord: false,
+				State:        "yes",
+			},
+			expectError: true,
+		},
 		{
 			desc: "record type CNAME",
 			record: DNSRecord{
@@ -409,6 +422,85 @@ func TestClient_GetDNSRecords(t *testing.T) {
 	assert.Equal(t, expected, records)
 }
 
+func TestClient_UpdateDNSRecord(t *testing.T) {
+	client, mux, tearDown := setupClientTest()
+	defer tearDown()
+
+	mux.HandleFunc("/", func(rw http.ResponseWriter, req *http.Request) {
+		raw, err := ioutil.ReadAll(req.Body)
+		if err != nil {
+			http.Error(rw, err.Error(), http.StatusInternalServerError)
+		}
+
+		if string(raw) != `{"action":"updateDnsRecords","param":{"domainname":"example.com","customernumber":"a","apikey":"b","apisessionid":"api-session-id","dnsrecordset":{"dnsrecords":[{"hostname":"_acme-challenge","type":"TXT","destination":"bGVnbw==","ttl":300}]}}}` {
+			http.Error(rw, fmt.Sprintf("invalid request body: %s", string(raw)), http.StatusBadRequest)
+		}
+
+		response := `
+			{
+			  "serverrequestid":"srv-request-id",
+			  "clientrequestid":"",
+			  "action":"updateDnsRecords",
+			  "status":"success",
+			  "statuscode":2000,
+			  "shortmessage":"DNS records successful updated",
+			  "longmessage":"The given DNS records for the domain were updated.",
+			  "responsedata":{
+			    "dnsrecords":[
+			      {
+			        "id":"1",
+			        "hostname":"www",
+			        "type":"A",
+			        "priority":"0",
+			        "destination":"127.0.0.1",
+			        "state":"yes"
+			      },
+			      {
+			        "id":"2",
+			        "hostname":"_acme-challenge",
+			        "type":"TXT",
+			        "priority":"0",
+			        "destination":"bGVnbw==",
+			        "state":"yes"
+			      }
+			    ]
+			  }
+			}`
+		_, err = rw.Write([]byte(response))
+		if err != nil {
+			http.Error(rw, err.Error(), http.StatusInternalServerError)
+		}
+	})
+
+	record := DNSRecord{
+		Hostname:    "_acme-challenge",
+		RecordType:  "TXT",
+		Destination: "bGVnbw==",
+		TTL:         300,
+	}
+
+	expected := []DNSRecord{{
+		ID:          1,
+		Hostname:    "www",
+		RecordType:  "A",
+		Priority:    "0",
+		Destination: "127.0.0.1",
+		State:       "yes",
+	}, {
+		ID:          2,
+		Hostname:    "_acme-challenge",
+		RecordType:  "TXT",
+		Priority:    "0",
+		Destination: "bGVnbw==",
+		State:       "yes",
+	}}
+
+	records, err := client.UpdateDNSRecord("api-session-id", "example.com", []DNSRecord{record})
+	require.NoError(t, err)
+
+	assert.Equal(t, expected, records)
+}
+
 func TestClient_GetDNSRecords_errors(t *testing.T) {
 	testCases := []struct {
 		desc    string
@@ -584,7 +676,7 @@ func TestLiveClientUpdateDnsRecord(t *testing.T) {
 	// test
 	zone = dns01.UnFqdn(zone)
 
-	err = client.UpdateDNSRecord(sessionID, zone, []DNSRecord{record})
+	_, err = client.UpdateDNSRecord(sessionID, zone, []DNSRecord{record})
 	require.NoError(t, err)
 
 	records, err := client.GetDNSRecords(zone, sessionID)
@@ -601,7 +693,7 @@ func TestLiveClientUpdateDnsRecord(t *testing.T) {
 	records[recordIdx].DeleteRecord = true
 
 	// Tear down
-	err = client.UpdateDNSRecord(sessionID, envTest.GetDomain(), []DNSRecord{records[recordIdx]})
+	_, err = client.UpdateDNSRecord(sessionID, envTest.GetDomain(), []DNSRecord{records[recordIdx]})
 	require.NoError(t, err, "Did not remove record! Please do so yourself.")
 
 	err = client.Logout(sessionID)
@@ -6,11 +6,13 @@ import (
 	"fmt"
 	"net/http"
 	"strings"
+	"sync"
 	"time"
 
 	"github.com/go-acme/lego/v4/challenge/dns01"
 	"github.com/go-acme/lego/v4/log"
 	"github.com/go-acme/lego/v4/platform/config/env"
+	"github.com/go-acme/lego/v4/providers/dns/internal/useragent"
 	"github.com/go-acme/lego/v4/providers/dns/netcup/internal"
 )
 
@@ -45,9 +47,9 @@ func NewDefaultConfig() *Config {
 		TTL:                env.GetOrDefaultInt(EnvTTL, dns01.DefaultTTL),
 		PropagationTimeout: env.GetOrDefaultSecond(EnvPropagationTimeout, 120*time.Second),
 		PollingInterval:    env.GetOrDefaultSecond(EnvPollingInterval, 5*time.Second),
-		HTTPClient: &http.Client{
+		HTTPClient: useragent.Wrap(&http.Client{
 			Timeout: env.GetOrDefaultSecond(EnvHTTPTimeout, 10*time.Second),
-		},
+		}),
 	}
 }
 
@@ -55,6 +57,14 @@ func NewDefaultConfig() *Config {
 type DNSProvider struct {
 	client *internal.Client
 	config *Config
+
+	records   map[string]txtRecord
+	recordsMu sync.Mutex
+}
+
+type txtRecord struct {
+	zone   string
+	record internal.DNSRecord
 }
 
 // NewDNSProvider returns a DNSProvider instance configured for netcup.
@@ -87,7 +97,11 @@ func NewDNSProviderConfig(config *Config) (*DNSProvider, error) {
 
 	client.HTTPClient = config.HTTPClient
 
-	return &DNSProvider{client: client, config: config}, nil
+	return &DNSProvider{
+		client:  client,
+		config:  config,
+		records: make(map[string]txtRecord),
+	}, nil
 }
 
 // Present creates a TXT record to fulfill the dns-01 challenge.
@@ -129,21 +143,31 @@ func (d *DNSProvider) Present(domainName, token, keyAuth string) error {
 
 	records = append(records, record)
 
-	err = d.client.UpdateDNSRecord(sessionID, zone, records)
+	records, err = d.client.UpdateDNSRecord(sessionID, zone, records)
 	if err != nil {
 		return fmt.Errorf("netcup: failed to add TXT-Record: %w", err)
 	}
 
+	idx, err := internal.GetDNSRecordIdx(records, record)
+	if err != nil {
+		return fmt.Errorf("netcup: failed to find the created TXT-Record: %w", err)
+	}
+
+	d.recordsMu.Lock()
+	d.records[token] = txtRecord{zone: zone, record: records[idx]}
+	d.recordsMu.Unlock()
+
 	return nil
 }
 
 // CleanUp removes the TXT record matching the specified parameters.
 func (d *DNSProvider) CleanUp(domainName, token, keyAuth string) error {
-	fqdn, value := dns01.GetRecord(domainName, keyAuth)
+	d.recordsMu.Lock()
+	txt, ok := d.records[token]
+	d.recordsMu.Unlock()
 
-	zone, err := dns01.FindZoneByFqdn(fqdn)
-	if err != nil {
-		return fmt.Errorf("netcup: failed to find DNSZone, %w", err)
+	if !ok {
+		return fmt.Errorf("netcup: unknown record ID for '%s'", domainName)
 	}
 
 	sessionID, err := d.client.Login()
@@ -158,32 +182,17 @@ func (d *DNSProvider) CleanUp(domainName, token, keyAuth string) error {
 		}
 	}()
 
-	hostname := strings.Replace(fqdn, "."+zone, "", 1)
-
-	zone = dns01.UnFqdn(zone)
+	record := txt.record
+	record.DeleteRecord = true
 
-	records, err := d.client.GetDNSRecords(zone, sessionID)
+	_, err = d.client.UpdateDNSRecord(sessionID, txt.zone, []internal.DNSRecord{record})
 	if err != nil {
 		return fmt.Errorf("netcup: %w", err)
 	}
 
-	record := internal.DNSRecord{
-		Hostname:    hostname,
-		RecordType:  "TXT",
-		Destination: value,
-	}
-
-	idx, err := internal.GetDNSRecordIdx(records, record)
-	if err != nil {
-		return fmt.Errorf("netcup: %w", err)
-	}
-
-	records[idx].DeleteRecord = true
-
-	err = d.client.UpdateDNSRecord(sessionID, zone, []internal.DNSRecord{records[idx]})
-	if err != nil {
-		return fmt.Errorf("netcup: %w", err)
-	}
+	d.recordsMu.Lock()
+	delete(d.records, token)
+	d.recordsMu.Unlock()
 
 	return nil
 }
@@ -1,11 +1,16 @@
 package netcup
 
 import (
+	"encoding/json"
 	"fmt"
+	"net/http"
+	"net/http/httptest"
 	"testing"
 
 	"github.com/go-acme/lego/v4/challenge/dns01"
 	"github.com/go-acme/lego/v4/platform/tester"
+	"github.com/go-acme/lego/v4/providers/dns/netcup/internal"
+	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 )
 
@@ -152,6 +157,79 @@ func TestNewDNSProviderConfig(t *testing.T) {
 	}
 }
 
+func TestDNSProvider_CleanUp(t *testing.T) {
+	var updates []internal.DNSRecord
+
+	server := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		var payload struct {
+			Action string                           `json:"action"`
+			Param  internal.UpdateDNSRecordsRequest `json:"param"`
+		}
+
+		err := json.NewDecoder(req.Body).Decode(&payload)
+		if err != nil {
+			http.Error(rw, err.Error(), http.StatusBadRequest)
+			return
+		}
+
+		responseData := `{}`
+		switch payload.Action {
+		case "login":
+			responseData = `{"apisessionid":"api-session-id"}`
+		case "updateDnsRecords":
+			updates = append(updates, payload.Param.DNSRecordSet.DNSRecords...)
+			responseData = `{"dnsrecords":[]}`
+		}
+
+		_, _ = fmt.Fprintf(rw, `{"action":%q,"status":"success","statuscode":2000,"responsedata":%s}`, payload.Action, responseData)
+	}))
+	defer server.Close()
+
+	config := NewDefaultConfig()
+	config.Customer = "a"
+	config.Key = "b"
+	config.Password = "c"
+
+	provider, err := NewDNSProviderConfig(config)
+	require.NoError(t, err)
+
+	provider.client.BaseURL = server.URL
+
+	// the same value is used by the two records: only the record of the token must be deleted.
+	provider.records["tokenA"] = txtRecord{
+		zone:   "example.com",
+		record: internal.DNSRecord{ID: 1, Hostname: "_acme-challenge.a", RecordType: "TXT", Destination: "value"},
+	}
+	provider.records["tokenB"] = txtRecord{
+		zone:   "example.com",
+		record: internal.DNSRecord{ID: 2, Hostname: "_acme-challenge.b", RecordType: "TXT", Destination: "value"},
+	}
+
+	err = provider.CleanUp("a.example.com", "tokenA", "123d==")
+	require.NoError(t, err)
+
+	expected := []internal.DNSRecord{
+		{ID: 1, Hostname: "_acme-challenge.a", RecordType: "TXT", Destination: "value", DeleteRecord: true},
+	}
+	assert.Equal(t, expected, updates)
+
+	assert.NotContains(t, provider.records, "tokenA")
+	assert.Contains(t, provider.records, "tokenB")
+}
+
+func TestDNSProvider_CleanUp_unknownRecord(t *testing.T) {
+	config := NewDefaultConfig()
+	config.Customer = "a"
+	config.Key = "b"
+	config.Password = "c"
+
+	provider, err := NewDNSProviderConfig(config)
+	require.NoError(t, err)
+
+	err = provider.CleanUp("example.com", "token", "123d==")
+	require.EqualError(t, err, "netcup: unknown record ID for 'example.com'")
+}
+
 func TestLivePresentAndCleanup(t *testing.T) {
 	if !envTest.IsLiveTest() {
 		t.Skip("skipping live test")
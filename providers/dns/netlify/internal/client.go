@@ -29,6 +29,46 @@ func NewClient(token string) *Client {
 	}
 }
 
+// GetDNSZones gets the DNS zones of the account.
+func (c *Client) GetDNSZones() ([]DNSZone, error) {
+	endpoint, err := c.createEndpoint("dns_zones")
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse endpoint: %w", err)
+	}
+
+	req, err := http.NewRequest(http.MethodGet, endpoint, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request: %w", err)
+	}
+
+	req.Header.Set("Accept", "application/json")
+	req.Header.Set("Authorization", fmt.Sprintf("Bearer %s", c.token))
+
+	resp, err := c.HTTPClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("API call failed: %w", err)
+	}
+
+	defer func() { _ = resp.Body.Close() }()
+
+	body, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read response body: %w", err)
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("invalid status code: %s: %s", resp.Status, string(body))
+	}
+
+	var zones []DNSZone
+	err = json.Unmarshal(body, &zones)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal response body: %w", err)
+	}
+
+	return zones, nil
+}
+
 // GetRecords gets a DNS records.
 func (c *Client) GetRecords(zoneID string) ([]DNSRecord, error) {
 	endpoint, err := c.createEndpoint("dns_zones", zoneID, "dns_records")
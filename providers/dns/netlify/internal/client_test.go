@@ -12,6 +12,53 @@ import (
 	"github.com/stretchr/testify/require"
 )
 
+func TestClient_GetDNSZones(t *testing.T) {
+	mux := http.NewServeMux()
+	server := httptest.NewServer(mux)
+	t.Cleanup(server.Close)
+
+	mux.HandleFunc("/dns_zones", func(rw http.ResponseWriter, req *http.Request) {
+		if req.Method != http.MethodGet {
+			http.Error(rw, "unsupported method", http.StatusMethodNotAllowed)
+			return
+		}
+
+		auth := req.Header.Get("Authorization")
+		if auth != "Bearer tokenA" {
+			http.Error(rw, fmt.Sprintf("invali token: %s", auth), http.StatusUnauthorized)
+			return
+		}
+
+		rw.Header().Set("Content-Type", "application/json; charset=utf-8")
+
+		file, err := os.Open("./fixtures/get_dns_zones.json")
+		if err != nil {
+			http.Error(rw, err.Error(), http.StatusInternalServerError)
+			return
+		}
+		defer func() { _ = file.Close() }()
+
+		_, err = io.Copy(rw, file)
+		if err != nil {
+			http.Error(rw, err.Error(), http.StatusInternalServerError)
+			return
+		}
+	})
+
+	client := NewClient("tokenA")
+	client.BaseURL = server.URL
+
+	zones, err := client.GetDNSZones()
+	require.NoError(t, err)
+
+	expected := []DNSZone{
+		{ID: "u6b4336178f002e0a06bb0b6", Name: "example.org"},
+		{ID: "u6b4a7b9c4e2f6a0d3f1c8e2", Name: "sub.example.org"},
+	}
+
+	assert.Equal(t, expected, zones)
+}
+
 func TestClient_GetRecords(t *testing.T) {
 	mux := http.NewServeMux()
 	server := httptest.NewServer(mux)
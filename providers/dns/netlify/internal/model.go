@@ -8,3 +8,9 @@ type DNSRecord struct {
 	Type     string `json:"type,omitempty"`
 	Value    string `json:"value,omitempty"`
 }
+
+// DNSZone DNS zone representation.
+type DNSZone struct {
+	ID   string `json:"id,omitempty"`
+	Name string `json:"name,omitempty"`
+}
@@ -5,12 +5,12 @@ import (
 	"errors"
 	"fmt"
 	"net/http"
-	"strings"
 	"sync"
 	"time"
 
 	"github.com/go-acme/lego/v4/challenge/dns01"
 	"github.com/go-acme/lego/v4/platform/config/env"
+	"github.com/go-acme/lego/v4/providers/dns/internal/useragent"
 	"github.com/go-acme/lego/v4/providers/dns/netlify/internal"
 )
 
@@ -41,9 +41,9 @@ func NewDefaultConfig() *Config {
 		TTL:                env.GetOrDefaultInt(EnvTTL, 300),
 		PropagationTimeout: env.GetOrDefaultSecond(EnvPropagationTimeout, dns01.DefaultPropagationTimeout),
 		PollingInterval:    env.GetOrDefaultSecond(EnvPollingInterval, dns01.DefaultPollingInterval),
-		HTTPClient: &http.Client{
+		HTTPClient: useragent.Wrap(&http.Client{
 			Timeout: env.GetOrDefaultSecond(EnvHTTPTimeout, 30*time.Second),
-		},
+		}),
 	}
 }
 
@@ -103,13 +103,11 @@ func (d *DNSProvider) Timeout() (timeout, interval time.Duration) {
 func (d *DNSProvider) Present(domain, token, keyAuth string) error {
 	fqdn, value := dns01.GetRecord(domain, keyAuth)
 
-	authZone, err := dns01.FindZoneByFqdn(fqdn)
+	zone, err := d.findZone(fqdn)
 	if err != nil {
 		return fmt.Errorf("netlify: failed to find zone: %w", err)
 	}
 
-	authZone = dns01.UnFqdn(authZone)
-
 	record := internal.DNSRecord{
 		Hostname: dns01.UnFqdn(fqdn),
 		TTL:      d.config.TTL,
@@ -117,9 +115,9 @@ func (d *DNSProvider) Present(domain, token, keyAuth string) error {
 		Value:    value,
 	}
 
-	resp, err := d.client.CreateRecord(strings.ReplaceAll(authZone, ".", "_"), record)
+	resp, err := d.client.CreateRecord(zone.ID, record)
 	if err != nil {
-		return fmt.Errorf("netlify: failed to create TXT records: fqdn=%s, authZone=%s: %w", fqdn, authZone, err)
+		return fmt.Errorf("netlify: failed to create TXT records: fqdn=%s, authZone=%s: %w", fqdn, zone.Name, err)
 	}
 
 	d.recordIDsMu.Lock()
@@ -133,13 +131,11 @@ func (d *DNSProvider) Present(domain, token, keyAuth string) error {
 func (d *DNSProvider) CleanUp(domain, token, keyAuth string) error {
 	fqdn, _ := dns01.GetRecord(domain, keyAuth)
 
-	authZone, err := dns01.FindZoneByFqdn(fqdn)
+	zone, err := d.findZone(fqdn)
 	if err != nil {
 		return fmt.Errorf("netlify: failed to find zone: %w", err)
 	}
 
-	authZone = dns01.UnFqdn(authZone)
-
 	// gets the record's unique ID from when we created it
 	d.recordIDsMu.Lock()
 	recordID, ok := d.recordIDs[token]
@@ -148,9 +144,9 @@ func (d *DNSProvider) CleanUp(domain, token, keyAuth string) error {
 		return fmt.Errorf("netlify: unknown record ID for '%s' '%s'", fqdn, token)
 	}
 
-	err = d.client.RemoveRecord(strings.ReplaceAll(authZone, ".", "_"), recordID)
+	err = d.client.RemoveRecord(zone.ID, recordID)
 	if err != nil {
-		return fmt.Errorf("netlify: failed to delete TXT records: fqdn=%s, authZone=%s, recordID=%s: %w", fqdn, authZone, recordID, err)
+		return fmt.Errorf("netlify: failed to delete TXT records: fqdn=%s, authZone=%s, recordID=%s: %w", fqdn, zone.Name, recordID, err)
 	}
 
 	// deletes record ID from map
@@ -160,3 +156,44 @@ func (d *DNSProvider) CleanUp(domain, token, keyAuth string) error {
 
 	return nil
 }
+
+// Zones returns the DNS zones of the account.
+func (d *DNSProvider) Zones() ([]string, error) {
+	dnsZones, err := d.client.GetDNSZones()
+	if err != nil {
+		return nil, fmt.Errorf("netlify: failed to get DNS zones: %w", err)
+	}
+
+	var zones []string
+	for _, zone := range dnsZones {
+		zones = append(zones, zone.Name)
+	}
+
+	return zones, nil
+}
+
+// findZone finds the most specific zone of the account containing the fqdn.
+func (d *DNSProvider) findZone(fqdn string) (*internal.DNSZone, error) {
+	dnsZones, err := d.client.GetDNSZones()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get DNS zones: %w", err)
+	}
+
+	var names []string
+	for _, zone := range dnsZones {
+		names = append(names, zone.Name)
+	}
+
+	name := dns01.FindMostSpecificZone(fqdn, names)
+	if name == "" {
+		return nil, fmt.Errorf("no DNS zone found for %s", dns01.UnFqdn(fqdn))
+	}
+
+	for _, zone := range dnsZones {
+		if zone.Name == name {
+			return &zone, nil
+		}
+	}
+
+	return nil, fmt.Errorf("no DNS zone found for %s", dns01.UnFqdn(fqdn))
+}
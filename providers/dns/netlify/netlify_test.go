@@ -1,10 +1,16 @@
 package netlify
 
 import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
 	"testing"
 	"time"
 
 	"github.com/go-acme/lego/v4/platform/tester"
+	"github.com/go-acme/lego/v4/providers/dns/netlify/internal"
+	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 )
 
@@ -87,6 +93,103 @@ func TestNewDNSProviderConfig(t *testing.T) {
 	}
 }
 
+func setupTest(t *testing.T) (*DNSProvider, *http.ServeMux) {
+	t.Helper()
+
+	mux := http.NewServeMux()
+	server := httptest.NewServer(mux)
+	t.Cleanup(server.Close)
+
+	mux.HandleFunc("/dns_zones", func(rw http.ResponseWriter, req *http.Request) {
+		_, _ = fmt.Fprint(rw, `[{"id":"zoneA","name":"example.com"},{"id":"zoneB","name":"sub.example.com"}]`)
+	})
+
+	config := NewDefaultConfig()
+	config.Token = "secret"
+	config.TTL = 120
+
+	provider, err := NewDNSProviderConfig(config)
+	require.NoError(t, err)
+
+	provider.client.BaseURL = server.URL
+
+	return provider, mux
+}
+
+func TestDNSProvider_Present(t *testing.T) {
+	provider, mux := setupTest(t)
+
+	mux.HandleFunc("/dns_zones/zoneB/dns_records", func(rw http.ResponseWriter, req *http.Request) {
+		if req.Method != http.MethodPost {
+			http.Error(rw, fmt.Sprintf("unsupported method: %s", req.Method), http.StatusMethodNotAllowed)
+			return
+		}
+
+		record := internal.DNSRecord{}
+		err := json.NewDecoder(req.Body).Decode(&record)
+		if err != nil {
+			http.Error(rw, err.Error(), http.StatusBadRequest)
+			return
+		}
+
+		expected := internal.DNSRecord{
+			Hostname: "_acme-challenge.www.sub.example.com",
+			TTL:      120,
+			Type:     "TXT",
+			Value:    "ADw2sEd82DUgXcQ9hNBZThJs7zVJkR5v9JeSbAb9mZY",
+		}
+		assert.Equal(t, expected, record)
+
+		rw.WriteHeader(http.StatusCreated)
+		_, _ = fmt.Fprint(rw, `{"id":"recordA","hostname":"_acme-challenge.www.sub.example.com","type":"TXT","ttl":120}`)
+	})
+
+	err := provider.Present("www.sub.example.com", "token", "123d==")
+	require.NoError(t, err)
+
+	assert.Equal(t, map[string]string{"token": "recordA"}, provider.recordIDs)
+}
+
+func TestDNSProvider_Present_unknownZone(t *testing.T) {
+	provider, _ := setupTest(t)
+
+	err := provider.Present("www.example.org", "token", "123d==")
+	require.EqualError(t, err, "netlify: failed to find zone: no DNS zone found for _acme-challenge.www.example.org")
+}
+
+func TestDNSProvider_CleanUp(t *testing.T) {
+	provider, mux := setupTest(t)
+
+	var deleted bool
+	mux.HandleFunc("/dns_zones/zoneA/dns_records/recordA", func(rw http.ResponseWriter, req *http.Request) {
+		if req.Method != http.MethodDelete {
+			http.Error(rw, fmt.Sprintf("unsupported method: %s", req.Method), http.StatusMethodNotAllowed)
+			return
+		}
+
+		deleted = true
+
+		rw.WriteHeader(http.StatusNoContent)
+	})
+
+	provider.recordIDs["token"] = "recordA"
+
+	err := provider.CleanUp("www.example.com", "token", "123d==")
+	require.NoError(t, err)
+
+	assert.True(t, deleted)
+	assert.Empty(t, provider.recordIDs)
+}
+
+func TestDNSProvider_Zones(t *testing.T) {
+	provider, _ := setupTest(t)
+
+	zones, err := provider.Zones()
+	require.NoError(t, err)
+
+	assert.Equal(t, []string{"example.com", "sub.example.com"}, zones)
+}
+
 func TestLivePresent(t *testing.T) {
 	if !envTest.IsLiveTest() {
 		t.Skip("skipping live test")
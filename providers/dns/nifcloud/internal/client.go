@@ -87,6 +87,16 @@ type ChangeInfo struct {
 	SubmittedAt string `xml:"SubmittedAt"`
 }
 
+// BaseURLForRegion returns the endpoint of the DNS API of a region.
+// The default endpoint is used when the region is empty.
+func BaseURLForRegion(region string) string {
+	if region == "" {
+		return defaultBaseURL
+	}
+
+	return fmt.Sprintf("https://%s.dns.api.nifcloud.com", region)
+}
+
 // NewClient Creates a new client of NIFCLOUD DNS.
 func NewClient(accessKey, secretKey string) (*Client, error) {
 	if len(accessKey) == 0 || len(secretKey) == 0 {
@@ -174,3 +174,27 @@ func TestGetChangeErrors(t *testing.T) {
 		})
 	}
 }
+
+func TestBaseURLForRegion(t *testing.T) {
+	assert.Equal(t, "https://dns.api.nifcloud.com", BaseURLForRegion(""))
+	assert.Equal(t, "https://jp-west-1.dns.api.nifcloud.com", BaseURLForRegion("jp-west-1"))
+}
+
+func TestClient_sign(t *testing.T) {
+	client, err := NewClient("A", "B")
+	require.NoError(t, err)
+
+	// the signature only depends on the date: it is the same for all the regional endpoints.
+	for _, region := range []string{"", "jp-east-1", "jp-west-1"} {
+		req, err := http.NewRequest(http.MethodGet, BaseURLForRegion(region)+"/2012-12-12N2013-12-16/change/xxxxx", nil)
+		require.NoError(t, err)
+
+		req.Header.Set("Date", "Mon, 02 Jan 2006 15:04:05 GMT")
+
+		err = client.sign(req)
+		require.NoError(t, err)
+
+		assert.Equal(t, "NIFTY3-HTTPS NiftyAccessKeyId=A,Algorithm=HmacSHA1,Signature=aHwTC7fwAfaorZXkgvs2IGZs18Q=",
+			req.Header.Get("X-Nifty-Authorization"), region)
+	}
+}
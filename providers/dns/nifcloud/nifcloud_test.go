@@ -5,6 +5,7 @@ import (
 	"time"
 
 	"github.com/go-acme/lego/v4/platform/tester"
+	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 )
 
@@ -12,7 +13,9 @@ const envDomain = envNamespace + "DOMAIN"
 
 var envTest = tester.NewEnvTest(
 	EnvAccessKeyID,
-	EnvSecretAccessKey).
+	EnvSecretAccessKey,
+	EnvDNSEndpoint,
+	EnvRegion).
 	WithDomain(envDomain)
 
 func TestNewDNSProvider(t *testing.T) {
@@ -123,6 +126,59 @@ func TestNewDNSProviderConfig(t *testing.T) {
 	}
 }
 
+func TestNewDNSProvider_endpoint(t *testing.T) {
+	testCases := []struct {
+		desc     string
+		envVars  map[string]string
+		expected string
+	}{
+		{
+			desc:     "default",
+			expected: "https://dns.api.nifcloud.com",
+		},
+		{
+			desc: "region",
+			envVars: map[string]string{
+				EnvRegion: "jp-west-1",
+			},
+			expected: "https://jp-west-1.dns.api.nifcloud.com",
+		},
+		{
+			desc: "endpoint",
+			envVars: map[string]string{
+				EnvDNSEndpoint: "https://dns.example.com",
+			},
+			expected: "https://dns.example.com",
+		},
+		{
+			desc: "endpoint and region",
+			envVars: map[string]string{
+				EnvDNSEndpoint: "https://dns.example.com",
+				EnvRegion:      "jp-west-1",
+			},
+			expected: "https://dns.example.com",
+		},
+	}
+
+	for _, test := range testCases {
+		t.Run(test.desc, func(t *testing.T) {
+			defer envTest.RestoreEnv()
+			envTest.ClearEnv()
+
+			envTest.Apply(map[string]string{
+				EnvAccessKeyID:     "123",
+				EnvSecretAccessKey: "456",
+			})
+			envTest.Apply(test.envVars)
+
+			p, err := NewDNSProvider()
+			require.NoError(t, err)
+
+			assert.Equal(t, test.expected, p.client.BaseURL)
+		})
+	}
+}
+
 func TestLivePresent(t *testing.T) {
 	if !envTest.IsLiveTest() {
 		t.Skip("skipping live test")
@@ -6,11 +6,13 @@ import (
 	"fmt"
 	"net/http"
 	"strings"
+	"sync"
 	"time"
 
 	"github.com/go-acme/lego/v4/challenge/dns01"
 	"github.com/go-acme/lego/v4/log"
 	"github.com/go-acme/lego/v4/platform/config/env"
+	"github.com/go-acme/lego/v4/providers/dns/internal/useragent"
 	"gopkg.in/ns1/ns1-go.v2/rest"
 	"gopkg.in/ns1/ns1-go.v2/rest/model/dns"
 )
@@ -42,9 +44,9 @@ func NewDefaultConfig() *Config {
 		TTL:                env.GetOrDefaultInt(EnvTTL, dns01.DefaultTTL),
 		PropagationTimeout: env.GetOrDefaultSecond(EnvPropagationTimeout, dns01.DefaultPropagationTimeout),
 		PollingInterval:    env.GetOrDefaultSecond(EnvPollingInterval, dns01.DefaultPollingInterval),
-		HTTPClient: &http.Client{
+		HTTPClient: useragent.Wrap(&http.Client{
 			Timeout: env.GetOrDefaultSecond(EnvHTTPTimeout, 10*time.Second),
-		},
+		}),
 	}
 }
 
@@ -52,6 +54,12 @@ func NewDefaultConfig() *Config {
 type DNSProvider struct {
 	client *rest.Client
 	config *Config
+
+	// the zones of the records, used for the propagation check.
+	zones   map[string]*dns.Zone
+	zonesMu sync.Mutex
+
+	tracker *dns01.RecordTracker
 }
 
 // NewDNSProvider returns a DNSProvider instance configured for NS1.
@@ -80,18 +88,37 @@ func NewDNSProviderConfig(config *Config) (*DNSProvider, error) {
 
 	client := rest.NewClient(config.HTTPClient, rest.SetAPIKey(config.APIKey))
 
-	return &DNSProvider{client: client, config: config}, nil
+	return &DNSProvider{
+		client:  client,
+		config:  config,
+		zones:   make(map[string]*dns.Zone),
+		tracker: dns01.NewRecordTracker(),
+	}, nil
 }
 
 // Present creates a TXT record to fulfill the dns-01 challenge.
 func (d *DNSProvider) Present(domain, token, keyAuth string) error {
+	_, err := d.PresentRecord(domain, token, keyAuth)
+	return err
+}
+
+// PresentRecord creates a TXT record to fulfill the dns-01 challenge,
+// and returns a handle allowing to clean up the record later with CleanUpByHandle.
+func (d *DNSProvider) PresentRecord(domain, token, keyAuth string) (*dns01.RecordHandle, error) {
 	fqdn, value := dns01.GetRecord(domain, keyAuth)
 
 	zone, err := d.getHostedZone(fqdn)
 	if err != nil {
-		return fmt.Errorf("ns1: %w", err)
+		d.tracker.NotWritten(domain, token)
+		return nil, fmt.Errorf("ns1: %w", err)
 	}
 
+	d.zonesMu.Lock()
+	d.zones[fqdn] = zone
+	d.zonesMu.Unlock()
+
+	handle := &dns01.RecordHandle{Zone: zone.Zone, Name: fqdn, Value: value}
+
 	record, _, err := d.client.Records.Get(zone.Zone, dns01.UnFqdn(fqdn), "TXT")
 
 	// Create a new record
@@ -100,37 +127,62 @@ func (d *DNSProvider) Present(domain, token, keyAuth string) error {
 
 		record = dns.NewRecord(zone.Zone, dns01.UnFqdn(fqdn), "TXT")
 		record.TTL = d.config.TTL
-		record.Answers = []*dns.Answer{{Rdata: []string{value}}}
+		record.Answers = []*dns.Answer{{Rdata: dns01.FormatTXTValueFor(d, value)}}
+
+		d.tracker.Written(domain, token)
 
 		_, err = d.client.Records.Create(record)
 		if err != nil {
-			return fmt.Errorf("ns1: failed to create record [zone: %q, fqdn: %q]: %w", zone.Zone, fqdn, err)
+			return nil, fmt.Errorf("ns1: failed to create record [zone: %q, fqdn: %q]: %w", zone.Zone, fqdn, err)
 		}
 
-		return nil
+		handle.RecordID = record.ID
+
+		return handle, nil
 	}
 
 	if err != nil {
-		return fmt.Errorf("ns1: failed to get the existing record: %w", err)
+		d.tracker.NotWritten(domain, token)
+		return nil, fmt.Errorf("ns1: failed to get the existing record: %w", err)
 	}
 
-	// Update the existing records
-	record.Answers = append(record.Answers, &dns.Answer{Rdata: []string{value}})
+	d.tracker.Written(domain, token)
 
-	log.Infof("Update an existing record for [zone: %s, fqdn: %s, domain: %s]", zone.Zone, fqdn, domain)
+	// Update the existing records, unless the value has already been added (e.g. by a previous run).
+	err = dns01.PresentIfAbsent(answerValues(record), value, func() error {
+		record.Answers = append(record.Answers, &dns.Answer{Rdata: dns01.FormatTXTValueFor(d, value)})
 
-	_, err = d.client.Records.Update(record)
+		log.Infof("Update an existing record for [zone: %s, fqdn: %s, domain: %s]", zone.Zone, fqdn, domain)
+
+		_, err = d.client.Records.Update(record)
+		if err != nil {
+			return fmt.Errorf("ns1: failed to update record [zone: %q, fqdn: %q]: %w", zone.Zone, fqdn, err)
+		}
+
+		return nil
+	})
 	if err != nil {
-		return fmt.Errorf("ns1: failed to update record [zone: %q, fqdn: %q]: %w", zone.Zone, fqdn, err)
+		return nil, err
 	}
 
-	return nil
+	handle.RecordID = record.ID
+
+	return handle, nil
 }
 
 // CleanUp removes the TXT record matching the specified parameters.
 func (d *DNSProvider) CleanUp(domain, token, keyAuth string) error {
 	fqdn, _ := dns01.GetRecord(domain, keyAuth)
 
+	d.zonesMu.Lock()
+	delete(d.zones, fqdn)
+	d.zonesMu.Unlock()
+
+	// Present failed before writing the record: nothing to delete.
+	if d.tracker.SkipCleanUp(domain, token) {
+		return nil
+	}
+
 	zone, err := d.getHostedZone(fqdn)
 	if err != nil {
 		return fmt.Errorf("ns1: %w", err)
@@ -138,12 +190,145 @@ func (d *DNSProvider) CleanUp(domain, token, keyAuth string) error {
 
 	name := dns01.UnFqdn(fqdn)
 	_, err = d.client.Records.Delete(zone.Zone, name, "TXT")
+	if err == rest.ErrRecordMissing {
+		// already removed, or never created (ex: the write request of Present failed).
+		return nil
+	}
+
 	if err != nil {
 		return fmt.Errorf("ns1: failed to delete record [zone: %q, domain: %q]: %w", zone.Zone, name, err)
 	}
 	return nil
 }
 
+// CleanUpByHandle removes the value described by the handle from the TXT record.
+// The record is deleted when it doesn't contain any other value.
+// It doesn't rely on the state of the provider: the handle can come from another instance, or another process.
+func (d *DNSProvider) CleanUpByHandle(handle dns01.RecordHandle) error {
+	name := dns01.UnFqdn(handle.Name)
+
+	record, _, err := d.client.Records.Get(handle.Zone, name, "TXT")
+	if err == rest.ErrRecordMissing {
+		// already removed.
+		return nil
+	}
+
+	if err != nil {
+		return fmt.Errorf("ns1: failed to get the record [zone: %q, fqdn: %q]: %w", handle.Zone, handle.Name, err)
+	}
+
+	var answers []*dns.Answer
+	for _, answer := range record.Answers {
+		if strings.Trim(strings.Join(answer.Rdata, ""), `"`) != handle.Value {
+			answers = append(answers, answer)
+		}
+	}
+
+	if len(answers) == len(record.Answers) {
+		// the value has already been removed.
+		return nil
+	}
+
+	if len(answers) == 0 {
+		_, err = d.client.Records.Delete(handle.Zone, name, "TXT")
+		if err != nil {
+			return fmt.Errorf("ns1: failed to delete record [zone: %q, domain: %q]: %w", handle.Zone, name, err)
+		}
+
+		return nil
+	}
+
+	record.Answers = answers
+
+	_, err = d.client.Records.Update(record)
+	if err != nil {
+		return fmt.Errorf("ns1: failed to update record [zone: %q, fqdn: %q]: %w", handle.Zone, handle.Name, err)
+	}
+
+	return nil
+}
+
+// TXTStringFormat returns the format of the TXT value expected by the API:
+// the value is sent as one string.
+func (d *DNSProvider) TXTStringFormat() dns01.TXTStringFormat {
+	return dns01.TXTSingleString
+}
+
+// Nameservers returns the nameservers of the zone where the TXT record has been created.
+// They are used to check the propagation directly on the authoritative nameservers.
+func (d *DNSProvider) Nameservers(fqdn string) []string {
+	d.zonesMu.Lock()
+	defer d.zonesMu.Unlock()
+
+	zone, ok := d.zones[fqdn]
+	if !ok {
+		return nil
+	}
+
+	return zone.DNSServers
+}
+
+// ZoneNameservers returns the nameservers of the zone, as defined by NS1.
+// They are used to verify that the zone is delegated to NS1.
+func (d *DNSProvider) ZoneNameservers(zone string) ([]string, error) {
+	z, _, err := d.client.Zones.Get(zone)
+	if err != nil {
+		return nil, fmt.Errorf("ns1: failed to get zone %q: %w", zone, err)
+	}
+
+	return z.DNSServers, nil
+}
+
+// PropagationStatus checks through the API that the TXT record contains the value.
+// It is checked before the queries of the nameservers of the zone (see Nameservers).
+func (d *DNSProvider) PropagationStatus(fqdn, value string) (bool, error) {
+	values, err := d.StoredValues(fqdn)
+	if err != nil {
+		return false, err
+	}
+
+	for _, v := range values {
+		if v == value {
+			return true, nil
+		}
+	}
+
+	return false, nil
+}
+
+// StoredValues returns the values of the TXT record, as stored by the API.
+// It is used to verify that the value has been stored without modification.
+func (d *DNSProvider) StoredValues(fqdn string) ([]string, error) {
+	d.zonesMu.Lock()
+	zone, ok := d.zones[fqdn]
+	d.zonesMu.Unlock()
+
+	if !ok {
+		return nil, fmt.Errorf("ns1: unknown zone for '%s'", fqdn)
+	}
+
+	record, _, err := d.client.Records.Get(zone.Zone, dns01.UnFqdn(fqdn), "TXT")
+	if err == rest.ErrRecordMissing {
+		return nil, nil
+	}
+
+	if err != nil {
+		return nil, fmt.Errorf("ns1: failed to get the record [zone: %q, fqdn: %q]: %w", zone.Zone, fqdn, err)
+	}
+
+	return answerValues(record), nil
+}
+
+// Reset drops the per-challenge state (zones and failed records) left by the previous certificates.
+// It allows to reuse the provider, and its client, across many certificates.
+func (d *DNSProvider) Reset() {
+	d.zonesMu.Lock()
+	d.zones = make(map[string]*dns.Zone)
+	d.zonesMu.Unlock()
+
+	d.tracker.Reset()
+}
+
 // Timeout returns the timeout and interval to use when checking for DNS propagation.
 // Adjusting here to cope with spikes in propagation times.
 func (d *DNSProvider) Timeout() (timeout, interval time.Duration) {
@@ -151,7 +336,7 @@ func (d *DNSProvider) Timeout() (timeout, interval time.Duration) {
 }
 
 func (d *DNSProvider) getHostedZone(fqdn string) (*dns.Zone, error) {
-	authZone, err := getAuthZone(fqdn)
+	authZone, err := d.findHostedZone(fqdn)
 	if err != nil {
 		return nil, fmt.Errorf("failed to extract auth zone from fqdn %q: %w", fqdn, err)
 	}
@@ -164,6 +349,28 @@ func (d *DNSProvider) getHostedZone(fqdn string) (*dns.Zone, error) {
 	return zone, nil
 }
 
+// findHostedZone finds the most specific zone of the account for the fqdn.
+// Falls back to the zone found through DNS if the zones cannot be listed.
+func (d *DNSProvider) findHostedZone(fqdn string) (string, error) {
+	zones, _, err := d.client.Zones.List()
+	if err != nil {
+		log.Infof("ns1: unable to list the zones, falling back to DNS: %v", err)
+		return getAuthZone(fqdn)
+	}
+
+	var names []string
+	for _, zone := range zones {
+		names = append(names, zone.Zone)
+	}
+
+	authZone := dns01.FindMostSpecificZone(fqdn, names)
+	if authZone == "" {
+		return "", fmt.Errorf("no hosted zone found for %s", fqdn)
+	}
+
+	return authZone, nil
+}
+
 func getAuthZone(fqdn string) (string, error) {
 	authZone, err := dns01.FindZoneByFqdn(fqdn)
 	if err != nil {
@@ -172,3 +379,13 @@ func getAuthZone(fqdn string) (string, error) {
 
 	return strings.TrimSuffix(authZone, "."), nil
 }
+
+// answerValues returns the values of the answers of the record.
+func answerValues(record *dns.Record) []string {
+	var values []string
+	for _, answer := range record.Answers {
+		values = append(values, strings.Join(answer.Rdata, ""))
+	}
+
+	return values
+}
@@ -1,12 +1,28 @@
 package ns1
 
 import (
+	"crypto/rand"
+	"crypto/rsa"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"sync"
 	"testing"
 	"time"
 
+	"github.com/go-acme/lego/v4/acme"
+	"github.com/go-acme/lego/v4/acme/api"
+	"github.com/go-acme/lego/v4/challenge"
+	"github.com/go-acme/lego/v4/challenge/dns01"
 	"github.com/go-acme/lego/v4/platform/tester"
+	miekgdns "github.com/miekg/dns"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+	"gopkg.in/ns1/ns1-go.v2/rest"
+	"gopkg.in/ns1/ns1-go.v2/rest/model/dns"
 )
 
 const envDomain = envNamespace + "DOMAIN"
@@ -142,6 +158,306 @@ func Test_getAuthZone(t *testing.T) {
 	}
 }
 
+func TestDNSProvider_Nameservers(t *testing.T) {
+	config := NewDefaultConfig()
+	config.APIKey = "123"
+
+	p, err := NewDNSProviderConfig(config)
+	require.NoError(t, err)
+
+	var provider dns01.NameserversProvider = p
+
+	assert.Empty(t, provider.Nameservers("_acme-challenge.example.com."))
+
+	// filled by Present with the NS of the zone returned by the API.
+	p.zones["_acme-challenge.example.com."] = &dns.Zone{Zone: "example.com", DNSServers: []string{"dns1.p01.nsone.net", "dns2.p01.nsone.net"}}
+
+	assert.Equal(t, []string{"dns1.p01.nsone.net", "dns2.p01.nsone.net"}, provider.Nameservers("_acme-challenge.example.com."))
+}
+
+func setupTest(t *testing.T) (*DNSProvider, *http.ServeMux) {
+	t.Helper()
+
+	mux := http.NewServeMux()
+	server := httptest.NewServer(mux)
+	t.Cleanup(server.Close)
+
+	// nested hosted zones.
+	mux.HandleFunc("/v1/zones", func(rw http.ResponseWriter, req *http.Request) {
+		_, _ = fmt.Fprint(rw, `[{"zone":"example.com"},{"zone":"internal.example.com"}]`)
+	})
+
+	mux.HandleFunc("/v1/zones/example.com", func(rw http.ResponseWriter, req *http.Request) {
+		_, _ = fmt.Fprint(rw, `{"zone":"example.com","dns_servers":["dns1.p01.nsone.net","dns2.p01.nsone.net"]}`)
+	})
+
+	mux.HandleFunc("/v1/zones/internal.example.com", func(rw http.ResponseWriter, req *http.Request) {
+		_, _ = fmt.Fprint(rw, `{"zone":"internal.example.com","dns_servers":["dns1.p02.nsone.net","dns2.p02.nsone.net"]}`)
+	})
+
+	config := NewDefaultConfig()
+	config.APIKey = "secret"
+
+	provider, err := NewDNSProviderConfig(config)
+	require.NoError(t, err)
+
+	provider.client = rest.NewClient(config.HTTPClient, rest.SetAPIKey(config.APIKey), rest.SetEndpoint(server.URL+"/v1/"))
+
+	return provider, mux
+}
+
+func TestDNSProvider_getHostedZone(t *testing.T) {
+	testCases := []struct {
+		desc     string
+		fqdn     string
+		expected string
+	}{
+		{
+			desc:     "parent zone",
+			fqdn:     "_acme-challenge.www.example.com.",
+			expected: "example.com",
+		},
+		{
+			desc:     "sub-zone",
+			fqdn:     "_acme-challenge.www.internal.example.com.",
+			expected: "internal.example.com",
+		},
+		{
+			desc:     "sub-zone apex",
+			fqdn:     "_acme-challenge.internal.example.com.",
+			expected: "internal.example.com",
+		},
+	}
+
+	for _, test := range testCases {
+		t.Run(test.desc, func(t *testing.T) {
+			provider, _ := setupTest(t)
+
+			zone, err := provider.getHostedZone(test.fqdn)
+			require.NoError(t, err)
+
+			assert.Equal(t, test.expected, zone.Zone)
+		})
+	}
+}
+
+func TestDNSProvider_Present_nameservers(t *testing.T) {
+	provider, mux := setupTest(t)
+
+	mux.HandleFunc("/v1/zones/internal.example.com/_acme-challenge.www.internal.example.com/TXT", func(rw http.ResponseWriter, req *http.Request) {
+		switch req.Method {
+		case http.MethodGet:
+			rw.WriteHeader(http.StatusNotFound)
+			_, _ = fmt.Fprint(rw, `{"message":"record not found"}`)
+		case http.MethodPut:
+			_, _ = fmt.Fprint(rw, `{}`)
+		default:
+			http.Error(rw, "unsupported method", http.StatusMethodNotAllowed)
+		}
+	})
+
+	err := provider.Present("www.internal.example.com", "", "123d==")
+	require.NoError(t, err)
+
+	// the propagation check uses the NS of the zone.
+	expected := []string{"dns1.p02.nsone.net", "dns2.p02.nsone.net"}
+	assert.Equal(t, expected, provider.Nameservers("_acme-challenge.www.internal.example.com."))
+}
+
+func TestDNSProvider_Present_rerun(t *testing.T) {
+	provider, mux := setupTest(t)
+
+	// the record already contains the value of another challenge.
+	record := []byte(`{"zone":"example.com","domain":"_acme-challenge.www.example.com","type":"TXT","answers":[{"answer":["other"]}]}`)
+
+	var updates int
+	mux.HandleFunc("/v1/zones/example.com/_acme-challenge.www.example.com/TXT", func(rw http.ResponseWriter, req *http.Request) {
+		switch req.Method {
+		case http.MethodGet:
+			_, _ = rw.Write(record)
+		case http.MethodPost:
+			updates++
+
+			raw, err := ioutil.ReadAll(req.Body)
+			if err != nil {
+				http.Error(rw, err.Error(), http.StatusBadRequest)
+				return
+			}
+
+			record = raw
+			_, _ = rw.Write(record)
+		default:
+			http.Error(rw, "unsupported method", http.StatusMethodNotAllowed)
+		}
+	})
+
+	err := provider.Present("www.example.com", "", "123d==")
+	require.NoError(t, err)
+
+	// simulates a restart: Present is called again with the same value.
+	err = provider.Present("www.example.com", "", "123d==")
+	require.NoError(t, err)
+
+	assert.Equal(t, 1, updates)
+
+	var result dns.Record
+	err = json.Unmarshal(record, &result)
+	require.NoError(t, err)
+
+	expected := []*dns.Answer{
+		{Rdata: []string{"other"}},
+		{Rdata: []string{"ADw2sEd82DUgXcQ9hNBZThJs7zVJkR5v9JeSbAb9mZY"}},
+	}
+	assert.Equal(t, expected, result.Answers)
+}
+
+func TestDNSProvider_CleanUp_presentFailed(t *testing.T) {
+	provider, _ := setupTest(t)
+
+	// no hosted zone: Present fails before writing the record.
+	err := provider.Present("www.example.org", "token", "123d==")
+	require.Error(t, err)
+
+	// no API call: the cleanup would fail otherwise.
+	err = provider.CleanUp("www.example.org", "token", "123d==")
+	require.NoError(t, err)
+}
+
+func TestDNSProvider_CleanUp_partialPresent(t *testing.T) {
+	provider, mux := setupTest(t)
+
+	var deletes int
+	mux.HandleFunc("/v1/zones/example.com/_acme-challenge.www.example.com/TXT", func(rw http.ResponseWriter, req *http.Request) {
+		switch req.Method {
+		case http.MethodGet:
+			rw.WriteHeader(http.StatusNotFound)
+			_, _ = fmt.Fprint(rw, `{"message":"record not found"}`)
+		case http.MethodPut:
+			// the record can have been created.
+			http.Error(rw, `{"message":"internal error"}`, http.StatusInternalServerError)
+		case http.MethodDelete:
+			deletes++
+			_, _ = fmt.Fprint(rw, `{}`)
+		default:
+			http.Error(rw, "unsupported method", http.StatusMethodNotAllowed)
+		}
+	})
+
+	err := provider.Present("www.example.com", "token", "123d==")
+	require.Error(t, err)
+
+	err = provider.CleanUp("www.example.com", "token", "123d==")
+	require.NoError(t, err)
+
+	assert.Equal(t, 1, deletes)
+}
+
+func TestDNSProvider_CleanUp_restarted(t *testing.T) {
+	provider, mux := setupTest(t)
+
+	var deletes int
+	mux.HandleFunc("/v1/zones/example.com/_acme-challenge.www.example.com/TXT", func(rw http.ResponseWriter, req *http.Request) {
+		if req.Method != http.MethodDelete {
+			http.Error(rw, "unsupported method", http.StatusMethodNotAllowed)
+			return
+		}
+
+		deletes++
+
+		// already removed.
+		if deletes > 1 {
+			rw.WriteHeader(http.StatusNotFound)
+			_, _ = fmt.Fprint(rw, `{"message":"record not found"}`)
+			return
+		}
+
+		_, _ = fmt.Fprint(rw, `{}`)
+	})
+
+	// the record has been created by a previous process.
+	err := provider.CleanUp("www.example.com", "token", "123d==")
+	require.NoError(t, err)
+
+	err = provider.CleanUp("www.example.com", "token", "123d==")
+	require.NoError(t, err)
+
+	assert.Equal(t, 2, deletes)
+}
+
+func TestDNSProvider_CleanUp(t *testing.T) {
+	provider, mux := setupTest(t)
+
+	var deletes int
+	mux.HandleFunc("/v1/zones/example.com/_acme-challenge.www.example.com/TXT", func(rw http.ResponseWriter, req *http.Request) {
+		switch req.Method {
+		case http.MethodGet:
+			rw.WriteHeader(http.StatusNotFound)
+			_, _ = fmt.Fprint(rw, `{"message":"record not found"}`)
+		case http.MethodPut:
+			_, _ = fmt.Fprint(rw, `{}`)
+		case http.MethodDelete:
+			deletes++
+			_, _ = fmt.Fprint(rw, `{}`)
+		default:
+			http.Error(rw, "unsupported method", http.StatusMethodNotAllowed)
+		}
+	})
+
+	err := provider.Present("www.example.com", "token", "123d==")
+	require.NoError(t, err)
+
+	err = provider.CleanUp("www.example.com", "token", "123d==")
+	require.NoError(t, err)
+
+	assert.Equal(t, 1, deletes)
+}
+
+func TestDNSProvider_Reset(t *testing.T) {
+	provider, mux := setupTest(t)
+
+	deletes := map[string]int{}
+	for _, name := range []string{"_acme-challenge.www.example.com", "_acme-challenge.api.example.com"} {
+		name := name
+		mux.HandleFunc("/v1/zones/example.com/"+name+"/TXT", func(rw http.ResponseWriter, req *http.Request) {
+			switch req.Method {
+			case http.MethodGet:
+				rw.WriteHeader(http.StatusNotFound)
+				_, _ = fmt.Fprint(rw, `{"message":"record not found"}`)
+			case http.MethodPut:
+				_, _ = fmt.Fprint(rw, `{}`)
+			case http.MethodDelete:
+				deletes[name]++
+				_, _ = fmt.Fprint(rw, `{}`)
+			default:
+				http.Error(rw, "unsupported method", http.StatusMethodNotAllowed)
+			}
+		})
+	}
+
+	// first certificate: interrupted before the cleanup.
+	err := provider.Present("www.example.com", "token1", "123d==")
+	require.NoError(t, err)
+
+	provider.Reset()
+
+	assert.Empty(t, provider.Nameservers("_acme-challenge.www.example.com."))
+
+	// second certificate, with the same provider.
+	err = provider.Present("api.example.com", "token2", "456d==")
+	require.NoError(t, err)
+
+	assert.Equal(t, []string{"dns1.p01.nsone.net", "dns2.p01.nsone.net"}, provider.Nameservers("_acme-challenge.api.example.com."))
+
+	err = provider.CleanUp("api.example.com", "token2", "456d==")
+	require.NoError(t, err)
+
+	// the record of the first certificate is still removed.
+	err = provider.CleanUp("www.example.com", "token1", "123d==")
+	require.NoError(t, err)
+
+	assert.Equal(t, map[string]int{"_acme-challenge.api.example.com": 1, "_acme-challenge.www.example.com": 1}, deletes)
+}
+
 func TestLivePresent(t *testing.T) {
 	if !envTest.IsLiveTest() {
 		t.Skip("skipping live test")
@@ -169,3 +485,353 @@ func TestLiveCleanUp(t *testing.T) {
 	err = provider.CleanUp(envTest.GetDomain(), "", "123d==")
 	require.NoError(t, err)
 }
+
+func TestDNSProvider_PropagationStatus(t *testing.T) {
+	provider, mux := setupTest(t)
+
+	var gets int
+	mux.HandleFunc("/v1/zones/example.com/_acme-challenge.www.example.com/TXT", func(rw http.ResponseWriter, req *http.Request) {
+		gets++
+
+		// the record is created after the first check.
+		if gets == 1 {
+			rw.WriteHeader(http.StatusNotFound)
+			_, _ = fmt.Fprint(rw, `{"message":"record not found"}`)
+			return
+		}
+
+		_, _ = fmt.Fprint(rw, `{"zone":"example.com","domain":"_acme-challenge.www.example.com","type":"TXT","answers":[{"answer":["other"]},{"answer":["ADw2sEd82DUgXcQ9hNBZThJs7zVJkR5v9JeSbAb9mZY"]}]}`)
+	})
+
+	var status dns01.PropagationStatusProvider = provider
+
+	_, err := status.PropagationStatus("_acme-challenge.www.example.com.", "ADw2sEd82DUgXcQ9hNBZThJs7zVJkR5v9JeSbAb9mZY")
+	require.EqualError(t, err, "ns1: unknown zone for '_acme-challenge.www.example.com.'")
+
+	// filled by Present with the zone returned by the API.
+	provider.zones["_acme-challenge.www.example.com."] = &dns.Zone{Zone: "example.com"}
+
+	propagated, err := status.PropagationStatus("_acme-challenge.www.example.com.", "ADw2sEd82DUgXcQ9hNBZThJs7zVJkR5v9JeSbAb9mZY")
+	require.NoError(t, err)
+	assert.False(t, propagated)
+
+	propagated, err = status.PropagationStatus("_acme-challenge.www.example.com.", "ADw2sEd82DUgXcQ9hNBZThJs7zVJkR5v9JeSbAb9mZY")
+	require.NoError(t, err)
+	assert.True(t, propagated)
+
+	propagated, err = status.PropagationStatus("_acme-challenge.www.example.com.", "unknown")
+	require.NoError(t, err)
+	assert.False(t, propagated)
+}
+
+func TestChallenge_Solve_propagationStatusThenNameservers(t *testing.T) {
+	_, apiURL, tearDown := tester.SetupFakeAPI()
+	defer tearDown()
+
+	privateKey, err := rsa.GenerateKey(rand.Reader, 512)
+	require.NoError(t, err)
+
+	core, err := api.New(http.DefaultClient, "lego-test", apiURL+"/dir", "", privateKey)
+	require.NoError(t, err)
+
+	keyAuth, err := core.GetKeyAuthorization("token")
+	require.NoError(t, err)
+
+	fqdn, value := dns01.GetRecord("www.example.com", keyAuth)
+
+	var mu sync.Mutex
+	var events []string
+
+	addEvent := func(event string) {
+		mu.Lock()
+		events = append(events, event)
+		mu.Unlock()
+	}
+
+	// the authoritative nameserver of the zone.
+	pc, err := net.ListenPacket("udp", "127.0.0.1:0")
+	require.NoError(t, err)
+
+	server := &miekgdns.Server{
+		PacketConn: pc,
+		Handler: miekgdns.HandlerFunc(func(w miekgdns.ResponseWriter, req *miekgdns.Msg) {
+			addEvent("dns")
+
+			m := new(miekgdns.Msg)
+			m.SetReply(req)
+			m.Answer = append(m.Answer, &miekgdns.TXT{
+				Hdr: miekgdns.RR_Header{Name: req.Question[0].Name, Rrtype: miekgdns.TypeTXT, Class: miekgdns.ClassINET},
+				Txt: []string{value},
+			})
+
+			_ = w.WriteMsg(m)
+		}),
+	}
+
+	go func() { _ = server.ActivateAndServe() }()
+	t.Cleanup(func() { _ = server.Shutdown() })
+
+	mux := http.NewServeMux()
+	apiServer := httptest.NewServer(mux)
+	t.Cleanup(apiServer.Close)
+
+	mux.HandleFunc("/v1/zones", func(rw http.ResponseWriter, req *http.Request) {
+		_, _ = fmt.Fprint(rw, `[{"zone":"example.com"}]`)
+	})
+
+	mux.HandleFunc("/v1/zones/example.com", func(rw http.ResponseWriter, req *http.Request) {
+		_, _ = fmt.Fprintf(rw, `{"zone":"example.com","dns_servers":[%q]}`, pc.LocalAddr().String())
+	})
+
+	var gets int
+	mux.HandleFunc("/v1/zones/example.com/_acme-challenge.www.example.com/TXT", func(rw http.ResponseWriter, req *http.Request) {
+		if req.Method == http.MethodPut {
+			_, _ = fmt.Fprint(rw, `{}`)
+			return
+		}
+
+		mu.Lock()
+		gets++
+		current := gets
+		mu.Unlock()
+
+		// the record is reported by the API after the first propagation check.
+		if current <= 2 {
+			addEvent("api: missing")
+
+			rw.WriteHeader(http.StatusNotFound)
+			_, _ = fmt.Fprint(rw, `{"message":"record not found"}`)
+			return
+		}
+
+		addEvent("api: found")
+
+		_, _ = fmt.Fprintf(rw, `{"zone":"example.com","domain":"_acme-challenge.www.example.com","type":"TXT","answers":[{"answer":[%q]}]}`, value)
+	})
+
+	config := NewDefaultConfig()
+	config.APIKey = "secret"
+	config.PropagationTimeout = 2 * time.Second
+	config.PollingInterval = 10 * time.Millisecond
+
+	provider, err := NewDNSProviderConfig(config)
+	require.NoError(t, err)
+
+	provider.client = rest.NewClient(config.HTTPClient, rest.SetAPIKey(config.APIKey), rest.SetEndpoint(apiServer.URL+"/v1/"))
+
+	err = provider.Present("www.example.com", "token", keyAuth)
+	require.NoError(t, err)
+
+	validate := func(_ *api.Core, _ string, _ acme.Challenge) error { return nil }
+
+	chlg := dns01.NewChallenge(core, validate, provider)
+
+	authz := acme.Authorization{
+		Identifier: acme.Identifier{Value: "www.example.com"},
+		Challenges: []acme.Challenge{{Type: challenge.DNS01.String(), Token: "token"}},
+	}
+
+	err = chlg.Solve(authz)
+	require.NoError(t, err)
+
+	mu.Lock()
+	defer mu.Unlock()
+
+	// the API is a first gate, then the nameservers of the zone are queried.
+	assert.Equal(t, []string{"api: missing", "api: missing", "api: found", "dns"}, events)
+	assert.Equal(t, []string{pc.LocalAddr().String()}, provider.Nameservers(fqdn))
+}
+
+func TestDNSProvider_StoredValues(t *testing.T) {
+	provider, mux := setupTest(t)
+
+	// the API has truncated the value.
+	mux.HandleFunc("/v1/zones/example.com/_acme-challenge.www.example.com/TXT", func(rw http.ResponseWriter, req *http.Request) {
+		_, _ = fmt.Fprint(rw, `{"zone":"example.com","domain":"_acme-challenge.www.example.com","type":"TXT","answers":[{"answer":["ADw2sEd82DUgXcQ9hNBZ"]}]}`)
+	})
+
+	var reader dns01.StoredValuesReader = provider
+
+	_, err := reader.StoredValues("_acme-challenge.www.example.com.")
+	require.EqualError(t, err, "ns1: unknown zone for '_acme-challenge.www.example.com.'")
+
+	// filled by Present with the zone returned by the API.
+	provider.zones["_acme-challenge.www.example.com."] = &dns.Zone{Zone: "example.com"}
+
+	values, err := reader.StoredValues("_acme-challenge.www.example.com.")
+	require.NoError(t, err)
+
+	assert.Equal(t, []string{"ADw2sEd82DUgXcQ9hNBZ"}, values)
+}
+
+func TestDNSProvider_Present_singleString(t *testing.T) {
+	provider, mux := setupTest(t)
+
+	var record dns.Record
+	mux.HandleFunc("/v1/zones/example.com/_acme-challenge.www.example.com/TXT", func(rw http.ResponseWriter, req *http.Request) {
+		switch req.Method {
+		case http.MethodGet:
+			rw.WriteHeader(http.StatusNotFound)
+			_, _ = fmt.Fprint(rw, `{"message":"record not found"}`)
+		case http.MethodPut:
+			err := json.NewDecoder(req.Body).Decode(&record)
+			if err != nil {
+				http.Error(rw, err.Error(), http.StatusBadRequest)
+				return
+			}
+
+			_, _ = fmt.Fprint(rw, `{}`)
+		default:
+			http.Error(rw, "unsupported method", http.StatusMethodNotAllowed)
+		}
+	})
+
+	assert.Equal(t, dns01.TXTSingleString, provider.TXTStringFormat())
+
+	err := provider.Present("www.example.com", "token", "123d==")
+	require.NoError(t, err)
+
+	expected := []*dns.Answer{{Rdata: []string{"ADw2sEd82DUgXcQ9hNBZThJs7zVJkR5v9JeSbAb9mZY"}}}
+	assert.Equal(t, expected, record.Answers)
+}
+
+func TestDNSProvider_ZoneNameservers(t *testing.T) {
+	provider, _ := setupTest(t)
+
+	var p dns01.ZoneNameserversProvider = provider
+
+	nameservers, err := p.ZoneNameservers("internal.example.com")
+	require.NoError(t, err)
+
+	assert.Equal(t, []string{"dns1.p02.nsone.net", "dns2.p02.nsone.net"}, nameservers)
+}
+
+func TestDNSProvider_CleanUpByHandle(t *testing.T) {
+	provider, mux := setupTest(t)
+
+	var record []byte
+	var deletes int
+	mux.HandleFunc("/v1/zones/example.com/_acme-challenge.www.example.com/TXT", func(rw http.ResponseWriter, req *http.Request) {
+		switch req.Method {
+		case http.MethodGet:
+			if record == nil {
+				rw.WriteHeader(http.StatusNotFound)
+				_, _ = fmt.Fprint(rw, `{"message":"record not found"}`)
+				return
+			}
+
+			_, _ = rw.Write(record)
+		case http.MethodPut:
+			raw, err := ioutil.ReadAll(req.Body)
+			if err != nil {
+				http.Error(rw, err.Error(), http.StatusBadRequest)
+				return
+			}
+
+			var created dns.Record
+			err = json.Unmarshal(raw, &created)
+			if err != nil {
+				http.Error(rw, err.Error(), http.StatusBadRequest)
+				return
+			}
+
+			created.ID = "recordA"
+
+			record, err = json.Marshal(created)
+			if err != nil {
+				http.Error(rw, err.Error(), http.StatusInternalServerError)
+				return
+			}
+
+			_, _ = rw.Write(record)
+		case http.MethodDelete:
+			deletes++
+			record = nil
+			_, _ = fmt.Fprint(rw, `{}`)
+		default:
+			http.Error(rw, "unsupported method", http.StatusMethodNotAllowed)
+		}
+	})
+
+	handle, err := provider.PresentRecord("www.example.com", "token", "123d==")
+	require.NoError(t, err)
+
+	expected := &dns01.RecordHandle{
+		Zone:     "example.com",
+		Name:     "_acme-challenge.www.example.com.",
+		Value:    "ADw2sEd82DUgXcQ9hNBZThJs7zVJkR5v9JeSbAb9mZY",
+		RecordID: "recordA",
+	}
+	assert.Equal(t, expected, handle)
+
+	raw, err := json.Marshal(handle)
+	require.NoError(t, err)
+
+	// simulates another process: a new provider, without the state of the previous one.
+	other, err := NewDNSProviderConfig(provider.config)
+	require.NoError(t, err)
+
+	other.client = provider.client
+
+	var stored dns01.RecordHandle
+	err = json.Unmarshal(raw, &stored)
+	require.NoError(t, err)
+
+	err = other.CleanUpByHandle(stored)
+	require.NoError(t, err)
+
+	assert.Equal(t, 1, deletes)
+
+	// already removed.
+	err = other.CleanUpByHandle(stored)
+	require.NoError(t, err)
+
+	assert.Equal(t, 1, deletes)
+}
+
+func TestDNSProvider_CleanUpByHandle_otherValues(t *testing.T) {
+	provider, mux := setupTest(t)
+
+	record := []byte(`{"id":"recordA","zone":"example.com","domain":"_acme-challenge.www.example.com","type":"TXT",` +
+		`"answers":[{"answer":["other"]},{"answer":["ADw2sEd82DUgXcQ9hNBZThJs7zVJkR5v9JeSbAb9mZY"]}]}`)
+
+	var deletes int
+	mux.HandleFunc("/v1/zones/example.com/_acme-challenge.www.example.com/TXT", func(rw http.ResponseWriter, req *http.Request) {
+		switch req.Method {
+		case http.MethodGet:
+			_, _ = rw.Write(record)
+		case http.MethodPost:
+			raw, err := ioutil.ReadAll(req.Body)
+			if err != nil {
+				http.Error(rw, err.Error(), http.StatusBadRequest)
+				return
+			}
+
+			record = raw
+			_, _ = rw.Write(record)
+		case http.MethodDelete:
+			deletes++
+			_, _ = fmt.Fprint(rw, `{}`)
+		default:
+			http.Error(rw, "unsupported method", http.StatusMethodNotAllowed)
+		}
+	})
+
+	handle := dns01.RecordHandle{
+		Zone:     "example.com",
+		Name:     "_acme-challenge.www.example.com.",
+		Value:    "ADw2sEd82DUgXcQ9hNBZThJs7zVJkR5v9JeSbAb9mZY",
+		RecordID: "recordA",
+	}
+
+	err := provider.CleanUpByHandle(handle)
+	require.NoError(t, err)
+
+	assert.Equal(t, 0, deletes)
+
+	var result dns.Record
+	err = json.Unmarshal(record, &result)
+	require.NoError(t, err)
+
+	assert.Equal(t, []*dns.Answer{{Rdata: []string{"other"}}}, result.Answers)
+}
@@ -1,10 +1,14 @@
 package ovh
 
 import (
+	"io/ioutil"
+	"net/http"
+	"strings"
 	"testing"
 	"time"
 
 	"github.com/go-acme/lego/v4/platform/tester"
+	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 )
 
@@ -132,6 +136,13 @@ func TestNewDNSProviderConfig(t *testing.T) {
 			applicationSecret: "C",
 			consumerKey:       "D",
 		},
+		{
+			desc:              "success US endpoint",
+			apiEndpoint:       "ovh-us",
+			applicationKey:    "B",
+			applicationSecret: "C",
+			consumerKey:       "D",
+		},
 		{
 			desc:     "missing credentials",
 			expected: "ovh: credentials missing",
@@ -201,6 +212,67 @@ func TestNewDNSProviderConfig(t *testing.T) {
 	}
 }
 
+type roundTripperRecorder struct {
+	requests []*http.Request
+}
+
+func (r *roundTripperRecorder) RoundTrip(req *http.Request) (*http.Response, error) {
+	r.requests = append(r.requests, req)
+
+	return &http.Response{
+		StatusCode: http.StatusOK,
+		Header:     http.Header{"Content-Type": []string{"application/json"}},
+		Body:       ioutil.NopCloser(strings.NewReader(`1600000000`)),
+		Request:    req,
+	}, nil
+}
+
+func TestNewDNSProviderConfig_endpoint(t *testing.T) {
+	testCases := []struct {
+		desc        string
+		apiEndpoint string
+		expected    string
+	}{
+		{
+			desc:        "EU",
+			apiEndpoint: "ovh-eu",
+			expected:    "https://eu.api.ovh.com/1.0/auth/time",
+		},
+		{
+			desc:        "CA",
+			apiEndpoint: "ovh-ca",
+			expected:    "https://ca.api.ovh.com/1.0/auth/time",
+		},
+		{
+			desc:        "US",
+			apiEndpoint: "ovh-us",
+			expected:    "https://api.us.ovhcloud.com/1.0/auth/time",
+		},
+	}
+
+	for _, test := range testCases {
+		t.Run(test.desc, func(t *testing.T) {
+			recorder := &roundTripperRecorder{}
+
+			config := NewDefaultConfig()
+			config.APIEndpoint = test.apiEndpoint
+			config.ApplicationKey = "B"
+			config.ApplicationSecret = "C"
+			config.ConsumerKey = "D"
+			config.HTTPClient = &http.Client{Transport: recorder}
+
+			p, err := NewDNSProviderConfig(config)
+			require.NoError(t, err)
+
+			_, err = p.client.Time()
+			require.NoError(t, err)
+
+			require.Len(t, recorder.requests, 1)
+			assert.Equal(t, test.expected, recorder.requests[0].URL.String())
+		})
+	}
+}
+
 func TestLivePresent(t *testing.T) {
 	if !envTest.IsLiveTest() {
 		t.Skip("skipping live test")
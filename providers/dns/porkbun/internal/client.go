@@ -0,0 +1,131 @@
+package internal
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"path"
+)
+
+// defaultBaseURL represents the API endpoint to call.
+const defaultBaseURL = "https://porkbun.com/api/json/v3/"
+
+// maxDomains the maximum number of domains returned by a call to list the domains.
+const maxDomains = 1000
+
+// Client the Porkbun client.
+type Client struct {
+	HTTPClient *http.Client
+	BaseURL    *url.URL
+
+	auth Authentication
+}
+
+// NewClient Creates a new Porkbun client.
+func NewClient(apiKey, secretAPIKey string) *Client {
+	baseURL, _ := url.Parse(defaultBaseURL)
+
+	return &Client{
+		HTTPClient: &http.Client{},
+		BaseURL:    baseURL,
+		auth:       Authentication{APIKey: apiKey, SecretAPIKey: secretAPIKey},
+	}
+}
+
+// ListDomains lists the domains of the account.
+// https://porkbun.com/api/json/v3/documentation#Domain%20List%20All
+func (c *Client) ListDomains() ([]Domain, error) {
+	var domains []Domain
+
+	for {
+		payload := listDomainsRequest{Authentication: c.auth, Start: len(domains)}
+
+		var result listDomainsResponse
+		err := c.do(&result, payload, "domain", "listAll")
+		if err != nil {
+			return nil, err
+		}
+
+		domains = append(domains, result.Domains...)
+
+		if len(result.Domains) < maxDomains {
+			return domains, nil
+		}
+	}
+}
+
+// CreateRecord creates a DNS record and returns its ID.
+// https://porkbun.com/api/json/v3/documentation#DNS%20Create%20Record
+func (c *Client) CreateRecord(domain string, record Record) (string, error) {
+	payload := recordRequest{Authentication: c.auth, Record: record}
+
+	var result createRecordResponse
+	err := c.do(&result, payload, "dns", "create", domain)
+	if err != nil {
+		return "", err
+	}
+
+	return result.ID.String(), nil
+}
+
+// DeleteRecord deletes a DNS record.
+// https://porkbun.com/api/json/v3/documentation#DNS%20Delete%20Record%20by%20Domain%20and%20ID
+func (c *Client) DeleteRecord(domain, recordID string) error {
+	payload := recordRequest{Authentication: c.auth}
+
+	var result APIResponse
+	return c.do(&result, payload, "dns", "delete", domain, recordID)
+}
+
+func (c *Client) do(result interface{}, payload interface{}, parts ...string) error {
+	endpoint, err := c.BaseURL.Parse(path.Join(c.BaseURL.Path, path.Join(parts...)))
+	if err != nil {
+		return fmt.Errorf("failed to create endpoint: %w", err)
+	}
+
+	body, err := json.Marshal(payload)
+	if err != nil {
+		return fmt.Errorf("failed to marshal request body: %w", err)
+	}
+
+	req, err := http.NewRequest(http.MethodPost, endpoint.String(), bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+
+	req.Header.Set("Accept", "application/json")
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := c.HTTPClient.Do(req)
+	if err != nil {
+		return err
+	}
+
+	defer func() { _ = resp.Body.Close() }()
+
+	raw, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return fmt.Errorf("failed to read response body: %w", err)
+	}
+
+	// the API returns the status in the body, even with an error status code.
+	var status APIResponse
+	err = json.Unmarshal(raw, &status)
+	if err != nil {
+		return fmt.Errorf("unexpected response: %d: %s", resp.StatusCode, string(raw))
+	}
+
+	if resp.StatusCode != http.StatusOK || status.Status != statusSuccess {
+		return fmt.Errorf("%s: %d: %s: %s", endpoint.Path, resp.StatusCode, status.Status, status.Message)
+	}
+
+	err = json.Unmarshal(raw, result)
+	if err != nil {
+		return fmt.Errorf("failed to unmarshal response body: %w: %s", err, string(raw))
+	}
+
+	return nil
+}
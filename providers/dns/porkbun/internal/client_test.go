@@ -0,0 +1,143 @@
+package internal
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func setupTest(t *testing.T, pattern string, handler http.HandlerFunc) *Client {
+	t.Helper()
+
+	mux := http.NewServeMux()
+	server := httptest.NewServer(mux)
+	t.Cleanup(server.Close)
+
+	mux.HandleFunc(pattern, func(rw http.ResponseWriter, req *http.Request) {
+		if req.Method != http.MethodPost {
+			http.Error(rw, fmt.Sprintf("unsupported method: %s", req.Method), http.StatusMethodNotAllowed)
+			return
+		}
+
+		if req.Header.Get("Content-Type") != "application/json" {
+			http.Error(rw, "invalid content type", http.StatusBadRequest)
+			return
+		}
+
+		handler(rw, req)
+	})
+
+	client := NewClient("key", "secret")
+	client.BaseURL, _ = url.Parse(server.URL + "/api/json/v3/")
+
+	return client
+}
+
+func readBody(t *testing.T, req *http.Request) map[string]interface{} {
+	t.Helper()
+
+	body := map[string]interface{}{}
+	err := json.NewDecoder(req.Body).Decode(&body)
+	require.NoError(t, err)
+
+	return body
+}
+
+func TestClient_ListDomains(t *testing.T) {
+	client := setupTest(t, "/api/json/v3/domain/listAll", func(rw http.ResponseWriter, req *http.Request) {
+		body := readBody(t, req)
+
+		assert.Equal(t, map[string]interface{}{"apikey": "key", "secretapikey": "secret", "start": float64(0)}, body)
+
+		_, _ = fmt.Fprint(rw, `{"status":"SUCCESS","domains":[{"domain":"example.com","status":"ACTIVE"},{"domain":"example.org","status":"ACTIVE"}]}`)
+	})
+
+	domains, err := client.ListDomains()
+	require.NoError(t, err)
+
+	expected := []Domain{
+		{Domain: "example.com", Status: "ACTIVE"},
+		{Domain: "example.org", Status: "ACTIVE"},
+	}
+	assert.Equal(t, expected, domains)
+}
+
+func TestClient_ListDomains_pagination(t *testing.T) {
+	var starts []float64
+	client := setupTest(t, "/api/json/v3/domain/listAll", func(rw http.ResponseWriter, req *http.Request) {
+		start := readBody(t, req)["start"].(float64)
+		starts = append(starts, start)
+
+		count := maxDomains
+		if start > 0 {
+			count = 1
+		}
+
+		result := listDomainsResponse{APIResponse: APIResponse{Status: statusSuccess}}
+		for i := 0; i < count; i++ {
+			result.Domains = append(result.Domains, Domain{Domain: fmt.Sprintf("example%d.com", int(start)+i)})
+		}
+
+		_ = json.NewEncoder(rw).Encode(result)
+	})
+
+	domains, err := client.ListDomains()
+	require.NoError(t, err)
+
+	assert.Len(t, domains, maxDomains+1)
+	assert.Equal(t, []float64{0, maxDomains}, starts)
+}
+
+func TestClient_CreateRecord(t *testing.T) {
+	client := setupTest(t, "/api/json/v3/dns/create/example.com", func(rw http.ResponseWriter, req *http.Request) {
+		body := readBody(t, req)
+
+		expected := map[string]interface{}{
+			"apikey":       "key",
+			"secretapikey": "secret",
+			"name":         "_acme-challenge",
+			"type":         "TXT",
+			"content":      "txtTXTtxt",
+			"ttl":          "600",
+		}
+		assert.Equal(t, expected, body)
+
+		_, _ = fmt.Fprint(rw, `{"status":"SUCCESS","id":106926659}`)
+	})
+
+	record := Record{Name: "_acme-challenge", Type: "TXT", Content: "txtTXTtxt", TTL: "600"}
+
+	recordID, err := client.CreateRecord("example.com", record)
+	require.NoError(t, err)
+
+	assert.Equal(t, "106926659", recordID)
+}
+
+func TestClient_DeleteRecord(t *testing.T) {
+	client := setupTest(t, "/api/json/v3/dns/delete/example.com/106926659", func(rw http.ResponseWriter, req *http.Request) {
+		body := readBody(t, req)
+
+		assert.Equal(t, map[string]interface{}{"apikey": "key", "secretapikey": "secret"}, body)
+
+		_, _ = fmt.Fprint(rw, `{"status":"SUCCESS"}`)
+	})
+
+	err := client.DeleteRecord("example.com", "106926659")
+	require.NoError(t, err)
+}
+
+func TestClient_error(t *testing.T) {
+	client := setupTest(t, "/api/json/v3/dns/delete/example.com/106926659", func(rw http.ResponseWriter, req *http.Request) {
+		rw.WriteHeader(http.StatusBadRequest)
+		_, _ = fmt.Fprint(rw, `{"status":"ERROR","message":"Invalid API key. (002)"}`)
+	})
+
+	err := client.DeleteRecord("example.com", "106926659")
+	require.EqualError(t, err, "/api/json/v3/dns/delete/example.com/106926659: 400: ERROR: Invalid API key. (002)")
+}
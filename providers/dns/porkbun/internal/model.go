@@ -0,0 +1,52 @@
+package internal
+
+import "encoding/json"
+
+const statusSuccess = "SUCCESS"
+
+// Authentication the credentials sent in the body of each request.
+type Authentication struct {
+	APIKey       string `json:"apikey"`
+	SecretAPIKey string `json:"secretapikey"`
+}
+
+// Record a DNS record.
+type Record struct {
+	ID      string `json:"id,omitempty"`
+	Name    string `json:"name,omitempty"`
+	Type    string `json:"type,omitempty"`
+	Content string `json:"content,omitempty"`
+	TTL     string `json:"ttl,omitempty"`
+}
+
+// Domain a domain of the account.
+type Domain struct {
+	Domain string `json:"domain"`
+	Status string `json:"status"`
+}
+
+type recordRequest struct {
+	Authentication
+	Record
+}
+
+type listDomainsRequest struct {
+	Authentication
+	Start int `json:"start"`
+}
+
+// APIResponse the status of an API response.
+type APIResponse struct {
+	Status  string `json:"status"`
+	Message string `json:"message,omitempty"`
+}
+
+type createRecordResponse struct {
+	APIResponse
+	ID json.Number `json:"id"`
+}
+
+type listDomainsResponse struct {
+	APIResponse
+	Domains []Domain `json:"domains"`
+}
@@ -0,0 +1,205 @@
+// Package porkbun implements a DNS provider for solving the DNS-01 challenge using Porkbun.
+package porkbun
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/go-acme/lego/v4/challenge/dns01"
+	"github.com/go-acme/lego/v4/platform/config/env"
+	"github.com/go-acme/lego/v4/providers/dns/internal/useragent"
+	"github.com/go-acme/lego/v4/providers/dns/porkbun/internal"
+)
+
+const minTTL = 600
+
+// Environment variables names.
+const (
+	envNamespace = "PORKBUN_"
+
+	EnvAPIKey       = envNamespace + "API_KEY"
+	EnvSecretAPIKey = envNamespace + "SECRET_API_KEY"
+
+	EnvTTL                = envNamespace + "TTL"
+	EnvPropagationTimeout = envNamespace + "PROPAGATION_TIMEOUT"
+	EnvPollingInterval    = envNamespace + "POLLING_INTERVAL"
+	EnvHTTPTimeout        = envNamespace + "HTTP_TIMEOUT"
+)
+
+// Config is used to configure the creation of the DNSProvider.
+type Config struct {
+	APIKey             string
+	SecretAPIKey       string
+	PropagationTimeout time.Duration
+	PollingInterval    time.Duration
+	TTL                int
+	HTTPClient         *http.Client
+}
+
+// NewDefaultConfig returns a default configuration for the DNSProvider.
+func NewDefaultConfig() *Config {
+	return &Config{
+		TTL:                env.GetOrDefaultInt(EnvTTL, minTTL),
+		PropagationTimeout: env.GetOrDefaultSecond(EnvPropagationTimeout, 10*time.Minute),
+		PollingInterval:    env.GetOrDefaultSecond(EnvPollingInterval, 10*time.Second),
+		HTTPClient: useragent.Wrap(&http.Client{
+			Timeout: env.GetOrDefaultSecond(EnvHTTPTimeout, 30*time.Second),
+		}),
+	}
+}
+
+// DNSProvider implements the challenge.Provider interface.
+type DNSProvider struct {
+	config *Config
+	client *internal.Client
+
+	records   map[string]txtRecord
+	recordsMu sync.Mutex
+}
+
+// txtRecord the reference of a TXT record created by Present.
+type txtRecord struct {
+	domain string
+	id     string
+}
+
+// NewDNSProvider returns a DNSProvider instance configured for Porkbun.
+// Credentials must be passed in the environment variables: PORKBUN_API_KEY and PORKBUN_SECRET_API_KEY.
+func NewDNSProvider() (*DNSProvider, error) {
+	values, err := env.Get(EnvAPIKey, EnvSecretAPIKey)
+	if err != nil {
+		return nil, fmt.Errorf("porkbun: %w", err)
+	}
+
+	config := NewDefaultConfig()
+	config.APIKey = values[EnvAPIKey]
+	config.SecretAPIKey = values[EnvSecretAPIKey]
+
+	return NewDNSProviderConfig(config)
+}
+
+// NewDNSProviderConfig return a DNSProvider instance configured for Porkbun.
+func NewDNSProviderConfig(config *Config) (*DNSProvider, error) {
+	if config == nil {
+		return nil, errors.New("porkbun: the configuration of the DNS provider is nil")
+	}
+
+	if config.APIKey == "" || config.SecretAPIKey == "" {
+		return nil, errors.New("porkbun: credentials missing")
+	}
+
+	if config.TTL < minTTL {
+		return nil, fmt.Errorf("porkbun: invalid TTL, TTL (%d) must be greater than %d", config.TTL, minTTL)
+	}
+
+	client := internal.NewClient(config.APIKey, config.SecretAPIKey)
+
+	if config.HTTPClient != nil {
+		client.HTTPClient = config.HTTPClient
+	}
+
+	return &DNSProvider{
+		config:  config,
+		client:  client,
+		records: make(map[string]txtRecord),
+	}, nil
+}
+
+// Timeout returns the timeout and interval to use when checking for DNS propagation.
+// Adjusting here to cope with spikes in propagation times.
+func (d *DNSProvider) Timeout() (timeout, interval time.Duration) {
+	return d.config.PropagationTimeout, d.config.PollingInterval
+}
+
+// Present creates a TXT record to fulfill the dns-01 challenge.
+func (d *DNSProvider) Present(domain, token, keyAuth string) error {
+	fqdn, value := dns01.GetRecord(domain, keyAuth)
+
+	zone, err := d.findZone(fqdn)
+	if err != nil {
+		return fmt.Errorf("porkbun: %w", err)
+	}
+
+	record := internal.Record{
+		Name:    extractRecordName(fqdn, zone),
+		Type:    "TXT",
+		Content: value,
+		TTL:     strconv.Itoa(d.config.TTL),
+	}
+
+	recordID, err := d.client.CreateRecord(zone, record)
+	if err != nil {
+		return fmt.Errorf("porkbun: failed to add TXT record: fqdn=%s: %w", fqdn, err)
+	}
+
+	d.recordsMu.Lock()
+	d.records[token] = txtRecord{domain: zone, id: recordID}
+	d.recordsMu.Unlock()
+
+	return nil
+}
+
+// CleanUp removes the TXT record matching the specified parameters.
+func (d *DNSProvider) CleanUp(domain, token, keyAuth string) error {
+	d.recordsMu.Lock()
+	record, ok := d.records[token]
+	d.recordsMu.Unlock()
+
+	if !ok {
+		return fmt.Errorf("porkbun: unknown record ID for '%s'", token)
+	}
+
+	err := d.client.DeleteRecord(record.domain, record.id)
+	if err != nil {
+		return fmt.Errorf("porkbun: failed to delete TXT record: id=%s: %w", record.id, err)
+	}
+
+	d.recordsMu.Lock()
+	delete(d.records, token)
+	d.recordsMu.Unlock()
+
+	return nil
+}
+
+// Zones returns the domains of the account.
+func (d *DNSProvider) Zones() ([]string, error) {
+	domains, err := d.client.ListDomains()
+	if err != nil {
+		return nil, err
+	}
+
+	var zones []string
+	for _, domain := range domains {
+		zones = append(zones, domain.Domain)
+	}
+
+	return zones, nil
+}
+
+// findZone finds the most specific domain of the account for the fqdn.
+func (d *DNSProvider) findZone(fqdn string) (string, error) {
+	zones, err := d.Zones()
+	if err != nil {
+		return "", fmt.Errorf("failed to list the domains: %w", err)
+	}
+
+	zone := dns01.FindMostSpecificZone(fqdn, zones)
+	if zone == "" {
+		return "", fmt.Errorf("no domain found for %s", fqdn)
+	}
+
+	return zone, nil
+}
+
+func extractRecordName(fqdn, zone string) string {
+	name := dns01.UnFqdn(fqdn)
+	if idx := strings.LastIndex(name, "."+zone); idx != -1 {
+		return name[:idx]
+	}
+	return name
+}
@@ -0,0 +1,255 @@
+package porkbun
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/go-acme/lego/v4/platform/tester"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const envDomain = envNamespace + "DOMAIN"
+
+var envTest = tester.NewEnvTest(
+	EnvAPIKey,
+	EnvSecretAPIKey).
+	WithDomain(envDomain)
+
+func TestNewDNSProvider(t *testing.T) {
+	testCases := []struct {
+		desc     string
+		envVars  map[string]string
+		expected string
+	}{
+		{
+			desc: "success",
+			envVars: map[string]string{
+				EnvAPIKey:       "key",
+				EnvSecretAPIKey: "secret",
+			},
+		},
+		{
+			desc: "missing credentials",
+			envVars: map[string]string{
+				EnvAPIKey:       "",
+				EnvSecretAPIKey: "",
+			},
+			expected: "porkbun: some credentials information are missing: PORKBUN_API_KEY,PORKBUN_SECRET_API_KEY",
+		},
+		{
+			desc: "missing API key",
+			envVars: map[string]string{
+				EnvAPIKey:       "",
+				EnvSecretAPIKey: "secret",
+			},
+			expected: "porkbun: some credentials information are missing: PORKBUN_API_KEY",
+		},
+		{
+			desc: "missing secret API key",
+			envVars: map[string]string{
+				EnvAPIKey:       "key",
+				EnvSecretAPIKey: "",
+			},
+			expected: "porkbun: some credentials information are missing: PORKBUN_SECRET_API_KEY",
+		},
+	}
+
+	for _, test := range testCases {
+		t.Run(test.desc, func(t *testing.T) {
+			defer envTest.RestoreEnv()
+			envTest.ClearEnv()
+
+			envTest.Apply(test.envVars)
+
+			p, err := NewDNSProvider()
+
+			if len(test.expected) == 0 {
+				require.NoError(t, err)
+				require.NotNil(t, p)
+				require.NotNil(t, p.config)
+			} else {
+				require.EqualError(t, err, test.expected)
+			}
+		})
+	}
+}
+
+func TestNewDNSProviderConfig(t *testing.T) {
+	testCases := []struct {
+		desc         string
+		apiKey       string
+		secretAPIKey string
+		ttl          int
+		expected     string
+	}{
+		{
+			desc:         "success",
+			apiKey:       "key",
+			secretAPIKey: "secret",
+			ttl:          minTTL,
+		},
+		{
+			desc:     "missing credentials",
+			ttl:      minTTL,
+			expected: "porkbun: credentials missing",
+		},
+		{
+			desc:         "missing API key",
+			secretAPIKey: "secret",
+			ttl:          minTTL,
+			expected:     "porkbun: credentials missing",
+		},
+		{
+			desc:     "missing secret API key",
+			apiKey:   "key",
+			ttl:      minTTL,
+			expected: "porkbun: credentials missing",
+		},
+		{
+			desc:         "invalid TTL",
+			apiKey:       "key",
+			secretAPIKey: "secret",
+			ttl:          60,
+			expected:     "porkbun: invalid TTL, TTL (60) must be greater than 600",
+		},
+	}
+
+	for _, test := range testCases {
+		t.Run(test.desc, func(t *testing.T) {
+			config := NewDefaultConfig()
+			config.APIKey = test.apiKey
+			config.SecretAPIKey = test.secretAPIKey
+			config.TTL = test.ttl
+
+			p, err := NewDNSProviderConfig(config)
+
+			if len(test.expected) == 0 {
+				require.NoError(t, err)
+				require.NotNil(t, p)
+				require.NotNil(t, p.config)
+			} else {
+				require.EqualError(t, err, test.expected)
+			}
+		})
+	}
+}
+
+func setupTest(t *testing.T) (*DNSProvider, *http.ServeMux) {
+	t.Helper()
+
+	mux := http.NewServeMux()
+	server := httptest.NewServer(mux)
+	t.Cleanup(server.Close)
+
+	mux.HandleFunc("/domain/listAll", func(rw http.ResponseWriter, req *http.Request) {
+		_, _ = fmt.Fprint(rw, `{"status":"SUCCESS","domains":[{"domain":"example.com","status":"ACTIVE"},{"domain":"sub.example.com","status":"ACTIVE"}]}`)
+	})
+
+	config := NewDefaultConfig()
+	config.APIKey = "key"
+	config.SecretAPIKey = "secret"
+
+	provider, err := NewDNSProviderConfig(config)
+	require.NoError(t, err)
+
+	provider.client.BaseURL, _ = url.Parse(server.URL + "/")
+
+	return provider, mux
+}
+
+func TestDNSProvider_Present(t *testing.T) {
+	provider, mux := setupTest(t)
+
+	mux.HandleFunc("/dns/create/sub.example.com", func(rw http.ResponseWriter, req *http.Request) {
+		if req.Method != http.MethodPost {
+			http.Error(rw, fmt.Sprintf("unsupported method: %s", req.Method), http.StatusMethodNotAllowed)
+			return
+		}
+
+		body := map[string]string{}
+		err := json.NewDecoder(req.Body).Decode(&body)
+		if err != nil {
+			http.Error(rw, err.Error(), http.StatusBadRequest)
+			return
+		}
+
+		expected := map[string]string{
+			"apikey":       "key",
+			"secretapikey": "secret",
+			"name":         "_acme-challenge.www",
+			"type":         "TXT",
+			"content":      "ADw2sEd82DUgXcQ9hNBZThJs7zVJkR5v9JeSbAb9mZY",
+			"ttl":          "600",
+		}
+		assert.Equal(t, expected, body)
+
+		_, _ = fmt.Fprint(rw, `{"status":"SUCCESS","id":106926659}`)
+	})
+
+	err := provider.Present("www.sub.example.com", "token", "123d==")
+	require.NoError(t, err)
+
+	assert.Equal(t, txtRecord{domain: "sub.example.com", id: "106926659"}, provider.records["token"])
+}
+
+func TestDNSProvider_Present_unknownDomain(t *testing.T) {
+	provider, _ := setupTest(t)
+
+	err := provider.Present("www.example.org", "token", "123d==")
+	require.EqualError(t, err, "porkbun: no domain found for _acme-challenge.www.example.org.")
+}
+
+func TestDNSProvider_CleanUp(t *testing.T) {
+	provider, mux := setupTest(t)
+
+	var deleted bool
+	mux.HandleFunc("/dns/delete/example.com/106926659", func(rw http.ResponseWriter, req *http.Request) {
+		body := map[string]string{}
+		err := json.NewDecoder(req.Body).Decode(&body)
+		if err != nil {
+			http.Error(rw, err.Error(), http.StatusBadRequest)
+			return
+		}
+
+		assert.Equal(t, map[string]string{"apikey": "key", "secretapikey": "secret"}, body)
+
+		deleted = true
+
+		_, _ = fmt.Fprint(rw, `{"status":"SUCCESS"}`)
+	})
+
+	provider.records["token"] = txtRecord{domain: "example.com", id: "106926659"}
+
+	err := provider.CleanUp("www.example.com", "token", "123d==")
+	require.NoError(t, err)
+
+	assert.True(t, deleted)
+	assert.Empty(t, provider.records)
+
+	err = provider.CleanUp("www.example.com", "token", "123d==")
+	require.EqualError(t, err, "porkbun: unknown record ID for 'token'")
+}
+
+func TestLivePresentAndCleanUp(t *testing.T) {
+	if !envTest.IsLiveTest() {
+		t.Skip("skipping live test")
+	}
+
+	envTest.RestoreEnv()
+	provider, err := NewDNSProvider()
+	require.NoError(t, err)
+
+	err = provider.Present(envTest.GetDomain(), "", "123d==")
+	require.NoError(t, err)
+
+	time.Sleep(1 * time.Second)
+
+	err = provider.CleanUp(envTest.GetDomain(), "", "123d==")
+	require.NoError(t, err)
+}
@@ -3,13 +3,19 @@ package rackspace
 import (
 	"bytes"
 	"encoding/json"
+	"errors"
 	"fmt"
-	"io"
 	"net/http"
+	"time"
 
 	"github.com/go-acme/lego/v4/challenge/dns01"
 )
 
+// tokenExpirationMargin the token is renewed when it expires within this delay.
+const tokenExpirationMargin = time.Minute
+
+var errUnauthorized = errors.New("unauthorized")
+
 // APIKeyCredentials API credential.
 type APIKeyCredentials struct {
 	Username string `json:"username"`
@@ -39,7 +45,8 @@ type Access struct {
 
 // Token Token.
 type Token struct {
-	ID string `json:"id"`
+	ID      string    `json:"id"`
+	Expires time.Time `json:"expires"`
 }
 
 // ServiceCatalog ServiceCatalog.
@@ -132,15 +139,30 @@ func (d *DNSProvider) findTxtRecord(fqdn string, zoneID int) (*Record, error) {
 }
 
 // makeRequest is a wrapper function used for making DNS API requests.
-func (d *DNSProvider) makeRequest(method, uri string, body io.Reader) (json.RawMessage, error) {
-	url := d.cloudDNSEndpoint + uri
+// The request is sent again with a new token if the token has been rejected.
+func (d *DNSProvider) makeRequest(method, uri string, body []byte) (json.RawMessage, error) {
+	result, err := d.doRequest(method, uri, body)
+	if errors.Is(err, errUnauthorized) {
+		return d.doRequest(method, uri, body)
+	}
+
+	return result, err
+}
 
-	req, err := http.NewRequest(method, url, body)
+func (d *DNSProvider) doRequest(method, uri string, body []byte) (json.RawMessage, error) {
+	token, endpoint, err := d.getIdentity()
 	if err != nil {
 		return nil, err
 	}
 
-	req.Header.Set("X-Auth-Token", d.token)
+	url := endpoint + uri
+
+	req, err := http.NewRequest(method, url, bytes.NewReader(body))
+	if err != nil {
+		return nil, err
+	}
+
+	req.Header.Set("X-Auth-Token", token)
 	req.Header.Set("Content-Type", "application/json")
 
 	resp, err := d.config.HTTPClient.Do(req)
@@ -150,6 +172,11 @@ func (d *DNSProvider) makeRequest(method, uri string, body io.Reader) (json.RawM
 
 	defer resp.Body.Close()
 
+	if resp.StatusCode == http.StatusUnauthorized {
+		d.invalidateToken(token)
+		return nil, fmt.Errorf("request failed for %s %s: %w", method, url, errUnauthorized)
+	}
+
 	if resp.StatusCode != http.StatusOK && resp.StatusCode != http.StatusAccepted {
 		return nil, fmt.Errorf("request failed for %s %s. Response code: %d", method, url, resp.StatusCode)
 	}
@@ -163,6 +190,59 @@ func (d *DNSProvider) makeRequest(method, uri string, body io.Reader) (json.RawM
 	return r, nil
 }
 
+// getIdentity returns the cached token and Cloud DNS endpoint,
+// and authenticates again if the token is missing or about to expire.
+func (d *DNSProvider) getIdentity() (string, string, error) {
+	d.identityMu.Lock()
+	defer d.identityMu.Unlock()
+
+	if d.token == "" || time.Now().Add(tokenExpirationMargin).After(d.tokenExpiresAt) {
+		err := d.authenticate()
+		if err != nil {
+			return "", "", err
+		}
+	}
+
+	return d.token, d.cloudDNSEndpoint, nil
+}
+
+// invalidateToken forces a new authentication on the next request, unless the token has already been renewed.
+func (d *DNSProvider) invalidateToken(token string) {
+	d.identityMu.Lock()
+	defer d.identityMu.Unlock()
+
+	if d.token == token {
+		d.token = ""
+	}
+}
+
+// authenticate gets a new token and the DNS endpoint from the Identity API.
+func (d *DNSProvider) authenticate() error {
+	identity, err := login(d.config)
+	if err != nil {
+		return err
+	}
+
+	// Iterate through the Service Catalog to get the DNS Endpoint
+	var dnsEndpoint string
+	for _, service := range identity.Access.ServiceCatalog {
+		if service.Name == "cloudDNS" {
+			dnsEndpoint = service.Endpoints[0].PublicURL
+			break
+		}
+	}
+
+	if dnsEndpoint == "" {
+		return errors.New("failed to populate DNS endpoint, check Rackspace API for changes")
+	}
+
+	d.token = identity.Access.Token.ID
+	d.tokenExpiresAt = identity.Access.Token.Expires
+	d.cloudDNSEndpoint = dnsEndpoint
+
+	return nil
+}
+
 func login(config *Config) (*Identity, error) {
 	authData := AuthData{
 		Auth: Auth{
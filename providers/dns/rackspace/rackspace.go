@@ -2,15 +2,16 @@
 package rackspace
 
 import (
-	"bytes"
 	"encoding/json"
 	"errors"
 	"fmt"
 	"net/http"
+	"sync"
 	"time"
 
 	"github.com/go-acme/lego/v4/challenge/dns01"
 	"github.com/go-acme/lego/v4/platform/config/env"
+	"github.com/go-acme/lego/v4/providers/dns/internal/useragent"
 )
 
 // defaultBaseURL represents the Identity API endpoint to call.
@@ -47,16 +48,19 @@ func NewDefaultConfig() *Config {
 		TTL:                env.GetOrDefaultInt(EnvTTL, 300),
 		PropagationTimeout: env.GetOrDefaultSecond(EnvPropagationTimeout, dns01.DefaultPropagationTimeout),
 		PollingInterval:    env.GetOrDefaultSecond(EnvPollingInterval, dns01.DefaultPollingInterval),
-		HTTPClient: &http.Client{
+		HTTPClient: useragent.Wrap(&http.Client{
 			Timeout: env.GetOrDefaultSecond(EnvHTTPTimeout, 30*time.Second),
-		},
+		}),
 	}
 }
 
 // DNSProvider implements the challenge.Provider interface.
 type DNSProvider struct {
-	config           *Config
+	config *Config
+
+	identityMu       sync.Mutex
 	token            string
+	tokenExpiresAt   time.Time
 	cloudDNSEndpoint string
 }
 
@@ -87,29 +91,14 @@ func NewDNSProviderConfig(config *Config) (*DNSProvider, error) {
 		return nil, errors.New("rackspace: credentials missing")
 	}
 
-	identity, err := login(config)
+	provider := &DNSProvider{config: config}
+
+	err := provider.authenticate()
 	if err != nil {
 		return nil, fmt.Errorf("rackspace: %w", err)
 	}
 
-	// Iterate through the Service Catalog to get the DNS Endpoint
-	var dnsEndpoint string
-	for _, service := range identity.Access.ServiceCatalog {
-		if service.Name == "cloudDNS" {
-			dnsEndpoint = service.Endpoints[0].PublicURL
-			break
-		}
-	}
-
-	if dnsEndpoint == "" {
-		return nil, errors.New("rackspace: failed to populate DNS endpoint, check Rackspace API for changes")
-	}
-
-	return &DNSProvider{
-		config:           config,
-		token:            identity.Access.Token.ID,
-		cloudDNSEndpoint: dnsEndpoint,
-	}, nil
+	return provider, nil
 }
 
 // Present creates a TXT record to fulfill the dns-01 challenge.
@@ -135,7 +124,7 @@ func (d *DNSProvider) Present(domain, token, keyAuth string) error {
 		return fmt.Errorf("rackspace: %w", err)
 	}
 
-	_, err = d.makeRequest(http.MethodPost, fmt.Sprintf("/domains/%d/records", zoneID), bytes.NewReader(body))
+	_, err = d.makeRequest(http.MethodPost, fmt.Sprintf("/domains/%d/records", zoneID), body)
 	if err != nil {
 		return fmt.Errorf("rackspace: %w", err)
 	}
@@ -61,6 +61,86 @@ func TestDNSProvider_CleanUp(t *testing.T) {
 	}
 }
 
+func TestDNSProvider_makeRequest_reuse(t *testing.T) {
+	provider, logins, _ := setupIdentityTest(t, time.Now().Add(time.Hour))
+
+	for i := 0; i < 3; i++ {
+		_, err := provider.findTxtRecord("_acme-challenge.example.com.", 112233)
+		require.NoError(t, err)
+	}
+
+	assert.Equal(t, 1, *logins)
+}
+
+func TestDNSProvider_makeRequest_expired(t *testing.T) {
+	provider, logins, _ := setupIdentityTest(t, time.Now().Add(30*time.Second))
+
+	for i := 0; i < 3; i++ {
+		_, err := provider.findTxtRecord("_acme-challenge.example.com.", 112233)
+		require.NoError(t, err)
+	}
+
+	assert.Equal(t, 4, *logins)
+}
+
+func TestDNSProvider_makeRequest_unauthorized(t *testing.T) {
+	provider, logins, revoked := setupIdentityTest(t, time.Now().Add(time.Hour))
+
+	// the first token is revoked.
+	revoked["token-1"] = true
+
+	for i := 0; i < 3; i++ {
+		_, err := provider.findTxtRecord("_acme-challenge.example.com.", 112233)
+		require.NoError(t, err)
+	}
+
+	assert.Equal(t, 2, *logins)
+	assert.Equal(t, "token-2", provider.token)
+}
+
+// setupIdentityTest creates a provider using an Identity API providing a new token ("token-<n>") for each login.
+// The DNS API rejects the revoked tokens.
+func setupIdentityTest(t *testing.T, expires time.Time) (*DNSProvider, *int, map[string]bool) {
+	t.Helper()
+
+	revoked := make(map[string]bool)
+
+	mux := http.NewServeMux()
+	dnsAPI := httptest.NewServer(mux)
+	t.Cleanup(dnsAPI.Close)
+
+	mux.HandleFunc("/123456/domains/112233/records", func(w http.ResponseWriter, r *http.Request) {
+		if revoked[r.Header.Get("X-Auth-Token")] {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+
+		fmt.Fprint(w, recordDetailsMock)
+	})
+
+	var logins int
+	identityAPI := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		logins++
+
+		resp := strings.Replace(identityResponseMock, "https://dns.api.rackspacecloud.com/v1.0/123456", dnsAPI.URL+"/123456", 1)
+		resp = strings.Replace(resp, `"testToken"`, fmt.Sprintf(`"token-%d"`, logins), 1)
+		resp = strings.Replace(resp, "1970-01-01T00:00:00.000Z", expires.UTC().Format(time.RFC3339), 1)
+
+		fmt.Fprint(w, resp)
+	}))
+	t.Cleanup(identityAPI.Close)
+
+	config := NewDefaultConfig()
+	config.APIUser = "testUser"
+	config.APIKey = "testKey"
+	config.BaseURL = identityAPI.URL + "/"
+
+	provider, err := NewDNSProviderConfig(config)
+	require.NoError(t, err)
+
+	return provider, &logins, revoked
+}
+
 func TestLiveNewDNSProvider_ValidEnv(t *testing.T) {
 	if !envTest.IsLiveTest() {
 		t.Skip("skipping live test")
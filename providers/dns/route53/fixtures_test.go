@@ -37,3 +37,51 @@ const GetChangeResponse = `<?xml version="1.0" encoding="UTF-8"?>
       <SubmittedAt>2016-02-10T01:36:41.958Z</SubmittedAt>
    </ChangeInfo>
 </GetChangeResponse>`
+
+const ListResourceRecordSetsResponse = `<?xml version="1.0" encoding="UTF-8"?>
+<ListResourceRecordSetsResponse xmlns="https://route53.amazonaws.com/doc/2013-04-01/">
+   <ResourceRecordSets>
+      <ResourceRecordSet>
+         <Name>_acme-challenge.example.com.</Name>
+         <Type>TXT</Type>
+         <TTL>10</TTL>
+         <ResourceRecords>
+            <ResourceRecord>
+               <Value>"ADw2sEd82DUgXcQ9hNBZThJs7zVJkR5v9JeSbAb9mZY"</Value>
+            </ResourceRecord>
+         </ResourceRecords>
+      </ResourceRecordSet>
+      <ResourceRecordSet>
+         <Name>_acme-challenge.www.example.com.</Name>
+         <Type>TXT</Type>
+         <TTL>10</TTL>
+         <ResourceRecords>
+            <ResourceRecord>
+               <Value>"ADw2sEd82DUgXcQ9hNBZThJs7zVJkR5v9JeSbAb9mZY"</Value>
+            </ResourceRecord>
+         </ResourceRecords>
+      </ResourceRecordSet>
+      <ResourceRecordSet>
+         <Name>example.com.</Name>
+         <Type>TXT</Type>
+         <TTL>300</TTL>
+         <ResourceRecords>
+            <ResourceRecord>
+               <Value>"v=spf1 -all"</Value>
+            </ResourceRecord>
+         </ResourceRecords>
+      </ResourceRecordSet>
+      <ResourceRecordSet>
+         <Name>www.example.com.</Name>
+         <Type>A</Type>
+         <TTL>300</TTL>
+         <ResourceRecords>
+            <ResourceRecord>
+               <Value>127.0.0.1</Value>
+            </ResourceRecord>
+         </ResourceRecords>
+      </ResourceRecordSet>
+   </ResourceRecordSets>
+   <IsTruncated>false</IsTruncated>
+   <MaxItems>100</MaxItems>
+</ListResourceRecordSetsResponse>`
@@ -16,6 +16,7 @@ import (
 	"github.com/go-acme/lego/v4/challenge/dns01"
 	"github.com/go-acme/lego/v4/platform/config/env"
 	"github.com/go-acme/lego/v4/platform/wait"
+	"github.com/go-acme/lego/v4/providers/dns/internal/useragent"
 )
 
 // Environment variables names.
@@ -27,12 +28,19 @@ const (
 	EnvRegion          = envNamespace + "REGION"
 	EnvHostedZoneID    = envNamespace + "HOSTED_ZONE_ID"
 	EnvMaxRetries      = envNamespace + "MAX_RETRIES"
+	EnvChangeComment   = envNamespace + "CHANGE_COMMENT"
 
 	EnvTTL                = envNamespace + "TTL"
 	EnvPropagationTimeout = envNamespace + "PROPAGATION_TIMEOUT"
 	EnvPollingInterval    = envNamespace + "POLLING_INTERVAL"
 )
 
+// defaultChangeComment the default comment of the change batches.
+const defaultChangeComment = "Managed by Lego: {action} {domain}"
+
+// maxChangeCommentLength the maximum length of the comment of a change batch.
+const maxChangeCommentLength = 256
+
 // Config is used to configure the creation of the DNSProvider.
 type Config struct {
 	MaxRetries         int
@@ -40,7 +48,10 @@ type Config struct {
 	PropagationTimeout time.Duration
 	PollingInterval    time.Duration
 	HostedZoneID       string
-	Client             *route53.Route53
+	// ChangeComment the comment of the change batches, for the audit trails.
+	// The placeholders {action} (present or cleanup) and {domain} are replaced.
+	ChangeComment string
+	Client        *route53.Route53
 }
 
 // NewDefaultConfig returns a default configuration for the DNSProvider.
@@ -51,6 +62,7 @@ func NewDefaultConfig() *Config {
 		PropagationTimeout: env.GetOrDefaultSecond(EnvPropagationTimeout, 2*time.Minute),
 		PollingInterval:    env.GetOrDefaultSecond(EnvPollingInterval, 4*time.Second),
 		HostedZoneID:       env.GetOrFile(EnvHostedZoneID),
+		ChangeComment:      env.GetOrDefaultString(EnvChangeComment, defaultChangeComment),
 	}
 }
 
@@ -115,6 +127,13 @@ func NewDNSProviderConfig(config *Config) (*DNSProvider, error) {
 		return nil, err
 	}
 
+	if ua := useragent.Get(); ua != "" {
+		// the handlers of the session are not shared with the other sessions.
+		sess.Handlers.Build.PushBack(func(r *request.Request) {
+			r.HTTPRequest.Header.Set("User-Agent", ua)
+		})
+	}
+
 	cl := route53.New(sess)
 	return &DNSProvider{client: cl, config: config}, nil
 }
@@ -158,7 +177,7 @@ func (d *DNSProvider) Present(domain, token, keyAuth string) error {
 		ResourceRecords: records,
 	}
 
-	err = d.changeRecord(route53.ChangeActionUpsert, hostedZoneID, recordSet)
+	err = d.changeRecord(route53.ChangeActionUpsert, hostedZoneID, recordSet, d.changeComment("present", domain))
 	if err != nil {
 		return fmt.Errorf("route53: %w", err)
 	}
@@ -190,23 +209,60 @@ func (d *DNSProvider) CleanUp(domain, token, keyAuth string) error {
 		ResourceRecords: records,
 	}
 
-	err = d.changeRecord(route53.ChangeActionDelete, hostedZoneID, recordSet)
+	err = d.changeRecord(route53.ChangeActionDelete, hostedZoneID, recordSet, d.changeComment("cleanup", domain))
 	if err != nil {
 		return fmt.Errorf("route53: %w", err)
 	}
 	return nil
 }
 
-func (d *DNSProvider) changeRecord(action, hostedZoneID string, recordSet *route53.ResourceRecordSet) error {
+// CleanUpAll removes all the challenge TXT records of the zone with one change batch.
+func (d *DNSProvider) CleanUpAll(zone string) error {
+	hostedZoneID, err := d.findHostedZoneID(zone)
+	if err != nil {
+		return fmt.Errorf("failed to determine Route 53 hosted zone ID: %w", err)
+	}
+
+	recordSets, err := d.getChallengeRecordSets(hostedZoneID)
+	if err != nil {
+		return fmt.Errorf("route53: %w", err)
+	}
+
+	if len(recordSets) == 0 {
+		return nil
+	}
+
+	var changes []*route53.Change
+	for _, recordSet := range recordSets {
+		changes = append(changes, &route53.Change{
+			Action:            aws.String(route53.ChangeActionDelete),
+			ResourceRecordSet: recordSet,
+		})
+	}
+
+	err = d.changeRecords(hostedZoneID, changes, d.changeComment("cleanup", dns01.UnFqdn(zone)))
+	if err != nil {
+		return fmt.Errorf("route53: %w", err)
+	}
+	return nil
+}
+
+func (d *DNSProvider) changeRecord(action, hostedZoneID string, recordSet *route53.ResourceRecordSet, comment string) error {
+	return d.changeRecords(hostedZoneID, []*route53.Change{{
+		Action:            aws.String(action),
+		ResourceRecordSet: recordSet,
+	}}, comment)
+}
+
+func (d *DNSProvider) changeRecords(hostedZoneID string, changes []*route53.Change, comment string) error {
+	batch := &route53.ChangeBatch{Changes: changes}
+	if comment != "" {
+		batch.Comment = aws.String(comment)
+	}
+
 	recordSetInput := &route53.ChangeResourceRecordSetsInput{
 		HostedZoneId: aws.String(hostedZoneID),
-		ChangeBatch: &route53.ChangeBatch{
-			Comment: aws.String("Managed by Lego"),
-			Changes: []*route53.Change{{
-				Action:            aws.String(action),
-				ResourceRecordSet: recordSet,
-			}},
-		},
+		ChangeBatch:  batch,
 	}
 
 	resp, err := d.client.ChangeResourceRecordSets(recordSetInput)
@@ -231,6 +287,17 @@ func (d *DNSProvider) changeRecord(action, hostedZoneID string, recordSet *route
 	})
 }
 
+// changeComment returns the comment of a change batch, describing the ACME context.
+func (d *DNSProvider) changeComment(action, domain string) string {
+	comment := strings.NewReplacer("{action}", action, "{domain}", domain).Replace(d.config.ChangeComment)
+
+	if len(comment) > maxChangeCommentLength {
+		return comment[:maxChangeCommentLength]
+	}
+
+	return comment
+}
+
 func (d *DNSProvider) getExistingRecordSets(hostedZoneID, fqdn string) ([]*route53.ResourceRecord, error) {
 	listInput := &route53.ListResourceRecordSetsInput{
 		HostedZoneId:    aws.String(hostedZoneID),
@@ -258,6 +325,29 @@ func (d *DNSProvider) getExistingRecordSets(hostedZoneID, fqdn string) ([]*route
 	return records, nil
 }
 
+// getChallengeRecordSets returns all the challenge TXT record sets of the hosted zone.
+func (d *DNSProvider) getChallengeRecordSets(hostedZoneID string) ([]*route53.ResourceRecordSet, error) {
+	listInput := &route53.ListResourceRecordSetsInput{
+		HostedZoneId: aws.String(hostedZoneID),
+	}
+
+	var recordSets []*route53.ResourceRecordSet
+
+	err := d.client.ListResourceRecordSetsPages(listInput, func(output *route53.ListResourceRecordSetsOutput, lastPage bool) bool {
+		for _, recordSet := range output.ResourceRecordSets {
+			if aws.StringValue(recordSet.Type) == route53.RRTypeTxt && strings.HasPrefix(aws.StringValue(recordSet.Name), "_acme-challenge.") {
+				recordSets = append(recordSets, recordSet)
+			}
+		}
+		return true
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return recordSets, nil
+}
+
 func (d *DNSProvider) getHostedZoneID(fqdn string) (string, error) {
 	if d.config.HostedZoneID != "" {
 		return d.config.HostedZoneID, nil
@@ -268,6 +358,15 @@ func (d *DNSProvider) getHostedZoneID(fqdn string) (string, error) {
 		return "", err
 	}
 
+	return d.findHostedZoneID(authZone)
+}
+
+// findHostedZoneID returns the ID of the hosted zone of authZone.
+func (d *DNSProvider) findHostedZoneID(authZone string) (string, error) {
+	if d.config.HostedZoneID != "" {
+		return d.config.HostedZoneID, nil
+	}
+
 	// .DNSName should not have a trailing dot
 	reqParams := &route53.ListHostedZonesByNameInput{
 		DNSName: aws.String(dns01.UnFqdn(authZone)),
@@ -277,17 +376,9 @@ func (d *DNSProvider) getHostedZoneID(fqdn string) (string, error) {
 		return "", err
 	}
 
-	var hostedZoneID string
-	for _, hostedZone := range resp.HostedZones {
-		// .Name has a trailing dot
-		if !aws.BoolValue(hostedZone.Config.PrivateZone) && aws.StringValue(hostedZone.Name) == authZone {
-			hostedZoneID = aws.StringValue(hostedZone.Id)
-			break
-		}
-	}
-
+	hostedZoneID := findPublicHostedZoneID(resp.HostedZones, authZone)
 	if len(hostedZoneID) == 0 {
-		return "", fmt.Errorf("zone %s not found for domain %s", authZone, fqdn)
+		return "", fmt.Errorf("zone %s not found", authZone)
 	}
 
 	if strings.HasPrefix(hostedZoneID, "/hostedzone/") {
@@ -296,3 +387,19 @@ func (d *DNSProvider) getHostedZoneID(fqdn string) (string, error) {
 
 	return hostedZoneID, nil
 }
+
+// findPublicHostedZoneID returns the ID of the public hosted zone matching the zone name.
+// The trailing dots are normalized: .Name should have a trailing dot, but it's not always the case.
+func findPublicHostedZoneID(hostedZones []*route53.HostedZone, zone string) string {
+	for _, hostedZone := range hostedZones {
+		if aws.BoolValue(hostedZone.Config.PrivateZone) {
+			continue
+		}
+
+		if dns01.ToFqdn(aws.StringValue(hostedZone.Name)) == dns01.ToFqdn(zone) {
+			return aws.StringValue(hostedZone.Id)
+		}
+	}
+
+	return ""
+}
@@ -1,8 +1,12 @@
 package route53
 
 import (
+	"encoding/xml"
+	"io/ioutil"
+	"net/http"
 	"net/http/httptest"
 	"os"
+	"strings"
 	"testing"
 	"time"
 
@@ -23,6 +27,7 @@ var envTest = tester.NewEnvTest(
 	EnvRegion,
 	EnvHostedZoneID,
 	EnvMaxRetries,
+	EnvChangeComment,
 	EnvTTL,
 	EnvPropagationTimeout,
 	EnvPollingInterval).
@@ -103,6 +108,73 @@ func Test_getHostedZoneID_FromEnv(t *testing.T) {
 	assert.Equal(t, expectedZoneID, hostedZoneID)
 }
 
+func Test_findPublicHostedZoneID(t *testing.T) {
+	testCases := []struct {
+		desc        string
+		zone        string
+		hostedZones []*route53.HostedZone
+		expected    string
+	}{
+		{
+			desc: "trailing dots",
+			zone: "example.com.",
+			hostedZones: []*route53.HostedZone{
+				{Id: aws.String("ABCDEFG"), Name: aws.String("example.com."), Config: &route53.HostedZoneConfig{}},
+			},
+			expected: "ABCDEFG",
+		},
+		{
+			desc: "hosted zone without trailing dot",
+			zone: "example.com.",
+			hostedZones: []*route53.HostedZone{
+				{Id: aws.String("ABCDEFG"), Name: aws.String("example.com"), Config: &route53.HostedZoneConfig{}},
+			},
+			expected: "ABCDEFG",
+		},
+		{
+			desc: "zone without trailing dot",
+			zone: "example.com",
+			hostedZones: []*route53.HostedZone{
+				{Id: aws.String("ABCDEFG"), Name: aws.String("example.com."), Config: &route53.HostedZoneConfig{}},
+			},
+			expected: "ABCDEFG",
+		},
+		{
+			desc: "mixed hosted zones",
+			zone: "example.com.",
+			hostedZones: []*route53.HostedZone{
+				{Id: aws.String("HIJKLMN"), Name: aws.String("sub.example.com"), Config: &route53.HostedZoneConfig{}},
+				{Id: aws.String("ABCDEFG"), Name: aws.String("example.com"), Config: &route53.HostedZoneConfig{}},
+				{Id: aws.String("OPQRSTU"), Name: aws.String("example.org."), Config: &route53.HostedZoneConfig{}},
+			},
+			expected: "ABCDEFG",
+		},
+		{
+			desc: "private hosted zone",
+			zone: "example.com.",
+			hostedZones: []*route53.HostedZone{
+				{Id: aws.String("HIJKLMN"), Name: aws.String("example.com"), Config: &route53.HostedZoneConfig{PrivateZone: aws.Bool(true)}},
+				{Id: aws.String("ABCDEFG"), Name: aws.String("example.com."), Config: &route53.HostedZoneConfig{}},
+			},
+			expected: "ABCDEFG",
+		},
+		{
+			desc: "not found",
+			zone: "example.com.",
+			hostedZones: []*route53.HostedZone{
+				{Id: aws.String("ABCDEFG"), Name: aws.String("example.org"), Config: &route53.HostedZoneConfig{}},
+			},
+		},
+	}
+
+	for _, test := range testCases {
+		t.Run(test.desc, func(t *testing.T) {
+			hostedZoneID := findPublicHostedZoneID(test.hostedZones, test.zone)
+			assert.Equal(t, test.expected, hostedZoneID)
+		})
+	}
+}
+
 func TestNewDefaultConfig(t *testing.T) {
 	defer envTest.RestoreEnv()
 
@@ -118,6 +190,7 @@ func TestNewDefaultConfig(t *testing.T) {
 				TTL:                10,
 				PropagationTimeout: 2 * time.Minute,
 				PollingInterval:    4 * time.Second,
+				ChangeComment:      defaultChangeComment,
 			},
 		},
 		{
@@ -128,6 +201,7 @@ func TestNewDefaultConfig(t *testing.T) {
 				EnvPropagationTimeout: "60",
 				EnvPollingInterval:    "60",
 				EnvHostedZoneID:       "abc123",
+				EnvChangeComment:      "ACME {action}",
 			},
 			expected: &Config{
 				MaxRetries:         10,
@@ -135,6 +209,7 @@ func TestNewDefaultConfig(t *testing.T) {
 				PropagationTimeout: 60 * time.Second,
 				PollingInterval:    60 * time.Second,
 				HostedZoneID:       "abc123",
+				ChangeComment:      "ACME {action}",
 			},
 		},
 	}
@@ -177,3 +252,150 @@ func TestDNSProvider_Present(t *testing.T) {
 	err := provider.Present(domain, "", keyAuth)
 	require.NoError(t, err, "Expected Present to return no error")
 }
+
+// newChangeCounterServer returns a mock server counting the change batches and the deleted record sets.
+func newChangeCounterServer(t *testing.T) (*httptest.Server, *int, *int) {
+	t.Helper()
+
+	var batches, deletes int
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/2013-04-01/hostedzone/ABCDEFG/rrset", func(rw http.ResponseWriter, req *http.Request) {
+		_, _ = rw.Write([]byte(ListResourceRecordSetsResponse))
+	})
+	mux.HandleFunc("/2013-04-01/hostedzone/ABCDEFG/rrset/", func(rw http.ResponseWriter, req *http.Request) {
+		body, err := ioutil.ReadAll(req.Body)
+		if err != nil {
+			http.Error(rw, err.Error(), http.StatusBadRequest)
+			return
+		}
+
+		batches++
+		deletes += strings.Count(string(body), "<Action>DELETE</Action>")
+
+		_, _ = rw.Write([]byte(ChangeResourceRecordSetsResponse))
+	})
+	mux.HandleFunc("/2013-04-01/change/123456", func(rw http.ResponseWriter, req *http.Request) {
+		_, _ = rw.Write([]byte(GetChangeResponse))
+	})
+
+	ts := httptest.NewServer(mux)
+	t.Cleanup(ts.Close)
+
+	return ts, &batches, &deletes
+}
+
+func TestDNSProvider_CleanUp_deleteCounts(t *testing.T) {
+	ts, batches, deletes := newChangeCounterServer(t)
+
+	provider := makeTestProvider(ts)
+	provider.config.HostedZoneID = "ABCDEFG"
+
+	for _, domain := range []string{"example.com", "www.example.com"} {
+		err := provider.CleanUp(domain, "", "123d==")
+		require.NoError(t, err)
+	}
+
+	assert.Equal(t, 2, *batches)
+	assert.Equal(t, 2, *deletes)
+}
+
+func TestDNSProvider_CleanUpAll(t *testing.T) {
+	ts, batches, deletes := newChangeCounterServer(t)
+
+	provider := makeTestProvider(ts)
+	provider.config.HostedZoneID = "ABCDEFG"
+
+	err := provider.CleanUpAll("example.com.")
+	require.NoError(t, err)
+
+	assert.Equal(t, 1, *batches)
+	assert.Equal(t, 2, *deletes)
+}
+
+func TestDNSProvider_changeComment(t *testing.T) {
+	testCases := []struct {
+		desc     string
+		comment  string
+		expected []string
+	}{
+		{
+			desc:    "default comment",
+			comment: defaultChangeComment,
+			expected: []string{
+				"Managed by Lego: present example.com",
+				"Managed by Lego: cleanup example.com",
+				"Managed by Lego: cleanup example.com",
+			},
+		},
+		{
+			desc:    "custom comment",
+			comment: "ticket ACME-42 ({action})",
+			expected: []string{
+				"ticket ACME-42 (present)",
+				"ticket ACME-42 (cleanup)",
+				"ticket ACME-42 (cleanup)",
+			},
+		},
+		{
+			desc: "no comment",
+		},
+	}
+
+	for _, test := range testCases {
+		t.Run(test.desc, func(t *testing.T) {
+			var comments []string
+
+			mux := http.NewServeMux()
+			mux.HandleFunc("/2013-04-01/hostedzone/ABCDEFG/rrset", func(rw http.ResponseWriter, req *http.Request) {
+				_, _ = rw.Write([]byte(ListResourceRecordSetsResponse))
+			})
+			mux.HandleFunc("/2013-04-01/hostedzone/ABCDEFG/rrset/", func(rw http.ResponseWriter, req *http.Request) {
+				var input struct {
+					ChangeBatch struct {
+						Comment string
+					}
+				}
+
+				err := xml.NewDecoder(req.Body).Decode(&input)
+				if err != nil {
+					http.Error(rw, err.Error(), http.StatusBadRequest)
+					return
+				}
+
+				if input.ChangeBatch.Comment != "" {
+					comments = append(comments, input.ChangeBatch.Comment)
+				}
+
+				_, _ = rw.Write([]byte(ChangeResourceRecordSetsResponse))
+			})
+			mux.HandleFunc("/2013-04-01/change/123456", func(rw http.ResponseWriter, req *http.Request) {
+				_, _ = rw.Write([]byte(GetChangeResponse))
+			})
+
+			ts := httptest.NewServer(mux)
+			t.Cleanup(ts.Close)
+
+			provider := makeTestProvider(ts)
+			provider.config.HostedZoneID = "ABCDEFG"
+			provider.config.ChangeComment = test.comment
+
+			err := provider.Present("example.com", "", "123d==")
+			require.NoError(t, err)
+
+			err = provider.CleanUp("example.com", "", "123d==")
+			require.NoError(t, err)
+
+			err = provider.CleanUpAll("example.com.")
+			require.NoError(t, err)
+
+			assert.Equal(t, test.expected, comments)
+		})
+	}
+}
+
+func TestDNSProvider_changeComment_truncated(t *testing.T) {
+	provider := &DNSProvider{config: &Config{ChangeComment: strings.Repeat("a", 300) + "{domain}"}}
+
+	assert.Len(t, provider.changeComment("present", "example.com"), maxChangeCommentLength)
+}
@@ -60,23 +60,27 @@ func (d *DNSProvider) cleanupTXTRecord(fqdn, domain string) error {
 	return nil
 }
 
+// zonesPageSize is the number of zones requested per page when listing the zones of the account.
+const zonesPageSize = 100
+
+// getHostedZone returns the most specific zone of the account containing the domain.
 func (d *DNSProvider) getHostedZone(domain string) (*sacloud.DNS, error) {
-	authZone, err := dns01.FindZoneByFqdn(dns01.ToFqdn(domain))
+	zones, err := d.listZones()
 	if err != nil {
 		return nil, err
 	}
 
-	zoneName := dns01.UnFqdn(authZone)
+	var names []string
+	for _, zone := range zones {
+		names = append(names, zone.Name)
+	}
 
-	res, err := d.client.Reset().WithNameLike(zoneName).Find()
-	if err != nil {
-		if notFound, ok := err.(api.Error); ok && notFound.ResponseCode() == http.StatusNotFound {
-			return nil, fmt.Errorf("zone %s not found on SakuraCloud DNS: %w", zoneName, err)
-		}
-		return nil, fmt.Errorf("API call failed: %w", err)
+	zoneName := dns01.FindMostSpecificZone(dns01.ToFqdn(domain), names)
+	if zoneName == "" {
+		return nil, fmt.Errorf("no zone found on SakuraCloud DNS for %s", domain)
 	}
 
-	for _, zone := range res.CommonServiceDNSItems {
+	for _, zone := range zones {
 		if zone.Name == zoneName {
 			return &zone, nil
 		}
@@ -85,6 +89,27 @@ func (d *DNSProvider) getHostedZone(domain string) (*sacloud.DNS, error) {
 	return nil, fmt.Errorf("zone %s not found", zoneName)
 }
 
+// listZones returns all the zones of the account, page by page.
+func (d *DNSProvider) listZones() ([]sacloud.DNS, error) {
+	var zones []sacloud.DNS
+
+	for {
+		res, err := d.client.Reset().Offset(len(zones)).Limit(zonesPageSize).Find()
+		if err != nil {
+			if notFound, ok := err.(api.Error); ok && notFound.ResponseCode() == http.StatusNotFound {
+				return nil, fmt.Errorf("no zone found on SakuraCloud DNS: %w", err)
+			}
+			return nil, fmt.Errorf("API call failed: %w", err)
+		}
+
+		zones = append(zones, res.CommonServiceDNSItems...)
+
+		if len(res.CommonServiceDNSItems) < zonesPageSize || (res.Total > 0 && len(zones) >= res.Total) {
+			return zones, nil
+		}
+	}
+}
+
 func findTxtRecords(fqdn string, zone *sacloud.DNS) []sacloud.DNSRecordSet {
 	recordName := extractRecordName(fqdn, zone.Name)
 
@@ -99,8 +124,8 @@ func findTxtRecords(fqdn string, zone *sacloud.DNS) []sacloud.DNSRecordSet {
 
 func extractRecordName(fqdn, zone string) string {
 	name := dns01.UnFqdn(fqdn)
-	if idx := strings.Index(name, "."+zone); idx != -1 {
-		return name[:idx]
+	if strings.HasSuffix(name, "."+zone) {
+		return strings.TrimSuffix(name, "."+zone)
 	}
 	return name
 }
@@ -10,6 +10,7 @@ import (
 
 	"github.com/sacloud/libsacloud/api"
 	"github.com/sacloud/libsacloud/sacloud"
+	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 )
 
@@ -18,6 +19,8 @@ type simpleResponse struct {
 }
 
 type apiQuery struct {
+	From   int `json:"From"`
+	Count  int `json:"Count"`
 	Filter struct {
 		Name          string `json:"Name"`
 		ProviderClass string `json:"Provider.Class"`
@@ -43,12 +46,7 @@ func TestDNSProvider_addTXTRecord(t *testing.T) {
 		switch req.Method {
 		case http.MethodGet:
 			if len(searchResp.CommonServiceDNSItems) == 0 {
-				q := &apiQuery{}
-				if err := json.Unmarshal([]byte(req.URL.RawQuery), q); err != nil {
-					http.Error(rw, err.Error(), http.StatusServiceUnavailable)
-				}
-
-				fakeZone := sacloud.CreateNewDNS(q.Filter.Name)
+				fakeZone := sacloud.CreateNewDNS("example.com")
 				fakeZone.ID = 123456789012
 				searchResp = &api.SearchDNSResponse{CommonServiceDNSItems: []sacloud.DNS{*fakeZone}}
 			}
@@ -105,12 +103,7 @@ func TestDNSProvider_cleanupTXTRecord(t *testing.T) {
 		switch req.Method {
 		case http.MethodGet:
 			if len(searchResp.CommonServiceDNSItems) == 0 {
-				q := &apiQuery{}
-				if err := json.Unmarshal([]byte(req.URL.RawQuery), q); err != nil {
-					http.Error(rw, err.Error(), http.StatusServiceUnavailable)
-				}
-
-				fakeZone := sacloud.CreateNewDNS(q.Filter.Name)
+				fakeZone := sacloud.CreateNewDNS("example.com")
 				fakeZone.ID = 123456789012
 				fakeZone.CreateNewRecord("test", "TXT", "dummyValue", 10)
 				searchResp = &api.SearchDNSResponse{CommonServiceDNSItems: []sacloud.DNS{*fakeZone}}
@@ -168,12 +161,7 @@ func TestDNSProvider_addTXTRecord_concurrent(t *testing.T) {
 		switch req.Method {
 		case http.MethodGet:
 			if len(searchResp.CommonServiceDNSItems) == 0 {
-				q := &apiQuery{}
-				if err := json.Unmarshal([]byte(req.URL.RawQuery), q); err != nil {
-					http.Error(rw, err.Error(), http.StatusServiceUnavailable)
-				}
-
-				fakeZone := sacloud.CreateNewDNS(q.Filter.Name)
+				fakeZone := sacloud.CreateNewDNS("example.com")
 				fakeZone.ID = 123456789012
 				searchResp = &api.SearchDNSResponse{CommonServiceDNSItems: []sacloud.DNS{*fakeZone}}
 			}
@@ -318,3 +306,96 @@ func TestDNSProvider_cleanupTXTRecord_concurrent(t *testing.T) {
 
 	require.Len(t, updZone.Settings.DNS.ResourceRecordSets, 0)
 }
+
+func TestDNSProvider_getHostedZone(t *testing.T) {
+	// many zones, with nested zones and zones sharing a suffix.
+	var zones []sacloud.DNS
+	for i := 0; i < 250; i++ {
+		zones = append(zones, *sacloud.CreateNewDNS(fmt.Sprintf("example%d.com", i)))
+	}
+	zones = append(zones,
+		*sacloud.CreateNewDNS("example.com"),
+		*sacloud.CreateNewDNS("sub.example.com"),
+		*sacloud.CreateNewDNS("myexample.com"),
+	)
+
+	var pages int
+	tearDown := fakeAPIServer(func(rw http.ResponseWriter, req *http.Request) {
+		q := &apiQuery{}
+		if err := json.Unmarshal([]byte(req.URL.RawQuery), q); err != nil {
+			http.Error(rw, err.Error(), http.StatusBadRequest)
+			return
+		}
+
+		pages++
+
+		end := q.From + q.Count
+		if end > len(zones) {
+			end = len(zones)
+		}
+
+		searchResp := &api.SearchDNSResponse{
+			Total:                 len(zones),
+			From:                  q.From,
+			Count:                 end - q.From,
+			CommonServiceDNSItems: zones[q.From:end],
+		}
+
+		if err := json.NewEncoder(rw).Encode(searchResp); err != nil {
+			http.Error(rw, err.Error(), http.StatusServiceUnavailable)
+		}
+	})
+	defer tearDown()
+
+	config := NewDefaultConfig()
+	config.Token = "token5"
+	config.Secret = "secret5"
+
+	p, err := NewDNSProviderConfig(config)
+	require.NoError(t, err)
+
+	testCases := []struct {
+		domain   string
+		expected string
+	}{
+		{domain: "example.com", expected: "example.com"},
+		{domain: "www.example.com", expected: "example.com"},
+		{domain: "sub.example.com", expected: "sub.example.com"},
+		{domain: "www.sub.example.com", expected: "sub.example.com"},
+		{domain: "www.myexample.com", expected: "myexample.com"},
+		{domain: "www.example249.com", expected: "example249.com"},
+	}
+
+	for _, test := range testCases {
+		t.Run(test.domain, func(t *testing.T) {
+			pages = 0
+
+			zone, err := p.getHostedZone(test.domain)
+			require.NoError(t, err)
+
+			assert.Equal(t, test.expected, zone.Name)
+			assert.Equal(t, 3, pages)
+		})
+	}
+
+	_, err = p.getHostedZone("example.org")
+	require.EqualError(t, err, "no zone found on SakuraCloud DNS for example.org")
+}
+
+func Test_extractRecordName(t *testing.T) {
+	testCases := []struct {
+		fqdn     string
+		zone     string
+		expected string
+	}{
+		{fqdn: "_acme-challenge.example.com.", zone: "example.com", expected: "_acme-challenge"},
+		{fqdn: "_acme-challenge.www.sub.example.com.", zone: "sub.example.com", expected: "_acme-challenge.www"},
+		{fqdn: "_acme-challenge.example.com.example.com.", zone: "example.com", expected: "_acme-challenge.example.com"},
+	}
+
+	for _, test := range testCases {
+		t.Run(test.fqdn, func(t *testing.T) {
+			assert.Equal(t, test.expected, extractRecordName(test.fqdn, test.zone))
+		})
+	}
+}
@@ -0,0 +1,142 @@
+package internal
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"path"
+	"strconv"
+	"strings"
+)
+
+// DefaultBaseURL the URL of the API.
+const DefaultBaseURL = "https://api.simply.com/2/"
+
+// Client the Simply.com API client.
+type Client struct {
+	HTTPClient *http.Client
+	BaseURL    string
+
+	accountName string
+	apiKey      string
+}
+
+// NewClient creates a new Client.
+func NewClient(accountName, apiKey string) *Client {
+	return &Client{
+		HTTPClient:  http.DefaultClient,
+		BaseURL:     DefaultBaseURL,
+		accountName: accountName,
+		apiKey:      apiKey,
+	}
+}
+
+// GetProducts gets the products (domains) of the account.
+// https://www.simply.com/en/docs/api/
+func (c *Client) GetProducts() ([]Product, error) {
+	resp := &productsResponse{}
+	err := c.do(http.MethodGet, resp, nil, "my", "products")
+	if err != nil {
+		return nil, err
+	}
+
+	return resp.Products, nil
+}
+
+// GetRecords gets the DNS records of a product.
+func (c *Client) GetRecords(object string) ([]Record, error) {
+	resp := &recordsResponse{}
+	err := c.do(http.MethodGet, resp, nil, "my", "products", object, "dns", "records")
+	if err != nil {
+		return nil, err
+	}
+
+	return resp.Records, nil
+}
+
+// AddRecord adds a DNS record to a product, and returns the ID of the record.
+func (c *Client) AddRecord(object string, record Record) (int64, error) {
+	body, err := json.Marshal(record)
+	if err != nil {
+		return 0, fmt.Errorf("failed to marshal request body: %w", err)
+	}
+
+	resp := &addRecordResponse{}
+	err = c.do(http.MethodPost, resp, bytes.NewReader(body), "my", "products", object, "dns", "records")
+	if err != nil {
+		return 0, err
+	}
+
+	return resp.Record.ID, nil
+}
+
+// DeleteRecord deletes a DNS record of a product.
+func (c *Client) DeleteRecord(object string, id int64) error {
+	resp := &apiResponse{}
+	return c.do(http.MethodDelete, resp, nil, "my", "products", object, "dns", "records", strconv.FormatInt(id, 10))
+}
+
+func (c *Client) do(method string, result statusResponse, body io.Reader, parts ...string) error {
+	endpoint, err := c.createEndpoint(parts...)
+	if err != nil {
+		return fmt.Errorf("failed to create endpoint: %w", err)
+	}
+
+	req, err := http.NewRequest(method, endpoint.String(), body)
+	if err != nil {
+		return err
+	}
+
+	req.Header.Set("Accept", "application/json")
+	if body != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+
+	req.SetBasicAuth(c.accountName, c.apiKey)
+
+	resp, err := c.HTTPClient.Do(req)
+	if err != nil {
+		return err
+	}
+
+	defer func() { _ = resp.Body.Close() }()
+
+	raw, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return fmt.Errorf("failed to read response body: %w", err)
+	}
+
+	err = json.Unmarshal(raw, result)
+	if err != nil {
+		return fmt.Errorf("unexpected response: %d: %s", resp.StatusCode, strings.TrimSpace(string(raw)))
+	}
+
+	status, message := result.status()
+	if resp.StatusCode != http.StatusOK || status != http.StatusOK {
+		if status == 0 {
+			status = resp.StatusCode
+		}
+
+		return APIError{Status: status, Message: message}
+	}
+
+	return nil
+}
+
+func (c *Client) createEndpoint(parts ...string) (*url.URL, error) {
+	baseURL, err := url.Parse(c.BaseURL)
+	if err != nil {
+		return nil, err
+	}
+
+	escaped := make([]string, len(parts))
+	for i, part := range parts {
+		escaped[i] = url.PathEscape(part)
+	}
+
+	return baseURL.Parse(path.Join(escaped...))
+}
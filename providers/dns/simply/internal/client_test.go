@@ -0,0 +1,137 @@
+package internal
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func setupTest(t *testing.T) (*Client, *http.ServeMux) {
+	t.Helper()
+
+	mux := http.NewServeMux()
+	server := httptest.NewServer(mux)
+	t.Cleanup(server.Close)
+
+	client := NewClient("S000000", "secret")
+	client.BaseURL = server.URL + "/"
+
+	return client, mux
+}
+
+// fixtureHandler checks the method and the credentials, and writes the fixture.
+func fixtureHandler(method string, status int, filename string) http.HandlerFunc {
+	return func(rw http.ResponseWriter, req *http.Request) {
+		if req.Method != method {
+			http.Error(rw, fmt.Sprintf("unsupported method: %s", req.Method), http.StatusMethodNotAllowed)
+			return
+		}
+
+		user, password, ok := req.BasicAuth()
+		if !ok || user != "S000000" || password != "secret" {
+			http.Error(rw, `{"status":401,"message":"Invalid credentials"}`, http.StatusUnauthorized)
+			return
+		}
+
+		file, err := os.Open(filename)
+		if err != nil {
+			http.Error(rw, err.Error(), http.StatusInternalServerError)
+			return
+		}
+		defer func() { _ = file.Close() }()
+
+		rw.WriteHeader(status)
+		_, _ = io.Copy(rw, file)
+	}
+}
+
+func TestClient_GetProducts(t *testing.T) {
+	client, mux := setupTest(t)
+	mux.HandleFunc("/my/products", fixtureHandler(http.MethodGet, http.StatusOK, "./fixtures/products.json"))
+
+	products, err := client.GetProducts()
+	require.NoError(t, err)
+
+	expected := []Product{
+		{Object: "S123456", Domain: ProductDomain{Name: "example.com", NameIDN: "example.com"}},
+		{Object: "S123457", Domain: ProductDomain{Name: "sub.example.com", NameIDN: "sub.example.com"}},
+	}
+	assert.Equal(t, expected, products)
+}
+
+func TestClient_GetRecords(t *testing.T) {
+	client, mux := setupTest(t)
+	mux.HandleFunc("/my/products/S123456/dns/records", fixtureHandler(http.MethodGet, http.StatusOK, "./fixtures/records.json"))
+
+	records, err := client.GetRecords("S123456")
+	require.NoError(t, err)
+
+	expected := []Record{
+		{ID: 123, Name: "@", Type: "NS", Data: "ns1.simply.com", TTL: 3600},
+		{ID: 456, Name: "_acme-challenge", Type: "TXT", Data: "txtTXTtxt", TTL: 120},
+	}
+	assert.Equal(t, expected, records)
+}
+
+func TestClient_AddRecord(t *testing.T) {
+	client, mux := setupTest(t)
+
+	mux.HandleFunc("/my/products/S123456/dns/records", func(rw http.ResponseWriter, req *http.Request) {
+		if req.Method != http.MethodPost {
+			http.Error(rw, fmt.Sprintf("unsupported method: %s", req.Method), http.StatusMethodNotAllowed)
+			return
+		}
+
+		var record Record
+		err := json.NewDecoder(req.Body).Decode(&record)
+		if err != nil {
+			http.Error(rw, err.Error(), http.StatusBadRequest)
+			return
+		}
+
+		expected := Record{Name: "_acme-challenge", Type: "TXT", Data: "txtTXTtxt", TTL: 120}
+		if record != expected {
+			http.Error(rw, fmt.Sprintf("unexpected record: %+v", record), http.StatusBadRequest)
+			return
+		}
+
+		fixtureHandler(http.MethodPost, http.StatusOK, "./fixtures/add_record.json")(rw, req)
+	})
+
+	id, err := client.AddRecord("S123456", Record{Name: "_acme-challenge", Type: "TXT", Data: "txtTXTtxt", TTL: 120})
+	require.NoError(t, err)
+
+	assert.Equal(t, int64(789), id)
+}
+
+func TestClient_DeleteRecord(t *testing.T) {
+	client, mux := setupTest(t)
+	mux.HandleFunc("/my/products/S123456/dns/records/456", fixtureHandler(http.MethodDelete, http.StatusOK, "./fixtures/delete_record.json"))
+
+	err := client.DeleteRecord("S123456", 456)
+	require.NoError(t, err)
+}
+
+func TestClient_DeleteRecord_error(t *testing.T) {
+	client, mux := setupTest(t)
+	mux.HandleFunc("/my/products/S123456/dns/records/456", fixtureHandler(http.MethodDelete, http.StatusBadRequest, "./fixtures/error.json"))
+
+	err := client.DeleteRecord("S123456", 456)
+	require.EqualError(t, err, "400: Record not found")
+}
+
+func TestClient_invalidCredentials(t *testing.T) {
+	client, mux := setupTest(t)
+	mux.HandleFunc("/my/products", fixtureHandler(http.MethodGet, http.StatusOK, "./fixtures/products.json"))
+	client.apiKey = "invalid"
+
+	_, err := client.GetProducts()
+	require.EqualError(t, err, "401: Invalid credentials")
+}
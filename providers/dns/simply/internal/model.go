@@ -0,0 +1,65 @@
+package internal
+
+import "fmt"
+
+// APIError is the error returned by the API.
+type APIError struct {
+	Status  int    `json:"status"`
+	Message string `json:"message"`
+}
+
+func (e APIError) Error() string {
+	return fmt.Sprintf("%d: %s", e.Status, e.Message)
+}
+
+type apiResponse struct {
+	Status  int    `json:"status"`
+	Message string `json:"message"`
+}
+
+// Product a product (domain) of the account.
+type Product struct {
+	Object string        `json:"object"`
+	Domain ProductDomain `json:"domain"`
+}
+
+// ProductDomain the domain of a product.
+type ProductDomain struct {
+	Name    string `json:"name"`
+	NameIDN string `json:"name_idn"`
+}
+
+type productsResponse struct {
+	apiResponse
+	Products []Product `json:"products"`
+}
+
+// Record a DNS record.
+type Record struct {
+	ID       int64  `json:"record_id,omitempty"`
+	Name     string `json:"name"`
+	Type     string `json:"type"`
+	Data     string `json:"data"`
+	TTL      int    `json:"ttl"`
+	Priority int    `json:"priority"`
+}
+
+type recordsResponse struct {
+	apiResponse
+	Records []Record `json:"records"`
+}
+
+type addRecordResponse struct {
+	apiResponse
+	Record struct {
+		ID int64 `json:"id"`
+	} `json:"record"`
+}
+
+type statusResponse interface {
+	status() (int, string)
+}
+
+func (r apiResponse) status() (int, string) {
+	return r.Status, r.Message
+}
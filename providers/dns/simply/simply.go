@@ -0,0 +1,228 @@
+// Package simply implements a DNS provider for solving the DNS-01 challenge using Simply.com (formerly UnoEuro).
+package simply
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/go-acme/lego/v4/challenge/dns01"
+	"github.com/go-acme/lego/v4/platform/config/env"
+	"github.com/go-acme/lego/v4/providers/dns/internal/useragent"
+	"github.com/go-acme/lego/v4/providers/dns/simply/internal"
+)
+
+// The minimum TTL accepted by the API.
+const minTTL = 60
+
+// Environment variables names.
+const (
+	envNamespace = "SIMPLY_"
+
+	EnvAccountName = envNamespace + "ACCOUNT_NAME"
+	EnvAPIKey      = envNamespace + "API_KEY"
+	EnvAPIURL      = envNamespace + "API_URL"
+
+	EnvTTL                = envNamespace + "TTL"
+	EnvPropagationTimeout = envNamespace + "PROPAGATION_TIMEOUT"
+	EnvPollingInterval    = envNamespace + "POLLING_INTERVAL"
+	EnvHTTPTimeout        = envNamespace + "HTTP_TIMEOUT"
+)
+
+// Config is used to configure the creation of the DNSProvider.
+type Config struct {
+	BaseURL            string
+	AccountName        string
+	APIKey             string
+	PropagationTimeout time.Duration
+	PollingInterval    time.Duration
+	TTL                int
+	HTTPClient         *http.Client
+}
+
+// NewDefaultConfig returns a default configuration for the DNSProvider.
+func NewDefaultConfig() *Config {
+	return &Config{
+		BaseURL:            env.GetOrDefaultString(EnvAPIURL, internal.DefaultBaseURL),
+		TTL:                env.GetOrDefaultInt(EnvTTL, dns01.DefaultTTL),
+		PropagationTimeout: env.GetOrDefaultSecond(EnvPropagationTimeout, 5*time.Minute),
+		PollingInterval:    env.GetOrDefaultSecond(EnvPollingInterval, 10*time.Second),
+		HTTPClient: useragent.Wrap(&http.Client{
+			Timeout: env.GetOrDefaultSecond(EnvHTTPTimeout, 30*time.Second),
+		}),
+	}
+}
+
+// DNSProvider implements the challenge.Provider interface.
+type DNSProvider struct {
+	config *Config
+	client *internal.Client
+
+	records   map[string]txtRecord
+	recordsMu sync.Mutex
+}
+
+type txtRecord struct {
+	object string
+	id     int64
+}
+
+// NewDNSProvider returns a DNSProvider instance configured for Simply.com.
+// Credentials must be passed in the environment variables: SIMPLY_ACCOUNT_NAME, SIMPLY_API_KEY.
+func NewDNSProvider() (*DNSProvider, error) {
+	values, err := env.Get(EnvAccountName, EnvAPIKey)
+	if err != nil {
+		return nil, fmt.Errorf("simply: %w", err)
+	}
+
+	config := NewDefaultConfig()
+	config.AccountName = values[EnvAccountName]
+	config.APIKey = values[EnvAPIKey]
+
+	return NewDNSProviderConfig(config)
+}
+
+// NewDNSProviderConfig return a DNSProvider instance configured for Simply.com.
+func NewDNSProviderConfig(config *Config) (*DNSProvider, error) {
+	if config == nil {
+		return nil, errors.New("simply: the configuration of the DNS provider is nil")
+	}
+
+	if config.AccountName == "" || config.APIKey == "" {
+		return nil, errors.New("simply: credentials missing")
+	}
+
+	if config.TTL < minTTL {
+		return nil, fmt.Errorf("simply: invalid TTL, TTL (%d) must be greater than %d", config.TTL, minTTL)
+	}
+
+	client := internal.NewClient(config.AccountName, config.APIKey)
+
+	if config.BaseURL != "" {
+		client.BaseURL = config.BaseURL
+	}
+
+	if config.HTTPClient != nil {
+		client.HTTPClient = config.HTTPClient
+	}
+
+	return &DNSProvider{
+		config:  config,
+		client:  client,
+		records: make(map[string]txtRecord),
+	}, nil
+}
+
+// Timeout returns the timeout and interval to use when checking for DNS propagation.
+// Adjusting here to cope with spikes in propagation times.
+func (d *DNSProvider) Timeout() (timeout, interval time.Duration) {
+	return d.config.PropagationTimeout, d.config.PollingInterval
+}
+
+// Present creates a TXT record to fulfill the dns-01 challenge.
+func (d *DNSProvider) Present(domain, token, keyAuth string) error {
+	fqdn, value := dns01.GetRecord(domain, keyAuth)
+
+	product, err := d.findProduct(fqdn)
+	if err != nil {
+		return fmt.Errorf("simply: %w", err)
+	}
+
+	record := internal.Record{
+		Name: extractRecordName(fqdn, product.Domain.Name),
+		Type: "TXT",
+		Data: value,
+		TTL:  d.config.TTL,
+	}
+
+	id, err := d.client.AddRecord(product.Object, record)
+	if err != nil {
+		return fmt.Errorf("simply: failed to create TXT record: fqdn=%s, domain=%s: %w", fqdn, product.Domain.Name, err)
+	}
+
+	d.recordsMu.Lock()
+	d.records[token] = txtRecord{object: product.Object, id: id}
+	d.recordsMu.Unlock()
+
+	return nil
+}
+
+// CleanUp removes the TXT record matching the specified parameters.
+func (d *DNSProvider) CleanUp(domain, token, keyAuth string) error {
+	fqdn, _ := dns01.GetRecord(domain, keyAuth)
+
+	// gets the record's unique ID from when we created it
+	d.recordsMu.Lock()
+	record, ok := d.records[token]
+	d.recordsMu.Unlock()
+	if !ok {
+		return fmt.Errorf("simply: unknown record ID for '%s'", fqdn)
+	}
+
+	err := d.client.DeleteRecord(record.object, record.id)
+	if err != nil {
+		return fmt.Errorf("simply: failed to delete TXT record: fqdn=%s, recordID=%d: %w", fqdn, record.id, err)
+	}
+
+	// deletes record ID from map
+	d.recordsMu.Lock()
+	delete(d.records, token)
+	d.recordsMu.Unlock()
+
+	return nil
+}
+
+// Zones returns the domains of the account.
+func (d *DNSProvider) Zones() ([]string, error) {
+	products, err := d.client.GetProducts()
+	if err != nil {
+		return nil, fmt.Errorf("simply: failed to get products: %w", err)
+	}
+
+	var zones []string
+	for _, product := range products {
+		zones = append(zones, product.Domain.Name)
+	}
+
+	return zones, nil
+}
+
+// findProduct finds the product of the account with the most specific domain containing the fqdn.
+func (d *DNSProvider) findProduct(fqdn string) (internal.Product, error) {
+	products, err := d.client.GetProducts()
+	if err != nil {
+		return internal.Product{}, fmt.Errorf("failed to get products: %w", err)
+	}
+
+	var names []string
+	for _, product := range products {
+		names = append(names, product.Domain.Name)
+	}
+
+	name := dns01.FindMostSpecificZone(fqdn, names)
+	if name == "" {
+		return internal.Product{}, fmt.Errorf("no domain found for %s", dns01.UnFqdn(fqdn))
+	}
+
+	for _, product := range products {
+		if product.Domain.Name == name {
+			return product, nil
+		}
+	}
+
+	return internal.Product{}, fmt.Errorf("no domain found for %s", dns01.UnFqdn(fqdn))
+}
+
+// extractRecordName returns the name of the record relatively to the domain.
+// The apex of the domain is represented by "@".
+func extractRecordName(fqdn, domain string) string {
+	name := dns01.UnFqdn(fqdn)
+	if strings.EqualFold(name, domain) {
+		return "@"
+	}
+
+	return strings.TrimSuffix(name, "."+domain)
+}
@@ -0,0 +1,271 @@
+package simply
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/go-acme/lego/v4/platform/tester"
+	"github.com/go-acme/lego/v4/providers/dns/simply/internal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const envDomain = envNamespace + "DOMAIN"
+
+var envTest = tester.NewEnvTest(EnvAccountName, EnvAPIKey, EnvAPIURL).WithDomain(envDomain)
+
+const responseProducts = `{"products":[` +
+	`{"object":"S123456","domain":{"name":"example.com","name_idn":"example.com"}},` +
+	`{"object":"S123457","domain":{"name":"sub.example.com","name_idn":"sub.example.com"}}` +
+	`],"status":200,"message":"OK"}`
+
+func TestNewDNSProvider(t *testing.T) {
+	testCases := []struct {
+		desc     string
+		envVars  map[string]string
+		expected string
+	}{
+		{
+			desc: "success",
+			envVars: map[string]string{
+				EnvAccountName: "S000000",
+				EnvAPIKey:      "secret",
+			},
+		},
+		{
+			desc: "missing credentials",
+			envVars: map[string]string{
+				EnvAccountName: "",
+				EnvAPIKey:      "",
+			},
+			expected: "simply: some credentials information are missing: SIMPLY_ACCOUNT_NAME,SIMPLY_API_KEY",
+		},
+		{
+			desc: "missing account name",
+			envVars: map[string]string{
+				EnvAccountName: "",
+				EnvAPIKey:      "secret",
+			},
+			expected: "simply: some credentials information are missing: SIMPLY_ACCOUNT_NAME",
+		},
+		{
+			desc: "missing API key",
+			envVars: map[string]string{
+				EnvAccountName: "S000000",
+				EnvAPIKey:      "",
+			},
+			expected: "simply: some credentials information are missing: SIMPLY_API_KEY",
+		},
+	}
+
+	for _, test := range testCases {
+		t.Run(test.desc, func(t *testing.T) {
+			defer envTest.RestoreEnv()
+			envTest.ClearEnv()
+
+			envTest.Apply(test.envVars)
+
+			p, err := NewDNSProvider()
+
+			if len(test.expected) == 0 {
+				require.NoError(t, err)
+				require.NotNil(t, p)
+				require.NotNil(t, p.config)
+				require.NotNil(t, p.client)
+			} else {
+				require.EqualError(t, err, test.expected)
+			}
+		})
+	}
+}
+
+func TestNewDNSProviderConfig(t *testing.T) {
+	testCases := []struct {
+		desc        string
+		accountName string
+		apiKey      string
+		ttl         int
+		expected    string
+	}{
+		{
+			desc:        "success",
+			accountName: "S000000",
+			apiKey:      "secret",
+			ttl:         minTTL,
+		},
+		{
+			desc:     "missing credentials",
+			ttl:      minTTL,
+			expected: "simply: credentials missing",
+		},
+		{
+			desc:        "missing API key",
+			accountName: "S000000",
+			ttl:         minTTL,
+			expected:    "simply: credentials missing",
+		},
+		{
+			desc:        "invalid TTL",
+			accountName: "S000000",
+			apiKey:      "secret",
+			ttl:         30,
+			expected:    "simply: invalid TTL, TTL (30) must be greater than 60",
+		},
+	}
+
+	for _, test := range testCases {
+		t.Run(test.desc, func(t *testing.T) {
+			config := NewDefaultConfig()
+			config.AccountName = test.accountName
+			config.APIKey = test.apiKey
+			config.TTL = test.ttl
+
+			p, err := NewDNSProviderConfig(config)
+
+			if len(test.expected) == 0 {
+				require.NoError(t, err)
+				require.NotNil(t, p)
+				require.NotNil(t, p.config)
+				require.NotNil(t, p.client)
+			} else {
+				require.EqualError(t, err, test.expected)
+			}
+		})
+	}
+}
+
+func setupTest(t *testing.T) (*DNSProvider, *http.ServeMux) {
+	t.Helper()
+
+	mux := http.NewServeMux()
+	server := httptest.NewServer(mux)
+	t.Cleanup(server.Close)
+
+	mux.HandleFunc("/my/products", func(rw http.ResponseWriter, req *http.Request) {
+		_, _ = fmt.Fprint(rw, responseProducts)
+	})
+
+	config := NewDefaultConfig()
+	config.BaseURL = server.URL + "/"
+	config.AccountName = "S000000"
+	config.APIKey = "secret"
+
+	provider, err := NewDNSProviderConfig(config)
+	require.NoError(t, err)
+
+	return provider, mux
+}
+
+func TestDNSProvider_Present(t *testing.T) {
+	provider, mux := setupTest(t)
+
+	var created []internal.Record
+	mux.HandleFunc("/my/products/S123457/dns/records", func(rw http.ResponseWriter, req *http.Request) {
+		if req.Method != http.MethodPost {
+			http.Error(rw, fmt.Sprintf("unsupported method: %s", req.Method), http.StatusMethodNotAllowed)
+			return
+		}
+
+		var record internal.Record
+		err := json.NewDecoder(req.Body).Decode(&record)
+		if err != nil {
+			http.Error(rw, err.Error(), http.StatusBadRequest)
+			return
+		}
+
+		created = append(created, record)
+
+		_, _ = fmt.Fprint(rw, `{"record":{"id":789},"status":200,"message":"Record created"}`)
+	})
+
+	err := provider.Present("www.sub.example.com", "token", "123d==")
+	require.NoError(t, err)
+
+	expected := []internal.Record{
+		{Name: "_acme-challenge.www", Type: "TXT", Data: "ADw2sEd82DUgXcQ9hNBZThJs7zVJkR5v9JeSbAb9mZY", TTL: 120},
+	}
+	assert.Equal(t, expected, created)
+
+	assert.Equal(t, map[string]txtRecord{"token": {object: "S123457", id: 789}}, provider.records)
+}
+
+func TestDNSProvider_Present_unknownDomain(t *testing.T) {
+	provider, _ := setupTest(t)
+
+	err := provider.Present("www.example.org", "token", "123d==")
+	require.EqualError(t, err, "simply: no domain found for _acme-challenge.www.example.org")
+}
+
+func TestDNSProvider_Present_error(t *testing.T) {
+	provider, mux := setupTest(t)
+
+	mux.HandleFunc("/my/products/S123456/dns/records", func(rw http.ResponseWriter, req *http.Request) {
+		rw.WriteHeader(http.StatusBadRequest)
+		_, _ = fmt.Fprint(rw, `{"status":400,"message":"Invalid TTL"}`)
+	})
+
+	err := provider.Present("example.com", "token", "123d==")
+	require.EqualError(t, err, "simply: failed to create TXT record: fqdn=_acme-challenge.example.com., domain=example.com: 400: Invalid TTL")
+}
+
+func TestDNSProvider_CleanUp(t *testing.T) {
+	provider, mux := setupTest(t)
+
+	var deleted int
+	mux.HandleFunc("/my/products/S123456/dns/records/789", func(rw http.ResponseWriter, req *http.Request) {
+		if req.Method != http.MethodDelete {
+			http.Error(rw, fmt.Sprintf("unsupported method: %s", req.Method), http.StatusMethodNotAllowed)
+			return
+		}
+
+		deleted++
+
+		_, _ = fmt.Fprint(rw, `{"status":200,"message":"Record removed"}`)
+	})
+
+	provider.records["token"] = txtRecord{object: "S123456", id: 789}
+
+	err := provider.CleanUp("example.com", "token", "123d==")
+	require.NoError(t, err)
+
+	assert.Equal(t, 1, deleted)
+	assert.Empty(t, provider.records)
+}
+
+func TestDNSProvider_CleanUp_unknownRecord(t *testing.T) {
+	provider, _ := setupTest(t)
+
+	err := provider.CleanUp("www.example.com", "token", "123d==")
+	require.EqualError(t, err, "simply: unknown record ID for '_acme-challenge.www.example.com.'")
+}
+
+func TestDNSProvider_Zones(t *testing.T) {
+	provider, _ := setupTest(t)
+
+	zones, err := provider.Zones()
+	require.NoError(t, err)
+
+	assert.Equal(t, []string{"example.com", "sub.example.com"}, zones)
+}
+
+func TestLivePresentAndCleanUp(t *testing.T) {
+	if !envTest.IsLiveTest() {
+		t.Skip("skipping live test")
+	}
+
+	envTest.RestoreEnv()
+	provider, err := NewDNSProvider()
+	require.NoError(t, err)
+
+	err = provider.Present(envTest.GetDomain(), "", "123d==")
+	require.NoError(t, err)
+
+	time.Sleep(1 * time.Second)
+
+	err = provider.CleanUp(envTest.GetDomain(), "", "123d==")
+	require.NoError(t, err)
+}
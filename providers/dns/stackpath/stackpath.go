@@ -14,6 +14,7 @@ import (
 	"github.com/go-acme/lego/v4/challenge/dns01"
 	"github.com/go-acme/lego/v4/log"
 	"github.com/go-acme/lego/v4/platform/config/env"
+	"github.com/go-acme/lego/v4/providers/dns/internal/useragent"
 	"golang.org/x/oauth2/clientcredentials"
 )
 
@@ -108,7 +109,7 @@ func getOathClient(config *Config) *http.Client {
 		ClientSecret: config.ClientSecret,
 	}
 
-	return oathConfig.Client(context.Background())
+	return useragent.Wrap(oathConfig.Client(context.Background()))
 }
 
 // Present creates a TXT record to fulfill the dns-01 challenge.
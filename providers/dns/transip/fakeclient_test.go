@@ -101,8 +101,9 @@ func (f *fakeClient) Delete(request rest.Request) error {
 
 	cp := make([]domain.DNSEntry, 0)
 
+	// the API removes only the entry matching all the fields.
 	for _, e := range f.dnsEntries {
-		if e.Name == entry.DNSEntry.Name {
+		if e == entry.DNSEntry {
 			continue
 		}
 
@@ -4,11 +4,12 @@ package transip
 import (
 	"errors"
 	"fmt"
-	"strings"
+	"net/http"
 	"time"
 
 	"github.com/go-acme/lego/v4/challenge/dns01"
 	"github.com/go-acme/lego/v4/platform/config/env"
+	"github.com/go-acme/lego/v4/providers/dns/internal/useragent"
 	"github.com/transip/gotransip/v6"
 	transipdomain "github.com/transip/gotransip/v6/domain"
 )
@@ -47,6 +48,11 @@ func NewDefaultConfig() *Config {
 type DNSProvider struct {
 	config     *Config
 	repository transipdomain.Repository
+
+	// findZoneByFqdn determines the DNS zone of an fqdn. It is overridden during tests.
+	findZoneByFqdn func(fqdn string) (string, error)
+
+	zoneRewriter dns01.ZoneRewriter
 }
 
 // NewDNSProvider returns a DNSProvider instance configured for TransIP.
@@ -74,6 +80,7 @@ func NewDNSProviderConfig(config *Config) (*DNSProvider, error) {
 	client, err := gotransip.NewClient(gotransip.ClientConfiguration{
 		AccountName:    config.AccountName,
 		PrivateKeyPath: config.PrivateKeyPath,
+		HTTPClient:     useragent.Wrap(&http.Client{}),
 	})
 	if err != nil {
 		return nil, fmt.Errorf("transip: %w", err)
@@ -81,7 +88,11 @@ func NewDNSProviderConfig(config *Config) (*DNSProvider, error) {
 
 	repo := transipdomain.Repository{Client: client}
 
-	return &DNSProvider{repository: repo, config: config}, nil
+	return &DNSProvider{
+		repository:     repo,
+		config:         config,
+		findZoneByFqdn: dns01.FindZoneByFqdn,
+	}, nil
 }
 
 // Timeout returns the timeout and interval to use when checking for DNS propagation.
@@ -90,19 +101,27 @@ func (d *DNSProvider) Timeout() (timeout, interval time.Duration) {
 	return d.config.PropagationTimeout, d.config.PollingInterval
 }
 
+// SetZoneRewriter sets the rewriter of the zones of the records (see dns01.AddZoneRewriter).
+func (d *DNSProvider) SetZoneRewriter(rewriter dns01.ZoneRewriter) {
+	d.zoneRewriter = rewriter
+}
+
 // Present creates a TXT record to fulfill the dns-01 challenge.
 func (d *DNSProvider) Present(domain, token, keyAuth string) error {
 	fqdn, value := dns01.GetRecord(domain, keyAuth)
 
-	authZone, err := dns01.FindZoneByFqdn(fqdn)
+	authZone, err := d.findZoneByFqdn(fqdn)
 	if err != nil {
 		return err
 	}
 
-	domainName := dns01.UnFqdn(authZone)
+	// get the subDomain, and the zone to write to (see dns01.AddZoneRewriter)
+	authZone, subDomain, err := dns01.RewriteZone(fqdn, authZone, d.zoneRewriter)
+	if err != nil {
+		return fmt.Errorf("transip: %w", err)
+	}
 
-	// get the subDomain
-	subDomain := strings.TrimSuffix(dns01.UnFqdn(fqdn), "."+domainName)
+	domainName := dns01.UnFqdn(authZone)
 
 	entry := transipdomain.DNSEntry{
 		Name:    subDomain,
@@ -123,15 +142,18 @@ func (d *DNSProvider) Present(domain, token, keyAuth string) error {
 func (d *DNSProvider) CleanUp(domain, token, keyAuth string) error {
 	fqdn, value := dns01.GetRecord(domain, keyAuth)
 
-	authZone, err := dns01.FindZoneByFqdn(fqdn)
+	authZone, err := d.findZoneByFqdn(fqdn)
 	if err != nil {
 		return err
 	}
 
-	domainName := dns01.UnFqdn(authZone)
+	// get the subDomain, and the zone to write to (see dns01.AddZoneRewriter)
+	authZone, subDomain, err := dns01.RewriteZone(fqdn, authZone, d.zoneRewriter)
+	if err != nil {
+		return fmt.Errorf("transip: %w", err)
+	}
 
-	// get the subDomain
-	subDomain := strings.TrimSuffix(dns01.UnFqdn(fqdn), "."+domainName)
+	domainName := dns01.UnFqdn(authZone)
 
 	// get all DNS entries
 	dnsEntries, err := d.repository.GetDNSEntries(domainName)
@@ -139,9 +161,10 @@ func (d *DNSProvider) CleanUp(domain, token, keyAuth string) error {
 		return fmt.Errorf("transip: error for %s in CleanUp: %w", fqdn, err)
 	}
 
-	// loop through the existing entries and remove the specific record
+	// loop through the existing entries and remove the specific record:
+	// the TXT records of a domain and of its wildcard share the same name, only the content differs.
 	for _, entry := range dnsEntries {
-		if entry.Name == subDomain && entry.Content == value {
+		if entry.Name == subDomain && entry.Type == "TXT" && entry.Content == value {
 			if err = d.repository.RemoveDNSEntry(domainName, entry); err != nil {
 				return fmt.Errorf("transip: couldn't get Record ID in CleanUp: %w", err)
 			}
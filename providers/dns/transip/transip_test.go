@@ -9,6 +9,7 @@ import (
 	"testing"
 	"time"
 
+	"github.com/go-acme/lego/v4/challenge/dns01"
 	"github.com/go-acme/lego/v4/platform/tester"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -171,8 +172,9 @@ func TestDNSProvider_concurrentGetDNSEntries(t *testing.T) {
 	repo := domain.Repository{Client: client}
 
 	p := &DNSProvider{
-		config:     NewDefaultConfig(),
-		repository: repo,
+		config:         NewDefaultConfig(),
+		repository:     repo,
+		findZoneByFqdn: fakeFindZoneByFqdn,
 	}
 
 	var wg sync.WaitGroup
@@ -227,8 +229,9 @@ func TestDNSProvider_concurrentAddDNSEntry(t *testing.T) {
 	repo := domain.Repository{Client: client}
 
 	p := &DNSProvider{
-		config:     NewDefaultConfig(),
-		repository: repo,
+		config:         NewDefaultConfig(),
+		repository:     repo,
+		findZoneByFqdn: fakeFindZoneByFqdn,
 	}
 
 	var wg sync.WaitGroup
@@ -262,6 +265,83 @@ func TestDNSProvider_concurrentAddDNSEntry(t *testing.T) {
 	assert.Empty(t, client.dnsEntries)
 }
 
+func TestDNSProvider_CleanUp_sharedName(t *testing.T) {
+	client := &fakeClient{
+		domainName: "lego.wtf",
+	}
+
+	p := &DNSProvider{
+		config:         NewDefaultConfig(),
+		repository:     domain.Repository{Client: client},
+		findZoneByFqdn: fakeFindZoneByFqdn,
+	}
+
+	// the challenges of lego.wtf and *.lego.wtf use the same record name.
+	err := p.Present("lego.wtf", "", "123d==")
+	require.NoError(t, err)
+
+	err = p.Present("lego.wtf", "", "456d==")
+	require.NoError(t, err)
+
+	require.Len(t, client.dnsEntries, 2)
+
+	err = p.CleanUp("lego.wtf", "", "123d==")
+	require.NoError(t, err)
+
+	_, value := dns01.GetRecord("lego.wtf", "456d==")
+
+	expected := []domain.DNSEntry{{
+		Name:    "_acme-challenge",
+		Expire:  int(p.config.TTL),
+		Type:    "TXT",
+		Content: value,
+	}}
+	assert.Equal(t, expected, client.dnsEntries)
+
+	err = p.CleanUp("lego.wtf", "", "456d==")
+	require.NoError(t, err)
+
+	assert.Empty(t, client.dnsEntries)
+}
+
+func TestDNSProvider_zoneRewriter(t *testing.T) {
+	// the records are written to the delegated zone.
+	client := &fakeClient{
+		domainName: "acme.example.net",
+	}
+
+	p := &DNSProvider{
+		config:         NewDefaultConfig(),
+		repository:     domain.Repository{Client: client},
+		findZoneByFqdn: fakeFindZoneByFqdn,
+	}
+
+	_ = dns01.NewChallenge(nil, nil, p,
+		dns01.AddZoneRewriter(dns01.ZoneRewriteTable(map[string]string{"lego.wtf": "acme.example.net"})))
+
+	err := p.Present("www.lego.wtf", "", "123d==")
+	require.NoError(t, err)
+
+	_, value := dns01.GetRecord("www.lego.wtf", "123d==")
+
+	expected := []domain.DNSEntry{{
+		Name:    "_acme-challenge.www",
+		Expire:  int(p.config.TTL),
+		Type:    "TXT",
+		Content: value,
+	}}
+	assert.Equal(t, expected, client.dnsEntries)
+
+	err = p.CleanUp("www.lego.wtf", "", "123d==")
+	require.NoError(t, err)
+
+	assert.Empty(t, client.dnsEntries)
+}
+
+func fakeFindZoneByFqdn(_ string) (string, error) {
+	return "lego.wtf.", nil
+}
+
 func TestLivePresent(t *testing.T) {
 	if !envTest.IsLiveTest() {
 		t.Skip("skipping live test")
@@ -0,0 +1,180 @@
+// Package zonefile implements a DNS provider which writes the challenge records as BIND zone file lines.
+package zonefile
+
+import (
+	"bufio"
+	"bytes"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"sync"
+	"time"
+
+	"github.com/go-acme/lego/v4/challenge/dns01"
+	"github.com/go-acme/lego/v4/platform/config/env"
+)
+
+// Environment variables names.
+const (
+	envNamespace = "ZONEFILE_"
+
+	EnvPath = envNamespace + "PATH"
+
+	EnvTTL                = envNamespace + "TTL"
+	EnvPropagationTimeout = envNamespace + "PROPAGATION_TIMEOUT"
+	EnvPollingInterval    = envNamespace + "POLLING_INTERVAL"
+)
+
+// Config is used to configure the creation of the DNSProvider.
+type Config struct {
+	Path               string
+	TTL                int
+	PropagationTimeout time.Duration
+	PollingInterval    time.Duration
+}
+
+// NewDefaultConfig returns a default configuration for the DNSProvider.
+func NewDefaultConfig() *Config {
+	return &Config{
+		TTL:                env.GetOrDefaultInt(EnvTTL, dns01.DefaultTTL),
+		PropagationTimeout: env.GetOrDefaultSecond(EnvPropagationTimeout, dns01.DefaultPropagationTimeout),
+		PollingInterval:    env.GetOrDefaultSecond(EnvPollingInterval, dns01.DefaultPollingInterval),
+	}
+}
+
+// DNSProvider implements the challenge.Provider interface.
+type DNSProvider struct {
+	config *Config
+
+	mu sync.Mutex
+}
+
+// NewDNSProvider returns a DNSProvider instance writing the challenge records
+// into the file defined by the environment variable ZONEFILE_PATH.
+func NewDNSProvider() (*DNSProvider, error) {
+	values, err := env.Get(EnvPath)
+	if err != nil {
+		return nil, fmt.Errorf("zonefile: %w", err)
+	}
+
+	config := NewDefaultConfig()
+	config.Path = values[EnvPath]
+
+	return NewDNSProviderConfig(config)
+}
+
+// NewDNSProviderConfig return a DNSProvider instance configured for zonefile.
+func NewDNSProviderConfig(config *Config) (*DNSProvider, error) {
+	if config == nil {
+		return nil, errors.New("zonefile: the configuration of the DNS provider is nil")
+	}
+
+	if config.Path == "" {
+		return nil, errors.New("zonefile: missing file path")
+	}
+
+	return &DNSProvider{config: config}, nil
+}
+
+// Present adds the TXT record line to the zone file.
+func (d *DNSProvider) Present(domain, token, keyAuth string) error {
+	fqdn, value := dns01.GetRecord(domain, keyAuth)
+
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	lines, err := d.readLines()
+	if err != nil {
+		return fmt.Errorf("zonefile: %w", err)
+	}
+
+	line := formatRecord(fqdn, d.config.TTL, value)
+
+	for _, l := range lines {
+		if l == line {
+			return nil
+		}
+	}
+
+	err = d.writeLines(append(lines, line))
+	if err != nil {
+		return fmt.Errorf("zonefile: %w", err)
+	}
+
+	return nil
+}
+
+// CleanUp removes the TXT record line from the zone file.
+func (d *DNSProvider) CleanUp(domain, token, keyAuth string) error {
+	fqdn, value := dns01.GetRecord(domain, keyAuth)
+
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	lines, err := d.readLines()
+	if err != nil {
+		return fmt.Errorf("zonefile: %w", err)
+	}
+
+	line := formatRecord(fqdn, d.config.TTL, value)
+
+	var kept []string
+	for _, l := range lines {
+		if l != line {
+			kept = append(kept, l)
+		}
+	}
+
+	if len(kept) == len(lines) {
+		return nil
+	}
+
+	err = d.writeLines(kept)
+	if err != nil {
+		return fmt.Errorf("zonefile: %w", err)
+	}
+
+	return nil
+}
+
+// Timeout returns the timeout and interval to use when checking for DNS propagation.
+// Adjusting here to cope with spikes in propagation times.
+func (d *DNSProvider) Timeout() (timeout, interval time.Duration) {
+	return d.config.PropagationTimeout, d.config.PollingInterval
+}
+
+// readLines reads the lines of the zone file, a missing file has no lines.
+func (d *DNSProvider) readLines() ([]string, error) {
+	raw, err := ioutil.ReadFile(d.config.Path)
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	var lines []string
+
+	scanner := bufio.NewScanner(bytes.NewReader(raw))
+	for scanner.Scan() {
+		lines = append(lines, scanner.Text())
+	}
+
+	return lines, scanner.Err()
+}
+
+func (d *DNSProvider) writeLines(lines []string) error {
+	buf := &bytes.Buffer{}
+	for _, line := range lines {
+		buf.WriteString(line)
+		buf.WriteString("\n")
+	}
+
+	return ioutil.WriteFile(d.config.Path, buf.Bytes(), 0o644)
+}
+
+// formatRecord formats a TXT record as a BIND zone file line.
+func formatRecord(fqdn string, ttl int, value string) string {
+	return fmt.Sprintf("%s %d IN TXT %q", fqdn, ttl, value)
+}
@@ -0,0 +1,154 @@
+package zonefile
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/go-acme/lego/v4/platform/tester"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var envTest = tester.NewEnvTest(EnvPath, EnvTTL)
+
+func TestNewDNSProvider(t *testing.T) {
+	testCases := []struct {
+		desc     string
+		envVars  map[string]string
+		expected string
+	}{
+		{
+			desc: "success",
+			envVars: map[string]string{
+				EnvPath: "/tmp/acme-challenge.zone",
+			},
+		},
+		{
+			desc: "missing path",
+			envVars: map[string]string{
+				EnvPath: "",
+			},
+			expected: "zonefile: some credentials information are missing: ZONEFILE_PATH",
+		},
+	}
+
+	for _, test := range testCases {
+		t.Run(test.desc, func(t *testing.T) {
+			defer envTest.RestoreEnv()
+			envTest.ClearEnv()
+
+			envTest.Apply(test.envVars)
+
+			p, err := NewDNSProvider()
+
+			if len(test.expected) == 0 {
+				require.NoError(t, err)
+				require.NotNil(t, p)
+				require.NotNil(t, p.config)
+			} else {
+				require.EqualError(t, err, test.expected)
+			}
+		})
+	}
+}
+
+func TestNewDNSProviderConfig(t *testing.T) {
+	testCases := []struct {
+		desc     string
+		path     string
+		expected string
+	}{
+		{
+			desc: "success",
+			path: "/tmp/acme-challenge.zone",
+		},
+		{
+			desc:     "missing path",
+			expected: "zonefile: missing file path",
+		},
+	}
+
+	for _, test := range testCases {
+		t.Run(test.desc, func(t *testing.T) {
+			config := NewDefaultConfig()
+			config.Path = test.path
+
+			p, err := NewDNSProviderConfig(config)
+
+			if len(test.expected) == 0 {
+				require.NoError(t, err)
+				require.NotNil(t, p)
+				require.NotNil(t, p.config)
+			} else {
+				require.EqualError(t, err, test.expected)
+			}
+		})
+	}
+}
+
+func TestDNSProvider_PresentAndCleanUp(t *testing.T) {
+	dir, err := ioutil.TempDir("", "lego_test")
+	require.NoError(t, err)
+	defer func() { _ = os.RemoveAll(dir) }()
+
+	config := NewDefaultConfig()
+	config.Path = filepath.Join(dir, "acme-challenge.zone")
+	config.TTL = 60
+
+	provider, err := NewDNSProviderConfig(config)
+	require.NoError(t, err)
+
+	err = provider.Present("example.com", "", "123d==")
+	require.NoError(t, err)
+
+	err = provider.Present("www.example.com", "", "456d==")
+	require.NoError(t, err)
+
+	// presenting twice the same challenge doesn't duplicate the line.
+	err = provider.Present("example.com", "", "123d==")
+	require.NoError(t, err)
+
+	expected := `_acme-challenge.example.com. 60 IN TXT "ADw2sEd82DUgXcQ9hNBZThJs7zVJkR5v9JeSbAb9mZY"
+_acme-challenge.www.example.com. 60 IN TXT "7SZcH8jldJ5zSS3kgbe2KZDOO-PHTMEqGU37zLnmPyk"
+`
+	assertFileContent(t, config.Path, expected)
+
+	err = provider.CleanUp("example.com", "", "123d==")
+	require.NoError(t, err)
+
+	assertFileContent(t, config.Path, `_acme-challenge.www.example.com. 60 IN TXT "7SZcH8jldJ5zSS3kgbe2KZDOO-PHTMEqGU37zLnmPyk"
+`)
+
+	err = provider.CleanUp("www.example.com", "", "456d==")
+	require.NoError(t, err)
+
+	assertFileContent(t, config.Path, "")
+}
+
+func TestDNSProvider_CleanUp_missingFile(t *testing.T) {
+	dir, err := ioutil.TempDir("", "lego_test")
+	require.NoError(t, err)
+	defer func() { _ = os.RemoveAll(dir) }()
+
+	config := NewDefaultConfig()
+	config.Path = filepath.Join(dir, "acme-challenge.zone")
+
+	provider, err := NewDNSProviderConfig(config)
+	require.NoError(t, err)
+
+	err = provider.CleanUp("example.com", "", "123d==")
+	require.NoError(t, err)
+
+	assert.NoFileExists(t, config.Path)
+}
+
+func assertFileContent(t *testing.T, path, expected string) {
+	t.Helper()
+
+	raw, err := ioutil.ReadFile(path)
+	require.NoError(t, err)
+
+	assert.Equal(t, expected, string(raw))
+}
@@ -1,8 +1,14 @@
 package registration
 
 import (
+	"context"
+	"crypto"
 	"errors"
+	"fmt"
+	"io"
+	"io/ioutil"
 	"net/http"
+	"strings"
 
 	"github.com/go-acme/lego/v4/acme"
 	"github.com/go-acme/lego/v4/acme/api"
@@ -25,6 +31,44 @@ type RegisterEABOptions struct {
 	TermsOfServiceAgreed bool
 	Kid                  string
 	HmacEncoded          string
+
+	// HmacFile the path of a file containing the MAC key (Base64 URL Encoding without padding).
+	// Used if HmacEncoded is empty.
+	HmacFile string
+
+	// HmacReader a reader of the MAC key (Base64 URL Encoding without padding).
+	// Used if HmacEncoded and HmacFile are empty.
+	HmacReader io.Reader
+
+	// Algorithm the MAC algorithm: HS256 (default), HS384, or HS512.
+	Algorithm string
+}
+
+// hmacEncoded returns the MAC key from the first defined source.
+func (o RegisterEABOptions) hmacEncoded() (string, error) {
+	switch {
+	case o.HmacEncoded != "":
+		return o.HmacEncoded, nil
+
+	case o.HmacFile != "":
+		data, err := ioutil.ReadFile(o.HmacFile)
+		if err != nil {
+			return "", fmt.Errorf("acme: could not read the hmac file: %w", err)
+		}
+
+		return strings.TrimSpace(string(data)), nil
+
+	case o.HmacReader != nil:
+		data, err := ioutil.ReadAll(o.HmacReader)
+		if err != nil {
+			return "", fmt.Errorf("acme: could not read the hmac key: %w", err)
+		}
+
+		return strings.TrimSpace(string(data)), nil
+
+	default:
+		return "", errors.New("acme: the hmac key is missing")
+	}
 }
 
 type Registrar struct {
@@ -39,6 +83,14 @@ func NewRegistrar(core *api.Core, user User) *Registrar {
 	}
 }
 
+// WithContext returns a copy of the Registrar whose requests to the ACME server are abandoned when the context is done.
+func (r *Registrar) WithContext(ctx context.Context) *Registrar {
+	return &Registrar{
+		core: r.core.WithContext(ctx),
+		user: r.user,
+	}
+}
+
 // Register the current account to the ACME server.
 func (r *Registrar) Register(options RegisterOptions) (*Resource, error) {
 	if r == nil || r.user == nil {
@@ -79,7 +131,12 @@ func (r *Registrar) RegisterWithExternalAccountBinding(options RegisterEABOption
 		accMsg.Contact = []string{"mailto:" + r.user.GetEmail()}
 	}
 
-	account, err := r.core.Accounts.NewEAB(accMsg, options.Kid, options.HmacEncoded)
+	hmacEncoded, err := options.hmacEncoded()
+	if err != nil {
+		return nil, err
+	}
+
+	account, err := r.core.Accounts.NewEABWithAlgorithm(accMsg, options.Kid, hmacEncoded, options.Algorithm)
 	if err != nil {
 		errorDetails, ok := err.(acme.ProblemDetails)
 		// FIXME seems impossible
@@ -142,12 +199,19 @@ func (r *Registrar) UpdateRegistration(options RegisterOptions) (*Resource, erro
 }
 
 // DeleteRegistration deletes the client's user registration from the ACME server.
+// It's an alias of Deactivate.
 func (r *Registrar) DeleteRegistration() error {
-	if r == nil || r.user == nil {
-		return errors.New("acme: cannot unregister a nil client or user")
+	return r.Deactivate()
+}
+
+// Deactivate deactivates the account of the user on the ACME server.
+// A deactivated account cannot be used anymore, its pending authorizations are also deactivated by the CA.
+func (r *Registrar) Deactivate() error {
+	if r == nil || r.user == nil || r.user.GetRegistration() == nil {
+		return errors.New("acme: cannot deactivate a nil client, user, or registration")
 	}
 
-	log.Infof("acme: Deleting account for %s", r.user.GetEmail())
+	log.Infof("acme: Deactivating account for %s", r.user.GetEmail())
 
 	return r.core.Accounts.Deactivate(r.user.GetRegistration().URI)
 }
@@ -165,3 +229,28 @@ func (r *Registrar) ResolveAccountByKey() (*Resource, error) {
 
 	return &Resource{URI: account.Location, Body: account.Account}, nil
 }
+
+// ChangeKey changes the key of the account (account key rollover),
+// and returns the account bound to the new key.
+// The next requests are signed with the new key,
+// and the key of the user is updated if it implements PrivateKeySetter.
+func (r *Registrar) ChangeKey(newKey crypto.PrivateKey) (*Resource, error) {
+	if r == nil || r.user == nil || r.user.GetRegistration() == nil {
+		return nil, errors.New("acme: cannot change the key of a nil client, user, or registration")
+	}
+
+	accountURL := r.user.GetRegistration().URI
+
+	log.Infof("acme: Changing the key of the account %s", accountURL)
+
+	account, err := r.core.Accounts.ChangeKey(newKey)
+	if err != nil {
+		return nil, err
+	}
+
+	if setter, ok := r.user.(PrivateKeySetter); ok {
+		setter.SetPrivateKey(newKey)
+	}
+
+	return &Resource{URI: accountURL, Body: account}, nil
+}
@@ -1,9 +1,14 @@
 package registration
 
 import (
+	"crypto"
 	"crypto/rand"
 	"crypto/rsa"
+	"encoding/base64"
+	"encoding/json"
+	"io/ioutil"
 	"net/http"
+	"strings"
 	"testing"
 
 	"github.com/go-acme/lego/v4/acme"
@@ -11,6 +16,7 @@ import (
 	"github.com/go-acme/lego/v4/platform/tester"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+	jose "gopkg.in/square/go-jose.v2"
 )
 
 func TestRegistrar_ResolveAccountByKey(t *testing.T) {
@@ -47,3 +53,271 @@ func TestRegistrar_ResolveAccountByKey(t *testing.T) {
 
 	assert.Equal(t, "valid", res.Body.Status, "Unexpected account status")
 }
+
+func TestRegistrar_ChangeKey(t *testing.T) {
+	mux, apiURL, tearDown := tester.SetupFakeAPI()
+	defer tearDown()
+
+	oldKey, err := rsa.GenerateKey(rand.Reader, 512)
+	require.NoError(t, err, "Could not generate test key")
+
+	newKey, err := rsa.GenerateKey(rand.Reader, 512)
+	require.NoError(t, err, "Could not generate test key")
+
+	mux.HandleFunc("/keyChange", func(w http.ResponseWriter, r *http.Request) {
+		outer, err := readSignedBody(r, &oldKey.PublicKey)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+
+		inner, err := jose.ParseSigned(string(outer))
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+
+		content, err := inner.Verify(&newKey.PublicKey)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+
+		keyChange := struct {
+			Account string          `json:"account"`
+			OldKey  jose.JSONWebKey `json:"oldKey"`
+		}{}
+		err = json.Unmarshal(content, &keyChange)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+
+		if keyChange.Account != apiURL+"/account/1" {
+			http.Error(w, "unexpected account: "+keyChange.Account, http.StatusBadRequest)
+			return
+		}
+
+		err = tester.WriteJSONResponse(w, acme.Account{Status: acme.StatusValid})
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+		}
+	})
+
+	mux.HandleFunc("/account/1", func(w http.ResponseWriter, r *http.Request) {
+		_, err := readSignedBody(r, &newKey.PublicKey)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+
+		err = tester.WriteJSONResponse(w, acme.Account{Status: acme.StatusValid})
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+		}
+	})
+
+	user := &rolloverUser{
+		mockUser: mockUser{email: "test@test.com", regres: &Resource{URI: apiURL + "/account/1"}},
+		key:      oldKey,
+	}
+
+	core, err := api.New(http.DefaultClient, "lego-test", apiURL+"/dir", apiURL+"/account/1", oldKey)
+	require.NoError(t, err)
+
+	registrar := NewRegistrar(core, user)
+
+	res, err := registrar.ChangeKey(newKey)
+	require.NoError(t, err)
+
+	assert.Equal(t, apiURL+"/account/1", res.URI)
+	assert.Equal(t, acme.StatusValid, res.Body.Status)
+	assert.Equal(t, newKey, user.GetPrivateKey())
+
+	// the next requests are signed with the new key.
+	_, err = registrar.QueryRegistration()
+	require.NoError(t, err)
+}
+
+func TestRegistrar_Deactivate(t *testing.T) {
+	mux, apiURL, tearDown := tester.SetupFakeAPI()
+	defer tearDown()
+
+	key, err := rsa.GenerateKey(rand.Reader, 512)
+	require.NoError(t, err, "Could not generate test key")
+
+	var status string
+	mux.HandleFunc("/account/1", func(w http.ResponseWriter, r *http.Request) {
+		content, err := readSignedBody(r, &key.PublicKey)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+
+		account := acme.Account{}
+		err = json.Unmarshal(content, &account)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+
+		status = account.Status
+
+		err = tester.WriteJSONResponse(w, account)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+		}
+	})
+
+	user := mockUser{
+		email:      "test@test.com",
+		regres:     &Resource{URI: apiURL + "/account/1"},
+		privatekey: key,
+	}
+
+	core, err := api.New(http.DefaultClient, "lego-test", apiURL+"/dir", apiURL+"/account/1", key)
+	require.NoError(t, err)
+
+	registrar := NewRegistrar(core, user)
+
+	err = registrar.Deactivate()
+	require.NoError(t, err)
+
+	assert.Equal(t, acme.StatusDeactivated, status)
+}
+
+func TestRegistrar_RegisterWithExternalAccountBinding(t *testing.T) {
+	mux, apiURL, tearDown := tester.SetupFakeAPI()
+	defer tearDown()
+
+	key, err := rsa.GenerateKey(rand.Reader, 512)
+	require.NoError(t, err, "Could not generate test key")
+
+	hmac := []byte("a-secret-hmac-key-from-the-external-ca")
+
+	mux.HandleFunc("/account", func(w http.ResponseWriter, r *http.Request) {
+		content, err := readSignedBody(r, &key.PublicKey)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+
+		account := acme.Account{}
+		err = json.Unmarshal(content, &account)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+
+		eab, err := jose.ParseSigned(string(account.ExternalAccountBinding))
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+
+		if alg := eab.Signatures[0].Header.Algorithm; alg != "HS384" {
+			http.Error(w, "unexpected algorithm: "+alg, http.StatusBadRequest)
+			return
+		}
+
+		_, err = eab.Verify(hmac)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+
+		w.Header().Set("Location", apiURL+"/account/1")
+		err = tester.WriteJSONResponse(w, acme.Account{Status: acme.StatusValid})
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+		}
+	})
+
+	user := mockUser{
+		email:      "test@test.com",
+		regres:     &Resource{},
+		privatekey: key,
+	}
+
+	core, err := api.New(http.DefaultClient, "lego-test", apiURL+"/dir", "", key)
+	require.NoError(t, err)
+
+	registrar := NewRegistrar(core, user)
+
+	res, err := registrar.RegisterWithExternalAccountBinding(RegisterEABOptions{
+		TermsOfServiceAgreed: true,
+		Kid:                  "kid-1",
+		HmacReader:           strings.NewReader(base64.RawURLEncoding.EncodeToString(hmac) + "\n"),
+		Algorithm:            "HS384",
+	})
+	require.NoError(t, err)
+
+	assert.Equal(t, apiURL+"/account/1", res.URI)
+}
+
+func TestRegisterEABOptions_hmacEncoded(t *testing.T) {
+	file, err := ioutil.TempFile(t.TempDir(), "hmac")
+	require.NoError(t, err)
+
+	_, err = file.WriteString("from-file\n")
+	require.NoError(t, err)
+	require.NoError(t, file.Close())
+
+	testCases := []struct {
+		desc     string
+		options  RegisterEABOptions
+		expected string
+	}{
+		{
+			desc:     "encoded",
+			options:  RegisterEABOptions{HmacEncoded: "encoded", HmacFile: file.Name()},
+			expected: "encoded",
+		},
+		{
+			desc:     "file",
+			options:  RegisterEABOptions{HmacFile: file.Name(), HmacReader: strings.NewReader("from-reader")},
+			expected: "from-file",
+		},
+		{
+			desc:     "reader",
+			options:  RegisterEABOptions{HmacReader: strings.NewReader("from-reader")},
+			expected: "from-reader",
+		},
+	}
+
+	for _, test := range testCases {
+		test := test
+		t.Run(test.desc, func(t *testing.T) {
+			hmacEncoded, err := test.options.hmacEncoded()
+			require.NoError(t, err)
+
+			assert.Equal(t, test.expected, hmacEncoded)
+		})
+	}
+
+	_, err = RegisterEABOptions{}.hmacEncoded()
+	require.EqualError(t, err, "acme: the hmac key is missing")
+}
+
+type rolloverUser struct {
+	mockUser
+	key crypto.PrivateKey
+}
+
+func (u *rolloverUser) GetPrivateKey() crypto.PrivateKey { return u.key }
+
+func (u *rolloverUser) SetPrivateKey(key crypto.PrivateKey) { u.key = key }
+
+func readSignedBody(r *http.Request, publicKey *rsa.PublicKey) ([]byte, error) {
+	reqBody, err := ioutil.ReadAll(r.Body)
+	if err != nil {
+		return nil, err
+	}
+
+	jws, err := jose.ParseSigned(string(reqBody))
+	if err != nil {
+		return nil, err
+	}
+
+	return jws.Verify(publicKey)
+}
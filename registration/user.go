@@ -11,3 +11,9 @@ type User interface {
 	GetRegistration() *Resource
 	GetPrivateKey() crypto.PrivateKey
 }
+
+// PrivateKeySetter is an optional interface of the User to update its private key
+// after an account key rollover (see Registrar.ChangeKey).
+type PrivateKeySetter interface {
+	SetPrivateKey(privateKey crypto.PrivateKey)
+}